@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+
+	"github.com/hammamikhairi/ottocook/internal/keychain"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// credentialKeys are the env vars that can be kept in the keychain.
+var credentialKeys = []string{
+	speech.EnvAzureSpeechKey,
+	speech.EnvAzureSpeechRegion,
+	envGPTKey,
+	envGPTEndpoint,
+}
+
+// runAuth implements `ottocook auth <list|set|delete> ...`.
+// Returns the process exit code.
+func runAuth(args []string) int {
+	fs := flag.NewFlagSet("auth", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: ottocook auth list\n")
+		fmt.Fprintf(fs.Output(), "       ottocook auth set KEY\n")
+		fmt.Fprintf(fs.Output(), "       ottocook auth delete KEY\n\n")
+		fmt.Fprintf(fs.Output(), "KEY is one of: %s\n", strings.Join(credentialKeys, ", "))
+		fmt.Fprintf(fs.Output(), "set reads the value from the terminal without echoing it, or from stdin.\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	switch fs.Arg(0) {
+	case "list", "":
+		for _, k := range credentialKeys {
+			where := "not set"
+			if _, err := keychain.Get(k); err == nil {
+				where = "keychain"
+			} else if !errors.Is(err, keychain.ErrNotFound) {
+				where = "keychain unavailable: " + err.Error()
+			}
+			if os.Getenv(k) != "" {
+				where = "environment (overrides the keychain)"
+			}
+			fmt.Printf("  %-20s %s\n", k, where)
+		}
+		return 0
+
+	case "set", "delete":
+		key := strings.ToUpper(fs.Arg(1))
+		if !slices.Contains(credentialKeys, key) {
+			fs.Usage()
+			return 2
+		}
+		if fs.Arg(0) == "delete" {
+			if err := keychain.Delete(key); err != nil {
+				fmt.Fprintf(os.Stderr, "auth: %v\n", err)
+				return 1
+			}
+			fmt.Printf("Removed %s from the keychain.\n", key)
+			return 0
+		}
+		value, err := readSecret(key)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "auth: %v\n", err)
+			return 1
+		}
+		if value == "" {
+			fmt.Fprintln(os.Stderr, "auth: empty value, nothing stored")
+			return 1
+		}
+		if err := keychain.Set(key, value); err != nil {
+			fmt.Fprintf(os.Stderr, "auth: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Stored %s in the keychain.\n", key)
+		return 0
+
+	default:
+		fs.Usage()
+		return 2
+	}
+}
+
+// readSecret prompts for a value without echo when stdin is a terminal,
+// and otherwise reads one line, so `pass show x | ottocook auth set KEY`
+// works too.
+func readSecret(key string) (string, error) {
+	if term.IsTerminal(os.Stdin.Fd()) {
+		fmt.Fprintf(os.Stderr, "%s: ", key)
+		b, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Fprintln(os.Stderr)
+		return strings.TrimSpace(string(b)), err
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
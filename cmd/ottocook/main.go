@@ -3,9 +3,13 @@
 // Usage:
 //
 //	ottocook [-verbose] [-quiet]
+//	ottocook models [list | pull NAME...]
+//	ottocook setup [PATH]
+//	ottocook auth [list | set KEY | delete KEY]
 package main
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"flag"
@@ -14,34 +18,62 @@ import (
 	stdlog "log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/joho/godotenv"
 
+	"github.com/hammamikhairi/ottocook/internal/app"
+	"github.com/hammamikhairi/ottocook/internal/awake"
 	"github.com/hammamikhairi/ottocook/internal/conversation"
 	"github.com/hammamikhairi/ottocook/internal/display"
 	"github.com/hammamikhairi/ottocook/internal/domain"
 	"github.com/hammamikhairi/ottocook/internal/engine"
 	"github.com/hammamikhairi/ottocook/internal/gpt"
+	"github.com/hammamikhairi/ottocook/internal/keychain"
 	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/metrics"
+	"github.com/hammamikhairi/ottocook/internal/plugin"
 	"github.com/hammamikhairi/ottocook/internal/recipe"
 	"github.com/hammamikhairi/ottocook/internal/speech"
 	"github.com/hammamikhairi/ottocook/internal/storage"
 	"github.com/hammamikhairi/ottocook/internal/timer"
+	"github.com/hammamikhairi/ottocook/internal/voiceid"
 	"github.com/hammamikhairi/ottocook/internal/wakeword"
 )
 
 func main() {
 	_ = godotenv.Load()
 
+	if len(os.Args) > 1 && os.Args[1] == "models" {
+		os.Exit(runModels(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "setup" {
+		os.Exit(runSetup(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "auth" {
+		os.Exit(runAuth(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "misheard" {
+		os.Exit(runMisheard(os.Args[2:]))
+	}
+
 	verbose := flag.Bool("verbose", false, "enable verbose/debug logging")
 	quiet := flag.Bool("quiet", false, "disable all logging")
 	logFile := flag.String("log-file", ".otto-logs/otto.log", "file to write logs to (use \"stderr\" to log to console)")
 	noSpeech := flag.Bool("no-speech", false, "disable text-to-speech even if Azure keys are set")
 	diskCache := flag.Bool("disk-cache", true, "persist TTS audio cache to disk (reads from disk even when false)")
 	cacheDir := flag.String("cache-dir", ".otto-cache", "directory for persistent TTS audio cache")
+	ttsFormat := flag.String("tts-format", speech.FormatWAV, "audio format requested from Azure and cached: wav, mp3, or ogg (compressed formats need ffmpeg to play)")
+	ttsRate := flag.Int("tts-rate", 20, "max TTS requests per minute (0 = unlimited); low-priority speech is skipped rather than queued")
+	ttsTimeout := flag.Duration("tts-timeout", 20*time.Second, "skip a line whose speech takes longer than this to synthesize (0 = only the HTTP timeout)")
+	ttsPrefetch := flag.Int("tts-prefetch", 2, "how many prefetches (the next step, fillers, ...) synthesize at once")
+	ttsDailyChars := flag.Int("tts-daily-chars", 16000, "daily TTS character budget (0 = unlimited); chatter and prefetches stop first, timer alerts never do")
 	noAI := flag.Bool("no-ai", false, "disable the AI agent even if GPT keys are set")
+	aiContext := flag.Int("ai-context", gpt.DefaultContextBudget, "about how many tokens of recipe and session context go with each AI call; long recipes are trimmed to fit (0 sends everything)")
+	promptsDir := flag.String("prompts-dir", gpt.DefaultPromptsDir(), "directory of prompt overrides (question.tmpl, modify.tmpl, ...)")
 	voice := flag.Bool("voice", false, "enable voice input via local Whisper STT")
 	whisperBin := flag.String("whisper-bin", "whisper-cli", "path to the whisper-cpp CLI binary")
 	whisperModel := flag.String("whisper-model", "bin/ggml-small.bin", "path to the Whisper GGML model file")
@@ -50,6 +82,47 @@ func main() {
 	wwEmbed := flag.String("ww-embed", "bin/embedding_model.onnx", "path to the embedding ONNX model")
 	wwLib := flag.String("ww-lib", "bin/libonnxruntime.dylib", "path to the ONNX Runtime shared library")
 	wwThreshold := flag.Float64("ww-threshold", 0.7, "wakeword detection threshold [0.0-1.0]")
+	wwAccel := flag.String("ww-accel", "cpu", "ONNX execution provider for the wakeword models: "+strings.Join(wakeword.Accelerators, ", "))
+	sttLanguage := flag.String("stt-language", "en", "spoken language for voice input (whisper code such as en, fr, de, or auto)")
+	sttMinConfidence := flag.Float64("stt-min-confidence", 0.6, "ask before acting on risky voice commands heard below this confidence [0.0-1.0]")
+	aiLog := flag.String("ai-log", "", "log AI answers and your \"good answer\" / \"that's wrong\" ratings of them to this file (e.g. .otto-ai.jsonl)")
+	aiPerMinute := flag.Int("ai-per-minute", 20, "at most this many questions, changes, and unrecognised commands go to the AI in any minute (0 for no limit)")
+	aiTimeout := flag.Duration("ai-timeout", app.DefaultAITimeout, "give up on an AI question, change, or classification after this long (Esc gives up sooner)")
+	aiSafety := flag.Bool("ai-safety", false, "have the AI review each recipe change for food-safety problems too, on top of the built-in rules (one more call per change)")
+	pluginDir := flag.String("plugins", "", "start every executable in this directory as a plugin adding its own commands (see internal/plugin)")
+	calendarFile := flag.String("calendar", defaultCalendar, "file \"add it to my calendar\" writes the session's upcoming milestones to, as iCalendar (.ics)")
+	timelineFile := flag.String("timeline", "", "draw each finished session to this file as a timeline of its steps, timers, pauses and nudges, as HTML (e.g. "+app.DefaultTimelinePath+")")
+	keepAudio := flag.Bool("keep-audio", false, "keep each voice command's audio and transcription in "+speech.DefaultAudioDir+", for debugging bad recognitions")
+	keepAudioMB := flag.Int("keep-audio-mb", 100, "with -keep-audio, delete the oldest clips once they take up more than this many megabytes (0 for no cap)")
+	keepAudioDays := flag.Int("keep-audio-days", 7, "with -keep-audio, delete clips older than this many days (0 keeps them)")
+	misheardLog := flag.String("misheard-log", "", "log voice commands corrected with \"that's not what I said\" to this file, for `ottocook misheard` (e.g. "+defaultMisheardLog+")")
+	whisperArgs := flag.String("whisper-args", "", "extra flags passed to whisper-cli, e.g. \"-fa -t 8\"")
+	wwVerifyModel := flag.String("ww-verify-model", "", "optional second-stage ONNX model that must confirm each wakeword hit")
+	wwVerifyThreshold := flag.Float64("ww-verify-threshold", 0, "verification threshold [0.0-1.0] (default: -ww-threshold)")
+	speakerModel := flag.String("speaker-model", "", "ONNX speaker embedding model (raw 16 kHz audio in, one embedding out); tells voices apart so each person's diet follows them")
+	speakerThreshold := flag.Float64("speaker-threshold", voiceid.DefaultThreshold, "how similar [0.0-1.0] a voice must be to an enrolled one to count as them")
+	profilesFile := flag.String("profiles", ".otto-profiles.json", "household profiles: enrolled voices and dietary needs, with -speaker-model")
+	wwExtra := flag.String("ww-extra", "", "comma-separated LANG=MODEL wake words for other languages, e.g. fr=models/salut_chef.onnx; commands after one are transcribed in its language")
+	wwAdapt := flag.Bool("ww-adapt", true, "raise the wakeword threshold after repeated empty wakes, lower it after \"you didn't hear me\"")
+	alwaysListen := flag.Bool("always-listen", false, "transcribe everything, not only what follows the wakeword, and act on overheard speech that is plainly a command (for private kitchens)")
+	alwaysListenConfidence := flag.Float64("always-listen-confidence", 0.8, "with -always-listen, how sure [0.0-1.0] whisper must be of overheard speech before Otto acts on it")
+	wwIdleLevel := flag.Float64("ww-idle-level", wakeword.DefaultIdleLevel, "with no session, stop wakeword scoring while the mic level stays below this RMS [0.0-1.0]; 0 always scores")
+	metricsAddr := flag.String("metrics-addr", "", "serve Prometheus metrics on this loopback address (e.g. 127.0.0.1:9464); empty disables")
+	typewriter := flag.Int("typewriter", display.DefaultTypewriterSpeed, "chat text reveal speed in characters per second (0 prints instantly; any key finishes a line)")
+	sessionDir := flag.String("session-dir", ".otto-sessions", "directory where sessions are kept: long hands-off waits, so Otto can be closed until they end, and a journal of every session, so a crash loses at most a few seconds")
+	historyFile := flag.String("history-file", ".otto-history", "file typed commands are saved to for up/down and Ctrl+R recall (empty keeps them for this run only)")
+	title := flag.String("title", display.TitleCount, "what the window title shows while timers run: "+strings.Join(display.TitleModes, ", "))
+	keepAwake := flag.Bool("keep-awake", true, "keep the machine from sleeping while a session is under way, so timers aren't lost (caffeinate on macOS, systemd-inhibit on Linux)")
+	bell := flag.Bool("bell", true, "ring the terminal bell and ask for the window's attention when a timer fires or needs you")
+	mouse := flag.Bool("mouse", true, "click recipes, timers, and the next-step preview (hold Shift to select text)")
+	cookalongHost := flag.String("cookalong-host", "", "host a cook-along on this address (e.g. :7331) so a partner can cook in sync")
+	cookalongJoin := flag.String("cookalong-join", "", "join a partner's cook-along at host:port")
+	cookalongName := flag.String("cookalong-name", defaultCookName(), "your name as shown to a cook-along partner")
+	escalationFile := flag.String("escalation", timer.DefaultEscalationFile(), "JSON file of timer alert ladders: how often, how loudly, and what each reminder says, per timer label")
+	aliasesFile := flag.String("aliases", conversation.DefaultAliasesFile(), "JSON file of your own phrases for commands, e.g. {\"chop chop\": \"next\"}")
+	notifyCommand := flag.String("notify-command", "", "command run with the message when a timer's last alert is marked external, e.g. \"notify-send Otto\"")
+	demo := flag.Bool("demo", false, "demo mode: timers run fast, the AI answers from a script, and nothing is saved")
+	demoSpeed := flag.Float64("demo-speed", 20, "how many times faster timers run in -demo")
 	flag.Parse()
 
 	// Configure logger.
@@ -85,120 +158,462 @@ func main() {
 
 	log := logger.New(logLevel, logOut)
 
+	// The audio device can only be opened once, and first-run setup
+	// may need it before the mouth does.
+	newPlayer := sync.OnceValues(func() (*speech.Player, error) { return speech.NewPlayer(log) })
+
+	// First run: ask instead of quietly running without speech or AI.
+	// Keys in the keychain, including any setup just stored there, fill
+	// in whatever the environment and ./.env left unset; the config file
+	// comes last.
+	cfgPath := configPath()
+	if needsSetup(cfgPath) {
+		if err := setupWizard(os.Stdin, os.Stdout, cfgPath, newPlayer); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: setup: %v\n", err)
+		}
+	}
+	if loaded := keychain.Load(credentialKeys...); len(loaded) > 0 {
+		log.Info("credentials from keychain: %s", strings.Join(loaded, ", "))
+	}
+	if cfgPath != "" {
+		// Doesn't override anything already set by the environment or ./.env.
+		_ = godotenv.Load(cfgPath)
+	}
+
 	// Set up context — cancelled when the UI quits.
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Local metrics endpoint (opt-in). A nil registry turns every
+	// instrumentation call into a no-op.
+	var reg *metrics.Registry
+	if *metricsAddr != "" {
+		reg = metrics.NewRegistry()
+		if err := metrics.Serve(ctx, *metricsAddr, reg, log); err != nil {
+			log.Error("metrics endpoint disabled: %v", err)
+			reg = nil
+		}
+	}
+
 	// Wire dependencies.
 	recipes := recipe.NewMemorySource(log)
-	store := storage.NewMemoryStore(log)
+	var store domain.SessionStore = storage.NewMemoryStore(log)
+	var waiting, recovered []*domain.Session
+	if *demo {
+		// A demo starts clean, leaves nothing behind, and says the
+		// same fillers every run.
+		*historyFile, *diskCache = "", false
+		speech.SeedLines(1)
+		log.Info("demo mode: timers at %gx, scripted AI, nothing saved", *demoSpeed)
+	} else {
+		var err error
+		journal := storage.NewJournal(store, filepath.Join(*sessionDir, "journal"), log)
+		recovered, err = journal.Recover(ctx)
+		if err != nil {
+			log.Error("recovering sessions: %v", err)
+		}
+		go journal.Run(ctx)
+		defer journal.Close()
+		hibernator := storage.NewHibernator(journal, *sessionDir, log)
+		waiting, err = hibernator.Restore(ctx)
+		if err != nil {
+			log.Error("restoring waiting sessions: %v", err)
+		}
+		store = hibernator
+	}
 	ui := display.NewUI(store)
+	if *mouse {
+		ui.EnableMouse()
+	}
+	ui.SetTypewriterSpeed(*typewriter)
+	ui.SetBell(*bell)
+	ui.SetTitleMode(*title)
+	ui.SetHistoryFile(*historyFile)
 	textNotifier := conversation.NewCLINotifier(log, ui.Printf)
-	parser := conversation.NewKeywordParser(log)
+	keywords := conversation.NewKeywordParser(log)
+	if aliases, err := conversation.LoadAliases(*aliasesFile); err != nil {
+		log.Error("aliases: %v", err)
+	} else if err := keywords.SetAliases(aliases); err != nil {
+		log.Error("aliases: %v", err)
+	}
+	var parser domain.IntentParser = keywords
+	var plugins *plugin.Host
+	if *pluginDir != "" && !*demo {
+		var err error
+		plugins, err = plugin.Load(ctx, *pluginDir)
+		if err != nil {
+			log.Error("loading plugins: %v", err)
+		}
+		defer plugins.Close()
+		for _, p := range plugins.Plugins() {
+			log.Info("plugin %s loaded (%d commands)", p.Name, len(p.Intents))
+		}
+		parser = plugin.NewParser(parser, plugins)
+	}
 	eng := engine.New(recipes, store, log)
 
 	// Build the active notifier. If TTS is available, wrap the text notifier
 	// with a SpeakingNotifier that also speaks through the Mouth.
 	var activeNotifier domain.Notifier = textNotifier
+	var timerNotifier, watcherNotifier domain.Notifier = textNotifier, textNotifier
 	var mouth *speech.Mouth
+	var player *speech.Player
 
 	azureKey := os.Getenv(speech.EnvAzureSpeechKey)
 	azureRegion := os.Getenv(speech.EnvAzureSpeechRegion)
 
 	if azureKey != "" && azureRegion != "" && !*noSpeech {
-		ttsClient := speech.NewAzureClient(azureKey, azureRegion, log)
+		format, err := speech.AzureAudioFormat(*ttsFormat)
+		if err == nil && !speech.CanDecode(*ttsFormat) {
+			err = fmt.Errorf("playing %s needs ffmpeg on the PATH", *ttsFormat)
+		}
+		if err != nil {
+			log.Error("%v; using wav", err)
+			format = speech.DefaultAudioFormat
+		}
+		ttsClient := speech.NewAzureClient(azureKey, azureRegion, log,
+			speech.WithVoice(cmp.Or(os.Getenv(speech.EnvVoice), speech.DefaultVoice)),
+			speech.WithAudioFormat(format),
+			speech.WithQuota(speech.NewQuota(*ttsRate, *ttsDailyChars, filepath.Join(*cacheDir, "quota.json"), log)),
+		)
 
-		player, err := speech.NewPlayer(log)
+		p, err := newPlayer()
 		if err != nil {
 			log.Error("audio player init failed, speech disabled: %v", err)
 		} else {
+			player = p
 			mouth = speech.NewMouth(ttsClient, player, log,
 				speech.WithCacheDir(*cacheDir),
 				speech.WithDiskWrite(*diskCache),
+				speech.WithMetrics(reg),
+				speech.WithSynthTimeout(*ttsTimeout),
+				speech.WithPrefetchWorkers(*ttsPrefetch),
 			)
-			mouth.Start(ctx)
 			mouth.Prefetch(ctx, speech.ThinkingFillers()...)
 			mouth.Prefetch(ctx, speech.ListeningFillers()...)
 			activeNotifier = speech.NewSpeakingNotifier(textNotifier, mouth, log)
-			log.Info("TTS enabled (voice=%s, region=%s)", speech.DefaultVoice, azureRegion)
+			timerNotifier = speech.NewSpeakingNotifier(textNotifier, mouth, log, speech.OnChannel(speech.ChannelTimers))
+			watcherNotifier = speech.NewSpeakingNotifier(textNotifier, mouth, log, speech.OnChannel(speech.ChannelWatcher))
+			log.Info("TTS enabled (voice=%s, region=%s, quota: %s)", ttsClient.Voice(), azureRegion, ttsClient.Quota())
 		}
 	} else if !*noSpeech {
 		log.Info("TTS disabled: set %s and %s env vars to enable", speech.EnvAzureSpeechKey, speech.EnvAzureSpeechRegion)
 	}
 
-	supervisor := timer.New(store, activeNotifier, log,
-		timer.WithWatcher(recipes),
-	)
+	// Urgent timer and watcher alerts also ring the bell, so a terminal in
+	// the background still gets noticed.
+	timerNotifier = conversation.NewAttentionNotifier(timerNotifier, ui.Attention)
+	watcherNotifier = conversation.NewAttentionNotifier(watcherNotifier, ui.Attention)
+
+	escalation, err := timer.LoadEscalation(*escalationFile)
+	if err != nil {
+		log.Error("timer escalation: %v", err)
+	}
+	supervisorOpts := []timer.Option{
+		timer.WithWatcher(recipes, timer.WithWatcherNotifier(watcherNotifier)),
+		timer.WithEscalation(escalation),
+		timer.WithSessionLock(eng.SessionLock()),
+	}
+	if *notifyCommand != "" {
+		supervisorOpts = append(supervisorOpts, timer.WithExternalNotifier(conversation.NewCommandNotifier(*notifyCommand, log)))
+	}
+	if *demo {
+		supervisorOpts = append(supervisorOpts, timer.WithSpeed(*demoSpeed))
+	}
+	supervisor := timer.New(store, timerNotifier, log, supervisorOpts...)
 
 	// Build AI agent if GPT credentials are available.
 	var agent *gpt.Agent
 
-	gptKey := os.Getenv("GPT_CHAT_KEY")
-	gptEndpoint := os.Getenv("GPT_CHAT_ENDPOINT")
+	gptKey := os.Getenv(envGPTKey)
+	gptEndpoint := os.Getenv(envGPTEndpoint)
 
-	if gptKey != "" && gptEndpoint != "" && !*noAI {
-		gptClient := gpt.NewClient(gptEndpoint, gptKey, log)
-		agent = gpt.NewAgent(gptClient, log)
+	if *demo && !*noAI {
+		agent = gpt.NewAgent(gpt.NewDemoClient(log), log, gpt.WithRetriever(recipe.NewIndex(recipes)))
+		log.Info("AI agent enabled (scripted demo replies)")
+	} else if gptKey != "" && gptEndpoint != "" && !*noAI {
+		gptClient := gpt.NewClient(gptEndpoint, gptKey, log, gpt.WithMetrics(reg))
+		prompts, overridden, err := gpt.LoadPrompts(*promptsDir)
+		if err != nil {
+			log.Error("prompt overrides: %v", err)
+		}
+		if len(overridden) > 0 {
+			log.Info("using prompt overrides from %s: %s", *promptsDir, strings.Join(overridden, ", "))
+		}
+		agent = gpt.NewAgent(gptClient, log,
+			gpt.WithPrompts(prompts),
+			gpt.WithRetriever(recipe.NewIndex(recipes)),
+			gpt.WithUnits(os.Getenv(gpt.EnvUnits)),
+			gpt.WithContextBudget(*aiContext),
+		)
 		log.Info("AI agent enabled")
 	} else if !*noAI {
 		log.Info("AI agent disabled: set GPT_CHAT_KEY and GPT_CHAT_ENDPOINT env vars to enable")
 	}
 
+	// Plugin commands are the model's to pick too, like the built-in ones.
+	if agent != nil && plugins != nil {
+		for _, p := range plugins.Plugins() {
+			for _, in := range p.Intents {
+				tool := gpt.Tool{Name: in.Name, Intent: domain.IntentPlugin, Description: in.Description}
+				if err := agent.RegisterTool(tool); err != nil {
+					log.Error("plugin %s: %v", p.Name, err)
+				}
+			}
+		}
+	}
+
 	// Build voice input (STT) if enabled.
 	var ear *speech.Ear
+	var detector *wakeword.Detector
+	var wakewords []wakeword.Wakeword // from -ww-extra
+	if *voice && !speech.AudioSupported {
+		fmt.Fprintln(os.Stderr, "warning: this build has no audio (-tags noaudio), so -voice is off")
+		log.Error("voice input disabled: %v", speech.ErrNoAudio)
+		*voice = false
+	}
 	if *voice {
-		if _, err := os.Stat(*whisperModel); err != nil {
-			fmt.Fprintf(os.Stderr, "error: whisper model not found at %s\n", *whisperModel)
-			os.Exit(1)
-		}
-		// Validate wakeword model files.
-		for _, p := range []string{*wwModel, *wwMelspec, *wwEmbed, *wwLib} {
-			if _, err := os.Stat(p); err != nil {
-				fmt.Fprintf(os.Stderr, "error: wakeword file not found: %s\n", p)
-				os.Exit(1)
+		// Locate model files, falling back to bin/, models/, and the
+		// download cache.  Missing files disable voice input rather than
+		// aborting — typing still works.
+		var missing []string
+		resolve := func(label string, path *string) {
+			found, msg := resolveModel(label, *path)
+			if msg != "" {
+				missing = append(missing, msg)
+				return
+			}
+			*path = found
+		}
+		resolve("whisper model", whisperModel)
+		resolve("wakeword model", wwModel)
+		resolve("melspectrogram model", wwMelspec)
+		resolve("embedding model", wwEmbed)
+		resolve("ONNX Runtime library", wwLib)
+		if *wwVerifyModel != "" {
+			resolve("wakeword verify model", wwVerifyModel)
+		}
+		for _, entry := range strings.Split(*wwExtra, ",") {
+			if entry = strings.TrimSpace(entry); entry == "" {
+				continue
 			}
+			lang, model, ok := strings.Cut(entry, "=")
+			if !ok || lang == "" || model == "" {
+				missing = append(missing, fmt.Sprintf("-ww-extra entry %q isn't LANG=MODEL", entry))
+				continue
+			}
+			w := wakeword.Wakeword{Language: strings.ToLower(strings.TrimSpace(lang)), Model: strings.TrimSpace(model)}
+			resolve("wakeword model ("+w.Language+")", &w.Model)
+			wakewords = append(wakewords, w)
+		}
+		if len(missing) > 0 {
+			for _, msg := range missing {
+				fmt.Fprintf(os.Stderr, "warning: %s\n", msg)
+				log.Error("voice input: %s", msg)
+			}
+			fmt.Fprintln(os.Stderr, "warning: voice input disabled")
+			*voice = false
 		}
-
+	}
+	if *voice {
 		os.MkdirAll(".otto-stt", 0o755)
 
 		// Create the ONNX-based wakeword detector.
-		detector := wakeword.New(wakeword.Config{
-			WakewordModel:  *wwModel,
-			MelspecModel:   *wwMelspec,
-			EmbeddingModel: *wwEmbed,
-			OnnxLib:        *wwLib,
-			Threshold:      *wwThreshold,
+		detector = wakeword.New(wakeword.Config{
+			WakewordModel:   *wwModel,
+			Wakewords:       wakewords,
+			MelspecModel:    *wwMelspec,
+			EmbeddingModel:  *wwEmbed,
+			OnnxLib:         *wwLib,
+			Threshold:       *wwThreshold,
+			VerifyModel:     *wwVerifyModel,
+			VerifyThreshold: *wwVerifyThreshold,
+			Accelerator:     *wwAccel,
+			IdleLevel:       *wwIdleLevel,
+			Metrics:         reg,
 		}, log)
 		go func() {
-			if err := detector.Start(ctx); err != nil {
-				log.Error("wakeword detector failed: %v", err)
+			select {
+			case <-detector.Ready():
+				log.Info("wakeword detector ready (model=%s, threshold=%.2f)", *wwModel, *wwThreshold)
+			case <-ctx.Done():
 			}
 		}()
-		log.Info("wakeword detector started (model=%s, threshold=%.2f)", *wwModel, *wwThreshold)
 
-		ear = speech.NewEar(*whisperBin, *whisperModel, detector, mouth, log)
-		go ear.Run(ctx)
+		earOpts := []speech.EarOption{
+			speech.WithEarMetrics(reg),
+			speech.WithWhisperArgs(strings.Fields(*whisperArgs)...),
+			speech.WithLanguage(*sttLanguage),
+		}
+		if *wwAdapt {
+			earOpts = append(earOpts, speech.WithThresholdTuning())
+		}
+		if *alwaysListen {
+			earOpts = append(earOpts, speech.WithAlwaysListening())
+		}
+		if *speakerModel != "" {
+			voices := voiceid.New(*speakerModel)
+			defer voices.Close()
+			earOpts = append(earOpts, speech.WithVoiceprints(voices))
+		}
+		if *keepAudio {
+			archive := speech.NewAudioArchive(speech.DefaultAudioDir, int64(*keepAudioMB)<<20, time.Duration(*keepAudioDays)*24*time.Hour)
+			earOpts = append(earOpts, speech.WithAudioArchive(archive))
+			log.Info("keeping voice command audio in %s (cap %d MB, %d days)", speech.DefaultAudioDir, *keepAudioMB, *keepAudioDays)
+		}
+		var speaker speech.Speaker
+		if mouth != nil {
+			speaker = mouth // a nil *speech.Mouth would make a non-nil interface
+		}
+		ear = speech.NewEar(*whisperBin, *whisperModel, detector, speaker, log, earOpts...)
 		log.Info("voice input enabled (bin=%s, model=%s)", *whisperBin, *whisperModel)
 	}
 
+	// Speech and voice input start and stop as one: on exit, and at
+	// "voice off" and "voice on".
+	var audio *speech.Audio
+	if mouth != nil || ear != nil {
+		audio = speech.NewAudio(mouth, player, detector, ear, log)
+		audio.Start(ctx)
+		defer audio.Close()
+	}
+
 	// Start background timer supervisor.
 	supervisor.Start(ctx)
 	defer supervisor.Stop()
 
-	// Build the CLI app.
-	app := &cliApp{
-		engine:   eng,
-		parser:   parser,
-		notifier: activeNotifier,
-		mouth:    mouth,
-		agent:    agent,
-		ear:      ear,
-		log:      log,
-		ui:       ui,
+	// Settings that can change mid-cook follow config.env as it's saved.
+	live := newLiveConfig(cfgPath, *escalationFile, supervisor.SetEscalation, log)
+
+	// Build the session controller.
+	cfg := app.Config{
+		Engine:   eng,
+		Parser:   parser,
+		Plugins:  plugins,
+		Notifier: activeNotifier,
+		Agent:    agent,
+		Log:      log,
+		Chat:     ui,
+		Status:   ui,
+		Input:    ui,
+
+		MinConfidence: *sttMinConfidence,
+		OverheardMin:  *alwaysListenConfidence,
+		AIPerMinute:   *aiPerMinute,
+		AITimeout:     *aiTimeout,
+		Metrics:       reg,
+		SafetyReview:  *aiSafety,
+		CalendarPath:  *calendarFile,
+		TimelinePath:  *timelineFile,
+		Settings:      live,
+	}
+	if mouth != nil {
+		cfg.Mouth = mouth // a nil *speech.Mouth would make a non-nil interface
+	}
+	if *aiLog != "" && !*demo {
+		cfg.AnswerLog = gpt.NewAnswerLog(*aiLog)
+	}
+	if *misheardLog != "" && !*demo {
+		cfg.MisheardLog = speech.NewMisheardLog(*misheardLog)
+	}
+	if *keepAwake {
+		cfg.KeepAwake = awake.New("a cook is under way; timers are running", log)
 	}
+	if audio != nil {
+		cfg.Audio = audio // a nil *speech.Audio would make a non-nil interface
+	}
+	if ear != nil {
+		cfg.Ear = ear // a nil *speech.Ear would make a non-nil interface
+		profiles, err := loadProfiles(*profilesFile, *speakerModel, *speakerThreshold)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			log.Error("speaker identification disabled: %v", err)
+		}
+		cfg.Profiles = profiles
+	}
+	controller := app.New(cfg)
+	controller.Restore(waiting, recovered)
 
-	// Wire space-on-empty-input to interrupt TTS and cancel listening.
+	if mouth != nil {
+		live.add(speech.EnvVoice, "voice", "", func(v string) error {
+			if !mouth.SetVoice(v) {
+				return errors.New("the speech service can't change voice")
+			}
+			return nil
+		})
+	}
+	if agent != nil {
+		live.add(gpt.EnvUnits, "units", "", func(v string) error {
+			if err := oneOf(v, []string{gpt.UnitsMetric, gpt.UnitsUS}); err != nil {
+				return err
+			}
+			agent.SetUnits(v)
+			return nil
+		})
+	}
+	if ear != nil {
+		live.add(envWWThreshold, "wake word threshold", "ww-threshold", func(v string) error {
+			f, err := fraction(v)
+			if err == nil {
+				ear.SetThreshold(f) // through the ear, so -ww-adapt tunes from it
+			}
+			return err
+		})
+	}
+	live.add(envMinConfidence, "voice confidence", "stt-min-confidence", func(v string) error {
+		f, err := fraction(v)
+		if err == nil {
+			controller.SetMinConfidence(f)
+		}
+		return err
+	})
+	live.add(envReminderEvery, "timer reminders", "", func(v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return fmt.Errorf("%q isn't a duration such as 3m", v)
+		}
+		supervisor.SetReminderInterval(d)
+		return nil
+	})
+	live.add(envTypewriter, "typewriter speed", "typewriter", func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%q isn't a number of characters per second", v)
+		}
+		ui.SetTypewriterSpeed(n)
+		return nil
+	})
+	live.add(envBell, "bell", "bell", func(v string) error {
+		on, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("%q isn't true or false", v)
+		}
+		ui.SetBell(on)
+		return nil
+	})
+	live.add(envTitle, "window title", "title", func(v string) error {
+		if err := oneOf(v, display.TitleModes); err != nil {
+			return err
+		}
+		ui.SetTitleMode(v)
+		return nil
+	})
+	given := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { given[f.Name] = true })
+	live.start(given)
+	go live.watch(ctx, controller.Reload)
+
+	if *cookalongHost != "" || *cookalongJoin != "" {
+		if err := controller.StartCookAlong(ctx, *cookalongHost, *cookalongJoin, *cookalongName); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			log.Error("cook-along disabled: %v", err)
+		}
+	}
+
+	// Wire space-on-empty-input to interrupt TTS, cancel listening, and
+	// call off an AI request in flight.
 	ui.OnInterrupt(func() {
+		controller.CancelAI()
 		if mouth != nil {
 			mouth.Interrupt()
 		}
@@ -206,26 +621,24 @@ func main() {
 			ear.CancelListening()
 		}
 	})
+	ui.OnMicToggle(controller.ToggleMic)
+	ui.OnCancel(func() { controller.CancelAI() })
 
-	// Mute the ear while the mouth is speaking so the wakeword detector
-	// and Whisper transcriber don't pick up the speaker output.
-	// Also update the inspector box for the mouth state.
+	// Show the mouth's state in the inspector box.  The ear subscribes
+	// itself, to sleep while the mouth speaks.
 	if mouth != nil {
 		ui.SetMouthState(display.MouthIdle)
 
 		mouth.OnSpeakingChange(func(speaking bool) {
 			if speaking {
 				ui.SetMouthState(display.MouthSpeaking)
-				if ear != nil {
-					ear.Mute()
-				}
 			} else {
 				ui.SetMouthState(display.MouthIdle)
-				if ear != nil {
-					ear.Unmute()
-				}
 			}
 		})
+		mouth.OnJobsChange(func(j speech.MouthJobs) {
+			ui.SetSpeechJobs(display.SpeechJobs(j))
+		})
 	}
 
 	// Wire voice-listening state to the ear badge only.
@@ -241,6 +654,10 @@ func main() {
 				ui.SetEarState(display.EarActive)
 			case speech.EarMuted:
 				ui.SetEarState(display.EarSleeping)
+			case speech.EarMicOff:
+				ui.SetEarState(display.EarMicOff)
+			case speech.EarClosed:
+				ui.SetEarState(display.EarOff)
 			default: // EarDormant
 				ui.SetEarState(display.EarReady)
 			}
@@ -260,7 +677,7 @@ func main() {
 		}
 		ui.Println("")
 
-		app.run(ctx)
+		controller.Run(ctx)
 		ui.Quit()
 	}()
 
@@ -271,966 +688,26 @@ func main() {
 	cancel()
 }
 
-type cliApp struct {
-	engine         *engine.Engine
-	parser         domain.IntentParser
-	notifier       domain.Notifier
-	mouth          *speech.Mouth // nil when TTS is disabled
-	agent          *gpt.Agent    // nil when AI is disabled
-	ear            *speech.Ear   // nil when voice input is disabled
-	log            *logger.Logger
-	ui             *display.UI
-	sessionID      string // current active session
-	selectedRecipe string // recipe chosen before typing 'start'
-}
-
-// say prints a message to stdout and queues it for speech at the given priority.
-// Use for conversational lines the user should hear. For raw formatting (menus,
-// ingredient lists, tables) use fmt directly — those shouldn't be spoken.
-func (a *cliApp) say(text string, priority speech.Priority) {
-	a.ui.PrintChat(text)
-	if a.mouth != nil {
-		a.mouth.Say(text, priority)
-	}
-}
-
-// sayUrgent prints a message in bold red and queues it at high priority.
-func (a *cliApp) sayUrgent(text string) {
-	a.ui.PrintUrgent(text)
-	if a.mouth != nil {
-		a.mouth.Say(text, speech.PriorityHigh)
-	}
-}
-
-// prefetchStep pre-warms the TTS cache for the step at the given 0-based
-// index within the current recipe. Non-blocking. Does nothing if TTS is
-// disabled or the index is out of range.
-func (a *cliApp) prefetchStep(ctx context.Context, recipeID string, stepIdx int) {
-	if a.mouth == nil || recipeID == "" {
-		return
-	}
-	r, err := a.engine.GetRecipe(ctx, recipeID)
-	if err != nil || stepIdx < 0 || stepIdx >= len(r.Steps) {
-		return
-	}
-	step := r.Steps[stepIdx]
-	total := len(r.Steps)
-
-	var conditions []string
-	for _, c := range step.Conditions {
-		conditions = append(conditions, c.Description)
-	}
-	tLabel := ""
-	var tDur time.Duration
-	if step.TimerConfig != nil {
-		tLabel = step.TimerConfig.Label
-		tDur = step.TimerConfig.Duration
-	}
-	text := speech.LineStep(step.Order, total, step.Instruction, conditions, step.ParallelHints, tLabel, tDur)
-	a.mouth.Prefetch(ctx, text)
-}
-
-func (a *cliApp) run(ctx context.Context) {
-	a.say(speech.LineWelcome(), speech.PriorityNormal)
-	a.ui.Println("")
-	a.showRecipes(ctx)
-
-	// Voice channel (nil-safe: receiving on a nil channel blocks forever,
-	// which is fine — select will only use the keyboard case).
-	var voiceCh <-chan string
-	if a.ear != nil {
-		voiceCh = a.ear.C()
-	}
-
-	uiCh := a.ui.InputChan()
-
-	for {
-		var input string
-		var ok bool
-
-		select {
-		case <-ctx.Done():
-			return
-		case input, ok = <-uiCh:
-			if !ok {
-				return
-			}
-		case input = <-voiceCh:
-			// Print what was heard so the user sees it in the REPL.
-			a.ui.PrintVoice(input)
-		}
-
-		input = strings.TrimSpace(input)
-		if input == "" {
-			continue
-		}
-
-		var session *domain.Session
-		if a.sessionID != "" {
-			s, err := a.engine.Status(ctx, a.sessionID)
-			if err == nil {
-				session = s
-			}
-		}
-
-		intent, err := a.parser.Parse(ctx, input, session)
-		if err != nil {
-			a.log.Error("parsing input: %v", err)
-			continue
-		}
-
-		a.log.Debug("intent: %s (payload=%q)", intent.Type, intent.Payload)
-		a.handleIntent(ctx, intent)
-	}
-}
-
-func (a *cliApp) handleIntent(ctx context.Context, intent *domain.Intent) {
-	// Action intents interrupt whatever is currently being spoken so the
-	// assistant doesn't keep talking over the new response.
-	switch intent.Type {
-	case domain.IntentListRecipes, domain.IntentSelectRecipe,
-		domain.IntentStartCooking, domain.IntentAdvance, domain.IntentSkip,
-		domain.IntentRepeat, domain.IntentRepeatLast, domain.IntentPause, domain.IntentResume,
-		domain.IntentStatus, domain.IntentQuit, domain.IntentDismissTimer,
-		domain.IntentAskQuestion, domain.IntentModify:
-		if a.mouth != nil {
-			a.mouth.Interrupt()
-		}
-	}
-
-	switch intent.Type {
-	case domain.IntentHelp:
-		a.showHelp()
-	case domain.IntentListRecipes:
-		a.showRecipes(ctx)
-	case domain.IntentSelectRecipe:
-		a.selectRecipe(ctx, intent.Payload)
-	case domain.IntentStartCooking:
-		a.startCooking(ctx)
-	case domain.IntentAdvance:
-		a.advance(ctx)
-	case domain.IntentSkip:
-		a.skip(ctx)
-	case domain.IntentRepeat:
-		a.repeat(ctx)
-	case domain.IntentRepeatLast:
-		a.repeatLast(ctx)
-	case domain.IntentPause:
-		a.pause(ctx)
-	case domain.IntentResume:
-		a.resume(ctx)
-	case domain.IntentStatus:
-		a.status(ctx)
-	case domain.IntentQuit:
-		a.quit(ctx)
-	case domain.IntentDismissTimer:
-		a.dismissTimer(ctx, intent.Payload)
-	case domain.IntentStartTimer:
-		a.startTimer(ctx)
-	case domain.IntentAskQuestion:
-		a.askQuestion(ctx, intent.Payload)
-	case domain.IntentModify:
-		a.modifyRequest(ctx, intent.Payload)
-	case domain.IntentUnknown:
-		a.classifyAndDispatch(ctx, intent)
-	}
-}
-
-// classifyAndDispatch sends unrecognised input to the AI for intent
-// classification, then re-dispatches the result. Falls back to the
-// generic "didn't catch that" line when the agent is unavailable or
-// still returns unknown.
-func (a *cliApp) classifyAndDispatch(ctx context.Context, original *domain.Intent) {
-	if a.agent == nil {
-		a.say(speech.LineUnknown(original.Payload), speech.PriorityLow)
-		return
-	}
-
-	filler := speech.LineThinkingClassify()
-	a.ui.PrintHint(filler)
-	if a.mouth != nil {
-		a.mouth.Say(filler, speech.PriorityCritical)
-	}
-
-	a.ui.SetActivity("Classifying...")
-	recipe, session := a.gatherContext(ctx)
-	classified, err := a.agent.Classify(ctx, original.Payload, recipe, session)
-	a.ui.ClearActivity()
-	if err != nil {
-		a.log.Error("AI classify failed: %v", err)
-		a.say(speech.LineUnknown(original.Payload), speech.PriorityLow)
-		return
-	}
-
-	if classified.Type == domain.IntentUnknown {
-		a.say(speech.LineUnknown(original.Payload), speech.PriorityLow)
-		return
-	}
-
-	a.log.Info("classified %q -> %s", original.Payload, classified.Type)
-	a.handleIntent(ctx, classified)
-}
-
-// ── AI agent handlers ────────────────────────────────────────────
-
-func (a *cliApp) askQuestion(ctx context.Context, question string) {
-	if a.agent == nil {
-		a.say(speech.LineAIDisabled(), speech.PriorityLow)
-		return
-	}
-
-	filler := speech.LineThinkingQuestion()
-	a.ui.PrintHint(filler)
-	if a.mouth != nil {
-		a.mouth.Say(filler, speech.PriorityCritical)
-	}
-
-	a.ui.SetActivity("Thinking...")
-	recipe, session := a.gatherContext(ctx)
-
-	answer, err := a.agent.AskQuestion(ctx, question, recipe, session)
-	a.ui.ClearActivity()
-	if err != nil {
-		a.log.Error("AI question failed: %v", err)
-		a.say(speech.LineAIError(), speech.PriorityNormal)
-		return
-	}
-
-	a.say(answer, speech.PriorityHigh)
-}
-
-// TODO(urgent): modification in the ingredients can affect the steps to cook the dish
-func (a *cliApp) modifyRequest(ctx context.Context, request string) {
-	if a.agent == nil {
-		a.say(speech.LineAIDisabled(), speech.PriorityLow)
-		return
-	}
-
-	filler := speech.LineThinkingModify()
-	a.ui.PrintHint(filler)
-	if a.mouth != nil {
-		a.mouth.Say(filler, speech.PriorityCritical)
-	}
-
-	a.ui.SetActivity("Modifying...")
-	recipe, session := a.gatherContext(ctx)
-
-	if recipe == nil {
-		a.ui.ClearActivity()
-		a.say(speech.LinePickRecipeFirst(), speech.PriorityNormal)
-		return
-	}
-
-	// Snapshot ingredients + steps BEFORE mutation for diffing.
-	oldIngs := snapshotIngredients(recipe)
-	oldSteps := snapshotSteps(recipe)
-	oldServings := recipe.Servings
-
-	resp, err := a.agent.Modify(ctx, request, recipe, session)
-	a.ui.ClearActivity()
-	if err != nil {
-		a.log.Error("AI modify failed: %v", err)
-		a.say(speech.LineAIError(), speech.PriorityNormal)
-		return
-	}
-
-	// If the AI returned actions, apply them to the recipe.
-	if len(resp.Actions) > 0 {
-		if err := gpt.ApplyActions(recipe, resp.Actions); err != nil {
-			a.log.Error("applying modifications failed: %v", err)
-			a.ui.PrintUrgent(fmt.Sprintf("Error applying changes: %v", err))
-			a.say(speech.LineAIError(), speech.PriorityNormal)
-			return
-		}
-
-		// Persist the mutated recipe.
-		if err := a.engine.UpdateRecipe(ctx, recipe); err != nil {
-			a.log.Error("persisting recipe update failed: %v", err)
-		}
-
-		// Display recipe diff.
-		a.showRecipeDiff(recipe, oldIngs, oldSteps, oldServings)
-	}
-
-	// Speak the summary.
-	a.say(resp.Summary, speech.PriorityHigh)
-}
-
-// ── Recipe diff helpers ──────────────────────────────────────────
-
-type ingredientSnap struct {
-	Name           string
-	Quantity       float64
-	Unit           string
-	SizeDescriptor string
-	Optional       bool
-}
-
-func fmtIngredient(ing domain.Ingredient) string {
-	opt := ""
-	if ing.Optional {
-		opt = " (optional)"
-	}
-	if ing.Quantity > 0 {
-		if ing.SizeDescriptor != "" {
-			return fmt.Sprintf("%.0f %s %s%s", ing.Quantity, ing.SizeDescriptor, ing.Name, opt)
-		}
-		return fmt.Sprintf("%.0f %s %s%s", ing.Quantity, ing.Unit, ing.Name, opt)
-	}
-	return fmt.Sprintf("%s %s%s", ing.SizeDescriptor, ing.Name, opt)
-}
-
-func fmtIngSnap(s ingredientSnap) string {
-	opt := ""
-	if s.Optional {
-		opt = " (optional)"
-	}
-	if s.Quantity > 0 {
-		if s.SizeDescriptor != "" {
-			return fmt.Sprintf("%.0f %s %s%s", s.Quantity, s.SizeDescriptor, s.Name, opt)
-		}
-		return fmt.Sprintf("%.0f %s %s%s", s.Quantity, s.Unit, s.Name, opt)
-	}
-	return fmt.Sprintf("%s %s%s", s.SizeDescriptor, s.Name, opt)
-}
-
-func snapshotIngredients(r *domain.Recipe) []ingredientSnap {
-	out := make([]ingredientSnap, len(r.Ingredients))
-	for i, ing := range r.Ingredients {
-		out[i] = ingredientSnap{
-			Name:           ing.Name,
-			Quantity:       ing.Quantity,
-			Unit:           ing.Unit,
-			SizeDescriptor: ing.SizeDescriptor,
-			Optional:       ing.Optional,
-		}
-	}
-	return out
-}
-
-func snapshotSteps(r *domain.Recipe) []string {
-	out := make([]string, len(r.Steps))
-	for i, s := range r.Steps {
-		out[i] = s.Instruction
-	}
-	return out
-}
-
-func (a *cliApp) showRecipeDiff(r *domain.Recipe, oldIngs []ingredientSnap, oldSteps []string, oldServings int) {
-	a.ui.PrintStep(fmt.Sprintf("=== %s (updated) ===", r.Name))
+// defaultCalendar is where -calendar points unless told otherwise.
+const defaultCalendar = "ottocook.ics"
 
-	// ── Servings ──
-	if r.Servings != oldServings {
-		a.ui.PrintDiffChanged(fmt.Sprintf("Servings: %d -> %d", oldServings, r.Servings))
+// defaultCookName is the name a cook-along partner sees when
+// -cookalong-name isn't given.
+func defaultCookName() string {
+	if u := os.Getenv("USER"); u != "" {
+		return u
 	}
-
-	a.ui.Println("")
-	a.ui.PrintStep("Ingredients:")
-
-	// Build a map of old ingredients by lowercase name for lookup.
-	oldMap := make(map[string]ingredientSnap, len(oldIngs))
-	for _, s := range oldIngs {
-		oldMap[strings.ToLower(s.Name)] = s
-	}
-
-	// Track which old ingredients were matched (to find removals).
-	matched := make(map[string]bool)
-
-	for _, ing := range r.Ingredients {
-		key := strings.ToLower(ing.Name)
-		old, existed := oldMap[key]
-		line := fmtIngredient(ing)
-		if !existed {
-			// New ingredient.
-			a.ui.PrintDiffAdded(line)
-		} else {
-			matched[key] = true
-			oldLine := fmtIngSnap(old)
-			if line != oldLine {
-				a.ui.PrintDiffRemoved(oldLine)
-				a.ui.PrintDiffAdded(line)
-			} else {
-				a.ui.PrintDiffUnchanged(line)
-			}
-		}
-	}
-
-	// Show removed ingredients.
-	for _, s := range oldIngs {
-		if !matched[strings.ToLower(s.Name)] {
-			a.ui.PrintDiffRemoved(fmtIngSnap(s))
-		}
-	}
-
-	// ── Steps ──
-	if len(oldSteps) > 0 || len(r.Steps) > 0 {
-		a.ui.Println("")
-		a.ui.PrintStep("Steps:")
-		maxLen := len(oldSteps)
-		if len(r.Steps) > maxLen {
-			maxLen = len(r.Steps)
-		}
-		for i := 0; i < maxLen; i++ {
-			var oldInst, newInst string
-			if i < len(oldSteps) {
-				oldInst = oldSteps[i]
-			}
-			if i < len(r.Steps) {
-				newInst = r.Steps[i].Instruction
-			}
-
-			label := fmt.Sprintf("%d. ", i+1)
-			if newInst == "" && oldInst != "" {
-				// Step removed.
-				a.ui.PrintDiffRemoved(label + oldInst)
-			} else if oldInst == "" && newInst != "" {
-				// Step added.
-				a.ui.PrintDiffAdded(label + newInst)
-			} else if oldInst != newInst {
-				// Step changed.
-				a.ui.PrintDiffRemoved(label + oldInst)
-				a.ui.PrintDiffAdded(label + newInst)
-			} else {
-				a.ui.PrintDiffUnchanged(label + newInst)
-			}
-		}
-	}
-}
-
-// gatherContext loads the current recipe and session for AI context.
-func (a *cliApp) gatherContext(ctx context.Context) (*domain.Recipe, *domain.Session) {
-	var recipe *domain.Recipe
-	var session *domain.Session
-
-	recipeID := a.selectedRecipe
-	if a.sessionID != "" {
-		if s, err := a.engine.Status(ctx, a.sessionID); err == nil {
-			session = s
-			recipeID = s.RecipeID
-		}
-	}
-	if recipeID != "" {
-		if r, err := a.engine.GetRecipe(ctx, recipeID); err == nil {
-			recipe = r
-		}
-	}
-	return recipe, session
-}
-
-func (a *cliApp) showRecipes(ctx context.Context) {
-	recipes, err := a.engine.ListRecipes(ctx)
-	if err != nil {
-		a.ui.PrintUrgent(fmt.Sprintf("Error loading recipes: %v", err))
-		return
-	}
-
-	a.ui.PrintStep("Available recipes:")
-	a.ui.Println("")
-	for i, r := range recipes {
-		a.ui.PrintInstruction(fmt.Sprintf("[%d] %s", i+1, r.Name))
-		a.ui.PrintHint(r.Description)
-		if len(r.Tags) > 0 {
-			a.ui.PrintHint("Tags: " + strings.Join(r.Tags, ", "))
-		}
-		a.ui.Println("")
-	}
-	a.ui.PrintChat("Pick a recipe by number, or type 'help' for commands.")
-}
-
-func (a *cliApp) selectRecipe(ctx context.Context, payload string) {
-	recipes, err := a.engine.ListRecipes(ctx)
-	if err != nil {
-		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
-		return
-	}
-
-	// Try numeric selection.
-	var idx int
-	if _, err := fmt.Sscanf(payload, "%d", &idx); err == nil {
-		idx-- // 1-indexed to 0-indexed
-		if idx >= 0 && idx < len(recipes) {
-			a.selectedRecipe = recipes[idx].ID
-			r, err := a.engine.GetRecipe(ctx, a.selectedRecipe)
-			if err != nil {
-				a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
-				return
-			}
-			a.showRecipeDetail(r)
-
-			// Build ingredient list for speech.
-			ingNames := make([]string, len(r.Ingredients))
-			for i, ing := range r.Ingredients {
-				if ing.Quantity > 0 {
-					if ing.SizeDescriptor != "" {
-						ingNames[i] = fmt.Sprintf("%.0f %s %s", ing.Quantity, ing.SizeDescriptor, ing.Name)
-					} else {
-						ingNames[i] = fmt.Sprintf("%.0f %s %s", ing.Quantity, ing.Unit, ing.Name)
-					}
-				} else {
-					ingNames[i] = ing.Name
-				}
-			}
-			a.say(speech.LineRecipeSelected(r.Name, ingNames), speech.PriorityNormal)
-
-			// Prefetch audio for the likely next action: starting to cook.
-			if a.mouth != nil {
-				a.mouth.Prefetch(ctx, speech.LineCookingStart(r.Name))
-				a.prefetchStep(ctx, r.ID, 0) // step 1
-			}
-			return
-		}
-	}
-
-	a.say(speech.LineInvalidSelection(payload), speech.PriorityLow)
-}
-
-func (a *cliApp) showRecipeDetail(r *domain.Recipe) {
-	a.ui.PrintStep(fmt.Sprintf("=== %s ===", r.Name))
-	a.ui.PrintInstruction(r.Description)
-	a.ui.PrintHint(fmt.Sprintf("Servings: %d", r.Servings))
-
-	a.ui.Println("")
-	a.ui.PrintStep("Ingredients:")
-	for _, ing := range r.Ingredients {
-		opt := ""
-		if ing.Optional {
-			opt = " (optional)"
-		}
-		var line string
-		if ing.Quantity > 0 {
-			if ing.SizeDescriptor != "" {
-				line = fmt.Sprintf("  - %.0f %s %s%s", ing.Quantity, ing.SizeDescriptor, ing.Name, opt)
-			} else {
-				line = fmt.Sprintf("  - %.0f %s %s%s", ing.Quantity, ing.Unit, ing.Name, opt)
-			}
-		} else {
-			line = fmt.Sprintf("  - %s %s%s", ing.SizeDescriptor, ing.Name, opt)
-		}
-		a.ui.PrintInstruction(line)
-	}
-	a.ui.PrintHint(fmt.Sprintf("Steps: %d", len(r.Steps)))
-}
-
-func (a *cliApp) startCooking(ctx context.Context) {
-	if a.selectedRecipe == "" {
-		a.say(speech.LinePickRecipeFirst(), speech.PriorityNormal)
-		return
-	}
-
-	if a.sessionID != "" {
-		a.say(speech.LineAlreadyActive(), speech.PriorityNormal)
-		return
-	}
-
-	session, err := a.engine.StartSession(ctx, a.selectedRecipe, 0)
-	if err != nil {
-		a.ui.PrintUrgent(fmt.Sprintf("Error starting session: %v", err))
-		return
-	}
-
-	a.sessionID = session.ID
-	a.say(speech.LineCookingStart(session.RecipeName), speech.PriorityNormal)
-	a.showCurrentStep(ctx)
-
-	// Prefetch step 2 while the user works on step 1.
-	a.prefetchStep(ctx, a.selectedRecipe, 1)
-}
-
-func (a *cliApp) showCurrentStep(ctx context.Context) {
-	if a.sessionID == "" {
-		a.say(speech.LineNoSession(), speech.PriorityLow)
-		return
-	}
-
-	step, state, err := a.engine.CurrentStep(ctx, a.sessionID)
-	if err != nil {
-		if errors.Is(err, domain.ErrNoMoreSteps) {
-			a.say(speech.LineSessionDone(), speech.PriorityNormal)
-			a.sessionID = ""
-			a.selectedRecipe = ""
-			return
-		}
-		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
-		return
-	}
-
-	session, _ := a.engine.Status(ctx, a.sessionID)
-	total := len(session.StepStates)
-
-	// Print visual step header.
-	header := fmt.Sprintf("Step %d/%d", step.Order, total)
-	if step.Duration > 0 {
-		header += fmt.Sprintf(" (~%s)", formatDuration(step.Duration))
-	}
-	a.ui.PrintStep(header)
-	a.ui.PrintInstruction(step.Instruction)
-
-	if len(step.Conditions) > 0 {
-		for _, c := range step.Conditions {
-			a.ui.PrintHint("→ " + c.Description)
-		}
-	}
-
-	if len(step.ParallelHints) > 0 {
-		for _, hint := range step.ParallelHints {
-			a.ui.PrintHint("tip: " + hint)
-		}
-	}
-
-	if step.TimerConfig != nil {
-		// Check whether timer is pending (not yet started by user).
-		pending, _ := a.engine.HasPendingTimers(ctx, a.sessionID)
-		if pending {
-			a.ui.PrintHint(fmt.Sprintf("Timer ready: %s / %s — starts automatically on 'next'", step.TimerConfig.Label, formatDuration(step.TimerConfig.Duration)))
-		} else {
-			a.ui.PrintHint(fmt.Sprintf("Timer: %s / %s", step.TimerConfig.Label, formatDuration(step.TimerConfig.Duration)))
-		}
-	}
-
-	// Speak the step.
-	if a.mouth != nil {
-		var conditions []string
-		for _, c := range step.Conditions {
-			conditions = append(conditions, c.Description)
-		}
-		tLabel := ""
-		var tDur time.Duration
-		if step.TimerConfig != nil {
-			tLabel = step.TimerConfig.Label
-			tDur = step.TimerConfig.Duration
-		}
-		a.mouth.Say(speech.LineStep(step.Order, total, step.Instruction, conditions, step.ParallelHints, tLabel, tDur), speech.PriorityNormal)
-
-		// Prefetch the next step while this one plays.
-		a.prefetchStep(ctx, session.RecipeID, session.CurrentStepIndex+1)
-	}
-
-	// ── Next-step preview + parallel guidance ────────────────────
-	nextStep, _ := a.engine.NextStep(ctx, a.sessionID)
-	if nextStep != nil {
-		a.ui.PrintHint("▸ Next: " + truncateStr(nextStep.Instruction, 80))
-
-		// If current step has a timer, tell the user they can move on
-		// (the timer auto-starts when they advance).
-		if step.TimerConfig != nil {
-			if nextStep.TimerConfig == nil || nextStep.ID != step.ID {
-				guidance := speech.LineCanContinue(step.TimerConfig.Label)
-				a.ui.PrintChat(guidance)
-				if a.mouth != nil {
-					a.mouth.Say(guidance, speech.PriorityLow)
-				}
-			}
-		}
-	}
-
-	_ = state // available for future display of step timing stats
-}
-
-func (a *cliApp) advance(ctx context.Context) {
-	if a.sessionID == "" {
-		a.say(speech.LineNoSession(), speech.PriorityLow)
-		return
-	}
-
-	_, err := a.engine.Advance(ctx, a.sessionID)
-	if err != nil {
-		if errors.Is(err, domain.ErrNoMoreSteps) {
-			a.say(speech.LineLastStepDone(), speech.PriorityNormal)
-			a.sessionID = ""
-			a.selectedRecipe = ""
-			return
-		}
-		if errors.Is(err, domain.ErrSessionNotActive) {
-			a.say(speech.LineIsPaused(), speech.PriorityNormal)
-			return
-		}
-		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
-		return
-	}
-
-	a.showCurrentStep(ctx)
-}
-
-func (a *cliApp) skip(ctx context.Context) {
-	if a.sessionID == "" {
-		a.say(speech.LineNoSession(), speech.PriorityLow)
-		return
-	}
-
-	_, err := a.engine.Skip(ctx, a.sessionID)
-	if err != nil {
-		if errors.Is(err, domain.ErrNoMoreSteps) {
-			a.say(speech.LineSkippedLastStep(), speech.PriorityNormal)
-			a.sessionID = ""
-			a.selectedRecipe = ""
-			return
-		}
-		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
-		return
-	}
-
-	a.say(speech.LineSkipped(), speech.PriorityLow)
-	a.showCurrentStep(ctx)
-}
-
-func (a *cliApp) repeat(ctx context.Context) {
-	if a.sessionID == "" {
-		a.say(speech.LineNoSession(), speech.PriorityLow)
-		return
-	}
-
-	a.showCurrentStep(ctx)
-}
-
-func (a *cliApp) repeatLast(ctx context.Context) {
-	if a.mouth == nil {
-		a.say(speech.LineNothingToRepeat(), speech.PriorityLow)
-		return
-	}
-
-	last := a.mouth.LastSpoken()
-	if last == "" {
-		a.say(speech.LineNothingToRepeat(), speech.PriorityLow)
-		return
-	}
-
-	a.say(last, speech.PriorityNormal)
-}
-
-func (a *cliApp) startTimer(ctx context.Context) {
-	if a.sessionID == "" {
-		a.say(speech.LineNoSession(), speech.PriorityLow)
-		return
-	}
-
-	n, err := a.engine.StartPendingTimers(ctx, a.sessionID)
-	if err != nil {
-		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
-		return
-	}
-
-	if n == 0 {
-		a.ui.PrintHint("No pending timers to start.")
-		return
-	}
-
-	a.say(fmt.Sprintf("Timer started! (%d)", n), speech.PriorityNormal)
-}
-
-func (a *cliApp) dismissTimer(ctx context.Context, payload string) {
-	if a.sessionID == "" {
-		a.say(speech.LineNoSession(), speech.PriorityLow)
-		return
-	}
-
-	active, err := a.engine.ActiveTimers(ctx, a.sessionID)
-	if err != nil || len(active) == 0 {
-		a.say(speech.LineNoActiveTimers(), speech.PriorityLow)
-		return
-	}
-
-	// If there's only one active timer, just dismiss it.
-	if len(active) == 1 {
-		if err := a.engine.DismissTimer(ctx, a.sessionID, active[0].ID); err != nil {
-			a.log.Error("dismiss timer: %v", err)
-			a.say(speech.LineTimerAck(), speech.PriorityNormal)
-			return
-		}
-		a.say(speech.LineTimerDismissed(active[0].Label), speech.PriorityNormal)
-		return
-	}
-
-	// Multiple timers — prioritise fired ones first.
-	// A plain "ok"/"dismiss" should dismiss whatever has fired,
-	// since that's obviously what the user is reacting to.
-	var fired []*domain.TimerState
-	for _, t := range active {
-		if t.Status == domain.TimerFired {
-			fired = append(fired, t)
-		}
-	}
-	if len(fired) > 0 {
-		for _, t := range fired {
-			if err := a.engine.DismissTimer(ctx, a.sessionID, t.ID); err != nil {
-				a.log.Error("dismiss timer %s: %v", t.ID, err)
-			}
-		}
-		if len(fired) == 1 {
-			a.say(speech.LineTimerDismissed(fired[0].Label), speech.PriorityNormal)
-		} else {
-			a.say(speech.LineTimerAck(), speech.PriorityNormal)
-		}
-		return
-	}
-
-	// No fired timers — multiple running. Ask AI which one(s) to dismiss.
-	if a.agent == nil {
-		// No AI: dismiss all.
-		for _, t := range active {
-			_ = a.engine.DismissTimer(ctx, a.sessionID, t.ID)
-		}
-		a.say(speech.LineTimerAck(), speech.PriorityNormal)
-		return
-	}
-
-	recipe, session := a.gatherContext(ctx)
-	resp, err := a.agent.DismissTimer(ctx, payload, recipe, session)
-	if err != nil {
-		a.log.Error("AI dismiss timer failed: %v", err)
-		a.say(speech.LineTimerAck(), speech.PriorityNormal)
-		return
-	}
-
-	if len(resp.TimerIDs) == 0 {
-		// AI couldn't figure it out — speak its clarification question.
-		a.say(resp.Summary, speech.PriorityNormal)
-		return
-	}
-
-	for _, tid := range resp.TimerIDs {
-		if err := a.engine.DismissTimer(ctx, a.sessionID, tid); err != nil {
-			a.log.Error("dismiss timer %s: %v", tid, err)
-		}
-	}
-	a.say(resp.Summary, speech.PriorityNormal)
-}
-
-func (a *cliApp) pause(ctx context.Context) {
-	if a.sessionID == "" {
-		a.say(speech.LineNoSession(), speech.PriorityLow)
-		return
-	}
-
-	if err := a.engine.Pause(ctx, a.sessionID); err != nil {
-		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
-		return
-	}
-
-	a.say(speech.LinePaused(), speech.PriorityNormal)
-}
-
-func (a *cliApp) resume(ctx context.Context) {
-	if a.sessionID == "" {
-		a.say(speech.LineNoSession(), speech.PriorityLow)
-		return
-	}
-
-	_, err := a.engine.Resume(ctx, a.sessionID)
-	if err != nil {
-		if errors.Is(err, domain.ErrSessionPaused) {
-			a.say(speech.LineNotPaused(), speech.PriorityLow)
-			return
-		}
-		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
-		return
-	}
-
-	a.say(speech.LineResumed(), speech.PriorityNormal)
-	a.showCurrentStep(ctx)
-}
-
-func (a *cliApp) status(ctx context.Context) {
-	if a.sessionID == "" {
-		a.say(speech.LineNoSession(), speech.PriorityLow)
-		return
-	}
-
-	session, err := a.engine.Status(ctx, a.sessionID)
-	if err != nil {
-		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
-		return
-	}
-
-	// Visual status dump (not spoken — too much data).
-	a.ui.PrintStep(fmt.Sprintf("Session: %s", session.ID[:8]))
-	a.ui.PrintInstruction(fmt.Sprintf("Recipe:  %s", session.RecipeName))
-	a.ui.PrintInstruction(fmt.Sprintf("Status:  %s", session.Status))
-	a.ui.PrintInstruction(fmt.Sprintf("Step:    %d/%d", session.CurrentStepIndex+1, len(session.StepStates)))
-	a.ui.PrintHint(fmt.Sprintf("Started: %s ago", formatDuration(time.Since(session.StartedAt))))
-
-	activeTimers := 0
-	for _, ts := range session.TimerStates {
-		if ts.Status == domain.TimerRunning {
-			a.ui.PrintChat(fmt.Sprintf("%s — %s remaining", ts.Label, formatDuration(ts.Remaining)))
-			activeTimers++
-		} else if ts.Status == domain.TimerFired {
-			a.ui.PrintUrgent(fmt.Sprintf("%s — DONE", ts.Label))
-			activeTimers++
-		}
-	}
-	if activeTimers == 0 {
-		a.ui.PrintHint("Timers:  none active")
-	}
-
-	// Speak a concise summary.
-	if a.mouth != nil {
-		a.mouth.Say(speech.LineStatus(
-			session.CurrentStepIndex+1, len(session.StepStates),
-			session.RecipeName, activeTimers,
-		), speech.PriorityLow)
-	}
-}
-
-func (a *cliApp) quit(ctx context.Context) {
-	if a.sessionID != "" {
-		if err := a.engine.Abandon(ctx, a.sessionID); err != nil {
-			a.log.Error("abandoning session: %v", err)
-		}
-		a.say(speech.LineAbandoned(), speech.PriorityNormal)
-		a.sessionID = ""
-		a.selectedRecipe = ""
-	}
-	a.say(speech.LineBye(), speech.PriorityNormal)
-	// Brief pause so TTS can start the goodbye line.
-	time.Sleep(300 * time.Millisecond)
-	a.ui.Quit()
-}
-
-func (a *cliApp) showHelp() {
-	a.ui.PrintStep("Commands:")
-	a.ui.PrintInstruction("  list / recipes   Show available recipes")
-	a.ui.PrintInstruction("  1, 2, 3...       Select a recipe by number")
-	a.ui.PrintInstruction("  start / go       Start cooking the selected recipe")
-	a.ui.PrintInstruction("  next / done      Move to the next step")
-	a.ui.PrintInstruction("  skip             Skip the current step")
-	a.ui.PrintInstruction("  repeat / again   Show the current step again")
-	a.ui.PrintInstruction("  repeat last      Replay the last thing the assistant said")
-	a.ui.PrintInstruction("  pause / brb      Pause the session and timers")
-	a.ui.PrintInstruction("  resume / back    Resume a paused session")
-	a.ui.PrintInstruction("  status / where   Show session progress and timers")
-	a.ui.PrintInstruction("  timer / ready    Start a pending step timer")
-	a.ui.PrintInstruction("  dismiss / ok     Acknowledge a timer notification")
-	a.ui.PrintInstruction("  dismiss ...      Dismiss a specific timer (e.g. \"dismiss the simmer timer\")")
-	a.ui.PrintInstruction("  help             Show this message")
-	a.ui.PrintInstruction("  quit / exit      Abandon session and exit")
-	a.ui.Println("")
-	a.ui.PrintStep("AI (requires GPT_CHAT_KEY + GPT_CHAT_ENDPOINT):")
-	a.ui.PrintInstruction("  how do I...?     Ask the AI a cooking question")
-	a.ui.PrintInstruction("  modify ...       Ask the AI to change the recipe")
-	a.ui.PrintInstruction("  change ...       (swap, replace, double, halve, adjust, substitute)")
-}
-
-func formatDuration(d time.Duration) string {
-	d = d.Round(time.Second)
-	if d < time.Minute {
-		return fmt.Sprintf("%ds", int(d.Seconds()))
-	}
-	if d < time.Hour {
-		m := int(d.Minutes())
-		s := int(d.Seconds()) % 60
-		if s == 0 {
-			return fmt.Sprintf("%dm", m)
-		}
-		return fmt.Sprintf("%dm%ds", m, s)
+	if h, err := os.Hostname(); err == nil {
+		return h
 	}
-	h := int(d.Hours())
-	m := int(d.Minutes()) % 60
-	return fmt.Sprintf("%dh%dm", h, m)
+	return "your partner"
 }
 
-func truncateStr(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+// loadProfiles reads the household's profiles, or returns nil when
+// speaker identification is off.
+func loadProfiles(path, model string, threshold float64) (*voiceid.Profiles, error) {
+	if model == "" {
+		return nil, nil
 	}
-	return s[:maxLen-3] + "..."
+	return voiceid.Load(path, threshold)
 }
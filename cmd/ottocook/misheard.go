@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// defaultMisheardLog is where `ottocook misheard` looks for the log.
+const defaultMisheardLog = ".otto-stt/misheard.jsonl"
+
+// runMisheard implements `ottocook misheard`: a summary of the
+// correction log.  Returns the process exit code.
+func runMisheard(args []string) int {
+	fs := flag.NewFlagSet("misheard", flag.ContinueOnError)
+	path := fs.String("log", defaultMisheardLog, "correction log written by -misheard-log")
+	top := fs.Int("top", 10, "how many of the most common mix-ups to list")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: ottocook misheard [-log FILE] [-top N]\n\n")
+		fmt.Fprintf(fs.Output(), "Summarises voice commands corrected with \"that's not what I said\".\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	entries, err := speech.ReadMisheard(*path)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("No corrections logged at %s.\n", *path)
+		fmt.Println("Run with -misheard-log to start logging them.")
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		if len(entries) == 0 {
+			return 1
+		}
+	}
+
+	r := speech.SummarizeMisheard(entries)
+	fmt.Printf("%d corrections, %d false wakes (%s)\n", r.Total, r.FalseWakes, *path)
+	if r.FalseWakes > r.Total/3 {
+		fmt.Println("Many false wakes: try a higher -ww-threshold or -ww-verify-model.")
+	}
+
+	if len(r.Pairs) > 0 {
+		fmt.Println("\nMost common:")
+		for i, p := range r.Pairs {
+			if i == *top {
+				break
+			}
+			meant := p.Meant
+			if meant == "" {
+				meant = "(not said)"
+			}
+			fmt.Printf("  %3d  %-30q -> %s\n", p.Count, p.Heard, meant)
+		}
+	}
+
+	if r.Measured > 0 {
+		fmt.Printf("\nRead back first with -stt-min-confidence (of %d with a confidence):\n", r.Measured)
+		for _, c := range r.Cuts {
+			fmt.Printf("  %.1f  %3d  %3.0f%%\n", c.Threshold, c.Caught, 100*float64(c.Caught)/float64(r.Measured))
+		}
+	}
+	return 0
+}
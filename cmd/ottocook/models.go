@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/models"
+)
+
+// runModels implements `ottocook models <list|pull> ...`.
+// Returns the process exit code.
+func runModels(args []string) int {
+	fs := flag.NewFlagSet("models", flag.ContinueOnError)
+	dir := fs.String("dir", models.DefaultDir(), "directory to store downloaded models")
+	unverified := fs.Bool("unverified", false, "install models that have no published SHA-256 to check")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: ottocook models [-dir DIR] list\n")
+		fmt.Fprintf(fs.Output(), "       ottocook models [-dir DIR] [-unverified] pull NAME...\n\n")
+		fmt.Fprintf(fs.Output(), "NAME is a model from `list` or a group (%s).\n\n", strings.Join(models.Groups(), ", "))
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	switch fs.Arg(0) {
+	case "list", "":
+		fmt.Printf("Models directory: %s\n\n", *dir)
+		for _, m := range models.Catalog() {
+			// Only what's in the directory: pull installs there, so a
+			// copy in bin/ or models/ doesn't count.
+			status := "-"
+			path := filepath.Join(*dir, m.File)
+			if _, err := os.Stat(path); err == nil {
+				status = path
+			}
+			fmt.Printf("  %-15s %-5s %-8s %s\n", m.Name, m.Kind, m.Size, status)
+		}
+		return 0
+
+	case "pull":
+		names := fs.Args()[1:]
+		if len(names) == 0 {
+			fs.Usage()
+			return 2
+		}
+		var todo []models.Model
+		for _, name := range names {
+			ms, ok := models.Lookup(name)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "error: unknown model %q (see `ottocook models list`)\n", name)
+				return 2
+			}
+			todo = append(todo, ms...)
+		}
+
+		var opts []models.PullOption
+		if *unverified {
+			opts = append(opts, models.AllowUnverified())
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		for _, m := range todo {
+			if _, err := models.Pull(ctx, nil, m, *dir, os.Stdout, opts...); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				if errors.Is(err, models.ErrUnverified) {
+					fmt.Fprintf(os.Stderr, "check the file by hand, or pass -unverified to install it anyway\n")
+				}
+				return 1
+			}
+		}
+		return 0
+
+	default:
+		fs.Usage()
+		return 2
+	}
+}
+
+// resolveModel finds a model file at path or in the usual places.  When
+// it can't be found, it returns a message telling the user how to get it.
+func resolveModel(label, path string) (string, string) {
+	if found, ok := models.Resolve(path); ok {
+		return found, ""
+	}
+	msg := fmt.Sprintf("%s not found at %s", label, path)
+	if hint := models.PullHint(path); hint != "" {
+		msg += fmt.Sprintf(" (run `%s`)", hint)
+	}
+	return "", msg
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/timer"
+)
+
+// ── Live settings ────────────────────────────────────────────────
+//
+// Some settings can change under a running session without harm: the
+// voice, units, thresholds, how often timers remind, and the display's
+// pace and title.  Those are re-read from config.env (and the
+// escalation file) whenever either is saved, or on "reload", and
+// applied on the spot; restarting mid-cook would lose every timer.
+// The rest are read once at startup.
+
+// Config keys for settings that otherwise only have a flag.
+const (
+	envWWThreshold   = "OTTOCOOK_WW_THRESHOLD"   // -ww-threshold
+	envMinConfidence = "OTTOCOOK_MIN_CONFIDENCE" // -stt-min-confidence
+	envReminderEvery = "OTTOCOOK_REMINDER_EVERY" // "X remaining" reminders, e.g. 3m; 0 for none
+	envTypewriter    = "OTTOCOOK_TYPEWRITER"     // -typewriter
+	envBell          = "OTTOCOOK_BELL"           // -bell
+	envTitle         = "OTTOCOOK_TITLE"          // -title
+)
+
+// reloadEvery is how often the config files are checked for changes.
+var reloadEvery = 2 * time.Second
+
+// setting is a config key that can be applied while Otto runs.
+type setting struct {
+	key   string
+	name  string // what it's called when it changes, e.g. "units"
+	flag  string // the flag it stands in for, which wins at startup when given
+	apply func(v string) error
+}
+
+// liveConfig is an app.Reloader over config.env and the escalation file.
+type liveConfig struct {
+	path           string // config.env; "" for none
+	escalationPath string
+	setEscalation  func(timer.EscalationConfig)
+	log            *logger.Logger
+	settings       []setting
+
+	seen    map[string]string // config.env as last read
+	escSeen fileStamp         // the escalation file as last read
+}
+
+// fileStamp tells whether a file has been saved since last looked at.
+type fileStamp struct {
+	mod  time.Time
+	size int64
+}
+
+func stampOf(path string) fileStamp {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{fi.ModTime(), fi.Size()}
+}
+
+func newLiveConfig(path, escalationPath string, setEscalation func(timer.EscalationConfig), log *logger.Logger) *liveConfig {
+	return &liveConfig{path: path, escalationPath: escalationPath, setEscalation: setEscalation, log: log}
+}
+
+// add registers a setting; flag may be "".
+func (c *liveConfig) add(key, name, flag string, apply func(v string) error) {
+	c.settings = append(c.settings, setting{key, name, flag, apply})
+}
+
+// start applies each setting the environment (with config.env loaded
+// into it) has a value for, unless its flag was given on the command
+// line, and notes the files as they are now.
+func (c *liveConfig) start(given map[string]bool) {
+	c.seen, _ = c.read()
+	c.escSeen = stampOf(c.escalationPath)
+	for _, s := range c.settings {
+		v := os.Getenv(s.key)
+		if v == "" || given[s.flag] {
+			continue
+		}
+		if err := s.apply(v); err != nil {
+			c.log.Error("config: %s: %v", s.key, err)
+		}
+	}
+}
+
+// Reload applies the settings that changed in config.env since it was
+// last read, and the escalation file if it was saved.  A key taken out
+// of the file keeps its current value until restart, and an escalation
+// file that doesn't parse (half saved, say) leaves the ladders as they
+// are.
+func (c *liveConfig) Reload() ([]string, error) {
+	file, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	var errs []error
+	for _, s := range c.settings {
+		v, ok := file[s.key]
+		if !ok || v == c.seen[s.key] {
+			continue
+		}
+		if err := s.apply(v); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.key, err))
+			continue
+		}
+		os.Setenv(s.key, v)
+		changed = append(changed, s.name)
+	}
+	c.seen = file
+
+	if stamp := stampOf(c.escalationPath); stamp != c.escSeen {
+		c.escSeen = stamp
+		esc, err := timer.LoadEscalation(c.escalationPath)
+		if errors.Is(err, timer.ErrEscalationUnreadable) {
+			errs = append(errs, err) // the ladders in use stay
+		} else {
+			if err != nil {
+				errs = append(errs, err) // what loaded is still used
+			}
+			c.setEscalation(esc)
+			changed = append(changed, "timer alerts")
+		}
+	}
+	return changed, errors.Join(errs...)
+}
+
+// read returns config.env's keys; a missing file has none.
+func (c *liveConfig) read() (map[string]string, error) {
+	if c.path == "" {
+		return map[string]string{}, nil
+	}
+	file, err := godotenv.Read(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.path, err)
+	}
+	return file, nil
+}
+
+// watch calls changed whenever config.env or the escalation file is
+// saved, until ctx is done.
+func (c *liveConfig) watch(ctx context.Context, changed func()) {
+	last := [2]fileStamp{stampOf(c.path), stampOf(c.escalationPath)}
+	ticker := time.NewTicker(reloadEvery)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := [2]fileStamp{stampOf(c.path), stampOf(c.escalationPath)}
+			if now != last {
+				last = now
+				changed()
+			}
+		}
+	}
+}
+
+// fraction parses a threshold in [0, 1].
+func fraction(v string) (float64, error) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 || f > 1 {
+		return 0, fmt.Errorf("%q isn't a number from 0 to 1", v)
+	}
+	return f, nil
+}
+
+// oneOf checks v is among the choices.
+func oneOf(v string, choices []string) error {
+	if !slices.Contains(choices, v) {
+		return fmt.Errorf("%q isn't one of %v", v, choices)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/timer"
+)
+
+// liveRig is a liveConfig over temp files with three settings that
+// record what they're given.
+type liveRig struct {
+	c          *liveConfig
+	env, esc   string
+	applied    map[string][]string
+	escalation []timer.EscalationConfig
+}
+
+func newLiveRig(t *testing.T) *liveRig {
+	t.Helper()
+	dir := t.TempDir()
+	r := &liveRig{
+		env:     filepath.Join(dir, "config.env"),
+		esc:     filepath.Join(dir, "escalation.json"),
+		applied: map[string][]string{},
+	}
+	r.c = newLiveConfig(r.env, r.esc, func(cfg timer.EscalationConfig) { r.escalation = append(r.escalation, cfg) }, logger.New(logger.LevelOff, nil))
+	for _, s := range []struct{ key, name, flag string }{
+		{"OTTO_TEST_VOICE", "voice", ""},
+		{"OTTO_TEST_THRESHOLD", "threshold", "threshold"},
+		{"OTTO_TEST_BELL", "bell", "bell"},
+	} {
+		t.Setenv(s.key, "")
+		r.c.add(s.key, s.name, s.flag, func(v string) error {
+			if s.key == "OTTO_TEST_THRESHOLD" {
+				if _, err := fraction(v); err != nil {
+					return err
+				}
+			}
+			r.applied[s.key] = append(r.applied[s.key], v)
+			return nil
+		})
+	}
+	return r
+}
+
+// write saves name with content, a second on from whenever it was last
+// saved so the change is seen however coarse the clock.
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	mod := time.Now()
+	if fi, err := os.Stat(path); err == nil {
+		mod = fi.ModTime().Add(time.Second)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLiveConfigStart(t *testing.T) {
+	r := newLiveRig(t)
+	write(t, r.env, "OTTO_TEST_VOICE=en-GB-RyanNeural\nOTTO_TEST_THRESHOLD=0.7\n")
+	// main loads config.env into the environment first.
+	t.Setenv("OTTO_TEST_VOICE", "en-GB-RyanNeural")
+	t.Setenv("OTTO_TEST_THRESHOLD", "0.7")
+
+	r.c.start(map[string]bool{"threshold": true})
+	if got := r.applied["OTTO_TEST_VOICE"]; !slices.Equal(got, []string{"en-GB-RyanNeural"}) {
+		t.Errorf("voice applied %v, want the file's", got)
+	}
+	if got := r.applied["OTTO_TEST_THRESHOLD"]; len(got) != 0 {
+		t.Errorf("threshold applied %v over its flag", got)
+	}
+	if got := r.applied["OTTO_TEST_BELL"]; len(got) != 0 {
+		t.Errorf("bell applied %v with no value", got)
+	}
+
+	// Nothing's changed since start.
+	if changed, err := r.c.Reload(); len(changed) != 0 || err != nil {
+		t.Errorf("Reload = %v, %v; want nothing", changed, err)
+	}
+}
+
+func TestLiveConfigReload(t *testing.T) {
+	r := newLiveRig(t)
+	write(t, r.env, "OTTO_TEST_VOICE=en-GB-RyanNeural\nOTTO_TEST_THRESHOLD=0.7\n")
+	t.Setenv("OTTO_TEST_VOICE", "en-GB-RyanNeural")
+	t.Setenv("OTTO_TEST_THRESHOLD", "0.7")
+	r.c.start(nil)
+
+	// A changed key is applied even if its flag was given; a removed
+	// one keeps its value; a bad one is reported and not applied.
+	write(t, r.env, "OTTO_TEST_THRESHOLD=1.5\nOTTO_TEST_BELL=off\n")
+	changed, err := r.c.Reload()
+	if !slices.Equal(changed, []string{"bell"}) {
+		t.Errorf("changed = %v, want the bell", changed)
+	}
+	if err == nil || !strings.Contains(err.Error(), "OTTO_TEST_THRESHOLD") {
+		t.Errorf("err = %v, want the bad threshold reported", err)
+	}
+	if got := r.applied["OTTO_TEST_VOICE"]; len(got) != 1 {
+		t.Errorf("voice applied %v; taking it out of the file should leave it", got)
+	}
+	if got := os.Getenv("OTTO_TEST_BELL"); got != "off" {
+		t.Errorf("$OTTO_TEST_BELL = %q, want the reloaded value", got)
+	}
+	if got := os.Getenv("OTTO_TEST_THRESHOLD"); got != "0.7" {
+		t.Errorf("$OTTO_TEST_THRESHOLD = %q, want the old value kept", got)
+	}
+
+	write(t, r.env, "OTTO_TEST_THRESHOLD=0.4\nOTTO_TEST_BELL=off\n")
+	if changed, err := r.c.Reload(); !slices.Equal(changed, []string{"threshold"}) || err != nil {
+		t.Errorf("Reload = %v, %v; want the fixed threshold", changed, err)
+	}
+}
+
+func TestLiveConfigReloadEscalation(t *testing.T) {
+	r := newLiveRig(t)
+	r.c.start(nil)
+
+	write(t, r.esc, `{"default": {"interval": "1m"}}`)
+	changed, err := r.c.Reload()
+	if !slices.Equal(changed, []string{"timer alerts"}) || err != nil || len(r.escalation) != 1 {
+		t.Fatalf("Reload = %v, %v; want the ladders applied", changed, err)
+	}
+	if got := time.Duration(r.escalation[0].For("Rice").Interval); got != time.Minute {
+		t.Errorf("interval = %s, want the file's minute", got)
+	}
+
+	// Half saved: reported, and the ladders in use stay.
+	write(t, r.esc, `{"default": {"interval": `)
+	changed, err = r.c.Reload()
+	if len(changed) != 0 || err == nil || len(r.escalation) != 1 {
+		t.Errorf("Reload = %v, %v after %d sets; want the error and nothing applied", changed, err, len(r.escalation))
+	}
+
+	// A bad rule is reported, and the rest applied.
+	write(t, r.esc, `{"default": {"interval": "2m"}, "rules": [{"match": "(unclosed"}]}`)
+	changed, err = r.c.Reload()
+	if !slices.Equal(changed, []string{"timer alerts"}) || err == nil || len(r.escalation) != 2 {
+		t.Errorf("Reload = %v, %v; want the good part applied and the rule reported", changed, err)
+	}
+}
+
+func TestLiveConfigWatch(t *testing.T) {
+	defer func(d time.Duration) { reloadEvery = d }(reloadEvery)
+	reloadEvery = 5 * time.Millisecond
+
+	r := newLiveRig(t)
+	r.c.start(nil)
+	saved := make(chan struct{}, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		r.c.watch(ctx, func() { saved <- struct{}{} })
+		close(done)
+	}()
+
+	for _, path := range []string{r.env, r.esc} {
+		time.Sleep(20 * time.Millisecond) // let a tick see the files as they were
+		write(t, path, "OTTO_TEST_BELL=off\n")
+		select {
+		case <-saved:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("saving %s wasn't noticed", filepath.Base(path))
+		}
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("watch didn't stop with its context")
+	}
+}
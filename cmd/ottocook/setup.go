@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/hammamikhairi/ottocook/internal/gpt"
+	"github.com/hammamikhairi/ottocook/internal/keychain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// Env var names for the GPT credentials.
+const (
+	envGPTKey      = "GPT_CHAT_KEY"
+	envGPTEndpoint = "GPT_CHAT_ENDPOINT"
+)
+
+// configPath returns where setup writes its answers:
+// <user config dir>/ottocook/config.env.  It's a dotenv file loaded
+// after the environment and ./.env, so either of those can override it.
+func configPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "ottocook", "config.env")
+}
+
+// needsSetup reports whether this looks like a first run: no config
+// file, no credentials in the environment or the keychain, and someone
+// at a terminal to answer questions.
+func needsSetup(path string) bool {
+	if path == "" {
+		return false
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+	for _, k := range []string{speech.EnvAzureSpeechKey, envGPTKey} {
+		if os.Getenv(k) != "" {
+			return false
+		}
+		if v, err := keychain.Get(k); err == nil && v != "" {
+			return false
+		}
+	}
+	fi, err := os.Stdin.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// runSetup implements `ottocook setup`.  Returns the process exit code.
+func runSetup(args []string) int {
+	path := configPath()
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "setup: no config directory; pass a path: ottocook setup PATH")
+		return 2
+	}
+	player := func() (*speech.Player, error) { return speech.NewPlayer(logger.New(logger.LevelOff, nil)) }
+	if err := setupWizard(os.Stdin, os.Stdout, path, player); err != nil {
+		fmt.Fprintf(os.Stderr, "setup: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// setupWizard walks through checking the speakers and mic, entering
+// credentials, and choosing a voice and units, then writes the answers
+// to path.  Anything skipped is left out, so the feature stays off
+// until it's configured.  The audio device can only be opened once per
+// process, so player hands out the one the app will keep using.
+func setupWizard(in io.Reader, out io.Writer, path string, player func() (*speech.Player, error)) error {
+	w := &wizard{in: bufio.NewReader(in), out: out}
+	cfg, err := godotenv.Read(path)
+	if err != nil {
+		cfg = map[string]string{}
+	}
+	log := logger.New(logger.LevelOff, nil)
+
+	fmt.Fprintln(out, "OttoCook setup — press Enter to accept the [default], or to skip a step.")
+	fmt.Fprintln(out)
+
+	// ── Speakers ──
+	fmt.Fprintln(out, "1. Speakers")
+	speaker, err := player()
+	if err != nil {
+		fmt.Fprintf(out, "   No audio output (%v). Otto will still show everything on screen.\n", err)
+	} else if w.yes("   Play a test tone?", true) {
+		speaker.Play(speech.Tone(660, 700*time.Millisecond))
+		if !w.yes("   Did you hear it?", true) {
+			fmt.Fprintln(out, "   Check your output device and volume; spoken steps won't be audible until then.")
+		}
+	}
+	fmt.Fprintln(out)
+
+	// ── Microphone ──
+	fmt.Fprintln(out, "2. Microphone (only needed for -voice)")
+	if w.yes("   Test the microphone?", false) {
+		fmt.Fprintln(out, "   Say something for three seconds...")
+		peak, err := speech.MicLevel(3*time.Second, func(rms float64) {
+			fmt.Fprintf(out, "\r   %s", levelMeter(rms))
+		})
+		fmt.Fprintln(out)
+		switch {
+		case err != nil:
+			fmt.Fprintf(out, "   Couldn't record: %v\n", err)
+		case peak < speech.SilenceRMS:
+			fmt.Fprintln(out, "   That was too quiet to hear. Check the mic isn't muted and is the default input.")
+		default:
+			fmt.Fprintln(out, "   Sounds good.")
+		}
+	}
+	fmt.Fprintln(out)
+
+	// ── Speech ──
+	fmt.Fprintln(out, "3. Spoken output (Azure Speech — free tier is plenty)")
+	w.askSecret(cfg, speech.EnvAzureSpeechKey, "   Azure Speech key")
+	if cfg[speech.EnvAzureSpeechKey] != "" {
+		w.ask(cfg, speech.EnvAzureSpeechRegion, "   Azure region (e.g. eastus)", "")
+		voice := w.choose("   Voice", speech.Voices, cmp.Or(cfg[speech.EnvVoice], speech.DefaultVoice))
+		cfg[speech.EnvVoice] = voice
+		if speaker != nil && cfg[speech.EnvAzureSpeechRegion] != "" && w.yes("   Hear a sample?", true) {
+			client := speech.NewAzureClient(cfg[speech.EnvAzureSpeechKey], cfg[speech.EnvAzureSpeechRegion], log, speech.WithVoice(voice))
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			audio, err := client.Synthesize(ctx, "Hi, I'm Otto. Let's cook something.")
+			cancel()
+			if err != nil {
+				fmt.Fprintf(out, "   Azure didn't answer: %v\n   Check the key and region; you can rerun `ottocook setup` later.\n", err)
+			} else {
+				speaker.Play(audio)
+			}
+		}
+	}
+	fmt.Fprintln(out)
+
+	// ── AI ──
+	fmt.Fprintln(out, "4. AI assistant (questions and recipe changes)")
+	w.askSecret(cfg, envGPTKey, "   GPT chat key")
+	if cfg[envGPTKey] != "" {
+		w.ask(cfg, envGPTEndpoint, "   GPT chat endpoint URL", "")
+	}
+	fmt.Fprintln(out)
+
+	// ── Where the keys live ──
+	var secrets []string
+	for _, k := range credentialKeys {
+		if cfg[k] != "" {
+			secrets = append(secrets, k)
+		}
+	}
+	if len(secrets) > 0 && w.yes("Keep the keys in the system keychain instead of the config file?", true) {
+		for _, k := range secrets {
+			if err := keychain.Set(k, cfg[k]); err != nil {
+				fmt.Fprintf(out, "   Couldn't store %s in the keychain (%v); it'll go in the config file.\n", k, err)
+				continue
+			}
+			delete(cfg, k)
+		}
+	}
+	fmt.Fprintln(out)
+
+	// ── Units ──
+	fmt.Fprintln(out, "5. Units")
+	cfg[gpt.EnvUnits] = w.choose("   Measure in", []string{gpt.UnitsMetric, gpt.UnitsUS}, cmp.Or(cfg[gpt.EnvUnits], gpt.UnitsMetric))
+	fmt.Fprintln(out)
+
+	for k, v := range cfg {
+		if v == "" {
+			delete(cfg, k)
+		}
+	}
+	if err := writeConfig(path, cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Saved to %s. Run `ottocook setup` to change it.\n\n", path)
+	return nil
+}
+
+// writeConfig saves cfg as a dotenv file readable only by the user,
+// since it may hold keys.
+func writeConfig(path string, cfg map[string]string) error {
+	data, err := godotenv.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(data+"\n"), 0o600)
+}
+
+// levelMeter draws a mic level (0–0.1 RMS, full scale for speech) as a bar.
+func levelMeter(rms float64) string {
+	const width = 30
+	n := min(width, int(rms/0.1*width))
+	bar := strings.Repeat("█", n) + strings.Repeat("·", width-n)
+	return fmt.Sprintf("[%s] %.3f", bar, rms)
+}
+
+// wizard reads answers from a line-oriented input.
+type wizard struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func (w *wizard) line(prompt string) string {
+	fmt.Fprint(w.out, prompt)
+	s, _ := w.in.ReadString('\n')
+	return strings.TrimSpace(s)
+}
+
+// yes asks a yes/no question.
+func (w *wizard) yes(question string, def bool) bool {
+	hint := " [y/N] "
+	if def {
+		hint = " [Y/n] "
+	}
+	switch strings.ToLower(w.line(question + hint)) {
+	case "":
+		return def
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// ask fills cfg[key], keeping the current value on an empty answer.
+func (w *wizard) ask(cfg map[string]string, key, prompt, def string) {
+	cur := cmp.Or(cfg[key], os.Getenv(key), def)
+	hint := ": "
+	if cur != "" {
+		hint = " [" + cur + "]: "
+	}
+	if v := w.line(prompt + hint); v != "" {
+		cfg[key] = v
+	} else {
+		cfg[key] = cur
+	}
+}
+
+// askSecret is ask for keys: the current value is never echoed.
+func (w *wizard) askSecret(cfg map[string]string, key, prompt string) {
+	cur := cmp.Or(cfg[key], os.Getenv(key))
+	hint := " (Enter to skip): "
+	if cur != "" {
+		hint = " [set — Enter to keep]: "
+	}
+	if v := w.line(prompt + hint); v != "" {
+		cfg[key] = v
+	} else {
+		cfg[key] = cur
+	}
+}
+
+// choose offers a numbered list and returns the pick.  A name typed in
+// full is accepted even if it isn't listed.
+func (w *wizard) choose(prompt string, options []string, def string) string {
+	for i, o := range options {
+		mark := " "
+		if o == def {
+			mark = "*"
+		}
+		fmt.Fprintf(w.out, "   %s %d) %s\n", mark, i+1, o)
+	}
+	v := w.line(prompt + " [" + def + "]: ")
+	if v == "" {
+		return def
+	}
+	if n, err := strconv.Atoi(v); err == nil && n >= 1 && n <= len(options) {
+		return options[n-1]
+	}
+	return v
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"maps"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joho/godotenv"
+
+	"github.com/hammamikhairi/ottocook/internal/gpt"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// noSpeaker stands in for a machine with no audio output.
+func noSpeaker() (*speech.Player, error) { return nil, errors.New("no device") }
+
+func TestSetupWizard(t *testing.T) {
+	for _, k := range []string{speech.EnvAzureSpeechKey, speech.EnvAzureSpeechRegion, speech.EnvVoice, envGPTKey, envGPTEndpoint, gpt.EnvUnits} {
+		t.Setenv(k, "")
+	}
+	path := filepath.Join(t.TempDir(), "ottocook", "config.env")
+
+	run := func(answers ...string) string {
+		t.Helper()
+		var out strings.Builder
+		if err := setupWizard(strings.NewReader(strings.Join(answers, "\n")+"\n"), &out, path, noSpeaker); err != nil {
+			t.Fatalf("setupWizard: %v", err)
+		}
+		return out.String()
+	}
+	read := func() map[string]string {
+		t.Helper()
+		cfg, err := godotenv.Read(path)
+		if err != nil {
+			t.Fatalf("reading config: %v", err)
+		}
+		return cfg
+	}
+
+	out := run(
+		"",          // test the mic? no
+		"az-secret", // Azure key
+		"westeurope",
+		"2",  // voice
+		"",   // GPT key: skip
+		"no", // keychain
+		"2",  // units
+	)
+	want := map[string]string{
+		speech.EnvAzureSpeechKey:    "az-secret",
+		speech.EnvAzureSpeechRegion: "westeurope",
+		speech.EnvVoice:             speech.Voices[1],
+		gpt.EnvUnits:                gpt.UnitsUS,
+	}
+	if got := read(); !maps.Equal(got, want) {
+		t.Errorf("first run saved %v, want %v", got, want)
+	}
+	if !strings.Contains(out, "No audio output") || strings.Contains(out, "GPT chat endpoint") {
+		t.Errorf("first run asked the wrong things:\n%s", out)
+	}
+
+	// A rerun keeps everything on Enter, and never echoes the key.
+	out = run("", "", "", "", "", "", "")
+	if got := read(); !maps.Equal(got, want) {
+		t.Errorf("rerun saved %v, want %v", got, want)
+	}
+	if strings.Contains(out, "az-secret") {
+		t.Errorf("rerun echoed the key:\n%s", out)
+	}
+	if !strings.Contains(out, "Azure Speech key [set — Enter to keep]") || !strings.Contains(out, "[westeurope]") {
+		t.Errorf("rerun didn't offer the saved answers:\n%s", out)
+	}
+}
+
+func TestWizardAnswers(t *testing.T) {
+	options := []string{"metric", "us"}
+	tests := []struct {
+		answer  string
+		yesDef  bool
+		wantYes bool
+		want    string // choose(options, "metric")
+	}{
+		{"", true, true, "metric"},
+		{"", false, false, "metric"},
+		{"Y", false, true, "Y"},
+		{"yes", false, true, "yes"},
+		{"nope", true, false, "nope"},
+		{"2", true, false, "us"},
+		{"3", true, false, "3"},
+		{"us", true, false, "us"},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		w := &wizard{in: bufio.NewReader(strings.NewReader(tt.answer + "\n" + tt.answer + "\n")), out: &out}
+		if got := w.yes("Go?", tt.yesDef); got != tt.wantYes {
+			t.Errorf("yes(%q, default %v) = %v, want %v", tt.answer, tt.yesDef, got, tt.wantYes)
+		}
+		if got := w.choose("Units", options, "metric"); got != tt.want {
+			t.Errorf("choose(%q) = %q, want %q", tt.answer, got, tt.want)
+		}
+		if !strings.Contains(out.String(), "* 1) metric") {
+			t.Errorf("the default isn't marked:\n%s", out.String())
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import "sync"
+
+// ── Activity spinner ─────────────────────────────────────────────
+//
+// The spinner above the prompt says what Otto is busy with: an AI call
+// by name ("Thinking...", "Modifying..."), or a line of speech still
+// being synthesized before it can play.  Both can be going at once —
+// a timer alert being voiced mid-question — so the AI call's label
+// wins, and the spinner goes only once neither is left.
+
+// synthLabel is the spinner's label while speech is synthesized.
+const synthLabel = "Preparing speech..."
+
+// activity decides what the spinner shows.  Speech reports from the
+// mouth's goroutines, so it's locked.
+type activity struct {
+	mu     sync.Mutex
+	status Status // nil shows nothing
+	ai     string // label of the AI call in flight; "" when none
+	synth  bool   // speech is waiting on the TTS backend
+}
+
+// setAI shows label for an AI call, or "" when it's done.
+func (s *activity) setAI(label string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ai = label
+	s.showLocked()
+}
+
+// setSynth is the activity's OnSynthesizing subscription.
+func (s *activity) setSynth(waiting bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.synth = waiting
+	s.showLocked()
+}
+
+func (s *activity) showLocked() {
+	switch {
+	case s.status == nil:
+	case s.ai != "":
+		s.status.SetActivity(s.ai)
+	case s.synth:
+		s.status.SetActivity(synthLabel)
+	default:
+		s.status.ClearActivity()
+	}
+}
@@ -0,0 +1,36 @@
+package app
+
+import "testing"
+
+// spinner records what the activity shows.
+type spinner struct {
+	label string // "" when cleared
+}
+
+func (s *spinner) SetActivity(label string) { s.label = label }
+func (s *spinner) ClearActivity()           { s.label = "" }
+func (s *spinner) Quit()                    {}
+
+func TestActivity(t *testing.T) {
+	ui := &spinner{}
+	act := &activity{status: ui}
+	steps := []struct {
+		do   func()
+		want string
+	}{
+		{func() { act.setSynth(true) }, synthLabel},
+		{func() { act.setAI("Thinking...") }, "Thinking..."}, // the AI call wins
+		{func() { act.setSynth(false) }, "Thinking..."},
+		{func() { act.setSynth(true) }, "Thinking..."},
+		{func() { act.setAI("") }, synthLabel}, // speech is still waiting
+		{func() { act.setAI("Modifying...") }, "Modifying..."},
+		{func() { act.setSynth(false) }, "Modifying..."},
+		{func() { act.setAI("") }, ""},
+	}
+	for i, s := range steps {
+		s.do()
+		if ui.label != s.want {
+			t.Errorf("step %d: spinner shows %q, want %q", i+1, ui.label, s.want)
+		}
+	}
+}
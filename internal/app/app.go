@@ -0,0 +1,227 @@
+// Package app is Otto's session controller: everything between a heard
+// or typed command and what gets said back.  It owns the conversation
+// state — the session being cooked, the question awaiting a yes/no, the
+// AI request still to answer — and runs it on one input loop.
+//
+// The controller only sees the outside world through Chat, Status and
+// Input, for what is printed, shown busy and typed, Utterances, for what
+// is heard, and Voice, for what is said.  The terminal UI (all of the
+// first three), the wake-word ear, and the mouth are one set; tests and
+// other front ends bring their own.
+package app
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/awake"
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/engine"
+	"github.com/hammamikhairi/ottocook/internal/gpt"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/metrics"
+	"github.com/hammamikhairi/ottocook/internal/plugin"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+	"github.com/hammamikhairi/ottocook/internal/voiceid"
+)
+
+// Chat is where the controller's output goes, one kind of line per
+// method so a front end can style each: *display.UI in the terminal.
+type Chat interface {
+	Println(a ...interface{})
+	PrintChat(text string)
+	PrintStep(text string)
+	PrintInstruction(text string)
+	PrintInstructionAction(text, command string)
+	PrintHint(text string)
+	PrintHintAction(text, command string)
+	PrintUrgent(text string)
+	PrintVoice(text string)
+
+	PrintDiffAdded(text string)
+	PrintDiffRemoved(text string)
+	PrintDiffChanged(text string)
+	PrintDiffUnchanged(text string)
+}
+
+// Status shows what Otto is busy with, and closes the front end once
+// the cook says goodbye.
+type Status interface {
+	// SetActivity shows a busy label (e.g. "Thinking…") until
+	// ClearActivity.
+	SetActivity(label string)
+	ClearActivity()
+
+	Quit()
+}
+
+// Input is where typed commands come from.
+type Input interface {
+	// InputChan delivers typed lines; closing it ends Run.
+	InputChan() <-chan string
+}
+
+// Voice is where the controller's speech goes: *speech.Mouth, or a
+// fake in tests.  On top of a Speaker it needs the mouth's extras.
+type Voice interface {
+	speech.Speaker
+
+	// Play queues a sound, such as an earcon, in turn with speech.
+	Play(name string, audio []byte, priority speech.Priority)
+
+	// InterruptChannel clears only the given channels, keeping nothing
+	// for TakeInterrupted, which returns what Interrupt cut off.
+	InterruptChannel(chs ...speech.Channel)
+	TakeInterrupted() string
+
+	// PrefetchGroup warms the cache for lines likely to be said soon.
+	PrefetchGroup(ctx context.Context, group string, texts ...string)
+	// OnSynthesizing subscribes to speech waiting on synthesis.
+	OnSynthesizing(fn func(waiting bool))
+}
+
+// Utterances is where heard commands come from: *speech.Ear, or a fake
+// in tests.  Optional extras — a microphone to switch off, idle power
+// saving, voice prints — are found by type assertion.
+type Utterances interface {
+	C() <-chan speech.Utterance
+}
+
+// AudioSwitch turns speech and voice input off and back on as a whole:
+// *speech.Audio, or a fake in tests.
+type AudioSwitch interface {
+	Start(ctx context.Context)
+	Close()
+	On() bool
+}
+
+// Reloader re-reads the config files and applies what can change while
+// Otto runs, returning what it changed, by name ("voice", "units").
+// Reload is only called on the input loop.
+type Reloader interface {
+	Reload() (changed []string, err error)
+}
+
+// Compile-time interface checks.
+var (
+	_ Voice       = (*speech.Mouth)(nil)
+	_ Utterances  = (*speech.Ear)(nil)
+	_ AudioSwitch = (*speech.Audio)(nil)
+)
+
+// Config is what a Controller is built from.  Engine, Parser, Log, Chat
+// and Input are required; everything else is off when left zero.
+type Config struct {
+	Engine   *engine.Engine
+	Parser   domain.IntentParser
+	Notifier domain.Notifier
+	Log      *logger.Logger
+	Chat     Chat
+	Status   Status // the busy spinner and quitting; nil for none
+	Input    Input
+
+	Mouth       Voice               // speech output; leave nil, not a nil *speech.Mouth
+	Ear         Utterances          // voice input; likewise
+	Agent       *gpt.Agent          // AI answers and changes
+	Plugins     *plugin.Host        // -plugins
+	Metrics     *metrics.Registry   // -metrics-addr
+	Profiles    *voiceid.Profiles   // -speaker-model
+	AnswerLog   *gpt.AnswerLog      // -ai-log
+	MisheardLog *speech.MisheardLog // -misheard-log
+	KeepAwake   *awake.Lock         // -keep-awake: held while a session is under way
+	Audio       AudioSwitch         // "voice off" and "voice on"; nil without speech or voice input
+	Settings    Reloader            // "reload"; nil when there's no config to reload
+
+	MinConfidence float64       // voice commands below this need a yes/no before risky intents
+	OverheardMin  float64       // -always-listen: speech without the wake word below this is ignored
+	AIPerMinute   int           // cap on AI calls; 0 for none
+	AITimeout     time.Duration // bound on one AI call; DefaultAITimeout when zero
+	SafetyReview  bool          // a model pass over AI changes after the rules
+	CalendarPath  string        // file "add it to my calendar" writes
+	TimelinePath  string        // file a finished session's timeline is drawn to; "" for none
+	Clock         domain.Clock  // what follow-ups, timings and the AI rate limit go by; the wall clock when nil
+}
+
+// New builds a controller.  Nothing happens until Run, except that
+// the mouth's synthesis waits start showing on the display's spinner.
+func New(cfg Config) *Controller {
+	a := &Controller{
+		engine:   cfg.Engine,
+		parser:   cfg.Parser,
+		plugins:  cfg.Plugins,
+		notifier: cfg.Notifier,
+		mouth:    cfg.Mouth,
+		agent:    cfg.Agent,
+		ear:      cfg.Ear,
+		log:      cfg.Log,
+		ui:       cfg.Chat,
+		statusUI: cfg.Status,
+		input:    cfg.Input,
+
+		minConfidence: cfg.MinConfidence,
+		overheardMin:  cfg.OverheardMin,
+		aiPerMinute:   cfg.AIPerMinute,
+		aiTimeout:     cmp.Or(cfg.AITimeout, DefaultAITimeout),
+		metrics:       cfg.Metrics,
+		safetyReview:  cfg.SafetyReview,
+		calendarPath:  cfg.CalendarPath,
+		timelinePath:  cfg.TimelinePath,
+		answerLog:     cfg.AnswerLog,
+		misheardLog:   cfg.MisheardLog,
+		profiles:      cfg.Profiles,
+		keepAwake:     cfg.KeepAwake,
+		audio:         cfg.Audio,
+		settings:      cfg.Settings,
+		activity:      &activity{status: cfg.Status},
+		events:        make(chan func(context.Context), 16),
+		clock:         cfg.Clock,
+	}
+	if a.clock == nil {
+		a.clock = domain.SystemClock{}
+	}
+	if cfg.Mouth != nil {
+		cfg.Mouth.OnSynthesizing(a.activity.setSynth)
+	}
+	return a
+}
+
+// Restore hands over the sessions left unfinished last time: journal
+// recoveries and hibernated waits.  A single session left in a long
+// wait on purpose is simply picked back up; anything else is offered
+// when Run starts.  Call before Run.
+func (a *Controller) Restore(lists ...[]*domain.Session) {
+	unfinished := unfinishedSessions(lists...)
+	if len(unfinished) == 1 && unfinished[0].Status == domain.SessionWaiting {
+		a.sessionID = unfinished[0].ID
+		a.selectedRecipe = unfinished[0].RecipeID
+		return
+	}
+	a.unfinished = unfinished
+}
+
+// Post runs fn on the input loop, which owns the controller's state.
+// Safe to call from any goroutine.
+func (a *Controller) Post(fn func(ctx context.Context)) {
+	a.events <- fn
+}
+
+// Reload re-reads the config files, as "reload" does, but says nothing
+// when nothing changed.  For a watcher on the files; safe to call from
+// any goroutine.
+func (a *Controller) Reload() {
+	a.Post(func(context.Context) { a.reload(false) })
+}
+
+// SetMinConfidence changes how sure a voice command must be heard
+// before a risky one goes ahead without a yes or no.  Call on the input
+// loop, as a Reloader does.
+func (a *Controller) SetMinConfidence(f float64) {
+	a.minConfidence = f
+}
+
+// ToggleMic turns the microphone off or back on, as the Ctrl+O hotkey
+// does.  Safe to call from any goroutine.
+func (a *Controller) ToggleMic() {
+	a.Post(func(context.Context) { a.toggleMic() })
+}
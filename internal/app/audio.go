@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Voice off ────────────────────────────────────────────────────
+//
+// "Voice off" goes further than muting the mic: the ear, the wake word
+// detector, the mouth and the audio player are all closed, letting go
+// of the sound card and the microphone, and Otto carries on in text.
+// "Voice on" (typed, since nothing is listening) starts them again
+// without a restart.  Anything said while the voice is off is printed
+// and dropped, not queued up for later.
+
+// setVoice turns speech and voice input off or on.
+func (a *Controller) setVoice(ctx context.Context, off bool) {
+	if a.audio == nil {
+		a.say(speech.LineNoAudio(), speech.PriorityLow)
+		return
+	}
+	if off != a.audio.On() {
+		a.say(speech.LineAudioAlready(off), speech.PriorityLow)
+		return
+	}
+	if off {
+		a.ui.PrintUrgent(speech.LineAudioOff())
+		a.audio.Close()
+		return
+	}
+	a.audio.Start(ctx)
+	a.say(speech.LineAudioOn(a.ear != nil), speech.PriorityNormal)
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// fakeAudio is an AudioSwitch that counts how often it was switched.
+type fakeAudio struct {
+	mu             sync.Mutex
+	on             bool
+	starts, closes int
+}
+
+func (f *fakeAudio) Start(ctx context.Context) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.on = true
+	f.starts++
+}
+
+func (f *fakeAudio) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.on = false
+	f.closes++
+}
+
+func (f *fakeAudio) On() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.on
+}
+
+func (f *fakeAudio) switched() (starts, closes int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.starts, f.closes
+}
+
+func TestSetVoice(t *testing.T) {
+	audio := &fakeAudio{on: true}
+	h := newHarnessWith(t, func(ctx context.Context, h *harness) {
+		h.app.audio = audio
+	})
+	h.expect("Chicken Alfredo")
+
+	h.typeLine("voice on")
+	h.expect(speech.LineAudioAlready(false))
+	h.typeLine("voice off")
+	h.expect(speech.LineAudioOff())
+	h.typeLine("voice off")
+	h.expect(speech.LineAudioAlready(true))
+	h.typeLine("voice on")
+	h.expect(speech.LineAudioOn(true))
+
+	if starts, closes := audio.switched(); starts != 1 || closes != 1 {
+		t.Errorf("audio started %d and closed %d times, want once each", starts, closes)
+	}
+}
+
+func TestSetVoiceWithoutEar(t *testing.T) {
+	audio := &fakeAudio{}
+	h := newHarnessWith(t, func(ctx context.Context, h *harness) {
+		h.app.audio = audio
+		h.app.ear = nil
+	})
+	h.expect("Chicken Alfredo")
+
+	h.typeLine("voice on")
+	line := h.expect(speech.LineAudioOn(false))
+	if strings.Contains(line, "Hey Chef") {
+		t.Errorf("said %q with nothing listening", line)
+	}
+}
+
+func TestSetVoiceWithoutAudio(t *testing.T) {
+	h := newHarness(t)
+	h.expect("Chicken Alfredo")
+
+	h.typeLine("voice off")
+	h.expect(speech.LineNoAudio())
+}
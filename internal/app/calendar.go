@@ -0,0 +1,120 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/calendar"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Calendar export ──────────────────────────────────────────────
+//
+// "Add it to my calendar" writes the session's milestones (a wait's end,
+// running timers, when to start the next wait and when to eat) to an
+// .ics file, -calendar, for the cook to open in their calendar app.  The
+// events keep the same IDs from one export to the next, so opening the
+// file again moves them rather than adding copies.  Once exported, the
+// file is rewritten whenever a wait starts or the serve time changes;
+// a milestone that drops out, and every one when the session ends, is
+// written back cancelled so opening the file takes it off the calendar.
+
+// exportCalendar handles "add it to my calendar".
+func (a *Controller) exportCalendar(ctx context.Context) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+	n, err := a.writeCalendar(ctx)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if n == 0 {
+		a.say(speech.LineNothingToSchedule(), speech.PriorityNormal)
+		return
+	}
+	a.calendarLive = true
+	a.ui.PrintHint("Open " + a.calendarPath + " to add it to your calendar.")
+	a.say(speech.LineCalendarExported(n), speech.PriorityNormal)
+}
+
+// refreshCalendar rewrites the calendar file after the milestones move,
+// if the cook has exported one this session.
+func (a *Controller) refreshCalendar(ctx context.Context) {
+	if !a.calendarLive || a.sessionID == "" {
+		return
+	}
+	if _, err := a.writeCalendar(ctx); err != nil {
+		a.log.Error("refreshing calendar: %v", err)
+	}
+}
+
+// retireCalendar cancels every event in the calendar file once the
+// session is over, finished or abandoned, and stops keeping it up to
+// date.
+func (a *Controller) retireCalendar() {
+	if !a.calendarLive {
+		return
+	}
+	if len(a.calendarSent) > 0 {
+		if err := a.writeEvents(nil); err != nil {
+			a.log.Error("cancelling calendar: %v", err)
+		}
+	}
+	a.calendarLive = false
+	a.calendarSent = nil
+}
+
+// writeCalendar writes the session's milestones to the calendar file
+// and returns how many there were.
+func (a *Controller) writeCalendar(ctx context.Context) (int, error) {
+	milestones, err := a.engine.Milestones(ctx, a.sessionID)
+	if err != nil {
+		return 0, err
+	}
+	if len(milestones) == 0 && len(a.calendarSent) == 0 {
+		return 0, nil
+	}
+	events := make([]calendar.Event, len(milestones))
+	for i, m := range milestones {
+		events[i] = calendar.Event{
+			UID:         fmt.Sprintf("%s-%s@ottocook", a.sessionID, m.Key),
+			Start:       m.At,
+			Summary:     m.Title,
+			Description: m.Detail,
+			Alarm:       true,
+		}
+	}
+	if err := a.writeEvents(events); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+// writeEvents writes events to the calendar file, along with the ones
+// written last time that are no longer among them, cancelled.
+func (a *Controller) writeEvents(events []calendar.Event) error {
+	all := slices.Clone(events)
+	for _, sent := range a.calendarSent {
+		if !slices.ContainsFunc(events, func(ev calendar.Event) bool { return ev.UID == sent.UID }) {
+			sent.Cancelled = true
+			all = append(all, sent)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.Write(&buf, all, time.Now()); err != nil {
+		return err
+	}
+	if err := os.WriteFile(a.calendarPath, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", a.calendarPath, err)
+	}
+	a.calendarSent = events
+	a.log.Info("calendar: %d milestone(s) written to %s, %d cancelled", len(events), a.calendarPath, len(all)-len(events))
+	return nil
+}
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/engine"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Comparing recipes ────────────────────────────────────────────
+//
+// "Compare 1 and 2" prints two recipes side by side, ingredients row by
+// row with the differences marked, then their step counts and times,
+// and reads out what sets the second apart from the first.  Either can
+// be a number from the last list, a version of the selected recipe
+// ("compare v1 and v3"), or a recipe's ID or name.
+
+// compareWidth is how wide each column is.
+const compareWidth = 34
+
+// compareRecipes handles "compare <first> and <second>".
+func (a *Controller) compareRecipes(ctx context.Context, args domain.CompareArgs) {
+	if args.A == "" || args.B == "" {
+		a.say(speech.LineCompareWhich(), speech.PriorityNormal)
+		return
+	}
+	first, firstName := a.compareTarget(ctx, args.A)
+	if first == nil {
+		a.say(speech.LineNoRecipeToCompare(args.A), speech.PriorityNormal)
+		return
+	}
+	second, secondName := a.compareTarget(ctx, args.B)
+	if second == nil {
+		a.say(speech.LineNoRecipeToCompare(args.B), speech.PriorityNormal)
+		return
+	}
+
+	c := engine.CompareRecipes(first, second)
+	row := func(left, right string) string {
+		return cell(left) + "  " + right
+	}
+
+	a.ui.PrintStep(row("=== "+firstName, secondName+" ==="))
+	a.ui.Println("")
+	a.ui.PrintStep("Ingredients:")
+	for _, p := range c.Ingredients {
+		var left, right string
+		if p.A != nil {
+			left = fmtIngredient(*p.A)
+		}
+		if p.B != nil {
+			right = fmtIngredient(*p.B)
+		}
+		switch {
+		case p.Same():
+			a.ui.PrintDiffUnchanged(row(left, right))
+		case p.B == nil:
+			a.ui.PrintDiffRemoved(row(left, ""))
+		case p.A == nil:
+			a.ui.PrintDiffAdded(row("", right))
+		default:
+			a.ui.PrintDiffChanged(row(left, right))
+		}
+	}
+
+	a.ui.Println("")
+	a.ui.PrintStep("Steps:")
+	a.ui.PrintInstruction(row(
+		fmt.Sprintf("%d steps, ~%s", len(first.Steps), formatDuration(c.TimeA)),
+		fmt.Sprintf("%d steps, ~%s", len(second.Steps), formatDuration(c.TimeB)),
+	))
+	if meta, other := recipeMeta(first.PrepTime, first.CookTime, first.Difficulty), recipeMeta(second.PrepTime, second.CookTime, second.Difficulty); meta != "" || other != "" {
+		a.ui.PrintHint(row(meta, other))
+	}
+	if first.Servings != second.Servings {
+		a.ui.PrintDiffChanged(row(fmt.Sprintf("Serves %d", first.Servings), fmt.Sprintf("Serves %d", second.Servings)))
+	}
+
+	onlyA, onlyB, changed := c.Differences()
+	a.say(speech.LineComparison(firstName, secondName, onlyA, onlyB, changed,
+		len(first.Steps), len(second.Steps), c.TimeA, c.TimeB), speech.PriorityNormal)
+}
+
+// compareTarget finds the recipe a comparison names, and what to call
+// it: a number from the last list, "v2" for a version of the selected
+// recipe, or an ID or name.  Returns nil when there's no such recipe.
+func (a *Controller) compareTarget(ctx context.Context, ref string) (*domain.Recipe, string) {
+	if n, err := strconv.Atoi(ref); err == nil {
+		recipes := a.listed
+		if len(recipes) == 0 {
+			recipes, _ = a.engine.ListRecipes(ctx)
+		}
+		if n < 1 || n > len(recipes) {
+			return nil, ""
+		}
+		r, err := a.engine.GetRecipe(ctx, recipes[n-1].ID)
+		if err != nil {
+			return nil, ""
+		}
+		return r, r.Name
+	}
+
+	if v, ok := strings.CutPrefix(strings.ToLower(ref), "v"); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			recipe, _ := a.gatherContext(ctx)
+			if recipe == nil {
+				return nil, ""
+			}
+			history, err := a.engine.RecipeVersions(ctx, recipe.ID)
+			if err != nil {
+				return nil, ""
+			}
+			for _, h := range history {
+				if h.Version == n {
+					return h.Recipe, fmt.Sprintf("version %d", n)
+				}
+			}
+			return nil, ""
+		}
+	}
+
+	if r, err := a.engine.GetRecipe(ctx, ref); err == nil {
+		return r, r.Name
+	}
+	recipes, _ := a.engine.ListRecipes(ctx)
+	for _, s := range recipes {
+		if strings.EqualFold(s.Name, ref) {
+			if r, err := a.engine.GetRecipe(ctx, s.ID); err == nil {
+				return r, r.Name
+			}
+		}
+	}
+	return nil, ""
+}
+
+// cell pads or cuts s to the width of a column.
+func cell(s string) string {
+	s = ansi.Truncate(s, compareWidth, "…")
+	return s + strings.Repeat(" ", compareWidth-ansi.StringWidth(s))
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Step conditions ──────────────────────────────────────────────
+//
+// "Check off the water" marks one of the step's conditions met.  Once
+// the cook has checked any off, "next" with some still open asks before
+// moving on; a cook who never checks them off is never asked.
+
+// checkCondition handles "check off …" and "the water's boiling".
+func (a *Controller) checkCondition(ctx context.Context, ref string) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+	checked, open, err := a.engine.CheckCondition(ctx, a.sessionID, ref)
+	switch {
+	case errors.Is(err, domain.ErrAmbiguous):
+		a.listConditions(ctx)
+		a.say(speech.LineWhichCondition(), speech.PriorityNormal)
+		return
+	case errors.Is(err, domain.ErrNoSuchCondition):
+		a.say(speech.LineNoSuchCondition(), speech.PriorityNormal)
+		return
+	case err != nil:
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	for _, c := range checked {
+		a.ui.PrintHint("✓ " + c.Description)
+	}
+	a.say(speech.LineConditionChecked(descriptions(open)), speech.PriorityNormal)
+}
+
+// listConditions prints the current step's conditions, numbered, with
+// the met ones ticked.
+func (a *Controller) listConditions(ctx context.Context) {
+	step, state, err := a.engine.CurrentStep(ctx, a.sessionID)
+	if err != nil {
+		return
+	}
+	for i, c := range step.Conditions {
+		mark := " "
+		if state.IsMet(i) {
+			mark = "✓"
+		}
+		a.ui.PrintHint(fmt.Sprintf("%s %d. %s", mark, i+1, c.Description))
+	}
+}
+
+// advanceChecked is "next": it asks first when the cook has been
+// checking off conditions and the current step still has some open.
+func (a *Controller) advanceChecked(ctx context.Context) {
+	if a.sessionID != "" {
+		if s, err := a.engine.Status(ctx, a.sessionID); err == nil && s.ChecksConditions() {
+			open, err := a.engine.UnmetConditions(ctx, a.sessionID)
+			if err != nil {
+				a.log.Debug("unmet conditions: %v", err)
+			}
+			if len(open) > 0 {
+				a.confirm(speech.LineConditionsOpen(descriptions(open)), "advance with open conditions", a.advance)
+				return
+			}
+		}
+	}
+	a.advance(ctx)
+}
+
+func descriptions(conds []domain.StepCondition) []string {
+	out := make([]string, len(conds))
+	for i, c := range conds {
+		out[i] = c.Description
+	}
+	return out
+}
@@ -0,0 +1,2172 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/awake"
+	"github.com/hammamikhairi/ottocook/internal/calendar"
+	"github.com/hammamikhairi/ottocook/internal/conversation"
+	"github.com/hammamikhairi/ottocook/internal/cookalong"
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/engine"
+	"github.com/hammamikhairi/ottocook/internal/gpt"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/metrics"
+	"github.com/hammamikhairi/ottocook/internal/offline"
+	"github.com/hammamikhairi/ottocook/internal/plugin"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+	"github.com/hammamikhairi/ottocook/internal/voiceid"
+)
+
+// Controller runs a cooking conversation: it reads commands from the
+// display and the ear and acts on them.  Build one with New.
+type Controller struct {
+	engine         *engine.Engine
+	parser         domain.IntentParser
+	plugins        *plugin.Host // nil unless -plugins
+	notifier       domain.Notifier
+	mouth          Voice      // nil when TTS is disabled
+	agent          *gpt.Agent // nil when AI is disabled
+	ear            Utterances // nil when voice input is disabled
+	log            *logger.Logger
+	ui             Chat
+	statusUI       Status // nil without a spinner
+	input          Input
+	sessionID      string                 // current active session
+	selectedRecipe string                 // recipe chosen before typing 'start'
+	listed         []domain.RecipeSummary // last numbered list shown; numbers pick from it
+	serveAt        time.Time              // serve time given before a session started
+	kitChecked     string                 // recipe whose equipment the user has been asked about
+	unfinished     []*domain.Session      // sessions from last time awaiting resume or abandon
+
+	minConfidence float64               // voice commands below this need a yes/no before risky intents
+	overheardMin  float64               // -always-listen: speech without the wake word below this is ignored
+	aiPerMinute   int                   // -ai-per-minute: cap on AI calls; 0 for none
+	metrics       *metrics.Registry     // nil unless -metrics-addr
+	pending       *pendingConfirmation  // question awaiting a yes/no, if any
+	lastHeard     *heardCommand         // last voice command, for "that's not what I said"
+	misheard      *heardCommand         // misheard command whose correction is awaited
+	misheardAsked time.Time             // when the correction was asked for
+	misheardLog   *speech.MisheardLog   // nil unless -misheard-log
+	clarify       *pendingClarification // AI follow-up question awaiting an answer, if any
+	unanswered    *aiRequest            // AI request that hasn't produced an answer yet
+	aiTimeout     time.Duration         // bound on one agent call
+	ai            inflight              // agent call in progress, for CancelAI
+	activity      *activity             // what the spinner shows
+	safetyReview  bool                  // -ai-safety: a model pass over changes after the rules
+	lastAnswer    *aiAnswer             // last AI answer, for "good answer" / "that's wrong"
+	answerLog     *gpt.AnswerLog        // nil unless -ai-log
+	calendarPath  string                // -calendar
+	calendarLive  bool                  // calendar exported this session; kept up to date
+	calendarSent  []calendar.Event      // events in the calendar file, to cancel when they drop out
+	timelinePath  string                // -timeline
+	finished      string                // last session finished, for "show the timeline"
+	cooked        []string              // recipes finished this run, oldest first, for suggestions
+	profiles      *voiceid.Profiles     // nil unless -speaker-model
+	speaker       *voiceid.Profile      // who gave the last voice command, if known
+	keepAwake     *awake.Lock           // nil unless -keep-awake
+	audio         AudioSwitch           // nil without speech or voice input
+	settings      Reloader              // nil when there's no config to reload
+	clock         domain.Clock          // what follow-ups, timings and the AI rate limit go by
+
+	events  chan func(ctx context.Context) // work posted from other goroutines, run by the input loop
+	peer    *cookalong.Peer                // nil unless cooking along with someone
+	partner *cookalong.Progress            // partner's last reported progress
+}
+
+// aiRequest is an agent call that's in flight, or that failed or was
+// cancelled before answering.  "What were you saying?" re-issues it.
+type aiRequest struct {
+	what  string // short description for the logs
+	retry func(ctx context.Context)
+}
+
+// clarifyTTL is how long an AI follow-up question stays open.  After
+// that, the next input is treated as a fresh command.
+const clarifyTTL = 2 * time.Minute
+
+// pendingClarification remembers a follow-up question the agent asked
+// so the user's next free-form reply goes back to the same request
+// instead of being parsed from scratch.
+type pendingClarification struct {
+	question string
+	asked    time.Time
+	resume   func(ctx context.Context, answer string)
+}
+
+// askClarification speaks the agent's follow-up question and routes the
+// next unrecognised input to resume.
+func (a *Controller) askClarification(question string, resume func(ctx context.Context, answer string)) {
+	a.clarify = &pendingClarification{question: question, asked: a.clock.Now(), resume: resume}
+	a.say(question, speech.PriorityNormal)
+}
+
+// withClarification folds a follow-up exchange into the original
+// request so the agent sees the whole conversation.
+func withClarification(original, question, answer string) string {
+	return fmt.Sprintf("%s\n[You asked: %q — the user replied: %q]", original, question, answer)
+}
+
+// pendingConfirmation is an action on hold until the user answers yes
+// or no.  Any other input drops it.
+type pendingConfirmation struct {
+	what     string                    // short description for the logs
+	run      func(ctx context.Context) // executed on "yes"
+	declined string                    // spoken on "no"; LineConfirmCancelled when empty
+}
+
+// confirm asks a yes/no question and parks run until it's answered.
+func (a *Controller) confirm(question, what string, run func(ctx context.Context)) {
+	a.pending = &pendingConfirmation{what: what, run: run}
+	a.say(question, speech.PriorityHigh)
+}
+
+// say prints a message to stdout and queues it for speech at the given priority.
+// Use for conversational lines the user should hear. For raw formatting (menus,
+// ingredient lists, tables) use fmt directly — those shouldn't be spoken.
+func (a *Controller) say(text string, priority speech.Priority) {
+	a.sayOn(speech.ChannelGeneral, text, priority)
+}
+
+// sayOn is say for speech from a particular channel.
+func (a *Controller) sayOn(ch speech.Channel, text string, priority speech.Priority) {
+	a.ui.PrintChat(text)
+	if a.mouth != nil {
+		a.mouth.SayOn(ch, text, priority)
+	}
+}
+
+// sayUrgent prints a message in bold red and queues it at high priority.
+func (a *Controller) sayUrgent(text string) {
+	a.ui.PrintUrgent(text)
+	if a.mouth != nil {
+		a.mouth.Say(text, speech.PriorityHigh)
+	}
+}
+
+// prefetchStep pre-warms the TTS cache for the step at the given 0-based
+// index within the current recipe. Non-blocking. Does nothing if TTS is
+// disabled or the index is out of range.
+func (a *Controller) prefetchStep(ctx context.Context, recipeID string, stepIdx int) {
+	if a.mouth == nil || recipeID == "" {
+		return
+	}
+	r, err := a.engine.GetRecipe(ctx, recipeID)
+	if err != nil || stepIdx < 0 || stepIdx >= len(r.Steps) {
+		return
+	}
+	step := r.Steps[stepIdx]
+	total := len(r.Steps)
+
+	var conditions []string
+	for _, c := range step.Conditions {
+		conditions = append(conditions, c.Description)
+	}
+	tLabel := ""
+	var tDur time.Duration
+	if step.TimerConfig != nil {
+		tLabel = step.TimerConfig.Label
+		tDur = step.TimerConfig.Duration
+	}
+	text := speech.LineStep(step.Order, total, step.Instruction, conditions, step.ParallelHints, step.Notes, tLabel, tDur)
+	a.mouth.PrefetchGroup(ctx, "step", text)
+}
+
+// syncIdle lets the ear save power at the recipe list: with no session
+// there are no timers to keep track of, and the wake word only needs
+// scoring once there's some sound in the room.  While there is one, the
+// machine is kept from sleeping.
+func (a *Controller) syncIdle() {
+	if e, ok := a.ear.(interface{ SetIdle(bool) }); ok {
+		e.SetIdle(a.sessionID == "")
+	}
+	a.keepAwake.Set(a.sessionID != "")
+}
+
+// Run reads and acts on commands until ctx is done or the display
+// closes its input.
+func (a *Controller) Run(ctx context.Context) {
+	defer a.keepAwake.Release()
+	if len(a.unfinished) > 0 {
+		a.holdUnfinished(ctx)
+		a.offerUnfinished(ctx)
+	} else if a.sessionID != "" {
+		a.welcomeBack(ctx)
+	} else {
+		a.say(speech.LineWelcome(), speech.PriorityNormal)
+		a.ui.Println("")
+		a.showRecipes(ctx)
+		a.offerSuggestion(ctx)
+	}
+
+	// Voice channel (nil-safe: receiving on a nil channel blocks forever,
+	// which is fine — select will only use the keyboard case).
+	var voiceCh <-chan speech.Utterance
+	if a.ear != nil {
+		voiceCh = a.ear.C()
+	}
+
+	uiCh := a.input.InputChan()
+	dispatch := a.pipeline()
+
+	for {
+		a.syncIdle()
+
+		var input string
+		var ok bool
+		var heard *heardCommand // nil for typed input
+		overheard := false      // heard without the wake word, with -always-listen
+		language := ""
+
+		// What was heard during the last AI call goes first.
+		u, voice := a.nextHeard()
+		if !voice {
+			select {
+			case <-ctx.Done():
+				return
+			case input, ok = <-uiCh:
+				if !ok {
+					return
+				}
+			case fn := <-a.events:
+				fn(ctx)
+				continue
+			case u = <-voiceCh:
+				voice = true
+			}
+		}
+		if voice {
+			input, overheard, language = u.Text, u.Overheard, u.Language
+			heard = &heardCommand{confidence: u.Confidence, audio: u.Audio, voiceprint: u.Voiceprint, at: time.Now()}
+		}
+
+		input = strings.TrimSpace(input)
+		if input == "" {
+			continue
+		}
+		if heard != nil {
+			heard.text = input
+			if overheard && !a.heedOverheard(ctx, input, heard.confidence) {
+				continue
+			}
+			if a.agent != nil {
+				a.agent.SetLanguage(language)
+			}
+			// Print what was heard so the user sees it in the REPL.
+			if who := a.identify(heard.voiceprint); who != "" {
+				a.ui.PrintVoice(who + ": " + input)
+			} else {
+				a.ui.PrintVoice(input)
+			}
+		}
+
+		if a.misheard != nil {
+			a.answerMisheard(input)
+		}
+		if a.pending != nil && a.answerPending(ctx, input) {
+			continue
+		}
+		if len(a.unfinished) > 0 && a.answerUnfinished(ctx, input) {
+			continue
+		}
+
+		var session *domain.Session
+		if a.sessionID != "" {
+			s, err := a.engine.Status(ctx, a.sessionID)
+			if err == nil {
+				session = s
+			}
+		}
+
+		intent, err := a.parser.Parse(ctx, input, session)
+		if err != nil {
+			a.log.Error("parsing input: %v", err)
+			continue
+		}
+
+		dispatch(ctx, &turn{intent: intent, input: input, heard: heard})
+	}
+}
+
+// riskyIfMisheard reports whether acting on a misrecognised command of
+// this type would lose progress or silence something important.
+func riskyIfMisheard(t domain.IntentType) bool {
+	switch t {
+	case domain.IntentQuit, domain.IntentSkip, domain.IntentDismissTimer, domain.IntentModify:
+		return true
+	}
+	return false
+}
+
+// confirmsItself reports whether handling intent will already ask the
+// user to confirm (see quit).
+func (a *Controller) confirmsItself(intent *domain.Intent) bool {
+	return intent.Type == domain.IntentQuit && a.sessionID != ""
+}
+
+// answerPending treats input as the reply to a pending yes/no question.
+// It returns false when the input isn't a yes or no — the question is
+// dropped and the caller handles the input as a fresh command.
+func (a *Controller) answerPending(ctx context.Context, input string) bool {
+	p := a.pending
+	a.pending = nil
+	yes, ok := conversation.ParseConfirmation(input)
+	if !ok {
+		a.log.Debug("pending confirmation dropped by new input %q", input)
+		return false
+	}
+	if !yes {
+		a.log.Debug("confirmation declined: %s", p.what)
+		if p.declined != "" {
+			a.say(p.declined, speech.PriorityNormal)
+		} else {
+			a.say(speech.LineConfirmCancelled(), speech.PriorityNormal)
+		}
+		return true
+	}
+	a.log.Debug("confirmation accepted: %s", p.what)
+	p.run(ctx)
+	return true
+}
+
+func (a *Controller) handleIntent(ctx context.Context, intent *domain.Intent) {
+	if intent.Args == nil {
+		conversation.Structure(intent) // made by hand rather than parsed
+	}
+
+	// Action intents interrupt whatever is currently being spoken so the
+	// assistant doesn't keep talking over the new response.
+	switch intent.Type {
+	case domain.IntentListRecipes, domain.IntentSelectRecipe,
+		domain.IntentStartCooking, domain.IntentAdvance, domain.IntentSkip,
+		domain.IntentRepeat, domain.IntentRepeatLast, domain.IntentPause, domain.IntentResume,
+		domain.IntentStatus, domain.IntentQuit, domain.IntentDismissTimer,
+		domain.IntentAskQuestion, domain.IntentModify, domain.IntentSearch,
+		domain.IntentTag, domain.IntentCollect, domain.IntentDuplicate, domain.IntentNote,
+		domain.IntentPrepList, domain.IntentHowMuch, domain.IntentMisheard, domain.IntentFeedback,
+		domain.IntentCheck, domain.IntentDelegate, domain.IntentTaskDone, domain.IntentTasks,
+		domain.IntentCalendar, domain.IntentTimeline, domain.IntentCompare, domain.IntentSuggest, domain.IntentPairing, domain.IntentMic, domain.IntentVoice, domain.IntentReload, domain.IntentMissedWake, domain.IntentEnrollVoice, domain.IntentDiet,
+		domain.IntentPlugin, domain.IntentCancel:
+		if a.mouth != nil {
+			a.mouth.Interrupt()
+		}
+	}
+
+	switch intent.Type {
+	case domain.IntentHelp:
+		a.showHelp(ctx, intent.Payload)
+	case domain.IntentListRecipes:
+		if intent.Payload != "" {
+			a.showFiltered(ctx, intent.Payload)
+		} else {
+			a.showRecipes(ctx)
+		}
+	case domain.IntentTag:
+		a.tagRecipe(ctx, intent.Args.(domain.TagArgs))
+	case domain.IntentCollect:
+		a.collectRecipe(ctx, intent.Args.(domain.CollectArgs))
+	case domain.IntentDuplicate:
+		a.duplicateRecipe(ctx, intent.Args.(domain.DuplicateArgs).Name)
+	case domain.IntentNote:
+		a.addNote(ctx, intent.Args.(domain.NoteArgs))
+	case domain.IntentSearch:
+		a.searchRecipes(ctx, intent.Payload)
+	case domain.IntentSelectRecipe:
+		a.selectRecipe(ctx, intent.Args.(domain.SelectRecipeArgs), intent.Payload)
+	case domain.IntentStartCooking:
+		a.startCooking(ctx)
+	case domain.IntentAdvance:
+		a.advanceChecked(ctx)
+	case domain.IntentSkip:
+		a.skip(ctx, intent.Payload)
+	case domain.IntentRepeat:
+		a.repeat(ctx)
+	case domain.IntentRepeatLast:
+		a.repeatLast(ctx)
+	case domain.IntentResumeLast:
+		a.resumeLast(ctx)
+	case domain.IntentPause:
+		a.pause(ctx)
+	case domain.IntentResume:
+		a.resume(ctx)
+	case domain.IntentStatus:
+		a.status(ctx)
+	case domain.IntentQuit:
+		a.quit(ctx)
+	case domain.IntentDismissTimer:
+		a.dismissTimer(ctx, intent.Payload)
+	case domain.IntentStartTimer:
+		a.startTimer(ctx, intent.Payload == "all")
+	case domain.IntentTimerControl:
+		a.controlTimer(ctx, intent.Args.(domain.TimerArgs), intent.Payload)
+	case domain.IntentServeTime:
+		a.setServeTime(ctx, intent.Payload)
+	case domain.IntentPrepList:
+		a.showPrepList(ctx)
+	case domain.IntentHowMuch:
+		a.howMuch(ctx, intent.Payload, intent.Args.(domain.HowMuchArgs).Ingredient)
+	case domain.IntentVersions:
+		a.recipeVersions(ctx, intent.Args.(domain.VersionArgs))
+	case domain.IntentMisheard:
+		a.correctMisheard(ctx, intent.Payload)
+	case domain.IntentFeedback:
+		a.rateAnswer(ctx, intent.Payload)
+	case domain.IntentCheck:
+		a.checkCondition(ctx, intent.Payload)
+	case domain.IntentDelegate:
+		a.delegate(ctx, intent.Args.(domain.TaskArgs))
+	case domain.IntentTaskDone:
+		a.finishTask(ctx, intent.Args.(domain.TaskArgs))
+	case domain.IntentTasks:
+		a.listTasks(ctx, intent.Args.(domain.TaskArgs).Helper)
+	case domain.IntentCalendar:
+		a.exportCalendar(ctx)
+	case domain.IntentTimeline:
+		a.exportTimeline(ctx)
+	case domain.IntentCompare:
+		a.compareRecipes(ctx, intent.Args.(domain.CompareArgs))
+	case domain.IntentSuggest:
+		a.suggest(ctx, intent.Payload)
+	case domain.IntentPairing:
+		a.pairing(ctx, intent.Payload)
+	case domain.IntentMic:
+		a.setMic(intent.Payload == "off")
+	case domain.IntentVoice:
+		a.setVoice(ctx, intent.Payload == "off")
+	case domain.IntentReload:
+		a.reload(true)
+	case domain.IntentMissedWake:
+		a.missedWake()
+	case domain.IntentEnrollVoice:
+		a.enrollVoice(intent.Payload)
+	case domain.IntentDiet:
+		a.noteDiet(intent.Args.(domain.DietArgs))
+	case domain.IntentPlugin:
+		a.runPlugin(ctx, intent.Args.(domain.PluginArgs))
+	case domain.IntentCancel:
+		a.cancel()
+	case domain.IntentAcknowledge:
+		// Nothing to do: the cook is agreeing, and the AI needn't hear it.
+	case domain.IntentAskQuestion:
+		a.askQuestion(ctx, intent.Payload)
+	case domain.IntentModify:
+		a.modifyRequest(ctx, intent.Payload)
+	case domain.IntentUnknown:
+		a.classifyAndDispatch(ctx, intent)
+	}
+}
+
+// classifyAndDispatch sends unrecognised input to the AI for intent
+// classification, then re-dispatches the result. Falls back to the
+// generic "didn't catch that" line when the agent is unavailable or
+// still returns unknown.
+func (a *Controller) classifyAndDispatch(ctx context.Context, original *domain.Intent) {
+	if a.agent == nil {
+		if !a.answerOffline(ctx, original.Payload) {
+			a.say(speech.LineUnknown(original.Payload), speech.PriorityLow)
+		}
+		return
+	}
+
+	filler := speech.LineThinkingClassify()
+	a.ui.PrintHint(filler)
+	if a.mouth != nil {
+		a.mouth.SayOn(speech.ChannelAI, filler, speech.PriorityCritical)
+	}
+
+	recipe, session := a.gatherContext(ctx)
+	callCtx, done := a.aiCall(ctx, "Classifying...")
+	classified, err := a.agent.Classify(callCtx, original.Payload, recipe, session)
+	done()
+	if err != nil {
+		if a.aiCancelled("classify", err) {
+			return
+		}
+		a.log.Error("AI classify failed: %v", err)
+		a.say(speech.LineUnknown(original.Payload), speech.PriorityLow)
+		return
+	}
+
+	if classified.Type == domain.IntentUnknown {
+		a.say(speech.LineUnknown(original.Payload), speech.PriorityLow)
+		return
+	}
+
+	a.log.Info("classified %q -> %s", original.Payload, classified.Type)
+	if classified.Type == domain.IntentHelp && findHelpTopic(classified.Payload) == nil {
+		// The model can hand back the whole question as the topic;
+		// the command list answers it better than "no help for".
+		classified.Payload = ""
+	}
+	a.handleIntent(ctx, classified)
+}
+
+// ── AI agent handlers ────────────────────────────────────────────
+
+func (a *Controller) askQuestion(ctx context.Context, question string) {
+	if a.agent == nil {
+		if !a.answerOffline(ctx, question) {
+			a.say(speech.LineAIDisabled(), speech.PriorityLow)
+		}
+		return
+	}
+
+	filler := speech.LineThinkingQuestion()
+	a.ui.PrintHint(filler)
+	if a.mouth != nil {
+		a.mouth.SayOn(speech.ChannelAI, filler, speech.PriorityCritical)
+	}
+
+	recipe, session := a.gatherContext(ctx)
+
+	a.unanswered = &aiRequest{what: "question: " + question, retry: func(ctx context.Context) {
+		a.askQuestion(ctx, question)
+	}}
+	callCtx, done := a.aiCall(ctx, "Thinking...")
+	answer, err := a.agent.AskQuestion(callCtx, question, recipe, session)
+	done()
+	if err != nil {
+		if a.aiCancelled("question", err) {
+			return
+		}
+		if gpt.IsUnavailable(err) && a.answerOffline(ctx, question) {
+			a.log.Error("AI question failed: %v", err)
+			return
+		}
+		a.aiFailed("question", err)
+		return
+	}
+	a.unanswered = nil
+
+	a.logAnswer(question, answer, "")
+	a.sayOn(speech.ChannelAI, answer, speech.PriorityHigh)
+}
+
+// howMuch answers "how much garlic?" from the recipe.  Anything the
+// recipe can't answer ("how much longer?") goes to askQuestion.
+func (a *Controller) howMuch(ctx context.Context, question, name string) {
+	recipe, _ := a.gatherContext(ctx)
+	if recipe == nil || name == "" {
+		a.askQuestion(ctx, question)
+		return
+	}
+	ings, err := a.engine.LookupIngredient(ctx, recipe.ID, name)
+	if err != nil {
+		a.log.Debug("how much %q: %v", name, err)
+		a.askQuestion(ctx, question)
+		return
+	}
+	amounts := make([]string, len(ings))
+	for i, ing := range ings {
+		amounts[i] = ing.Phrase()
+	}
+	a.say(speech.LineHowMuch(amounts), speech.PriorityHigh)
+}
+
+// answerOffline answers from the built-in notes (conversions,
+// substitutions, glossary, recipe text) when the agent isn't available.
+// Returns false when the notes don't cover the question.
+func (a *Controller) answerOffline(ctx context.Context, question string) bool {
+	recipe, _ := a.gatherContext(ctx)
+	var library []*domain.Recipe
+	if summaries, err := a.engine.ListRecipes(ctx); err == nil {
+		for _, s := range summaries {
+			if r, err := a.engine.GetRecipe(ctx, s.ID); err == nil {
+				library = append(library, r)
+			}
+		}
+	}
+	answer, ok := offline.Answer(question, recipe, library)
+	if !ok {
+		return false
+	}
+	a.log.Info("answered offline: %q", question)
+	a.say(speech.LineOfflineAnswer(answer), speech.PriorityHigh)
+	return true
+}
+
+func (a *Controller) modifyRequest(ctx context.Context, request string) {
+	if a.agent == nil {
+		a.say(speech.LineAIDisabled(), speech.PriorityLow)
+		return
+	}
+
+	filler := speech.LineThinkingModify()
+	a.ui.PrintHint(filler)
+	if a.mouth != nil {
+		a.mouth.SayOn(speech.ChannelAI, filler, speech.PriorityCritical)
+	}
+
+	recipe, session := a.gatherContext(ctx)
+	if recipe == nil {
+		a.say(speech.LinePickRecipeFirst(), speech.PriorityNormal)
+		return
+	}
+
+	// Snapshot ingredients + steps BEFORE mutation for diffing.
+	oldIngs := snapshotIngredients(recipe)
+	oldSteps := snapshotSteps(recipe)
+	oldServings := recipe.Servings
+
+	a.unanswered = &aiRequest{what: "modify: " + request, retry: func(ctx context.Context) {
+		a.modifyRequest(ctx, request)
+	}}
+	callCtx, done := a.aiCall(ctx, "Modifying...")
+	resp, err := a.agent.Modify(callCtx, request, recipe, session)
+	done()
+	if err != nil {
+		a.aiFailed("modify", err)
+		return
+	}
+	a.unanswered = nil
+
+	// No changes and a question back means the request was ambiguous
+	// ("which cheese?") — keep it open for the answer.
+	if len(resp.Actions) == 0 && strings.HasSuffix(strings.TrimSpace(resp.Summary), "?") {
+		question := resp.Summary
+		a.askClarification(question, func(ctx context.Context, answer string) {
+			a.modifyRequest(ctx, withClarification(request, question, answer))
+		})
+		return
+	}
+
+	// Anything unsafe is dropped before it gets near the recipe, and
+	// the summary no longer describes what's left.
+	kept, warnings := a.screenActions(ctx, recipe, session, resp.Actions)
+	if len(kept) < len(resp.Actions) {
+		if len(kept) == 0 {
+			return
+		}
+		resp = &gpt.ModifyResponse{Actions: kept, Summary: speech.LineSafetyRest(gpt.DescribeActions(kept))}
+	}
+
+	// Removing steps or ingredients can't be undone, so spell out what
+	// goes and wait for a yes.
+	apply := func(ctx context.Context) {
+		if removed := describeRemovals(recipe, resp.Actions); len(removed) > 0 {
+			a.ui.PrintHint(resp.Summary)
+			a.confirm(speech.LineConfirmRemoval(removed), "modify: remove "+strings.Join(removed, ", "), func(ctx context.Context) {
+				a.applyModification(ctx, recipe, resp, oldIngs, oldSteps, oldServings)
+			})
+			return
+		}
+		a.applyModification(ctx, recipe, resp, oldIngs, oldSteps, oldServings)
+	}
+	if len(warnings) > 0 {
+		a.ui.PrintHint(resp.Summary)
+		a.confirm(speech.LineSafetyWarning(warnings), "modify despite safety warning", apply)
+		return
+	}
+	apply(ctx)
+}
+
+// describeRemovals names what the remove_* actions would delete, e.g.
+// "step 3" or "garlic".  Empty when nothing is removed.
+func describeRemovals(r *domain.Recipe, actions []gpt.Action) []string {
+	var out []string
+	for _, act := range actions {
+		switch act.Type {
+		case gpt.ActionRemoveStep:
+			desc := fmt.Sprintf("step %d", act.StepIndex)
+			if act.StepIndex >= 1 && act.StepIndex <= len(r.Steps) {
+				desc += " (" + truncateStr(r.Steps[act.StepIndex-1].Instruction, 40) + ")"
+			}
+			out = append(out, desc)
+		case gpt.ActionRemoveIngredient:
+			out = append(out, act.IngredientName)
+		}
+	}
+	return out
+}
+
+// applyModification applies the AI's actions, persists the recipe, and
+// shows the diff against the pre-modification snapshots.
+func (a *Controller) applyModification(ctx context.Context, recipe *domain.Recipe, resp *gpt.ModifyResponse,
+	oldIngs []ingredientSnap, oldSteps []string, oldServings int) {
+	// If the AI returned actions, apply them to the recipe.
+	if len(resp.Actions) > 0 {
+		// Applied and persisted as a new version of the recipe.
+		err := a.engine.ReviseRecipe(ctx, recipe, gpt.DescribeActions(resp.Actions), func(r *domain.Recipe) error {
+			return gpt.ApplyActions(r, resp.Actions)
+		})
+		if err != nil {
+			a.log.Error("applying modifications failed: %v", err)
+			a.ui.PrintUrgent(fmt.Sprintf("Error applying changes: %v", err))
+			a.say(speech.LineAIError(), speech.PriorityNormal)
+			return
+		}
+
+		// Display recipe diff.
+		a.showRecipeDiff(recipe, oldIngs, oldSteps, oldServings)
+	}
+
+	// Speak the summary.
+	a.sayOn(speech.ChannelAI, resp.Summary, speech.PriorityHigh)
+
+	// Different amounts can mean different timings; have the steps
+	// reviewed.
+	if gpt.AffectsTiming(resp.Actions) {
+		a.replan(ctx, recipe, resp.Actions)
+	}
+}
+
+// replan asks the agent whether applied changes have knock-on effects on
+// the steps or timers, and offers its follow-up changes for a yes/no.
+func (a *Controller) replan(ctx context.Context, recipe *domain.Recipe, applied []gpt.Action) {
+	_, session := a.gatherContext(ctx)
+	callCtx, done := a.aiCall(ctx, "Checking the steps...")
+	resp, err := a.agent.Replan(callCtx, applied, recipe, session)
+	done()
+	if err != nil {
+		a.log.Error("AI replan failed: %v", err)
+		return
+	}
+	if len(resp.Actions) == 0 {
+		a.log.Debug("replan: steps unaffected")
+		return
+	}
+	var warnings []string
+	resp.Actions, warnings = a.screenActions(ctx, recipe, session, resp.Actions)
+	if len(resp.Actions) == 0 {
+		return
+	}
+
+	for _, act := range resp.Actions {
+		switch act.Type {
+		case gpt.ActionUpdateStep:
+			a.ui.PrintHint(fmt.Sprintf("step %d → %s", act.StepIndex, truncateStr(act.Instruction, 80)))
+		case gpt.ActionUpdateTimer:
+			a.ui.PrintHint(fmt.Sprintf("step %d timer → %s", act.StepIndex, formatDuration(act.ParsedTimerDuration())))
+		}
+	}
+	oldIngs, oldSteps, oldServings := snapshotIngredients(recipe), snapshotSteps(recipe), recipe.Servings
+	for _, w := range warnings {
+		a.ui.PrintUrgent(w)
+	}
+	a.confirm(speech.LineReplan(resp.Summary), "replan", func(ctx context.Context) {
+		a.applyModification(ctx, recipe, &gpt.ModifyResponse{Actions: resp.Actions, Summary: speech.LineReplanDone()},
+			oldIngs, oldSteps, oldServings)
+	})
+}
+
+// ── Recipe diff helpers ──────────────────────────────────────────
+
+type ingredientSnap struct {
+	Name           string
+	Quantity       float64
+	Unit           string
+	SizeDescriptor string
+	Optional       bool
+}
+
+func fmtIngredient(ing domain.Ingredient) string {
+	opt := ""
+	if ing.Optional {
+		opt = " (optional)"
+	}
+	if ing.Quantity > 0 {
+		if ing.SizeDescriptor != "" {
+			return fmt.Sprintf("%s %s %s%s", domain.FormatQuantity(ing.Quantity), ing.SizeDescriptor, ing.Name, opt)
+		}
+		return fmt.Sprintf("%s %s %s%s", domain.FormatQuantity(ing.Quantity), ing.Unit, ing.Name, opt)
+	}
+	return fmt.Sprintf("%s %s%s", ing.SizeDescriptor, ing.Name, opt)
+}
+
+func fmtIngSnap(s ingredientSnap) string {
+	opt := ""
+	if s.Optional {
+		opt = " (optional)"
+	}
+	if s.Quantity > 0 {
+		if s.SizeDescriptor != "" {
+			return fmt.Sprintf("%s %s %s%s", domain.FormatQuantity(s.Quantity), s.SizeDescriptor, s.Name, opt)
+		}
+		return fmt.Sprintf("%s %s %s%s", domain.FormatQuantity(s.Quantity), s.Unit, s.Name, opt)
+	}
+	return fmt.Sprintf("%s %s%s", s.SizeDescriptor, s.Name, opt)
+}
+
+func snapshotIngredients(r *domain.Recipe) []ingredientSnap {
+	out := make([]ingredientSnap, len(r.Ingredients))
+	for i, ing := range r.Ingredients {
+		out[i] = ingredientSnap{
+			Name:           ing.Name,
+			Quantity:       ing.Quantity,
+			Unit:           ing.Unit,
+			SizeDescriptor: ing.SizeDescriptor,
+			Optional:       ing.Optional,
+		}
+	}
+	return out
+}
+
+func snapshotSteps(r *domain.Recipe) []string {
+	out := make([]string, len(r.Steps))
+	for i, s := range r.Steps {
+		out[i] = s.Instruction
+	}
+	return out
+}
+
+func (a *Controller) showRecipeDiff(r *domain.Recipe, oldIngs []ingredientSnap, oldSteps []string, oldServings int) {
+	a.ui.PrintStep(fmt.Sprintf("=== %s (updated) ===", r.Name))
+
+	// ── Servings ──
+	if r.Servings != oldServings {
+		a.ui.PrintDiffChanged(fmt.Sprintf("Servings: %d -> %d", oldServings, r.Servings))
+	}
+
+	a.ui.Println("")
+	a.ui.PrintStep("Ingredients:")
+
+	// Build a map of old ingredients by lowercase name for lookup.
+	oldMap := make(map[string]ingredientSnap, len(oldIngs))
+	for _, s := range oldIngs {
+		oldMap[strings.ToLower(s.Name)] = s
+	}
+
+	// Track which old ingredients were matched (to find removals).
+	matched := make(map[string]bool)
+
+	for _, ing := range r.Ingredients {
+		key := strings.ToLower(ing.Name)
+		old, existed := oldMap[key]
+		line := fmtIngredient(ing)
+		if !existed {
+			// New ingredient.
+			a.ui.PrintDiffAdded(line)
+		} else {
+			matched[key] = true
+			oldLine := fmtIngSnap(old)
+			if line != oldLine {
+				a.ui.PrintDiffRemoved(oldLine)
+				a.ui.PrintDiffAdded(line)
+			} else {
+				a.ui.PrintDiffUnchanged(line)
+			}
+		}
+	}
+
+	// Show removed ingredients.
+	for _, s := range oldIngs {
+		if !matched[strings.ToLower(s.Name)] {
+			a.ui.PrintDiffRemoved(fmtIngSnap(s))
+		}
+	}
+
+	// ── Steps ──
+	if len(oldSteps) > 0 || len(r.Steps) > 0 {
+		a.ui.Println("")
+		a.ui.PrintStep("Steps:")
+		maxLen := len(oldSteps)
+		if len(r.Steps) > maxLen {
+			maxLen = len(r.Steps)
+		}
+		for i := 0; i < maxLen; i++ {
+			var oldInst, newInst string
+			if i < len(oldSteps) {
+				oldInst = oldSteps[i]
+			}
+			if i < len(r.Steps) {
+				newInst = r.Steps[i].Instruction
+			}
+
+			label := fmt.Sprintf("%d. ", i+1)
+			if newInst == "" && oldInst != "" {
+				// Step removed.
+				a.ui.PrintDiffRemoved(label + oldInst)
+			} else if oldInst == "" && newInst != "" {
+				// Step added.
+				a.ui.PrintDiffAdded(label + newInst)
+			} else if oldInst != newInst {
+				// Step changed.
+				a.ui.PrintDiffRemoved(label + oldInst)
+				a.ui.PrintDiffAdded(label + newInst)
+			} else {
+				a.ui.PrintDiffUnchanged(label + newInst)
+			}
+		}
+	}
+}
+
+// gatherContext loads the current recipe and session for AI context.
+func (a *Controller) gatherContext(ctx context.Context) (*domain.Recipe, *domain.Session) {
+	var recipe *domain.Recipe
+	var session *domain.Session
+
+	recipeID := a.selectedRecipe
+	if a.sessionID != "" {
+		if s, err := a.engine.Status(ctx, a.sessionID); err == nil {
+			session = s
+			recipeID = s.RecipeID
+		}
+	}
+	if recipeID != "" {
+		if r, err := a.engine.GetRecipe(ctx, recipeID); err == nil {
+			recipe = r
+		}
+	}
+	return recipe, session
+}
+
+func (a *Controller) showRecipes(ctx context.Context) {
+	recipes, err := a.engine.ListRecipes(ctx)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error loading recipes: %v", err))
+		return
+	}
+
+	a.showRecipeList("Available recipes:", recipes)
+	a.ui.PrintChat("Pick a recipe by number, or type 'help' for commands.")
+}
+
+// showRecipeList prints a numbered list and remembers it, so the next
+// "2" or "number two" picks from what the user is looking at.
+func (a *Controller) showRecipeList(title string, recipes []domain.RecipeSummary) {
+	a.listed = recipes
+	a.ui.PrintStep(title)
+	a.ui.Println("")
+	for i, r := range recipes {
+		a.ui.PrintInstructionAction(fmt.Sprintf("[%d] %s", i+1, r.Name), "select "+r.ID)
+		a.ui.PrintHint(r.Description)
+		if meta := recipeMeta(r.PrepTime, r.CookTime, r.Difficulty); meta != "" {
+			a.ui.PrintHint(meta)
+		}
+		if len(r.Tags) > 0 {
+			a.ui.PrintHint("Tags: " + strings.Join(r.Tags, ", "))
+		}
+		a.ui.Println("")
+	}
+}
+
+// searchRecipes lists the recipes matching query, numbered for picking.
+func (a *Controller) searchRecipes(ctx context.Context, query string) {
+	if query == "" {
+		a.showRecipes(ctx)
+		return
+	}
+	results, err := a.engine.SearchRecipes(ctx, query)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error searching recipes: %v", err))
+		return
+	}
+	if len(results) == 0 {
+		a.say(speech.LineNoSearchResults(query), speech.PriorityNormal)
+		return
+	}
+
+	a.showRecipeList(fmt.Sprintf("Recipes matching %q:", query), results)
+	names := make([]string, len(results))
+	for i, r := range results {
+		names[i] = r.Name
+	}
+	a.say(speech.LineSearchResults(names), speech.PriorityNormal)
+}
+
+// showFiltered lists the recipes with a tag or in a collection.
+// "collections" on its own lists the collection names instead.
+func (a *Controller) showFiltered(ctx context.Context, label string) {
+	if strings.EqualFold(label, "collections") {
+		names, err := a.engine.Collections(ctx)
+		if err != nil {
+			a.ui.PrintUrgent(fmt.Sprintf("Error loading collections: %v", err))
+			return
+		}
+		for _, n := range names {
+			a.ui.PrintInstruction("  " + n)
+		}
+		a.say(speech.LineCollections(names), speech.PriorityNormal)
+		return
+	}
+
+	results, err := a.engine.FilterRecipes(ctx, label)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error loading recipes: %v", err))
+		return
+	}
+	if len(results) == 0 {
+		a.say(speech.LineNoLabelMatches(label), speech.PriorityNormal)
+		return
+	}
+	a.showRecipeList(fmt.Sprintf("Recipes tagged or collected as %q:", label), results)
+	a.ui.PrintChat("Pick a recipe by number.")
+}
+
+// labelTarget is the recipe tag and collection commands apply to: the
+// one being cooked, else the one selected.
+func (a *Controller) labelTarget(ctx context.Context) *domain.Recipe {
+	recipe, _ := a.gatherContext(ctx)
+	if recipe == nil {
+		a.say(speech.LinePickRecipeFirst(), speech.PriorityNormal)
+	}
+	return recipe
+}
+
+func (a *Controller) tagRecipe(ctx context.Context, args domain.TagArgs) {
+	tag, remove := args.Tag, args.Remove
+	if tag == "" {
+		a.ui.PrintHint("Usage: tag <tag>, untag <tag>")
+		return
+	}
+	recipe := a.labelTarget(ctx)
+	if recipe == nil {
+		return
+	}
+	changed, err := a.engine.TagRecipe(ctx, recipe.ID, tag, remove)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	a.say(speech.LineTagged(recipe.Name, strings.ToLower(tag), remove, changed), speech.PriorityNormal)
+}
+
+func (a *Controller) collectRecipe(ctx context.Context, args domain.CollectArgs) {
+	name, remove := args.Collection, args.Remove
+	if name == "" {
+		a.ui.PrintHint("Usage: add this to <collection>, remove this from <collection>")
+		return
+	}
+	recipe := a.labelTarget(ctx)
+	if recipe == nil {
+		return
+	}
+	changed, err := a.engine.CollectRecipe(ctx, recipe.ID, name, remove)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	a.say(speech.LineCollected(recipe.Name, name, remove, changed), speech.PriorityNormal)
+}
+
+// duplicateRecipe saves a copy of the recipe being cooked or selected
+// and switches to it, so later modifications leave the original as is.
+func (a *Controller) duplicateRecipe(ctx context.Context, name string) {
+	recipe := a.labelTarget(ctx)
+	if recipe == nil {
+		return
+	}
+	variant, err := a.engine.DuplicateRecipe(ctx, recipe.ID, name)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	cooking := a.sessionID != ""
+	if cooking {
+		if _, err := a.engine.MoveSession(ctx, a.sessionID, variant.ID); err != nil {
+			a.log.Error("moving session to variant: %v", err)
+			cooking = false
+		}
+	}
+	a.selectedRecipe = variant.ID
+	a.say(speech.LineDuplicated(variant.Name, cooking), speech.PriorityNormal)
+}
+
+// addNote attaches a note to the current step, or with "keep my notes"
+// saves the session's notes into the recipe.
+func (a *Controller) addNote(ctx context.Context, args domain.NoteArgs) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+	text, keep := args.Text, args.Keep
+	if text == "" {
+		if !keep {
+			a.ui.PrintHint("Usage: note: <text>, note for next time: <text>, keep my notes")
+			return
+		}
+		n, err := a.engine.KeepNotes(ctx, a.sessionID)
+		if err != nil {
+			a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		a.say(speech.LineNotesKept(n), speech.PriorityNormal)
+		return
+	}
+	step, err := a.engine.AddNote(ctx, a.sessionID, text, keep)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	a.ui.PrintHint("note: " + text)
+	a.say(speech.LineNoted(step, keep), speech.PriorityNormal)
+}
+
+func (a *Controller) selectRecipe(ctx context.Context, args domain.SelectRecipeArgs, payload string) {
+	// A number picks from the list on screen; a name from the library.
+	recipes := a.listed
+	if len(recipes) == 0 || args.Index == 0 {
+		var err error
+		recipes, err = a.engine.ListRecipes(ctx)
+		if err != nil {
+			a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+			return
+		}
+	}
+
+	id := pickRecipe(recipes, args)
+	if id == "" {
+		a.say(speech.LineInvalidSelection(payload), speech.PriorityLow)
+		return
+	}
+	a.selectedRecipe = id
+	r, err := a.engine.GetRecipe(ctx, a.selectedRecipe)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	a.showRecipeDetail(r)
+
+	// Build ingredient list for speech.
+	ingNames := make([]string, len(r.Ingredients))
+	for i, ing := range r.Ingredients {
+		if ing.Quantity > 0 {
+			if ing.SizeDescriptor != "" {
+				ingNames[i] = fmt.Sprintf("%s %s %s", domain.FormatQuantity(ing.Quantity), ing.SizeDescriptor, ing.Name)
+			} else {
+				ingNames[i] = fmt.Sprintf("%s %s %s", domain.FormatQuantity(ing.Quantity), ing.Unit, ing.Name)
+			}
+		} else {
+			ingNames[i] = ing.Name
+		}
+	}
+	a.say(speech.LineRecipeSelected(r.Name, ingNames, r.AllEquipment()), speech.PriorityNormal)
+
+	// Prefetch audio for the likely next action: starting to cook.
+	if a.mouth != nil {
+		a.mouth.PrefetchGroup(ctx, "recipe", speech.LineCookingStart(r.Name))
+		a.prefetchStep(ctx, r.ID, 0) // step 1
+	}
+}
+
+// pickRecipe returns the ID of the recipe args picks from recipes, or
+// "" when it picks none.  A number is a position in the list; a name is
+// a recipe ID (what a click on a listed recipe sends, so it still picks
+// that recipe after the list has changed) or a recipe's name.
+func pickRecipe(recipes []domain.RecipeSummary, args domain.SelectRecipeArgs) string {
+	if args.Index > 0 {
+		if args.Index <= len(recipes) {
+			return recipes[args.Index-1].ID
+		}
+		return ""
+	}
+	if args.Name == "" {
+		return ""
+	}
+	for _, match := range []func(r domain.RecipeSummary) bool{
+		func(r domain.RecipeSummary) bool { return strings.EqualFold(r.ID, args.Name) },
+		func(r domain.RecipeSummary) bool { return strings.EqualFold(r.Name, args.Name) },
+	} {
+		for _, r := range recipes {
+			if match(r) {
+				return r.ID
+			}
+		}
+	}
+	return ""
+}
+
+func (a *Controller) showRecipeDetail(r *domain.Recipe) {
+	a.ui.PrintStep(fmt.Sprintf("=== %s ===", r.Name))
+	a.ui.PrintInstruction(r.Description)
+	a.ui.PrintHint(fmt.Sprintf("Servings: %d", r.Servings))
+	if meta := recipeMeta(r.PrepTime, r.CookTime, r.Difficulty); meta != "" {
+		a.ui.PrintHint(meta)
+	}
+	if r.ParentID != "" {
+		a.ui.PrintHint("Variant of " + r.ParentID)
+	}
+	if r.Version > 1 {
+		a.ui.PrintHint(fmt.Sprintf("Version %d — say \"versions\" for the history", r.Version))
+	}
+
+	a.ui.Println("")
+	a.ui.PrintStep("Ingredients:")
+	for _, ing := range r.Ingredients {
+		opt := ""
+		if ing.Optional {
+			opt = " (optional)"
+		}
+		var line string
+		if ing.Quantity > 0 {
+			if ing.SizeDescriptor != "" {
+				line = fmt.Sprintf("  - %s %s %s%s", domain.FormatQuantity(ing.Quantity), ing.SizeDescriptor, ing.Name, opt)
+			} else {
+				line = fmt.Sprintf("  - %s %s %s%s", domain.FormatQuantity(ing.Quantity), ing.Unit, ing.Name, opt)
+			}
+		} else {
+			line = fmt.Sprintf("  - %s %s%s", ing.SizeDescriptor, ing.Name, opt)
+		}
+		a.ui.PrintInstruction(line)
+	}
+	if kit := r.AllEquipment(); len(kit) > 0 {
+		a.ui.Println("")
+		a.ui.PrintStep("Equipment:")
+		for _, item := range kit {
+			a.ui.PrintInstruction("  - " + item)
+		}
+	}
+	a.ui.PrintHint(fmt.Sprintf("Steps: %d", len(r.Steps)))
+	drink := offline.PairingFor(r)
+	a.ui.PrintHint(fmt.Sprintf("To drink: %s, or %s", drink.Wine, drink.Soft))
+
+	var optional []string
+	for _, st := range r.Steps {
+		if st.Optional && st.Section != "" && !slices.Contains(optional, st.Section) {
+			optional = append(optional, st.Section)
+		}
+	}
+	for _, sec := range optional {
+		a.ui.PrintHint(fmt.Sprintf("Optional: %s — say \"skip the %s\" to leave it out", sec, strings.ToLower(sec)))
+	}
+}
+
+func (a *Controller) startCooking(ctx context.Context) {
+	if a.selectedRecipe == "" {
+		a.say(speech.LinePickRecipeFirst(), speech.PriorityNormal)
+		return
+	}
+
+	if a.sessionID != "" {
+		a.say(speech.LineAlreadyActive(), speech.PriorityNormal)
+		return
+	}
+
+	// Check the equipment once per recipe; saying start again (or yes)
+	// goes ahead.
+	if a.kitChecked != a.selectedRecipe {
+		a.kitChecked = a.selectedRecipe
+		r, err := a.engine.GetRecipe(ctx, a.selectedRecipe)
+		if err != nil {
+			a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		if kit := r.AllEquipment(); len(kit) > 0 {
+			for _, item := range kit {
+				a.ui.PrintInstruction("  [ ] " + item)
+			}
+			a.confirm(speech.LineEquipmentCheck(kit), "start "+r.Name, a.beginCooking)
+			a.pending.declined = speech.LineEquipmentMissing()
+			return
+		}
+	}
+	a.beginCooking(ctx)
+}
+
+// beginCooking starts a session on the selected recipe.
+func (a *Controller) beginCooking(ctx context.Context) {
+	session, err := a.engine.StartSession(ctx, a.selectedRecipe, 0)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error starting session: %v", err))
+		return
+	}
+
+	a.sessionID = session.ID
+	if !a.serveAt.IsZero() {
+		if err := a.engine.SetServeTime(ctx, session.ID, a.serveAt); err != nil {
+			a.log.Error("set serve time: %v", err)
+		}
+		a.serveAt = time.Time{}
+	}
+	a.say(speech.LineCookingStart(session.RecipeName), speech.PriorityNormal)
+	a.showCurrentStep(ctx)
+
+	// Prefetch step 2 while the user works on step 1.
+	a.prefetchStep(ctx, a.selectedRecipe, 1)
+}
+
+func (a *Controller) showCurrentStep(ctx context.Context) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+
+	step, state, err := a.engine.CurrentStep(ctx, a.sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoMoreSteps) {
+			a.say(speech.LineSessionDone(), speech.PriorityNormal)
+			a.sessionFinished(ctx)
+			return
+		}
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	session, _ := a.engine.Status(ctx, a.sessionID)
+	total := len(session.StepStates)
+
+	// Print visual step header.
+	header := fmt.Sprintf("Step %d/%d", step.Order, total)
+	if step.Section != "" {
+		header += " · " + step.Section
+	}
+	if step.Optional {
+		header += " (optional)"
+	}
+	if step.Duration > 0 {
+		header += fmt.Sprintf(" (~%s)", formatDuration(step.Duration))
+	}
+	a.ui.PrintStep(header)
+	a.ui.PrintInstruction(step.Instruction)
+
+	if recipe, err := a.engine.GetRecipe(ctx, session.RecipeID); err == nil {
+		var uses []string
+		for _, ing := range recipe.StepIngredients(session.CurrentStepIndex) {
+			uses = append(uses, ing.Phrase())
+		}
+		if len(uses) > 0 {
+			a.ui.PrintHint("uses: " + strings.Join(uses, "; "))
+		}
+	}
+
+	if len(step.Conditions) > 0 {
+		for i, c := range step.Conditions {
+			if state.IsMet(i) {
+				a.ui.PrintHint("✓ " + c.Description)
+				continue
+			}
+			a.ui.PrintHint("→ " + c.Description)
+		}
+	}
+
+	if len(step.ParallelHints) > 0 {
+		for _, hint := range step.ParallelHints {
+			a.ui.PrintHint(hintHelper(session, hint) + ": " + hint)
+		}
+	}
+
+	for _, t := range offline.StepSafety(step.Instruction) {
+		a.ui.PrintHint("safe at: " + t.String())
+	}
+
+	for _, note := range step.Notes {
+		a.ui.PrintHint("your note: " + note)
+	}
+
+	if len(step.Equipment) > 0 {
+		a.ui.PrintHint("need: " + strings.Join(step.Equipment, ", "))
+	}
+
+	if step.Wait > 0 {
+		a.ui.PrintHintAction(fmt.Sprintf("Hands-off wait: %s — say \"ready\" once it's going, and you can close Otto until it's done", formatDuration(step.Wait)), "ready")
+	}
+
+	if step.TimerConfig != nil {
+		// Check whether timer is pending (not yet started by user).
+		pending, _ := a.engine.HasPendingTimers(ctx, a.sessionID)
+		if pending {
+			a.ui.PrintHint(fmt.Sprintf("Timer ready: %s / %s — starts automatically on 'next'", step.TimerConfig.Label, formatDuration(step.TimerConfig.Duration)))
+		} else {
+			a.ui.PrintHint(fmt.Sprintf("Timer: %s / %s", step.TimerConfig.Label, formatDuration(step.TimerConfig.Duration)))
+		}
+	}
+
+	// Speak the step.
+	if a.mouth != nil {
+		var conditions []string
+		for _, c := range step.Conditions {
+			conditions = append(conditions, c.Description)
+		}
+		tLabel := ""
+		var tDur time.Duration
+		if step.TimerConfig != nil {
+			tLabel = step.TimerConfig.Label
+			tDur = step.TimerConfig.Duration
+		}
+		// Whatever the watcher was about to say is about the old step.
+		a.mouth.InterruptChannel(speech.ChannelWatcher)
+		a.mouth.SayOn(speech.ChannelSteps, speech.LineStep(step.Order, total, step.Instruction, conditions, step.ParallelHints, step.Notes, tLabel, tDur), speech.PriorityNormal)
+
+		// Prefetch the next step while this one plays.
+		a.prefetchStep(ctx, session.RecipeID, session.CurrentStepIndex+1)
+	}
+
+	// ── Next-step preview + parallel guidance ────────────────────
+	nextStep, _ := a.engine.NextStep(ctx, a.sessionID)
+	if nextStep != nil {
+		a.ui.PrintHintAction("▸ Next: "+truncateStr(nextStep.Instruction, 80), "next")
+
+		// If current step has a timer, tell the user they can move on
+		// (the timer auto-starts when they advance).
+		if step.TimerConfig != nil {
+			if nextStep.TimerConfig == nil || nextStep.ID != step.ID {
+				guidance := speech.LineCanContinue(step.TimerConfig.Label)
+				a.ui.PrintChat(guidance)
+				if a.mouth != nil {
+					a.mouth.SayOn(speech.ChannelSteps, guidance, speech.PriorityLow)
+				}
+			}
+		}
+	}
+
+	_ = state // available for future display of step timing stats
+}
+
+func (a *Controller) advance(ctx context.Context) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+
+	_, err := a.engine.Advance(ctx, a.sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoMoreSteps) {
+			a.say(speech.LineLastStepDone(), speech.PriorityNormal)
+			a.sessionFinished(ctx)
+			return
+		}
+		if errors.Is(err, domain.ErrSessionNotActive) {
+			// "next" during a hands-off wait means it's ready early.
+			if s, _ := a.engine.Status(ctx, a.sessionID); s != nil && s.Status == domain.SessionWaiting {
+				if err := a.engine.EndWait(ctx, a.sessionID); err == nil {
+					a.advance(ctx)
+					return
+				}
+			}
+			a.say(speech.LineIsPaused(), speech.PriorityNormal)
+			return
+		}
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	a.showCurrentStep(ctx)
+}
+
+// skip skips the current step, or with a target (see
+// conversation.skipTarget) the rest of the section ("section"), ahead to
+// a section ("to sauce") or a step ("step 5"), or an optional section
+// still to come ("garnish").
+func (a *Controller) skip(ctx context.Context, target string) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+
+	var err error
+	line := speech.LineSkipped()
+	switch {
+	case target == "":
+		_, err = a.engine.Skip(ctx, a.sessionID)
+	case target == "section":
+		_, err = a.engine.SkipSection(ctx, a.sessionID)
+		line = speech.LineSkippedSection()
+	case strings.HasPrefix(target, "to "):
+		section := strings.TrimPrefix(target, "to ")
+		_, err = a.engine.SkipTo(ctx, a.sessionID, section)
+		line = speech.LineSkippedTo(section)
+	case strings.HasPrefix(target, "step "):
+		n, _ := strconv.Atoi(strings.TrimPrefix(target, "step "))
+		_, err = a.engine.SkipToStep(ctx, a.sessionID, n)
+		line = speech.LineSkippedToStep(n)
+	default:
+		if _, err := a.engine.DeclineSection(ctx, a.sessionID, target); err != nil {
+			if errors.Is(err, domain.ErrNoSuchSection) {
+				a.say(speech.LineNoSuchSection(target), speech.PriorityNormal)
+				return
+			}
+			a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		a.say(speech.LineDeclinedSection(target), speech.PriorityNormal)
+		return
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNoSuchSection) {
+			a.say(speech.LineNoSuchSection(strings.TrimPrefix(target, "to ")), speech.PriorityNormal)
+			return
+		}
+		if errors.Is(err, domain.ErrNoSuchStep) {
+			a.say(speech.LineNoSuchStep(strings.TrimPrefix(target, "step ")), speech.PriorityNormal)
+			return
+		}
+		if errors.Is(err, domain.ErrNoMoreSteps) {
+			a.say(speech.LineSkippedLastStep(), speech.PriorityNormal)
+			a.sessionFinished(ctx)
+			return
+		}
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	a.say(line, speech.PriorityLow)
+	a.showCurrentStep(ctx)
+}
+
+func (a *Controller) repeat(ctx context.Context) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+
+	a.showCurrentStep(ctx)
+}
+
+func (a *Controller) repeatLast(ctx context.Context) {
+	if a.mouth == nil {
+		a.say(speech.LineNothingToRepeat(), speech.PriorityLow)
+		return
+	}
+
+	last := a.mouth.LastSpoken()
+	if last == "" {
+		a.say(speech.LineNothingToRepeat(), speech.PriorityLow)
+		return
+	}
+
+	a.say(last, speech.PriorityNormal)
+}
+
+// resumeLast picks up whatever the user missed: the rest of an answer
+// the mouth was cut off in the middle of, or failing that, an AI
+// request that errored or was cancelled before it answered.
+func (a *Controller) resumeLast(ctx context.Context) {
+	if a.mouth != nil {
+		if text := a.mouth.TakeInterrupted(); text != "" {
+			a.say(text, speech.PriorityHigh)
+			return
+		}
+	}
+
+	if r := a.unanswered; r != nil {
+		a.unanswered = nil
+		a.log.Info("re-issuing dropped AI request: %s", r.what)
+		a.ui.PrintHint(speech.LineRetryingRequest())
+		r.retry(ctx)
+		return
+	}
+
+	a.say(speech.LineNothingToResume(), speech.PriorityLow)
+}
+
+// startTimer starts the current step's pending timer, or with all every
+// pending timer in the session.
+func (a *Controller) startTimer(ctx context.Context, all bool) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+
+	start := a.engine.StartPendingTimers
+	if all {
+		start = a.engine.StartAllPendingTimers
+	}
+	n, err := start(ctx, a.sessionID)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	if n == 0 {
+		// "ready" on a wait step starts the wait instead.
+		if step, _, _ := a.engine.CurrentStep(ctx, a.sessionID); step != nil && step.Wait > 0 {
+			a.startWait(ctx, step.Wait)
+			return
+		}
+		a.ui.PrintHint("No pending timers to start.")
+		return
+	}
+
+	a.say(fmt.Sprintf("Timer started! (%d)", n), speech.PriorityNormal)
+}
+
+// startWait puts the session into the current step's hands-off wait.
+func (a *Controller) startWait(ctx context.Context, d time.Duration) {
+	until, err := a.engine.StartWait(ctx, a.sessionID)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	a.say(speech.LineWaitStarted(d, until), speech.PriorityNormal)
+	a.refreshCalendar(ctx)
+}
+
+// welcomeBack picks up a session restored from a long wait, whether it
+// is still waiting or ended while Otto was closed.
+func (a *Controller) welcomeBack(ctx context.Context) {
+	session, err := a.engine.Status(ctx, a.sessionID)
+	if err != nil {
+		a.sessionID, a.selectedRecipe = "", ""
+		a.say(speech.LineWelcome(), speech.PriorityNormal)
+		a.showRecipes(ctx)
+		return
+	}
+	if session.Status == domain.SessionWaiting && time.Now().Before(session.WaitUntil) {
+		a.say(speech.LineWaitingStill(session.RecipeName, time.Until(session.WaitUntil)), speech.PriorityNormal)
+		return
+	}
+	a.say(speech.LineWelcomeBack(session.RecipeName), speech.PriorityNormal)
+	a.showCurrentStep(ctx)
+}
+
+func (a *Controller) dismissTimer(ctx context.Context, payload string) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+
+	active, err := a.engine.ActiveTimers(ctx, a.sessionID)
+	if err != nil || len(active) == 0 {
+		a.say(speech.LineNoActiveTimers(), speech.PriorityLow)
+		return
+	}
+
+	// If there's only one active timer, just dismiss it.
+	if len(active) == 1 {
+		if err := a.engine.DismissTimer(ctx, a.sessionID, active[0].ID); err != nil {
+			a.log.Error("dismiss timer: %v", err)
+			a.say(speech.LineTimerAck(), speech.PriorityNormal)
+			return
+		}
+		a.say(speech.LineTimerDismissed(active[0].Label), speech.PriorityNormal)
+		return
+	}
+
+	// A timer named outright ("dismiss 2", "dismiss simmer", or a
+	// click on the timer bar) is dismissed without asking the AI.
+	if ref := timerRef(payload); ref != "" {
+		t, err := a.engine.ResolveTimer(ctx, a.sessionID, ref)
+		switch {
+		case err == nil:
+			if err := a.engine.DismissTimer(ctx, a.sessionID, t.ID); err != nil {
+				a.log.Error("dismiss timer %s: %v", t.ID, err)
+			}
+			a.say(speech.LineTimerDismissed(t.Label), speech.PriorityNormal)
+			return
+		case errors.Is(err, domain.ErrNotFound) && isIndex(ref):
+			a.say(speech.LineNoSuchTimer(ref), speech.PriorityNormal)
+			return
+		}
+		a.log.Debug("resolving timer %q: %v", ref, err)
+	}
+
+	// Multiple timers — prioritise fired ones first.
+	// A plain "ok"/"dismiss" should dismiss whatever has fired,
+	// since that's obviously what the user is reacting to.
+	var fired []*domain.TimerState
+	for _, t := range active {
+		if t.Status == domain.TimerFired {
+			fired = append(fired, t)
+		}
+	}
+	if len(fired) > 0 {
+		for _, t := range fired {
+			if err := a.engine.DismissTimer(ctx, a.sessionID, t.ID); err != nil {
+				a.log.Error("dismiss timer %s: %v", t.ID, err)
+			}
+		}
+		if len(fired) == 1 {
+			a.say(speech.LineTimerDismissed(fired[0].Label), speech.PriorityNormal)
+		} else {
+			a.say(speech.LineTimerAck(), speech.PriorityNormal)
+		}
+		return
+	}
+
+	// No fired timers — multiple running. Ask AI which one(s) to dismiss.
+	if a.agent == nil {
+		// No AI: dismiss all.
+		for _, t := range active {
+			_ = a.engine.DismissTimer(ctx, a.sessionID, t.ID)
+		}
+		a.say(speech.LineTimerAck(), speech.PriorityNormal)
+		return
+	}
+
+	recipe, session := a.gatherContext(ctx)
+	callCtx, done := a.aiCall(ctx, "Finding the timer...")
+	resp, err := a.agent.DismissTimer(callCtx, payload, recipe, session)
+	done()
+	if a.aiCancelled("dismiss timer", err) {
+		return
+	}
+	if err != nil {
+		a.log.Error("AI dismiss timer failed: %v", err)
+		a.say(speech.LineTimerAck(), speech.PriorityNormal)
+		return
+	}
+
+	if len(resp.TimerIDs) == 0 {
+		// AI couldn't figure it out — ask its clarification question and
+		// send the answer back along with the original request.
+		question := resp.Summary
+		a.askClarification(question, func(ctx context.Context, answer string) {
+			a.dismissTimer(ctx, withClarification(payload, question, answer))
+		})
+		return
+	}
+
+	for _, tid := range resp.TimerIDs {
+		if err := a.engine.DismissTimer(ctx, a.sessionID, tid); err != nil {
+			a.log.Error("dismiss timer %s: %v", tid, err)
+		}
+	}
+	a.sayOn(speech.ChannelAI, resp.Summary, speech.PriorityNormal)
+}
+
+// timerRef returns what a dismiss command names ("dismiss 2" → "2",
+// "dismiss the simmer timer" → "the simmer timer"), or "" for a bare
+// "dismiss" / "ok".
+func timerRef(payload string) string {
+	ref, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(payload)), "dismiss ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(ref)
+}
+
+// controlTimer handles "pause all timers", "cancel the chicken timer",
+// "restart timer 2" and the like: one timer, or every one, without
+// touching the session.
+func (a *Controller) controlTimer(ctx context.Context, args domain.TimerArgs, payload string) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+	action, target := args.Action, args.Target
+	if action == "" {
+		a.say(speech.LineUnknown(payload), speech.PriorityLow)
+		return
+	}
+
+	active, err := a.engine.ActiveTimers(ctx, a.sessionID)
+	if err != nil {
+		a.log.Error("active timers: %v", err)
+		return
+	}
+	if len(active) == 0 {
+		a.say(speech.LineNoActiveTimers(), speech.PriorityLow)
+		return
+	}
+
+	// "pause the timer" with several going means all of them.
+	if target == "" && len(active) > 1 && (action == "pause" || action == "resume") {
+		target = "all"
+	}
+	if target == "all" {
+		var n int
+		var line func(int) string
+		switch action {
+		case "pause":
+			n, err = a.engine.PauseAllTimers(ctx, a.sessionID)
+			line = speech.LineTimersPaused
+		case "resume":
+			n, err = a.engine.ResumeAllTimers(ctx, a.sessionID)
+			line = speech.LineTimersResumed
+		default:
+			// Cancelling or restarting everything at once is almost
+			// always a mishearing; make them name one.
+			a.say(speech.LineWhichTimer(), speech.PriorityNormal)
+			return
+		}
+		if err != nil {
+			a.log.Error("%s all timers: %v", action, err)
+			a.say(speech.LineIsPaused(), speech.PriorityNormal)
+			return
+		}
+		a.say(line(n), speech.PriorityNormal)
+		return
+	}
+
+	t := active[0]
+	if target != "" {
+		t, err = a.engine.ResolveTimer(ctx, a.sessionID, target)
+		switch {
+		case errors.Is(err, domain.ErrNotFound) && isIndex(target):
+			a.say(speech.LineNoSuchTimer(target), speech.PriorityNormal)
+			return
+		case err != nil:
+			a.log.Debug("resolving timer %q: %v", target, err)
+			a.say(speech.LineWhichTimer(), speech.PriorityNormal)
+			return
+		}
+	} else if len(active) > 1 {
+		a.say(speech.LineWhichTimer(), speech.PriorityNormal)
+		return
+	}
+
+	status := t.Status
+	var line string
+	switch action {
+	case "pause":
+		err = a.engine.PauseTimer(ctx, a.sessionID, t.ID)
+		line = speech.LineTimerPaused(t.Label)
+	case "resume":
+		err = a.engine.ResumeTimer(ctx, a.sessionID, t.ID)
+		line = speech.LineTimerResumed(t.Label)
+	case "cancel":
+		err = a.engine.CancelTimer(ctx, a.sessionID, t.ID)
+		line = speech.LineTimerCancelled(t.Label)
+	case "restart":
+		err = a.engine.RestartTimer(ctx, a.sessionID, t.ID)
+		line = speech.LineTimerRestarted(t.Label, t.Duration)
+	}
+	if errors.Is(err, domain.ErrSessionNotActive) {
+		a.say(speech.LineIsPaused(), speech.PriorityNormal)
+		return
+	}
+	if err != nil {
+		a.log.Debug("%s timer %s: %v", action, t.ID, err)
+		a.say(speech.LineTimerCantDo(t.Label, status.String(), action), speech.PriorityNormal)
+		return
+	}
+	a.say(line, speech.PriorityNormal)
+}
+
+// isIndex reports whether ref is a timer number rather than a label.
+func isIndex(ref string) bool {
+	ref = strings.TrimPrefix(ref, "#")
+	return ref != "" && strings.Trim(ref, "0123456789") == ""
+}
+
+func (a *Controller) pause(ctx context.Context) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+
+	if err := a.engine.Pause(ctx, a.sessionID); err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	a.say(speech.LinePaused(), speech.PriorityNormal)
+}
+
+func (a *Controller) resume(ctx context.Context) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+	if s, _ := a.engine.Status(ctx, a.sessionID); s != nil && s.Status == domain.SessionWaiting {
+		if err := a.engine.EndWait(ctx, a.sessionID); err != nil {
+			a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		a.say(speech.LineResumed(), speech.PriorityNormal)
+		a.showCurrentStep(ctx)
+		return
+	}
+
+	_, err := a.engine.Resume(ctx, a.sessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionPaused) {
+			a.say(speech.LineNotPaused(), speech.PriorityLow)
+			return
+		}
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	a.say(speech.LineResumed(), speech.PriorityNormal)
+	a.showCurrentStep(ctx)
+}
+
+func (a *Controller) status(ctx context.Context) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+
+	session, err := a.engine.Status(ctx, a.sessionID)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	// Visual status dump (not spoken — too much data).
+	a.ui.PrintStep(fmt.Sprintf("Session: %s", session.ID[:8]))
+	a.ui.PrintInstruction(fmt.Sprintf("Recipe:  %s", session.RecipeName))
+	a.ui.PrintInstruction(fmt.Sprintf("Status:  %s", session.Status))
+	if session.Status == domain.SessionWaiting {
+		a.ui.PrintInstruction(fmt.Sprintf("Until:   %s (%s left)", session.WaitUntil.Format(time.Kitchen), formatDuration(time.Until(session.WaitUntil))))
+	}
+	a.ui.PrintInstruction(fmt.Sprintf("Step:    %d/%d", session.CurrentStepIndex+1, len(session.StepStates)))
+	a.ui.PrintHint(fmt.Sprintf("Started: %s ago", formatDuration(time.Since(session.StartedAt))))
+	var finish time.Time
+	if left, err := a.engine.Remaining(ctx, a.sessionID); err == nil && left > 0 {
+		finish = time.Now().Add(left)
+		a.ui.PrintHint(fmt.Sprintf("Left:    ~%s (done ~%s)", formatDuration(left), finish.Format(time.Kitchen)))
+	}
+	pace, err := a.engine.Pace(ctx, a.sessionID)
+	if err != nil {
+		a.log.Error("pace: %v", err)
+	}
+	switch pace = pace.Round(time.Minute); {
+	case pace >= time.Minute:
+		a.ui.PrintUrgent(fmt.Sprintf("Pace:    %s behind the recipe's estimates", formatDuration(pace)))
+	case pace <= -time.Minute:
+		a.ui.PrintHint(fmt.Sprintf("Pace:    %s ahead of the recipe's estimates", formatDuration(-pace)))
+	default:
+		a.ui.PrintHint("Pace:    on the recipe's estimates")
+	}
+	if p := a.partner; p != nil {
+		a.ui.PrintHint(fmt.Sprintf("Partner: %s, step %d/%d (%s)", p.Name, p.Step, p.Total, p.Status))
+	}
+	if !session.ServeAt.IsZero() {
+		a.showSchedule(ctx, session.ServeAt)
+	}
+
+	// Numbered so "dismiss 2" can name one.
+	active, _ := a.engine.ActiveTimers(ctx, a.sessionID)
+	activeTimers := len(active)
+	for i, ts := range active {
+		if ts.Status == domain.TimerFired {
+			a.ui.PrintUrgent(fmt.Sprintf("%d. %s — DONE", i+1, ts.Label))
+		} else if ts.Status == domain.TimerPaused {
+			a.ui.PrintChat(fmt.Sprintf("%d. %s — paused, %s left", i+1, ts.Label, formatDuration(ts.Remaining)))
+		} else {
+			a.ui.PrintChat(fmt.Sprintf("%d. %s — %s remaining", i+1, ts.Label, formatDuration(ts.Remaining)))
+		}
+	}
+	if activeTimers == 0 {
+		a.ui.PrintHint("Timers:  none active")
+	}
+	for _, t := range session.OpenTasks("") {
+		a.ui.PrintChat(fmt.Sprintf("%s: %s — %s", t.Helper, t.Text, formatDuration(time.Since(t.Assigned))))
+	}
+
+	// Speak a concise summary.
+	if a.mouth != nil {
+		a.mouth.Say(speech.LineStatus(
+			session.CurrentStepIndex+1, len(session.StepStates),
+			session.RecipeName, activeTimers, pace, finish,
+		), speech.PriorityLow)
+	}
+}
+
+// setServeTime handles "dinner at 19:30".  Before cooking starts it says
+// when to start; during a session it sets the target the schedule and
+// the watcher's "you're falling behind" work from.
+func (a *Controller) setServeTime(ctx context.Context, payload string) {
+	if payload == "clear" {
+		a.serveAt = time.Time{}
+		if a.sessionID != "" {
+			if err := a.engine.SetServeTime(ctx, a.sessionID, time.Time{}); err != nil {
+				a.log.Error("clear serve time: %v", err)
+			}
+			a.refreshCalendar(ctx)
+		}
+		a.say(speech.LineServeTimeCleared(), speech.PriorityNormal)
+		return
+	}
+
+	at, ok := conversation.ParseClockTime(payload, time.Now())
+	if !ok {
+		a.say(speech.LineBadServeTime(payload), speech.PriorityNormal)
+		return
+	}
+
+	if a.sessionID == "" {
+		a.serveAt = at
+		if a.selectedRecipe == "" {
+			a.say(speech.LineServeTimeNoted(at), speech.PriorityNormal)
+			return
+		}
+		startBy, err := a.engine.StartBy(ctx, a.selectedRecipe, at)
+		if err != nil {
+			a.log.Error("start by: %v", err)
+			a.say(speech.LineServeTimeNoted(at), speech.PriorityNormal)
+			return
+		}
+		a.say(speech.LineServeStartBy(at, startBy), speech.PriorityNormal)
+		return
+	}
+
+	if err := a.engine.SetServeTime(ctx, a.sessionID, at); err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	left, _ := a.engine.Remaining(ctx, a.sessionID)
+	a.say(speech.LineServeTimeSet(at, time.Now().Add(left).Sub(at)), speech.PriorityNormal)
+	a.showSchedule(ctx, at)
+	a.refreshCalendar(ctx)
+}
+
+// showSchedule prints the serve time, whether the estimate is on track
+// for it, and when each timed step still to come should start.
+func (a *Controller) showSchedule(ctx context.Context, at time.Time) {
+	left, _ := a.engine.Remaining(ctx, a.sessionID)
+	line := fmt.Sprintf("Serve:   %s", at.Format(time.Kitchen))
+	if late := time.Now().Add(left).Sub(at); late >= time.Minute {
+		a.ui.PrintUrgent(fmt.Sprintf("%s (~%s behind)", line, formatDuration(late)))
+	} else {
+		a.ui.PrintHint(line + " (on track)")
+	}
+
+	plan, err := a.engine.Schedule(ctx, a.sessionID)
+	if err != nil {
+		a.log.Error("schedule: %v", err)
+		return
+	}
+	for _, p := range plan {
+		if p.Step.TimerConfig == nil && p.Step.Wait == 0 {
+			continue
+		}
+		a.ui.PrintHint(fmt.Sprintf("  Step %d by %s — %s", p.Step.Order, p.StartBy.Format(time.Kitchen), truncateStr(p.Step.Instruction, 50)))
+	}
+}
+
+// recipeVersions lists the recipe's versions, or with "restore N" or
+// "start N" brings back version N (and starts cooking it).
+func (a *Controller) recipeVersions(ctx context.Context, args domain.VersionArgs) {
+	recipe := a.labelTarget(ctx)
+	if recipe == nil {
+		return
+	}
+
+	action, n := args.Action, args.Version
+	if action == "" {
+		history, err := a.engine.RecipeVersions(ctx, recipe.ID)
+		if err != nil {
+			a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		a.ui.PrintStep(fmt.Sprintf("Versions of %s:", recipe.Name))
+		for _, v := range history {
+			line := fmt.Sprintf("  v%d", v.Version)
+			if !v.SavedAt.IsZero() {
+				line += " · " + v.SavedAt.Format("Jan 2 15:04")
+			}
+			line += " · " + v.Change
+			if v.Version == recipe.Version {
+				line += " (current)"
+			}
+			a.ui.PrintInstruction(line)
+		}
+		a.say(speech.LineVersions(len(history), recipe.Version), speech.PriorityNormal)
+		return
+	}
+
+	if a.sessionID != "" {
+		if s, err := a.engine.Status(ctx, a.sessionID); err == nil && s.RecipeID == recipe.ID {
+			a.say(speech.LineVersionWhileCooking(), speech.PriorityNormal)
+			return
+		}
+	}
+	if n != recipe.Version {
+		restored, err := a.engine.RestoreVersion(ctx, recipe.ID, n)
+		if errors.Is(err, domain.ErrNotFound) {
+			a.say(speech.LineNoSuchVersion(n), speech.PriorityNormal)
+			return
+		}
+		if err != nil {
+			a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+			return
+		}
+		a.say(speech.LineVersionRestored(n, restored.Version), speech.PriorityNormal)
+	} else if action != "start" {
+		a.say(speech.LineVersionCurrent(n), speech.PriorityNormal)
+	}
+
+	a.selectedRecipe = recipe.ID
+	if action == "start" {
+		a.startCooking(ctx)
+	}
+}
+
+// showPrepList prints the knife work as a checklist and reads it out.
+func (a *Controller) showPrepList(ctx context.Context) {
+	recipe := a.labelTarget(ctx)
+	if recipe == nil {
+		return
+	}
+	tasks, err := a.engine.PrepList(ctx, recipe.ID)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	var spoken []string
+	if len(tasks) > 0 {
+		a.ui.PrintStep("Prep first:")
+	}
+	for _, t := range tasks {
+		line := fmt.Sprintf("  [ ] %s — %s", t.Ingredient, t.Prep)
+		if t.Step > 0 {
+			line += fmt.Sprintf(" (step %d)", t.Step)
+		}
+		a.ui.PrintInstruction(line)
+		spoken = append(spoken, "the "+t.Ingredient+" "+t.Prep)
+	}
+	a.say(speech.LinePrepList(spoken), speech.PriorityNormal)
+}
+
+// quit exits, asking first if that would throw away a session in progress.
+func (a *Controller) quit(ctx context.Context) {
+	if a.sessionID != "" {
+		name := "this recipe"
+		if r, _ := a.gatherContext(ctx); r != nil {
+			name = r.Name
+		}
+		a.confirm(speech.LineConfirmQuit(name), "quit", a.abandonAndQuit)
+		return
+	}
+	a.abandonAndQuit(ctx)
+}
+
+func (a *Controller) abandonAndQuit(ctx context.Context) {
+	if a.sessionID != "" {
+		if err := a.engine.Abandon(ctx, a.sessionID); err != nil {
+			a.log.Error("abandoning session: %v", err)
+		}
+		a.say(speech.LineAbandoned(), speech.PriorityNormal)
+		a.retireCalendar()
+		a.sessionID = ""
+		a.selectedRecipe = ""
+	}
+	a.say(speech.LineBye(), speech.PriorityNormal)
+	// Brief pause so TTS can start the goodbye line.
+	time.Sleep(300 * time.Millisecond)
+	if a.statusUI != nil {
+		a.statusUI.Quit()
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		m := int(d.Minutes())
+		s := int(d.Seconds()) % 60
+		if s == 0 {
+			return fmt.Sprintf("%dm", m)
+		}
+		return fmt.Sprintf("%dm%ds", m, s)
+	}
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	return fmt.Sprintf("%dh%dm", h, m)
+}
+
+// recipeMeta is the "45m (prep 10m, cook 35m) · medium" line shown for
+// a recipe, or "" when it has no times or difficulty.
+func recipeMeta(prep, cook time.Duration, diff domain.Difficulty) string {
+	var parts []string
+	switch {
+	case prep > 0 && cook > 0:
+		parts = append(parts, fmt.Sprintf("%s (prep %s, cook %s)", formatDuration(prep+cook), formatDuration(prep), formatDuration(cook)))
+	case prep+cook > 0:
+		parts = append(parts, formatDuration(prep+cook))
+	}
+	if d := diff.String(); d != "" {
+		parts = append(parts, d)
+	}
+	return strings.Join(parts, " · ")
+}
+
+func truncateStr(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/hammamikhairi/ottocook/internal/cookalong"
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// StartCookAlong hosts or joins a cook-along.  Every session change is
+// broadcast to the partner; the partner's hello and progress come back
+// on the peer's goroutine and are posted to the input loop, which owns
+// the app's state.
+func (a *Controller) StartCookAlong(ctx context.Context, hostAddr, joinAddr, name string) error {
+	// The peer's reader goroutine asks for a snapshot when a partner
+	// connects, so keep the latest one somewhere it can read safely.
+	var latest atomic.Pointer[domain.Session]
+
+	opts := []cookalong.Option{
+		cookalong.WithSnapshot(func() *domain.Session {
+			s := latest.Load()
+			if s == nil || (s.Status != domain.SessionActive && s.Status != domain.SessionPaused) {
+				return nil
+			}
+			return s
+		}),
+		cookalong.WithOnJoin(func(partner string, session *domain.Session) {
+			a.post(func(ctx context.Context) { a.partnerJoined(ctx, partner, session) })
+		}),
+		cookalong.WithOnProgress(func(p cookalong.Progress) {
+			a.post(func(ctx context.Context) { a.partnerProgress(p) })
+		}),
+		cookalong.WithOnLeave(func(partner string) {
+			a.post(func(ctx context.Context) {
+				a.partner = nil
+				a.say(speech.LineCookAlongLeft(partner), speech.PriorityNormal)
+			})
+		}),
+	}
+
+	var err error
+	if joinAddr != "" {
+		a.peer, err = cookalong.Join(ctx, joinAddr, name, a.log, opts...)
+	} else {
+		a.peer, err = cookalong.Host(ctx, hostAddr, name, a.log, opts...)
+	}
+	if err != nil {
+		return err
+	}
+
+	a.engine.OnSessionChange(func(s *domain.Session) {
+		latest.Store(s)
+		a.peer.Send(cookalong.ProgressOf(name, s))
+	})
+	return nil
+}
+
+// post hands fn to the input loop.  Drops it if the loop is backed up
+// rather than blocking the network goroutine.
+func (a *Controller) post(fn func(ctx context.Context)) {
+	select {
+	case a.events <- fn:
+	default:
+		a.log.Error("event queue full, dropping cook-along update")
+	}
+}
+
+// partnerJoined greets a new partner and, if we aren't cooking yet but
+// they are, picks up their session so both start from the same step.
+func (a *Controller) partnerJoined(ctx context.Context, partner string, remote *domain.Session) {
+	if a.sessionID != "" || remote == nil {
+		a.say(speech.LineCookAlongJoined(partner), speech.PriorityNormal)
+		return
+	}
+	session, err := a.engine.ImportSession(ctx, remote)
+	if err != nil {
+		a.log.Error("cook-along: picking up %s's session: %v", partner, err)
+		a.say(speech.LineCookAlongJoined(partner), speech.PriorityNormal)
+		return
+	}
+	a.sessionID = session.ID
+	a.selectedRecipe = session.RecipeID
+	a.say(speech.LineCookAlongPickedUp(partner, session.RecipeName, session.CurrentStepIndex+1), speech.PriorityNormal)
+	a.showCurrentStep(ctx)
+}
+
+// partnerProgress announces the partner's step changes.
+func (a *Controller) partnerProgress(p cookalong.Progress) {
+	prev := a.partner
+	a.partner = &p
+	if prev != nil && prev.Step == p.Step && prev.Status == p.Status {
+		return
+	}
+	line := speech.LineCookAlongProgress(p.Name, p.Step, p.Total, p.Status)
+	a.ui.PrintHint("⇄ " + line)
+	if a.mouth != nil {
+		a.mouth.Say(line, speech.PriorityLow)
+	}
+}
@@ -0,0 +1,388 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/conversation"
+	"github.com/hammamikhairi/ottocook/internal/display"
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/engine"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/recipe"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+	"github.com/hammamikhairi/ottocook/internal/storage"
+	"github.com/hammamikhairi/ottocook/internal/testkit"
+	"github.com/hammamikhairi/ottocook/internal/timer"
+)
+
+// waitTimeout is how long the harness waits for a line to show up.
+const waitTimeout = 2 * time.Second
+
+// harness runs a Controller wired like main, but with the outside world
+// faked: typed lines go in through the UI, voice through a FakeEar, and
+// output lands on a Screen and a FakeTTS.  Timers run 600x fast.
+type harness struct {
+	t      *testing.T
+	app    *Controller
+	ui     *display.UI
+	screen *testkit.Screen
+	tts    *testkit.FakeTTS
+	ear    *testkit.FakeEar
+	agent  *testkit.FakeAgent
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	return newHarnessWith(t, nil)
+}
+
+// newHarnessWith is newHarness with setup run against the wired app
+// before it starts, e.g. to leave sessions from "last time".
+func newHarnessWith(t *testing.T, setup func(ctx context.Context, h *harness)) *harness {
+	t.Helper()
+	log := logger.New(logger.LevelOff, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	h := &harness{
+		t:      t,
+		screen: testkit.NewScreen(),
+		tts:    testkit.NewFakeTTS(),
+		ear:    testkit.NewFakeEar(),
+		agent:  testkit.NewFakeAgent(),
+	}
+
+	recipes := recipe.NewMemorySource(log)
+	store := storage.NewMemoryStore(log)
+	h.ui = display.NewUI(store)
+	h.ui.SetOutput(h.screen)
+
+	mouth := speech.NewMouth(h.tts, h.tts, log, speech.WithChunkSize(0))
+	mouth.Start(ctx)
+	text := conversation.NewCLINotifier(log, h.ui.Printf)
+	notifier := speech.NewSpeakingNotifier(text, mouth, log)
+
+	eng := engine.New(recipes, store, log)
+	supervisor := timer.New(store, speech.NewSpeakingNotifier(text, mouth, log, speech.OnChannel(speech.ChannelTimers)), log,
+		timer.WithTickInterval(10*time.Millisecond), timer.WithSpeed(600), timer.WithSessionLock(eng.SessionLock()))
+	supervisor.Start(ctx)
+	t.Cleanup(supervisor.Stop)
+
+	h.app = New(Config{
+		Engine:   eng,
+		Parser:   conversation.NewKeywordParser(log),
+		Notifier: notifier,
+		Mouth:    mouth,
+		Agent:    h.agent.Agent(log),
+		Ear:      h.ear,
+		Log:      log,
+		Chat:     h.ui,
+		Status:   h.ui,
+		Input:    h.ui,
+
+		MinConfidence: 0.6,
+	})
+	if setup != nil {
+		setup(ctx, h)
+	}
+	go h.app.Run(ctx)
+	return h
+}
+
+// typeLine enters a line as if typed at the prompt.
+func (h *harness) typeLine(line string) {
+	h.ui.Submit(line)
+}
+
+// expect fails the test unless a line containing substr is printed
+// after the last one expected.
+func (h *harness) expect(substr string) string {
+	h.t.Helper()
+	line, ok := h.screen.Expect(substr, waitTimeout)
+	if !ok {
+		h.t.Fatalf("never printed %q; screen:\n%s", substr, strings.Join(h.screen.Lines(), "\n"))
+	}
+	return line
+}
+
+// expectSpoken fails the test unless something containing substr is
+// spoken.
+func (h *harness) expectSpoken(substr string) {
+	h.t.Helper()
+	if _, ok := h.tts.WaitSpoken(substr, waitTimeout); !ok {
+		h.t.Fatalf("never said %q; spoken:\n%s", substr, strings.Join(h.tts.Spoken(), "\n"))
+	}
+}
+
+func TestCookingSessionEndToEnd(t *testing.T) {
+	h := newHarness(t)
+	h.expect("Chicken Alfredo")
+
+	h.typeLine("select 1")
+	h.expect("Equipment")
+	h.typeLine("start")
+	h.expect("large pot")
+	h.typeLine("yes")
+	h.expect("Step 1/8")
+	h.expect("Timer ready: Water boiling")
+	h.expectSpoken("Step 1")
+
+	// Moving on starts the 8-minute boil; at 600x it fires in under a
+	// second.
+	h.typeLine("next")
+	h.expect("Step 2/8")
+	h.expect("[Timer] Water boiling is up.")
+	h.expectSpoken("Water boiling is up")
+	h.typeLine("dismiss")
+	h.expect("dismissed")
+
+	for step := 3; step <= 8; step++ {
+		h.ear.Hear("next", 0.95)
+		h.expect(fmt.Sprintf("Step %d/8", step))
+	}
+	h.typeLine("next")
+	h.expect("That was the last step.")
+	h.expectSpoken("You're done")
+}
+
+func TestQuestionGoesToAgent(t *testing.T) {
+	h := newHarness(t)
+	h.expect("Chicken Alfredo")
+
+	h.agent.Reply(testkit.KindQuestion, "Use pecorino instead.")
+	h.typeLine("what can I use instead of parmesan?")
+	h.expect("Use pecorino instead.")
+
+	calls := h.agent.Calls()
+	if len(calls) != 1 || calls[0].Kind != testkit.KindQuestion || !strings.Contains(calls[0].Input, "parmesan") {
+		t.Fatalf("agent calls = %+v, want one question about parmesan", calls)
+	}
+}
+
+func TestUnfinishedSessionsOfferedAtStartup(t *testing.T) {
+	h := newHarnessWith(t, func(ctx context.Context, h *harness) {
+		eng := h.app.engine
+		stirFry, err := eng.StartSession(ctx, "vegetable-stir-fry", 2)
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+		alfredo, err := eng.StartSession(ctx, "chicken-alfredo", 2)
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+		eng.Advance(ctx, alfredo.ID)
+		eng.Advance(ctx, alfredo.ID)
+		if alfredo, err = eng.Status(ctx, alfredo.ID); err != nil {
+			t.Fatalf("status: %v", err)
+		}
+		h.app.Restore([]*domain.Session{stirFry}, []*domain.Session{alfredo, stirFry})
+	})
+
+	h.expect("Unfinished from last time:")
+	h.expect("[1] Chicken Alfredo — step 3/8")
+	h.expect("[2] Vegetable Stir Fry — step 1/")
+	h.expectSpoken("2 unfinished sessions")
+
+	// Nothing on offer runs until it's picked.
+	for _, u := range h.app.unfinished {
+		if s, _ := h.app.engine.Status(context.Background(), u.ID); s == nil || s.Status != domain.SessionPaused {
+			t.Errorf("%s wasn't held while on offer", u.RecipeName)
+		}
+	}
+
+	h.ear.Hear("abandon 2", 0.9)
+	h.expect("Throw away Vegetable Stir Fry and its progress?")
+	h.typeLine("no")
+	h.expect("keeping Vegetable Stir Fry")
+	h.typeLine("no") // a bare no is abandon, and still asks
+	h.expect("Throw away Chicken Alfredo and its progress?")
+	h.typeLine("no")
+	h.typeLine("abandon 2")
+	h.expect("Throw away Vegetable Stir Fry and its progress?")
+	h.typeLine("yes")
+	h.expect("Vegetable Stir Fry abandoned.")
+	h.expect("You didn't finish Chicken Alfredo last time. You were on step 3 of 8.")
+
+	h.typeLine("resume")
+	h.expect("back to Chicken Alfredo. Step 3 of 8.")
+	h.expect("Step 3/8")
+
+	if s, _ := h.app.engine.Status(context.Background(), h.app.sessionID); s == nil || s.Status != domain.SessionActive {
+		t.Errorf("the picked-up session wasn't resumed")
+	}
+
+	// Commands go to the picked-up session now.
+	h.typeLine("next")
+	h.expect("Step 4/8")
+}
+
+// Clicks send what they pick by ID, so a list that changed after it
+// was printed still picks the row that was clicked.
+func TestClicksPickByID(t *testing.T) {
+	var stirFry *domain.Session
+	h := newHarnessWith(t, func(ctx context.Context, h *harness) {
+		eng := h.app.engine
+		alfredo, err := eng.StartSession(ctx, "chicken-alfredo", 2)
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+		if stirFry, err = eng.StartSession(ctx, "vegetable-stir-fry", 2); err != nil {
+			t.Fatalf("start: %v", err)
+		}
+		h.app.Restore(nil, []*domain.Session{alfredo, stirFry})
+	})
+	h.expect("Unfinished from last time:")
+	h.typeLine("resume " + stirFry.ID)
+	h.expect("back to Vegetable Stir Fry")
+	if h.app.sessionID != stirFry.ID {
+		t.Errorf("resumed session %s, want %s", h.app.sessionID, stirFry.ID)
+	}
+
+	h.typeLine("search alfredo")
+	h.expect("[1] Chicken Alfredo")
+	h.typeLine("select vegetable-stir-fry") // a row of an earlier list
+	h.expect("Equipment")
+	if h.app.selectedRecipe != "vegetable-stir-fry" {
+		t.Errorf("selected %q, want vegetable-stir-fry", h.app.selectedRecipe)
+	}
+	h.typeLine("select Chicken Alfredo")
+	h.expect("Equipment")
+	if h.app.selectedRecipe != "chicken-alfredo" {
+		t.Errorf("selected %q, want chicken-alfredo", h.app.selectedRecipe)
+	}
+}
+
+func TestCalendarFollowsTheSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cook.ics")
+	h := newHarnessWith(t, func(ctx context.Context, h *harness) {
+		h.app.calendarPath = path
+	})
+	h.expect("Chicken Alfredo")
+
+	// event returns the serve event's lines, or "" when it's not there.
+	event := func() string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading calendar: %v", err)
+		}
+		for _, ev := range strings.Split(string(data), "BEGIN:VEVENT") {
+			if strings.Contains(ev, "-serve@ottocook") {
+				return ev
+			}
+		}
+		return ""
+	}
+
+	h.typeLine("select 1")
+	h.expect("Equipment")
+	h.typeLine("start")
+	h.expect("large pot")
+	h.typeLine("yes")
+	h.expect("Step 1/8")
+	h.typeLine("dinner at 11:59pm")
+	h.expectSpoken("eating at 11:59PM")
+	h.typeLine("add it to my calendar")
+	h.expect("to add it to your calendar")
+	if ev := event(); ev == "" || strings.Contains(ev, "STATUS:CANCELLED") {
+		t.Fatalf("serve event after export:\n%s", ev)
+	}
+
+	h.typeLine("clear the serve time")
+	h.expectSpoken("no serve time")
+	if ev := event(); !strings.Contains(ev, "STATUS:CANCELLED") {
+		t.Fatalf("serve event not cancelled after clearing it:\n%s", ev)
+	}
+
+	// The file is rewritten after the reply, so give it a moment.
+	h.typeLine("dinner at 11:59pm")
+	deadline := time.Now().Add(waitTimeout)
+	for ev := event(); ev == "" || strings.Contains(ev, "STATUS:CANCELLED"); ev = event() {
+		if time.Now().After(deadline) {
+			t.Fatalf("serve event not back after setting it again:\n%s", ev)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	h.typeLine("quit")
+	h.expect("Quit and lose your progress?")
+	h.typeLine("yes")
+	h.expectSpoken("Bye.")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading calendar: %v", err)
+	}
+	if events, cancelled := strings.Count(string(data), "BEGIN:VEVENT"), strings.Count(string(data), "STATUS:CANCELLED"); events == 0 || cancelled != events {
+		t.Fatalf("%d of %d events cancelled after abandoning:\n%s", cancelled, events, data)
+	}
+}
+
+func TestShowTimeline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timeline.html")
+	h := newHarnessWith(t, func(ctx context.Context, h *harness) {
+		h.app.timelinePath = path
+	})
+	h.expect("Chicken Alfredo")
+
+	h.typeLine("show the timeline")
+	h.expectSpoken(speech.LineNoTimeline())
+
+	h.typeLine("select 1")
+	h.expect("Equipment")
+	h.typeLine("start")
+	h.expect("large pot")
+	h.typeLine("yes")
+	h.expect("Step 1/8")
+	h.typeLine("show the timeline")
+	h.expect("Open " + path)
+	if data, err := os.ReadFile(path); err != nil || !strings.Contains(string(data), "Chicken Alfredo") {
+		t.Errorf("timeline = %.80q, %v; want the session drawn", data, err)
+	}
+}
+
+func TestClassifiedHelp(t *testing.T) {
+	h := newHarness(t)
+	h.expect("Chicken Alfredo")
+
+	h.agent.Reply(testkit.KindClassify, `{"intent":"help","payload":"timers"}`)
+	h.typeLine("gimme the lowdown on timers")
+	h.expect("Try saying:")
+
+	h.agent.Reply(testkit.KindClassify, `{"intent":"help","payload":"I am totally lost here"}`)
+	h.typeLine("I am totally lost here")
+	h.expect("Commands:")
+	if lines := h.screen.Lines(); slices.ContainsFunc(lines, func(l string) bool { return strings.Contains(l, "No help for") }) {
+		t.Errorf("the whole question was taken as a help topic:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestAbandonAsksFirst(t *testing.T) {
+	h := newHarness(t)
+	h.expect("Chicken Alfredo")
+	h.typeLine("select 1")
+	h.typeLine("start")
+	h.typeLine("yes")
+	h.expect("Step 1/8")
+
+	h.typeLine("abandon")
+	h.expect("Quit and lose your progress?")
+	h.typeLine("no")
+	h.expect(speech.LineConfirmCancelled())
+	h.typeLine("next")
+	h.expect("Step 2/8")
+
+	// Heard unsure, it's still asked once, the same way.
+	h.ear.Hear("abandon this recipe", 0.4)
+	h.expect("Quit and lose your progress?")
+	if spoken := strings.Join(h.tts.Spoken(), "\n"); strings.Contains(spoken, "Did you say") {
+		t.Errorf("asked twice:\n%s", spoken)
+	}
+	h.typeLine("yes")
+	h.expect(speech.LineAbandoned())
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/gpt"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Answer feedback ──────────────────────────────────────────────
+//
+// "Good answer" and "that's wrong" rate the last AI answer in the answer
+// log (-ai-log).  "That's wrong" also asks again straight away under a
+// stricter prompt; a retry that's rejected too isn't tried a third time.
+
+// feedbackTTL is how long after an answer it can still be rated.
+const feedbackTTL = 5 * time.Minute
+
+// aiAnswer is the last thing the agent answered.
+type aiAnswer struct {
+	id       string
+	question string
+	answer   string
+	retry    bool // the answer to an "ask again"
+	at       time.Time
+}
+
+// logAnswer records an answer in the answer log and makes it the one
+// feedback applies to.
+func (a *Controller) logAnswer(question, answer, retryOf string) {
+	id, err := a.answerLog.Answer("question", question, answer, retryOf)
+	if err != nil {
+		a.log.Error("logging AI answer: %v", err)
+	}
+	a.lastAnswer = &aiAnswer{id: id, question: question, answer: answer, retry: retryOf != "", at: time.Now()}
+}
+
+// rateAnswer handles "good answer" and "that's wrong".
+func (a *Controller) rateAnswer(ctx context.Context, rating string) {
+	last := a.lastAnswer
+	if last == nil || time.Since(last.at) > feedbackTTL {
+		a.say(speech.LineNoAnswerToRate(), speech.PriorityNormal)
+		return
+	}
+	if err := a.answerLog.Feedback(last.id, rating); err != nil {
+		a.log.Error("logging answer feedback: %v", err)
+	}
+	a.log.Info("answer %s rated %s", last.id, rating)
+
+	if rating == gpt.RatingUp {
+		a.lastAnswer = nil
+		a.say(speech.LineAnswerThanks(), speech.PriorityLow)
+		return
+	}
+	if last.retry || a.agent == nil {
+		a.lastAnswer = nil
+		a.say(speech.LineAnswerGiveUp(), speech.PriorityNormal)
+		return
+	}
+	a.askAgain(ctx, last)
+}
+
+// askAgain re-asks a rejected question under the stricter prompt.
+func (a *Controller) askAgain(ctx context.Context, rejected *aiAnswer) {
+	filler := speech.LineThinkingAgain()
+	a.ui.PrintHint(filler)
+	if a.mouth != nil {
+		a.mouth.SayOn(speech.ChannelAI, filler, speech.PriorityCritical)
+	}
+
+	recipe, session := a.gatherContext(ctx)
+	a.unanswered = &aiRequest{what: "retry: " + rejected.question, retry: func(ctx context.Context) {
+		a.askAgain(ctx, rejected)
+	}}
+	callCtx, done := a.aiCall(ctx, "Thinking again...")
+	answer, err := a.agent.AskAgain(callCtx, rejected.question, rejected.answer, recipe, session)
+	done()
+	if err != nil {
+		a.aiFailed("retry", err)
+		return
+	}
+	a.unanswered = nil
+
+	a.logAnswer(rejected.question, answer, rejected.id)
+	a.sayOn(speech.ChannelAI, answer, speech.PriorityHigh)
+}
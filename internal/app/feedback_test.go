@@ -0,0 +1,75 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hammamikhairi/ottocook/internal/gpt"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+	"github.com/hammamikhairi/ottocook/internal/testkit"
+)
+
+func TestRateAnswer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ai.jsonl")
+	h := newHarnessWith(t, func(ctx context.Context, h *harness) {
+		h.app.answerLog = gpt.NewAnswerLog(path)
+	})
+	h.expect("Chicken Alfredo")
+
+	h.typeLine("good answer")
+	h.expectSpoken(speech.LineNoAnswerToRate())
+
+	h.agent.Reply(testkit.KindQuestion, "Use pecorino instead.")
+	h.typeLine("what can I use instead of parmesan?")
+	h.expect("Use pecorino instead.")
+	h.agent.Reply(testkit.KindQuestion, "Grana padano is closer.")
+	h.typeLine("that's wrong")
+	h.expect("Grana padano is closer.")
+	h.typeLine("that's wrong")
+	h.expectSpoken(speech.LineAnswerGiveUp())
+
+	h.agent.Reply(testkit.KindQuestion, "Twenty minutes.")
+	h.typeLine("how long does the sauce keep warm?")
+	h.expect("Twenty minutes.")
+	h.typeLine("good answer")
+	h.expectSpoken(speech.LineAnswerThanks())
+
+	var events []gpt.AnswerEvent
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening answer log: %v", err)
+	}
+	defer f.Close()
+	for sc := bufio.NewScanner(f); sc.Scan(); {
+		var ev gpt.AnswerEvent
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			t.Fatalf("line %q: %v", sc.Text(), err)
+		}
+		events = append(events, ev)
+	}
+
+	type line struct{ event, answer, rating string }
+	want := []line{
+		{"answer", "Use pecorino instead.", ""},
+		{"feedback", "", gpt.RatingDown},
+		{"answer", "Grana padano is closer.", ""},
+		{"feedback", "", gpt.RatingDown},
+		{"answer", "Twenty minutes.", ""},
+		{"feedback", "", gpt.RatingUp},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("logged %+v, want %d events", events, len(want))
+	}
+	for i, ev := range events {
+		if got := (line{ev.Event, ev.Answer, ev.Rating}); got != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+	if events[2].RetryOf != events[0].ID || events[3].ID != events[2].ID {
+		t.Errorf("the retry should point back at the rejected answer, and be what was rated next: %+v", events)
+	}
+}
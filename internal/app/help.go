@@ -0,0 +1,384 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// helpTopic is one command in the help listing.  "help <name>" prints
+// its detail and the phrasings voice input understands.
+type helpTopic struct {
+	name    string   // what "help <name>" looks for; also the click target
+	aliases []string // other words that find this topic
+	usage   string   // left column of the listing
+	summary string   // right column of the listing
+	detail  string
+	voice   []string // example phrasings
+	ai      bool     // needs the AI agent
+}
+
+var helpTopics = []helpTopic{
+	{
+		name: "list", aliases: []string{"recipes", "show", "browse"},
+		usage: "list / recipes", summary: "Show available recipes",
+		detail: "Lists every recipe with a number to pick it by, with its prep and cook time and difficulty. Add a tag or collection name to narrow it down, or \"quick\", \"easy\", or \"under 30 minutes\"; \"list collections\" names your collections.",
+		voice:  []string{"list", "show my weeknight favorites", "list vegan recipes", "show me quick recipes"},
+	},
+	{
+		name: "select", aliases: []string{"pick", "choose", "number", "1"},
+		usage: "1, 2, 3...", summary: "Select a recipe by number",
+		detail: "Picks a recipe from the last list shown and prints its ingredients and steps. You can also pick by name.",
+		voice:  []string{"two", "number 3", "the first one", "pick pasta"},
+	},
+	{
+		name: "find", aliases: []string{"search", "look for"},
+		usage: "find ...", summary: "Search recipes (e.g. \"find me something with broccoli\")",
+		detail: "Searches recipe names, tags, and ingredients. \"quick\", \"easy\", \"medium\", \"hard\", and \"under N minutes\" go by the recipe's time and difficulty instead.",
+		voice:  []string{"find me something with broccoli", "anything with leeks?", "recipes using rice and beans"},
+	},
+	{
+		name: "tag", aliases: []string{"untag", "tags"},
+		usage: "tag / untag ...", summary: "Tag the selected recipe (e.g. \"tag this as quick\")",
+		detail: "Adds a tag to the selected recipe, or removes one with \"untag\". \"list <tag>\" shows everything tagged with it.",
+		voice:  []string{"tag this as quick", "untag quick"},
+	},
+	{
+		name: "collection", aliases: []string{"collect", "add", "collections"},
+		usage: "add this to ...", summary: "Add the selected recipe to a collection (\"remove this from ...\" undoes it)",
+		detail: "Files the selected recipe under a named collection, creating it if needed.",
+		voice:  []string{"add this to weeknight favorites", "remove it from the date night collection"},
+	},
+	{
+		name: "duplicate", aliases: []string{"copy", "fork", "variant"},
+		usage: "duplicate as ...", summary: "Save a copy of the selected recipe as your own variant",
+		detail: "Copies the selected recipe under a new name so changes don't touch the original. If you're cooking, the session moves to the copy.",
+		voice:  []string{"duplicate this as Mom's version", "save it as spicy", "make a copy of this recipe"},
+	},
+	{
+		name: "start", aliases: []string{"cook", "go", "begin"},
+		usage: "start / go", summary: "Start cooking the selected recipe",
+		detail: "Starts a session on the selected recipe at step 1. Mid-cook, with a step timer waiting on you, \"start\" starts the timer instead. If the recipe needs equipment, you get a checklist first: say yes (or start again) to go, or tell me what's missing and the steps are reworked without it.",
+		voice:  []string{"start", "let's go"},
+	},
+	{
+		name: "note", aliases: []string{"notes", "remember", "keep"},
+		usage: "note: ...", summary: "Note something about the current step (\"note for next time: ...\" keeps it in the recipe)",
+		detail: "Attaches a note to the current step. Notes marked for next time, or kept with \"keep my notes\", are read out when you next cook the recipe.",
+		voice:  []string{"note: the sauce needed 5 extra minutes", "remember the pan runs hot next time", "keep my notes"},
+	},
+	{
+		name: "next", aliases: []string{"done", "advance"},
+		usage: "next / done", summary: "Move to the next step",
+		detail: "Marks the current step done and reads the next one.",
+		voice:  []string{"next", "done", "continue"},
+	},
+	{
+		name: "skip", aliases: []string{"section", "optional"},
+		usage: "skip [section]", summary: "Skip the current step, a section, or an optional part",
+		detail: "Moves on without marking the step done. \"skip this section\" skips the rest of the current section, \"skip to <section>\" or \"go to step 5\" jumps ahead, and naming an optional section (\"skip the garnish\") leaves it out before you get there.",
+		voice:  []string{"skip", "skip the rest of this section", "skip ahead to the sauce", "go to step five", "skip the garnish"},
+	},
+	{
+		name: "repeat", aliases: []string{"again"},
+		usage: "repeat / again", summary: "Show the current step again",
+		detail: "Reprints and rereads the current step. \"repeat last\" replays the last thing said instead.",
+		voice:  []string{"repeat", "again", "what?", "say that again"},
+	},
+	{
+		name: "go on", aliases: []string{"carry on", "keep going"},
+		usage: "go on", summary: "Pick up where the assistant was cut off",
+		detail: "Finishes an answer that was interrupted, or retries one that failed.",
+		voice:  []string{"go on", "what were you saying?"},
+	},
+	{
+		name: "never mind", aliases: []string{"cancel", "forget it", "esc"},
+		usage: "never mind (Esc)", summary: "Call off an AI request", ai: true,
+		detail: "Forgets a request that failed or timed out, so \"go on\" won't retry it, and drops a follow-up question the AI asked. While the AI is still thinking, press Esc (or space) to stop it there.",
+		voice:  []string{"never mind", "forget it", "cancel that"},
+	},
+	{
+		name: "pause", aliases: []string{"brb", "wait"},
+		usage: "pause / brb", summary: "Pause the session and timers",
+		detail: "Freezes the session and every running timer until you resume.",
+		voice:  []string{"pause", "brb", "wait"},
+	},
+	{
+		name: "resume", aliases: []string{"back", "unpause"},
+		usage: "resume / back", summary: "Resume a paused session",
+		detail: "Picks the session and its timers up where they were paused.",
+		voice:  []string{"resume", "back", "unpause"},
+	},
+	{
+		name: "status", aliases: []string{"where", "progress"},
+		usage: "status / where", summary: "Show session progress and timers",
+		detail: "Shows the step you're on, how long you've been cooking, and every running timer.",
+		voice:  []string{"status", "where", "progress"},
+	},
+	{
+		name: "timer", aliases: []string{"ready", "timers"},
+		usage: "timer / ready", summary: "Start a pending step timer",
+		detail: "Steps with a timer wait for you to be ready before counting down. This starts the current step's waiting timer; \"start all timers\" starts every one still waiting. On a hands-off wait step (\"marinate 2 hours\") it starts the wait instead: Otto can be closed, and picks the session back up when the wait is over.",
+		voice:  []string{"timer", "ready", "start timer", "start all timers"},
+	},
+	{
+		name: "dismiss", aliases: []string{"ok", "got it"},
+		usage: "dismiss [n|name] / ok", summary: "Acknowledge a timer notification",
+		detail: "Silences a timer that's gone off. \"ok\" and \"got it\" only dismiss while one is going off, so they never stop a timer still counting down. Give its number from status or the start of its name to dismiss a specific one; click it in the timer bar with the mouse.",
+		voice:  []string{"ok", "got it", "dismiss two", "dismiss the simmer timer"},
+	},
+	{
+		name: "cancel", aliases: []string{"restart", "reset", "pause timer"},
+		usage: "pause|resume|cancel|restart <timer>", summary: "Control one timer, or all of them",
+		detail: "Pauses, resumes, cancels, or restarts a single timer without pausing the session. Name it by its number from status or by name, or say \"all timers\" to pause or resume every one. Cancelling stops a timer before it goes off; restarting sets it back to its full length.",
+		voice:  []string{"pause all timers", "cancel the chicken timer", "restart the simmer timer", "resume timer two"},
+	},
+	{
+		name: "prep", aliases: []string{"chop", "mise en place", "knife work"},
+		usage: "prep", summary: "List the knife work to do before cooking",
+		detail: "Gathers every ingredient that needs chopping, mincing, slicing, or grating into one checklist, in the order they're used, so it can all be done up front. Works once a recipe is selected.",
+		voice:  []string{"what do I need to chop", "things to prep", "mise en place"},
+	},
+	{
+		name: "how much", aliases: []string{"amount", "quantity", "how many"},
+		usage: "how much <ingredient>", summary: "Hear how much of an ingredient the recipe uses",
+		detail: "Answered straight from the recipe, scaled to the current servings, without asking the AI. \"how much oil\" lists every oil. Anything the recipe can't answer goes to the AI as a question.",
+		voice:  []string{"how much garlic?", "how many cloves of garlic do I need"},
+	},
+	{
+		name: "versions", aliases: []string{"version", "history", "restore"},
+		usage: "versions", summary: "See how the recipe has changed, and go back to an older version",
+		detail: "Every change the AI makes is saved as a new version, with what changed. \"go back to version 2\" restores it as the newest version, so nothing is lost; \"cook version 2\" restores it and starts cooking. Not while you're cooking that recipe.",
+		voice:  []string{"show versions", "go back to version 2", "cook version 1"},
+	},
+	{
+		name: "serve", aliases: []string{"dinner", "serve time", "eat"},
+		usage: "dinner at <time>", summary: "Plan backwards from when you want to eat",
+		detail: "Before you start, says when to start cooking to eat on time. While cooking, shows when each timed step should start (status lists them too) and warns if you're falling more than five minutes behind. \"clear the serve time\" drops it.",
+		voice:  []string{"dinner at 19:30", "we're eating at 7pm", "I want it ready by 8"},
+	},
+	{
+		name: "check", aliases: []string{"check off", "tick off", "condition", "conditions"},
+		usage: "check off <condition>", summary: "Check off one of the step's conditions",
+		detail: "Marks one of the step's conditions met, by its number (\"check off 2\") or a few of its words (\"check off the water\"); on a step with just one, \"checked\" is enough. Otto says what's still open. Once you've checked any off, \"next\" on a step with conditions still open asks first. Time conditions aren't checked off; the timer covers them.",
+		voice:  []string{"check off the water", "the chicken's at 74", "tick off 2"},
+	},
+	{
+		name: "tasks", aliases: []string{"delegate", "helper", "helpers", "jobs", "hand off"},
+		usage: "give the side jobs to <name> / <name>: <job>", summary: "Hand jobs to someone cooking with you",
+		detail: "\"Give the side jobs to Sam\" hands Sam the current step's tips, the things to do while you wait; \"Sam: chop the broccoli\" or \"ask Sam to chop the broccoli\" hands over any job. Jobs stay open while you move through the steps, and Otto checks in on one every five minutes until you say \"Sam's done\" (or \"Sam finished the broccoli\", if Sam has more than one). \"Sam isn't done\" opens a job again. \"Tasks\" or \"what's Sam doing\" lists them; status does too.",
+		voice:  []string{"give the side jobs to Sam", "ask Sam to grate the cheese", "Sam's done", "what's Sam doing"},
+	},
+	{
+		name: "calendar", aliases: []string{"ics", "reminders", "export", "add to calendar"},
+		usage: "add it to my calendar", summary: "Put upcoming times in your calendar",
+		detail: "Writes the session's upcoming milestones, when a wait ends, when running timers go off, and with a serve time set, when to start each wait still to come and when to eat, to an .ics file (-calendar, ottocook.ics by default), each with a reminder. Open it to import them into your calendar. After that the file is rewritten when a wait starts or the serve time changes; opening it again updates the events rather than adding copies.",
+		voice:  []string{"add it to my calendar", "put the times in my calendar"},
+	},
+	{
+		name: "timeline", aliases: []string{"gantt", "how it went", "export timeline"},
+		usage: "show the timeline", summary: "See how the cook went, on a time axis",
+		detail: "Draws the session as a Gantt chart to an HTML file (-timeline, ottocook-timeline.html by default): each step from start to done, each timer's runs and how long it rang before you answered it, the pauses and hands-off waits, and when the watcher spoke up, with a few lines summing it up. It's written by itself when you finish; say this mid-cook, or just after, to write it again.",
+		voice:  []string{"show me the timeline"},
+	},
+	{
+		name: "compare", aliases: []string{"diff", "side by side", "versus", "vs"},
+		usage: "compare <a> and <b>", summary: "Set two recipes side by side",
+		detail: "Prints two recipes side by side: each ingredient with the amount each uses, marked where only one uses it or the amounts differ, then their steps, time and servings. Then it reads out what sets the second apart: what it adds and drops, its steps, how much longer it takes. Each can be a number from the last list, a version of the selected recipe (\"compare v1 and v3\"), or a recipe's ID or name.",
+		voice:  []string{"compare 1 and 2", "compare version 1 with version 3"},
+	},
+	{
+		name: "pairing", aliases: []string{"wine", "drink", "drinks", "beer", "what to drink"},
+		usage: "what wine goes with this?", summary: "What to drink with the recipe",
+		detail: "Suggests a wine or two and something without alcohol for the selected recipe. With the AI, the whole recipe goes with the question, so the pairing follows the sauce and spice, and you can ask for a beer or no alcohol. Without it, or when it can't be reached, Otto pairs by the style of dish from its own table. The recipe detail shows that pairing too.",
+		voice:  []string{"what wine goes with this?", "what should I drink with it, no alcohol"},
+	},
+	{
+		name: "suggest", aliases: []string{"what should I cook", "ideas", "surprise me", "what's for dinner", "recommend"},
+		usage: "what should I cook? [with X] [in under N minutes]", summary: "Get two or three recipes picked for you",
+		detail: "Picks up to three recipes and says why each: what it uses of what you have (\"with chicken and rice\"), how long it takes (\"in under 30 minutes\", \"something quick\"), that it's in season, or that you haven't cooked it lately. Recipes that clash with the speaker's diet are left out. Say a number to open one. Asking again gives something else. Otto offers one when it starts.",
+		voice:  []string{"what should I cook?", "what can I make with chicken in 30 minutes"},
+	},
+	{
+		name: "voice", aliases: []string{"remember my voice", "speaker", "speakers", "diet", "allergic", "profiles"},
+		usage: "remember my voice as NAME / my diet is ...", summary: "Tell voices apart, each with their own diet",
+		detail: "With -speaker-model, Otto recognises who's talking. \"Remember my voice as Sam\" learns the voice that said it; say it two or three times. \"I'm allergic to peanuts\", \"I don't eat pork\" or \"my diet is vegetarian\" add to the speaker's profile, and \"Alex's diet is vegan\" to someone else's. Whenever a known voice asks the AI something, their diet goes with it. Profiles are kept in -profiles and can be edited by hand.",
+		voice:  []string{"remember my voice as Sam", "I'm allergic to peanuts"},
+	},
+	{
+		name: "mic", aliases: []string{"mute mic", "microphone", "privacy", "stop listening", "mic on", "mic off"},
+		usage: "mute mic / mic on (Ctrl+O)", summary: "Turn the microphone off and on",
+		detail: "Turns the microphone off completely, not just the wake word: nothing is recorded until it's back on, and the status box shows MIC OFF. A falling chime plays as it goes off and a rising one when it's back. Once it's off it can't hear \"mic on\", so type it or press Ctrl+O.",
+		voice:  []string{"mute mic", "stop listening"},
+	},
+	{
+		name: "voice off", aliases: []string{"voice on", "audio off", "audio on", "text only"},
+		usage: "voice off / voice on", summary: "Shut speech and voice input down, and bring them back",
+		detail: "Closes the ear, the wake word detector, the speech queue and the audio player, so the sound card and microphone are free for something else, and carries on in text. \"Voice on\" starts them again without restarting Otto. Nothing is listening while it's off, so type it.",
+		voice:  []string{"voice off", "text only"},
+	},
+	{
+		name: "reload", aliases: []string{"reload config", "reload settings", "config", "settings"},
+		usage: "reload", summary: "Apply changes to the config file now",
+		detail: "Re-reads config.env and the timer escalation file and applies what can change mid-cook: the voice, units, wake word threshold, voice confidence, how often timers remind you, and the typewriter speed, bell and window title. Otto also notices when either file is saved and reloads by itself. Anything else, such as keys or models, still needs a restart.",
+		voice:  []string{"reload", "reload the settings"},
+	},
+	{
+		name: "feedback", aliases: []string{"good answer", "wrong", "that's wrong", "thumbs up", "thumbs down"},
+		usage: "good answer / that's wrong", summary: "Rate the AI's last answer",
+		detail: "Rates the last answer, within five minutes of it, in the answer log (-ai-log). \"That's wrong\" also asks again with a stricter prompt; if the second answer is wrong too, Otto says so rather than guess a third time.",
+		voice:  []string{"good answer", "that's wrong", "thumbs down"},
+		ai:     true,
+	},
+	{
+		name: "misheard", aliases: []string{"not what i said", "misheard", "correction"},
+		usage: "that's not what I said", summary: "Correct a misheard voice command",
+		detail: "Corrects the last voice command, within a minute of it. Say what you meant and Otto does that instead, or say nothing more and Otto asks. \"I wasn't talking to you\" marks a wake by mistake. Whatever the misheard command did isn't undone. With -misheard-log, every correction is logged, and `ottocook misheard` lists the most common mix-ups, the false wakes, and what -stt-min-confidence would have caught.",
+		voice:  []string{"that's not what I said", "no, I said next", "I wasn't talking to you"},
+	},
+	{
+		name: "missed", aliases: []string{"didn't hear me", "you didn't hear me", "missed wake", "wake word"},
+		usage: "you didn't hear me", summary: "Say the wake word went unheard",
+		detail: "Tells Otto it missed \"Hey Chef\". After a couple of these it lowers the wake word threshold a step; after three wakes in a row that hear nothing, or \"I wasn't talking to you\", it raises it again. It stays within 0.2 of -ww-threshold, and -ww-adapt=false turns this off.",
+		voice:  []string{"you didn't hear me", "I said hey chef twice"},
+	},
+	{
+		name:  "help",
+		usage: "help [command]", summary: "Show this message, or details for one command",
+		detail: "Lists the commands, highlighting the one you most likely want right now.",
+		voice:  []string{"help", "help timer"},
+	},
+	{
+		name: "quit", aliases: []string{"exit", "stop", "abandon"},
+		usage: "quit / exit / abandon", summary: "Abandon session and exit",
+		detail: "Exits, asking first if a session is in progress.",
+		voice:  []string{"quit", "exit", "abandon this recipe"},
+	},
+	{
+		name: "ask", aliases: []string{"question", "how"},
+		usage: "how do I...?", summary: "Ask the AI a cooking question",
+		detail: "Anything phrased as a question goes to the AI, with the recipe and your progress as context.",
+		voice:  []string{"how do I know the chicken is done?", "can I use butter instead of oil?"},
+		ai:     true,
+	},
+	{
+		name: "modify", aliases: []string{"change", "swap", "replace", "double", "halve", "adjust", "substitute"},
+		usage: "modify ...", summary: "Ask the AI to change the recipe (swap, replace, double, halve, adjust, substitute)",
+		detail: "The AI rewrites the recipe and shows what changed. Duplicate first to keep the original.",
+		voice:  []string{"double the recipe", "swap the cream for milk", "make it vegetarian"},
+		ai:     true,
+	},
+}
+
+// findHelpTopic looks a topic up by name or alias.
+func findHelpTopic(name string) *helpTopic {
+	name = strings.ToLower(strings.Trim(strings.TrimSpace(name), ".?!\"'"))
+	name = strings.TrimPrefix(name, "the ")
+	name = strings.TrimSuffix(name, " command")
+	for i := range helpTopics {
+		t := &helpTopics[i]
+		if t.name == name {
+			return t
+		}
+		for _, alias := range t.aliases {
+			if alias == name {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+// showHelp lists the commands, leading with what fits the moment, or
+// details one command when topic is given.
+func (a *Controller) showHelp(ctx context.Context, topic string) {
+	if topic != "" {
+		a.showHelpTopic(topic)
+		return
+	}
+
+	focus, reason := a.helpFocus(ctx)
+	if reason != "" {
+		a.ui.PrintHint(reason)
+	}
+	a.ui.PrintStep("Commands:")
+	for _, t := range helpTopics {
+		if t.ai {
+			continue
+		}
+		a.printHelpRow(t, focus)
+	}
+	a.ui.Println("")
+	a.ui.PrintStep("AI (requires GPT_CHAT_KEY + GPT_CHAT_ENDPOINT):")
+	for _, t := range helpTopics {
+		if t.ai {
+			a.printHelpRow(t, focus)
+		}
+	}
+	a.showPluginHelp()
+	a.ui.PrintHint("Say \"help <command>\" for details and phrasings, e.g. \"help timer\".")
+}
+
+func (a *Controller) printHelpRow(t helpTopic, focus string) {
+	line := fmt.Sprintf("%-16s %s", t.usage, t.summary)
+	if t.name == focus {
+		a.ui.PrintStep("▸ " + line)
+		return
+	}
+	a.ui.PrintInstructionAction("  "+line, "help "+t.name)
+}
+
+func (a *Controller) showHelpTopic(name string) {
+	t := findHelpTopic(name)
+	if t == nil {
+		a.ui.PrintHint(fmt.Sprintf("No help for %q. Type \"help\" for the list of commands.", name))
+		return
+	}
+	a.ui.PrintStep(t.usage)
+	a.ui.PrintInstruction("  " + t.detail)
+	if t.ai && a.agent == nil {
+		a.ui.PrintHint("  Needs the AI agent (GPT_CHAT_KEY + GPT_CHAT_ENDPOINT).")
+	}
+	if len(t.voice) > 0 {
+		a.ui.PrintHint("  Try saying:")
+		for _, v := range t.voice {
+			a.ui.PrintHint("    \"" + v + "\"")
+		}
+	}
+}
+
+// helpFocus picks the command most likely wanted right now and a line
+// saying why.  Empty when nothing stands out.
+func (a *Controller) helpFocus(ctx context.Context) (topic, reason string) {
+	if a.sessionID == "" {
+		if a.selectedRecipe != "" {
+			return "start", "A recipe is selected — \"start\" begins cooking it."
+		}
+		return "list", "Pick a recipe to get going — \"list\" shows them."
+	}
+	session, err := a.engine.Status(ctx, a.sessionID)
+	if err != nil {
+		return "", ""
+	}
+	if session.Status == domain.SessionWaiting {
+		return "next", "You're in a hands-off wait — \"next\" ends it early."
+	}
+	if session.Status == domain.SessionPaused {
+		return "resume", "You're paused — \"resume\" picks up where you left off."
+	}
+	pending := false
+	for _, ts := range session.TimerStates {
+		switch ts.Status {
+		case domain.TimerFired:
+			return "dismiss", fmt.Sprintf("%s is done — \"ok\" acknowledges it.", ts.Label)
+		case domain.TimerPending:
+			pending = true
+		}
+	}
+	if pending {
+		return "timer", "A timer is waiting to start — say \"timer\" or \"ready\" when you are."
+	}
+	return "next", ""
+}
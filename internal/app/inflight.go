@@ -0,0 +1,157 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Cancelling AI calls ──────────────────────────────────────────
+//
+// Every agent call gets its own deadline and can be called off from
+// outside the input loop, which is blocked on it: Esc, the space-bar
+// "shut up", or CancelAI from another front end.  The spinner goes
+// either way.  A cancelled or timed-out request is left unanswered, so
+// "what were you saying?" sends it again; "never mind" forgets it.
+//
+// The ear is listened to while a call runs, so a spoken "never mind"
+// calls it off too.  Anything else heard meanwhile waits for the input
+// loop, in order.
+
+// DefaultAITimeout bounds one agent call when Config leaves it zero.
+// Longer than the HTTP client's own timeout: a modification can take
+// more than one request.
+const DefaultAITimeout = 45 * time.Second
+
+// inflight is the agent call in progress, if any.
+type inflight struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	heard  []speech.Utterance // heard during a call, for the input loop
+}
+
+// aiCall starts an agent call: label goes on the spinner, and the
+// returned context ends at the timeout or on CancelAI.  done clears
+// both; call it as soon as the agent returns.
+func (a *Controller) aiCall(ctx context.Context, label string) (context.Context, func()) {
+	callCtx, cancel := context.WithTimeout(ctx, a.aiTimeout)
+	a.ai.mu.Lock()
+	a.ai.cancel = cancel
+	a.ai.mu.Unlock()
+	a.activity.setAI(label)
+
+	stop := make(chan struct{})
+	var listening sync.WaitGroup
+	if a.ear != nil {
+		listening.Add(1)
+		go func() {
+			defer listening.Done()
+			a.listenDuringAI(ctx, stop)
+		}()
+	}
+	return callCtx, func() {
+		close(stop)
+		listening.Wait()
+		a.ai.mu.Lock()
+		a.ai.cancel = nil
+		a.ai.mu.Unlock()
+		cancel()
+		a.activity.setAI("")
+	}
+}
+
+// listenDuringAI reads the ear until stop, in place of the input loop
+// the call is holding up.  "Never mind" with the wake word calls the
+// call off; the rest is kept for the loop.
+func (a *Controller) listenDuringAI(ctx context.Context, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case u := <-a.ear.C():
+			if !u.Overheard {
+				if intent, err := a.parser.Parse(ctx, u.Text, nil); err == nil && intent.Type == domain.IntentCancel {
+					a.ui.PrintVoice(u.Text)
+					a.CancelAI()
+					continue
+				}
+			}
+			a.ai.mu.Lock()
+			a.ai.heard = append(a.ai.heard, u)
+			a.ai.mu.Unlock()
+		}
+	}
+}
+
+// nextHeard returns the oldest utterance heard during an AI call that
+// the input loop hasn't had yet.
+func (a *Controller) nextHeard() (speech.Utterance, bool) {
+	a.ai.mu.Lock()
+	defer a.ai.mu.Unlock()
+	if len(a.ai.heard) == 0 {
+		return speech.Utterance{}, false
+	}
+	u := a.ai.heard[0]
+	a.ai.heard = a.ai.heard[1:]
+	return u, true
+}
+
+// CancelAI calls off the agent call in progress, along with its
+// "thinking" filler.  Reports whether there was one.  Safe to call from
+// any goroutine.
+func (a *Controller) CancelAI() bool {
+	a.ai.mu.Lock()
+	cancel := a.ai.cancel
+	a.ai.cancel = nil
+	a.ai.mu.Unlock()
+	if cancel == nil {
+		return false
+	}
+	cancel()
+	if a.mouth != nil {
+		a.mouth.InterruptChannel(speech.ChannelAI)
+	}
+	return true
+}
+
+// aiCancelled reports whether err is a call CancelAI stopped, and if
+// so says so.  Timeouts aren't cancellations.
+func (a *Controller) aiCancelled(what string, err error) bool {
+	if !errors.Is(err, context.Canceled) {
+		return false
+	}
+	a.log.Info("AI %s cancelled", what)
+	a.say(speech.LineAICancelled(), speech.PriorityNormal)
+	return true
+}
+
+// aiFailed reports an agent call that didn't answer.
+func (a *Controller) aiFailed(what string, err error) {
+	if a.aiCancelled(what, err) {
+		return
+	}
+	a.log.Error("AI %s failed: %v", what, err)
+	if errors.Is(err, context.DeadlineExceeded) {
+		a.say(speech.LineAITimeout(), speech.PriorityNormal)
+		return
+	}
+	a.say(speech.LineAIError(), speech.PriorityNormal)
+}
+
+// cancel handles "never mind": it forgets the AI request left to retry
+// and the follow-up question awaiting an answer.  A yes/no question is
+// answered "no" before it gets here.
+func (a *Controller) cancel() {
+	if a.unanswered == nil && a.clarify == nil {
+		a.say(speech.LineNothingToCancel(), speech.PriorityLow)
+		return
+	}
+	a.unanswered, a.clarify = nil, nil
+	a.say(speech.LineAICancelled(), speech.PriorityNormal)
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/speech"
+	"github.com/hammamikhairi/ottocook/internal/testkit"
+)
+
+func TestAICancelledAndRetried(t *testing.T) {
+	h := newHarness(t)
+	h.expect("Chicken Alfredo")
+	if h.app.aiTimeout != DefaultAITimeout {
+		t.Errorf("aiTimeout = %s, want the default %s", h.app.aiTimeout, DefaultAITimeout)
+	}
+
+	h.agent.Hold()
+	h.typeLine("what can I use instead of parmesan?")
+	if !h.agent.WaitCalls(1, waitTimeout) {
+		t.Fatal("the question never reached the agent")
+	}
+	if !h.app.CancelAI() {
+		t.Fatal("CancelAI found no call in progress")
+	}
+	h.expectSpoken(speech.LineAICancelled())
+	if h.app.CancelAI() {
+		t.Error("CancelAI found a call after it was cancelled")
+	}
+
+	h.agent.Release()
+	h.agent.Reply(testkit.KindQuestion, "Use pecorino instead.")
+	h.typeLine("what were you saying?")
+	h.expect("Use pecorino instead.")
+}
+
+func TestAITimeout(t *testing.T) {
+	h := newHarnessWith(t, func(ctx context.Context, h *harness) {
+		h.app.aiTimeout = 50 * time.Millisecond
+	})
+	h.expect("Chicken Alfredo")
+
+	h.agent.Hold()
+	defer h.agent.Release()
+	h.typeLine("why do onions make me cry?") // nothing in the offline notes
+	h.expectSpoken(speech.LineAITimeout())
+}
+
+func TestSpokenNeverMindCancelsAI(t *testing.T) {
+	h := newHarness(t)
+	h.expect("Chicken Alfredo")
+
+	h.agent.Hold()
+	defer h.agent.Release()
+	h.typeLine("what can I use instead of parmesan?")
+	if !h.agent.WaitCalls(1, waitTimeout) {
+		t.Fatal("the question never reached the agent")
+	}
+
+	// The input loop is waiting on the agent; the ear isn't.
+	h.ear.Hear("list recipes", 0.95)
+	h.ear.Hear("never mind", 0.95)
+	h.expect(speech.LineAICancelled())
+
+	// What else was heard is acted on afterwards.
+	h.expect("list recipes")
+	h.expect("No recipes are tagged")
+}
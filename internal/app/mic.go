@@ -0,0 +1,59 @@
+package app
+
+import (
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Microphone off ───────────────────────────────────────────────
+//
+// "Mute mic" (or Ctrl+O) stops the capture device outright, not just the
+// wake-word scoring, for when there are guests in the kitchen.  A
+// falling earcon marks it going off and a rising one coming back, and
+// the inspector shows MIC OFF until then.  With the mic off, only the
+// keyboard can turn it back on.
+
+// setMic turns the microphone off or on.
+func (a *Controller) setMic(off bool) {
+	if a.ear == nil {
+		a.say(speech.LineNoMic(), speech.PriorityLow)
+		return
+	}
+	mic, ok := a.ear.(microphone)
+	if !ok {
+		return
+	}
+	if off == mic.MicIsOff() {
+		a.say(speech.LineMicAlready(off), speech.PriorityLow)
+		return
+	}
+	if off {
+		mic.MicOff()
+		a.earcon("mic off", speech.MicOffEarcon())
+		a.ui.PrintUrgent(speech.LineMicOff())
+		return
+	}
+	mic.MicOn()
+	a.earcon("mic on", speech.MicOnEarcon())
+	a.say(speech.LineMicOn(), speech.PriorityNormal)
+}
+
+// toggleMic is the Ctrl+O hotkey.
+func (a *Controller) toggleMic() {
+	if mic, ok := a.ear.(microphone); ok {
+		a.setMic(!mic.MicIsOff())
+	}
+}
+
+// earcon plays a sound through the speakers, when there are any.
+func (a *Controller) earcon(name string, audio []byte) {
+	if a.mouth != nil {
+		a.mouth.Play(name, audio, speech.PriorityCritical)
+	}
+}
+
+// microphone is the part of the ear that turns the mic off and on.
+type microphone interface {
+	MicOff()
+	MicOn()
+	MicIsOff() bool
+}
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/metrics"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Intent pipeline ──────────────────────────────────────────────
+//
+// Every parsed input goes through a chain of middleware on its way to
+// handleIntent: logging, metrics, remembering what was heard for "that's
+// not what I said", answering an open follow-up question, reading back
+// risky commands whisper wasn't sure of, and keeping AI calls to a sane
+// rate.  Behaviour that applies to every intent goes here, not into the
+// handleIntent switch.
+
+// turn is one input on its way through the pipeline.
+type turn struct {
+	intent *domain.Intent
+	input  string
+	heard  *heardCommand // nil for typed input
+}
+
+// confidence is whisper's confidence in a spoken input; -1 for typed
+// input, which is taken at face value.
+func (t *turn) confidence() float64 {
+	if t.heard == nil {
+		return -1
+	}
+	return t.heard.confidence
+}
+
+// intentHandler handles a turn.
+type intentHandler func(ctx context.Context, t *turn)
+
+// middleware wraps an intentHandler.  It may act before or after next,
+// or handle the turn itself and not call next at all.
+type middleware func(next intentHandler) intentHandler
+
+// chain wraps h in mw, the first outermost.
+func chain(h intentHandler, mw ...middleware) intentHandler {
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
+
+// pipeline builds the chain parsed input goes through.
+func (a *Controller) pipeline() intentHandler {
+	return chain(func(ctx context.Context, t *turn) { a.handleIntent(ctx, t.intent) }, a.middleware()...)
+}
+
+// middleware is the pipeline's middleware, outermost first.
+func (a *Controller) middleware() []middleware {
+	return []middleware{
+		a.logIntents,
+		a.measureIntents(a.metrics),
+		a.rememberHeard,
+		a.answerClarification,
+		a.confirmUnsure,
+		a.limitAI(a.aiPerMinute),
+	}
+}
+
+func (a *Controller) logIntents(next intentHandler) intentHandler {
+	return func(ctx context.Context, t *turn) {
+		a.log.Debug("intent: %s (payload=%q)", t.intent.Type, t.intent.Payload)
+		next(ctx, t)
+	}
+}
+
+// measureIntents counts intents and times their handling.
+func (a *Controller) measureIntents(reg *metrics.Registry) middleware {
+	total := reg.Counter("ottocook_intents_total", "Inputs parsed into an intent and handled.")
+	unknown := reg.Counter("ottocook_intents_unknown_total", "Inputs the keyword parser didn't recognise.")
+	latency := reg.Histogram("ottocook_intent_seconds", "Time to handle an intent, AI calls included.", metrics.LatencyBuckets)
+	return func(next intentHandler) intentHandler {
+		return func(ctx context.Context, t *turn) {
+			total.Inc()
+			if t.intent.Type == domain.IntentUnknown {
+				unknown.Inc()
+			}
+			start := a.clock.Now()
+			next(ctx, t)
+			latency.ObserveDuration(a.clock.Now().Sub(start))
+		}
+	}
+}
+
+// rememberHeard keeps the last voice command for "that's not what I
+// said".
+func (a *Controller) rememberHeard(next intentHandler) intentHandler {
+	return func(ctx context.Context, t *turn) {
+		if t.heard != nil && t.intent.Type != domain.IntentMisheard {
+			a.lastHeard = t.heard
+		}
+		next(ctx, t)
+	}
+}
+
+// answerClarification sends the reply to an open follow-up question
+// back to the request that asked it, unless the reply is clearly a
+// command of its own ("next", "pause", ...).  "Never mind" is left to
+// drop it.
+func (a *Controller) answerClarification(next intentHandler) intentHandler {
+	return func(ctx context.Context, t *turn) {
+		if c := a.clarify; c != nil && t.intent.Type != domain.IntentCancel {
+			a.clarify = nil
+			if t.intent.Type == domain.IntentUnknown && a.clock.Now().Sub(c.asked) < clarifyTTL {
+				a.log.Debug("routing %q to pending clarification %q", t.input, c.question)
+				c.resume(ctx, t.input)
+				return
+			}
+		}
+		next(ctx, t)
+	}
+}
+
+// confirmUnsure reads back a risky command whisper wasn't sure of: a
+// misheard "skip" or "quit" is expensive to undo.  Intents that ask for
+// their own confirmation are left alone so the user isn't asked twice.
+func (a *Controller) confirmUnsure(next intentHandler) intentHandler {
+	return func(ctx context.Context, t *turn) {
+		conf := t.confidence()
+		if conf >= 0 && conf < a.minConfidence && riskyIfMisheard(t.intent.Type) && !a.confirmsItself(t.intent) {
+			a.log.Info("low-confidence voice command %q (%.2f) — confirming", t.input, conf)
+			a.confirm(speech.LineConfirmHeard(t.input), t.intent.Type.String(), func(ctx context.Context) {
+				next(ctx, t)
+			})
+			return
+		}
+		next(ctx, t)
+	}
+}
+
+// limitAI turns away input bound for the AI agent once perMinute calls
+// have gone out in the last minute, so a chatty kitchen or a stuck key
+// can't run up the bill.  0 means no limit.
+func (a *Controller) limitAI(perMinute int) middleware {
+	var sent []time.Time
+	return func(next intentHandler) intentHandler {
+		return func(ctx context.Context, t *turn) {
+			if perMinute <= 0 || a.agent == nil || !boundForAI(t.intent.Type) {
+				next(ctx, t)
+				return
+			}
+			now := a.clock.Now()
+			for len(sent) > 0 && now.Sub(sent[0]) >= time.Minute {
+				sent = sent[1:]
+			}
+			if len(sent) >= perMinute {
+				a.log.Info("AI rate limit: dropping %q", t.input)
+				a.say(speech.LineSlowDown(), speech.PriorityLow)
+				return
+			}
+			sent = append(sent, now)
+			next(ctx, t)
+		}
+	}
+}
+
+// boundForAI reports whether handling an intent calls the AI agent.
+func boundForAI(t domain.IntentType) bool {
+	switch t {
+	case domain.IntentUnknown, domain.IntentAskQuestion, domain.IntentModify, domain.IntentPairing:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,194 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/conversation"
+	"github.com/hammamikhairi/ottocook/internal/display"
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/engine"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/recipe"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+	"github.com/hammamikhairi/ottocook/internal/storage"
+	"github.com/hammamikhairi/ottocook/internal/testkit"
+)
+
+// pipelineRig is a controller's middleware ending in a handler that
+// records what gets through, instead of handleIntent.
+type pipelineRig struct {
+	app     *Controller
+	clock   *testkit.FakeClock
+	screen  *testkit.Screen
+	handle  intentHandler
+	handled []domain.IntentType
+}
+
+func newPipelineRig(t *testing.T, aiPerMinute int) *pipelineRig {
+	t.Helper()
+	log := logger.New(logger.LevelOff, nil)
+	store := storage.NewMemoryStore(log)
+	ui := display.NewUI(store)
+	r := &pipelineRig{
+		clock:  testkit.NewFakeClock(time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)),
+		screen: testkit.NewScreen(),
+	}
+	ui.SetOutput(r.screen)
+	r.app = New(Config{
+		Engine: engine.New(recipe.NewMemorySource(log), store, log),
+		Parser: conversation.NewKeywordParser(log),
+		Agent:  testkit.NewFakeAgent().Agent(log),
+		Log:    log,
+		Chat:   ui,
+		Status: ui,
+		Input:  ui,
+		Clock:  r.clock,
+
+		MinConfidence: 0.6,
+		AIPerMinute:   aiPerMinute,
+	})
+	r.handle = chain(func(ctx context.Context, t *turn) {
+		r.handled = append(r.handled, t.intent.Type)
+	}, r.app.middleware()...)
+	return r
+}
+
+// typed sends typed input of the given intent down the pipeline.
+func (r *pipelineRig) typed(typ domain.IntentType, input string) {
+	r.handle(context.Background(), &turn{intent: &domain.Intent{Type: typ, Payload: input}, input: input})
+}
+
+// heard sends a voice command whisper was conf sure of.
+func (r *pipelineRig) heard(typ domain.IntentType, input string, conf float64) {
+	r.handle(context.Background(), &turn{
+		intent: &domain.Intent{Type: typ, Payload: input},
+		input:  input,
+		heard:  &heardCommand{confidence: conf, at: r.clock.Now()},
+	})
+}
+
+func TestChainOrder(t *testing.T) {
+	var got []string
+	mark := func(name string) middleware {
+		return func(next intentHandler) intentHandler {
+			return func(ctx context.Context, t *turn) {
+				got = append(got, name+" in")
+				next(ctx, t)
+				got = append(got, name+" out")
+			}
+		}
+	}
+	h := chain(func(context.Context, *turn) { got = append(got, "handle") }, mark("outer"), mark("inner"))
+	h(context.Background(), &turn{intent: &domain.Intent{}})
+
+	want := []string{"outer in", "inner in", "handle", "inner out", "outer out"}
+	if len(got) != len(want) {
+		t.Fatalf("ran %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ran %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPipelineClarification(t *testing.T) {
+	r := newPipelineRig(t, 0)
+	var answers []string
+	ask := func() {
+		r.app.askClarification("Which pan?", func(ctx context.Context, answer string) { answers = append(answers, answer) })
+	}
+
+	// An unrecognised reply goes to the question, not the handler.
+	ask()
+	r.typed(domain.IntentUnknown, "the big one")
+	if len(answers) != 1 || answers[0] != "the big one" || len(r.handled) != 0 {
+		t.Fatalf("answers %v, handled %v; want the reply routed to the question", answers, r.handled)
+	}
+	if r.app.clarify != nil {
+		t.Error("the question is still open after its answer")
+	}
+
+	// A command of its own drops the question and goes through.
+	ask()
+	r.typed(domain.IntentAdvance, "next")
+	if len(answers) != 1 || len(r.handled) != 1 || r.app.clarify != nil {
+		t.Fatalf("answers %v, handled %v; want next handled and the question dropped", answers, r.handled)
+	}
+
+	// So does a reply once the question has gone stale.
+	ask()
+	r.clock.Advance(clarifyTTL + time.Second)
+	r.typed(domain.IntentUnknown, "the big one")
+	if len(answers) != 1 || len(r.handled) != 2 {
+		t.Fatalf("answers %v, handled %v; want a stale question ignored", answers, r.handled)
+	}
+}
+
+func TestPipelineConfirmsUnsureCommands(t *testing.T) {
+	r := newPipelineRig(t, 0)
+
+	// A risky command heard unsurely waits for a yes.
+	r.heard(domain.IntentSkip, "skip", 0.3)
+	if len(r.handled) != 0 || r.app.pending == nil {
+		t.Fatalf("handled %v, pending %v; want the skip held for confirmation", r.handled, r.app.pending)
+	}
+	if !r.app.answerPending(context.Background(), "yes") || len(r.handled) != 1 || r.handled[0] != domain.IntentSkip {
+		t.Fatalf("handled %v after yes; want the skip", r.handled)
+	}
+
+	// Typed, heard clearly, or harmless, it goes straight through.
+	r.typed(domain.IntentSkip, "skip")
+	r.heard(domain.IntentSkip, "skip", 0.9)
+	r.heard(domain.IntentAdvance, "next", 0.3)
+	if len(r.handled) != 4 || r.app.pending != nil {
+		t.Fatalf("handled %v, pending %v; want all three through", r.handled, r.app.pending)
+	}
+}
+
+func TestPipelineRateLimitsAI(t *testing.T) {
+	r := newPipelineRig(t, 2)
+
+	for range 3 {
+		r.typed(domain.IntentAskQuestion, "how hot should the pan be")
+	}
+	if len(r.handled) != 2 {
+		t.Fatalf("%d of 3 questions got through a limit of 2", len(r.handled))
+	}
+	if _, ok := r.screen.Expect(speech.LineSlowDown(), waitTimeout); !ok {
+		t.Error("the dropped question wasn't told to slow down")
+	}
+
+	// Commands that don't go to the AI aren't counted.
+	r.typed(domain.IntentAdvance, "next")
+	if len(r.handled) != 3 {
+		t.Fatalf("next was held back by the AI limit")
+	}
+
+	// A clarification answer is routed before the limit applies.
+	r.app.askClarification("Which pan?", func(context.Context, string) { r.handled = append(r.handled, domain.IntentUnknown) })
+	r.typed(domain.IntentUnknown, "cast iron")
+	if len(r.handled) != 4 {
+		t.Fatalf("the clarification answer was rate limited")
+	}
+
+	// An unsure AI command is confirmed before it counts against the limit.
+	r.clock.Advance(time.Minute)
+	r.heard(domain.IntentModify, "no garlic", 0.3)
+	r.answerNo(t)
+	r.typed(domain.IntentAskQuestion, "one")
+	r.typed(domain.IntentAskQuestion, "two")
+	if len(r.handled) != 6 {
+		t.Fatalf("handled %d; a declined command used up the limit", len(r.handled))
+	}
+}
+
+// answerNo declines the pending confirmation.
+func (r *pipelineRig) answerNo(t *testing.T) {
+	t.Helper()
+	if r.app.pending == nil || !r.app.answerPending(context.Background(), "no") {
+		t.Fatal("nothing was waiting for a yes or no")
+	}
+}
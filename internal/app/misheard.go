@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Misheard commands ────────────────────────────────────────────
+//
+// "That's not what I said" corrects the last voice command.  With what
+// was meant ("no, I said next") the correction is logged and acted on;
+// without it Otto asks, and the next thing said is taken as the answer.
+// "I wasn't talking to you" is logged as a false wake.  The log is
+// opt-in (-misheard-log) and `ottocook misheard` summarises it.
+
+// misheardTTL is how long after a voice command it can still be
+// corrected, and how long a "say it again" waits for the answer.
+const misheardTTL = time.Minute
+
+// heardCommand is a voice command as whisper transcribed it.
+type heardCommand struct {
+	text       string
+	confidence float64
+	audio      string    // archived clip, with -keep-audio
+	voiceprint []float32 // with -speaker-model
+	at         time.Time
+}
+
+// correctMisheard handles "that's not what I said".
+func (a *Controller) correctMisheard(ctx context.Context, meant string) {
+	heard := a.lastHeard
+	if heard == nil || time.Since(heard.at) > misheardTTL {
+		a.say(speech.LineMisheardNothing(), speech.PriorityNormal)
+		return
+	}
+	a.lastHeard = nil
+
+	switch meant {
+	case "nothing":
+		a.recordMisheard(heard, "", true)
+		if e, ok := a.ear.(wakeTuner); ok {
+			if t, raised := e.FalseWake(); raised {
+				a.ui.PrintHint(fmt.Sprintf("Wake word threshold raised to %.2f", t))
+			}
+		}
+		a.say(speech.LineFalseWake(), speech.PriorityNormal)
+	case "":
+		a.misheard, a.misheardAsked = heard, time.Now()
+		a.say(speech.LineSayAgain(), speech.PriorityNormal)
+	default:
+		a.recordMisheard(heard, meant, false)
+		a.actOnCorrection(ctx, meant)
+	}
+}
+
+// missedWake handles "you didn't hear me": enough of them and the wake
+// threshold comes down.
+func (a *Controller) missedWake() {
+	e, ok := a.ear.(wakeTuner)
+	if !ok {
+		a.say(speech.LineNoVoice(), speech.PriorityLow)
+		return
+	}
+	t, lowered := e.MissedWake()
+	if lowered {
+		a.ui.PrintHint(fmt.Sprintf("Wake word threshold lowered to %.2f", t))
+	}
+	a.say(speech.LineMissedWake(lowered), speech.PriorityNormal)
+}
+
+// wakeTuner is the part of the ear that learns from wakes gone wrong.
+type wakeTuner interface {
+	FalseWake() (float64, bool)
+	MissedWake() (float64, bool)
+}
+
+// answerMisheard takes input as what was meant by a command Otto asked
+// to hear again, and logs the pair.  The input is then handled as usual.
+func (a *Controller) answerMisheard(input string) {
+	heard := a.misheard
+	a.misheard = nil
+	if time.Since(a.misheardAsked) > misheardTTL {
+		return
+	}
+	a.recordMisheard(heard, input, false)
+}
+
+// actOnCorrection runs the command the cook says they meant.
+func (a *Controller) actOnCorrection(ctx context.Context, meant string) {
+	var session *domain.Session
+	if a.sessionID != "" {
+		session, _ = a.engine.Status(ctx, a.sessionID)
+	}
+	intent, err := a.parser.Parse(ctx, meant, session)
+	if err != nil {
+		a.log.Error("parsing correction: %v", err)
+		return
+	}
+	if intent.Type == domain.IntentMisheard || intent.Type == domain.IntentUnknown {
+		a.say(speech.LineMisheardNoted(), speech.PriorityNormal)
+		return
+	}
+	a.handleIntent(ctx, intent)
+}
+
+// recordMisheard logs a correction, when logging is on.
+func (a *Controller) recordMisheard(heard *heardCommand, meant string, falseWake bool) {
+	a.log.Info("misheard: %q (confidence %.2f), meant %q, false wake %v", heard.text, heard.confidence, meant, falseWake)
+	err := a.misheardLog.Record(speech.Misheard{
+		At:         heard.at,
+		Heard:      heard.text,
+		Meant:      meant,
+		Confidence: heard.confidence,
+		FalseWake:  falseWake,
+		Audio:      heard.audio,
+	})
+	if err != nil {
+		a.log.Error("logging misheard command: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+func TestCorrectMisheard(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "misheard.jsonl")
+	h := newHarnessWith(t, func(ctx context.Context, h *harness) {
+		h.app.misheardLog = speech.NewMisheardLog(path)
+	})
+	h.expect("Chicken Alfredo")
+
+	// Nothing heard yet, so nothing to correct.
+	h.typeLine("I meant pause")
+	h.expectSpoken(speech.LineMisheardNothing())
+
+	h.typeLine("select 1")
+	h.typeLine("start")
+	h.typeLine("yes")
+	h.expect("Step 1/")
+
+	// Said what was meant: it's logged and acted on.
+	h.ear.Hear("next", 0.7)
+	h.expect("Step 2/")
+	h.typeLine("no, I said next")
+	h.expect("Step 3/")
+
+	// Didn't say: Otto asks, and the answer is logged.
+	h.ear.Hear("repeat", 0.8)
+	h.typeLine("that's not what I said")
+	h.expectSpoken(speech.LineSayAgain())
+	h.typeLine("next")
+	h.expect("Step 4/")
+
+	// Nobody was talking to Otto.
+	h.ear.Hear("next", 0.9)
+	h.expect("Step 5/")
+	h.typeLine("I wasn't talking to you")
+	h.expectSpoken(speech.LineFalseWake())
+
+	// A correction is only good for the command it corrects.
+	h.typeLine("I meant pause")
+	deadline := time.Now().Add(waitTimeout)
+	for strings.Count(strings.Join(h.tts.Spoken(), "\n"), speech.LineMisheardNothing()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("a second correction of the same command was taken; spoken:\n%s", strings.Join(h.tts.Spoken(), "\n"))
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	entries, err := speech.ReadMisheard(path)
+	if err != nil {
+		t.Fatalf("ReadMisheard: %v", err)
+	}
+	want := []speech.Misheard{
+		{Heard: "next", Meant: "next", Confidence: 0.7},
+		{Heard: "repeat", Meant: "next", Confidence: 0.8},
+		{Heard: "next", Confidence: 0.9, FalseWake: true},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("logged %+v, want %+v", entries, want)
+	}
+	for i, e := range entries {
+		e.At = want[i].At
+		if e != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, e, want[i])
+		}
+	}
+}
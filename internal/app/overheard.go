@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Always listening ─────────────────────────────────────────────
+//
+// With -always-listen the ear transcribes everything said in the
+// kitchen, not only what follows the wake word.  Most of that is people
+// talking to each other, so overheard speech is only acted on when
+// whisper is sure of it and it parses as one of Otto's own commands.
+// Nothing overheard goes to the AI, answers a question, or does anything
+// that would be costly if it was never meant for Otto.
+
+// heedOverheard reports whether speech heard without the wake word
+// should be acted on.
+func (a *Controller) heedOverheard(ctx context.Context, input string, confidence float64) bool {
+	if confidence < a.overheardMin {
+		a.log.Debug("ignoring overheard %q (confidence %.2f)", input, confidence)
+		return false
+	}
+	var session *domain.Session
+	if a.sessionID != "" {
+		if s, err := a.engine.Status(ctx, a.sessionID); err == nil {
+			session = s
+		}
+	}
+	intent, err := a.parser.Parse(ctx, input, session)
+	if err != nil || !overheardCommand(intent.Type) {
+		a.log.Debug("ignoring overheard %q (not a command)", input)
+		return false
+	}
+	return true
+}
+
+// overheardCommand reports whether an intent is safe to act on when
+// nobody said the wake word.
+func overheardCommand(t domain.IntentType) bool {
+	if riskyIfMisheard(t) {
+		return false
+	}
+	switch t {
+	case domain.IntentUnknown, domain.IntentAskQuestion, domain.IntentSelectRecipe,
+		domain.IntentMisheard, domain.IntentMissedWake, domain.IntentFeedback,
+		domain.IntentEnrollVoice, domain.IntentDiet, domain.IntentPlugin, domain.IntentCancel,
+		domain.IntentAcknowledge:
+		return false
+	}
+	return true
+}
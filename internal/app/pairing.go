@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+
+	"github.com/hammamikhairi/ottocook/internal/offline"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Drink pairings ───────────────────────────────────────────────
+//
+// "What wine goes with this?" is put to the agent under its own prompt,
+// with the whole recipe in context, so it can ask for a beer or nothing
+// alcoholic.  Without the agent, or when it can't be reached, the
+// offline table pairs by the style of dish.  The recipe detail shows the
+// table's pairing too.
+
+// pairing handles "what wine goes with this?"; request is what was said.
+func (a *Controller) pairing(ctx context.Context, request string) {
+	recipe, session := a.gatherContext(ctx)
+	if recipe == nil {
+		a.say(speech.LinePickRecipeFirst(), speech.PriorityNormal)
+		return
+	}
+	if a.agent == nil {
+		a.say(offline.PairingFor(recipe).Sentence(), speech.PriorityNormal)
+		return
+	}
+
+	a.unanswered = &aiRequest{what: "pairing: " + request, retry: func(ctx context.Context) {
+		a.pairing(ctx, request)
+	}}
+	callCtx, done := a.aiCall(ctx, "Pairing...")
+	answer, err := a.agent.Pair(callCtx, request, recipe, session)
+	done()
+	if a.aiCancelled("pairing", err) {
+		return
+	}
+	a.unanswered = nil
+	if err != nil {
+		a.log.Error("AI pairing failed: %v", err)
+		a.say(speech.LineOfflineAnswer(offline.PairingFor(recipe).Sentence()), speech.PriorityNormal)
+		return
+	}
+	a.sayOn(speech.ChannelAI, answer, speech.PriorityNormal)
+}
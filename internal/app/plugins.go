@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/plugin"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Plugins ──────────────────────────────────────────────────────
+//
+// Every executable in -plugins is started and asked for the commands it
+// handles (see package plugin).  Their commands reach runPlugin as
+// IntentPlugin, and whatever the plugin answers is spoken and printed
+// like any other reply.
+
+// pluginTimeout bounds one plugin call, so a hung plugin can't hold up
+// the input loop.
+const pluginTimeout = 10 * time.Second
+
+// runPlugin hands a plugin intent to the plugin that owns it.
+func (a *Controller) runPlugin(ctx context.Context, args domain.PluginArgs) {
+	if a.plugins == nil {
+		return
+	}
+	req := plugin.Request{Intent: args.Intent, Text: args.Text}
+	recipe, session := a.gatherContext(ctx)
+	if recipe != nil {
+		req.Recipe = recipe.Name
+	}
+	if session != nil && recipe != nil && session.CurrentStepIndex < len(recipe.Steps) {
+		req.Step = session.CurrentStepIndex + 1
+		req.StepText = recipe.Steps[session.CurrentStepIndex].Instruction
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pluginTimeout)
+	defer cancel()
+	reply, err := a.plugins.Handle(ctx, req)
+	if err != nil {
+		a.log.Error("%v", err)
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	for _, l := range reply.Print {
+		a.ui.PrintHint(l)
+	}
+	if reply.Say != "" {
+		a.say(reply.Say, speech.PriorityNormal)
+	}
+}
+
+// showPluginHelp lists the commands plugins add, under the built-in
+// ones in "help".
+func (a *Controller) showPluginHelp() {
+	if a.plugins == nil || len(a.plugins.Plugins()) == 0 {
+		return
+	}
+	a.ui.Println("")
+	a.ui.PrintStep("Plugins:")
+	for _, p := range a.plugins.Plugins() {
+		for _, in := range p.Intents {
+			line := fmt.Sprintf("  %-16s %s", in.Name, in.Description)
+			if len(in.Examples) > 0 {
+				line += fmt.Sprintf(" (e.g. %q)", in.Examples[0])
+			}
+			a.ui.PrintInstruction(line)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Reloading the config ─────────────────────────────────────────
+//
+// Restarting mid-cook would lose the session's timers and everything
+// said so far, so the settings that are safe to change underneath a
+// running session (the voice, units, thresholds, how often timers
+// remind, the display's pace and title) are re-read from the config
+// file when it changes, and on "reload".  Everything else still needs
+// a restart.
+
+// reload re-reads the config; asked is whether the cook asked for it,
+// rather than a watcher noticing the file change.
+func (a *Controller) reload(asked bool) {
+	if a.settings == nil {
+		if asked {
+			a.say(speech.LineNoConfig(), speech.PriorityLow)
+		}
+		return
+	}
+	changed, err := a.settings.Reload()
+	if err != nil {
+		a.log.Error("reloading config: %v", err)
+		a.ui.PrintUrgent(fmt.Sprintf("Config: %v", err))
+	}
+	if len(changed) == 0 {
+		if asked && err == nil {
+			a.say(speech.LineNothingReloaded(), speech.PriorityLow)
+		}
+		return
+	}
+	a.log.Info("config reloaded: %s", strings.Join(changed, ", "))
+	a.say(speech.LineReloaded(changed), speech.PriorityNormal)
+}
@@ -0,0 +1,166 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/conversation"
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Unfinished sessions ──────────────────────────────────────────
+//
+// Sessions that were still going when Otto last stopped — recovered
+// from the journal after a crash, or hibernated in a long wait — are
+// offered back at startup before the recipe list: resume one, or
+// abandon it, once the cook has said yes to losing it.  Until every one
+// is dealt with (or the cook types some other command), replies go to
+// answerUnfinished.  The ones still cooking are paused while on offer,
+// so nothing the cook hasn't picked goes off or nags; one that's left
+// alone stays paused.
+
+// unfinishedSessions merges the sessions restored at startup, most
+// recently touched first.
+func unfinishedSessions(lists ...[]*domain.Session) []*domain.Session {
+	var out []*domain.Session
+	for _, list := range lists {
+		for _, s := range list {
+			if !slices.ContainsFunc(out, func(o *domain.Session) bool { return o.ID == s.ID }) {
+				out = append(out, s)
+			}
+		}
+	}
+	slices.SortStableFunc(out, func(a, b *domain.Session) int { return b.UpdatedAt.Compare(a.UpdatedAt) })
+	return out
+}
+
+// holdUnfinished pauses the unfinished sessions that were still
+// cooking, timers and all, until the cook picks one.  Their entries in
+// a.unfinished keep the status they came back with; pickUp resumes them.
+func (a *Controller) holdUnfinished(ctx context.Context) {
+	for _, s := range a.unfinished {
+		if s.Status != domain.SessionActive {
+			continue
+		}
+		if err := a.engine.Pause(ctx, s.ID); err != nil {
+			a.log.Error("holding unfinished session %s: %v", s.ID, err)
+		}
+	}
+}
+
+// offerUnfinished lists the unfinished sessions and asks what to do.
+func (a *Controller) offerUnfinished(ctx context.Context) {
+	if len(a.unfinished) == 1 {
+		s := a.unfinished[0]
+		step, total := a.stepOf(ctx, s)
+		a.say(speech.LineUnfinishedOne(s.RecipeName, step, total), speech.PriorityNormal)
+		a.ui.PrintHintAction("resume", "resume")
+		a.ui.PrintHintAction("abandon", "abandon")
+		return
+	}
+
+	a.ui.Println("")
+	a.ui.PrintStep("Unfinished from last time:")
+	for i, s := range a.unfinished {
+		step, total := a.stepOf(ctx, s)
+		line := fmt.Sprintf("[%d] %s — step %d/%d, %s, %s", i+1, s.RecipeName, step, total, s.Status, s.UpdatedAt.Format(time.Kitchen))
+		a.ui.PrintInstructionAction(line, "resume "+s.ID)
+	}
+	a.ui.Println("")
+	a.say(speech.LineUnfinishedMany(len(a.unfinished)), speech.PriorityNormal)
+}
+
+// answerUnfinished handles a reply to offerUnfinished.  It returns false
+// when the input isn't about the unfinished sessions; they're left as
+// they are and the input is handled as a fresh command.
+func (a *Controller) answerUnfinished(ctx context.Context, input string) bool {
+	// A click on a listed session names it by ID, so it still picks
+	// that one after another has been abandoned and the list renumbered.
+	if id, ok := strings.CutPrefix(input, "resume "); ok {
+		if i := slices.IndexFunc(a.unfinished, func(s *domain.Session) bool { return s.ID == id }); i >= 0 {
+			s := a.unfinished[i]
+			a.unfinished = nil
+			a.pickUp(ctx, s)
+			return true
+		}
+	}
+
+	resume, n, ok := conversation.ParseSessionChoice(input)
+	if !ok {
+		a.log.Debug("unfinished sessions left alone for new input %q", input)
+		a.unfinished = nil
+		return false
+	}
+	if n == 0 {
+		n = 1
+	}
+	if n > len(a.unfinished) {
+		a.say(speech.LineNoSuchSession(n), speech.PriorityNormal)
+		return true
+	}
+	s := a.unfinished[n-1]
+
+	if resume {
+		a.unfinished = nil
+		a.pickUp(ctx, s)
+		return true
+	}
+
+	a.confirm(speech.LineConfirmAbandonUnfinished(s.RecipeName), "abandon unfinished", func(ctx context.Context) {
+		a.abandonUnfinished(ctx, s)
+	})
+	a.pending.declined = speech.LineUnfinishedKept(s.RecipeName)
+	return true
+}
+
+// abandonUnfinished throws s away, once the cook has confirmed it, and
+// offers what's left.
+func (a *Controller) abandonUnfinished(ctx context.Context, s *domain.Session) {
+	if err := a.engine.Abandon(ctx, s.ID); err != nil {
+		a.log.Error("abandoning session %s: %v", s.ID, err)
+	}
+	a.unfinished = slices.DeleteFunc(a.unfinished, func(o *domain.Session) bool { return o.ID == s.ID })
+	a.say(speech.LineUnfinishedAbandoned(s.RecipeName), speech.PriorityNormal)
+	if len(a.unfinished) > 0 {
+		a.offerUnfinished(ctx)
+		return
+	}
+	a.ui.Println("")
+	a.showRecipes(ctx)
+}
+
+// pickUp makes s the current session and carries on from its step.
+func (a *Controller) pickUp(ctx context.Context, s *domain.Session) {
+	a.sessionID, a.selectedRecipe = s.ID, s.RecipeID
+	switch s.Status {
+	case domain.SessionWaiting:
+		a.welcomeBack(ctx)
+	case domain.SessionPaused:
+		a.resume(ctx)
+	default:
+		// Held while on offer (see holdUnfinished).
+		if cur, err := a.engine.Status(ctx, s.ID); err == nil && cur.Status == domain.SessionPaused {
+			if _, err := a.engine.Resume(ctx, s.ID); err != nil {
+				a.log.Error("resuming session %s: %v", s.ID, err)
+			}
+		}
+		step, total := a.stepOf(ctx, s)
+		a.say(speech.LinePickedUp(s.RecipeName, step, total), speech.PriorityNormal)
+		a.showCurrentStep(ctx)
+	}
+}
+
+// stepOf is the session's 1-based current step and its recipe's step
+// count.
+func (a *Controller) stepOf(ctx context.Context, s *domain.Session) (step, total int) {
+	step = s.CurrentStepIndex + 1
+	total = step
+	if r, err := a.engine.GetRecipe(ctx, s.RecipeID); err == nil {
+		total = len(r.Steps)
+	}
+	return step, total
+}
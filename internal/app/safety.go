@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/gpt"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// screenActions runs the AI's proposed actions past the safety rules,
+// and the AI safety review when -ai-safety is on.  Blocked actions are
+// dropped, with the reason said aloud; what's left is returned with the
+// warnings the cook should hear before it's applied.
+func (a *Controller) screenActions(ctx context.Context, recipe *domain.Recipe, session *domain.Session, actions []gpt.Action) ([]gpt.Action, []string) {
+	issues := gpt.ScreenActions(recipe, actions)
+	if a.safetyReview && len(actions) > 0 {
+		callCtx, done := a.aiCall(ctx, "Checking it's safe...")
+		more, err := a.agent.SafetyReview(callCtx, actions, recipe, session)
+		done()
+		if err != nil {
+			a.log.Error("AI safety review failed: %v", err)
+		}
+		issues = append(issues, more...)
+	}
+
+	blocked := make(map[int]bool)
+	for _, is := range issues {
+		a.log.Info("safety: %s (block=%v): %s", actions[is.Action], is.Block, is.Message)
+		if is.Block && !blocked[is.Action] {
+			blocked[is.Action] = true
+			a.ui.PrintUrgent(fmt.Sprintf("Not applied — %s: %s", actions[is.Action], is.Message))
+			a.say(speech.LineSafetyBlocked(is.Message), speech.PriorityHigh)
+		}
+	}
+	var warnings []string
+	for _, is := range issues {
+		if !blocked[is.Action] && !slices.Contains(warnings, is.Message) {
+			warnings = append(warnings, is.Message)
+		}
+	}
+
+	var kept []gpt.Action
+	for i, act := range actions {
+		if !blocked[i] {
+			kept = append(kept, act)
+		}
+	}
+	return kept, warnings
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Speakers ─────────────────────────────────────────────────────
+//
+// With -speaker-model, every voice command carries a voiceprint, and
+// the closest enrolled profile is taken to be the speaker: their diet
+// then goes to the AI with whatever they ask.  "Remember my voice as
+// Sam" enrolls the voice that said it; "I'm allergic to peanuts" adds
+// to the speaker's profile, "Alex's diet is vegan" to someone else's.
+
+// identify works out who gave a voice command from its voiceprint and
+// tells the agent.  Returns the speaker's name, or "" when unknown.
+func (a *Controller) identify(voiceprint []float32) string {
+	if a.profiles == nil || voiceprint == nil {
+		return ""
+	}
+	who, score := a.profiles.Identify(voiceprint)
+	a.speaker = who
+	if who == nil {
+		a.log.Debug("speaker: unknown voice")
+		if a.agent != nil {
+			a.agent.SetSpeaker("", nil)
+		}
+		return ""
+	}
+	a.log.Debug("speaker: %s (%.2f)", who.Name, score)
+	if a.agent != nil {
+		a.agent.SetSpeaker(who.Name, who.Diet)
+	}
+	return who.Name
+}
+
+// enrollVoice handles "remember my voice as Sam", learning the voice of
+// the last voice command: this one, when it was said out loud.
+func (a *Controller) enrollVoice(name string) {
+	if a.profiles == nil {
+		a.say(speech.LineNoSpeakerID(), speech.PriorityLow)
+		return
+	}
+	heard := a.lastHeard
+	if heard == nil || heard.voiceprint == nil {
+		a.say(speech.LineNoVoiceprint(), speech.PriorityNormal)
+		return
+	}
+	who := a.profiles.Enroll(name, heard.voiceprint)
+	if err := a.profiles.Save(); err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	a.speaker = who
+	if a.agent != nil {
+		a.agent.SetSpeaker(who.Name, who.Diet)
+	}
+	a.say(speech.LineVoiceEnrolled(who.Name, len(who.Prints)), speech.PriorityNormal)
+}
+
+// noteDiet handles "I'm allergic to peanuts" and "Alex's diet is
+// vegan".
+func (a *Controller) noteDiet(args domain.DietArgs) {
+	if a.profiles == nil {
+		a.say(speech.LineNoSpeakerID(), speech.PriorityLow)
+		return
+	}
+	name, need := args.Name, args.Need
+	if name == "" {
+		if a.speaker == nil {
+			a.say(speech.LineWhoseDiet(), speech.PriorityNormal)
+			return
+		}
+		name = a.speaker.Name
+	}
+	who := a.profiles.AddDiet(name, need)
+	if err := a.profiles.Save(); err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if a.speaker == who && a.agent != nil {
+		a.agent.SetSpeaker(who.Name, who.Diet)
+	}
+	a.say(speech.LineDietNoted(who.Name, need), speech.PriorityNormal)
+}
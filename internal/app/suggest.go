@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hammamikhairi/ottocook/internal/engine"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Suggestions ──────────────────────────────────────────────────
+//
+// "What should I cook?" proposes a few recipes, numbered so a number
+// picks one, each with its reasons.  The speaker's diet, when their
+// voice is known, rules recipes out; what's been cooked this run goes to
+// the back.  On startup the recipe list ends with one such suggestion.
+
+// suggestCount is how many recipes "what should I cook?" offers.
+const suggestCount = 3
+
+// suggest handles "what should I cook?"; query is any time or
+// ingredients asked for.
+func (a *Controller) suggest(ctx context.Context, query string) {
+	picks, err := a.suggestions(ctx, query, suggestCount)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if len(picks) == 0 {
+		a.say(speech.LineNoSuggestions(), speech.PriorityNormal)
+		return
+	}
+
+	a.listed = nil
+	a.ui.PrintStep("You could make:")
+	a.ui.Println("")
+	names := make([]string, len(picks))
+	reasons := make([]string, len(picks))
+	for i, p := range picks {
+		a.listed = append(a.listed, p.Recipe)
+		names[i] = p.Recipe.Name
+		reasons[i] = speech.SuggestionReason(p.Uses, p.Season, p.Time, p.Fresh, p.Diet)
+		a.ui.PrintInstructionAction(fmt.Sprintf("[%d] %s", i+1, p.Recipe.Name), "select "+p.Recipe.ID)
+		if reasons[i] != "" {
+			a.ui.PrintHint(reasons[i])
+		}
+		a.ui.Println("")
+	}
+	a.say(speech.LineSuggestions(names, reasons), speech.PriorityNormal)
+}
+
+// offerSuggestion ends the startup recipe list with one idea.
+func (a *Controller) offerSuggestion(ctx context.Context) {
+	picks, err := a.suggestions(ctx, "", 1)
+	if err != nil || len(picks) == 0 {
+		return
+	}
+	p := picks[0]
+	line := "Not sure? How about " + p.Recipe.Name
+	if why := speech.SuggestionReason(p.Uses, p.Season, p.Time, p.Fresh, p.Diet); why != "" {
+		line += ": " + why
+	}
+	a.ui.PrintHintAction(line+". Say \"what should I cook\" for more ideas.", "what should I cook")
+}
+
+// suggestions asks the engine for up to n recipes, with the speaker's
+// diet and what's been cooked this run.
+func (a *Controller) suggestions(ctx context.Context, query string, n int) ([]engine.Suggestion, error) {
+	var diet []string
+	if a.speaker != nil {
+		diet = a.speaker.Diet
+	}
+	return a.engine.Suggest(ctx, query, diet, a.cooked, n)
+}
+
+// noteCooked remembers a finished session's recipe, so suggestions
+// move on from it.
+func (a *Controller) noteCooked(ctx context.Context, sessionID string) {
+	s, err := a.engine.Status(ctx, sessionID)
+	if err != nil {
+		return
+	}
+	a.cooked = append(a.cooked, s.RecipeID)
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Helper tasks ─────────────────────────────────────────────────
+//
+// "Give the side jobs to Sam" hands the step's parallel hints to a
+// helper; "Sam: chop the broccoli" hands over anything else.  The tasks
+// stay open across steps until "Sam's done", and the watcher reminds
+// about ones left open a while.
+
+// hintHelper labels a step's parallel hint with the helper it was
+// handed to, or "tip" when it's still the cook's.
+func hintHelper(session *domain.Session, hint string) string {
+	for _, t := range session.Tasks {
+		if t.Step == session.CurrentStepIndex && t.Text == hint {
+			return t.Helper
+		}
+	}
+	return "tip"
+}
+
+// delegate handles "give the side jobs to Sam" and "Sam: chop the
+// broccoli".
+func (a *Controller) delegate(ctx context.Context, args domain.TaskArgs) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+	helper, text := args.Helper, args.Words
+	tasks, err := a.engine.Delegate(ctx, a.sessionID, helper, text)
+	switch {
+	case errors.Is(err, domain.ErrNothingToHandOff):
+		a.say(speech.LineNothingToHandOff(), speech.PriorityNormal)
+		return
+	case err != nil:
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	var texts []string
+	for _, t := range tasks {
+		a.ui.PrintHint(fmt.Sprintf("%s: %s", t.Helper, t.Text))
+		texts = append(texts, t.Text)
+	}
+	a.say(speech.LineDelegated(tasks[0].Helper, texts), speech.PriorityNormal)
+}
+
+// finishTask handles "Sam's done" and "Sam isn't done with the
+// broccoli".  A name nobody has tasks under is taken for a step
+// condition instead: "the chicken's done".
+func (a *Controller) finishTask(ctx context.Context, args domain.TaskArgs) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+	helper, words, reopen := args.Helper, args.Words, args.Reopen
+	if known, _ := a.engine.Tasks(ctx, a.sessionID, helper); len(known) == 0 {
+		if reopen {
+			a.say(speech.LineNoTasks(helper), speech.PriorityNormal)
+			return
+		}
+		a.checkCondition(ctx, strings.TrimSpace(helper+" "+words))
+		return
+	}
+
+	tasks, err := a.engine.FinishTasks(ctx, a.sessionID, helper, words, !reopen)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		a.say(speech.LineNoSuchTask(helper), speech.PriorityNormal)
+		return
+	case err != nil:
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+
+	session, err := a.engine.Status(ctx, a.sessionID)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	var texts []string
+	for _, t := range tasks {
+		texts = append(texts, t.Text)
+	}
+	if reopen {
+		a.say(speech.LineTaskReopened(helper, texts), speech.PriorityNormal)
+		return
+	}
+	for _, t := range tasks {
+		a.ui.PrintHint(fmt.Sprintf("✓ %s: %s", t.Helper, t.Text))
+	}
+	a.say(speech.LineTaskDone(helper, len(session.OpenTasks(helper))), speech.PriorityNormal)
+}
+
+// listTasks handles "tasks" and "what's Sam doing".
+func (a *Controller) listTasks(ctx context.Context, helper string) {
+	if a.sessionID == "" {
+		a.say(speech.LineNoSession(), speech.PriorityLow)
+		return
+	}
+	tasks, err := a.engine.Tasks(ctx, a.sessionID, helper)
+	if err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	if len(tasks) == 0 {
+		a.say(speech.LineNoTasks(helper), speech.PriorityNormal)
+		return
+	}
+
+	var open []string
+	for _, t := range tasks {
+		if t.Done {
+			a.ui.PrintHint(fmt.Sprintf("✓ %s: %s", t.Helper, t.Text))
+			continue
+		}
+		a.ui.PrintChat(fmt.Sprintf("%s: %s — %s", t.Helper, t.Text, formatDuration(time.Since(t.Assigned))))
+		open = append(open, fmt.Sprintf("%s: %s", t.Helper, t.Text))
+	}
+	if a.mouth != nil {
+		a.mouth.Say(speech.LineOpenTasks(open), speech.PriorityLow)
+	}
+}
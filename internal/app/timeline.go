@@ -0,0 +1,151 @@
+package app
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/engine"
+	"github.com/hammamikhairi/ottocook/internal/gantt"
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// ── Timeline export ──────────────────────────────────────────────
+//
+// With -timeline set, a finished session is drawn (steps, timers,
+// pauses and waits, the watcher's nudges) as a Gantt chart to that HTML
+// file, with a few lines summing it up: how long it took, how long it
+// sat paused, how long timers rang unanswered.  "Show the timeline"
+// writes it on demand, mid-cook or for the session just finished, to
+// DefaultTimelinePath when -timeline isn't set.
+
+// DefaultTimelinePath is where "show the timeline" draws when Config
+// has no TimelinePath.
+const DefaultTimelinePath = "ottocook-timeline.html"
+
+// stretchClasses colours each kind of stretch on the chart.
+var stretchClasses = map[engine.StretchKind]string{
+	engine.StretchStep:    "step",
+	engine.StretchSkipped: "skipped",
+	engine.StretchTimer:   "timer",
+	engine.StretchRinging: "ringing",
+	engine.StretchPaused:  "paused",
+	engine.StretchWaiting: "waiting",
+	engine.StretchNudge:   "nudge",
+}
+
+// exportTimeline handles "show the timeline".
+func (a *Controller) exportTimeline(ctx context.Context) {
+	id := a.sessionID
+	if id == "" {
+		id = a.finished
+	}
+	if id == "" {
+		a.say(speech.LineNoTimeline(), speech.PriorityLow)
+		return
+	}
+	path := cmp.Or(a.timelinePath, DefaultTimelinePath)
+	if err := a.writeTimeline(ctx, id, path); err != nil {
+		a.ui.PrintUrgent(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	a.ui.PrintHint("Open " + path + " to see how it went.")
+	a.say(speech.LineTimelineWritten(), speech.PriorityNormal)
+}
+
+// sessionFinished writes the timeline of the session that just ended,
+// notes its recipe as cooked, takes it off the calendar, and forgets it
+// as the current one.
+func (a *Controller) sessionFinished(ctx context.Context) {
+	a.finished = a.sessionID
+	a.noteCooked(ctx, a.sessionID)
+	a.retireCalendar()
+	if a.timelinePath != "" {
+		if err := a.writeTimeline(ctx, a.sessionID, a.timelinePath); err != nil {
+			a.log.Error("writing timeline: %v", err)
+		} else {
+			a.ui.PrintHint("How it went: " + a.timelinePath)
+		}
+	}
+	a.sessionID = ""
+	a.selectedRecipe = ""
+}
+
+// writeTimeline draws a session to the HTML file at path.
+func (a *Controller) writeTimeline(ctx context.Context, sessionID, path string) error {
+	tl, err := a.engine.Timeline(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	chart := gantt.Chart{
+		Title:   tl.Recipe,
+		Summary: timelineSummary(tl),
+		Start:   tl.Start,
+		End:     tl.End,
+	}
+	for _, s := range tl.Stretches {
+		chart.Bars = append(chart.Bars, gantt.Bar{
+			Lane:   s.Lane,
+			Label:  s.Label,
+			Detail: s.Detail,
+			From:   s.From,
+			To:     s.To,
+			Class:  stretchClasses[s.Kind],
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := gantt.WriteHTML(&buf, chart); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	a.log.Info("timeline: %d stretch(es) written to %s", len(tl.Stretches), a.timelinePath)
+	return nil
+}
+
+// timelineSummary sums a timeline up in a few lines.
+func timelineSummary(tl *engine.Timeline) []string {
+	var steps, skipped, nudges int
+	var paused, waited, rang time.Duration
+	for _, s := range tl.Stretches {
+		switch s.Kind {
+		case engine.StretchStep:
+			steps++
+		case engine.StretchSkipped:
+			skipped++
+		case engine.StretchPaused:
+			paused += s.To.Sub(s.From)
+		case engine.StretchWaiting:
+			waited += s.To.Sub(s.From)
+		case engine.StretchRinging:
+			rang += s.To.Sub(s.From)
+		case engine.StretchNudge:
+			nudges++
+		}
+	}
+
+	lines := []string{fmt.Sprintf("%s from %s to %s", formatDuration(tl.End.Sub(tl.Start)), tl.Start.Format("15:04"), tl.End.Format("15:04"))}
+	done := fmt.Sprintf("%d step(s) cooked", steps)
+	if skipped > 0 {
+		done += fmt.Sprintf(", %d skipped", skipped)
+	}
+	lines = append(lines, done)
+	if paused > 0 {
+		lines = append(lines, "Paused for "+formatDuration(paused))
+	}
+	if waited > 0 {
+		lines = append(lines, "Waited hands-off for "+formatDuration(waited))
+	}
+	if rang > 0 {
+		lines = append(lines, "Timers rang for "+formatDuration(rang)+" before they were answered")
+	}
+	if nudges > 0 {
+		lines = append(lines, fmt.Sprintf("The watcher spoke up %d time(s)", nudges))
+	}
+	return lines
+}
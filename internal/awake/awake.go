@@ -0,0 +1,118 @@
+// Package awake stops the machine sleeping while a cook is under way,
+// so a laptop left on the counter doesn't suspend twenty minutes into a
+// braise and take the timers with it.  It holds a sleep inhibitor from
+// the operating system's own tool, caffeinate on macOS and
+// systemd-inhibit on Linux, run as a helper that exits with this
+// process, so a crash doesn't leave the machine unable to sleep.
+package awake
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// ErrUnsupported means there's no known way to keep this system awake.
+var ErrUnsupported = errors.New("awake: not supported on this system")
+
+// inhibitor is the platform's helper command: it keeps the machine
+// awake until it's killed or process pid exits.  A var for tests.
+var inhibitor = platformInhibitor
+
+// Lock keeps the machine awake while it's held.  A nil *Lock does
+// nothing, for when keeping awake is turned off.
+type Lock struct {
+	why string
+	log *logger.Logger
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	done   chan struct{} // closed when the helper exits
+	failed bool          // the helper couldn't start; don't try on every command
+}
+
+// New returns a Lock, not yet held.  why is shown by tools that list
+// what's keeping the machine up.
+func New(why string, log *logger.Logger) *Lock {
+	return &Lock{why: why, log: log}
+}
+
+// Set holds the lock when on is true and releases it otherwise.  It's
+// cheap to call when nothing changes.
+func (l *Lock) Set(on bool) {
+	if l == nil {
+		return
+	}
+	if on {
+		l.hold()
+	} else {
+		l.Release()
+	}
+}
+
+// Held reports whether the helper is running.
+func (l *Lock) Held() bool {
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.running()
+}
+
+// hold starts the helper unless it's already running.
+func (l *Lock) hold() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.failed || l.running() {
+		return
+	}
+	name, args, err := inhibitor(l.why, os.Getpid())
+	if err == nil {
+		cmd := exec.Command(name, args...)
+		if err = cmd.Start(); err == nil {
+			l.cmd, l.done = cmd, make(chan struct{})
+			go func(done chan struct{}) {
+				cmd.Wait()
+				close(done)
+			}(l.done)
+			l.log.Info("awake: holding with %s", name)
+			return
+		}
+	}
+	l.failed = true
+	l.log.Error("awake: can't keep the machine awake, it may sleep mid-cook: %v", err)
+}
+
+// running reports whether the helper is up.  Call with mu held.
+func (l *Lock) running() bool {
+	if l.cmd == nil {
+		return false
+	}
+	select {
+	case <-l.done:
+		l.cmd, l.done = nil, nil
+		return false
+	default:
+		return true
+	}
+}
+
+// Release stops the helper, letting the machine sleep again.
+func (l *Lock) Release() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cmd == nil {
+		return
+	}
+	l.cmd.Process.Kill()
+	<-l.done
+	l.cmd, l.done = nil, nil
+	l.log.Info("awake: released")
+}
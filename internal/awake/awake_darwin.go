@@ -0,0 +1,9 @@
+package awake
+
+import "strconv"
+
+// platformInhibitor uses caffeinate(8), which ships with macOS: -i stops
+// idle sleep, and -w ends it with this process.
+func platformInhibitor(why string, pid int) (string, []string, error) {
+	return "caffeinate", []string{"-i", "-w", strconv.Itoa(pid)}, nil
+}
@@ -0,0 +1,18 @@
+package awake
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+// platformInhibitor takes a block inhibitor lock on idle and sleep from
+// systemd-logind for as long as a tail waiting on this process runs.
+func platformInhibitor(why string, pid int) (string, []string, error) {
+	if _, err := exec.LookPath("systemd-inhibit"); err != nil {
+		return "", nil, ErrUnsupported
+	}
+	return "systemd-inhibit", []string{
+		"--what=idle:sleep", "--who=ottocook", "--why=" + why, "--mode=block",
+		"tail", "--pid=" + strconv.Itoa(pid), "-f", "/dev/null",
+	}, nil
+}
@@ -0,0 +1,8 @@
+//go:build !darwin && !linux
+
+package awake
+
+// platformInhibitor has nothing to run on systems without a known tool.
+func platformInhibitor(string, int) (string, []string, error) {
+	return "", nil, ErrUnsupported
+}
@@ -0,0 +1,54 @@
+package awake
+
+import (
+	"testing"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+func TestLock(t *testing.T) {
+	orig := inhibitor
+	t.Cleanup(func() { inhibitor = orig })
+	starts := 0
+	inhibitor = func(string, int) (string, []string, error) {
+		starts++
+		return "sleep", []string{"60"}, nil
+	}
+
+	l := New("testing", logger.New(logger.LevelOff, nil))
+	l.Set(true)
+	l.Set(true)
+	if !l.Held() || starts != 1 {
+		t.Fatalf("after two holds: held=%v, %d helper(s) started; want one", l.Held(), starts)
+	}
+	l.Set(false)
+	if l.Held() {
+		t.Fatal("still held after release")
+	}
+	l.Set(true)
+	if !l.Held() || starts != 2 {
+		t.Fatalf("held again: held=%v, %d start(s)", l.Held(), starts)
+	}
+	l.Release()
+}
+
+func TestLockUnsupported(t *testing.T) {
+	orig := inhibitor
+	t.Cleanup(func() { inhibitor = orig })
+	starts := 0
+	inhibitor = func(string, int) (string, []string, error) {
+		starts++
+		return "", nil, ErrUnsupported
+	}
+
+	l := New("testing", logger.New(logger.LevelOff, nil))
+	l.Set(true)
+	l.Set(true)
+	if l.Held() || starts != 1 {
+		t.Errorf("held=%v after %d attempt(s); want one failed attempt", l.Held(), starts)
+	}
+
+	var off *Lock
+	off.Set(true)
+	off.Release()
+}
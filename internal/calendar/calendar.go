@@ -0,0 +1,104 @@
+// Package calendar writes iCalendar (.ics) files, so the milestones of a
+// long cook — the marinade's done at six, dinner's at half seven — live
+// in the cook's calendar app and not only in a terminal that might be
+// closed.
+//
+// Only what that needs is here: timed events with a reminder, written
+// per RFC 5545.  Nothing is read back.
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// prodID names the program that wrote the file.
+const prodID = "-//ottocook//ottocook//EN"
+
+// Event is one entry in the calendar.
+type Event struct {
+	UID         string // stable across exports, so re-importing updates rather than duplicates
+	Start       time.Time
+	End         time.Time // zero for a moment in time
+	Summary     string
+	Description string
+	Alarm       bool // remind at the start
+	Cancelled   bool // taken back; importing it removes the earlier copy
+}
+
+// Write writes events as a calendar.  stamp is the time the calendar
+// was made.
+func Write(w io.Writer, events []Event, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		bw.WriteString(fold(s))
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:" + prodID)
+	line("CALSCALE:GREGORIAN")
+	for _, ev := range events {
+		end := ev.End
+		if end.IsZero() {
+			end = ev.Start
+		}
+		line("BEGIN:VEVENT")
+		line("UID:" + escape(ev.UID))
+		line("DTSTAMP:" + utc(stamp))
+		line("DTSTART:" + utc(ev.Start))
+		line("DTEND:" + utc(end))
+		line("SUMMARY:" + escape(ev.Summary))
+		if ev.Description != "" {
+			line("DESCRIPTION:" + escape(ev.Description))
+		}
+		if ev.Cancelled {
+			line("STATUS:CANCELLED")
+		} else if ev.Alarm {
+			line("BEGIN:VALARM")
+			line("ACTION:DISPLAY")
+			line("DESCRIPTION:" + escape(ev.Summary))
+			line("TRIGGER:PT0S")
+			line("END:VALARM")
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing calendar: %w", err)
+	}
+	return nil
+}
+
+func utc(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// escape escapes a TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// fold ends a content line with CRLF, folding it so no line is longer
+// than 75 octets, without splitting a UTF-8 sequence.
+func fold(s string) string {
+	var b strings.Builder
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = 74 // the leading space counts
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	return b.String()
+}
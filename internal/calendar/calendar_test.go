@@ -0,0 +1,60 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	at := time.Date(2026, 3, 14, 18, 0, 0, 0, time.FixedZone("CET", 3600))
+	var b strings.Builder
+	err := Write(&b, []Event{{
+		UID:         "s1-wait@ottocook",
+		Start:       at,
+		Summary:     "Marinade ready; back to step 3, grill",
+		Description: strings.Repeat("Turn the chicken once, ", 5) + "then grill it.",
+		Alarm:       true,
+	}}, at)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20260314T170000Z\r\n",
+		"DTEND:20260314T170000Z\r\n",
+		`SUMMARY:Marinade ready\; back to step 3\, grill` + "\r\n",
+		"TRIGGER:PT0S\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	for _, l := range strings.Split(out, "\r\n") {
+		if len(l) > 75 {
+			t.Errorf("line longer than 75 octets: %q", l)
+		}
+	}
+	if unfolded := strings.ReplaceAll(out, "\r\n ", ""); !strings.Contains(unfolded, `once\, then grill it.`) {
+		t.Errorf("description doesn't unfold back whole:\n%s", unfolded)
+	}
+}
+
+func TestWriteCancelled(t *testing.T) {
+	at := time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC)
+	var b strings.Builder
+	err := Write(&b, []Event{{UID: "s1-serve@ottocook", Start: at, Summary: "Dinner", Alarm: true, Cancelled: true}}, at)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := b.String()
+	if !strings.Contains(out, "STATUS:CANCELLED\r\n") {
+		t.Errorf("cancelled event not marked:\n%s", out)
+	}
+	if strings.Contains(out, "BEGIN:VALARM") {
+		t.Errorf("cancelled event still has a reminder:\n%s", out)
+	}
+}
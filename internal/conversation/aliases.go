@@ -0,0 +1,120 @@
+package conversation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Aliases ──────────────────────────────────────────────────────
+//
+// Everyone has their own kitchen vocabulary.  The aliases file maps a
+// phrase of the cook's own to a command Otto already knows, written the
+// way it would be typed, or to an intent by name:
+//
+//	{
+//	  "chop chop": "next",
+//	  "oi": "repeat",
+//	  "shh": "mic off",
+//	  "kettle's on": "start_timer"
+//	}
+//
+// An alias matches the whole utterance, in any case and with whisper's
+// trailing punctuation ignored, and is tried before the built-in
+// commands, so it can take over a word of theirs.
+
+// EnvAliasesFile overrides where the aliases file is looked for.
+const EnvAliasesFile = "OTTOCOOK_ALIASES"
+
+// DefaultAliasesFile returns where the aliases file is looked for:
+// $OTTOCOOK_ALIASES, else <user config dir>/ottocook/aliases.json.
+func DefaultAliasesFile() string {
+	if path := os.Getenv(EnvAliasesFile); path != "" {
+		return path
+	}
+	if cfg, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(cfg, "ottocook", "aliases.json")
+	}
+	return ""
+}
+
+// LoadAliases reads the aliases file at path, phrase to command.  A
+// missing file (or an empty path) has none.
+func LoadAliases(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var aliases map[string]string
+	if err := json.Unmarshal(data, &aliases); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return aliases, nil
+}
+
+// alias is what one of the cook's phrases stands for.  The command is
+// kept so the session can still settle it, as if it had been said.
+type alias struct {
+	intent  *domain.Intent
+	command string
+}
+
+// SetAliases replaces the parser's aliases.  Each command is parsed once
+// here, so an alias always means the same thing; one that isn't a
+// command or an intent name is dropped and reported, and the rest load.
+func (p *KeywordParser) SetAliases(aliases map[string]string) error {
+	set := make(map[string]alias, len(aliases))
+	var errs []error
+	for phrase, command := range aliases {
+		key := aliasKey(phrase)
+		if key == "" {
+			errs = append(errs, fmt.Errorf("alias %q: empty phrase", phrase))
+			continue
+		}
+		intent, ok := p.aliasIntent(command)
+		if !ok {
+			errs = append(errs, fmt.Errorf("alias %q: %q isn't a command or an intent", phrase, command))
+			continue
+		}
+		set[key] = alias{intent: intent, command: command}
+	}
+	p.aliases = set
+	return errors.Join(errs...)
+}
+
+// aliasIntent is what an alias's command means: the intent it parses to,
+// or the intent it names, e.g. "start_timer".  Aliases aren't consulted,
+// so one alias can't lean on another.
+func (p *KeywordParser) aliasIntent(command string) (*domain.Intent, bool) {
+	intent := p.parse(command)
+	if intent.Type != domain.IntentUnknown && intent.Type != domain.IntentAskQuestion {
+		return intent, true
+	}
+	name := strings.TrimSpace(strings.ToLower(command))
+	if t := domain.IntentFromString(name); t != domain.IntentUnknown && t != domain.IntentAskQuestion {
+		return &domain.Intent{Type: t}, true
+	}
+	return nil, false
+}
+
+// aliasSpace collapses the gaps between words.
+var aliasSpace = regexp.MustCompile(`\s+`)
+
+// aliasKey is how a phrase is looked up: lower case, single-spaced, and
+// without the punctuation whisper puts at the end.
+func aliasKey(s string) string {
+	s = strings.TrimRight(strings.TrimSpace(s), ".,!?")
+	return aliasSpace.ReplaceAllString(strings.ToLower(s), " ")
+}
@@ -0,0 +1,68 @@
+package conversation
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// Structure fills in intent.Args from its payload, for the intents whose
+// payload has a shape (see domain/args.go).  The payload is read the way
+// the keyword parser writes it, which is also how the AI classifier is
+// asked to.  Returns intent, for chaining.
+func Structure(intent *domain.Intent) *domain.Intent {
+	p := strings.TrimSpace(intent.Payload)
+	switch intent.Type {
+	case domain.IntentSelectRecipe:
+		if n, err := strconv.Atoi(p); err == nil {
+			intent.Args = domain.SelectRecipeArgs{Index: n}
+		} else {
+			intent.Args = domain.SelectRecipeArgs{Name: p}
+		}
+	case domain.IntentTimerControl:
+		action, target := ParseTimerCommand(p)
+		intent.Args = domain.TimerArgs{Action: action, Target: target}
+	case domain.IntentVersions:
+		var args domain.VersionArgs
+		if action, n, ok := strings.Cut(p, " "); ok && (action == "restore" || action == "start") {
+			if v, err := strconv.Atoi(n); err == nil {
+				args = domain.VersionArgs{Action: action, Version: v}
+			}
+		}
+		intent.Args = args
+	case domain.IntentCompare:
+		first, second, _ := strings.Cut(p, " vs ")
+		intent.Args = domain.CompareArgs{A: strings.TrimSpace(first), B: strings.TrimSpace(second)}
+	case domain.IntentTag:
+		tag, remove := ParseTagCommand(p)
+		intent.Args = domain.TagArgs{Tag: tag, Remove: remove}
+	case domain.IntentCollect:
+		name, remove := ParseCollectCommand(p)
+		intent.Args = domain.CollectArgs{Collection: name, Remove: remove}
+	case domain.IntentDuplicate:
+		intent.Args = domain.DuplicateArgs{Name: ParseDuplicateCommand(p)}
+	case domain.IntentNote:
+		text, keep := ParseNoteCommand(p)
+		intent.Args = domain.NoteArgs{Text: text, Keep: keep}
+	case domain.IntentHowMuch:
+		intent.Args = domain.HowMuchArgs{Ingredient: ParseHowMuch(p)}
+	case domain.IntentDelegate, domain.IntentTaskDone, domain.IntentTasks:
+		rest, reopen := strings.CutPrefix(p, "not ")
+		helper, words := splitPair(rest)
+		intent.Args = domain.TaskArgs{Helper: helper, Words: words, Reopen: reopen}
+	case domain.IntentDiet:
+		name, need := splitPair(p)
+		intent.Args = domain.DietArgs{Name: name, Need: need}
+	case domain.IntentPlugin:
+		name, text := splitPair(p)
+		intent.Args = domain.PluginArgs{Intent: name, Text: text}
+	}
+	return intent
+}
+
+// splitPair reads a "Name: words" payload.
+func splitPair(payload string) (name, words string) {
+	name, words, _ = strings.Cut(payload, ":")
+	return strings.TrimSpace(name), strings.TrimSpace(words)
+}
@@ -0,0 +1,29 @@
+package conversation
+
+import (
+	"context"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// Compile-time interface check.
+var _ domain.Notifier = (*AttentionNotifier)(nil)
+
+// AttentionNotifier wraps a Notifier and calls attend before every urgent
+// message — to ring the terminal bell, say, so a fired timer is noticed
+// even when the window isn't.
+type AttentionNotifier struct {
+	domain.Notifier
+	attend func()
+}
+
+// NewAttentionNotifier wraps inner, calling attend on urgent messages.
+func NewAttentionNotifier(inner domain.Notifier, attend func()) *AttentionNotifier {
+	return &AttentionNotifier{Notifier: inner, attend: attend}
+}
+
+// NotifyUrgent calls attend, then passes the message on.
+func (n *AttentionNotifier) NotifyUrgent(ctx context.Context, message string) error {
+	n.attend()
+	return n.Notifier.NotifyUrgent(ctx, message)
+}
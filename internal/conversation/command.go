@@ -0,0 +1,60 @@
+package conversation
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// Compile-time interface check.
+var _ domain.Notifier = (*CommandNotifier)(nil)
+
+// commandTimeout bounds how long a notification command may run.
+const commandTimeout = 10 * time.Second
+
+// CommandNotifier sends notifications out of the app by running a
+// command with the message as its last argument — "notify-send Otto" for
+// the desktop, "ntfy publish kitchen" for a phone.
+type CommandNotifier struct {
+	name string
+	args []string
+	log  *logger.Logger
+}
+
+// NewCommandNotifier creates a notifier that runs command, split on
+// spaces, for every message.
+func NewCommandNotifier(command string, log *logger.Logger) *CommandNotifier {
+	fields := strings.Fields(command)
+	n := &CommandNotifier{log: log}
+	if len(fields) > 0 {
+		n.name, n.args = fields[0], fields[1:]
+	}
+	return n
+}
+
+// Notify runs the command with the message.
+func (n *CommandNotifier) Notify(ctx context.Context, message string) error {
+	if n.name == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	args := append(append([]string{}, n.args...), message)
+	out, err := exec.CommandContext(ctx, n.name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("running %s: %w: %s", n.name, err, strings.TrimSpace(string(out)))
+	}
+	n.log.Debug("notify command %s: %s", n.name, message)
+	return nil
+}
+
+// NotifyUrgent is Notify; the command decides how loud to be.
+func (n *CommandNotifier) NotifyUrgent(ctx context.Context, message string) error {
+	return n.Notify(ctx, message)
+}
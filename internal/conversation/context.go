@@ -0,0 +1,49 @@
+package conversation
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Reading the session ──────────────────────────────────────────
+//
+// A few short words mean different things at different points of a
+// cook.  "Ok" while a timer is going off dismisses it; any other time
+// it's only the cook agreeing, and dismissing a timer that's still
+// running would be a nasty surprise.  "Start" with a timer waiting on
+// the cook starts the timer; otherwise it starts cooking.
+
+var (
+	// ackCommand is the cook agreeing, thanking, or dismissing a timer.
+	ackCommand = regexp.MustCompile(`(?i)^(?:(?:ok(?:ay)?|got it|acknowledged|thanks|thank you|cheers|cool)[,.!]?\s*)+$`)
+	// startWord is "start" on its own, not "start cooking" or "start timer".
+	startWord = regexp.MustCompile(`(?i)^start[.!]?$`)
+)
+
+// inContext settles what an intent means in the session it was said in;
+// session is nil before one starts.
+func inContext(intent *domain.Intent, input string, session *domain.Session) *domain.Intent {
+	input = strings.TrimSpace(input)
+	switch {
+	case intent.Type == domain.IntentAcknowledge && hasTimer(session, domain.TimerFired):
+		return &domain.Intent{Type: domain.IntentDismissTimer, Payload: input}
+	case intent.Type == domain.IntentStartCooking && startWord.MatchString(input) && hasTimer(session, domain.TimerPending):
+		return &domain.Intent{Type: domain.IntentStartTimer}
+	}
+	return intent
+}
+
+// hasTimer reports whether any of the session's timers is in status.
+func hasTimer(session *domain.Session, status domain.TimerStatus) bool {
+	if session == nil {
+		return false
+	}
+	for _, ts := range session.TimerStates {
+		if ts.Status == status {
+			return true
+		}
+	}
+	return false
+}
@@ -4,7 +4,10 @@ package conversation
 import (
 	"context"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/hammamikhairi/ottocook/internal/domain"
 	"github.com/hammamikhairi/ottocook/internal/logger"
@@ -18,6 +21,7 @@ var _ domain.IntentParser = (*KeywordParser)(nil)
 type KeywordParser struct {
 	log      *logger.Logger
 	patterns []patternRule
+	aliases  map[string]alias // the cook's own phrases, by aliasKey
 }
 
 type patternRule struct {
@@ -31,36 +35,101 @@ func NewKeywordParser(log *logger.Logger) *KeywordParser {
 	p.patterns = []patternRule{
 		{regexp.MustCompile(`(?i)^(next|done|continue|n|advance)$`), domain.IntentAdvance},
 		{regexp.MustCompile(`(?i)^(skip|s)$`), domain.IntentSkip},
+		{skipCommand, domain.IntentSkip},
+		{goToStep, domain.IntentSkip},
 		{regexp.MustCompile(`(?i)^(repeat|again|what\??|r|re)$`), domain.IntentRepeat},
 		{regexp.MustCompile(`(?i)^(repeat last|say that again|what did you say|come again)$`), domain.IntentRepeatLast},
+		{regexp.MustCompile(`(?i)^(what were you saying|you were saying|go on|carry on|keep going|finish what you were saying)\??$`), domain.IntentResumeLast},
+		{cancelCommand, domain.IntentCancel},
+		{missedWake, domain.IntentMissedWake},
+		{misheardCommand, domain.IntentMisheard},
+		{misheardSaid, domain.IntentMisheard}, // two words at most: "no, I said add two eggs" is an instruction
+		{checkCommand, domain.IntentCheck},
+		{calendarCommand, domain.IntentCalendar},
+		{timelineCommand, domain.IntentTimeline},
+		{compareCommand, domain.IntentCompare},
+		{pairingCommand, domain.IntentPairing},
+		{suggestCommand, domain.IntentSuggest},
+		{enrollVoice, domain.IntentEnrollVoice},
+		{enrollVoiceIs, domain.IntentEnrollVoice},
+		{dietMine, domain.IntentDiet},
+		{dietNamed, domain.IntentDiet},
+		{dietAllergic, domain.IntentDiet},
+		{dietAvoid, domain.IntentDiet},
+		{micOff, domain.IntentMic},
+		{micOn, domain.IntentMic},
+		{voiceOff, domain.IntentVoice},
+		{voiceOn, domain.IntentVoice},
+		{reloadCommand, domain.IntentReload},
+		{feedbackUp, domain.IntentFeedback},
+		{feedbackDown, domain.IntentFeedback},
 		{regexp.MustCompile(`(?i)^(pause|brb|wait|p)$`), domain.IntentPause},
 		{regexp.MustCompile(`(?i)^(resume|back|continue|unpause)$`), domain.IntentResume},
 		{regexp.MustCompile(`(?i)^(status|where|progress|info)$`), domain.IntentStatus},
-		{regexp.MustCompile(`(?i)^(quit|exit|stop|q|abandon)$`), domain.IntentQuit},
+		{regexp.MustCompile(`(?i)^(quit|exit|stop|q|abandon(?: (?:it|this|this recipe|the recipe|the session|cooking))?)[.!]?$`), domain.IntentQuit},
 		{regexp.MustCompile(`(?i)^(help|h|\?)$`), domain.IntentHelp},
-		{regexp.MustCompile(`(?i)^(dismiss|ok|got it|acknowledged)$`), domain.IntentDismissTimer},
+		{helpCommand, domain.IntentHelp},
+		{regexp.MustCompile(`(?i)^dismiss$`), domain.IntentDismissTimer},
+		{ackCommand, domain.IntentAcknowledge},
 		{regexp.MustCompile(`(?i)^dismiss\b`), domain.IntentDismissTimer},
 		{regexp.MustCompile(`(?i)^(list|recipes|show|browse)$`), domain.IntentListRecipes},
 		{regexp.MustCompile(`(?i)^(start|cook|go|begin|let'?s go)$`), domain.IntentStartCooking},
 		{regexp.MustCompile(`(?i)^(timer|start timer|ready|set timer)$`), domain.IntentStartTimer},
+		{startAllTimers, domain.IntentStartTimer},
+		{timerCommand, domain.IntentTimerControl},
+		{serveCommand, domain.IntentServeTime},
+		{serveClear, domain.IntentServeTime},
+		{prepCommand, domain.IntentPrepList},
+		{howMuchCommand, domain.IntentHowMuch},
+		{versionsCommand, domain.IntentVersions},
+		{versionPick, domain.IntentVersions},
+		{searchPattern, domain.IntentSearch},
+		{regexp.MustCompile(`(?i)^(list|show|browse) \S`), domain.IntentListRecipes},
+		{regexp.MustCompile(`(?i)^(un)?tag\b`), domain.IntentTag},
+		{collectCommand, domain.IntentCollect},
+		// "copy" alone is radio-speak for "understood", so it needs an object.
+		{regexp.MustCompile(`(?i)^((duplicate|fork|make a copy)\b|copy (it|this|this recipe|the recipe)\b|save (it|this|this recipe) as\b)`), domain.IntentDuplicate},
+		{noteCommand, domain.IntentNote},
+		{keepNotesCommand, domain.IntentNote},
 		// Modify intent — explicit keywords at the start.
 		{regexp.MustCompile(`(?i)^(modify|change|swap|replace|double|halve|adjust|substitute)\b`), domain.IntentModify},
+		// Helper tasks last: "Sam: …" would otherwise take "note: …".
+		{delegateTo, domain.IntentDelegate},
+		{delegateTask, domain.IntentDelegate},
+		{taskDone, domain.IntentTaskDone},
+		{taskUndone, domain.IntentTaskDone},
+		{tasksCommand, domain.IntentTasks},
 	}
 	return p
 }
 
-// Parse converts user input into an intent.
+// Parse converts user input into an intent, with its Args filled in.
+// The session, nil before one starts, settles the few words whose
+// meaning depends on it (see inContext).
 func (p *KeywordParser) Parse(ctx context.Context, input string, session *domain.Session) (*domain.Intent, error) {
+	if alias, ok := p.aliases[aliasKey(input)]; ok {
+		p.log.Debug("alias %q: %s", input, alias.intent.Type)
+		intent := *alias.intent
+		return Structure(inContext(&intent, alias.command, session)), nil
+	}
+	return Structure(inContext(p.parse(input), input, session)), nil
+}
+
+func (p *KeywordParser) parse(input string) *domain.Intent {
 	trimmed := strings.TrimSpace(input)
 	if trimmed == "" {
-		return &domain.Intent{Type: domain.IntentUnknown}, nil
+		return &domain.Intent{Type: domain.IntentUnknown}
 	}
 
 	p.log.Debug("parsing input: %q", trimmed)
 
 	// Check for recipe selection by number (e.g., "1", "2", "3").
 	if len(trimmed) <= 2 && isDigits(trimmed) {
-		return &domain.Intent{Type: domain.IntentSelectRecipe, Payload: trimmed}, nil
+		return &domain.Intent{Type: domain.IntentSelectRecipe, Payload: trimmed}
+	}
+	// ...or by voice: "two", "number 3", "the first one".
+	if n, ok := spokenChoice(trimmed); ok {
+		return &domain.Intent{Type: domain.IntentSelectRecipe, Payload: strconv.Itoa(n)}
 	}
 
 	// Check keyword patterns.
@@ -68,10 +137,93 @@ func (p *KeywordParser) Parse(ctx context.Context, input string, session *domain
 		if rule.regex.MatchString(trimmed) {
 			p.log.Debug("matched intent: %s", rule.intent)
 			// Carry the full input as payload for intents that need it.
-			if rule.intent == domain.IntentModify || rule.intent == domain.IntentDismissTimer {
-				return &domain.Intent{Type: rule.intent, Payload: trimmed}, nil
+			if rule.intent == domain.IntentDismissTimer {
+				return &domain.Intent{Type: rule.intent, Payload: dismissPayload(trimmed)}
+			}
+			if rule.intent == domain.IntentModify || rule.intent == domain.IntentTimerControl ||
+				rule.intent == domain.IntentTag || rule.intent == domain.IntentCollect ||
+				rule.intent == domain.IntentDuplicate || rule.intent == domain.IntentNote ||
+				rule.intent == domain.IntentHowMuch || rule.intent == domain.IntentPairing {
+				return &domain.Intent{Type: rule.intent, Payload: trimmed}
+			}
+			if rule.intent == domain.IntentStartTimer && rule.regex == startAllTimers {
+				return &domain.Intent{Type: rule.intent, Payload: "all"}
+			}
+			if rule.intent == domain.IntentServeTime {
+				if rule.regex == serveClear {
+					return &domain.Intent{Type: rule.intent, Payload: "clear"}
+				}
+				return &domain.Intent{Type: rule.intent, Payload: serveCommand.FindStringSubmatch(trimmed)[1]}
+			}
+			if rule.intent == domain.IntentSuggest {
+				return &domain.Intent{Type: rule.intent, Payload: strings.TrimSpace(suggestCommand.FindStringSubmatch(trimmed)[1])}
+			}
+			if rule.intent == domain.IntentCompare {
+				payload, ok := comparePayload(trimmed)
+				if !ok {
+					continue
+				}
+				return &domain.Intent{Type: rule.intent, Payload: payload}
+			}
+			if rule.intent == domain.IntentVersions && rule.regex == versionPick {
+				return &domain.Intent{Type: rule.intent, Payload: versionPayload(trimmed)}
+			}
+			if rule.intent == domain.IntentCheck {
+				m := checkCommand.FindStringSubmatch(trimmed)
+				return &domain.Intent{Type: rule.intent, Payload: strings.TrimSpace(m[1] + m[2])}
+			}
+			if rule.intent == domain.IntentDelegate || rule.intent == domain.IntentTaskDone || rule.intent == domain.IntentTasks {
+				payload, ok := taskPayload(rule.regex, trimmed)
+				if !ok {
+					continue
+				}
+				return &domain.Intent{Type: rule.intent, Payload: payload}
+			}
+			if rule.intent == domain.IntentEnrollVoice {
+				name := []rune(rule.regex.FindStringSubmatch(trimmed)[1])
+				name[0] = unicode.ToUpper(name[0])
+				return &domain.Intent{Type: rule.intent, Payload: string(name)}
+			}
+			if rule.intent == domain.IntentDiet {
+				return &domain.Intent{Type: rule.intent, Payload: dietPayload(rule.regex, trimmed)}
+			}
+			if rule.intent == domain.IntentMic || rule.intent == domain.IntentVoice {
+				state := "on"
+				if rule.regex == micOff || rule.regex == voiceOff {
+					state = "off"
+				}
+				return &domain.Intent{Type: rule.intent, Payload: state}
+			}
+			if rule.intent == domain.IntentFeedback {
+				rating := "up"
+				if rule.regex == feedbackDown {
+					rating = "down"
+				}
+				return &domain.Intent{Type: rule.intent, Payload: rating}
+			}
+			if rule.intent == domain.IntentMisheard {
+				return &domain.Intent{Type: rule.intent, Payload: misheardPayload(trimmed)}
+			}
+			if rule.intent == domain.IntentSkip && rule.regex == goToStep {
+				target, ok := stepTarget(goToStep.FindStringSubmatch(trimmed)[1])
+				if !ok {
+					continue
+				}
+				return &domain.Intent{Type: rule.intent, Payload: target}
 			}
-			return &domain.Intent{Type: rule.intent}, nil
+			if rule.intent == domain.IntentSkip {
+				return &domain.Intent{Type: rule.intent, Payload: skipTarget(trimmed)}
+			}
+			if rule.intent == domain.IntentHelp {
+				return &domain.Intent{Type: rule.intent, Payload: helpTopic(trimmed)}
+			}
+			if rule.intent == domain.IntentSearch {
+				return &domain.Intent{Type: rule.intent, Payload: searchQuery(trimmed)}
+			}
+			if rule.intent == domain.IntentListRecipes {
+				return &domain.Intent{Type: rule.intent, Payload: listFilter(trimmed)}
+			}
+			return &domain.Intent{Type: rule.intent}
 		}
 	}
 
@@ -79,17 +231,467 @@ func (p *KeywordParser) Parse(ctx context.Context, input string, session *domain
 	if strings.HasPrefix(strings.ToLower(trimmed), "select ") || strings.HasPrefix(strings.ToLower(trimmed), "pick ") {
 		parts := strings.SplitN(trimmed, " ", 2)
 		if len(parts) == 2 {
-			return &domain.Intent{Type: domain.IntentSelectRecipe, Payload: strings.TrimSpace(parts[1])}, nil
+			pick := strings.TrimSpace(parts[1])
+			if n, ok := spokenChoice(pick); ok {
+				pick = strconv.Itoa(n) // "pick the second one"
+			}
+			return &domain.Intent{Type: domain.IntentSelectRecipe, Payload: pick}
 		}
 	}
 
 	// Detect questions: ends with "?", or starts with a question word.
 	if isQuestion(trimmed) {
-		return &domain.Intent{Type: domain.IntentAskQuestion, Payload: trimmed}, nil
+		return &domain.Intent{Type: domain.IntentAskQuestion, Payload: trimmed}
 	}
 
 	p.log.Debug("no match, returning unknown intent")
-	return &domain.Intent{Type: domain.IntentUnknown, Payload: trimmed}, nil
+	return &domain.Intent{Type: domain.IntentUnknown, Payload: trimmed}
+}
+
+var (
+	// searchPattern matches "find me something with broccoli", "search
+	// for pasta", "anything with leeks?", "recipes using rice".
+	searchPattern = regexp.MustCompile(`(?i)^((find|search|look for|look up)\b|(show me |got )?(something|anything|recipes?|dishes) (with|using|that (use|uses|has|have)|containing|made with)\b)`)
+	// searchFiller is stripped from a search to leave the query.
+	searchFiller = regexp.MustCompile(`(?i)^((find|search|look|show|got)( (me|for|up))*\s+)?((something|anything|recipes?|a recipe|dishes)\s+)?((with|using|that (use|uses|has|have)|containing|made with)\s+)?`)
+
+	spokenChoicePattern = regexp.MustCompile(`(?i)^(?:number |the |option )?([a-z]+|\d{1,2}(?:st|nd|rd|th)?)(?: one)?[.!]?$`)
+)
+
+// searchQuery strips the command words from a search request.
+func searchQuery(input string) string {
+	q := searchFiller.ReplaceAllString(strings.TrimSpace(input), "")
+	return strings.TrimRight(strings.TrimSpace(q), ".?!")
+}
+
+// numberWords are the numbers whisper spells out, as counted and as
+// ordered; past twenty it writes digits.
+var numberWords = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+	"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19, "twenty": 20,
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5,
+	"sixth": 6, "seventh": 7, "eighth": 8, "ninth": 9, "tenth": 10,
+	"eleventh": 11, "twelfth": 12, "thirteenth": 13, "fourteenth": 14, "fifteenth": 15,
+	"sixteenth": 16, "seventeenth": 17, "eighteenth": 18, "nineteenth": 19, "twentieth": 20,
+}
+
+// ordinalSuffix is the "rd" of "3rd".
+var ordinalSuffix = regexp.MustCompile(`(?i)^(\d+)(?:st|nd|rd|th)$`)
+
+// spokenNumber reads one word as a number: "3", "3rd", "three", "third".
+func spokenNumber(w string) (int, bool) {
+	if m := ordinalSuffix.FindStringSubmatch(w); m != nil {
+		w = m[1]
+	}
+	if n, err := strconv.Atoi(w); err == nil {
+		return n, n > 0
+	}
+	n, ok := numberWords[strings.ToLower(w)]
+	return n, ok
+}
+
+// spokenChoice reads a list pick the way whisper transcribes it:
+// "Two.", "number 3", "the first one", "the 3rd".
+func spokenChoice(s string) (int, bool) {
+	m := spokenChoicePattern.FindStringSubmatch(s)
+	if m == nil || isDigits(s) {
+		// Bare digits are handled by the caller; this is "number 3".
+		return 0, false
+	}
+	return spokenNumber(m[1])
+}
+
+// stepTarget reads a step by number as a skip target: "step five",
+// "step number 3", "the third step" → "step 3".
+func stepTarget(ref string) (string, bool) {
+	m := stepRef.FindStringSubmatch(strings.TrimSpace(ref))
+	if m == nil {
+		return "", false
+	}
+	n, ok := spokenNumber(m[1] + m[2])
+	if !ok {
+		return "", false
+	}
+	return "step " + strconv.Itoa(n), true
+}
+
+var (
+	tagCommand       = regexp.MustCompile(`(?i)^(un)?tag\s+(?:(?:it|this|this recipe)\s+)?(?:(?:as|with)\s+)?(.+?)[.!]?$`)
+	collectCommand   = regexp.MustCompile(`(?i)^(?:(?:add|save|put)|(remove)) (?:it|this|this recipe|that) (?:to|in|into|from) (?:my |the )?(.+?)(?: collection)?[.!]?$`)
+	duplicateCommand = regexp.MustCompile(`(?i)^(?:duplicate|copy|fork|save|make a copy)(?: of)?(?: (?:it|this|this recipe|the recipe))?(?: (?:as|called|named)(?: a)? (.+?))?[.!]?$`)
+	noteCommand      = regexp.MustCompile(`(?i)^(?:make a note|add a note|note|remember)(?:\s+(for next time))?(?:\s*[:,-]\s*|\s+)(?:that\s+)?(.+?)(\s*,?\s*(?:for|next) time)?[.!]?$`)
+	keepNotesCommand = regexp.MustCompile(`(?i)^(?:keep|save) (?:my |the |these |those )?notes\b`)
+	helpCommand      = regexp.MustCompile(`(?i)^help(?: (?:me )?(?:with|on|for))?\s+(.+?)[.?!]?$`)
+	listCommand      = regexp.MustCompile(`(?i)^(?:list|show|browse)(?: me)?(?: my| the| all)?\s*(.*?)(?: recipes)?[.!]?$`)
+	startAllTimers   = regexp.MustCompile(`(?i)^start (?:all|every)(?: (?:the|of the|my))? (?:pending )?timers?[.!]?$`)
+	timerCommand     = regexp.MustCompile(`(?i)^(pause|hold|freeze|resume|unpause|unfreeze|cancel|kill|delete|restart|reset|redo)\s+(.*\btimers?\b.*?)[.!]?$`)
+	serveCommand     = regexp.MustCompile(`(?i)^(?:(?:serve|serving|dinner|lunch|supper|breakfast|food)(?:'s| is)?(?: ready)?|(?:i|we) want to eat|(?:i|we) want (?:it|dinner|food) ready|we(?:'re| are) eating|(?:let'?s )?eat|ready) (?:at|by) (.+?)[.!]?$`)
+	prepCommand      = regexp.MustCompile(`(?i)^(?:(?:what|things|stuff)\b.*\b(?:to (?:chop|cut|prep|slice|dice)|to be (?:chopped|cut|prepped|sliced|diced))\b.*|(?:show |read |give )?(?:me )?(?:the |my )?(?:prep(?: list| work)?|knife work|mise en place)(?: list)?[.?!]?)$`)
+	howMuchCommand   = regexp.MustCompile(`(?i)^how (?:much|many) (?:(?:\w+ )?of )?(?:the |my )?(.+?)(?: (?:do|should|did) (?:i|we|you) (?:need|use|add|put in)| (?:goes|go) in| (?:is|are) (?:in )?(?:it|this|that|there)| again| in (?:it|this|the recipe|total))*[.?!]?$`)
+	versionsCommand  = regexp.MustCompile(`(?i)^(?:show |list )?(?:me )?(?:the |all )?(?:recipe |old |previous )?(?:versions|version history|revisions)[.!?]?$`)
+	versionPick      = regexp.MustCompile(`(?i)^(cook|start|make|use|restore|go back to|revert to|roll back to)(?: from)?(?: the)? (?:version|v) ?(\d+|[a-z]+)[.!]?$`)
+	serveClear       = regexp.MustCompile(`(?i)^(?:clear|cancel|forget|remove|drop)(?: the)? (?:serve|serving|dinner|target) time[.!]?$`)
+	clockTime        = regexp.MustCompile(`(?i)^(?:around |about )?(\d{1,2})(?:[:.h](\d{2}))?\s*(am|pm|a\.m\.|p\.m\.)?(?: (?:tonight|today))?$`)
+	timerWord        = regexp.MustCompile(`(?i)\btimers?\b`)
+	dismissTimerRef  = regexp.MustCompile(`(?i)^dismiss\s+(?:timer\s+)?(.+?)[.!]?$`)
+	missedWake       = regexp.MustCompile(`(?i)^(?:(?:you )?(?:didn'?t|did not|never) hear me(?: the first time| calling(?: you)?| say hey chef)?|you missed (?:me|the wake word)|i (?:already )?(?:said hey chef|called you)(?: already| twice)?)[.!]?$`)
+	misheardCommand  = regexp.MustCompile(`(?i)^(?:no[,.!]?\s+)?(?:that'?s not what i said|that is not what i said|you misheard(?: me)?|you heard (?:me )?wrong|i didn'?t say (?:that|anything|a thing)|nobody said anything|i wasn'?t talking to you)(?:[,.!;:]?\s*(?:i said|i meant)\s+(.+?))?[.!]?$`)
+	misheardSaid     = regexp.MustCompile(`(?i)^(?:no[,.!]?\s+)?i (?:said|meant)\s+([^\s.!]+(?:\s+[^\s.!]+)?)[.!]?$`)
+	nothingSaid      = regexp.MustCompile(`(?i)\b(?:didn'?t say (?:anything|a thing)|nobody said anything|wasn'?t talking to you)\b|^(?:no[,.!]?\s+)?i said nothing[.!]?$`)
+	checkCommand     = regexp.MustCompile(`(?i)^(?:(?:check|tick) off|checked|ticked|mark)(?:\s+(.+?))?(?: as (?:done|met))?[.!]?$|^condition\s+(\S+)(?: is)? (?:done|met|checked)[.!]?$`)
+	calendarCommand  = regexp.MustCompile(`(?i)^(?:(?:add|put|export|save|send)(?: it| this| that| (?:the )?(?:times|milestones|reminders|schedule|timers))? (?:to|in|into|on) (?:my |the )?(?:calendar|reminders|phone)|export (?:the )?(?:calendar|ics|milestones|schedule)|calendar)[.!]?$`)
+	suggestCommand   = regexp.MustCompile(`(?i)^(?:what (?:should|shall|can|could) (?:i|we) (?:cook|make|have)|what'?s for (?:dinner|lunch|tea)|(?:suggest|recommend) (?:me )?(?:something|a recipe|a dish|what to cook)|any (?:ideas|suggestions)|i don'?t know what to (?:cook|make)|surprise me)\b,?\s*(.*?)[.?!]?$`)
+	pairingCommand   = regexp.MustCompile(`(?i)^(?:(?:any |a |the |suggest (?:a )?)?(?:wine|drinks?|beverage|beer) pairings?|pairings?|what (?:wine|drinks?|beverage|beer)s? (?:goes|go|pairs?|would go|should (?:i|we) (?:serve|have|open|drink))|what (?:should|can|do) (?:i|we) drink|what to drink)\b(?:[,\s].*)?$`)
+	compareCommand   = regexp.MustCompile(`(?i)^compare\s+(.+?)[.!?]?$`)
+	compareSplit     = regexp.MustCompile(`(?i)\s+(?:and|with|to|against|vs\.?|versus)\s+|\s*,\s*`)
+	timelineCommand  = regexp.MustCompile(`(?i)^(?:(?:show|export|save|draw|make)(?: me)? (?:the |a |my )?(?:session |cooking |cook )?timeline|timeline)[.!]?$`)
+	enrollVoice      = regexp.MustCompile(`(?i)^(?:remember|learn|save) my voice as (\p{L}[\p{L}'-]*)[.!]?$`)
+	enrollVoiceIs    = regexp.MustCompile(`(?i)^(?:this is|it'?s|i'?m|i am) (\p{L}[\p{L}'-]*)[,.]? (?:remember|learn|save) my voice[.!]?$`)
+	dietMine         = regexp.MustCompile(`(?i)^my diet(?: is)?:? (.+?)[.!]?$`)
+	dietNamed        = regexp.MustCompile(`(?i)^(\p{L}[\p{L}-]*)'s diet(?: is)?:? (.+?)[.!]?$`)
+	dietAllergic     = regexp.MustCompile(`(?i)^(?:i'?m|i am) allergic to (.+?)[.!]?$`)
+	dietAvoid        = regexp.MustCompile(`(?i)^i (?:don'?t|do not|can'?t|cannot) eat (.+?)[.!]?$`)
+	cancelCommand    = regexp.MustCompile(`(?i)^(?:never ?mind|forget (?:about )?it|cancel (?:that|it|the question)|stop thinking|drop it)[.!]?$`)
+	micOff           = regexp.MustCompile(`(?i)^(?:(?:mute|turn off|switch off|disable|kill)(?: the| your)? (?:mic|microphone)|(?:mic|microphone) off|stop listening|privacy mode(?: on)?)[.!]?$`)
+	micOn            = regexp.MustCompile(`(?i)^(?:(?:unmute|turn on|switch on|enable)(?: the| your)? (?:mic|microphone)|(?:mic|microphone) on|start listening(?: again)?|privacy mode off)[.!]?$`)
+	voiceOff         = regexp.MustCompile(`(?i)^(?:(?:turn|switch|shut) (?:off|down)(?: the| your)? (?:voice|audio|speech)|(?:turn|switch|shut)(?: the| your)? (?:voice|audio|speech) (?:off|down)|(?:voice|audio|speech) off|disable(?: the| your)? (?:voice|audio|speech)|text only(?: mode)?)[.!]?$`)
+	voiceOn          = regexp.MustCompile(`(?i)^(?:(?:turn|switch) on(?: the| your)? (?:voice|audio|speech)|(?:turn|switch)(?: the| your)? (?:voice|audio|speech) (?:back )?on|(?:voice|audio|speech) (?:back )?on|enable(?: the| your)? (?:voice|audio|speech))[.!]?$`)
+	reloadCommand    = regexp.MustCompile(`(?i)^(?:reload|re-?read)(?: the| your)?(?: (?:config|configuration|settings|config file))?[.!]?$`)
+	feedbackUp       = regexp.MustCompile(`(?i)^(?:that'?s a |that was a )?(?:good|great|helpful|nice) answer[.!]?$|^thumbs up[.!]?$|^(?:that'?s|that is|that was) (?:right|correct|helpful)[.!]?$`)
+	feedbackDown     = regexp.MustCompile(`(?i)^(?:that'?s a |that was a )?(?:bad|wrong|unhelpful) answer[.!]?$|^thumbs down[.!]?$|^(?:no[,.!]?\s+)?(?:that'?s|that is|that was) (?:wrong|not right|incorrect|not correct|not helpful)[.!]?$`)
+	delegateTo       = regexp.MustCompile(`(?i)^(?:delegate|hand|give|pass)(?: (?:this|that|it|them|the (?:side |other )?(?:tasks?|jobs?)))?(?: (?:off|over))? to ([a-z][\w-]*)[.!]?$`)
+	delegateTask     = regexp.MustCompile(`(?i)^(?:(?:ask|tell|get) ([a-z][\w-]*) to|([a-z][\w-]*):|([a-z][\w-]*) (?:can|could|will) do)\s+(.+?)[.!]?$`)
+	taskDone         = regexp.MustCompile(`(?i)^([a-z][\w-]*)(?:'s| is| has)? (?:done|finished)(?: (?:with )?(.+?))?[.!]?$`)
+	taskUndone       = regexp.MustCompile(`(?i)^([a-z][\w-]*)(?:'s not| is not| isn'?t| hasn'?t| has not) (?:done|finished)(?: (?:with )?(.+?))?(?: yet)?[.!]?$`)
+	tasksCommand     = regexp.MustCompile(`(?i)^(?:(?:show |list )?(?:the |my )?(?:helper )?(?:tasks|jobs)|what(?:'s| is) ([a-z][\w-]*) (?:doing|working on|on))[.?!]?$`)
+	skipCommand      = regexp.MustCompile(`(?i)^skip\s+(?:ahead\s+)?(.+?)[.!]?$`)
+	goToStep         = regexp.MustCompile(`(?i)^(?:go|jump|move|fast forward)(?: ahead| forward| on)? to (.+?)[.!]?$`)
+	stepRef          = regexp.MustCompile(`(?i)^(?:the )?(?:step (?:number )?(\w+)|(\w+) step)$`)
+	skipSection      = regexp.MustCompile(`(?i)^(?:the )?(?:rest of )?(?:the |this )?(?:section|part)$`)
+	skipToSection    = regexp.MustCompile(`(?i)^to (?:the )?(.+?)(?: section| part)?$`)
+	skipNamed        = regexp.MustCompile(`(?i)^(?:the )?(.+?)(?: section| part| steps?)?$`)
+)
+
+// misheardPayload is what the cook says they actually said: "nothing"
+// when they weren't talking to Otto at all, or "" when they didn't say.
+func misheardPayload(input string) string {
+	if nothingSaid.MatchString(input) {
+		return "nothing"
+	}
+	for _, re := range []*regexp.Regexp{misheardCommand, misheardSaid} {
+		if m := re.FindStringSubmatch(input); m != nil {
+			return strings.Trim(m[1], `"' `)
+		}
+	}
+	return ""
+}
+
+// dietPayload reads a dietary need as "Name: need", with no name when
+// it's the speaker's own:
+//
+//	"my diet is vegetarian"     → ": vegetarian"
+//	"Alex's diet is vegan"      → "Alex: vegan"
+//	"I'm allergic to peanuts"   → ": allergic to peanuts"
+//	"I don't eat pork"          → ": no pork"
+func dietPayload(re *regexp.Regexp, input string) string {
+	m := re.FindStringSubmatch(input)
+	switch re {
+	case dietNamed:
+		return m[1] + ": " + m[2]
+	case dietAllergic:
+		return ": allergic to " + m[1]
+	case dietAvoid:
+		return ": no " + m[1]
+	}
+	return ": " + m[1]
+}
+
+// skipTarget reads what a "skip ..." command is aimed at:
+//
+//	"skip this step"                → ""         (just the current step)
+//	"skip the rest of this section" → "section"
+//	"skip to the sauce"             → "to sauce"
+//	"skip to step five"             → "step 5"
+//	"skip the garnish"              → "garnish"  (decline an optional section)
+func skipTarget(input string) string {
+	m := skipCommand.FindStringSubmatch(strings.TrimSpace(input))
+	if m == nil {
+		return ""
+	}
+	rest := strings.ToLower(m[1])
+	switch rest {
+	case "it", "this", "that", "step", "this step", "that step", "this one", "the step":
+		return ""
+	}
+	if skipSection.MatchString(rest) {
+		return "section"
+	}
+	if target, ok := stepTarget(strings.TrimPrefix(rest, "to ")); ok {
+		return target
+	}
+	if m := skipToSection.FindStringSubmatch(rest); m != nil {
+		return "to " + m[1]
+	}
+	return skipNamed.FindStringSubmatch(rest)[1]
+}
+
+// dismissPayload is the full dismiss command, with a spoken timer number
+// turned into digits: "dismiss two" / "dismiss timer 2" / "dismiss the
+// second one" → "dismiss 2".  Anything else is passed through as typed.
+func dismissPayload(input string) string {
+	m := dismissTimerRef.FindStringSubmatch(input)
+	if m == nil {
+		return input
+	}
+	if isDigits(m[1]) {
+		return "dismiss " + m[1]
+	}
+	if n, ok := spokenChoice(m[1]); ok {
+		return "dismiss " + strconv.Itoa(n)
+	}
+	return input
+}
+
+// timerVerbs maps the verbs timerCommand accepts to the action taken.
+var timerVerbs = map[string]string{
+	"pause": "pause", "hold": "pause", "freeze": "pause",
+	"resume": "resume", "unpause": "resume", "unfreeze": "resume",
+	"cancel": "cancel", "kill": "cancel", "delete": "cancel",
+	"restart": "restart", "reset": "restart", "redo": "restart",
+}
+
+// ParseTimerCommand reads "pause all timers" / "cancel the chicken timer"
+// / "restart timer two".  action is pause, resume, cancel, or restart;
+// target is "all", a timer number, a label, or "" when no timer was
+// named ("pause the timer").
+func ParseTimerCommand(input string) (action, target string) {
+	m := timerCommand.FindStringSubmatch(strings.TrimSpace(input))
+	if m == nil {
+		return "", ""
+	}
+	action = timerVerbs[strings.ToLower(m[1])]
+
+	var words []string
+	for _, w := range strings.Fields(strings.ToLower(timerWord.ReplaceAllString(m[2], " "))) {
+		switch w {
+		case "all", "every", "both", "each":
+			return action, "all"
+		case "the", "my", "of", "this", "that":
+			continue
+		}
+		words = append(words, w)
+	}
+	target = strings.Join(words, " ")
+	if n, ok := spokenChoice(target); ok {
+		target = strconv.Itoa(n)
+	}
+	return action, target
+}
+
+// notHelper are words that start "… is done" and "…: " without being
+// anyone's name.
+var notHelper = map[string]bool{
+	"i": true, "we": true, "you": true, "it": true, "that": true, "this": true,
+	"all": true, "everything": true, "everyone": true, "they": true, "he": true,
+	"she": true, "step": true, "recipe": true, "note": true, "timer": true, "otto": true,
+}
+
+// taskPayload reads a helper-task command into "Name: words", with
+// "not " in front when a task isn't done after all.  ok is false when the
+// name is a word like "it" or "we", so "we're done" and "that's done"
+// are left to other patterns.
+func taskPayload(re *regexp.Regexp, input string) (string, bool) {
+	groups := re.FindStringSubmatch(input)[1:]
+	var name, words string
+	if re != delegateTo && re != tasksCommand {
+		words = groups[len(groups)-1]
+		groups = groups[:len(groups)-1]
+	}
+	for _, g := range groups {
+		if g != "" {
+			name = g
+			break
+		}
+	}
+	if re == tasksCommand && name == "" {
+		return "", true
+	}
+	if notHelper[strings.ToLower(name)] {
+		return "", false
+	}
+	payload := name
+	if words = strings.TrimSpace(words); words != "" {
+		payload += ": " + words
+	}
+	if re == taskUndone {
+		payload = "not " + payload
+	}
+	return payload, true
+}
+
+// comparePayload reads the two recipes of "compare 1 and 2",
+// "compare v1 with v3" or "compare chicken-alfredo pasta-primavera" as
+// "<first> vs <second>".  Without a word between them, each has to be a
+// single word.
+func comparePayload(input string) (string, bool) {
+	rest := compareCommand.FindStringSubmatch(input)[1]
+	rest = versionWord.ReplaceAllString(rest, "v")
+	parts := compareSplit.Split(rest, 2)
+	if len(parts) < 2 {
+		parts = strings.Fields(rest)
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+	return parts[0] + " vs " + parts[1], true
+}
+
+// versionWord shortens "version 2" to "v2".
+var versionWord = regexp.MustCompile(`(?i)\bversion\s+`)
+
+// versionPayload turns "cook version two" into "start 2" and "go back to
+// v1" into "restore 1".
+func versionPayload(input string) string {
+	m := versionPick.FindStringSubmatch(input)
+	n, _ := spokenNumber(m[2])
+	switch strings.ToLower(m[1]) {
+	case "cook", "start", "make":
+		return "start " + strconv.Itoa(n)
+	}
+	return "restore " + strconv.Itoa(n)
+}
+
+// ParseHowMuch reads the ingredient from "how much garlic do I need" /
+// "how many cloves of garlic?".
+func ParseHowMuch(input string) string {
+	m := howMuchCommand.FindStringSubmatch(strings.TrimSpace(input))
+	if m == nil {
+		return ""
+	}
+	return strings.ToLower(m[1])
+}
+
+// ParseClockTime reads a serve time the way people say it — "19:30",
+// "7:30pm", "7", "noon" — as the next such time after now.  A bare hour
+// up to 12 means whichever of the morning or evening time comes first.
+func ParseClockTime(s string, now time.Time) (time.Time, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "noon", "midday":
+		s = "12pm"
+	case "midnight":
+		s = "12am"
+	}
+	m := clockTime.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, false
+	}
+	h, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	if h > 23 || minute > 59 {
+		return time.Time{}, false
+	}
+
+	var hours []int
+	switch suffix := strings.ReplaceAll(m[3], ".", ""); {
+	case suffix != "":
+		if h == 0 || h > 12 {
+			return time.Time{}, false
+		}
+		h %= 12
+		if suffix == "pm" {
+			h += 12
+		}
+		hours = []int{h}
+	case h <= 12 && !strings.HasPrefix(m[1], "0"):
+		hours = []int{h % 12, h%12 + 12}
+	default:
+		hours = []int{h}
+	}
+
+	var best time.Time
+	for _, h := range hours {
+		t := time.Date(now.Year(), now.Month(), now.Day(), h, minute, 0, 0, now.Location())
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		if best.IsZero() || t.Before(best) {
+			best = t
+		}
+	}
+	return best, true
+}
+
+// ParseTagCommand reads "tag this as quick" / "untag quick".  tag is
+// empty when none was given.
+func ParseTagCommand(input string) (tag string, remove bool) {
+	m := tagCommand.FindStringSubmatch(strings.TrimSpace(input))
+	if m == nil {
+		return "", false
+	}
+	return m[2], m[1] != ""
+}
+
+// ParseCollectCommand reads "add this to weeknight favorites" /
+// "remove it from the date night collection".
+func ParseCollectCommand(input string) (name string, remove bool) {
+	m := collectCommand.FindStringSubmatch(strings.TrimSpace(input))
+	if m == nil {
+		return "", false
+	}
+	return m[2], m[1] != ""
+}
+
+// ParseDuplicateCommand reads the variant name from "duplicate this as
+// mom's version" / "save it as spicy".  name is empty for a bare
+// "duplicate".
+func ParseDuplicateCommand(input string) (name string) {
+	m := duplicateCommand.FindStringSubmatch(strings.TrimSpace(input))
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// ParseNoteCommand reads "note: the sauce needed 5 extra minutes".  keep
+// is set when the note should go into the recipe for next time ("note
+// for next time: ...", "remember ... next time"); "keep my notes" gives
+// an empty text with keep set, meaning every note from the session.
+func ParseNoteCommand(input string) (text string, keep bool) {
+	input = strings.TrimSpace(input)
+	if keepNotesCommand.MatchString(input) {
+		return "", true
+	}
+	m := noteCommand.FindStringSubmatch(input)
+	if m == nil {
+		return "", false
+	}
+	return m[2], m[1] != "" || m[3] != ""
+}
+
+// helpTopic pulls the command out of "help timer" or "help with
+// dismiss".  Empty for a bare "help".
+func helpTopic(input string) string {
+	m := helpCommand.FindStringSubmatch(input)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// listFilter pulls the tag or collection out of "list vegan recipes" or
+// "show my weeknight favorites".  Empty for a bare "list".
+func listFilter(input string) string {
+	m := listCommand.FindStringSubmatch(input)
+	if m == nil {
+		return ""
+	}
+	return m[1]
 }
 
 // questionPrefixes are common English question starters.
@@ -121,3 +723,52 @@ func isDigits(s string) bool {
 	}
 	return len(s) > 0
 }
+
+var (
+	confirmYes = regexp.MustCompile(`(?i)^(y|yes|yeah|yep|yup|sure|ok|okay|correct|right|do it|go ahead|confirm|affirmative)[.!]?$`)
+	confirmNo  = regexp.MustCompile(`(?i)^(n|no|nope|nah|cancel|never ?mind|don'?t|stop|wrong|negative)[.!]?$`)
+)
+
+// ParseConfirmation interprets an answer to a yes/no question.  ok is
+// false when the input is neither, so callers can treat it as a new
+// command instead.
+func ParseConfirmation(input string) (yes, ok bool) {
+	s := strings.TrimSpace(input)
+	switch {
+	case confirmYes.MatchString(s):
+		return true, true
+	case confirmNo.MatchString(s):
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+var sessionChoice = regexp.MustCompile(`(?i)^(?:(resume|continue|pick (?:it )?up|carry on|keep going)|(abandon|discard|drop|forget|throw (?:it )?away|start (?:fresh|over)))(?: (?:it |session |number |#)?(\w+))?[.!]?$`)
+
+// ParseSessionChoice interprets an answer to "pick up where you left
+// off?" at startup: resume or abandon, optionally with the session's
+// number, or a bare number to resume it.  Numbers can be spoken ("resume
+// number two", "the second one").  A yes or no counts as resume or
+// abandon.  n is 0 when no number was given; ok is false for anything
+// else.
+func ParseSessionChoice(input string) (resume bool, n int, ok bool) {
+	s := strings.TrimSpace(input)
+	if isDigits(s) {
+		n, _ = strconv.Atoi(s)
+		return true, n, true
+	}
+	if n, ok := spokenChoice(s); ok {
+		return true, n, true
+	}
+	if m := sessionChoice.FindStringSubmatch(s); m != nil {
+		if m[3] == "" {
+			return m[1] != "", 0, true
+		}
+		if n, ok := spokenNumber(m[3]); ok {
+			return m[1] != "", n, true
+		}
+	}
+	yes, ok := ParseConfirmation(s)
+	return yes, 0, ok
+}
@@ -3,6 +3,7 @@ package conversation
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/hammamikhairi/ottocook/internal/domain"
 	"github.com/hammamikhairi/ottocook/internal/logger"
@@ -27,12 +28,119 @@ func TestKeywordParser(t *testing.T) {
 		// Skip
 		{"skip", domain.IntentSkip, ""},
 		{"s", domain.IntentSkip, ""},
+		{"skip this step", domain.IntentSkip, ""},
+		{"skip the rest of this section", domain.IntentSkip, "section"},
+		{"skip this section", domain.IntentSkip, "section"},
+		{"skip ahead to the sauce", domain.IntentSkip, "to sauce"},
+		{"skip to garnish section", domain.IntentSkip, "to garnish"},
+		{"skip the garnish", domain.IntentSkip, "garnish"},
+		{"skip the rice part.", domain.IntentSkip, "rice"},
+		{"skip to step five", domain.IntentSkip, "step 5"},
+		{"skip step five", domain.IntentSkip, "step 5"},
+		{"skip ahead to the third step", domain.IntentSkip, "step 3"},
+		{"Go to step number 12.", domain.IntentSkip, "step 12"},
+		{"jump ahead to step 4", domain.IntentSkip, "step 4"},
+
+		// Speakers
+		{"remember my voice as sam", domain.IntentEnrollVoice, "Sam"},
+		{"I'm Alex, learn my voice", domain.IntentEnrollVoice, "Alex"},
+		{"my diet is vegetarian", domain.IntentDiet, ": vegetarian"},
+		{"Alex's diet is vegan", domain.IntentDiet, "Alex: vegan"},
+		{"I'm allergic to peanuts.", domain.IntentDiet, ": allergic to peanuts"},
+		{"I don't eat pork", domain.IntentDiet, ": no pork"},
+
+		// Missed wake word
+		{"you didn't hear me", domain.IntentMissedWake, ""},
+		{"I said hey chef twice", domain.IntentMissedWake, ""},
+		{"you missed the wake word", domain.IntentMissedWake, ""},
+
+		// Misheard
+		{"that's not what I said", domain.IntentMisheard, ""},
+		{"No, that's not what I said, I said next.", domain.IntentMisheard, "next"},
+		{"you misheard me", domain.IntentMisheard, ""},
+		{"I meant pause", domain.IntentMisheard, "pause"},
+		{"no, I said next step", domain.IntentMisheard, "next step"},
+		{"I said go back.", domain.IntentMisheard, "go back"},
+		{"No, that's not what I said, I said pause the timer", domain.IntentMisheard, "pause the timer"},
+		{"I didn't say anything", domain.IntentMisheard, "nothing"},
+		{"I wasn't talking to you", domain.IntentMisheard, "nothing"},
+		{"no, I said add two eggs", domain.IntentUnknown, "no, I said add two eggs"},
+
+		// Condition check-off
+		{"check off the water", domain.IntentCheck, "the water"},
+		{"tick off 2", domain.IntentCheck, "2"},
+		{"checked", domain.IntentCheck, ""},
+		{"condition 1 is met", domain.IntentCheck, "1"},
+		{"mark all as done", domain.IntentCheck, "all"},
+
+		// Helper tasks
+		{"hand the side jobs to sam", domain.IntentDelegate, "sam"},
+		{"Sam: chop the broccoli", domain.IntentDelegate, "Sam: chop the broccoli"},
+		{"ask Alex to grate the cheese", domain.IntentDelegate, "Alex: grate the cheese"},
+		{"Sam's done", domain.IntentTaskDone, "Sam"},
+		{"Sam finished the broccoli", domain.IntentTaskDone, "Sam: the broccoli"},
+		{"Sam isn't done with the broccoli yet", domain.IntentTaskDone, "not Sam: the broccoli"},
+		{"what's Sam doing?", domain.IntentTasks, "Sam"},
+		{"tasks", domain.IntentTasks, ""},
+
+		// Calendar export
+		{"add it to my calendar", domain.IntentCalendar, ""},
+		{"put the times in my calendar", domain.IntentCalendar, ""},
+		{"export the schedule", domain.IntentCalendar, ""},
+		{"show me the timeline", domain.IntentTimeline, ""},
+		{"export the session timeline", domain.IntentTimeline, ""},
+
+		// Pairing
+		{"what wine goes with this?", domain.IntentPairing, "what wine goes with this?"},
+		{"any drink pairing", domain.IntentPairing, "any drink pairing"},
+		{"what should I drink with it, no alcohol", domain.IntentPairing, "what should I drink with it, no alcohol"},
+		{"what beer would go with this", domain.IntentPairing, "what beer would go with this"},
+
+		// Suggestions
+		{"what should I cook?", domain.IntentSuggest, ""},
+		{"what can we make with chicken and rice", domain.IntentSuggest, "with chicken and rice"},
+		{"suggest something quick", domain.IntentSuggest, "quick"},
+
+		// Recipe comparison
+		{"compare 1 and 2", domain.IntentCompare, "1 vs 2"},
+		{"compare version 1 with version 3", domain.IntentCompare, "v1 vs v3"},
+		{"compare chicken-alfredo pasta-primavera", domain.IntentCompare, "chicken-alfredo vs pasta-primavera"},
+		{"compare chicken alfredo to pasta primavera", domain.IntentCompare, "chicken alfredo vs pasta primavera"},
+
+		// Microphone
+		{"mute mic", domain.IntentMic, "off"},
+		{"stop listening", domain.IntentMic, "off"},
+		{"mic on", domain.IntentMic, "on"},
+		{"unmute the microphone", domain.IntentMic, "on"},
+
+		// Voice off and on
+		{"voice off", domain.IntentVoice, "off"},
+		{"turn the audio off", domain.IntentVoice, "off"},
+		{"text only mode", domain.IntentVoice, "off"},
+		{"voice on", domain.IntentVoice, "on"},
+		{"turn the speech back on", domain.IntentVoice, "on"},
+
+		// Reload
+		{"reload", domain.IntentReload, ""},
+		{"reload the config", domain.IntentReload, ""},
+		{"reread your settings", domain.IntentReload, ""},
+
+		// Answer feedback
+		{"good answer", domain.IntentFeedback, "up"},
+		{"that's right!", domain.IntentFeedback, "up"},
+		{"thumbs down", domain.IntentFeedback, "down"},
+		{"No, that's wrong.", domain.IntentFeedback, "down"},
+		{"that was a bad answer", domain.IntentFeedback, "down"},
 
 		// Repeat
 		{"repeat", domain.IntentRepeat, ""},
 		{"again", domain.IntentRepeat, ""},
 		{"what?", domain.IntentRepeat, ""},
 
+		// Resume an interrupted answer
+		{"what were you saying?", domain.IntentResumeLast, ""},
+		{"go on", domain.IntentResumeLast, ""},
+
 		// Pause/Resume
 		{"pause", domain.IntentPause, ""},
 		{"brb", domain.IntentPause, ""},
@@ -47,27 +155,108 @@ func TestKeywordParser(t *testing.T) {
 		{"quit", domain.IntentQuit, ""},
 		{"exit", domain.IntentQuit, ""},
 		{"q", domain.IntentQuit, ""},
+		{"abandon", domain.IntentQuit, ""},
+		{"Abandon this recipe.", domain.IntentQuit, ""},
 
 		// Help
 		{"help", domain.IntentHelp, ""},
 		{"?", domain.IntentHelp, ""},
+		{"help timer", domain.IntentHelp, "timer"},
+		{"help me with dismiss?", domain.IntentHelp, "dismiss"},
 
 		// Dismiss
-		{"ok", domain.IntentDismissTimer, ""},
+		{"ready", domain.IntentStartTimer, ""},
+		{"start all timers", domain.IntentStartTimer, "all"},
+		{"start all the pending timers", domain.IntentStartTimer, "all"},
+		{"ok", domain.IntentAcknowledge, ""}, // dismisses only a timer that's gone off; see TestParseInSession
+		{"Okay, thanks.", domain.IntentAcknowledge, ""},
 		{"dismiss", domain.IntentDismissTimer, ""},
+		{"dismiss 2", domain.IntentDismissTimer, "dismiss 2"},
+		{"dismiss two", domain.IntentDismissTimer, "dismiss 2"},
+		{"Dismiss timer 3.", domain.IntentDismissTimer, "dismiss 3"},
+		{"dismiss the second one", domain.IntentDismissTimer, "dismiss 2"},
+		{"dismiss the water", domain.IntentDismissTimer, "dismiss the water"},
+
+		// Timer control
+		{"pause all timers", domain.IntentTimerControl, "pause all timers"},
+		{"cancel the chicken timer", domain.IntentTimerControl, ""},
+		{"Restart the simmer timer.", domain.IntentTimerControl, ""},
+		{"pause", domain.IntentPause, ""},
+		{"cancel", domain.IntentUnknown, ""},
+
+		// Cancel
+		{"never mind", domain.IntentCancel, ""},
+		{"Forget it.", domain.IntentCancel, ""},
+		{"cancel that", domain.IntentCancel, ""},
+
+		// Serve time
+		{"dinner at 19:30", domain.IntentServeTime, "19:30"},
+		{"We're eating at 7pm.", domain.IntentServeTime, "7pm"},
+		{"I want it ready by 8", domain.IntentServeTime, "8"},
+		{"clear the serve time", domain.IntentServeTime, "clear"},
+		{"dinner for two", domain.IntentUnknown, "dinner for two"},
+		{"ready for the next step", domain.IntentUnknown, "ready for the next step"},
+
+		// Prep list
+		{"what do I need to chop?", domain.IntentPrepList, ""},
+		{"things to prep", domain.IntentPrepList, ""},
+		{"what needs to be chopped", domain.IntentPrepList, ""},
+		{"mise en place", domain.IntentPrepList, ""},
+		{"prep", domain.IntentPrepList, ""},
+
+		// Versions
+		{"versions", domain.IntentVersions, ""},
+		{"show me the version history", domain.IntentVersions, ""},
+		{"cook version two", domain.IntentVersions, "start 2"},
+		{"go back to v1", domain.IntentVersions, "restore 1"},
+
+		// How much
+		{"how much garlic?", domain.IntentHowMuch, "how much garlic?"},
+		{"how many cloves of garlic do I need", domain.IntentHowMuch, "how many cloves of garlic do I need"},
 
 		// List
 		{"list", domain.IntentListRecipes, ""},
 		{"recipes", domain.IntentListRecipes, ""},
+		{"list vegan recipes", domain.IntentListRecipes, "vegan"},
+		{"show my weeknight favorites", domain.IntentListRecipes, "weeknight favorites"},
+
+		// Tags and collections
+		{"tag this as quick", domain.IntentTag, ""},
+		{"untag quick", domain.IntentTag, ""},
+		{"add this to weeknight favorites", domain.IntentCollect, ""},
+		{"remove it from the date night collection", domain.IntentCollect, ""},
+
+		// Variants
+		{"duplicate this as mom's version", domain.IntentDuplicate, ""},
+		{"save it as spicy", domain.IntentDuplicate, ""},
+		{"copy that", domain.IntentUnknown, ""},
+
+		// Notes
+		{"note: the sauce needed 5 extra minutes", domain.IntentNote, ""},
+		{"keep my notes", domain.IntentNote, ""},
 
 		// Select by number
 		{"1", domain.IntentSelectRecipe, "1"},
 		{"2", domain.IntentSelectRecipe, "2"},
 		{"99", domain.IntentSelectRecipe, "99"},
+		{"Two.", domain.IntentSelectRecipe, "2"},
+		{"number 3", domain.IntentSelectRecipe, "3"},
+		{"the first one", domain.IntentSelectRecipe, "1"},
+		{"Number twelve.", domain.IntentSelectRecipe, "12"},
+		{"the 3rd one", domain.IntentSelectRecipe, "3"},
+		{"the eleventh", domain.IntentSelectRecipe, "11"},
+
+		// Search
+		{"find me something with broccoli", domain.IntentSearch, "broccoli"},
+		{"search for pasta", domain.IntentSearch, "pasta"},
+		{"Anything with leeks?", domain.IntentSearch, "leeks"},
+		{"recipes using rice and beans", domain.IntentSearch, "rice and beans"},
 
 		// Select by name
 		{"select 2", domain.IntentSelectRecipe, "2"},
 		{"pick pasta", domain.IntentSelectRecipe, "pasta"},
+		{"pick the second one", domain.IntentSelectRecipe, "2"},
+		{"select number four", domain.IntentSelectRecipe, "4"},
 
 		// Start
 		{"start", domain.IntentStartCooking, ""},
@@ -93,3 +282,315 @@ func TestKeywordParser(t *testing.T) {
 		})
 	}
 }
+
+func TestParseInSession(t *testing.T) {
+	parser := NewKeywordParser(logger.New(logger.LevelOff, nil))
+	if err := parser.SetAliases(map[string]string{"cheers mate": "ok", "fire": "start"}); err != nil {
+		t.Fatalf("SetAliases: %v", err)
+	}
+	withTimer := func(status domain.TimerStatus) *domain.Session {
+		return &domain.Session{TimerStates: map[string]*domain.TimerState{
+			"timer-boil": {ID: "timer-boil", Label: "Boil", Status: status},
+		}}
+	}
+	tests := []struct {
+		input       string
+		session     *domain.Session
+		wantType    domain.IntentType
+		wantPayload string
+	}{
+		{"ok", nil, domain.IntentAcknowledge, ""},
+		{"ok", withTimer(domain.TimerRunning), domain.IntentAcknowledge, ""},
+		{"ok", withTimer(domain.TimerFired), domain.IntentDismissTimer, "ok"},
+		{"Got it, thanks!", withTimer(domain.TimerFired), domain.IntentDismissTimer, "Got it, thanks!"},
+		{"dismiss", withTimer(domain.TimerRunning), domain.IntentDismissTimer, "dismiss"},
+		{"start", nil, domain.IntentStartCooking, ""},
+		{"start", withTimer(domain.TimerRunning), domain.IntentStartCooking, ""},
+		{"start", withTimer(domain.TimerPending), domain.IntentStartTimer, ""},
+		{"go", withTimer(domain.TimerPending), domain.IntentStartCooking, ""},
+		{"cheers mate", withTimer(domain.TimerRunning), domain.IntentAcknowledge, ""},
+		{"cheers mate", withTimer(domain.TimerFired), domain.IntentDismissTimer, "ok"},
+		{"fire", nil, domain.IntentStartCooking, ""},
+		{"fire", withTimer(domain.TimerPending), domain.IntentStartTimer, ""},
+	}
+	for _, tt := range tests {
+		intent, err := parser.Parse(context.Background(), tt.input, tt.session)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		if intent.Type != tt.wantType || intent.Payload != tt.wantPayload {
+			t.Errorf("Parse(%q) = %s %q, want %s %q", tt.input, intent.Type, intent.Payload, tt.wantType, tt.wantPayload)
+		}
+	}
+}
+
+func TestParseConfirmation(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantYes bool
+		wantOK  bool
+	}{
+		{"yes", true, true},
+		{"Yeah.", true, true},
+		{"go ahead", true, true},
+		{"no", false, true},
+		{"Nope!", false, true},
+		{"never mind", false, true},
+		{"skip", false, false},
+		{"yes please skip", false, false},
+	}
+	for _, tt := range tests {
+		yes, ok := ParseConfirmation(tt.input)
+		if yes != tt.wantYes || ok != tt.wantOK {
+			t.Errorf("ParseConfirmation(%q) = (%v, %v), want (%v, %v)", tt.input, yes, ok, tt.wantYes, tt.wantOK)
+		}
+	}
+}
+
+func TestParseLabelCommands(t *testing.T) {
+	tags := []struct {
+		input  string
+		tag    string
+		remove bool
+	}{
+		{"tag this as quick", "quick", false},
+		{"Tag it with weeknight.", "weeknight", false},
+		{"untag quick", "quick", true},
+		{"tag", "", false},
+	}
+	for _, tt := range tags {
+		tag, remove := ParseTagCommand(tt.input)
+		if tag != tt.tag || remove != tt.remove {
+			t.Errorf("ParseTagCommand(%q) = %q, %v; want %q, %v", tt.input, tag, remove, tt.tag, tt.remove)
+		}
+	}
+
+	collections := []struct {
+		input  string
+		name   string
+		remove bool
+	}{
+		{"add this to weeknight favorites", "weeknight favorites", false},
+		{"save it in my date night collection", "date night", false},
+		{"remove this recipe from the Sunday collection.", "Sunday", true},
+	}
+	for _, tt := range collections {
+		name, remove := ParseCollectCommand(tt.input)
+		if name != tt.name || remove != tt.remove {
+			t.Errorf("ParseCollectCommand(%q) = %q, %v; want %q, %v", tt.input, name, remove, tt.name, tt.remove)
+		}
+	}
+
+	duplicates := []struct{ input, name string }{
+		{"duplicate this as Mom's version", "Mom's version"},
+		{"save it as a spicy one.", "spicy one"},
+		{"make a copy of this recipe", ""},
+		{"duplicate", ""},
+	}
+	for _, tt := range duplicates {
+		if name := ParseDuplicateCommand(tt.input); name != tt.name {
+			t.Errorf("ParseDuplicateCommand(%q) = %q, want %q", tt.input, name, tt.name)
+		}
+	}
+
+	notes := []struct {
+		input string
+		text  string
+		keep  bool
+	}{
+		{"note: the sauce needed 5 extra minutes", "the sauce needed 5 extra minutes", false},
+		{"Note for next time, use less salt.", "use less salt", true},
+		{"remember that the oven runs hot next time", "the oven runs hot", true},
+		{"keep my notes", "", true},
+	}
+	for _, tt := range notes {
+		text, keep := ParseNoteCommand(tt.input)
+		if text != tt.text || keep != tt.keep {
+			t.Errorf("ParseNoteCommand(%q) = %q, %v; want %q, %v", tt.input, text, keep, tt.text, tt.keep)
+		}
+	}
+}
+
+func TestParseTimerCommand(t *testing.T) {
+	tests := []struct{ input, action, target string }{
+		{"pause all timers", "pause", "all"},
+		{"resume all the timers", "resume", "all"},
+		{"cancel the chicken timer", "cancel", "chicken"},
+		{"Restart the simmer timer.", "restart", "simmer"},
+		{"reset timer two", "restart", "2"},
+		{"pause timer 3", "pause", "3"},
+		{"hold the timer", "pause", ""},
+		{"cancel the chicken", "", ""},
+	}
+	for _, tt := range tests {
+		action, target := ParseTimerCommand(tt.input)
+		if action != tt.action || target != tt.target {
+			t.Errorf("ParseTimerCommand(%q) = %q, %q; want %q, %q", tt.input, action, target, tt.action, tt.target)
+		}
+	}
+}
+
+func TestParseHowMuch(t *testing.T) {
+	tests := []struct{ input, want string }{
+		{"how much garlic?", "garlic"},
+		{"How many cloves of garlic do I need?", "garlic"},
+		{"how much of the creme fraiche goes in", "creme fraiche"},
+		{"how much olive oil", "olive oil"},
+		{"how many tablespoons of soy sauce again?", "soy sauce"},
+		{"how long does it rest", ""},
+	}
+	for _, tt := range tests {
+		if got := ParseHowMuch(tt.input); got != tt.want {
+			t.Errorf("ParseHowMuch(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseClockTime(t *testing.T) {
+	now := time.Date(2024, 3, 1, 17, 10, 0, 0, time.Local)
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"19:30", time.Date(2024, 3, 1, 19, 30, 0, 0, time.Local)},
+		{"7:30", time.Date(2024, 3, 1, 19, 30, 0, 0, time.Local)},
+		{"7:30 PM", time.Date(2024, 3, 1, 19, 30, 0, 0, time.Local)},
+		{"5", time.Date(2024, 3, 2, 5, 0, 0, 0, time.Local)},
+		{"8am", time.Date(2024, 3, 2, 8, 0, 0, 0, time.Local)},
+		{"noon", time.Date(2024, 3, 2, 12, 0, 0, 0, time.Local)},
+		{"07:00", time.Date(2024, 3, 2, 7, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		got, ok := ParseClockTime(tt.input, now)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("ParseClockTime(%q) = %s, %v; want %s", tt.input, got, ok, tt.want)
+		}
+	}
+	for _, bad := range []string{"soon", "25:00", "13pm"} {
+		if got, ok := ParseClockTime(bad, now); ok {
+			t.Errorf("ParseClockTime(%q) = %s, want no match", bad, got)
+		}
+	}
+}
+
+func TestParseSessionChoice(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantResume bool
+		wantN      int
+		wantOK     bool
+	}{
+		{"resume", true, 0, true},
+		{"Resume 2", true, 2, true},
+		{"pick it up", true, 0, true},
+		{"2", true, 2, true},
+		{"yes", true, 0, true},
+		{"abandon", false, 0, true},
+		{"abandon number 1", false, 1, true},
+		{"resume number two", true, 2, true},
+		{"drop session three", false, 3, true},
+		{"the second one", true, 2, true},
+		{"resume banana", false, 0, false},
+		{"start fresh", false, 0, true},
+		{"no", false, 0, true},
+		{"list recipes", false, 0, false},
+		{"resume the timers", false, 0, false},
+	}
+	for _, tt := range tests {
+		resume, n, ok := ParseSessionChoice(tt.input)
+		if resume != tt.wantResume || n != tt.wantN || ok != tt.wantOK {
+			t.Errorf("ParseSessionChoice(%q) = (%v, %d, %v), want (%v, %d, %v)", tt.input, resume, n, ok, tt.wantResume, tt.wantN, tt.wantOK)
+		}
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	parser := NewKeywordParser(logger.New(logger.LevelOff, nil))
+	tests := []struct {
+		input string
+		want  any
+	}{
+		{"3", domain.SelectRecipeArgs{Index: 3}},
+		{"the second one", domain.SelectRecipeArgs{Index: 2}},
+		{"pick pasta", domain.SelectRecipeArgs{Name: "pasta"}},
+		{"cancel the chicken timer", domain.TimerArgs{Action: "cancel", Target: "chicken"}},
+		{"cook version two", domain.VersionArgs{Action: "start", Version: 2}},
+		{"versions", domain.VersionArgs{}},
+		{"untag quick", domain.TagArgs{Tag: "quick", Remove: true}},
+		{"note for next time: less salt", domain.NoteArgs{Text: "less salt", Keep: true}},
+		{"how much garlic do I need", domain.HowMuchArgs{Ingredient: "garlic"}},
+		{"Sam isn't done with the broccoli", domain.TaskArgs{Helper: "Sam", Words: "the broccoli", Reopen: true}},
+		{"Alex's diet is vegan", domain.DietArgs{Name: "Alex", Need: "vegan"}},
+		{"compare 2 vs 4", domain.CompareArgs{A: "2", B: "4"}},
+		{"next", nil},
+	}
+	for _, tt := range tests {
+		intent, err := parser.Parse(context.Background(), tt.input, nil)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		if intent.Args != tt.want {
+			t.Errorf("Parse(%q).Args = %#v, want %#v", tt.input, intent.Args, tt.want)
+		}
+	}
+}
+
+func TestKeywordParserAliases(t *testing.T) {
+	parser := NewKeywordParser(logger.New(logger.LevelOff, nil))
+	err := parser.SetAliases(map[string]string{
+		"chop chop":       "next",
+		"Oi":              "repeat",
+		"shh":             "mic off",
+		"kettle's on":     "start_timer",
+		"stop":            "pause",
+		"flibbertigibbet": "jabberwocky",
+	})
+	if err == nil {
+		t.Error("SetAliases: want an error for the alias that isn't a command")
+	}
+
+	tests := []struct {
+		input       string
+		wantType    domain.IntentType
+		wantPayload string
+	}{
+		{"chop chop", domain.IntentAdvance, ""},
+		{"Chop  chop!", domain.IntentAdvance, ""},
+		{"oi.", domain.IntentRepeat, ""},
+		{"shh", domain.IntentMic, "off"},
+		{"kettle's on", domain.IntentStartTimer, ""},
+		{"stop", domain.IntentPause, ""}, // takes over a built-in word
+		{"chop chop chop", domain.IntentUnknown, "chop chop chop"},
+		{"flibbertigibbet", domain.IntentUnknown, "flibbertigibbet"},
+		{"next", domain.IntentAdvance, ""}, // built-ins still work
+	}
+	for _, tt := range tests {
+		intent, err := parser.Parse(context.Background(), tt.input, nil)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.input, err)
+		}
+		if intent.Type != tt.wantType || intent.Payload != tt.wantPayload {
+			t.Errorf("Parse(%q) = %s %q, want %s %q", tt.input, intent.Type, intent.Payload, tt.wantType, tt.wantPayload)
+		}
+	}
+}
+
+func TestStructureClassifierPayloads(t *testing.T) {
+	// The AI classifier writes payloads the way the prompt asks, which
+	// isn't always how a person would type the command.
+	tests := []struct {
+		intent domain.Intent
+		want   any
+	}{
+		{domain.Intent{Type: domain.IntentTag, Payload: "tag quick"}, domain.TagArgs{Tag: "quick"}},
+		{domain.Intent{Type: domain.IntentVersions, Payload: "restore 1"}, domain.VersionArgs{Action: "restore", Version: 1}},
+		{domain.Intent{Type: domain.IntentDiet, Payload: ": allergic to peanuts"}, domain.DietArgs{Need: "allergic to peanuts"}},
+		{domain.Intent{Type: domain.IntentTasks, Payload: ""}, domain.TaskArgs{}},
+		{domain.Intent{Type: domain.IntentPlugin, Payload: "wine_pairing: which red?"}, domain.PluginArgs{Intent: "wine_pairing", Text: "which red?"}},
+	}
+	for _, tt := range tests {
+		if got := Structure(&tt.intent).Args; got != tt.want {
+			t.Errorf("Structure(%s %q) = %#v, want %#v", tt.intent.Type, tt.intent.Payload, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Package cookalong links two ottocook instances cooking the same recipe
+// over the network.  Each side broadcasts its progress as it moves
+// through the steps and sees the other's; a partner who joins without a
+// session of their own can pick up the host's.
+//
+// The wire format is newline-delimited JSON over a single TCP
+// connection.  There's no authentication — it's meant for a LAN or a
+// tunnel both cooks trust.
+package cookalong
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// Progress is where one cook is in their session.
+type Progress struct {
+	Name       string `json:"name"`
+	RecipeID   string `json:"recipe_id"`
+	RecipeName string `json:"recipe_name"`
+	Step       int    `json:"step"` // 1-based
+	Total      int    `json:"total"`
+	Status     string `json:"status"`
+}
+
+// ProgressOf summarises a session for broadcasting.
+func ProgressOf(name string, s *domain.Session) Progress {
+	return Progress{
+		Name:       name,
+		RecipeID:   s.RecipeID,
+		RecipeName: s.RecipeName,
+		Step:       s.CurrentStepIndex + 1,
+		Total:      len(s.StepStates),
+		Status:     s.Status.String(),
+	}
+}
+
+// message is one line on the wire.
+type message struct {
+	Type     string          `json:"type"` // "hello" or "progress"
+	Name     string          `json:"name,omitempty"`
+	Session  *domain.Session `json:"session,omitempty"` // hello only; nil when not cooking
+	Progress *Progress       `json:"progress,omitempty"`
+}
+
+// writeTimeout bounds one write to the partner; one who stops reading
+// is dropped rather than backing up progress behind them.
+const writeTimeout = 5 * time.Second
+
+// outbox is how many progress messages may wait to be written.  Only
+// the latest matters, so when it's full the oldest are dropped.
+const outbox = 16
+
+// Option configures a Peer.
+type Option func(*Peer)
+
+// WithOnJoin sets the callback run when a partner connects.  session is
+// the partner's current session, or nil if they aren't cooking yet.
+func WithOnJoin(fn func(partner string, session *domain.Session)) Option {
+	return func(p *Peer) { p.onJoin = fn }
+}
+
+// WithOnProgress sets the callback run when the partner's progress changes.
+func WithOnProgress(fn func(Progress)) Option {
+	return func(p *Peer) { p.onProgress = fn }
+}
+
+// WithOnLeave sets the callback run when the partner disconnects.
+func WithOnLeave(fn func(partner string)) Option {
+	return func(p *Peer) { p.onLeave = fn }
+}
+
+// WithSnapshot sets the function that supplies the local session sent
+// in the hello to a new partner.  It may return nil.
+func WithSnapshot(fn func() *domain.Session) Option {
+	return func(p *Peer) { p.snapshot = fn }
+}
+
+// Peer is one end of a cook-along link.  Callbacks run on the peer's
+// reader goroutine.
+type Peer struct {
+	name string
+	log  *logger.Logger
+
+	onJoin     func(string, *domain.Session)
+	onProgress func(Progress)
+	onLeave    func(string)
+	snapshot   func() *domain.Session
+
+	out chan Progress // drained by the writer goroutine of the open link
+
+	mu     sync.Mutex
+	ln     net.Listener
+	conn   net.Conn
+	linked bool      // hellos written and the writer running
+	last   *Progress // last progress sent, replayed to a new partner
+}
+
+func newPeer(name string, log *logger.Logger, opts []Option) *Peer {
+	p := &Peer{name: name, log: log, out: make(chan Progress, outbox)}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Host listens on addr and accepts one partner at a time.  It returns
+// once listening; partners are handled in the background until ctx is
+// cancelled or Close is called.
+func Host(ctx context.Context, addr, name string, log *logger.Logger, opts ...Option) (*Peer, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("cookalong: listen %s: %w", addr, err)
+	}
+	p := newPeer(name, log, opts)
+	p.ln = ln
+	log.Info("cookalong: hosting on %s", ln.Addr())
+
+	go func() {
+		<-ctx.Done()
+		p.Close()
+	}()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					log.Error("cookalong: accept: %v", err)
+				}
+				return
+			}
+			p.mu.Lock()
+			busy := p.conn != nil
+			if !busy {
+				p.conn = conn
+			}
+			p.mu.Unlock()
+			if busy {
+				log.Info("cookalong: rejecting %s, already cooking with someone", conn.RemoteAddr())
+				conn.Close()
+				continue
+			}
+			go p.serve(conn)
+		}
+	}()
+	return p, nil
+}
+
+// Join connects to a hosting partner at addr.
+func Join(ctx context.Context, addr, name string, log *logger.Logger, opts ...Option) (*Peer, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("cookalong: dial %s: %w", addr, err)
+	}
+	p := newPeer(name, log, opts)
+	p.conn = conn
+	log.Info("cookalong: joined %s", addr)
+
+	go func() {
+		<-ctx.Done()
+		p.Close()
+	}()
+	go p.serve(conn)
+	return p, nil
+}
+
+// Addr returns the address a host is listening on, or "" for a joiner.
+func (p *Peer) Addr() string {
+	if p.ln == nil {
+		return ""
+	}
+	return p.ln.Addr().String()
+}
+
+// Send broadcasts local progress to the partner.  Repeats of the last
+// progress sent are dropped, so it's fine to call on every session
+// change.  It never blocks: the engine calls it from inside a save, and
+// the write happens on the link's own goroutine.  With the outbox full
+// the oldest update goes, so a slow partner still ends up on the latest.
+func (p *Peer) Send(pr Progress) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.last != nil && *p.last == pr {
+		return
+	}
+	p.last = &pr
+	if !p.linked {
+		return
+	}
+	for {
+		select {
+		case p.out <- pr:
+			return
+		default:
+		}
+		select {
+		case <-p.out:
+			p.log.Debug("cookalong: partner is slow, dropping older progress")
+		default:
+		}
+	}
+}
+
+// Close ends the link and, for a host, stops listening.
+func (p *Peer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ln != nil {
+		p.ln.Close()
+	}
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	return nil
+}
+
+// serve runs one partner connection: exchange hellos, then relay
+// progress until either side hangs up.
+func (p *Peer) serve(conn net.Conn) {
+	enc := json.NewEncoder(conn)
+	hello := message{Type: "hello", Name: p.name}
+	if p.snapshot != nil {
+		hello.Session = p.snapshot()
+	}
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	err := enc.Encode(hello)
+	p.mu.Lock()
+	for len(p.out) > 0 {
+		<-p.out // left over from an earlier partner; last covers it
+	}
+	last := p.last
+	p.linked = err == nil
+	p.mu.Unlock()
+	if err == nil && last != nil {
+		err = enc.Encode(message{Type: "progress", Progress: last})
+	}
+
+	done := make(chan struct{})
+	if err == nil {
+		go p.write(conn, enc, done)
+	}
+
+	partner := conn.RemoteAddr().String()
+	defer func() {
+		close(done)
+		p.mu.Lock()
+		p.conn, p.linked = nil, false
+		p.mu.Unlock()
+		conn.Close()
+		p.log.Info("cookalong: %s left", partner)
+		if p.onLeave != nil {
+			p.onLeave(partner)
+		}
+	}()
+	if err != nil {
+		p.log.Error("cookalong: hello: %v", err)
+		return
+	}
+
+	sc := bufio.NewScanner(conn)
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+	for sc.Scan() {
+		var msg message
+		if err := json.Unmarshal(sc.Bytes(), &msg); err != nil {
+			p.log.Error("cookalong: bad message from %s: %v", partner, err)
+			continue
+		}
+		switch msg.Type {
+		case "hello":
+			if msg.Name != "" {
+				partner = msg.Name
+			}
+			p.log.Info("cookalong: %s joined", partner)
+			if p.onJoin != nil {
+				p.onJoin(partner, msg.Session)
+			}
+		case "progress":
+			if msg.Progress != nil && p.onProgress != nil {
+				p.onProgress(*msg.Progress)
+			}
+		default:
+			p.log.Debug("cookalong: ignoring %q message", msg.Type)
+		}
+	}
+	if err := sc.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		p.log.Error("cookalong: read: %v", err)
+	}
+}
+
+// write sends queued progress to the partner until done.  A write that
+// fails or times out closes the link, which ends serve.
+func (p *Peer) write(conn net.Conn, enc *json.Encoder, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case pr := <-p.out:
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := enc.Encode(message{Type: "progress", Progress: &pr}); err != nil {
+				p.log.Error("cookalong: send: %v", err)
+				conn.Close()
+				return
+			}
+		}
+	}
+}
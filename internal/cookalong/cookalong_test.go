@@ -0,0 +1,134 @@
+package cookalong
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+func TestHostAndJoin(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hostSession := &domain.Session{
+		RecipeID:         "chicken-alfredo",
+		RecipeName:       "Chicken Alfredo",
+		CurrentStepIndex: 2,
+		StepStates:       map[int]*domain.StepState{0: {}, 1: {}, 2: {}, 3: {}},
+	}
+	host, err := Host(ctx, "127.0.0.1:0", "Sam", log,
+		WithSnapshot(func() *domain.Session { return hostSession }))
+	if err != nil {
+		t.Fatalf("Host: %v", err)
+	}
+	host.Send(ProgressOf("Sam", hostSession))
+
+	joined := make(chan *domain.Session, 1)
+	progress := make(chan Progress, 4)
+	if _, err := Join(ctx, host.Addr(), "Alex", log,
+		WithOnJoin(func(partner string, s *domain.Session) {
+			if partner != "Sam" {
+				t.Errorf("partner = %q, want Sam", partner)
+			}
+			joined <- s
+		}),
+		WithOnProgress(func(p Progress) { progress <- p }),
+	); err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+
+	select {
+	case s := <-joined:
+		if s == nil || s.RecipeID != "chicken-alfredo" || s.CurrentStepIndex != 2 {
+			t.Errorf("joiner got session %+v", s)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for hello")
+	}
+
+	// The host's last progress is replayed on join, then new steps follow.
+	want := []int{3, 4}
+	hostSession.CurrentStepIndex = 3
+	host.Send(ProgressOf("Sam", hostSession))
+	host.Send(ProgressOf("Sam", hostSession)) // duplicate, dropped
+	for _, step := range want {
+		select {
+		case p := <-progress:
+			if p.Step != step || p.Total != 4 || p.Name != "Sam" {
+				t.Errorf("progress = %+v, want step %d/4 from Sam", p, step)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for step %d", step)
+		}
+	}
+	select {
+	case p := <-progress:
+		t.Errorf("unexpected extra progress %+v", p)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSendDoesNotBlockOnStalledPartner(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	host, err := Host(ctx, "127.0.0.1:0", "Sam", log)
+	if err != nil {
+		t.Fatalf("Host: %v", err)
+	}
+	// A partner that connects and never reads a thing.
+	conn, err := net.Dial("tcp", host.Addr())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	for deadline := time.Now().Add(2 * time.Second); ; {
+		host.mu.Lock()
+		linked := host.linked
+		host.mu.Unlock()
+		if linked {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the link")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Far more than the socket buffers hold: a blocking write would hang.
+	sent := make(chan struct{})
+	go func() {
+		for i := 0; i < 20000; i++ {
+			host.Send(Progress{Name: "Sam", RecipeName: strings.Repeat("x", 1000), Step: i + 1})
+		}
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Send blocked on a partner that isn't reading")
+	}
+}
+
+func TestSendKeepsLatestWhenOutboxFull(t *testing.T) {
+	p := newPeer("Sam", logger.New(logger.LevelOff, nil), nil)
+	p.linked = true // no writer: nothing drains the outbox
+
+	for i := 1; i <= outbox+5; i++ {
+		p.Send(Progress{Name: "Sam", Step: i})
+	}
+	var steps []int
+	for len(p.out) > 0 {
+		steps = append(steps, (<-p.out).Step)
+	}
+	if len(steps) != outbox || steps[0] != 6 || steps[len(steps)-1] != outbox+5 {
+		t.Errorf("queued steps %v, want the latest %d, oldest dropped", steps, outbox)
+	}
+}
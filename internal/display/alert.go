@@ -0,0 +1,149 @@
+package display
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ── Alert overlay ────────────────────────────────────────────────
+//
+// A fired timer is one red line in the scrollback and one red item in
+// the bar, which is easy to miss from across the kitchen.  So when a
+// timer goes off the message area gives way to a flashing block with
+// the label in big letters and how long ago it fired.  Any key or click
+// puts the messages back (the key is swallowed, so a stray press doesn't
+// end up in the prompt); the timer stays fired until it's dismissed.
+
+var (
+	alertStyle = lipgloss.NewStyle().
+			Background(lipgloss.Color("#dc2626")).
+			Foreground(lipgloss.Color("#fafafa")).
+			Bold(true)
+
+	// Every other second the colours swap, so the block flashes.
+	alertFlashStyle = lipgloss.NewStyle().
+			Background(lipgloss.Color("#fafafa")).
+			Foreground(lipgloss.Color("#dc2626")).
+			Bold(true)
+)
+
+// checkAlerts raises the overlay for fired timers that haven't been
+// shown yet.  A timer that is dismissed and later fires again (after a
+// restart) counts as new.
+func (m *model) checkAlerts() {
+	seen := make(map[string]bool)
+	m.alert = m.alert[:0]
+	for _, t := range m.timers {
+		if !t.fired {
+			continue
+		}
+		seen[t.key] = m.alertSeen[t.key]
+		if !seen[t.key] {
+			m.alert = append(m.alert, t)
+		}
+	}
+	m.alertSeen = seen
+}
+
+// dismissAlert puts the messages back.
+func (m *model) dismissAlert() {
+	for _, t := range m.alert {
+		m.alertSeen[t.key] = true
+	}
+	m.alert = nil
+}
+
+// renderAlert draws the overlay in place of height rows of messages.
+func (m model) renderAlert(height int) []msgLine {
+	if height <= 0 {
+		return nil
+	}
+	w := m.width
+	if w <= 0 {
+		w = 80
+	}
+	style := alertStyle
+	if time.Now().Unix()%2 == 1 {
+		style = alertFlashStyle
+	}
+
+	first := m.alert[0]
+	body := []string{"", "TIMER", "", bigLabel(first.label, w-4), ""}
+	if !first.firedAt.IsZero() {
+		body = append(body, "fired "+fmtDuration(time.Since(first.firedAt))+" ago")
+	}
+	if len(m.alert) > 1 {
+		var also []string
+		for _, t := range m.alert[1:] {
+			also = append(also, t.label)
+		}
+		body = append(body, "also: "+strings.Join(also, ", "))
+	}
+	body = append(body, "", "press any key", "")
+	if len(body) > height {
+		// Short terminal: keep the label and the hint.
+		body = []string{bigLabel(first.label, w-4), "press any key"}[:min(2, height)]
+	}
+
+	row := style.Width(w).Align(lipgloss.Center)
+	lines := make([]msgLine, 0, height)
+	pad := (height - len(body)) / 2
+	for range pad {
+		lines = append(lines, msgLine{})
+	}
+	for _, b := range body {
+		lines = append(lines, msgLine{text: row.Render(truncateLabel(b, w))})
+	}
+	for len(lines) < height {
+		lines = append(lines, msgLine{})
+	}
+	return lines
+}
+
+// ── Attention ────────────────────────────────────────────────────
+//
+// The overlay only helps if the terminal is on screen.  For one that's
+// behind another window or in a background tab, Attention rings the bell
+// — most terminals and window managers turn that into an urgency hint (a
+// flashing taskbar entry, a bouncing dock icon, a marked tab) — and, in
+// terminals with their own request for it, asks for attention outright.
+
+const bel = "\a"
+
+// attentionSequence is what Attention writes: the bell, plus iTerm2's
+// request to bounce the dock icon when that's the terminal.
+func attentionSequence() string {
+	if os.Getenv("TERM_PROGRAM") == "iTerm.app" {
+		return bel + "\x1b]1337;RequestAttention=yes" + bel
+	}
+	return bel
+}
+
+// SetBell turns the bell and attention hints on urgent events on or off.
+// On by default.  Thread-safe.
+func (u *UI) SetBell(on bool) { u.noBell.Store(!on) }
+
+// Attention rings the terminal bell and asks for the window's attention.
+// Thread-safe.
+func (u *UI) Attention() {
+	if u.noBell.Load() {
+		return
+	}
+	// The sequences take no space on screen, so writing them past the
+	// renderer doesn't disturb the frame.
+	u.out.Write([]byte(attentionSequence()))
+}
+
+// bigLabel spells the label out in spaced capitals ("P A S T A") when it
+// fits in width, else just in capitals.
+func bigLabel(label string, width int) string {
+	up := strings.ToUpper(label)
+	spaced := strings.Join(strings.Split(up, ""), " ")
+	if lipgloss.Width(spaced) <= width {
+		return spaced
+	}
+	return up
+}
@@ -9,7 +9,10 @@ package display
 import (
 	"context"
 	"fmt"
+	"io"
 	"math"
+	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"sync/atomic"
@@ -18,6 +21,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/hammamikhairi/ottocook/internal/domain"
 )
@@ -32,6 +36,14 @@ var (
 	timerRunStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#fde68a"))
 
+	// A running timer's bar and time shift from green through amber to
+	// red as it nears zero; see timerStyle.
+	timerFreshStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#86efac"))
+
+	timerLateStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#f87171"))
+
 	timerDoneStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#fca5a5"))
 
@@ -126,6 +138,12 @@ var (
 	inspectTimer = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#a1a1aa"))
 
+	inspectMicOff = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#fafafa")).
+			Background(lipgloss.Color("#dc2626")).
+			Bold(true).
+			Padding(0, 1)
+
 	brandStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#52525b")).
 			Bold(true)
@@ -145,7 +163,15 @@ type UI struct {
 	quitCh      chan struct{}
 	store       domain.SessionStore
 	done        atomic.Bool
-	interruptFn func() // called when user presses space on empty input
+	interruptFn func()      // called when user presses space on empty input
+	micToggleFn func()      // called on Ctrl+O
+	cancelFn    func()      // called on Esc
+	mouse       bool        // enable click-to-act (see EnableMouse)
+	twSpeed     int         // typewriter characters per second; 0 prints instantly
+	historyPath string      // file input history is kept in; "" = memory only
+	out         io.Writer   // where lines go when Run isn't running
+	noBell      atomic.Bool // no bell or attention hints (see SetBell)
+	titleMode   string      // what the window title shows (see SetTitleMode)
 
 	// Ear timing constants passed in once at startup.
 	earListenTimeout time.Duration
@@ -175,6 +201,82 @@ func (u *UI) SetMouthState(s MouthIndicator) {
 	}
 }
 
+// SpeechJobs is what the speech pipeline is busy with, shown under the
+// mouth in the inspector so it's clear why speech hasn't started yet.
+type SpeechJobs struct {
+	Chunks         int      // chunks in the line being spoken; 0 when idle
+	Ready          int      // of those, with audio so far
+	CacheHit       bool     // the line came from the cache
+	Queued         int      // lines waiting their turn
+	Prefetching    int      // prefetches queued or running
+	PrefetchGroups []string // what they're for, e.g. "step"
+}
+
+// SetSpeechJobs updates the speech detail in the inspector box.
+// Thread-safe.
+func (u *UI) SetSpeechJobs(j SpeechJobs) {
+	if u.program != nil && !u.done.Load() {
+		u.program.Send(speechJobsMsg{jobs: j})
+	}
+}
+
+// EnableMouse turns on mouse support: clicking a recipe in a list
+// selects it, clicking a timer in the bar dismisses it, and clicking the
+// "Next:" preview advances.  Most terminals still select text with
+// Shift held down.  Call before Run().
+func (u *UI) EnableMouse() { u.mouse = true }
+
+// DefaultTypewriterSpeed is how fast PrintChat reveals text, in
+// characters per second.
+const DefaultTypewriterSpeed = 80
+
+// SetTypewriterSpeed sets how fast chat lines are revealed, in
+// characters per second.  0 turns the effect off and prints lines
+// instantly.  Thread-safe; while Run is running, it takes effect from
+// the next line.
+func (u *UI) SetTypewriterSpeed(charsPerSecond int) {
+	charsPerSecond = max(charsPerSecond, 0)
+	if u.program != nil && !u.done.Load() {
+		u.program.Send(typewriterSpeedMsg{speed: charsPerSecond})
+		return
+	}
+	u.twSpeed = charsPerSecond
+}
+
+// SetHistoryFile sets where typed commands are saved so up/down and
+// Ctrl+R can recall them in later runs.  "" keeps history for this run
+// only.  Call before Run().
+func (u *UI) SetHistoryFile(path string) { u.historyPath = path }
+
+// Window title modes: what the terminal's title (and so its tab) shows
+// while timers are going.
+const (
+	TitleAll   = "all"   // every timer: "Pasta: 2m05s | Sauce: DONE!"
+	TitleNext  = "next"  // the most pressing one: "Pasta 2m"
+	TitleCount = "count" // how many, and the most pressing: "3 timers, next: Pasta 2m"
+)
+
+// TitleModes lists the window title modes, for flag help.
+var TitleModes = []string{TitleCount, TitleNext, TitleAll}
+
+// SetTitleMode sets what the window title shows while timers are going:
+// TitleCount (the default), TitleNext, or TitleAll.  Thread-safe.
+func (u *UI) SetTitleMode(mode string) {
+	if u.program != nil && !u.done.Load() {
+		u.program.Send(titleModeMsg{mode: mode})
+		return
+	}
+	u.titleMode = mode
+}
+
+// OnMicToggle registers a callback invoked on Ctrl+O, the hotkey that
+// turns the microphone off and on.
+func (u *UI) OnMicToggle(fn func()) { u.micToggleFn = fn }
+
+// OnCancel registers a callback invoked on Esc, the hotkey that calls
+// off an AI request in flight.
+func (u *UI) OnCancel(fn func()) { u.cancelFn = fn }
+
 // OnInterrupt registers a callback invoked when the user presses
 // space with an empty input line (i.e. "shut up" gesture).
 func (u *UI) OnInterrupt(fn func()) { u.interruptFn = fn }
@@ -182,10 +284,13 @@ func (u *UI) OnInterrupt(fn func()) { u.interruptFn = fn }
 // NewUI creates the display. Call Run() to start.
 func NewUI(store domain.SessionStore) *UI {
 	return &UI{
-		store:   store,
-		inputCh: make(chan string, 16),
-		readyCh: make(chan struct{}),
-		quitCh:  make(chan struct{}),
+		store:     store,
+		twSpeed:   DefaultTypewriterSpeed,
+		titleMode: TitleCount,
+		out:       os.Stdout,
+		inputCh:   make(chan string, 16),
+		readyCh:   make(chan struct{}),
+		quitCh:    make(chan struct{}),
 	}
 }
 
@@ -195,7 +300,17 @@ func (u *UI) Println(a ...interface{}) {
 	if u.program != nil && !u.done.Load() {
 		u.program.Send(appendMsg{text: text})
 	} else {
-		fmt.Println(text)
+		fmt.Fprintln(u.out, text)
+	}
+}
+
+// printAction appends a line that runs command, as if typed, when
+// clicked.  Thread-safe.
+func (u *UI) printAction(text, command string) {
+	if u.program != nil && !u.done.Load() {
+		u.program.Send(appendMsg{text: text, action: command})
+	} else {
+		fmt.Fprintln(u.out, text)
 	}
 }
 
@@ -205,13 +320,22 @@ func (u *UI) Printf(format string, a ...interface{}) {
 	if u.program != nil && !u.done.Load() {
 		u.program.Send(appendMsg{text: text})
 	} else {
-		fmt.Printf(format, a...)
+		fmt.Fprintln(u.out, text)
 	}
 }
 
 // InputChan returns completed user-input lines.
 func (u *UI) InputChan() <-chan string { return u.inputCh }
 
+// Submit hands a line to InputChan as if it had been typed, for driving
+// the app without a terminal.
+func (u *UI) Submit(line string) { u.inputCh <- line }
+
+// SetOutput sets where lines are written while Run isn't running (before
+// it starts, after it ends, or without a terminal at all).  Defaults to
+// stdout.
+func (u *UI) SetOutput(w io.Writer) { u.out = w }
+
 // ── Styled print helpers ─────────────────────────────────────────
 // These give output visual hierarchy with lipgloss colors.
 
@@ -239,6 +363,18 @@ func (u *UI) PrintHint(text string) {
 	u.Println(secondaryStyle.Render("  " + text))
 }
 
+// PrintInstructionAction is PrintInstruction for a line that runs
+// command when clicked (with mouse support on).
+func (u *UI) PrintInstructionAction(text, command string) {
+	u.printAction(primaryStyle.Render("  "+text), command)
+}
+
+// PrintHintAction is PrintHint for a line that runs command when clicked
+// (with mouse support on).
+func (u *UI) PrintHintAction(text, command string) {
+	u.printAction(secondaryStyle.Render("  "+text), command)
+}
+
 // PrintUrgent prints an urgent/error line (red, bold).
 func (u *UI) PrintUrgent(text string) {
 	u.Println(urgentOutputStyle.Render("  " + text))
@@ -270,7 +406,7 @@ func (u *UI) PrintVoice(text string) {
 		u.program.Send(voiceInputEchoMsg{text: text})
 		return
 	}
-	fmt.Println("otto> [heard] " + text)
+	fmt.Fprintln(u.out, "otto> [heard] "+text)
 }
 
 // PrintUserInput echoes the user's typed command into the scrollback.
@@ -279,7 +415,7 @@ func (u *UI) PrintUserInput(text string) {
 		u.program.Send(userInputEchoMsg{text: text})
 		return
 	}
-	fmt.Println("otto> " + text)
+	fmt.Fprintln(u.out, "otto> "+text)
 }
 
 // SetActivity shows an animated spinner with the given label above the
@@ -330,12 +466,21 @@ func (u *UI) Run() error {
 		inputCh:          u.inputCh,
 		readyCh:          u.readyCh,
 		interruptFn:      u.interruptFn,
+		micToggleFn:      u.micToggleFn,
+		cancelFn:         u.cancelFn,
 		earListenTimeout: u.earListenTimeout,
 		earSilenceDur:    u.earSilenceDur,
 		earGraceDur:      u.earGraceDur,
+		history:          loadHistory(u.historyPath),
+		titleMode:        u.titleMode,
 	}
+	m.twChunk, m.twDelay = typewriterPace(u.twSpeed)
 
-	u.program = tea.NewProgram(m, tea.WithAltScreen())
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if u.mouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	u.program = tea.NewProgram(m, opts...)
 	_, err := u.program.Run()
 	u.done.Store(true)
 	close(u.quitCh)
@@ -353,18 +498,32 @@ type model struct {
 	inputCh     chan<- string
 	readyCh     chan struct{}
 	interruptFn func() // called on space-when-empty ("shut up")
+	micToggleFn func() // called on Ctrl+O (microphone off/on)
+	cancelFn    func() // called on Esc (call off the AI)
 	timers      []timerInfo
+	cookingFrom time.Time // when the current session started; zero when none
+	serveAt     time.Time // the session's serve time, if one was given
 	width       int
 	height      int
+	history     *history // typed commands, for up/down and Ctrl+R
+	titleMode   string   // TitleCount, TitleNext, or TitleAll
 
 	// Message buffer — all output goes here instead of program.Println.
 	messages []string
+	actions  map[int]string // message index -> command run when it's clicked
 
 	// Typewriter state.
 	twLines   []string       // pre-wrapped lines of plain text still to reveal
 	twCurLine int            // index into twLines for current line
 	twCurPos  int            // runes revealed on current line
 	twStyle   lipgloss.Style // style applied to the line
+	twGen     int            // generation counter — ticks from a flushed line are dropped
+	twChunk   int            // cells revealed per tick; 0 = no typewriter
+	twDelay   time.Duration  // time between ticks
+
+	// Alert overlay state.
+	alert     []timerInfo     // fired timers on the overlay; empty when it's down
+	alertSeen map[string]bool // fired timers by key, true once their overlay was dismissed
 
 	// Activity spinner state.
 	activityLabel string // e.g. "Thinking" — empty means no spinner
@@ -375,7 +534,8 @@ type model struct {
 	earState        EarIndicator
 	earActiveSince  time.Time // when ear entered EarActive
 	mouthState      MouthIndicator
-	mouthSpeakSince time.Time // when mouth started speaking
+	mouthSpeakSince time.Time  // when mouth started speaking
+	speechJobs      SpeechJobs // what the speech pipeline is busy with
 
 	// Ear timing constants (set once at init).
 	earListenTimeout time.Duration
@@ -384,10 +544,15 @@ type model struct {
 }
 
 type timerInfo struct {
+	key       string // session and timer, plus when it fired
+	id        string // timer ID, for clicks; "" for the wait
 	label     string
 	remaining time.Duration
+	total     time.Duration // full length; zero when unknown (no progress bar)
 	fired     bool
 	pending   bool
+	paused    bool
+	firedAt   time.Time
 }
 
 // Messages.
@@ -406,11 +571,12 @@ type typewriterStartMsg struct {
 }
 
 // typewriterTickMsg advances the typewriter by one chunk.
-type typewriterTickMsg struct{}
+type typewriterTickMsg struct{ gen int }
 
 // appendMsg adds a line to the message buffer (replaces program.Println).
 type appendMsg struct {
-	text string
+	text   string
+	action string // command sent when the line is clicked, if any
 }
 
 // activityMsg sets or clears the activity spinner.
@@ -426,6 +592,7 @@ const (
 	EarReady                        // waiting for wake word
 	EarActive                       // actively listening
 	EarSleeping                     // muted while mouth speaks
+	EarMicOff                       // microphone turned off for privacy
 )
 
 // MouthIndicator represents the mouth's display state.
@@ -447,6 +614,21 @@ type mouthStateMsg struct {
 	state MouthIndicator
 }
 
+// speechJobsMsg updates the speech detail under the mouth.
+type speechJobsMsg struct {
+	jobs SpeechJobs
+}
+
+// typewriterSpeedMsg changes the typewriter speed while running.
+type typewriterSpeedMsg struct {
+	speed int
+}
+
+// titleModeMsg changes what the window title shows while running.
+type titleModeMsg struct {
+	mode string
+}
+
 // activityTickMsg advances the spinner animation.
 type activityTickMsg struct {
 	gen int
@@ -490,9 +672,29 @@ func tickCmd() tea.Cmd {
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Any key finishes the line being typed out, so the user never
+		// waits on the effect to read what's there.
+		m.flushTypewriter()
+		if len(m.alert) > 0 && msg.Type != tea.KeyCtrlC {
+			m.dismissAlert()
+			return m, nil
+		}
+		if m.updateHistoryKey(msg) {
+			return m, nil
+		}
 		switch msg.Type {
 		case tea.KeyCtrlC:
 			return m, tea.Quit
+		case tea.KeyCtrlO:
+			if m.micToggleFn != nil {
+				m.micToggleFn()
+			}
+			return m, nil
+		case tea.KeyEsc:
+			if m.cancelFn != nil {
+				m.cancelFn()
+			}
+			return m, nil
 		case tea.KeySpace:
 			if m.input.Value() == "" && m.interruptFn != nil {
 				m.interruptFn()
@@ -502,14 +704,24 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			v := m.input.Value()
 			m.input.Reset()
 			if strings.TrimSpace(v) != "" {
-				m.inputCh <- v
-				return m, func() tea.Msg {
-					return userInputEchoMsg{text: v}
-				}
+				return m, tea.Batch(m.history.add(v), m.submit(v))
 			}
 			return m, nil
 		}
 
+	case tea.MouseMsg:
+		if msg.Action != tea.MouseActionRelease || msg.Button != tea.MouseButtonLeft {
+			return m, nil
+		}
+		if len(m.alert) > 0 {
+			m.dismissAlert()
+			return m, nil
+		}
+		if command := m.clickTarget(msg.X, msg.Y); command != "" {
+			return m, m.submit(command)
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -530,12 +742,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 
 	case typewriterStartMsg:
-		// Flush any in-progress typewriter lines directly to messages.
-		if len(m.twLines) > 0 {
-			for i := m.twCurLine; i < len(m.twLines); i++ {
-				m.messages = append(m.messages, m.twStyle.Render("  "+m.twLines[i]))
-			}
-		}
+		m.flushTypewriter()
 		// Pre-wrap text into terminal-width lines.
 		w := m.width
 		if w <= 0 {
@@ -546,16 +753,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.twStyle = msg.style
 		m.twCurLine = 0
 		m.twCurPos = 0
-		return m, twTickCmd()
+		if m.twChunk == 0 {
+			m.flushTypewriter()
+			return m, nil
+		}
+		return m, twTickCmd(m.twGen, m.twDelay)
 
 	case typewriterTickMsg:
-		if len(m.twLines) == 0 || m.twCurLine >= len(m.twLines) {
+		if msg.gen != m.twGen || len(m.twLines) == 0 || m.twCurLine >= len(m.twLines) {
 			return m, nil
 		}
-		chunk := 2
-		m.twCurPos += chunk
-		curRunes := []rune(m.twLines[m.twCurLine])
-		if m.twCurPos >= len(curRunes) {
+		m.twCurPos += m.twChunk
+		if m.twCurPos >= ansi.StringWidth(m.twLines[m.twCurLine]) {
 			// Current line done — commit to message buffer.
 			finishedLine := m.twStyle.Render("  " + m.twLines[m.twCurLine])
 			m.messages = append(m.messages, finishedLine)
@@ -566,9 +775,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.twLines = nil
 				return m, nil
 			}
-			return m, twTickCmd()
+			return m, twTickCmd(m.twGen, m.twDelay)
 		}
-		return m, twTickCmd()
+		return m, twTickCmd(m.twGen, m.twDelay)
 
 	case activityMsg:
 		m.activityLabel = msg.label
@@ -606,12 +815,25 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mouthState = msg.state
 		return m, nil
 
+	case speechJobsMsg:
+		m.speechJobs = msg.jobs
+		return m, nil
+
+	case typewriterSpeedMsg:
+		m.twChunk, m.twDelay = typewriterPace(msg.speed)
+		return m, nil
+
+	case titleModeMsg:
+		m.titleMode = msg.mode
+		return m, nil
+
 	case userInputEchoMsg:
+		m.flushTypewriter()
 		w := m.width
 		if w <= 0 {
 			w = 80
 		}
-		sep := sepLineStyle.Render("  " + strings.Repeat("╌", 46))
+		sep := sepLineStyle.Render("  " + strings.Repeat("╌", max(0, min(46, w-2))))
 		m.messages = append(m.messages, sep)
 		prefix := promptStyle.Render("otto") + secondaryStyle.Render("> ")
 		prefixW := lipgloss.Width(prefix)
@@ -626,11 +848,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case voiceInputEchoMsg:
+		m.flushTypewriter()
 		w := m.width
 		if w <= 0 {
 			w = 80
 		}
-		sep := sepLineStyle.Render("  " + strings.Repeat("╌", 46))
+		sep := sepLineStyle.Render("  " + strings.Repeat("╌", max(0, min(46, w-2))))
 		m.messages = append(m.messages, sep)
 		prefix := secondaryStyle.Render("otto> [heard] ")
 		prefixW := lipgloss.Width(prefix)
@@ -645,6 +868,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case appendMsg:
+		// Finish the chat line first so output stays in the order it
+		// was printed.
+		m.flushTypewriter()
+		if msg.action != "" {
+			if m.actions == nil {
+				m.actions = make(map[int]string)
+			}
+			m.actions[len(m.messages)] = msg.action
+		}
 		m.messages = append(m.messages, msg.text)
 		return m, nil
 	}
@@ -654,10 +886,70 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// submit sends v to the app as a command and echoes it.
+func (m model) submit(v string) tea.Cmd {
+	m.inputCh <- v
+	return func() tea.Msg {
+		return userInputEchoMsg{text: v}
+	}
+}
+
+// clickTarget returns the command for a click at (x, y), or "" when
+// nothing clickable is there.
+func (m model) clickTarget(x, y int) string {
+	top, bar, _, msgH := m.layout()
+	if y < len(top) {
+		return ""
+	}
+	y -= len(top)
+	if y < len(bar) {
+		for _, it := range bar[y].items {
+			// +1 for the bar's leading space.
+			if x >= it.start+1 && x < it.end+1 && it.id != "" {
+				return "dismiss " + it.id
+			}
+		}
+		return ""
+	}
+	if len(bar) > 0 {
+		y -= len(bar) + 1 // bar rows + buffer line
+	}
+	if y < 0 || y >= msgH {
+		return ""
+	}
+	return m.renderMessages(msgH)[y].action
+}
+
+// flushTypewriter commits whatever is left of the line being typed out
+// to the message buffer at once.
+func (m *model) flushTypewriter() {
+	for i := m.twCurLine; i < len(m.twLines); i++ {
+		m.messages = append(m.messages, m.twStyle.Render("  "+m.twLines[i]))
+	}
+	m.twLines = nil
+	m.twGen++
+}
+
+// typewriterPace turns a speed in characters per second into how many
+// cells to reveal per tick and how long to wait between ticks.  Ticks
+// are kept at least ~16ms apart (about one frame) by revealing more per
+// tick at high speeds.  A speed of 0 gives chunk 0: no typewriter.
+func typewriterPace(charsPerSecond int) (chunk int, delay time.Duration) {
+	if charsPerSecond <= 0 {
+		return 0, 0
+	}
+	const minDelay = 16 * time.Millisecond
+	chunk = 2
+	for time.Duration(chunk)*time.Second/time.Duration(charsPerSecond) < minDelay {
+		chunk++
+	}
+	return chunk, time.Duration(chunk) * time.Second / time.Duration(charsPerSecond)
+}
+
 // twTickCmd schedules the next typewriter tick.
-func twTickCmd() tea.Cmd {
-	return tea.Tick(25*time.Millisecond, func(time.Time) tea.Msg {
-		return typewriterTickMsg{}
+func twTickCmd(gen int, delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return typewriterTickMsg{gen: gen}
 	})
 }
 
@@ -695,71 +987,186 @@ func crossingBar(frame, width int) string {
 	return b.String()
 }
 
-// wrapText breaks s into lines of at most maxWidth runes, splitting
-// on word boundaries when possible.
+// wrapText breaks s into lines at most maxWidth cells wide, splitting
+// on word boundaries when possible.  s may already contain ANSI styling
+// (e.g. a lipgloss-rendered name inside a chat line); escape sequences
+// are never split and don't count towards the width.
 func wrapText(s string, maxWidth int) []string {
 	if maxWidth <= 0 {
 		maxWidth = 78
 	}
-	words := strings.Fields(s)
-	if len(words) == 0 {
+	return wrapANSI(strings.Join(strings.Fields(s), " "), maxWidth, 0)
+}
+
+// wrapANSI wraps s to width cells, indenting continuation lines by
+// indent spaces.  Styling still open at a line break is closed at the
+// end of that line and reopened at the start of the next, so each line
+// renders correctly on its own.
+func wrapANSI(s string, width, indent int) []string {
+	if ansi.StringWidth(s) <= width {
 		return []string{s}
 	}
-	var lines []string
-	cur := words[0]
-	for _, w := range words[1:] {
-		if len([]rune(cur))+1+len([]rune(w)) > maxWidth {
-			lines = append(lines, cur)
-			cur = w
-		} else {
-			cur += " " + w
+	if indent >= width {
+		indent = 0
+	}
+	first, rest, _ := strings.Cut(ansi.Wrap(s, width-indent, ""), "\n")
+	lines := []string{first}
+	if rest != "" {
+		pad := strings.Repeat(" ", indent)
+		for _, l := range strings.Split(rest, "\n") {
+			lines = append(lines, pad+l)
 		}
 	}
-	lines = append(lines, cur)
+	return carrySGR(lines)
+}
+
+// carrySGR makes each line self-contained: SGR (colour/style) sequences
+// still in effect at the end of a line are reset there and replayed at
+// the start of the next.
+func carrySGR(lines []string) []string {
+	var open []string
+	for i, l := range lines {
+		prefix := strings.Join(open, "")
+		for _, seq := range sgrPattern.FindAllString(l, -1) {
+			if seq == "\x1b[0m" || seq == "\x1b[m" {
+				open = open[:0]
+			} else {
+				open = append(open, seq)
+			}
+		}
+		if len(open) > 0 {
+			l += "\x1b[0m"
+		}
+		lines[i] = prefix + l
+	}
 	return lines
 }
 
+var sgrPattern = regexp.MustCompile(`\x1b\[[0-9;:]*m`)
+
 func (m *model) refreshTimers() {
 	sessions, err := m.store.ListActive(context.Background())
 	if err != nil {
 		return
 	}
 	m.timers = m.timers[:0]
+	m.cookingFrom, m.serveAt = time.Time{}, time.Time{}
 	for _, s := range sessions {
+		if m.cookingFrom.IsZero() || s.StartedAt.Before(m.cookingFrom) {
+			m.cookingFrom = s.StartedAt
+		}
+		if !s.ServeAt.IsZero() {
+			m.serveAt = s.ServeAt
+		}
+		if s.Status == domain.SessionWaiting {
+			m.timers = append(m.timers, timerInfo{
+				label:     "Waiting",
+				remaining: max(0, time.Until(s.WaitUntil)),
+			})
+		}
 		for _, ts := range s.TimerStates {
 			switch ts.Status {
 			case domain.TimerPending:
 				m.timers = append(m.timers, timerInfo{
+					id:        ts.ID,
 					label:     ts.Label,
 					remaining: ts.Remaining,
 					pending:   true,
 				})
-			case domain.TimerRunning:
+			case domain.TimerRunning, domain.TimerPaused:
 				m.timers = append(m.timers, timerInfo{
+					id:        ts.ID,
 					label:     ts.Label,
 					remaining: ts.Remaining,
+					total:     ts.Duration,
+					paused:    ts.Status == domain.TimerPaused,
 				})
 			case domain.TimerFired:
 				m.timers = append(m.timers, timerInfo{
-					label: ts.Label,
-					fired: true,
+					key:     s.ID + "/" + ts.ID + "@" + ts.FiredAt.Format(time.RFC3339Nano),
+					id:      ts.ID,
+					label:   ts.Label,
+					fired:   true,
+					firedAt: ts.FiredAt,
 				})
 			}
 		}
 	}
-	// Sort by label so the bar doesn't shuffle every tick.
-	sort.Slice(m.timers, func(i, j int) bool {
-		return m.timers[i].label < m.timers[j].label
+	sortByUrgency(m.timers)
+	m.checkAlerts()
+}
+
+// sortByUrgency orders timers fired first, then running by shortest
+// remaining, then paused, then pending.  Running timers count down
+// together, so the order only changes when one is added, paused, or
+// fires.  Ties go by label so the bar doesn't shuffle every tick.
+func sortByUrgency(timers []timerInfo) {
+	rank := func(t timerInfo) int {
+		switch {
+		case t.fired:
+			return 0
+		case t.paused:
+			return 2
+		case t.pending:
+			return 3
+		default:
+			return 1
+		}
+	}
+	sort.SliceStable(timers, func(i, j int) bool {
+		a, b := timers[i], timers[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		if !a.fired && !a.pending && a.remaining.Round(time.Second) != b.remaining.Round(time.Second) {
+			return a.remaining < b.remaining
+		}
+		return a.label < b.label
 	})
 }
 
+// clockStr is the top row's readout: the time of day, how long the
+// session has been cooking, and the serve time when there is one.
+func (m model) clockStr() string {
+	parts := []string{time.Now().Format(time.Kitchen)}
+	if !m.cookingFrom.IsZero() {
+		d := time.Since(m.cookingFrom).Truncate(time.Minute)
+		if h := int(d.Hours()); h > 0 {
+			parts = append(parts, fmt.Sprintf("cooking %dh%02dm", h, int(d.Minutes())%60))
+		} else {
+			parts = append(parts, fmt.Sprintf("cooking %dm", int(d.Minutes())))
+		}
+	}
+	if !m.serveAt.IsZero() {
+		parts = append(parts, "serve "+m.serveAt.Format(time.Kitchen))
+	}
+	return "   " + strings.Join(parts, " · ")
+}
+
+// titleStr is the window title while timers are going.  Tab titles are
+// short, so by default only the most pressing timer is named: the first
+// after sortByUrgency, a fired one if any.
 func (m model) titleStr() string {
+	switch m.titleMode {
+	case TitleNext:
+		return "OttoCook — " + titleTimer(m.timers[0])
+	case TitleAll:
+		// Every timer, below.
+	default:
+		if len(m.timers) == 1 {
+			return "OttoCook — " + titleTimer(m.timers[0])
+		}
+		return fmt.Sprintf("OttoCook — %d timers, next: %s", len(m.timers), titleTimer(m.timers[0]))
+	}
+
 	var p []string
 	for _, t := range m.timers {
 		if t.fired {
 			p = append(p, t.label+": DONE!")
 		} else if t.pending {
 			p = append(p, t.label+": waiting")
+		} else if t.paused {
+			p = append(p, t.label+": paused")
 		} else {
 			p = append(p, t.label+": "+fmtDuration(t.remaining))
 		}
@@ -768,6 +1175,32 @@ func (m model) titleStr() string {
 }
 
 func (m model) View() string {
+	top, bar, bottom, msgH := m.layout()
+
+	var out []string
+	out = append(out, top...)
+	if len(bar) > 0 {
+		for _, r := range bar {
+			out = append(out, r.text)
+		}
+		out = append(out, "") // buffer line
+	}
+	lines := m.renderMessages
+	if len(m.alert) > 0 {
+		lines = m.renderAlert
+	}
+	for _, l := range lines(msgH) {
+		out = append(out, l.text)
+	}
+	out = append(out, bottom...)
+
+	return strings.Join(out, "\n")
+}
+
+// layout builds the screen's fixed parts — the top row, timer bar, and
+// bottom section — and works out how many rows are left for messages.
+// View draws it; clickTarget uses it to map a click back to a row.
+func (m model) layout() (top []string, bar []barRow, bottom []string, msgH int) {
 	w := m.width
 	h := m.height
 	if w <= 0 {
@@ -778,9 +1211,8 @@ func (m model) View() string {
 	}
 
 	// ── 1. Top row: branding left + inspector right ──
-	var topLines []string
-	box := m.renderInspector()
-	brand := brandStyle.Render("  Otto")
+	box := m.renderInspector(w)
+	brand := brandStyle.Render("  Otto") + labelStyle.Render(m.clockStr())
 	if box != "" {
 		// Place brand left, inspector right on the same rows.
 		boxLines := strings.Split(box, "\n")
@@ -798,114 +1230,293 @@ func (m model) View() string {
 			if gap < 0 {
 				gap = 0
 			}
-			topLines = append(topLines, left+strings.Repeat(" ", gap)+bl)
+			top = append(top, left+strings.Repeat(" ", gap)+bl)
 		}
 	} else {
-		topLines = append(topLines, brand)
+		top = append(top, brand)
 	}
 
 	// ── 2. Timer bar (pinned right after top row) ──
 	if len(m.timers) > 0 {
-		topLines = append(topLines, m.renderBar())
-		topLines = append(topLines, "") // buffer line
+		bar = m.renderBar()
 	}
 
 	// ── 3. Bottom section: activity + typewriter + blank + prompt ──
-	var bottomParts []string
 	if m.activityLabel != "" {
 		frame := spinnerFrames[m.activityFrame%len(spinnerFrames)]
-		bottomParts = append(bottomParts,
+		bottom = append(bottom,
 			activityStyle.Render("  "+frame+" "+m.activityLabel))
 		barW := 1 + 1 + len([]rune(m.activityLabel))
-		bottomParts = append(bottomParts,
+		bottom = append(bottom,
 			"  "+crossingBar(m.activityFrame, barW))
 	}
 	if len(m.twLines) > 0 && m.twCurLine < len(m.twLines) {
-		runes := []rune(m.twLines[m.twCurLine])
-		n := m.twCurPos
-		if n > len(runes) {
-			n = len(runes)
-		}
-		bottomParts = append(bottomParts,
-			m.twStyle.Render("  "+string(runes[:n])))
+		// Truncate by cells, not runes, so styling inside the line is
+		// never cut mid-sequence.
+		shown := ansi.Truncate(m.twLines[m.twCurLine], m.twCurPos, "")
+		bottom = append(bottom, m.twStyle.Render("  "+shown))
+	}
+	bottom = append(bottom, "") // blank separator
+	if m.history.searching {
+		bottom = append(bottom, m.searchView())
+	} else {
+		bottom = append(bottom, m.input.View())
 	}
-	bottomParts = append(bottomParts, "") // blank separator
-	bottomParts = append(bottomParts, m.input.View())
 
 	// ── 4. Message area fills remaining height ──
-	topH := len(topLines)
-	bottomH := len(bottomParts)
-	msgH := h - topH - bottomH
+	topH := len(top)
+	if len(bar) > 0 {
+		topH += len(bar) + 1
+	}
+	msgH = h - topH - len(bottom)
 	if msgH < 0 {
 		msgH = 0
 	}
+	return top, bar, bottom, msgH
+}
 
-	// ── 5. Compose full screen ──
-	var out []string
-	out = append(out, topLines...)
-	out = append(out, m.renderMessages(msgH)...)
-	out = append(out, bottomParts...)
+// maxBarLines is how many rows the timer bar may take before it falls
+// back to the compact form, and then to "+N more".
+const maxBarLines = 2
 
-	return strings.Join(out, "\n")
+// barRow is one rendered row of the timer bar.  items records where
+// each timer sits on the row so a click can be mapped back to it.
+type barRow struct {
+	text  string
+	items []barItem
+}
+
+// barItem is one entry in the timer bar.  start and end are columns
+// within the row's content; label is empty for the "+N more" marker.
+type barItem struct {
+	text       string
+	id         string // timer ID a click dismisses; "" for none
+	start, end int
 }
 
-func (m model) renderBar() string {
-	var parts []string
+// renderBar renders the timer bar, wrapping onto a second row when the
+// timers don't fit on one.  If they need more rows than maxBarLines, it
+// switches to a compact form (short labels, no colons), and if even
+// that doesn't fit, the least urgent timers are folded into "+N more".
+func (m model) renderBar() []barRow {
+	w := m.width
+	if w <= 0 {
+		w = 80
+	}
+
+	var full, compact []barItem
 	for _, t := range m.timers {
-		if t.fired {
-			parts = append(parts, timerDoneStyle.Render(t.label+": DONE!"))
-		} else if t.pending {
-			parts = append(parts, timerPendingStyle.Render(t.label+": waiting"))
-		} else {
-			parts = append(parts,
-				labelStyle.Render(t.label+": ")+
-					timerRunStyle.Render(fmtDuration(t.remaining)))
+		full = append(full, barItem{text: renderTimer(t, false), id: t.id})
+		compact = append(compact, barItem{text: renderTimer(t, true), id: t.id})
+	}
+
+	rows := packBar(full, sepStyle.Render("  │  "), w-2)
+	if len(rows) > maxBarLines {
+		rows = packBar(compact, sepStyle.Render(" │ "), w-2)
+	}
+	if len(rows) > maxBarLines {
+		rows = packBarLimited(compact, sepStyle.Render(" │ "), w-2, maxBarLines)
+	}
+
+	for i := range rows {
+		rows[i].text = barBg.Width(w).Render(" " + rows[i].text + " ")
+	}
+	return rows
+}
+
+// renderTimer formats one timer for the bar.  compact drops the colon
+// and "DONE!"/"waiting" words, shortens long labels, and uses a shorter
+// progress bar.
+func renderTimer(t timerInfo, compact bool) string {
+	if !compact {
+		switch {
+		case t.fired:
+			return timerDoneStyle.Render(t.label + ": DONE!")
+		case t.pending:
+			return timerPendingStyle.Render(t.label + ": waiting")
+		case t.paused:
+			return timerPendingStyle.Render(t.label + ": paused " + progressBar(t, 8) + fmtDuration(t.remaining))
+		default:
+			return labelStyle.Render(t.label+": ") + timerStyle(t).Render(progressBar(t, 8)+fmtDuration(t.remaining))
 		}
 	}
+	label := truncateLabel(t.label, 12)
+	switch {
+	case t.fired:
+		return timerDoneStyle.Render(label + " !")
+	case t.pending:
+		return timerPendingStyle.Render(label + " …")
+	case t.paused:
+		return timerPendingStyle.Render(label + " ‖ " + progressBar(t, 4) + fmtDuration(t.remaining))
+	default:
+		return labelStyle.Render(label+" ") + timerStyle(t).Render(progressBar(t, 4)+fmtDuration(t.remaining))
+	}
+}
 
-	content := " " + strings.Join(parts, sepStyle.Render("  │  ")) + " "
+// progressBar draws how much of a timer has elapsed in cells characters,
+// followed by a space, or nothing when its full length isn't known.
+func progressBar(t timerInfo, cells int) string {
+	if t.total <= 0 {
+		return ""
+	}
+	elapsed := float64(t.total-t.remaining) / float64(t.total)
+	filled := min(cells, max(0, int(math.Round(elapsed*float64(cells)))))
+	return strings.Repeat("█", filled) + strings.Repeat("░", cells-filled) + " "
+}
 
-	w := m.width
-	if w <= 0 {
-		w = 80
+// timerStyle colours a running timer by how close it is to done: green
+// with plenty left, amber in the last quarter or last two minutes, red
+// in the last tenth or last 30 seconds.
+func timerStyle(t timerInfo) lipgloss.Style {
+	frac := 1.0
+	if t.total > 0 {
+		frac = float64(t.remaining) / float64(t.total)
+	}
+	switch {
+	case frac <= 0.1 || t.remaining <= 30*time.Second:
+		return timerLateStyle
+	case frac <= 0.25 || t.remaining <= 2*time.Minute:
+		return timerRunStyle
+	default:
+		return timerFreshStyle
 	}
-	return barBg.Width(w).Render(content)
+}
+
+// packBar lays items out left to right, starting a new row whenever the
+// next one wouldn't fit in width.
+func packBar(items []barItem, sep string, width int) []barRow {
+	sepW := lipgloss.Width(sep)
+	var rows []barRow
+	var cur barRow
+	col := 0
+	for _, it := range items {
+		itW := lipgloss.Width(it.text)
+		if len(cur.items) > 0 && col+sepW+itW > width {
+			rows = append(rows, cur)
+			cur, col = barRow{}, 0
+		}
+		if len(cur.items) > 0 {
+			cur.text += sep
+			col += sepW
+		}
+		it.start, it.end = col, col+itW
+		cur.text += it.text
+		cur.items = append(cur.items, it)
+		col += itW
+	}
+	if len(cur.items) > 0 {
+		rows = append(rows, cur)
+	}
+	return rows
+}
+
+// packBarLimited packs as many items as fit in maxRows rows, leaving
+// room at the end for a "+N more" marker covering the rest.  items are
+// in urgency order, so the ones dropped are the least pressing.
+func packBarLimited(items []barItem, sep string, width, maxRows int) []barRow {
+	more := func(n int) barItem {
+		return barItem{text: timerPendingStyle.Render(fmt.Sprintf("+%d more", n))}
+	}
+	for n := len(items) - 1; n > 0; n-- {
+		rows := packBar(append(items[:n:n], more(len(items)-n)), sep, width)
+		if len(rows) <= maxRows {
+			return rows
+		}
+	}
+	return packBar([]barItem{items[0], more(len(items) - 1)}, sep, width)
+}
+
+// leadingSpaces returns the indentation of a possibly styled line,
+// capped so deeply indented lines still leave room to wrap.
+func leadingSpaces(s string) int {
+	plain := ansi.Strip(s)
+	return min(len(plain)-len(strings.TrimLeft(plain, " ")), 8)
+}
+
+func truncateLabel(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// msgLine is one terminal row of the message area.
+type msgLine struct {
+	text   string
+	action string // command run when the row is clicked, if any
 }
 
 // renderMessages returns exactly `height` lines from the tail of the
 // message buffer, padding with blanks at top when content is short.
-func (m model) renderMessages(height int) []string {
+// Lines wider than the terminal are wrapped at the current width, so
+// the scrollback reflows when the window is resized.
+func (m model) renderMessages(height int) []msgLine {
 	if height <= 0 {
 		return nil
 	}
-
-	// Flatten all messages into individual terminal lines.
-	var allLines []string
-	for _, msg := range m.messages {
-		allLines = append(allLines, strings.Split(msg, "\n")...)
+	w := m.width
+	if w <= 0 {
+		w = 80
 	}
 
-	// Take the last `height` lines.
-	start := len(allLines) - height
-	if start < 0 {
-		start = 0
+	// Flatten messages into terminal lines, newest first, stopping once
+	// the screen is full — older messages aren't visible anyway.
+	var rev []msgLine
+	for i := len(m.messages) - 1; i >= 0 && len(rev) < height; i-- {
+		var lines []string
+		for _, l := range strings.Split(m.messages[i], "\n") {
+			lines = append(lines, wrapANSI(l, w, leadingSpaces(l))...)
+		}
+		for j := len(lines) - 1; j >= 0; j-- {
+			rev = append(rev, msgLine{text: lines[j], action: m.actions[i]})
+		}
+	}
+	if len(rev) > height {
+		rev = rev[:height]
+	}
+	visible := make([]msgLine, 0, height)
+	for i := len(rev) - 1; i >= 0; i-- {
+		visible = append(visible, rev[i])
 	}
-	visible := allLines[start:]
 
 	// Pad with blank lines at the top.
 	for len(visible) < height {
-		visible = append([]string{""}, visible...)
+		visible = append([]msgLine{{}}, visible...)
 	}
 
 	return visible
 }
 
-// renderInspector builds the top-right status box showing ear + mouth state.
-func (m model) renderInspector() string {
+// Inspector layout thresholds.  At inspectorFullWidth columns and up
+// the full box fits beside the brand; down to inspectorCompactWidth it
+// collapses to a single "ear … │ mouth …" line; below that it's hidden
+// so the prompt and timer bar get the room.
+const (
+	inspectorFullWidth    = 72
+	inspectorCompactWidth = 44
+)
+
+// renderInspector builds the top-right status box showing ear + mouth
+// state, sized for a terminal width columns wide.  Empty when there's
+// nothing to show or no room for it.
+func (m model) renderInspector(width int) string {
 	if m.earState == EarOff && m.mouthState == MouthOff {
 		return ""
 	}
+	switch {
+	case width >= inspectorFullWidth:
+		return m.renderInspectorBox()
+	case width >= inspectorCompactWidth:
+		return inspectLabel.Render("ear ") + m.earValue() +
+			sepStyle.Render(" │ ") + inspectLabel.Render("mouth ") + m.mouthValue()
+	default:
+		return ""
+	}
+}
 
+// renderInspectorBox is the full bordered inspector.
+func (m model) renderInspectorBox() string {
 	// Inner content width = box Width - 2 (border) - 2 (padding).
 	const innerW = 32
 
@@ -924,54 +1535,71 @@ func (m model) renderInspector() string {
 	lines = append(lines, inspectHeader.Render("-- status --"))
 
 	// ── Ear ──
-	switch m.earState {
-	case EarReady:
-		lines = append(lines, row(
-			inspectLabel.Render("ear"),
-			inspectOn.Render("awaiting wake word")))
-	case EarActive:
-		elapsed := m.fmtElapsed(m.earActiveSince)
+	lines = append(lines, row(inspectLabel.Render("ear"), m.earValue()))
+	if m.earState == EarActive && m.earListenTimeout > 0 && !m.earActiveSince.IsZero() {
+		remain := m.earListenTimeout - time.Since(m.earActiveSince)
+		if remain < 0 {
+			remain = 0
+		}
 		lines = append(lines, row(
-			inspectLabel.Render("ear"),
-			inspectActive.Render("listening ")+inspectTimer.Render(elapsed)))
-		if m.earListenTimeout > 0 && !m.earActiveSince.IsZero() {
-			remain := m.earListenTimeout - time.Since(m.earActiveSince)
-			if remain < 0 {
-				remain = 0
-			}
+			inspectLabel.Render("└ timeout"),
+			inspectTimer.Render(fmtDuration(remain))))
+	}
+
+	// ── Mouth ──
+	lines = append(lines, row(inspectLabel.Render("mouth"), m.mouthValue()))
+	if m.mouthState != MouthOff {
+		j := m.speechJobs
+		switch {
+		case j.Chunks > 0 && j.Ready < j.Chunks:
 			lines = append(lines, row(
-				inspectLabel.Render("└ timeout"),
-				inspectTimer.Render(fmtDuration(remain))))
+				inspectLabel.Render("└ synthesizing"),
+				inspectActive.Render(fmt.Sprintf("chunk %d/%d", j.Ready+1, j.Chunks))))
+		case j.Chunks > 0 && j.CacheHit:
+			lines = append(lines, row(inspectLabel.Render("└ source"), inspectDim.Render("cache hit")))
+		}
+		if j.Queued > 0 {
+			lines = append(lines, row(inspectLabel.Render("└ queued"), inspectTimer.Render(fmt.Sprint(j.Queued))))
 		}
+		if j.Prefetching > 0 {
+			what := fmt.Sprint(j.Prefetching)
+			if len(j.PrefetchGroups) > 0 {
+				what = strings.Join(j.PrefetchGroups, ", ") + " ×" + what
+			}
+			lines = append(lines, row(inspectLabel.Render("└ prefetching"), inspectDim.Render(what)))
+		}
+	}
+
+	content := strings.Join(lines, "\n")
+	return inspectBorder.Render(content)
+}
+
+// earValue renders the ear's state, e.g. "listening 3s".
+func (m model) earValue() string {
+	switch m.earState {
+	case EarReady:
+		return inspectOn.Render("awaiting wake word")
+	case EarActive:
+		return inspectActive.Render("listening ") + inspectTimer.Render(m.fmtElapsed(m.earActiveSince))
 	case EarSleeping:
-		lines = append(lines, row(
-			inspectLabel.Render("ear"),
-			inspectDim.Render("paused")))
+		return inspectDim.Render("paused")
+	case EarMicOff:
+		return inspectMicOff.Render("MIC OFF")
 	default:
-		lines = append(lines, row(
-			inspectLabel.Render("ear"),
-			inspectOff.Render("disabled")))
+		return inspectOff.Render("disabled")
 	}
+}
 
-	// ── Mouth ──
+// mouthValue renders the mouth's state, e.g. "speaking 2s".
+func (m model) mouthValue() string {
 	switch m.mouthState {
 	case MouthIdle:
-		lines = append(lines, row(
-			inspectLabel.Render("mouth"),
-			inspectOn.Render("idle")))
+		return inspectOn.Render("idle")
 	case MouthSpeaking:
-		elapsed := m.fmtElapsed(m.mouthSpeakSince)
-		lines = append(lines, row(
-			inspectLabel.Render("mouth"),
-			inspectActive.Render("speaking ")+inspectTimer.Render(elapsed)))
+		return inspectActive.Render("speaking ") + inspectTimer.Render(m.fmtElapsed(m.mouthSpeakSince))
 	default:
-		lines = append(lines, row(
-			inspectLabel.Render("mouth"),
-			inspectOff.Render("disabled")))
+		return inspectOff.Render("disabled")
 	}
-
-	content := strings.Join(lines, "\n")
-	return inspectBorder.Render(content)
 }
 
 // fmtElapsed formats duration since t as a compact string.
@@ -987,6 +1615,27 @@ func (m model) fmtElapsed(t time.Time) string {
 	return fmt.Sprintf("%dm%02ds", s/60, s%60)
 }
 
+// titleTimer is one timer in the short title forms: "Pasta 2m".
+func titleTimer(t timerInfo) string {
+	switch {
+	case t.fired:
+		return t.label + " DONE!"
+	case t.pending:
+		return t.label + " waiting"
+	case t.paused:
+		return t.label + " paused"
+	}
+	d := t.remaining.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%s %ds", t.label, int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%s %dm", t.label, int(d.Round(time.Minute).Minutes()))
+	default:
+		return fmt.Sprintf("%s %dh%02dm", t.label, int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
 // ── Helpers ──────────────────────────────────────────────────────
 
 func fmtDuration(d time.Duration) string {
@@ -0,0 +1,85 @@
+package display
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTypewriterSpeedMsg(t *testing.T) {
+	tests := []struct {
+		speed int
+		chunk int
+		delay time.Duration
+	}{
+		{80, 2, 25 * time.Millisecond},
+		{0, 0, 0},
+	}
+	for _, tt := range tests {
+		next, _ := model{}.Update(typewriterSpeedMsg{speed: tt.speed})
+		m := next.(model)
+		if m.twChunk != tt.chunk || m.twDelay != tt.delay {
+			t.Errorf("speed %d: got chunk %d delay %v, want %d %v", tt.speed, m.twChunk, m.twDelay, tt.chunk, tt.delay)
+		}
+	}
+}
+
+func TestTitleModeMsg(t *testing.T) {
+	timers := []timerInfo{
+		{label: "pasta", remaining: 90 * time.Second},
+		{label: "sauce", paused: true},
+	}
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{"", "OttoCook — 2 timers, next: pasta 2m"},
+		{TitleNext, "OttoCook — pasta 2m"},
+		{TitleAll, "OttoCook — pasta: 1m30s | sauce: paused"},
+	}
+	for _, tt := range tests {
+		next, _ := model{timers: timers}.Update(titleModeMsg{mode: tt.mode})
+		m := next.(model)
+		if m.titleMode != tt.mode {
+			t.Errorf("mode %q: titleMode = %q", tt.mode, m.titleMode)
+		}
+		if got := m.titleStr(); got != tt.want {
+			t.Errorf("mode %q: title = %q, want %q", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestSettersBeforeRun(t *testing.T) {
+	u := &UI{}
+	u.SetTypewriterSpeed(-5)
+	u.SetTitleMode(TitleAll)
+	if u.twSpeed != 0 {
+		t.Errorf("twSpeed = %d, want 0", u.twSpeed)
+	}
+	if u.titleMode != TitleAll {
+		t.Errorf("titleMode = %q, want %q", u.titleMode, TitleAll)
+	}
+}
+
+func TestClickTargetTimerBar(t *testing.T) {
+	m := model{width: 80, height: 24, history: loadHistory(""), timers: []timerInfo{
+		{id: "timer-pasta", label: "pasta", remaining: 90 * time.Second},
+		{label: "Waiting", remaining: time.Hour},
+	}}
+	top, bar, _, _ := m.layout()
+	if len(bar) != 1 || len(bar[0].items) != 2 {
+		t.Fatalf("bar = %+v, want one row of two timers", bar)
+	}
+	tests := []struct {
+		item int
+		want string
+	}{
+		{0, "dismiss timer-pasta"},
+		{1, ""}, // the wait isn't a timer
+	}
+	for _, tt := range tests {
+		it := bar[0].items[tt.item]
+		if got := m.clickTarget(it.start+1, len(top)); got != tt.want {
+			t.Errorf("click on item %d = %q, want %q", tt.item, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package display
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ── Input history ────────────────────────────────────────────────
+//
+// Submitted commands are kept in memory for up/down recall and Ctrl+R
+// search, and appended to a plain-text file (one command per line) so
+// they survive restarts.
+
+// maxHistory is how many commands are kept, in memory and on disk.
+const maxHistory = 500
+
+// history is the command history plus the browsing / search cursor.
+type history struct {
+	entries []string   // oldest first
+	path    string     // file commands are appended to; "" = don't persist
+	fileMu  sync.Mutex // serialises appends to path with the trims after them
+
+	pos   int    // entry being shown while browsing; len(entries) = not browsing
+	draft string // what was typed before browsing started
+
+	searching bool
+	query     string
+	match     int // index into entries of the current search hit, -1 = none
+}
+
+// loadHistory reads the last maxHistory commands from path.  A missing
+// file is an empty history.
+func loadHistory(path string) *history {
+	h := &history{path: path, match: -1}
+	if path == "" {
+		return h
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return h
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[len(h.entries)-maxHistory:]
+	}
+	h.pos = len(h.entries)
+	return h
+}
+
+// add records a submitted command and resets browsing.  Returns a
+// command that appends it to the history file, or nil.
+func (h *history) add(line string) tea.Cmd {
+	line = strings.TrimSpace(line)
+	h.pos = len(h.entries)
+	h.draft = ""
+	if line == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == line) {
+		return nil
+	}
+	h.entries = append(h.entries, line)
+	if len(h.entries) > maxHistory {
+		h.entries = h.entries[len(h.entries)-maxHistory:]
+	}
+	h.pos = len(h.entries)
+	if h.path == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		h.appendFile(line)
+		return nil
+	}
+}
+
+// prev steps back to an older command.  current is the text in the
+// input, kept as the draft when browsing starts.
+func (h *history) prev(current string) (string, bool) {
+	if h.pos == 0 {
+		return "", false
+	}
+	if h.pos == len(h.entries) {
+		h.draft = current
+	}
+	h.pos--
+	return h.entries[h.pos], true
+}
+
+// next steps forward to a newer command, ending on the draft.
+func (h *history) next() (string, bool) {
+	if h.pos >= len(h.entries) {
+		return "", false
+	}
+	h.pos++
+	if h.pos == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.pos], true
+}
+
+// search finds the newest command older than before containing query
+// (case-insensitive).  Returns -1 when nothing matches.
+func (h *history) search(query string, before int) int {
+	q := strings.ToLower(query)
+	for i := min(before, len(h.entries)) - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToLower(h.entries[i]), q) {
+			return i
+		}
+	}
+	return -1
+}
+
+// hit returns the current search match, or "".
+func (h *history) hit() string {
+	if h.match < 0 || h.match >= len(h.entries) {
+		return ""
+	}
+	return h.entries[h.match]
+}
+
+// appendFile writes one command to the end of the history file,
+// trimming the file back to maxHistory lines when it grows to twice that.
+// Appends run as commands, so two can overlap; the lock keeps a trim
+// from writing back a file that's missing the other's line.
+func (h *history) appendFile(line string) {
+	h.fileMu.Lock()
+	defer h.fileMu.Unlock()
+	path := h.path
+	if dir := filepath.Dir(path); dir != "." {
+		os.MkdirAll(dir, 0o755)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	f.WriteString(line + "\n")
+	f.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) < 2*maxHistory {
+		return
+	}
+	lines = lines[len(lines)-maxHistory:]
+	os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600)
+}
+
+// updateHistoryKey handles the keys that browse or search history.
+// Returns true if the key was consumed.
+func (m *model) updateHistoryKey(msg tea.KeyMsg) bool {
+	h := m.history
+	if h.searching {
+		switch msg.Type {
+		case tea.KeyCtrlR:
+			if i := h.search(h.query, h.match); i >= 0 {
+				h.match = i
+			}
+		case tea.KeyBackspace:
+			if r := []rune(h.query); len(r) > 0 {
+				h.query = string(r[:len(r)-1])
+				h.match = h.search(h.query, len(h.entries))
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			h.query += string(msg.Runes)
+			if msg.Type == tea.KeySpace {
+				h.query += " "
+			}
+			h.match = h.search(h.query, len(h.entries))
+		case tea.KeyEsc, tea.KeyCtrlG:
+			h.searching = false
+		case tea.KeyEnter:
+			// Take the match into the input but leave submitting to a
+			// second Enter, so a stale hit can still be edited.
+			h.searching = false
+			if hit := h.hit(); hit != "" {
+				m.input.SetValue(hit)
+				m.input.CursorEnd()
+			}
+		default:
+			// Any other key (arrows, Ctrl+A...) accepts the match and
+			// carries on editing with it.
+			h.searching = false
+			if hit := h.hit(); hit != "" {
+				m.input.SetValue(hit)
+				m.input.CursorEnd()
+			}
+			return false
+		}
+		return true
+	}
+
+	switch msg.Type {
+	case tea.KeyCtrlR:
+		h.searching = true
+		h.query = ""
+		h.match = -1
+		return true
+	case tea.KeyUp:
+		if v, ok := h.prev(m.input.Value()); ok {
+			m.input.SetValue(v)
+			m.input.CursorEnd()
+		}
+		return true
+	case tea.KeyDown:
+		if v, ok := h.next(); ok {
+			m.input.SetValue(v)
+			m.input.CursorEnd()
+		}
+		return true
+	}
+	return false
+}
+
+// searchView renders the prompt line while searching history.
+func (m *model) searchView() string {
+	h := m.history
+	label := "(search)'" + h.query + "': "
+	if h.query != "" && h.match < 0 {
+		label = "(failed search)'" + h.query + "': "
+	}
+	return promptStyle.Render(label) + userInputEchoStyle.Render(h.hit())
+}
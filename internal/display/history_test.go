@@ -0,0 +1,122 @@
+package display
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestHistoryBrowse(t *testing.T) {
+	h := loadHistory("")
+	for _, line := range []string{"next", " next ", "pause", "", "set a timer for 5 minutes"} {
+		if cmd := h.add(line); cmd != nil {
+			t.Errorf("add(%q) wants to write with no history file", line)
+		}
+	}
+	if want := []string{"next", "pause", "set a timer for 5 minutes"}; !slices.Equal(h.entries, want) {
+		t.Fatalf("entries = %q, want %q (blank and repeated lines dropped)", h.entries, want)
+	}
+
+	steps := []struct {
+		key  string
+		want string
+		ok   bool
+	}{
+		{"up", "set a timer for 5 minutes", true},
+		{"up", "pause", true},
+		{"up", "next", true},
+		{"up", "", false},
+		{"down", "pause", true},
+		{"down", "set a timer for 5 minutes", true},
+		{"down", "half-typed", true}, // back to the draft
+		{"down", "", false},
+	}
+	for i, s := range steps {
+		var got string
+		var ok bool
+		if s.key == "up" {
+			got, ok = h.prev("half-typed")
+		} else {
+			got, ok = h.next()
+		}
+		if got != s.want || ok != s.ok {
+			t.Errorf("step %d (%s) = %q, %v; want %q, %v", i, s.key, got, ok, s.want, s.ok)
+		}
+	}
+
+	tests := []struct {
+		query  string
+		before int
+		want   int
+	}{
+		{"TIMER", 3, 2},
+		{"e", 3, 2},
+		{"e", 2, 1},
+		{"e", 1, 0},
+		{"e", 0, -1},
+		{"stir", 3, -1},
+		{"next", 99, 0},
+	}
+	for _, tt := range tests {
+		if got := h.search(tt.query, tt.before); got != tt.want {
+			t.Errorf("search(%q, %d) = %d, want %d", tt.query, tt.before, got, tt.want)
+		}
+	}
+}
+
+func TestHistoryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "history")
+	h := loadHistory(path)
+	for _, line := range []string{"next", "pause"} {
+		h.add(line)()
+	}
+	if got := loadHistory(path).entries; !slices.Equal(got, []string{"next", "pause"}) {
+		t.Errorf("reloaded %q", got)
+	}
+
+	// A long file loads its last maxHistory lines.
+	var lines []string
+	for i := range maxHistory + 10 {
+		lines = append(lines, fmt.Sprintf("cmd %d", i))
+	}
+	os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600)
+	h = loadHistory(path)
+	if len(h.entries) != maxHistory || h.entries[0] != "cmd 10" || h.pos != maxHistory {
+		t.Errorf("loaded %d entries from %q at %d; want the last %d", len(h.entries), h.entries[0], h.pos, maxHistory)
+	}
+}
+
+func TestHistoryFileConcurrentAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	h := loadHistory(path)
+
+	// Enough to trim once: the file should end up with exactly the lines
+	// written since, none lost to an overlapping trim.
+	n := 2*maxHistory + 10
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.appendFile(fmt.Sprintf("cmd %d", i))
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if want := maxHistory + 10; len(lines) != want {
+		t.Errorf("history file has %d lines, want %d", len(lines), want)
+	}
+	slices.Sort(lines)
+	if len(slices.Compact(lines)) != len(lines) {
+		t.Error("history file has a line twice")
+	}
+}
@@ -0,0 +1,83 @@
+package domain
+
+// ── Intent arguments ─────────────────────────────────────────────
+//
+// Intent.Payload is the words; Intent.Args is what they mean, for the
+// intents whose payload has a shape: a recipe number, a timer and what
+// to do with it, a helper and their job.  The keyword parser and the AI
+// classifier both fill it in (see conversation.Structure), so handlers
+// read fields rather than re-parse strings.  Free-form intents, such as
+// a question or a modification, only have the Payload.
+
+// SelectRecipeArgs picks a recipe by its number in the last list, or
+// by name.
+type SelectRecipeArgs struct {
+	Index int // 1-based; 0 when picked by name
+	Name  string
+}
+
+// TimerArgs pauses, resumes, cancels, or restarts timers.
+type TimerArgs struct {
+	Action string // "pause", "resume", "cancel", or "restart"; "" when not understood
+	Target string // "all", a timer's number, a label, or "" for the one that's obvious
+}
+
+// VersionArgs lists a recipe's versions, or brings one back.
+type VersionArgs struct {
+	Action  string // "" to list, "restore", or "start" to cook it
+	Version int
+}
+
+// CompareArgs names two recipes to set side by side.  Each is a number
+// in the last list, a version of the selected recipe ("v2"), or a
+// recipe's ID or name.
+type CompareArgs struct {
+	A, B string
+}
+
+// TagArgs adds or removes a tag.
+type TagArgs struct {
+	Tag    string // "" when none was given
+	Remove bool
+}
+
+// CollectArgs adds a recipe to a collection or takes it out.
+type CollectArgs struct {
+	Collection string // "" when none was given
+	Remove     bool
+}
+
+// DuplicateArgs names a copy of a recipe.
+type DuplicateArgs struct {
+	Name string // "" for a bare "duplicate"
+}
+
+// NoteArgs is a note on the current step.
+type NoteArgs struct {
+	Text string // "" with Keep set keeps every note from the session
+	Keep bool   // keep it in the recipe for next time
+}
+
+// HowMuchArgs asks after an ingredient's quantity.
+type HowMuchArgs struct {
+	Ingredient string // "" when it couldn't be picked out
+}
+
+// TaskArgs is a helper's job: handed over, finished, or asked about.
+type TaskArgs struct {
+	Helper string // "" for everyone, when asking
+	Words  string // the job, or words from it
+	Reopen bool   // it isn't done after all
+}
+
+// DietArgs is a dietary need.
+type DietArgs struct {
+	Name string // "" for the speaker
+	Need string
+}
+
+// PluginArgs is a command for a plugin.
+type PluginArgs struct {
+	Intent string // the plugin's name for it
+	Text   string
+}
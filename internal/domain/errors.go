@@ -9,5 +9,10 @@ var (
 	ErrSessionPaused    = errors.New("session is paused")
 	ErrNoMoreSteps      = errors.New("no more steps in recipe")
 	ErrAlreadyExists    = errors.New("already exists")
-	ErrNotImplemented   = errors.New("not implemented")
+	ErrNoSuchSection    = errors.New("no such section")
+	ErrNoSuchStep       = errors.New("no such step")
+	ErrNoWait           = errors.New("step has no wait")
+	ErrAmbiguous        = errors.New("ambiguous")
+	ErrNoSuchCondition  = errors.New("no such condition")
+	ErrNothingToHandOff = errors.New("nothing to hand off")
 )
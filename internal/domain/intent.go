@@ -17,10 +17,41 @@ const (
 	IntentQuit
 	IntentHelp
 	IntentDismissTimer
-	IntentRepeatLast  // replay the last thing the mouth said
-	IntentAskQuestion // free-form question sent to the AI agent
-	IntentModify      // user wants the AI to change something (recipe, servings, etc.)
-	IntentStartTimer  // user confirms they're ready — start pending timers
+	IntentRepeatLast   // replay the last thing the mouth said
+	IntentAskQuestion  // free-form question sent to the AI agent
+	IntentModify       // user wants the AI to change something (recipe, servings, etc.)
+	IntentStartTimer   // user confirms they're ready — start pending timers
+	IntentResumeLast   // pick up whatever was cut off or dropped mid-answer
+	IntentSearch       // find recipes matching a free-text query
+	IntentTag          // add or remove a tag on the selected recipe
+	IntentCollect      // add or remove the selected recipe from a collection
+	IntentDuplicate    // save a copy of the selected recipe as a named variant
+	IntentNote         // attach a note to the current step, optionally keeping it in the recipe
+	IntentTimerControl // pause, resume, cancel, or restart one timer or all of them
+	IntentServeTime    // set (or clear) the time the cook wants to eat
+	IntentPrepList     // read out the knife work to do before cooking
+	IntentHowMuch      // how much of an ingredient the recipe calls for
+	IntentVersions     // list the recipe's versions, or restore or cook an older one
+	IntentMisheard     // the last voice command was misheard; payload is what was meant, if said
+	IntentFeedback     // rate the last AI answer; payload is "up" or "down"
+	IntentCheck        // check off a condition of the current step; payload names it
+	IntentDelegate     // hand a task to a helper; payload is "Sam" or "Sam: chop the broccoli"
+	IntentTaskDone     // a helper's task is done; payload is "Sam", "Sam: broccoli", or "not …" to reopen
+	IntentTasks        // list helpers' tasks; payload names a helper, or is empty for all
+	IntentCalendar     // export the session's upcoming milestones to a calendar file
+	IntentTimeline     // draw the session as it went, on a time axis
+	IntentCompare      // set two recipes side by side; payload is "<first> vs <second>"
+	IntentSuggest      // propose a few recipes; payload is any time or ingredients asked for
+	IntentPairing      // what to drink with the recipe; payload is the full input
+	IntentMic          // turn the microphone "off" or "on" (payload)
+	IntentVoice        // shut speech and voice input down ("off") or bring them back ("on")
+	IntentReload       // re-read the config files and apply what can change while running
+	IntentMissedWake   // the wake word was said and not heard
+	IntentEnrollVoice  // learn the speaker's voice under the name in the payload
+	IntentDiet         // note a dietary need; payload is "Name: need", or ": need" for the speaker
+	IntentPlugin       // handled by a plugin; payload is "<plugin intent>: <input>"
+	IntentCancel       // "never mind": drop the AI request or follow-up question left open
+	IntentAcknowledge  // "ok", "thanks": nothing to do
 )
 
 // String returns a human-readable intent type.
@@ -58,6 +89,68 @@ func (i IntentType) String() string {
 		return "modify"
 	case IntentStartTimer:
 		return "start_timer"
+	case IntentResumeLast:
+		return "resume_last"
+	case IntentSearch:
+		return "search_recipes"
+	case IntentTag:
+		return "tag_recipe"
+	case IntentCollect:
+		return "collect_recipe"
+	case IntentDuplicate:
+		return "duplicate_recipe"
+	case IntentNote:
+		return "add_note"
+	case IntentTimerControl:
+		return "timer_control"
+	case IntentServeTime:
+		return "serve_time"
+	case IntentPrepList:
+		return "prep_list"
+	case IntentHowMuch:
+		return "how_much"
+	case IntentVersions:
+		return "recipe_versions"
+	case IntentMisheard:
+		return "misheard"
+	case IntentFeedback:
+		return "answer_feedback"
+	case IntentCheck:
+		return "check_condition"
+	case IntentDelegate:
+		return "delegate"
+	case IntentTaskDone:
+		return "task_done"
+	case IntentTasks:
+		return "tasks"
+	case IntentCalendar:
+		return "calendar"
+	case IntentTimeline:
+		return "timeline"
+	case IntentCompare:
+		return "compare_recipes"
+	case IntentSuggest:
+		return "suggest_recipe"
+	case IntentPairing:
+		return "pairing"
+	case IntentMic:
+		return "microphone"
+	case IntentVoice:
+		return "voice"
+	case IntentReload:
+		return "reload"
+	case IntentMissedWake:
+		return "missed_wake"
+	case IntentEnrollVoice:
+		return "enroll_voice"
+	case IntentDiet:
+		return "diet"
+	case IntentPlugin:
+		return "plugin"
+	case IntentCancel:
+		return "cancel"
+	case IntentAcknowledge:
+		return "acknowledge"
 	default:
 		return "unknown"
 	}
@@ -67,27 +160,69 @@ func (i IntentType) String() string {
 type Intent struct {
 	Type    IntentType
 	Payload string // optional context, e.g. recipe ID for select
+	Args    any    // Payload's meaning, for the intents that have one (see args.go)
 }
 
-// intentNames maps snake_case names to IntentType values.
+// intentNames maps snake_case names to IntentType values.  IntentPlugin
+// isn't here: the names are what the AI classifier may answer, and it
+// doesn't know which plugin to name.
 var intentNames = map[string]IntentType{
-	"list_recipes":  IntentListRecipes,
-	"select_recipe": IntentSelectRecipe,
-	"start_cooking": IntentStartCooking,
-	"advance":       IntentAdvance,
-	"skip":          IntentSkip,
-	"repeat":        IntentRepeat,
-	"pause":         IntentPause,
-	"resume":        IntentResume,
-	"status":        IntentStatus,
-	"quit":          IntentQuit,
-	"help":          IntentHelp,
-	"dismiss_timer": IntentDismissTimer,
-	"repeat_last":   IntentRepeatLast,
-	"ask_question":  IntentAskQuestion,
-	"modify":        IntentModify,
-	"start_timer":   IntentStartTimer,
-	"unknown":       IntentUnknown,
+	"list_recipes":     IntentListRecipes,
+	"select_recipe":    IntentSelectRecipe,
+	"start_cooking":    IntentStartCooking,
+	"advance":          IntentAdvance,
+	"skip":             IntentSkip,
+	"repeat":           IntentRepeat,
+	"pause":            IntentPause,
+	"resume":           IntentResume,
+	"status":           IntentStatus,
+	"quit":             IntentQuit,
+	"help":             IntentHelp,
+	"dismiss_timer":    IntentDismissTimer,
+	"repeat_last":      IntentRepeatLast,
+	"ask_question":     IntentAskQuestion,
+	"modify":           IntentModify,
+	"start_timer":      IntentStartTimer,
+	"resume_last":      IntentResumeLast,
+	"search_recipes":   IntentSearch,
+	"tag_recipe":       IntentTag,
+	"collect_recipe":   IntentCollect,
+	"duplicate_recipe": IntentDuplicate,
+	"add_note":         IntentNote,
+	"timer_control":    IntentTimerControl,
+	"serve_time":       IntentServeTime,
+	"prep_list":        IntentPrepList,
+	"how_much":         IntentHowMuch,
+	"recipe_versions":  IntentVersions,
+	"misheard":         IntentMisheard,
+	"answer_feedback":  IntentFeedback,
+	"check_condition":  IntentCheck,
+	"delegate":         IntentDelegate,
+	"task_done":        IntentTaskDone,
+	"tasks":            IntentTasks,
+	"calendar":         IntentCalendar,
+	"timeline":         IntentTimeline,
+	"compare_recipes":  IntentCompare,
+	"suggest_recipe":   IntentSuggest,
+	"pairing":          IntentPairing,
+	"microphone":       IntentMic,
+	"voice":            IntentVoice,
+	"reload":           IntentReload,
+	"missed_wake":      IntentMissedWake,
+	"enroll_voice":     IntentEnrollVoice,
+	"diet":             IntentDiet,
+	"cancel":           IntentCancel,
+	"acknowledge":      IntentAcknowledge,
+	"unknown":          IntentUnknown,
+}
+
+// IntentNames returns every snake_case intent name, in no particular order.
+func IntentNames() []string {
+	names := make([]string, 0, len(intentNames))
+	for name := range intentNames {
+		names = append(names, name)
+	}
+	return names
 }
 
 // IntentFromString converts a snake_case intent name to an IntentType.
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // RecipeSource provides recipes. Implementations can be in-memory (hardcoded),
 // file-based, API-backed, or LLM-generated.
@@ -32,10 +35,15 @@ type Notifier interface {
 	NotifyUrgent(ctx context.Context, message string) error
 }
 
-// SpeechProvider handles voice input/output. The Listen method is for
-// speech-to-text (future), and Speak sends text through the TTS pipeline.
-// The no-op implementation is used when voice is disabled.
-type SpeechProvider interface {
-	Listen(ctx context.Context) (string, error)
-	Speak(ctx context.Context, text string) error
+// Clock tells the time.  Anything whose behaviour depends on how much
+// time has passed takes one, so tests can move time along instead of
+// waiting for it.
+type Clock interface {
+	Now() time.Time
 }
+
+// SystemClock is the wall clock.
+type SystemClock struct{}
+
+// Now returns time.Now().
+func (SystemClock) Now() time.Time { return time.Now() }
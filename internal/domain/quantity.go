@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// quantityFractions are the fractions a quantity is shown with, as the
+// glyphs recipes print.  Anything else falls back to a short decimal.
+var quantityFractions = []struct {
+	v     float64
+	glyph string
+}{
+	{1.0 / 8, "⅛"},
+	{1.0 / 4, "¼"},
+	{1.0 / 3, "⅓"},
+	{1.0 / 2, "½"},
+	{2.0 / 3, "⅔"},
+	{3.0 / 4, "¾"},
+}
+
+// FormatQuantity shows an ingredient amount the way a recipe card does:
+// whole numbers bare, common fractions as glyphs ("½", "1¾"), and
+// anything else to at most two decimals with trailing zeros trimmed.
+// The same string is shown on screen and, after speech normalization,
+// read aloud.
+func FormatQuantity(q float64) string {
+	whole := math.Floor(q)
+	frac := q - whole
+	if frac < 0.01 {
+		return strconv.FormatFloat(whole, 'f', -1, 64)
+	}
+	if frac > 0.99 {
+		return strconv.FormatFloat(whole+1, 'f', -1, 64)
+	}
+	for _, f := range quantityFractions {
+		if math.Abs(frac-f.v) < 0.01 {
+			if whole == 0 {
+				return f.glyph
+			}
+			return strconv.FormatFloat(whole, 'f', -1, 64) + f.glyph
+		}
+	}
+	s := strconv.FormatFloat(q, 'f', 2, 64)
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
+
+// sizeWords are size descriptors that read before the unit ("4 medium
+// cloves"); other descriptors ("grated", "to taste") read after the name.
+var sizeWords = map[string]bool{"small": true, "medium": true, "large": true, "heaped": true, "level": true}
+
+// Phrase says how much of the ingredient the recipe calls for, e.g.
+// "4 medium cloves of garlic", "1 cup of gruyere cheese, grated", "salt,
+// to taste".
+func (i Ingredient) Phrase() string {
+	desc := strings.TrimSpace(i.SizeDescriptor)
+	if i.Quantity <= 0 {
+		if desc != "" {
+			return i.Name + ", " + desc
+		}
+		return i.Name
+	}
+
+	var b strings.Builder
+	b.WriteString(FormatQuantity(i.Quantity))
+	if sizeWords[strings.ToLower(desc)] {
+		b.WriteString(" " + desc)
+		desc = ""
+	}
+	unit := strings.TrimSpace(i.Unit)
+	if unit != "" && unit != "pieces" && unit != "piece" {
+		b.WriteString(" " + unit + " of")
+	}
+	b.WriteString(" " + i.Name)
+	if desc != "" {
+		b.WriteString(", " + desc)
+	}
+	return b.String()
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "testing"
+
+func TestFormatQuantity(t *testing.T) {
+	tests := []struct {
+		q    float64
+		want string
+	}{
+		{0, "0"},
+		{2, "2"},
+		{250, "250"},
+		{0.5, "½"},
+		{1.75, "1¾"},
+		{1.0 / 3, "⅓"},
+		{2 + 2.0/3, "2⅔"},
+		{0.125, "⅛"},
+		{0.333, "⅓"}, // close enough to a third
+		{1.995, "2"},
+		{3.004, "3"},
+		{0.4, "0.4"},
+		{1.15, "1.15"},
+		{2.0456, "2.05"},
+	}
+	for _, tt := range tests {
+		if got := FormatQuantity(tt.q); got != tt.want {
+			t.Errorf("FormatQuantity(%v) = %q, want %q", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestIngredientPhrase(t *testing.T) {
+	tests := []struct {
+		ing  Ingredient
+		want string
+	}{
+		{Ingredient{Name: "garlic", Quantity: 4, Unit: "cloves", SizeDescriptor: "medium"}, "4 medium cloves of garlic"},
+		{Ingredient{Name: "gruyere cheese", Quantity: 1, Unit: "cup", SizeDescriptor: "grated"}, "1 cup of gruyere cheese, grated"},
+		{Ingredient{Name: "butter", Quantity: 0.5, Unit: "cup"}, "½ cup of butter"},
+		{Ingredient{Name: "eggs", Quantity: 2, Unit: "pieces"}, "2 eggs"},
+		{Ingredient{Name: "salt", SizeDescriptor: "to taste"}, "salt, to taste"},
+		{Ingredient{Name: "water"}, "water"},
+	}
+	for _, tt := range tests {
+		if got := tt.ing.Phrase(); got != tt.want {
+			t.Errorf("Phrase(%+v) = %q, want %q", tt.ing, got, tt.want)
+		}
+	}
+}
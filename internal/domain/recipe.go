@@ -2,7 +2,11 @@
 // All other packages depend on domain; domain depends on nothing.
 package domain
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
 // Recipe represents a complete cooking recipe.
 type Recipe struct {
@@ -13,7 +17,64 @@ type Recipe struct {
 	Ingredients []Ingredient
 	Steps       []Step
 	Tags        []string
+	Collections []string // user collections this recipe belongs to, e.g. "Weeknight favorites"
+	ParentID    string   // recipe this one was duplicated from, "" for originals
 	Version     int
+	PrepTime    time.Duration // hands-on prep before cooking starts; 0 if unknown
+	CookTime    time.Duration // time at the stove or oven; 0 if unknown
+	Difficulty  Difficulty
+	Equipment   []string // needed throughout, e.g. "wok"; steps list their own extras
+}
+
+// TotalTime is prep plus cook time.
+func (r *Recipe) TotalTime() time.Duration {
+	return r.PrepTime + r.CookTime
+}
+
+// AllEquipment is everything the recipe needs: its own equipment first,
+// then what individual steps add, each named once.
+func (r *Recipe) AllEquipment() []string {
+	var out []string
+	seen := map[string]bool{}
+	add := func(items []string) {
+		for _, item := range items {
+			key := strings.ToLower(strings.TrimSpace(item))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, item)
+		}
+	}
+	add(r.Equipment)
+	for _, s := range r.Steps {
+		add(s.Equipment)
+	}
+	return out
+}
+
+// Summary returns the listing view of the recipe.
+func (r *Recipe) Summary() RecipeSummary {
+	return RecipeSummary{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Tags:        r.Tags,
+		Collections: r.Collections,
+		PrepTime:    r.PrepTime,
+		CookTime:    r.CookTime,
+		Difficulty:  r.Difficulty,
+		Version:     r.Version,
+	}
+}
+
+// RecipeVersion is a recipe as it was at one version, kept so it can be
+// looked back at or restored.
+type RecipeVersion struct {
+	Version int
+	SavedAt time.Time
+	Change  string // what produced this version, e.g. "update servings to 4"
+	Recipe  *Recipe
 }
 
 // RecipeSummary is a lightweight view of a recipe for listing.
@@ -22,6 +83,54 @@ type RecipeSummary struct {
 	Name        string
 	Description string
 	Tags        []string
+	Collections []string
+	PrepTime    time.Duration
+	CookTime    time.Duration
+	Difficulty  Difficulty
+	Version     int
+}
+
+// TotalTime is prep plus cook time.
+func (r RecipeSummary) TotalTime() time.Duration {
+	return r.PrepTime + r.CookTime
+}
+
+// Difficulty rates how much skill a recipe asks for.
+type Difficulty int
+
+const (
+	DifficultyUnrated Difficulty = iota
+	DifficultyEasy
+	DifficultyMedium
+	DifficultyHard
+)
+
+// String returns a human-readable difficulty, "" when unrated.
+func (d Difficulty) String() string {
+	switch d {
+	case DifficultyEasy:
+		return "easy"
+	case DifficultyMedium:
+		return "medium"
+	case DifficultyHard:
+		return "hard"
+	default:
+		return ""
+	}
+}
+
+// ParseDifficulty reads "easy", "medium", or "hard" and the usual
+// synonyms ("simple", "beginner", "advanced").
+func ParseDifficulty(s string) (Difficulty, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "easy", "simple", "beginner":
+		return DifficultyEasy, true
+	case "medium", "moderate", "intermediate":
+		return DifficultyMedium, true
+	case "hard", "difficult", "advanced", "challenging":
+		return DifficultyHard, true
+	}
+	return DifficultyUnrated, false
 }
 
 // Ingredient represents a single ingredient with human-style quantities.
@@ -31,6 +140,44 @@ type Ingredient struct {
 	Unit           string // "pieces", "cups", "tablespoons", "grams", ""
 	SizeDescriptor string // "small", "medium", "large", "handful", ""
 	Optional       bool
+	Prep           string // knife work before cooking: "minced", "sliced into strips", ""
+}
+
+// genericWords don't identify an ingredient by themselves: "black" in
+// "black pepper", "oil" in "olive oil".
+var genericWords = map[string]bool{
+	"fresh": true, "black": true, "white": true, "green": true, "large": true, "small": true,
+	"sauce": true, "cheese": true, "breast": true, "florets": true, "powder": true, "dried": true,
+	"ground": true, "whole": true, "water": true, "stock": true, "paste": true, "juice": true,
+}
+
+// MentionedIn reports whether text refers to the ingredient: by its full
+// name (or plural), or by any distinctive word of it ("chicken" for
+// "chicken breast", "broccoli" for "broccoli florets").
+func (i Ingredient) MentionedIn(text string) bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 0x80 || r == '\'')
+	})
+	joined := " " + strings.Join(words, " ") + " "
+	name := strings.ToLower(strings.TrimSpace(i.Name))
+	if name == "" {
+		return false
+	}
+	for _, form := range []string{name, name + "s", name + "es"} {
+		if strings.Contains(joined, " "+form+" ") {
+			return true
+		}
+	}
+	parts := strings.Fields(name)
+	if len(parts) < 2 {
+		return false
+	}
+	for _, p := range parts {
+		if len(p) >= 4 && !genericWords[p] && slices.Contains(words, p) {
+			return true
+		}
+	}
+	return false
 }
 
 // Step represents a single cooking step.
@@ -42,6 +189,45 @@ type Step struct {
 	Conditions    []StepCondition
 	ParallelHints []string // suggestions like "while waiting, chop X"
 	TimerConfig   *TimerConfig
+	Notes         []string      // kept from earlier cooks, e.g. "the sauce needed 5 extra minutes"
+	Section       string        // part of the recipe, e.g. "Garnish"; consecutive steps share it
+	Optional      bool          // can be left out; a section is optional if its steps are
+	Wait          time.Duration // hands-off wait ("marinate 2 hours"); the app can be closed meanwhile
+	Equipment     []string      // needed for this step only, e.g. "meat thermometer"
+	Ingredients   []string      // names of the ingredients used; read from the instruction when empty
+}
+
+// StepIngredients is what step i uses: the ingredients its Ingredients
+// field names, or else the ones its instruction mentions.
+func (r *Recipe) StepIngredients(i int) []Ingredient {
+	if i < 0 || i >= len(r.Steps) {
+		return nil
+	}
+	step := r.Steps[i]
+	var out []Ingredient
+	for _, ing := range r.Ingredients {
+		if len(step.Ingredients) > 0 {
+			if slices.ContainsFunc(step.Ingredients, func(n string) bool { return strings.EqualFold(n, ing.Name) }) {
+				out = append(out, ing)
+			}
+		} else if ing.MentionedIn(step.Instruction) {
+			out = append(out, ing)
+		}
+	}
+	return out
+}
+
+// Length is how long the step is expected to take: its duration, else
+// its timer, else its hands-off wait.
+func (s Step) Length() time.Duration {
+	switch {
+	case s.Duration > 0:
+		return s.Duration
+	case s.TimerConfig != nil:
+		return s.TimerConfig.Duration
+	default:
+		return s.Wait
+	}
 }
 
 // StepCondition defines when a step is considered done.
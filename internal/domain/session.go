@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
 // Session represents an active cooking session.
 type Session struct {
@@ -14,6 +18,88 @@ type Session struct {
 	Status           SessionStatus
 	StartedAt        time.Time
 	UpdatedAt        time.Time
+	WaitUntil        time.Time // when a SessionWaiting session wakes up
+	ServeAt          time.Time // when the cook wants to eat; zero if they haven't said
+	Tasks            []*Task   // jobs handed to helpers, in the order given
+	Timeline         []Event   // pauses, waits, timers and nudges, oldest first
+}
+
+// TimeLeft estimates the cooking time left at now: the expected length
+// of the current step, less time already spent on it, plus every step
+// still pending.  Declined and skipped steps don't count.
+func (s *Session) TimeLeft(recipe *Recipe, now time.Time) time.Duration {
+	var left time.Duration
+	for i, step := range recipe.Steps {
+		st, ok := s.StepStates[i]
+		if !ok {
+			continue
+		}
+		switch st.Status {
+		case StepPending:
+			left += step.Length()
+		case StepActive:
+			left += max(0, step.Length()-now.Sub(st.StartedAt))
+		}
+	}
+	return left
+}
+
+// Pace compares the time spent so far with the recipe's estimates at
+// now: each finished step's actual length against its estimate, plus how
+// far the current step has run over.  Positive is behind, negative
+// ahead.  Steps the recipe gives no estimate for, and skipped steps,
+// don't count.
+func (s *Session) Pace(recipe *Recipe, now time.Time) time.Duration {
+	var pace time.Duration
+	for i, step := range recipe.Steps {
+		st, ok := s.StepStates[i]
+		if !ok || step.Length() == 0 || st.StartedAt.IsZero() {
+			continue
+		}
+		switch st.Status {
+		case StepDone:
+			if !st.CompletedAt.IsZero() {
+				pace += st.CompletedAt.Sub(st.StartedAt) - step.Length()
+			}
+		case StepActive:
+			pace += max(0, now.Sub(st.StartedAt)-step.Length())
+		}
+	}
+	return pace
+}
+
+// Wake ends a hands-off wait: the session is active again and timers
+// paused for the wait run on.
+func (s *Session) Wake(now time.Time) {
+	s.Status = SessionActive
+	s.WaitUntil = time.Time{}
+	s.UpdatedAt = now
+	s.Record(now, EventWaitEnded, "", "")
+	s.ReleaseTimers(now)
+}
+
+// HoldTimers pauses the running timers along with the session, marking
+// them so ReleaseTimers restarts only these.  Pending timers stay
+// pending, and timers the cook paused stay paused.
+func (s *Session) HoldTimers(now time.Time) {
+	for _, ts := range s.TimerStates {
+		if ts.Status == TimerRunning {
+			s.SetTimer(ts, TimerPaused, now)
+			ts.Held = true
+		}
+	}
+}
+
+// ReleaseTimers restarts the timers HoldTimers paused.
+func (s *Session) ReleaseTimers(now time.Time) {
+	for _, ts := range s.TimerStates {
+		if ts.Held {
+			ts.Held = false
+			if ts.Status == TimerPaused {
+				s.SetTimer(ts, TimerRunning, now)
+			}
+		}
+	}
 }
 
 // SessionStatus tracks the lifecycle of a cooking session.
@@ -24,6 +110,7 @@ const (
 	SessionPaused
 	SessionCompleted
 	SessionAbandoned
+	SessionWaiting // in a hands-off wait step until WaitUntil
 )
 
 // String returns a human-readable session status.
@@ -37,16 +124,128 @@ func (s SessionStatus) String() string {
 		return "completed"
 	case SessionAbandoned:
 		return "abandoned"
+	case SessionWaiting:
+		return "waiting"
+	default:
+		return "unknown"
+	}
+}
+
+// Task is a job handed to someone cooking alongside: usually one of a
+// step's parallel hints ("Sam: chop the broccoli").
+type Task struct {
+	ID       string
+	Helper   string // who's doing it
+	Text     string
+	Step     int // index of the step it was given on
+	Done     bool
+	Assigned time.Time
+	DoneAt   time.Time
+}
+
+// OpenTasks returns the tasks not yet done, all helpers' when helper is
+// empty.
+func (s *Session) OpenTasks(helper string) []*Task {
+	var out []*Task
+	for _, t := range s.Tasks {
+		if !t.Done && (helper == "" || strings.EqualFold(t.Helper, helper)) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Event is a moment in a session worth putting on its timeline: what
+// the step states don't already say.
+type Event struct {
+	At   time.Time
+	Kind EventKind
+	Ref  string // the timer's ID, for timer events
+	Text string // what was said, for a nudge
+}
+
+// EventKind is what happened at an Event.
+type EventKind int
+
+const (
+	EventPaused EventKind = iota
+	EventResumed
+	EventWaitStarted
+	EventWaitEnded
+	EventTimerStarted // counting down, from the start or after a pause
+	EventTimerStopped // paused, cancelled or dismissed
+	EventTimerFired
+	EventNudge // the watcher spoke up
+)
+
+// String returns a human-readable event kind.
+func (k EventKind) String() string {
+	switch k {
+	case EventPaused:
+		return "paused"
+	case EventResumed:
+		return "resumed"
+	case EventWaitStarted:
+		return "wait started"
+	case EventWaitEnded:
+		return "wait ended"
+	case EventTimerStarted:
+		return "timer started"
+	case EventTimerStopped:
+		return "timer stopped"
+	case EventTimerFired:
+		return "timer fired"
+	case EventNudge:
+		return "nudge"
 	default:
 		return "unknown"
 	}
 }
 
+// Record adds an event to the session's timeline.
+func (s *Session) Record(at time.Time, kind EventKind, ref, text string) {
+	s.Timeline = append(s.Timeline, Event{At: at, Kind: kind, Ref: ref, Text: text})
+}
+
+// SetTimer moves a timer to status, recording on the timeline when it
+// starts counting, goes off, or stops counting or ringing.
+func (s *Session) SetTimer(ts *TimerState, status TimerStatus, now time.Time) {
+	was := ts.Status
+	ts.Status = status
+	switch {
+	case was == status:
+	case status == TimerRunning:
+		s.Record(now, EventTimerStarted, ts.ID, "")
+	case status == TimerFired:
+		s.Record(now, EventTimerFired, ts.ID, "")
+	case was == TimerRunning || was == TimerFired:
+		s.Record(now, EventTimerStopped, ts.ID, "")
+	}
+}
+
 // StepState tracks progress of a single step within a session.
 type StepState struct {
 	Status      StepStatus
 	StartedAt   time.Time
 	CompletedAt time.Time
+	Notes       []string // user notes taken during this session
+	Met         []int    // indexes of the step's Conditions the cook has checked off
+}
+
+// ChecksConditions reports whether the cook has checked off any step
+// condition this session, and so is keeping track of them.
+func (s *Session) ChecksConditions() bool {
+	for _, st := range s.StepStates {
+		if len(st.Met) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMet reports whether condition i of the step has been checked off.
+func (s *StepState) IsMet(i int) bool {
+	return slices.Contains(s.Met, i)
 }
 
 // StepStatus tracks the state of a single step.
@@ -83,10 +282,12 @@ type TimerState struct {
 	Duration        time.Duration
 	Remaining       time.Duration
 	Status          TimerStatus
+	FiredAt         time.Time // when it went off
 	LastNotified    time.Time
 	LastRemindedAt  time.Time // last periodic reminder
 	WarnedAlmost    bool      // true after the "almost done" warning
 	EscalationLevel int
+	Held            bool // paused with the session, so it resumes with it
 }
 
 // TimerStatus represents the state of a timer.
@@ -98,6 +299,7 @@ const (
 	TimerPaused
 	TimerFired
 	TimerDismissed
+	TimerCancelled // stopped before it went off
 )
 
 // String returns a human-readable timer status.
@@ -113,6 +315,8 @@ func (t TimerStatus) String() string {
 		return "fired"
 	case TimerDismissed:
 		return "dismissed"
+	case TimerCancelled:
+		return "cancelled"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Comparing recipes ────────────────────────────────────────────
+//
+// "Compare 1 and 2" sets two recipes side by side, say two variants of a
+// dish or two versions of one: which ingredients they share and in what
+// amounts, which only one uses, and how many steps and how much time
+// each takes.  Ingredients are matched by name, ignoring case.
+
+// Comparison is two recipes side by side.
+type Comparison struct {
+	A, B        *domain.Recipe
+	Ingredients []IngredientPair // A's in order, then those only B uses
+	TimeA       time.Duration    // the steps' expected lengths added up
+	TimeB       time.Duration
+}
+
+// IngredientPair is one ingredient as each recipe uses it.
+type IngredientPair struct {
+	Name string
+	A, B *domain.Ingredient // nil in the recipe that doesn't use it
+}
+
+// Same reports whether both recipes use the ingredient, in the same
+// amount.
+func (p IngredientPair) Same() bool {
+	return p.A != nil && p.B != nil && p.A.Quantity == p.B.Quantity && strings.EqualFold(p.A.Unit, p.B.Unit) &&
+		strings.EqualFold(p.A.SizeDescriptor, p.B.SizeDescriptor) && p.A.Optional == p.B.Optional
+}
+
+// CompareRecipes sets a beside b.
+func CompareRecipes(a, b *domain.Recipe) *Comparison {
+	c := &Comparison{A: a, B: b, TimeA: stepsLength(a), TimeB: stepsLength(b)}
+	inB := make(map[string]*domain.Ingredient, len(b.Ingredients))
+	for i := range b.Ingredients {
+		inB[strings.ToLower(b.Ingredients[i].Name)] = &b.Ingredients[i]
+	}
+	seen := make(map[string]bool)
+	for i := range a.Ingredients {
+		key := strings.ToLower(a.Ingredients[i].Name)
+		seen[key] = true
+		c.Ingredients = append(c.Ingredients, IngredientPair{Name: a.Ingredients[i].Name, A: &a.Ingredients[i], B: inB[key]})
+	}
+	for i := range b.Ingredients {
+		if !seen[strings.ToLower(b.Ingredients[i].Name)] {
+			c.Ingredients = append(c.Ingredients, IngredientPair{Name: b.Ingredients[i].Name, B: &b.Ingredients[i]})
+		}
+	}
+	return c
+}
+
+// Differences names the ingredients only A uses, only B uses, and
+// that both use in different amounts.
+func (c *Comparison) Differences() (onlyA, onlyB, changed []string) {
+	for _, p := range c.Ingredients {
+		switch {
+		case p.B == nil:
+			onlyA = append(onlyA, p.Name)
+		case p.A == nil:
+			onlyB = append(onlyB, p.Name)
+		case !p.Same():
+			changed = append(changed, p.Name)
+		}
+	}
+	return onlyA, onlyB, changed
+}
+
+// stepsLength adds up the expected length of every step.
+func stepsLength(r *domain.Recipe) time.Duration {
+	var d time.Duration
+	for _, s := range r.Steps {
+		d += s.Length()
+	}
+	return d
+}
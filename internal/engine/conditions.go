@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Step conditions ──────────────────────────────────────────────
+//
+// A step's conditions are how the cook knows it's done: "water is at a
+// rolling boil", "internal temperature reaches 74°C".  Each can be
+// checked off on its own, by number or by saying it back roughly ("the
+// water's boiling"), and what's still open can be asked for before
+// moving on.  Time conditions aren't checked off; the timer covers them.
+
+// CheckCondition marks conditions of the current step met.  ref is a
+// 1-based number ("2", "second"), "all", or words from the condition; a
+// step with one open condition takes any ref, even "".  Returns the
+// conditions checked off and those still open.
+func (e *Engine) CheckCondition(ctx context.Context, sessionID, ref string) (checked, open []domain.StepCondition, err error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading session: %w", err)
+	}
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting recipe: %w", err)
+	}
+	idx := session.CurrentStepIndex
+	state, ok := session.StepStates[idx]
+	if !ok || idx >= len(recipe.Steps) {
+		return nil, nil, domain.ErrNoMoreSteps
+	}
+	conds := recipe.Steps[idx].Conditions
+
+	hits, err := matchConditions(conds, state, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, i := range hits {
+		if !state.IsMet(i) {
+			state.Met = append(state.Met, i)
+		}
+		checked = append(checked, conds[i])
+	}
+	slices.Sort(state.Met)
+	session.UpdatedAt = e.clock.Now()
+	if err := e.save(ctx, session); err != nil {
+		return nil, nil, fmt.Errorf("saving session: %w", err)
+	}
+	e.log.Info("session %s: step %d conditions met %v", sessionID, idx+1, state.Met)
+	return checked, unmet(conds, state), nil
+}
+
+// UnmetConditions returns the current step's conditions that haven't
+// been checked off.
+func (e *Engine) UnmetConditions(ctx context.Context, sessionID string) ([]domain.StepCondition, error) {
+	step, state, err := e.CurrentStep(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return unmet(step.Conditions, state), nil
+}
+
+// checkable reports whether a condition is one the cook checks off.
+func checkable(c domain.StepCondition) bool {
+	return c.Type != domain.ConditionTime
+}
+
+func unmet(conds []domain.StepCondition, state *domain.StepState) []domain.StepCondition {
+	var out []domain.StepCondition
+	for i, c := range conds {
+		if checkable(c) && !state.IsMet(i) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+var (
+	conditionAll      = regexp.MustCompile(`(?i)^(?:all|everything|all of them|both|all done)$`)
+	conditionOrdinals = map[string]int{"first": 1, "one": 1, "second": 2, "two": 2, "third": 3, "three": 3, "fourth": 4, "four": 4, "fifth": 5, "five": 5, "last": -1}
+	conditionFiller   = map[string]bool{"the": true, "and": true, "is": true, "are": true, "it": true, "its": true, "now": true, "has": true, "have": true, "one": true, "that": true, "this": true, "done": true, "ready": true, "yes": true}
+)
+
+// matchConditions finds which of conds ref names, as indexes.
+func matchConditions(conds []domain.StepCondition, state *domain.StepState, ref string) ([]int, error) {
+	var candidates, open []int
+	for i, c := range conds {
+		if checkable(c) {
+			candidates = append(candidates, i)
+			if !state.IsMet(i) {
+				open = append(open, i)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, domain.ErrNoSuchCondition
+	}
+
+	ref = strings.ToLower(strings.Trim(strings.TrimSpace(ref), ".!?"))
+	ref = strings.TrimPrefix(ref, "number ")
+	ref = strings.TrimSuffix(strings.TrimPrefix(ref, "the "), " one")
+	switch {
+	case conditionAll.MatchString(ref):
+		return candidates, nil
+	case ref == "" && len(open) == 1:
+		return open, nil
+	case ref == "":
+		return nil, domain.ErrAmbiguous
+	}
+	n, err := strconv.Atoi(ref)
+	if err != nil {
+		n = conditionOrdinals[ref]
+	}
+	if n == -1 {
+		n = len(candidates)
+	}
+	if n > 0 {
+		if n > len(candidates) {
+			return nil, domain.ErrNoSuchCondition
+		}
+		return []int{candidates[n-1]}, nil
+	}
+
+	// Words: the condition sharing the most of them wins.
+	want := conditionStems(ref)
+	best, bestScore, tie := -1, 0, false
+	for _, i := range candidates {
+		score := 0
+		have := conditionStems(conds[i].Description)
+		for w := range want {
+			if have[w] {
+				score++
+			}
+		}
+		switch {
+		case score > bestScore:
+			best, bestScore, tie = i, score, false
+		case score == bestScore && score > 0:
+			tie = true
+		}
+	}
+	if best < 0 {
+		if len(open) == 1 {
+			return open, nil
+		}
+		return nil, domain.ErrNoSuchCondition
+	}
+	if tie {
+		return nil, domain.ErrAmbiguous
+	}
+	return []int{best}, nil
+}
+
+// conditionStems reduces text to the first four letters of each word
+// that carries meaning, so "boiling" meets "boil" and "water's" meets
+// "water".
+func conditionStems(text string) map[string]bool {
+	out := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '\'')
+	}) {
+		w = strings.TrimSuffix(strings.TrimSuffix(w, "'s"), "'")
+		if len(w) < 3 || conditionFiller[w] {
+			continue
+		}
+		out[w[:min(4, len(w))]] = true
+	}
+	return out
+}
@@ -4,7 +4,7 @@ package engine
 import (
 	"context"
 	"fmt"
-	"time"
+	"sync"
 
 	"github.com/hammamikhairi/ottocook/internal/domain"
 	"github.com/hammamikhairi/ottocook/internal/logger"
@@ -20,13 +20,28 @@ func WithServingsDefault(n int) Option {
 	}
 }
 
+// WithClock sets the clock session and timer timestamps come from.
+func WithClock(c domain.Clock) Option {
+	return func(e *Engine) {
+		e.clock = c
+	}
+}
+
 // Engine manages cooking sessions. It depends only on interfaces and is
 // fully testable with mocks.
 type Engine struct {
 	recipes         domain.RecipeSource
 	store           domain.SessionStore
 	log             *logger.Logger
+	clock           domain.Clock
 	defaultServings int
+
+	mu              sync.Mutex
+	onSessionChange func(*domain.Session)
+
+	// sessions is held from load to save by every method that changes a
+	// session, so two changes can't each overwrite the other.
+	sessions sync.Mutex
 }
 
 // RecipeUpdater is an optional interface that RecipeSource implementations
@@ -35,12 +50,19 @@ type RecipeUpdater interface {
 	Update(ctx context.Context, recipe *domain.Recipe) error
 }
 
+// RecipeAdder is an optional interface that RecipeSource implementations
+// can satisfy to accept new recipes, such as duplicated variants.
+type RecipeAdder interface {
+	Add(ctx context.Context, recipe *domain.Recipe) error
+}
+
 // New creates a cooking engine with the given dependencies and options.
 func New(recipes domain.RecipeSource, store domain.SessionStore, log *logger.Logger, opts ...Option) *Engine {
 	e := &Engine{
 		recipes:         recipes,
 		store:           store,
 		log:             log,
+		clock:           domain.SystemClock{},
 		defaultServings: 2,
 	}
 	for _, opt := range opts {
@@ -49,11 +71,39 @@ func New(recipes domain.RecipeSource, store domain.SessionStore, log *logger.Log
 	return e
 }
 
+// SessionLock is the lock the engine holds while it changes a session.
+// Anything else that loads, changes and saves sessions, like the timer
+// supervisor, takes it too.
+func (e *Engine) SessionLock() sync.Locker {
+	return &e.sessions
+}
+
 // ListRecipes returns all available recipes.
 func (e *Engine) ListRecipes(ctx context.Context) ([]domain.RecipeSummary, error) {
 	return e.recipes.List(ctx)
 }
 
+// SearchRecipes returns recipes matching a free-text query.  Time and
+// difficulty terms ("quick", "easy", "under 20 minutes") filter on the
+// recipe's metadata instead of matching text.
+func (e *Engine) SearchRecipes(ctx context.Context, query string) ([]domain.RecipeSummary, error) {
+	rest, keep := metaFilter(query)
+	if keep == nil {
+		return e.recipes.Search(ctx, query)
+	}
+	var found []domain.RecipeSummary
+	var err error
+	if rest == "" {
+		found, err = e.recipes.List(ctx)
+	} else {
+		found, err = e.recipes.Search(ctx, rest)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return filterSummaries(found, keep), nil
+}
+
 // GetRecipe returns a full recipe by ID.
 func (e *Engine) GetRecipe(ctx context.Context, id string) (*domain.Recipe, error) {
 	return e.recipes.Get(ctx, id)
@@ -71,6 +121,8 @@ func (e *Engine) UpdateRecipe(ctx context.Context, recipe *domain.Recipe) error
 
 // StartSession begins a new cooking session for the given recipe.
 func (e *Engine) StartSession(ctx context.Context, recipeID string, servings int) (*domain.Session, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
 	recipe, err := e.recipes.Get(ctx, recipeID)
 	if err != nil {
 		return nil, fmt.Errorf("getting recipe: %w", err)
@@ -89,8 +141,8 @@ func (e *Engine) StartSession(ctx context.Context, recipeID string, servings int
 		StepStates:       make(map[int]*domain.StepState),
 		TimerStates:      make(map[string]*domain.TimerState),
 		Status:           domain.SessionActive,
-		StartedAt:        time.Now(),
-		UpdatedAt:        time.Now(),
+		StartedAt:        e.clock.Now(),
+		UpdatedAt:        e.clock.Now(),
 	}
 
 	// Initialize step states.
@@ -100,12 +152,12 @@ func (e *Engine) StartSession(ctx context.Context, recipeID string, servings int
 
 	// Mark first step as active.
 	session.StepStates[0].Status = domain.StepActive
-	session.StepStates[0].StartedAt = time.Now()
+	session.StepStates[0].StartedAt = e.clock.Now()
 
 	// Start timer for the first step if configured.
 	e.maybeStartTimer(session, recipe.Steps[0])
 
-	if err := e.store.Save(ctx, session); err != nil {
+	if err := e.save(ctx, session); err != nil {
 		return nil, fmt.Errorf("saving session: %w", err)
 	}
 
@@ -137,6 +189,8 @@ func (e *Engine) CurrentStep(ctx context.Context, sessionID string) (*domain.Ste
 
 // Advance moves the session to the next step.
 func (e *Engine) Advance(ctx context.Context, sessionID string) (*domain.Step, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
 	session, err := e.store.Load(ctx, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("loading session: %w", err)
@@ -152,7 +206,7 @@ func (e *Engine) Advance(ctx context.Context, sessionID string) (*domain.Step, e
 	}
 
 	// Complete current step.
-	now := time.Now()
+	now := e.clock.Now()
 	current := session.StepStates[session.CurrentStepIndex]
 	current.Status = domain.StepDone
 	current.CompletedAt = now
@@ -162,17 +216,17 @@ func (e *Engine) Advance(ctx context.Context, sessionID string) (*domain.Step, e
 	// instead of staying in limbo.
 	for _, ts := range session.TimerStates {
 		if ts.Status == domain.TimerPending {
-			ts.Status = domain.TimerRunning
+			session.SetTimer(ts, domain.TimerRunning, now)
 			e.log.Debug("auto-started timer %s (%s) on advance", ts.ID, ts.Duration)
 		}
 	}
 
-	// Move to next step.
-	nextIdx := session.CurrentStepIndex + 1
+	// Move to next step, passing over any that were declined.
+	nextIdx := nextOpenStep(session, session.CurrentStepIndex+1, len(recipe.Steps))
 	if nextIdx >= len(recipe.Steps) {
 		session.Status = domain.SessionCompleted
 		session.UpdatedAt = now
-		if err := e.store.Save(ctx, session); err != nil {
+		if err := e.save(ctx, session); err != nil {
 			return nil, fmt.Errorf("saving session: %w", err)
 		}
 		e.log.Info("session %s completed", sessionID)
@@ -187,7 +241,7 @@ func (e *Engine) Advance(ctx context.Context, sessionID string) (*domain.Step, e
 	step := &recipe.Steps[nextIdx]
 	e.maybeStartTimer(session, *step)
 
-	if err := e.store.Save(ctx, session); err != nil {
+	if err := e.save(ctx, session); err != nil {
 		return nil, fmt.Errorf("saving session: %w", err)
 	}
 
@@ -197,6 +251,8 @@ func (e *Engine) Advance(ctx context.Context, sessionID string) (*domain.Step, e
 
 // Skip skips the current step and moves to the next one.
 func (e *Engine) Skip(ctx context.Context, sessionID string) (*domain.Step, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
 	session, err := e.store.Load(ctx, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("loading session: %w", err)
@@ -212,23 +268,23 @@ func (e *Engine) Skip(ctx context.Context, sessionID string) (*domain.Step, erro
 	}
 
 	// Mark current as skipped.
-	now := time.Now()
+	now := e.clock.Now()
 	session.StepStates[session.CurrentStepIndex].Status = domain.StepSkipped
 	session.StepStates[session.CurrentStepIndex].CompletedAt = now
 
 	// Auto-start any pending timers from the step we're skipping.
 	for _, ts := range session.TimerStates {
 		if ts.Status == domain.TimerPending {
-			ts.Status = domain.TimerRunning
+			session.SetTimer(ts, domain.TimerRunning, now)
 			e.log.Debug("auto-started timer %s (%s) on skip", ts.ID, ts.Duration)
 		}
 	}
 
-	nextIdx := session.CurrentStepIndex + 1
+	nextIdx := nextOpenStep(session, session.CurrentStepIndex+1, len(recipe.Steps))
 	if nextIdx >= len(recipe.Steps) {
 		session.Status = domain.SessionCompleted
 		session.UpdatedAt = now
-		if err := e.store.Save(ctx, session); err != nil {
+		if err := e.save(ctx, session); err != nil {
 			return nil, fmt.Errorf("saving session: %w", err)
 		}
 		e.log.Info("session %s completed (last step skipped)", sessionID)
@@ -243,7 +299,7 @@ func (e *Engine) Skip(ctx context.Context, sessionID string) (*domain.Step, erro
 	step := &recipe.Steps[nextIdx]
 	e.maybeStartTimer(session, *step)
 
-	if err := e.store.Save(ctx, session); err != nil {
+	if err := e.save(ctx, session); err != nil {
 		return nil, fmt.Errorf("saving session: %w", err)
 	}
 
@@ -263,6 +319,8 @@ func (e *Engine) Repeat(ctx context.Context, sessionID string) (*domain.Step, er
 
 // Pause pauses the session and all running timers.
 func (e *Engine) Pause(ctx context.Context, sessionID string) error {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
 	session, err := e.store.Load(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("loading session: %w", err)
@@ -272,17 +330,14 @@ func (e *Engine) Pause(ctx context.Context, sessionID string) error {
 		return domain.ErrSessionNotActive
 	}
 
+	now := e.clock.Now()
 	session.Status = domain.SessionPaused
-	session.UpdatedAt = time.Now()
+	session.UpdatedAt = now
+	session.Record(now, domain.EventPaused, "", "")
 
-	// Pause all running timers (pending timers stay pending).
-	for _, ts := range session.TimerStates {
-		if ts.Status == domain.TimerRunning {
-			ts.Status = domain.TimerPaused
-		}
-	}
+	session.HoldTimers(now)
 
-	if err := e.store.Save(ctx, session); err != nil {
+	if err := e.save(ctx, session); err != nil {
 		return fmt.Errorf("saving session: %w", err)
 	}
 
@@ -290,8 +345,10 @@ func (e *Engine) Pause(ctx context.Context, sessionID string) error {
 	return nil
 }
 
-// Resume resumes a paused session.
+// Resume resumes a paused session and the timers Pause paused.
 func (e *Engine) Resume(ctx context.Context, sessionID string) (*domain.Session, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
 	session, err := e.store.Load(ctx, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("loading session: %w", err)
@@ -301,17 +358,15 @@ func (e *Engine) Resume(ctx context.Context, sessionID string) (*domain.Session,
 		return nil, domain.ErrSessionPaused
 	}
 
+	now := e.clock.Now()
 	session.Status = domain.SessionActive
-	session.UpdatedAt = time.Now()
+	session.UpdatedAt = now
+	session.Record(now, domain.EventResumed, "", "")
 
-	// Resume paused timers.
-	for _, ts := range session.TimerStates {
-		if ts.Status == domain.TimerPaused {
-			ts.Status = domain.TimerRunning
-		}
-	}
+	// Timers the cook paused on their own stay paused.
+	session.ReleaseTimers(now)
 
-	if err := e.store.Save(ctx, session); err != nil {
+	if err := e.save(ctx, session); err != nil {
 		return nil, fmt.Errorf("saving session: %w", err)
 	}
 
@@ -326,15 +381,17 @@ func (e *Engine) Status(ctx context.Context, sessionID string) (*domain.Session,
 
 // Abandon marks a session as abandoned.
 func (e *Engine) Abandon(ctx context.Context, sessionID string) error {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
 	session, err := e.store.Load(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("loading session: %w", err)
 	}
 
 	session.Status = domain.SessionAbandoned
-	session.UpdatedAt = time.Now()
+	session.UpdatedAt = e.clock.Now()
 
-	if err := e.store.Save(ctx, session); err != nil {
+	if err := e.save(ctx, session); err != nil {
 		return fmt.Errorf("saving session: %w", err)
 	}
 
@@ -362,26 +419,46 @@ func (e *Engine) maybeStartTimer(session *domain.Session, step domain.Step) {
 	e.log.Debug("created pending timer %s (%s) for step %s", timerID, step.TimerConfig.Duration, step.ID)
 }
 
-// StartPendingTimers transitions all pending timers for the current step
-// from TimerPending to TimerRunning. Returns the number of timers started.
+// StartPendingTimers transitions the current step's pending timers from
+// TimerPending to TimerRunning. Timers other steps left pending are not
+// touched; see StartAllPendingTimers. Returns the number of timers started.
 func (e *Engine) StartPendingTimers(ctx context.Context, sessionID string) (int, error) {
+	return e.startPending(ctx, sessionID, false)
+}
+
+// StartAllPendingTimers starts every pending timer in the session,
+// whichever step created it. Returns the number of timers started.
+func (e *Engine) StartAllPendingTimers(ctx context.Context, sessionID string) (int, error) {
+	return e.startPending(ctx, sessionID, true)
+}
+
+func (e *Engine) startPending(ctx context.Context, sessionID string, all bool) (int, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
 	session, err := e.store.Load(ctx, sessionID)
 	if err != nil {
 		return 0, fmt.Errorf("loading session: %w", err)
 	}
+	stepID := ""
+	if !all {
+		if stepID, err = e.currentStepID(ctx, session); err != nil {
+			return 0, err
+		}
+	}
 
+	now := e.clock.Now()
 	started := 0
 	for _, ts := range session.TimerStates {
-		if ts.Status == domain.TimerPending {
-			ts.Status = domain.TimerRunning
+		if ts.Status == domain.TimerPending && (all || ts.StepID == stepID) {
+			session.SetTimer(ts, domain.TimerRunning, now)
 			started++
 			e.log.Debug("started timer %s (%s)", ts.ID, ts.Duration)
 		}
 	}
 
 	if started > 0 {
-		session.UpdatedAt = time.Now()
-		if err := e.store.Save(ctx, session); err != nil {
+		session.UpdatedAt = now
+		if err := e.save(ctx, session); err != nil {
 			return 0, fmt.Errorf("saving session: %w", err)
 		}
 	}
@@ -389,20 +466,38 @@ func (e *Engine) StartPendingTimers(ctx context.Context, sessionID string) (int,
 	return started, nil
 }
 
-// HasPendingTimers returns true if the session has any timers waiting to start.
+// HasPendingTimers returns true if the current step has a timer waiting
+// to start.
 func (e *Engine) HasPendingTimers(ctx context.Context, sessionID string) (bool, error) {
 	session, err := e.store.Load(ctx, sessionID)
 	if err != nil {
 		return false, fmt.Errorf("loading session: %w", err)
 	}
+	stepID, err := e.currentStepID(ctx, session)
+	if err != nil {
+		return false, err
+	}
 	for _, ts := range session.TimerStates {
-		if ts.Status == domain.TimerPending {
+		if ts.Status == domain.TimerPending && ts.StepID == stepID {
 			return true, nil
 		}
 	}
 	return false, nil
 }
 
+// currentStepID returns the ID of the session's current step, or "" once
+// the recipe is finished.
+func (e *Engine) currentStepID(ctx context.Context, session *domain.Session) (string, error) {
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return "", fmt.Errorf("getting recipe: %w", err)
+	}
+	if session.CurrentStepIndex >= len(recipe.Steps) {
+		return "", nil
+	}
+	return recipe.Steps[session.CurrentStepIndex].ID, nil
+}
+
 // dismissStepTimers dismisses all timers associated with a step.
 func (e *Engine) dismissStepTimers(session *domain.Session, stepID string) {
 	for _, ts := range session.TimerStates {
@@ -415,6 +510,8 @@ func (e *Engine) dismissStepTimers(session *domain.Session, stepID string) {
 
 // DismissTimer dismisses a single timer by ID.
 func (e *Engine) DismissTimer(ctx context.Context, sessionID, timerID string) error {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
 	session, err := e.store.Load(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("loading session: %w", err)
@@ -425,14 +522,15 @@ func (e *Engine) DismissTimer(ctx context.Context, sessionID, timerID string) er
 		return fmt.Errorf("timer %q not found", timerID)
 	}
 
-	if ts.Status != domain.TimerRunning && ts.Status != domain.TimerFired {
+	if ts.Status != domain.TimerRunning && ts.Status != domain.TimerPaused && ts.Status != domain.TimerFired {
 		return fmt.Errorf("timer %q is %s, cannot dismiss", timerID, ts.Status)
 	}
 
-	ts.Status = domain.TimerDismissed
-	session.UpdatedAt = time.Now()
+	now := e.clock.Now()
+	session.SetTimer(ts, domain.TimerDismissed, now)
+	session.UpdatedAt = now
 
-	if err := e.store.Save(ctx, session); err != nil {
+	if err := e.save(ctx, session); err != nil {
 		return fmt.Errorf("saving session: %w", err)
 	}
 
@@ -440,23 +538,24 @@ func (e *Engine) DismissTimer(ctx context.Context, sessionID, timerID string) er
 	return nil
 }
 
-// ActiveTimers returns all running or fired timers for a session.
+// ActiveTimers returns all running, paused, or fired timers for a session, in
+// recipe step order.  The order is stable, so a timer's position in the
+// list (1-based) is the index status shows and ResolveTimer accepts.
 func (e *Engine) ActiveTimers(ctx context.Context, sessionID string) ([]*domain.TimerState, error) {
 	session, err := e.store.Load(ctx, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("loading session: %w", err)
 	}
 
-	var active []*domain.TimerState
-	for _, ts := range session.TimerStates {
-		if ts.Status == domain.TimerRunning || ts.Status == domain.TimerFired {
-			active = append(active, ts)
-		}
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
 	}
-	return active, nil
+	return activeTimers(session, recipe), nil
 }
 
-// NextStep returns the step after the current one, or nil if this is the last step.
+// NextStep returns the step after the current one, or nil if this is the
+// last step.  Declined steps are passed over.
 func (e *Engine) NextStep(ctx context.Context, sessionID string) (*domain.Step, error) {
 	session, err := e.store.Load(ctx, sessionID)
 	if err != nil {
@@ -468,7 +567,7 @@ func (e *Engine) NextStep(ctx context.Context, sessionID string) (*domain.Step,
 		return nil, fmt.Errorf("getting recipe: %w", err)
 	}
 
-	nextIdx := session.CurrentStepIndex + 1
+	nextIdx := nextOpenStep(session, session.CurrentStepIndex+1, len(recipe.Steps))
 	if nextIdx >= len(recipe.Steps) {
 		return nil, nil // last step
 	}
@@ -3,12 +3,16 @@ package engine
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/hammamikhairi/ottocook/internal/domain"
 	"github.com/hammamikhairi/ottocook/internal/logger"
 	"github.com/hammamikhairi/ottocook/internal/recipe"
 	"github.com/hammamikhairi/ottocook/internal/storage"
+	"github.com/hammamikhairi/ottocook/internal/testkit"
 )
 
 func setupEngine(t *testing.T) (*Engine, context.Context) {
@@ -20,6 +24,25 @@ func setupEngine(t *testing.T) (*Engine, context.Context) {
 	return eng, context.Background()
 }
 
+// reload reads a session back from the store; the store hands out
+// copies, so a session a test holds doesn't see the engine's changes.
+func reload(t *testing.T, eng *Engine, ctx context.Context, id string) *domain.Session {
+	t.Helper()
+	session, err := eng.store.Load(ctx, id)
+	if err != nil {
+		t.Fatalf("loading session: %v", err)
+	}
+	return session
+}
+
+// save stores a session the test has changed by hand.
+func save(t *testing.T, eng *Engine, ctx context.Context, session *domain.Session) {
+	t.Helper()
+	if err := eng.store.Save(ctx, session); err != nil {
+		t.Fatalf("saving session: %v", err)
+	}
+}
+
 func TestStartSession(t *testing.T) {
 	eng, ctx := setupEngine(t)
 
@@ -280,3 +303,1051 @@ func TestPauseResumesTimers(t *testing.T) {
 		}
 	}
 }
+
+func TestResumeKeepsTimersPausedByHand(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	session, err := eng.StartSession(ctx, "chicken-alfredo", 2)
+	if err != nil {
+		t.Fatalf("starting session: %v", err)
+	}
+	eng.StartPendingTimers(ctx, session.ID)
+	session = reload(t, eng, ctx, session.ID)
+	session.TimerStates["timer-x"] = &domain.TimerState{
+		ID: "timer-x", StepID: "ca-9", Label: "Chicken", Duration: time.Minute, Remaining: time.Minute, Status: domain.TimerRunning,
+	}
+	save(t, eng, ctx, session)
+
+	if err := eng.PauseTimer(ctx, session.ID, "timer-x"); err != nil {
+		t.Fatalf("PauseTimer: %v", err)
+	}
+	if err := eng.Pause(ctx, session.ID); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if _, err := eng.Resume(ctx, session.ID); err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+
+	s := reload(t, eng, ctx, session.ID)
+	if st := s.TimerStates["timer-ca-1"].Status; st != domain.TimerRunning {
+		t.Errorf("timer paused with the session is %s after resume, want running", st)
+	}
+	if st := s.TimerStates["timer-x"].Status; st != domain.TimerPaused {
+		t.Errorf("timer paused by hand is %s after resume, want paused", st)
+	}
+	for _, ts := range s.TimerStates {
+		if ts.Held {
+			t.Errorf("timer %s still held after resume", ts.ID)
+		}
+	}
+}
+
+func TestTagsAndCollections(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	if changed, err := eng.TagRecipe(ctx, "chicken-alfredo", "Weeknight", false); err != nil || !changed {
+		t.Fatalf("tag: changed=%v err=%v", changed, err)
+	}
+	if changed, _ := eng.TagRecipe(ctx, "chicken-alfredo", "weeknight", false); changed {
+		t.Error("re-adding an existing tag should be a no-op")
+	}
+	if _, err := eng.CollectRecipe(ctx, "chicken-alfredo", "Date Night", false); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if _, err := eng.CollectRecipe(ctx, "vegetable-stir-fry", "date night", false); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+
+	names, _ := eng.Collections(ctx)
+	if len(names) != 1 || names[0] != "Date Night" {
+		t.Errorf("expected one collection spelled as first created, got %v", names)
+	}
+	if got, _ := eng.FilterRecipes(ctx, "DATE NIGHT"); len(got) != 2 {
+		t.Errorf("expected 2 recipes in collection, got %d", len(got))
+	}
+	if got, _ := eng.FilterRecipes(ctx, "weeknight"); len(got) != 1 || got[0].ID != "chicken-alfredo" {
+		t.Errorf("expected tag filter to find chicken-alfredo, got %v", got)
+	}
+
+	if changed, _ := eng.TagRecipe(ctx, "chicken-alfredo", "weeknight", true); !changed {
+		t.Error("expected tag removal")
+	}
+	if got, _ := eng.FilterRecipes(ctx, "weeknight"); len(got) != 0 {
+		t.Errorf("expected no recipes after untagging, got %d", len(got))
+	}
+	if _, err := eng.TagRecipe(ctx, "nonexistent", "x", false); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDuplicateRecipe(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	variant, err := eng.DuplicateRecipe(ctx, "chicken-alfredo", "Mom's version")
+	if err != nil {
+		t.Fatalf("duplicate: %v", err)
+	}
+	if variant.Name != "Chicken Alfredo — Mom's version" || variant.ID != "chicken-alfredo-moms-version" {
+		t.Errorf("unexpected variant name/ID: %q / %q", variant.Name, variant.ID)
+	}
+	if variant.ParentID != "chicken-alfredo" {
+		t.Errorf("expected parent link, got %q", variant.ParentID)
+	}
+
+	// Editing the variant must leave the original alone.
+	variant.Steps[0].Instruction = "changed"
+	variant.Ingredients[0].Quantity = 99
+	if err := eng.UpdateRecipe(ctx, variant); err != nil {
+		t.Fatalf("update variant: %v", err)
+	}
+	orig, _ := eng.GetRecipe(ctx, "chicken-alfredo")
+	if orig.Steps[0].Instruction == "changed" || orig.Ingredients[0].Quantity == 99 {
+		t.Error("modifying the variant changed the original")
+	}
+
+	again, err := eng.DuplicateRecipe(ctx, "chicken-alfredo", "mom's version")
+	if err != nil {
+		t.Fatalf("second duplicate: %v", err)
+	}
+	if again.ID != "chicken-alfredo-moms-version-2" {
+		t.Errorf("expected a fresh ID for the second copy, got %q", again.ID)
+	}
+
+	session, _ := eng.StartSession(ctx, "chicken-alfredo", 0)
+	moved, err := eng.MoveSession(ctx, session.ID, variant.ID)
+	if err != nil {
+		t.Fatalf("move session: %v", err)
+	}
+	if moved.RecipeID != variant.ID || moved.RecipeName != variant.Name {
+		t.Errorf("session not moved: %+v", moved)
+	}
+}
+
+func TestStepNotes(t *testing.T) {
+	eng, ctx := setupEngine(t)
+	session, _ := eng.StartSession(ctx, "chicken-alfredo", 0)
+
+	step, err := eng.AddNote(ctx, session.ID, "water took ages to boil", false)
+	if err != nil || step != 1 {
+		t.Fatalf("AddNote: step=%d err=%v", step, err)
+	}
+	eng.Advance(ctx, session.ID)
+	if _, err := eng.AddNote(ctx, session.ID, "needed extra salt", true); err != nil {
+		t.Fatalf("AddNote keep: %v", err)
+	}
+
+	s, _ := eng.Status(ctx, session.ID)
+	if got := s.StepStates[0].Notes; len(got) != 1 || got[0] != "water took ages to boil" {
+		t.Errorf("step 1 session notes = %v", got)
+	}
+	r, _ := eng.GetRecipe(ctx, "chicken-alfredo")
+	if len(r.Steps[0].Notes) != 0 || len(r.Steps[1].Notes) != 1 {
+		t.Fatalf("only the kept note should be on the recipe: %v / %v", r.Steps[0].Notes, r.Steps[1].Notes)
+	}
+
+	added, err := eng.KeepNotes(ctx, session.ID)
+	if err != nil || added != 1 {
+		t.Errorf("KeepNotes: added=%d err=%v, want 1 (the other is already kept)", added, err)
+	}
+	r, _ = eng.GetRecipe(ctx, "chicken-alfredo")
+	if len(r.Steps[0].Notes) != 1 || len(r.Steps[1].Notes) != 1 {
+		t.Errorf("expected one note per step after keeping, got %v / %v", r.Steps[0].Notes, r.Steps[1].Notes)
+	}
+}
+
+func TestSessionReplication(t *testing.T) {
+	eng, ctx := setupEngine(t)
+	var changes []*domain.Session
+	eng.OnSessionChange(func(s *domain.Session) { changes = append(changes, s) })
+
+	session, _ := eng.StartSession(ctx, "chicken-alfredo", 0)
+	eng.Advance(ctx, session.ID)
+	if len(changes) != 2 || changes[1].CurrentStepIndex != 1 {
+		t.Fatalf("expected start and advance to be observed, got %d changes", len(changes))
+	}
+	changes[1].CurrentStepIndex = 99
+	if s, _ := eng.Status(ctx, session.ID); s.CurrentStepIndex != 1 {
+		t.Error("observer copy should not alias the stored session")
+	}
+
+	snap, err := eng.SnapshotSession(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	other, _ := setupEngine(t)
+	imported, err := other.ImportSession(ctx, snap)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if imported.ID == session.ID || imported.CurrentStepIndex != 1 || imported.StepStates[0].Status != domain.StepDone {
+		t.Errorf("unexpected imported session: %+v", imported)
+	}
+	if step, _, err := other.CurrentStep(ctx, imported.ID); err != nil || step.Order != 2 {
+		t.Errorf("imported session should be on step 2, got %v, %v", step, err)
+	}
+}
+
+func TestImportRejectsMalformedSession(t *testing.T) {
+	eng, ctx := setupEngine(t)
+	session, _ := eng.StartSession(ctx, "chicken-alfredo", 0)
+	eng.Advance(ctx, session.ID)
+
+	tests := []struct {
+		name  string
+		spoil func(s *domain.Session)
+	}{
+		{"negative step", func(s *domain.Session) { s.CurrentStepIndex = -1 }},
+		{"step past the end", func(s *domain.Session) { s.CurrentStepIndex = len(s.StepStates) }},
+		{"nil step state", func(s *domain.Session) { s.StepStates[0] = nil }},
+		{"missing step state", func(s *domain.Session) {
+			delete(s.StepStates, 1)
+			s.StepStates[99] = &domain.StepState{}
+		}},
+		{"unknown step status", func(s *domain.Session) { s.StepStates[0].Status = 42 }},
+		{"finished session", func(s *domain.Session) { s.Status = domain.SessionCompleted }},
+		{"nil timer", func(s *domain.Session) { s.TimerStates["timer-x"] = nil }},
+		{"timer under another ID", func(s *domain.Session) { s.TimerStates["timer-x"] = &domain.TimerState{ID: "timer-y"} }},
+		{"unknown timer status", func(s *domain.Session) { s.TimerStates["timer-x"] = &domain.TimerState{ID: "timer-x", Status: 42} }},
+		{"nil task", func(s *domain.Session) { s.Tasks = append(s.Tasks, nil) }},
+	}
+	other, _ := setupEngine(t)
+	for _, tt := range tests {
+		remote, err := eng.SnapshotSession(ctx, session.ID)
+		if err != nil {
+			t.Fatalf("snapshot: %v", err)
+		}
+		if remote.TimerStates == nil {
+			remote.TimerStates = map[string]*domain.TimerState{}
+		}
+		tt.spoil(remote)
+		if imported, err := other.ImportSession(ctx, remote); err == nil {
+			t.Errorf("%s: imported %+v, want an error", tt.name, imported)
+		}
+	}
+}
+
+// addSectionedRecipe adds a recipe whose steps are grouped: Prep (1),
+// Sauce (2-3), Garnish (4-5, optional), Serve (6).
+func addSectionedRecipe(t *testing.T, eng *Engine, ctx context.Context) {
+	t.Helper()
+	r := &domain.Recipe{ID: "sectioned", Name: "Sectioned"}
+	for i, sec := range []string{"Prep", "Sauce", "Sauce", "Garnish", "Garnish", "Serve"} {
+		r.Steps = append(r.Steps, domain.Step{
+			ID: sec + string(rune('1'+i)), Order: i + 1, Instruction: sec,
+			Section: sec, Optional: sec == "Garnish", Duration: time.Minute,
+		})
+	}
+	if err := eng.recipes.(RecipeAdder).Add(ctx, r); err != nil {
+		t.Fatalf("adding recipe: %v", err)
+	}
+}
+
+func TestSections(t *testing.T) {
+	eng, ctx := setupEngine(t)
+	addSectionedRecipe(t, eng, ctx)
+
+	session, err := eng.StartSession(ctx, "sectioned", 2)
+	if err != nil {
+		t.Fatalf("starting session: %v", err)
+	}
+
+	if left, _ := eng.Remaining(ctx, session.ID); left <= 5*time.Minute || left > 6*time.Minute {
+		t.Fatalf("remaining = %s, want about 6m", left)
+	}
+
+	// Declining the garnish up front takes it out of the estimate.
+	if n, err := eng.DeclineSection(ctx, session.ID, "garnish"); err != nil || n != 2 {
+		t.Fatalf("DeclineSection = %d, %v; want 2, nil", n, err)
+	}
+	if left, _ := eng.Remaining(ctx, session.ID); left > 4*time.Minute {
+		t.Fatalf("remaining after declining = %s, want at most 4m", left)
+	}
+	if _, err := eng.DeclineSection(ctx, session.ID, "sauce"); !errors.Is(err, domain.ErrNoSuchSection) {
+		t.Fatalf("declining a required section: got %v, want ErrNoSuchSection", err)
+	}
+
+	// Skipping to the sauce, then the rest of it, lands past the declined garnish.
+	step, err := eng.SkipTo(ctx, session.ID, "sauce")
+	if err != nil || step.Order != 2 {
+		t.Fatalf("SkipTo sauce = %v, %v; want step 2", step, err)
+	}
+	step, err = eng.SkipToStep(ctx, session.ID, 3)
+	if err != nil || step.Order != 3 {
+		t.Fatalf("SkipToStep 3 = %v, %v; want step 3", step, err)
+	}
+	step, err = eng.SkipSection(ctx, session.ID)
+	if err != nil || step.Order != 6 {
+		t.Fatalf("SkipSection = %v, %v; want step 6", step, err)
+	}
+	if _, err := eng.SkipTo(ctx, session.ID, "prep"); !errors.Is(err, domain.ErrNoSuchSection) {
+		t.Fatalf("SkipTo a section behind: got %v, want ErrNoSuchSection", err)
+	}
+	for _, n := range []int{3, 6, 9} {
+		if _, err := eng.SkipToStep(ctx, session.ID, n); !errors.Is(err, domain.ErrNoSuchStep) {
+			t.Fatalf("SkipToStep %d from step 6: got %v, want ErrNoSuchStep", n, err)
+		}
+	}
+	if _, err := eng.Advance(ctx, session.ID); !errors.Is(err, domain.ErrNoMoreSteps) {
+		t.Fatalf("advance past the last step: got %v, want ErrNoMoreSteps", err)
+	}
+}
+
+func TestWaitStep(t *testing.T) {
+	eng, ctx := setupEngine(t)
+	r := &domain.Recipe{ID: "marinated", Name: "Marinated", Steps: []domain.Step{
+		{ID: "m1", Order: 1, Instruction: "Mix the marinade"},
+		{ID: "m2", Order: 2, Instruction: "Marinate", Wait: 2 * time.Hour},
+		{ID: "m3", Order: 3, Instruction: "Grill"},
+	}}
+	if err := eng.recipes.(RecipeAdder).Add(ctx, r); err != nil {
+		t.Fatalf("adding recipe: %v", err)
+	}
+	session, err := eng.StartSession(ctx, "marinated", 2)
+	if err != nil {
+		t.Fatalf("starting session: %v", err)
+	}
+
+	if _, err := eng.StartWait(ctx, session.ID); !errors.Is(err, domain.ErrNoWait) {
+		t.Fatalf("StartWait on a step without a wait: got %v, want ErrNoWait", err)
+	}
+	if _, err := eng.Advance(ctx, session.ID); err != nil {
+		t.Fatalf("advance: %v", err)
+	}
+	until, err := eng.StartWait(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("StartWait: %v", err)
+	}
+	if d := time.Until(until); d < 119*time.Minute || d > 2*time.Hour {
+		t.Fatalf("wakes in %s, want 2h", d)
+	}
+	if _, err := eng.Advance(ctx, session.ID); !errors.Is(err, domain.ErrSessionNotActive) {
+		t.Fatalf("advance while waiting: got %v, want ErrSessionNotActive", err)
+	}
+
+	if err := eng.EndWait(ctx, session.ID); err != nil {
+		t.Fatalf("EndWait: %v", err)
+	}
+	step, err := eng.Advance(ctx, session.ID)
+	if err != nil || step.Order != 3 {
+		t.Fatalf("advance after wait = %v, %v; want step 3", step, err)
+	}
+}
+
+func TestStartPendingTimersScopedToStep(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	session, err := eng.StartSession(ctx, "chicken-alfredo", 2)
+	if err != nil {
+		t.Fatalf("starting session: %v", err)
+	}
+	// A timer some other step left pending.
+	session.TimerStates["timer-other"] = &domain.TimerState{
+		ID: "timer-other", StepID: "other-step", Label: "Other", Duration: time.Minute, Remaining: time.Minute, Status: domain.TimerPending,
+	}
+	save(t, eng, ctx, session)
+
+	n, err := eng.StartPendingTimers(ctx, session.ID)
+	if err != nil || n != 1 {
+		t.Fatalf("StartPendingTimers = %d, %v; want 1 (current step only)", n, err)
+	}
+	if st := reload(t, eng, ctx, session.ID).TimerStates["timer-other"].Status; st != domain.TimerPending {
+		t.Fatalf("other step's timer is %s, want still pending", st)
+	}
+	if pending, _ := eng.HasPendingTimers(ctx, session.ID); pending {
+		t.Fatal("HasPendingTimers true after starting the current step's timer")
+	}
+
+	n, err = eng.StartAllPendingTimers(ctx, session.ID)
+	if err != nil || n != 1 {
+		t.Fatalf("StartAllPendingTimers = %d, %v; want 1", n, err)
+	}
+	if st := reload(t, eng, ctx, session.ID).TimerStates["timer-other"].Status; st != domain.TimerRunning {
+		t.Fatalf("other step's timer is %s, want running", st)
+	}
+}
+
+func TestResolveTimer(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	session, err := eng.StartSession(ctx, "chicken-alfredo", 2)
+	if err != nil {
+		t.Fatalf("starting session: %v", err)
+	}
+	eng.StartPendingTimers(ctx, session.ID)
+	session = reload(t, eng, ctx, session.ID)
+	for _, ts := range []*domain.TimerState{
+		{ID: "timer-x", StepID: "ca-9", Label: "Sauce simmer", Remaining: time.Minute, Status: domain.TimerRunning},
+		{ID: "timer-y", StepID: "ca-9", Label: "Sauce rest", Remaining: time.Minute, Status: domain.TimerFired},
+		{ID: "timer-z", StepID: "ca-9", Label: "Garnish", Remaining: time.Minute, Status: domain.TimerDismissed},
+	} {
+		session.TimerStates[ts.ID] = ts
+	}
+	save(t, eng, ctx, session)
+
+	active, err := eng.ActiveTimers(ctx, session.ID)
+	if err != nil || len(active) != 3 || active[0].Label != "Water boiling" {
+		t.Fatalf("ActiveTimers = %v, %v; want Water boiling first of 3", active, err)
+	}
+
+	for _, tt := range []struct {
+		ref, want string
+		err       error
+	}{
+		{"1", "Water boiling", nil},
+		{"#3", "Sauce simmer", nil},
+		{"the water timer", "Water boiling", nil},
+		{"timer-y", "Sauce rest", nil},
+		{"timer-z", "", domain.ErrNotFound},
+		{"sauce rest", "Sauce rest", nil},
+		{"simmer", "Sauce simmer", nil},
+		{"sauce", "", domain.ErrAmbiguous},
+		{"4", "", domain.ErrNotFound},
+		{"garnish", "", domain.ErrNotFound},
+	} {
+		ts, err := eng.ResolveTimer(ctx, session.ID, tt.ref)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("ResolveTimer(%q) err = %v, want %v", tt.ref, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || ts.Label != tt.want {
+			t.Errorf("ResolveTimer(%q) = %v, %v; want %s", tt.ref, ts, err, tt.want)
+		}
+	}
+}
+
+func TestTimerControl(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	session, err := eng.StartSession(ctx, "chicken-alfredo", 2)
+	if err != nil {
+		t.Fatalf("starting session: %v", err)
+	}
+	eng.StartPendingTimers(ctx, session.ID)
+	session = reload(t, eng, ctx, session.ID)
+	session.TimerStates["timer-x"] = &domain.TimerState{
+		ID: "timer-x", StepID: "ca-9", Label: "Chicken", Duration: time.Minute, Remaining: time.Minute, Status: domain.TimerRunning,
+	}
+	save(t, eng, ctx, session)
+	water := func() *domain.TimerState { return reload(t, eng, ctx, session.ID).TimerStates["timer-ca-1"] }
+
+	if err := eng.PauseTimer(ctx, session.ID, "timer-ca-1"); err != nil || water().Status != domain.TimerPaused {
+		t.Fatalf("PauseTimer = %v, status %s; want paused", err, water().Status)
+	}
+	if err := eng.PauseTimer(ctx, session.ID, "timer-ca-1"); err == nil {
+		t.Fatal("pausing a paused timer should fail")
+	}
+	if s, _ := eng.Status(ctx, session.ID); s.Status != domain.SessionActive {
+		t.Fatalf("session is %s after pausing one timer, want active", s.Status)
+	}
+	if err := eng.ResumeTimer(ctx, session.ID, "timer-ca-1"); err != nil || water().Status != domain.TimerRunning {
+		t.Fatalf("ResumeTimer = %v, status %s; want running", err, water().Status)
+	}
+
+	if n, err := eng.PauseAllTimers(ctx, session.ID); err != nil || n != 2 {
+		t.Fatalf("PauseAllTimers = %d, %v; want 2", n, err)
+	}
+	if n, err := eng.ResumeAllTimers(ctx, session.ID); err != nil || n != 2 {
+		t.Fatalf("ResumeAllTimers = %d, %v; want 2", n, err)
+	}
+
+	if err := eng.CancelTimer(ctx, session.ID, "timer-x"); err != nil {
+		t.Fatalf("CancelTimer: %v", err)
+	}
+	if st := reload(t, eng, ctx, session.ID).TimerStates["timer-x"].Status; st != domain.TimerCancelled {
+		t.Fatalf("cancelled timer is %s", st)
+	}
+
+	session = reload(t, eng, ctx, session.ID)
+	fired := session.TimerStates["timer-ca-1"]
+	fired.Remaining = 10 * time.Second
+	fired.Status = domain.TimerFired
+	fired.EscalationLevel = 2
+	save(t, eng, ctx, session)
+	if err := eng.RestartTimer(ctx, session.ID, "timer-ca-1"); err != nil {
+		t.Fatalf("RestartTimer: %v", err)
+	}
+	if w := water(); w.Status != domain.TimerRunning || w.Remaining != w.Duration || w.EscalationLevel != 0 {
+		t.Fatalf("restarted timer = %s, %s left, level %d; want running from full", w.Status, w.Remaining, w.EscalationLevel)
+	}
+	if err := eng.CancelTimer(ctx, session.ID, "nope"); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("CancelTimer(unknown) = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	session, err := eng.StartSession(ctx, "chicken-alfredo", 2)
+	if err != nil {
+		t.Fatalf("starting session: %v", err)
+	}
+	if plan, err := eng.Schedule(ctx, session.ID); err != nil || plan != nil {
+		t.Fatalf("Schedule without serve time = %v, %v; want nil", plan, err)
+	}
+
+	serveAt := time.Now().Add(3 * time.Hour).Truncate(time.Minute)
+	if err := eng.SetServeTime(ctx, session.ID, serveAt); err != nil {
+		t.Fatalf("SetServeTime: %v", err)
+	}
+	plan, err := eng.Schedule(ctx, session.ID)
+	if err != nil || len(plan) != len(session.StepStates) {
+		t.Fatalf("Schedule = %d steps, %v; want %d", len(plan), err, len(session.StepStates))
+	}
+
+	// The last step has to start its own length before serving, and
+	// every step starts one length before the next.
+	last := plan[len(plan)-1]
+	if want := serveAt.Add(-last.Step.Length()); !last.StartBy.Equal(want) {
+		t.Fatalf("last step start by %s, want %s", last.StartBy, want)
+	}
+	for i := 0; i+1 < len(plan); i++ {
+		if got := plan[i+1].StartBy.Sub(plan[i].StartBy); got != plan[i].Step.Length() {
+			t.Fatalf("step %d to %d: %s apart, want %s", plan[i].Step.Order, plan[i+1].Step.Order, got, plan[i].Step.Length())
+		}
+	}
+
+	startBy, err := eng.StartBy(ctx, "chicken-alfredo", serveAt)
+	if err != nil || !startBy.Equal(plan[0].StartBy) {
+		t.Fatalf("StartBy = %s, %v; want %s", startBy, err, plan[0].StartBy)
+	}
+}
+
+func TestFilterByTimeAndDifficulty(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	names := func(list []domain.RecipeSummary) []string {
+		var out []string
+		for _, r := range list {
+			out = append(out, r.Name)
+		}
+		return out
+	}
+
+	for _, tt := range []struct {
+		query string
+		want  []string
+	}{
+		{"quick", []string{"Vegetable Stir Fry"}},
+		{"medium", []string{"Chicken Alfredo"}},
+		{"under 1 hour", []string{"Chicken Alfredo", "Vegetable Stir Fry"}},
+		{"an easy chicken", nil},
+		{"pasta under 50 minutes", []string{"Chicken Alfredo"}},
+	} {
+		got, err := eng.SearchRecipes(ctx, tt.query)
+		if err != nil {
+			t.Fatalf("SearchRecipes(%q): %v", tt.query, err)
+		}
+		if g := names(got); !slices.Equal(g, tt.want) {
+			t.Errorf("SearchRecipes(%q) = %v, want %v", tt.query, g, tt.want)
+		}
+	}
+
+	got, err := eng.FilterRecipes(ctx, "easy")
+	if err != nil || !slices.Equal(names(got), []string{"Vegetable Stir Fry"}) {
+		t.Fatalf("FilterRecipes(easy) = %v, %v", names(got), err)
+	}
+}
+
+func TestPrepList(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	tasks, err := eng.PrepList(ctx, "vegetable-stir-fry")
+	if err != nil {
+		t.Fatalf("PrepList: %v", err)
+	}
+	var got []string
+	for _, task := range tasks {
+		got = append(got, fmt.Sprintf("%s: %s (%d)", task.Ingredient, task.Prep, task.Step))
+	}
+	want := []string{
+		"bell pepper: sliced into strips (2)", "broccoli florets: cut into small florets (2)",
+		"carrot: julienned (2)", "snap peas: trimmed (2)", "garlic: minced (2)", "fresh ginger: grated (2)",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("PrepList = %v, want %v", got, want)
+	}
+
+	// Without Prep fields the first step using each ingredient is read;
+	// slicing the cooked chicken at the end isn't prep.
+	r := &domain.Recipe{
+		Ingredients: []domain.Ingredient{{Name: "chicken breast"}, {Name: "onion"}, {Name: "garlic"}, {Name: "parsley"}},
+		Steps: []domain.Step{
+			{Instruction: "Season the chicken."},
+			{Instruction: "Dice the onion and add minced garlic to the pan."},
+			{Instruction: "Slice the chicken and scatter chopped parsley over it."},
+		},
+	}
+	got = nil
+	for _, task := range prepTasks(r) {
+		got = append(got, fmt.Sprintf("%s: %s (%d)", task.Ingredient, task.Prep, task.Step))
+	}
+	want = []string{"onion: diced (2)", "garlic: minced (2)", "parsley: chopped (3)"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("prepTasks = %v, want %v", got, want)
+	}
+}
+
+func TestLookupIngredient(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	for _, tt := range []struct {
+		recipe, name string
+		want         []string
+	}{
+		{"chicken-alfredo", "garlic", []string{"4 medium cloves of garlic"}},
+		{"chicken-alfredo", "chicken", []string{"2 medium chicken breast"}},
+		{"chicken-alfredo", "gruyere", []string{"1 cup of gruyere cheese, grated"}},
+		{"chicken-alfredo", "salt", []string{"salt, to taste"}},
+		{"vegetable-stir-fry", "oil", []string{"2 tablespoons of vegetable oil", "1 tablespoon of sesame oil"}},
+		{"vegetable-stir-fry", "carrots", []string{"1 medium carrot"}},
+	} {
+		ings, err := eng.LookupIngredient(ctx, tt.recipe, tt.name)
+		if err != nil {
+			t.Fatalf("LookupIngredient(%s, %q): %v", tt.recipe, tt.name, err)
+		}
+		var got []string
+		for _, ing := range ings {
+			got = append(got, ing.Phrase())
+		}
+		slices.Sort(got)
+		want := slices.Sorted(slices.Values(tt.want))
+		if !slices.Equal(got, want) {
+			t.Errorf("LookupIngredient(%s, %q) = %v, want %v", tt.recipe, tt.name, got, want)
+		}
+	}
+
+	if _, err := eng.LookupIngredient(ctx, "chicken-alfredo", "longer"); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("LookupIngredient(longer) err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRecipeVersions(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	r, _ := eng.GetRecipe(ctx, "chicken-alfredo")
+	err := eng.ReviseRecipe(ctx, r, "servings to 4", func(r *domain.Recipe) error {
+		r.Servings = 4
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReviseRecipe: %v", err)
+	}
+	err = eng.ReviseRecipe(ctx, r, "remove garlic", func(r *domain.Recipe) error {
+		r.Ingredients = slices.DeleteFunc(r.Ingredients, func(i domain.Ingredient) bool { return i.Name == "garlic" })
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReviseRecipe: %v", err)
+	}
+
+	history, err := eng.RecipeVersions(ctx, "chicken-alfredo")
+	if err != nil {
+		t.Fatalf("RecipeVersions: %v", err)
+	}
+	var got []string
+	for _, v := range history {
+		got = append(got, fmt.Sprintf("v%d %s", v.Version, v.Change))
+	}
+	want := []string{"v1 original", "v2 servings to 4", "v3 remove garlic"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("versions = %v, want %v", got, want)
+	}
+	if history[0].Recipe.Servings != 2 || history[1].Recipe.Servings != 4 {
+		t.Fatal("snapshots should keep the recipe as it was at each version")
+	}
+
+	restored, err := eng.RestoreVersion(ctx, "chicken-alfredo", 1)
+	if err != nil {
+		t.Fatalf("RestoreVersion: %v", err)
+	}
+	if restored.Version != 4 || restored.Servings != 2 || len(restored.Ingredients) != len(history[0].Recipe.Ingredients) {
+		t.Fatalf("restored = v%d, %d servings, %d ingredients", restored.Version, restored.Servings, len(restored.Ingredients))
+	}
+	if _, err := eng.RestoreVersion(ctx, "chicken-alfredo", 9); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("RestoreVersion(9) err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestEngineUsesClock(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	clock := testkit.NewFakeClock(time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC))
+	eng := New(recipe.NewMemorySource(log), storage.NewMemoryStore(log), log, WithClock(clock))
+	ctx := context.Background()
+
+	session, err := eng.StartSession(ctx, "chicken-alfredo", 2)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if !session.StartedAt.Equal(clock.Now()) {
+		t.Fatalf("StartedAt = %s, want the clock's %s", session.StartedAt, clock.Now())
+	}
+	before, err := eng.Remaining(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("remaining: %v", err)
+	}
+
+	// Five minutes into the 8-minute first step.
+	clock.Advance(5 * time.Minute)
+	after, err := eng.Remaining(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("remaining: %v", err)
+	}
+	if before-after != 5*time.Minute {
+		t.Fatalf("remaining went %s -> %s; want 5m less", before, after)
+	}
+}
+
+func TestEnginePace(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	clock := testkit.NewFakeClock(time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC))
+	eng := New(recipe.NewMemorySource(log), storage.NewMemoryStore(log), log, WithClock(clock))
+	ctx := context.Background()
+
+	session, err := eng.StartSession(ctx, "chicken-alfredo", 2)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	pace := func() time.Duration {
+		t.Helper()
+		p, err := eng.Pace(ctx, session.ID)
+		if err != nil {
+			t.Fatalf("pace: %v", err)
+		}
+		return p
+	}
+
+	// The 8-minute boil takes 11.
+	clock.Advance(11 * time.Minute)
+	if _, err := eng.Advance(ctx, session.ID); err != nil {
+		t.Fatalf("advance: %v", err)
+	}
+	if got := pace(); got != 3*time.Minute {
+		t.Fatalf("pace after step 1 = %s, want 3m behind", got)
+	}
+
+	// Seasoning the chicken has no estimate, so however long it takes
+	// doesn't count.
+	clock.Advance(20 * time.Minute)
+	if _, err := eng.Advance(ctx, session.ID); err != nil {
+		t.Fatalf("advance: %v", err)
+	}
+	if got := pace(); got != 3*time.Minute {
+		t.Fatalf("pace after step 2 = %s, want still 3m", got)
+	}
+
+	// Part way through the 12-minute sear isn't ahead...
+	clock.Advance(5 * time.Minute)
+	if got := pace(); got != 3*time.Minute {
+		t.Fatalf("pace mid step 3 = %s, want still 3m", got)
+	}
+	// ...but running over it is behind.
+	clock.Advance(11 * time.Minute)
+	if got := pace(); got != 7*time.Minute {
+		t.Fatalf("pace 4m over step 3 = %s, want 7m", got)
+	}
+}
+
+func TestCheckCondition(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	session, err := eng.StartSession(ctx, "chicken-alfredo", 2)
+	if err != nil {
+		t.Fatalf("starting session: %v", err)
+	}
+	checked, open, err := eng.CheckCondition(ctx, session.ID, "the water's boiling")
+	if err != nil || len(checked) != 1 || len(open) != 0 {
+		t.Fatalf("step 1 check = %v, %v, %v; want the boil checked, none open", checked, open, err)
+	}
+	if !reload(t, eng, ctx, session.ID).ChecksConditions() {
+		t.Error("ChecksConditions = false after a check-off")
+	}
+
+	for range 2 {
+		if _, err := eng.Advance(ctx, session.ID); err != nil {
+			t.Fatalf("advancing: %v", err)
+		}
+	}
+	unmet, err := eng.UnmetConditions(ctx, session.ID)
+	if err != nil || len(unmet) != 2 {
+		t.Fatalf("UnmetConditions on step 3 = %v, %v; want 2", unmet, err)
+	}
+
+	for _, tt := range []struct {
+		ref      string
+		want     string
+		wantOpen int
+		err      error
+	}{
+		{"", "", 0, domain.ErrAmbiguous},
+		{"banana", "", 0, domain.ErrNoSuchCondition},
+		{"3", "", 0, domain.ErrNoSuchCondition},
+		{"it's at temperature", "Internal temperature reaches 165°F / 74°C", 1, nil},
+		{"first", "Chicken is golden brown on both sides, juices run clear", 0, nil},
+	} {
+		checked, open, err := eng.CheckCondition(ctx, session.ID, tt.ref)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("CheckCondition(%q) err = %v, want %v", tt.ref, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(checked) != 1 || checked[0].Description != tt.want || len(open) != tt.wantOpen {
+			t.Errorf("CheckCondition(%q) = %v, %d open; want %q, %d open", tt.ref, checked, len(open), tt.want, tt.wantOpen)
+		}
+	}
+}
+
+func TestDelegate(t *testing.T) {
+	eng, ctx := setupEngine(t)
+
+	session, err := eng.StartSession(ctx, "chicken-alfredo", 2)
+	if err != nil {
+		t.Fatalf("starting session: %v", err)
+	}
+	if _, err := eng.Delegate(ctx, session.ID, "sam", ""); !errors.Is(err, domain.ErrNothingToHandOff) {
+		t.Fatalf("Delegate on a step without hints: err = %v, want ErrNothingToHandOff", err)
+	}
+	eng.Advance(ctx, session.ID)
+
+	given, err := eng.Delegate(ctx, session.ID, "sam", "")
+	if err != nil || len(given) != 1 || given[0].Helper != "Sam" || given[0].Text != "Do this while waiting for water to boil" {
+		t.Fatalf("Delegate hints = %+v, %v; want the step's hint for Sam", given, err)
+	}
+	if _, err := eng.Delegate(ctx, session.ID, "Sam", ""); !errors.Is(err, domain.ErrNothingToHandOff) {
+		t.Errorf("second Delegate of the same hints: err = %v, want ErrNothingToHandOff", err)
+	}
+	if _, err := eng.Delegate(ctx, session.ID, "Alex", "grate the parmesan"); err != nil {
+		t.Fatalf("Delegate text: %v", err)
+	}
+	if _, err := eng.Delegate(ctx, session.ID, "Sam", "set the table"); err != nil {
+		t.Fatalf("Delegate text: %v", err)
+	}
+
+	if _, err := eng.FinishTasks(ctx, session.ID, "", "", true); !errors.Is(err, domain.ErrAmbiguous) {
+		t.Errorf("FinishTasks with three open: err = %v, want ErrAmbiguous", err)
+	}
+	if _, err := eng.FinishTasks(ctx, session.ID, "Alex", "the table", true); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("FinishTasks for a job Alex doesn't have: err = %v, want ErrNotFound", err)
+	}
+	done, err := eng.FinishTasks(ctx, session.ID, "", "cheese parmesan", true)
+	if err != nil || len(done) != 1 || done[0].Helper != "Alex" {
+		t.Fatalf("FinishTasks by words = %+v, %v; want Alex's parmesan", done, err)
+	}
+	done, err = eng.FinishTasks(ctx, session.ID, "sam", "", true)
+	if err != nil || len(done) != 2 {
+		t.Fatalf("FinishTasks for Sam = %+v, %v; want both of Sam's", done, err)
+	}
+	if open := session.OpenTasks(""); len(open) != 0 {
+		t.Errorf("OpenTasks = %+v after all done", open)
+	}
+
+	reopened, err := eng.FinishTasks(ctx, session.ID, "Sam", "table", false)
+	if err != nil || len(reopened) != 1 || reopened[0].Done || !reopened[0].DoneAt.IsZero() {
+		t.Fatalf("reopening = %+v, %v", reopened, err)
+	}
+}
+
+func TestMilestones(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	clock := testkit.NewFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	eng := New(recipe.NewMemorySource(log), storage.NewMemoryStore(log), log, WithClock(clock))
+	ctx := context.Background()
+	r := &domain.Recipe{ID: "marinated", Name: "Marinated", Steps: []domain.Step{
+		{ID: "m1", Order: 1, Instruction: "Mix the marinade", Duration: 10 * time.Minute},
+		{ID: "m2", Order: 2, Instruction: "Marinate", Wait: 2 * time.Hour},
+		{ID: "m3", Order: 3, Instruction: "Grill", Duration: 15 * time.Minute},
+	}}
+	if err := eng.recipes.(RecipeAdder).Add(ctx, r); err != nil {
+		t.Fatalf("adding recipe: %v", err)
+	}
+	session, err := eng.StartSession(ctx, "marinated", 2)
+	if err != nil {
+		t.Fatalf("starting session: %v", err)
+	}
+	if ms, err := eng.Milestones(ctx, session.ID); err != nil || len(ms) != 0 {
+		t.Fatalf("Milestones with no serve time = %v, %v; want none", ms, err)
+	}
+
+	serve := clock.Now().Add(4 * time.Hour)
+	eng.SetServeTime(ctx, session.ID, serve)
+	ms, err := eng.Milestones(ctx, session.ID)
+	if err != nil || len(ms) != 2 {
+		t.Fatalf("Milestones = %v, %v; want the marinade start and serve", ms, err)
+	}
+	if ms[0].Key != "start-1" || !ms[0].At.Equal(serve.Add(-135*time.Minute)) || ms[1].Key != "serve" {
+		t.Errorf("Milestones = %+v; want start-1 at 13:45, then serve", ms)
+	}
+
+	eng.Advance(ctx, session.ID)
+	if _, err := eng.StartWait(ctx, session.ID); err != nil {
+		t.Fatalf("StartWait: %v", err)
+	}
+	ms, _ = eng.Milestones(ctx, session.ID)
+	if len(ms) != 2 || ms[0].Key != "wait" || !ms[0].At.Equal(clock.Now().Add(2*time.Hour)) || ms[0].Detail != "Grill" {
+		t.Errorf("Milestones while waiting = %+v; want the wait's end, then serve", ms)
+	}
+
+	clock.Advance(5 * time.Hour)
+	if ms, _ := eng.Milestones(ctx, session.ID); len(ms) != 0 {
+		t.Errorf("Milestones once they've all passed = %+v", ms)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	start := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	clock := testkit.NewFakeClock(start)
+	eng := New(recipe.NewMemorySource(log), storage.NewMemoryStore(log), log, WithClock(clock))
+	ctx := context.Background()
+	r := &domain.Recipe{ID: "rice", Name: "Rice", Steps: []domain.Step{
+		{ID: "r1", Order: 1, Instruction: "Simmer the rice", TimerConfig: &domain.TimerConfig{Duration: 20 * time.Minute, Label: "Rice"}},
+		{ID: "r2", Order: 2, Instruction: "Fry the onions"},
+	}}
+	if err := eng.recipes.(RecipeAdder).Add(ctx, r); err != nil {
+		t.Fatalf("adding recipe: %v", err)
+	}
+	session, err := eng.StartSession(ctx, "rice", 2)
+	if err != nil {
+		t.Fatalf("starting session: %v", err)
+	}
+
+	clock.Advance(time.Minute)
+	eng.StartPendingTimers(ctx, session.ID) // 18:01
+	clock.Advance(5 * time.Minute)
+	eng.Pause(ctx, session.ID) // 18:06
+	clock.Advance(2 * time.Minute)
+	eng.Resume(ctx, session.ID) // 18:08
+	clock.Advance(4 * time.Minute)
+	eng.Advance(ctx, session.ID) // 18:12
+	clock.Advance(10 * time.Minute)
+	eng.CancelTimer(ctx, session.ID, "timer-r1") // 18:22
+	clock.Advance(3 * time.Minute)
+	eng.Advance(ctx, session.ID) // 18:25, done
+	clock.Advance(time.Hour)
+
+	tl, err := eng.Timeline(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Timeline: %v", err)
+	}
+	at := func(m int) time.Time { return start.Add(time.Duration(m) * time.Minute) }
+	if !tl.Start.Equal(start) || !tl.End.Equal(at(25)) {
+		t.Errorf("Timeline runs %s to %s; want 18:00 to 18:25, when it finished", tl.Start, tl.End)
+	}
+	want := []Stretch{
+		{Lane: "Steps", Kind: StretchStep, Label: "Step 1", Detail: "Simmer the rice", From: at(0), To: at(12)},
+		{Lane: "Steps", Kind: StretchStep, Label: "Step 2", Detail: "Fry the onions", From: at(12), To: at(25)},
+		{Lane: "Rice timer", Kind: StretchTimer, Label: "running", From: at(1), To: at(6)},
+		{Lane: "Rice timer", Kind: StretchTimer, Label: "running", From: at(8), To: at(22)},
+		{Lane: "Paused", Kind: StretchPaused, Label: "paused", From: at(6), To: at(8)},
+	}
+	if !slices.EqualFunc(tl.Stretches, want, func(a, b Stretch) bool {
+		return a.Lane == b.Lane && a.Kind == b.Kind && a.Label == b.Label && a.Detail == b.Detail && a.From.Equal(b.From) && a.To.Equal(b.To)
+	}) {
+		t.Errorf("Stretches = %+v\nwant %+v", tl.Stretches, want)
+	}
+}
+
+func TestCompareRecipes(t *testing.T) {
+	a := &domain.Recipe{Name: "Pasta", Ingredients: []domain.Ingredient{
+		{Name: "Spaghetti", Quantity: 200, Unit: "grams"},
+		{Name: "Garlic", Quantity: 2, Unit: "cloves"},
+		{Name: "Butter", Quantity: 1, Unit: "tablespoons"},
+	}, Steps: []domain.Step{{Duration: 10 * time.Minute}, {Duration: 5 * time.Minute}}}
+	b := &domain.Recipe{Name: "Pasta v2", Ingredients: []domain.Ingredient{
+		{Name: "spaghetti", Quantity: 200, Unit: "grams"},
+		{Name: "Garlic", Quantity: 4, Unit: "cloves"},
+		{Name: "Cream", Quantity: 100, Unit: "ml"},
+	}, Steps: []domain.Step{{Duration: 10 * time.Minute}, {Wait: 20 * time.Minute}, {Duration: 5 * time.Minute}}}
+
+	c := CompareRecipes(a, b)
+	if len(c.Ingredients) != 4 || !c.Ingredients[0].Same() || c.Ingredients[3].Name != "Cream" {
+		t.Errorf("Ingredients = %+v; want spaghetti matched across case, cream last", c.Ingredients)
+	}
+	onlyA, onlyB, changed := c.Differences()
+	if !slices.Equal(onlyA, []string{"Butter"}) || !slices.Equal(onlyB, []string{"Cream"}) || !slices.Equal(changed, []string{"Garlic"}) {
+		t.Errorf("Differences = %v, %v, %v; want butter dropped, cream added, garlic changed", onlyA, onlyB, changed)
+	}
+	if c.TimeA != 15*time.Minute || c.TimeB != 35*time.Minute {
+		t.Errorf("times = %s, %s; want 15m and 35m, the wait included", c.TimeA, c.TimeB)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	clock := testkit.NewFakeClock(time.Date(2026, 4, 10, 18, 0, 0, 0, time.UTC))
+	recipes := recipe.NewMemorySource(log)
+	eng := New(recipes, storage.NewMemoryStore(log), log, WithClock(clock))
+	ctx := context.Background()
+
+	names := func(list []Suggestion) []string {
+		var out []string
+		for _, s := range list {
+			out = append(out, s.Recipe.Name)
+		}
+		return out
+	}
+	suggest := func(query string, diet, recent []string) []Suggestion {
+		t.Helper()
+		got, err := eng.Suggest(ctx, query, diet, recent, 3)
+		if err != nil {
+			t.Fatalf("Suggest(%q): %v", query, err)
+		}
+		return got
+	}
+
+	if got := suggest("", []string{"vegetarian"}, nil); !slices.Equal(names(got), []string{"Vegetable Stir Fry"}) || !got[0].Diet {
+		t.Errorf("vegetarian = %v; want only the stir fry, marked as fitting", names(got))
+	}
+	if got := suggest("with some chicken", nil, nil); !slices.Equal(names(got), []string{"Chicken Alfredo"}) || !slices.Equal(got[0].Uses, []string{"chicken breast"}) {
+		t.Errorf("with chicken = %v; want the alfredo, using the chicken breast", got)
+	}
+	if got := suggest("in under 30 minutes", nil, nil); !slices.Equal(names(got), []string{"Vegetable Stir Fry"}) || got[0].Time != 25*time.Minute {
+		t.Errorf("under 30 minutes = %v; want the 25 minute stir fry", got)
+	}
+	for range 5 {
+		if got := suggest("", nil, []string{"vegetable-stir-fry"}); !slices.Equal(names(got), []string{"Chicken Alfredo", "Vegetable Stir Fry"}) || !got[0].Fresh {
+			t.Fatalf("after the stir fry = %v; want the alfredo first", names(got))
+		}
+	}
+
+	if err := recipes.Add(ctx, &domain.Recipe{ID: "pea-soup", Name: "Pea Soup", Tags: []string{"Spring"},
+		Ingredients: []domain.Ingredient{{Name: "peas"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := suggest("", nil, nil); len(got) != 3 || got[0].Recipe.Name != "Pea Soup" || got[0].Season != "spring" {
+		t.Errorf("in April = %v; want the spring soup first", got)
+	}
+
+	// Needs it can only read word for word still leave out what they
+	// name, but never count as a fit.
+	if err := recipes.Add(ctx, &domain.Recipe{ID: "praline-tart", Name: "Praline Tart",
+		Ingredients: []domain.Ingredient{{Name: "cashews"}, {Name: "ground almonds"}, {Name: "sugar"}}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		diet []string
+		want []string
+	}{
+		{[]string{"gluten-free"}, []string{"Pea Soup", "Praline Tart", "Vegetable Stir Fry"}},
+		{[]string{"no dairy"}, []string{"Pea Soup", "Praline Tart", "Vegetable Stir Fry"}},
+		{[]string{"allergic to nuts"}, []string{"Chicken Alfredo", "Pea Soup", "Vegetable Stir Fry"}},
+		{[]string{"tree nut allergy"}, []string{"Chicken Alfredo", "Pea Soup", "Vegetable Stir Fry"}},
+		{[]string{"vegetarian", "no pork"}, []string{"Pea Soup", "Praline Tart", "Vegetable Stir Fry"}},
+		{[]string{"vegan, allergic to eggs"}, []string{"Pea Soup", "Praline Tart", "Vegetable Stir Fry"}},
+	} {
+		got := suggest("", tt.diet, nil)
+		gotNames := names(got)
+		slices.Sort(gotNames)
+		if !slices.Equal(gotNames, tt.want) {
+			t.Errorf("%q = %v; want %v", tt.diet, gotNames, tt.want)
+		}
+		for _, s := range got {
+			if s.Diet {
+				t.Errorf("%q: %s said to fit the diet", tt.diet, s.Recipe.Name)
+			}
+		}
+	}
+}
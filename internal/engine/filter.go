@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Time and difficulty filters ──────────────────────────────────
+//
+// "Quick", "easy", and "under 20 minutes" are answered from the recipe's
+// prep/cook time and difficulty rather than by text matching, so "show
+// me quick recipes" finds every recipe that is quick, not just the ones
+// someone tagged.
+
+// quickTime is the longest total time "quick" allows.
+const quickTime = 30 * time.Minute
+
+var underTime = regexp.MustCompile(`(?i)\b(?:in |(?:in )?(?:under|less than|within|at most) )(\d+) ?(?:m|mins?|minutes?|(h|hrs?|hours?))\b`)
+
+// metaWords are left over from "find me an easy pasta" once the filter
+// terms are taken out, and don't belong in the text query.
+var metaWords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "with": true, "that": true,
+	"recipe": true, "recipes": true, "something": true, "anything": true,
+	"dish": true, "dishes": true, "meal": true, "meals": true, "one": true,
+}
+
+// metaFilter takes the time and difficulty terms out of query.  rest is
+// what's left to match as text; keep is nil when there were no such
+// terms.
+func metaFilter(query string) (rest string, keep func(domain.RecipeSummary) bool) {
+	var limit time.Duration
+	diff := domain.DifficultyUnrated
+	quick := false
+
+	if m := underTime.FindStringSubmatch(query); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		limit = time.Duration(n) * time.Minute
+		if m[2] != "" {
+			limit = time.Duration(n) * time.Hour
+		}
+		query = strings.Replace(query, m[0], " ", 1)
+	}
+
+	var words []string
+	for _, w := range strings.Fields(strings.ToLower(query)) {
+		w = strings.Trim(w, ".,!?")
+		if w == "quick" || w == "fast" || w == "speedy" {
+			quick = true
+			continue
+		}
+		if d, ok := domain.ParseDifficulty(w); ok {
+			diff = d
+			continue
+		}
+		if !metaWords[w] {
+			words = append(words, w)
+		}
+	}
+	if limit == 0 && !quick && diff == domain.DifficultyUnrated {
+		return strings.Join(words, " "), nil
+	}
+
+	keep = func(r domain.RecipeSummary) bool {
+		total := r.TotalTime()
+		if limit > 0 && (total == 0 || total > limit) {
+			return false
+		}
+		if quick && !(total > 0 && total <= quickTime) && indexFold(r.Tags, "quick") < 0 {
+			return false
+		}
+		return diff == domain.DifficultyUnrated || r.Difficulty == diff
+	}
+	return strings.Join(words, " "), keep
+}
+
+func filterSummaries(list []domain.RecipeSummary, keep func(domain.RecipeSummary) bool) []domain.RecipeSummary {
+	var out []domain.RecipeSummary
+	for _, r := range list {
+		if keep(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Ingredient lookup ────────────────────────────────────────────
+//
+// "How much garlic?" is answered straight from the recipe — the amounts
+// are right there, already scaled to the servings, and a round trip to
+// the agent for them is slow and can get them wrong.
+
+// LookupIngredient finds the recipe's ingredients matching name: the one
+// named exactly, else any the name picks out ("garlic cloves" finds
+// garlic), else every one with name in it ("oil" finds olive and sesame
+// oil).  Returns domain.ErrNotFound when nothing matches.
+func (e *Engine) LookupIngredient(ctx context.Context, recipeID, name string) ([]domain.Ingredient, error) {
+	recipe, err := e.recipes.Get(ctx, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+
+	q := strings.ToLower(strings.TrimSpace(name))
+	q = strings.TrimPrefix(q, "the ")
+	if q == "" {
+		return nil, domain.ErrNotFound
+	}
+	singular := strings.TrimSuffix(strings.TrimSuffix(q, "es"), "s")
+
+	tiers := []func(domain.Ingredient) bool{
+		func(ing domain.Ingredient) bool {
+			n := strings.ToLower(ing.Name)
+			return n == q || n+"s" == q || n+"es" == q
+		},
+		func(ing domain.Ingredient) bool { return ing.MentionedIn(q) },
+		func(ing domain.Ingredient) bool {
+			words := strings.Fields(strings.ToLower(ing.Name))
+			for _, w := range words {
+				if w == q || w == singular {
+					return true
+				}
+			}
+			return false
+		},
+	}
+	for _, match := range tiers {
+		var found []domain.Ingredient
+		for _, ing := range recipe.Ingredients {
+			if match(ing) {
+				found = append(found, ing)
+			}
+		}
+		if len(found) > 0 {
+			return found, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
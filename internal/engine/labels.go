@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Tags and collections ─────────────────────────────────────────
+//
+// Tags are short lowercase labels ("quick", "vegan"); collections are
+// user-named groups ("Weeknight favorites").  Both live on the recipe
+// and are saved through UpdateRecipe, so they persist with it.
+
+// TagRecipe adds tag to a recipe, or removes it when remove is set.
+// Returns false when the recipe already was in that state.
+func (e *Engine) TagRecipe(ctx context.Context, recipeID, tag string, remove bool) (bool, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	return e.editLabels(ctx, recipeID, func(r *domain.Recipe) *[]string { return &r.Tags }, tag, remove)
+}
+
+// CollectRecipe adds a recipe to a collection, or removes it when remove
+// is set.  Returns false when nothing changed.
+func (e *Engine) CollectRecipe(ctx context.Context, recipeID, collection string, remove bool) (bool, error) {
+	collection = strings.TrimSpace(collection)
+	// Reuse an existing collection's spelling so "weeknight favorites"
+	// and "Weeknight Favorites" don't become two collections.
+	if names, err := e.Collections(ctx); err == nil {
+		for _, n := range names {
+			if strings.EqualFold(n, collection) {
+				collection = n
+				break
+			}
+		}
+	}
+	return e.editLabels(ctx, recipeID, func(r *domain.Recipe) *[]string { return &r.Collections }, collection, remove)
+}
+
+func (e *Engine) editLabels(ctx context.Context, recipeID string, field func(*domain.Recipe) *[]string, label string, remove bool) (bool, error) {
+	if label == "" {
+		return false, nil
+	}
+	r, err := e.recipes.Get(ctx, recipeID)
+	if err != nil {
+		return false, err
+	}
+	labels := field(r)
+	idx := indexFold(*labels, label)
+	switch {
+	case remove && idx >= 0:
+		*labels = append((*labels)[:idx:idx], (*labels)[idx+1:]...)
+	case !remove && idx < 0:
+		*labels = append(*labels, label)
+	default:
+		return false, nil
+	}
+	if err := e.UpdateRecipe(ctx, r); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// FilterRecipes returns the recipes carrying label as a tag or belonging
+// to a collection of that name (case-insensitive).  A label that's only
+// time and difficulty terms ("quick", "easy", "under 30 minutes") also
+// matches on the recipe's metadata; see metaFilter.
+func (e *Engine) FilterRecipes(ctx context.Context, label string) ([]domain.RecipeSummary, error) {
+	all, err := e.recipes.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	label = strings.TrimSpace(label)
+	rest, keep := metaFilter(label)
+	if rest != "" {
+		keep = nil
+	}
+	var out []domain.RecipeSummary
+	for _, r := range all {
+		if indexFold(r.Tags, label) >= 0 || indexFold(r.Collections, label) >= 0 || (keep != nil && keep(r)) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// Collections returns every collection name in use, sorted.
+func (e *Engine) Collections(ctx context.Context) ([]string, error) {
+	all, err := e.recipes.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, r := range all {
+		for _, c := range r.Collections {
+			if indexFold(names, c) < 0 {
+				names = append(names, c)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func indexFold(list []string, s string) int {
+	for i, v := range list {
+		if strings.EqualFold(v, s) {
+			return i
+		}
+	}
+	return -1
+}
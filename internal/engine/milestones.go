@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Milestones ───────────────────────────────────────────────────
+//
+// The moments in a session worth knowing about away from the kitchen:
+// when a hands-off wait ends, when a running timer goes off, and with a
+// serve time set, when each wait still to come has to start and when to
+// eat.  They're what gets exported to a calendar.
+
+// Milestone is one upcoming moment in a session.
+type Milestone struct {
+	Key    string // stable within the session: "wait", "timer-<id>", "start-<step>", "serve"
+	At     time.Time
+	Title  string
+	Detail string
+}
+
+// Milestones returns the session's milestones still to come, soonest
+// first.
+func (e *Engine) Milestones(ctx context.Context, sessionID string) ([]Milestone, error) {
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading session: %w", err)
+	}
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+	now := e.clock.Now()
+	name := session.RecipeName
+
+	var out []Milestone
+	if session.Status == domain.SessionWaiting {
+		idx := session.CurrentStepIndex
+		title := fmt.Sprintf("%s: wait over", name)
+		detail := ""
+		if idx+1 < len(recipe.Steps) {
+			title = fmt.Sprintf("%s: wait over, on to step %d", name, idx+2)
+			detail = recipe.Steps[idx+1].Instruction
+		}
+		out = append(out, Milestone{Key: "wait", At: session.WaitUntil, Title: title, Detail: detail})
+	}
+	for _, ts := range session.TimerStates {
+		if ts.Status == domain.TimerRunning {
+			out = append(out, Milestone{
+				Key:   "timer-" + ts.ID,
+				At:    now.Add(ts.Remaining),
+				Title: fmt.Sprintf("%s: %s timer", name, ts.Label),
+			})
+		}
+	}
+
+	if !session.ServeAt.IsZero() {
+		plan, err := e.Schedule(ctx, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range plan {
+			if p.Step.Wait <= 0 || p.Index == session.CurrentStepIndex && session.Status == domain.SessionWaiting {
+				continue
+			}
+			out = append(out, Milestone{
+				Key:    fmt.Sprintf("start-%d", p.Index),
+				At:     p.StartBy,
+				Title:  fmt.Sprintf("%s: start step %d, it needs %s", name, p.Step.Order, formatWait(p.Step.Wait)),
+				Detail: p.Step.Instruction,
+			})
+		}
+		out = append(out, Milestone{Key: "serve", At: session.ServeAt, Title: fmt.Sprintf("%s: serve", name)})
+	}
+
+	out = slices.DeleteFunc(out, func(m Milestone) bool { return !m.At.After(now) })
+	slices.SortStableFunc(out, func(a, b Milestone) int { return a.At.Compare(b.At) })
+	return out, nil
+}
+
+// formatWait says a wait the way a calendar title would: "2h", "45m",
+// "1h30m".
+func formatWait(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh%02dm", h, m)
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Step notes ───────────────────────────────────────────────────
+//
+// Notes are free text the cook attaches to the current step ("the sauce
+// needed 5 extra minutes").  They live on the session's StepState until
+// kept, which copies them onto the recipe's step so they're read out the
+// next time the recipe is cooked.
+
+// AddNote attaches a note to the session's current step and returns the
+// 1-based step number it went on.  With keep set, the note is also saved
+// to the recipe for next time.
+func (e *Engine) AddNote(ctx context.Context, sessionID, text string, keep bool) (int, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, fmt.Errorf("empty note")
+	}
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("loading session: %w", err)
+	}
+	idx := session.CurrentStepIndex
+	state, ok := session.StepStates[idx]
+	if !ok {
+		return 0, domain.ErrNoMoreSteps
+	}
+
+	state.Notes = append(state.Notes, text)
+	session.UpdatedAt = e.clock.Now()
+	if err := e.save(ctx, session); err != nil {
+		return 0, fmt.Errorf("saving session: %w", err)
+	}
+	e.log.Info("session %s: note on step %d: %q", sessionID, idx+1, text)
+
+	if keep {
+		if err := e.keepNotes(ctx, session.RecipeID, map[int][]string{idx: {text}}); err != nil {
+			return 0, err
+		}
+	}
+	return idx + 1, nil
+}
+
+// KeepNotes saves every note taken during the session onto the recipe,
+// skipping ones the recipe already has.  Returns how many were added.
+func (e *Engine) KeepNotes(ctx context.Context, sessionID string) (int, error) {
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("loading session: %w", err)
+	}
+	notes := make(map[int][]string)
+	for idx, state := range session.StepStates {
+		if len(state.Notes) > 0 {
+			notes[idx] = state.Notes
+		}
+	}
+	if len(notes) == 0 {
+		return 0, nil
+	}
+
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return 0, fmt.Errorf("getting recipe: %w", err)
+	}
+	added := 0
+	for idx, list := range notes {
+		if idx >= len(recipe.Steps) {
+			continue
+		}
+		for _, n := range list {
+			if indexFold(recipe.Steps[idx].Notes, n) < 0 {
+				added++
+			}
+		}
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	return added, e.keepNotes(ctx, session.RecipeID, notes)
+}
+
+// keepNotes appends notes (keyed by 0-based step index) to the recipe's
+// steps and persists it.  Notes already on a step aren't repeated.
+func (e *Engine) keepNotes(ctx context.Context, recipeID string, notes map[int][]string) error {
+	recipe, err := e.recipes.Get(ctx, recipeID)
+	if err != nil {
+		return fmt.Errorf("getting recipe: %w", err)
+	}
+	for idx, list := range notes {
+		if idx < 0 || idx >= len(recipe.Steps) {
+			continue
+		}
+		step := &recipe.Steps[idx]
+		for _, n := range list {
+			if indexFold(step.Notes, n) < 0 {
+				step.Notes = append(step.Notes, n)
+			}
+		}
+	}
+	return e.UpdateRecipe(ctx, recipe)
+}
@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Prep list ────────────────────────────────────────────────────
+//
+// "What do I need to chop?": every bit of knife work in the recipe,
+// gathered into one checklist so it can all be done before the pan goes
+// on.  An ingredient's Prep field says it outright; otherwise the step
+// that first uses the ingredient is read for a prep verb ("julienne the
+// carrot", "add minced garlic").  Later mentions don't count — "slice the
+// rested chicken" at the end is plating, not prep.
+
+// PrepTask is one ingredient and what to do to it before cooking.
+type PrepTask struct {
+	Ingredient string
+	Prep       string // "minced", "sliced into strips"
+	Step       int    // 1-based step that first uses the ingredient, 0 if none does
+}
+
+// prepVerbs maps every form of a prep verb to how the checklist says it.
+var prepVerbs = map[string]string{}
+
+func init() {
+	for base, done := range map[string]string{
+		"chop": "chopped", "dice": "diced", "mince": "minced", "slice": "sliced",
+		"julienne": "julienned", "grate": "grated", "trim": "trimmed", "peel": "peeled",
+		"crush": "crushed", "zest": "zested", "shred": "shredded", "cube": "cubed",
+		"cut": "cut", "halve": "halved", "core": "cored",
+	} {
+		prepVerbs[base] = done
+		prepVerbs[done] = done
+	}
+}
+
+var sentenceSplit = regexp.MustCompile(`[.;!?]+`)
+
+// PrepList gathers the recipe's prep work into one list, in the order
+// the ingredients are needed.
+func (e *Engine) PrepList(ctx context.Context, recipeID string) ([]PrepTask, error) {
+	recipe, err := e.recipes.Get(ctx, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+	return prepTasks(recipe), nil
+}
+
+func prepTasks(r *domain.Recipe) []PrepTask {
+	var tasks []PrepTask
+	for _, ing := range r.Ingredients {
+		first := firstUse(r, ing)
+		prep := ing.Prep
+		if prep == "" && first > 0 {
+			prep = prepIn(r.Steps[first-1].Instruction, ing)
+		}
+		if prep != "" {
+			tasks = append(tasks, PrepTask{Ingredient: ing.Name, Prep: prep, Step: first})
+		}
+	}
+	// By first use; ingredients no step mentions go last.
+	slices.SortStableFunc(tasks, func(a, b PrepTask) int {
+		return stepKey(a.Step) - stepKey(b.Step)
+	})
+	return tasks
+}
+
+func stepKey(step int) int {
+	if step == 0 {
+		return 1 << 30
+	}
+	return step
+}
+
+// firstUse is the 1-based order of the first step that mentions ing, or 0.
+func firstUse(r *domain.Recipe, ing domain.Ingredient) int {
+	for i, s := range r.Steps {
+		if ing.MentionedIn(s.Instruction) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// prepIn finds a prep verb applied to ing in text: the ingredient has to
+// come after the verb and before the next one, within a sentence.
+func prepIn(text string, ing domain.Ingredient) string {
+	for _, sentence := range sentenceSplit.Split(strings.ToLower(text), -1) {
+		verb := ""
+		var clause []string
+		flush := func() string {
+			if verb != "" && ing.MentionedIn(strings.Join(clause, " ")) {
+				return verb
+			}
+			return ""
+		}
+		for _, w := range strings.Fields(sentence) {
+			if done, ok := prepVerbs[strings.Trim(w, ",:()")]; ok {
+				if p := flush(); p != "" {
+					return p
+				}
+				verb, clause = done, nil
+				continue
+			}
+			clause = append(clause, w)
+		}
+		if p := flush(); p != "" {
+			return p
+		}
+	}
+	return ""
+}
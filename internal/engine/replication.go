@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Session replication ──────────────────────────────────────────
+//
+// These let another process mirror a session: OnSessionChange streams
+// every change out, SnapshotSession hands over a full copy, and
+// ImportSession starts a local session from someone else's copy.  Used
+// by cook-along mode to keep two kitchens on the same recipe.
+
+// OnSessionChange registers a callback invoked with a copy of the
+// session after every change the engine saves.  The callback runs on
+// the caller's goroutine and must not block.
+func (e *Engine) OnSessionChange(fn func(session *domain.Session)) {
+	e.mu.Lock()
+	e.onSessionChange = fn
+	e.mu.Unlock()
+}
+
+// save persists a session and notifies the change callback.
+func (e *Engine) save(ctx context.Context, session *domain.Session) error {
+	if err := e.store.Save(ctx, session); err != nil {
+		return err
+	}
+	e.mu.Lock()
+	fn := e.onSessionChange
+	e.mu.Unlock()
+	if fn != nil {
+		if snap, err := cloneSession(session); err == nil {
+			fn(snap)
+		}
+	}
+	return nil
+}
+
+// SnapshotSession returns a deep copy of a session, safe to hand to
+// another goroutine or serialise.
+func (e *Engine) SnapshotSession(ctx context.Context, sessionID string) (*domain.Session, error) {
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading session: %w", err)
+	}
+	return cloneSession(session)
+}
+
+// ImportSession starts a local session that picks up where a remote
+// one is: same recipe, step, and step states.  The recipe must exist
+// here with the same number of steps.  The copy gets a fresh local ID.
+// The remote session came over the network, so it's checked (see
+// checkImport) before anything here trusts it.
+func (e *Engine) ImportSession(ctx context.Context, remote *domain.Session) (*domain.Session, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	recipe, err := e.recipes.Get(ctx, remote.RecipeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+	if err := checkImport(remote, recipe); err != nil {
+		return nil, fmt.Errorf("remote session: %w", err)
+	}
+
+	session, err := cloneSession(remote)
+	if err != nil {
+		return nil, err
+	}
+	session.ID = generateID()
+	session.RecipeName = recipe.Name
+	session.UpdatedAt = e.clock.Now()
+	if session.TimerStates == nil {
+		session.TimerStates = make(map[string]*domain.TimerState)
+	}
+
+	if err := e.save(ctx, session); err != nil {
+		return nil, fmt.Errorf("saving session: %w", err)
+	}
+	e.log.Info("imported session %s for recipe %q at step %d", session.ID, recipe.Name, session.CurrentStepIndex+1)
+	return session, nil
+}
+
+// checkImport rejects a remote session that would break the engine:
+// a step index off the end of the recipe, missing or unknown step and
+// timer states, or a session that isn't under way.
+func checkImport(remote *domain.Session, recipe *domain.Recipe) error {
+	n := len(recipe.Steps)
+	if len(remote.StepStates) != n {
+		return fmt.Errorf("recipe %s has %d steps here, %d remotely", recipe.ID, n, len(remote.StepStates))
+	}
+	if remote.CurrentStepIndex < 0 || remote.CurrentStepIndex >= n {
+		return fmt.Errorf("step %d is out of range for %d steps", remote.CurrentStepIndex+1, n)
+	}
+	switch remote.Status {
+	case domain.SessionActive, domain.SessionPaused, domain.SessionWaiting:
+	default:
+		return fmt.Errorf("session is %s", remote.Status)
+	}
+	for i := 0; i < n; i++ {
+		st := remote.StepStates[i]
+		if st == nil {
+			return fmt.Errorf("no state for step %d", i+1)
+		}
+		if st.Status < domain.StepPending || st.Status > domain.StepSkipped {
+			return fmt.Errorf("step %d has unknown status %d", i+1, st.Status)
+		}
+	}
+	for id, ts := range remote.TimerStates {
+		if ts == nil || ts.ID != id {
+			return fmt.Errorf("bad timer %q", id)
+		}
+		if ts.Status < domain.TimerPending || ts.Status > domain.TimerCancelled {
+			return fmt.Errorf("timer %q has unknown status %d", id, ts.Status)
+		}
+		if ts.Duration < 0 || ts.Remaining < 0 {
+			return fmt.Errorf("timer %q has a negative duration", id)
+		}
+	}
+	for _, t := range remote.Tasks {
+		if t == nil || t.Step < 0 || t.Step >= n {
+			return fmt.Errorf("bad helper task")
+		}
+	}
+	return nil
+}
+
+// cloneSession deep-copies a session by round-tripping it through JSON,
+// which is also the format it's replicated in.
+func cloneSession(s *domain.Session) (*domain.Session, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("encoding session: %w", err)
+	}
+	var out domain.Session
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("decoding session: %w", err)
+	}
+	return &out, nil
+}
@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Serve time ───────────────────────────────────────────────────
+//
+// "Dinner at 19:30": the cook gives a time to eat, and the schedule is
+// worked backwards from it — each remaining step's start-by time is the
+// serve time less the expected length of that step and everything after
+// it.  Nothing is enforced; the watcher just says so when the estimate
+// runs past the target.
+
+// PlannedStep is one remaining step and the latest it can start for the
+// food to be ready at the serve time.
+type PlannedStep struct {
+	Index   int // position in recipe.Steps
+	Step    domain.Step
+	StartBy time.Time
+}
+
+// SetServeTime records when the cook wants to eat.  A zero time clears it.
+func (e *Engine) SetServeTime(ctx context.Context, sessionID string, at time.Time) error {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("loading session: %w", err)
+	}
+
+	session.ServeAt = at
+	session.UpdatedAt = e.clock.Now()
+
+	if err := e.save(ctx, session); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+
+	e.log.Info("session %s serve time set to %s", sessionID, at.Format(time.Kitchen))
+	return nil
+}
+
+// Schedule back-computes a start-by time for every step still to do.
+// Returns nil when no serve time is set.
+func (e *Engine) Schedule(ctx context.Context, sessionID string) ([]PlannedStep, error) {
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading session: %w", err)
+	}
+	if session.ServeAt.IsZero() {
+		return nil, nil
+	}
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+
+	var plan []PlannedStep
+	at := session.ServeAt
+	for i := len(recipe.Steps) - 1; i >= 0; i-- {
+		st, ok := session.StepStates[i]
+		if !ok || (st.Status != domain.StepPending && st.Status != domain.StepActive) {
+			continue
+		}
+		at = at.Add(-recipe.Steps[i].Length())
+		plan = append(plan, PlannedStep{Index: i, Step: recipe.Steps[i], StartBy: at})
+	}
+	slices.Reverse(plan) // built back to front
+	return plan, nil
+}
+
+// StartBy is the latest a recipe can be started to be ready at serveAt,
+// for planning before there's a session.
+func (e *Engine) StartBy(ctx context.Context, recipeID string, serveAt time.Time) (time.Time, error) {
+	recipe, err := e.recipes.Get(ctx, recipeID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("getting recipe: %w", err)
+	}
+	for _, step := range recipe.Steps {
+		serveAt = serveAt.Add(-step.Length())
+	}
+	return serveAt, nil
+}
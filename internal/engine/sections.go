@@ -0,0 +1,207 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Sections and optional steps ──────────────────────────────────
+//
+// Steps can be grouped into named sections ("Sauce", "Garnish") and
+// marked optional.  The cook can skip the rest of a section, jump ahead
+// to one (or to a step by number), or decline an optional section
+// before reaching it; declined steps are marked skipped up front, so
+// Advance passes over them and Remaining leaves them out.
+
+// nextOpenStep returns the first step at or after from that is still
+// pending, or n when there is none.
+func nextOpenStep(session *domain.Session, from, n int) int {
+	for i := from; i < n; i++ {
+		if st, ok := session.StepStates[i]; !ok || st.Status == domain.StepPending {
+			return i
+		}
+	}
+	return n
+}
+
+// sectionMatches reports whether a step's section answers to name:
+// the same ignoring case, or containing it ("garnish" finds "Garnish
+// and serve").
+func sectionMatches(section, name string) bool {
+	section, name = strings.ToLower(section), strings.ToLower(strings.TrimSpace(name))
+	return section != "" && name != "" && (section == name || strings.Contains(section, name))
+}
+
+// SkipSection skips the rest of the current step's section and moves to
+// the first step after it.  A step without a section is skipped alone,
+// as with Skip.
+func (e *Engine) SkipSection(ctx context.Context, sessionID string) (*domain.Step, error) {
+	return e.skipUntil(ctx, sessionID, func(r *domain.Recipe, cur int) (int, error) {
+		end := cur + 1
+		if section := r.Steps[cur].Section; section != "" {
+			for end < len(r.Steps) && r.Steps[end].Section == section {
+				end++
+			}
+		}
+		return end, nil
+	})
+}
+
+// SkipTo skips every step between the current one and the start of the
+// named section, which must lie ahead.  Returns domain.ErrNoSuchSection
+// if it doesn't.
+func (e *Engine) SkipTo(ctx context.Context, sessionID, section string) (*domain.Step, error) {
+	return e.skipUntil(ctx, sessionID, func(r *domain.Recipe, cur int) (int, error) {
+		for i := cur + 1; i < len(r.Steps); i++ {
+			if sectionMatches(r.Steps[i].Section, section) {
+				return i, nil
+			}
+		}
+		return 0, domain.ErrNoSuchSection
+	})
+}
+
+// SkipToStep skips every step between the current one and step n
+// (counted from 1), which must lie ahead.  Returns domain.ErrNoSuchStep
+// if it doesn't.
+func (e *Engine) SkipToStep(ctx context.Context, sessionID string, n int) (*domain.Step, error) {
+	return e.skipUntil(ctx, sessionID, func(r *domain.Recipe, cur int) (int, error) {
+		if n-1 <= cur || n > len(r.Steps) {
+			return 0, domain.ErrNoSuchStep
+		}
+		return n - 1, nil
+	})
+}
+
+// skipUntil marks the current step and everything before the index
+// target returns as skipped, then moves to the first open step from
+// there.  Returns domain.ErrNoMoreSteps when that finishes the recipe.
+func (e *Engine) skipUntil(ctx context.Context, sessionID string, target func(r *domain.Recipe, cur int) (int, error)) (*domain.Step, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading session: %w", err)
+	}
+	if session.Status != domain.SessionActive {
+		return nil, domain.ErrSessionNotActive
+	}
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+
+	cur := session.CurrentStepIndex
+	end, err := target(recipe, cur)
+	if err != nil {
+		return nil, err
+	}
+
+	now := e.clock.Now()
+	for i := cur; i < end && i < len(recipe.Steps); i++ {
+		if st := session.StepStates[i]; st.Status == domain.StepPending || st.Status == domain.StepActive {
+			st.Status = domain.StepSkipped
+			st.CompletedAt = now
+		}
+	}
+	// As with Skip, timers waiting on the current step start counting.
+	for _, ts := range session.TimerStates {
+		if ts.Status == domain.TimerPending {
+			session.SetTimer(ts, domain.TimerRunning, now)
+		}
+	}
+
+	nextIdx := nextOpenStep(session, end, len(recipe.Steps))
+	session.UpdatedAt = now
+	if nextIdx >= len(recipe.Steps) {
+		session.Status = domain.SessionCompleted
+		if err := e.save(ctx, session); err != nil {
+			return nil, fmt.Errorf("saving session: %w", err)
+		}
+		e.log.Info("session %s completed (skipped to the end)", sessionID)
+		return nil, domain.ErrNoMoreSteps
+	}
+
+	session.CurrentStepIndex = nextIdx
+	session.StepStates[nextIdx].Status = domain.StepActive
+	session.StepStates[nextIdx].StartedAt = now
+	step := &recipe.Steps[nextIdx]
+	e.maybeStartTimer(session, *step)
+
+	if err := e.save(ctx, session); err != nil {
+		return nil, fmt.Errorf("saving session: %w", err)
+	}
+	e.log.Debug("session %s skipped %d step(s) to step %d/%d", sessionID, nextIdx-cur, nextIdx+1, len(recipe.Steps))
+	return step, nil
+}
+
+// DeclineSection marks the optional steps of a section still ahead as
+// skipped, without moving from the current step.  Returns how many were
+// declined, or domain.ErrNoSuchSection if the section has no optional
+// steps left to decline.
+func (e *Engine) DeclineSection(ctx context.Context, sessionID, section string) (int, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("loading session: %w", err)
+	}
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return 0, fmt.Errorf("getting recipe: %w", err)
+	}
+
+	now := e.clock.Now()
+	declined := 0
+	for i := session.CurrentStepIndex + 1; i < len(recipe.Steps); i++ {
+		step := recipe.Steps[i]
+		st := session.StepStates[i]
+		if !step.Optional || !sectionMatches(step.Section, section) || st.Status != domain.StepPending {
+			continue
+		}
+		st.Status = domain.StepSkipped
+		st.CompletedAt = now
+		declined++
+	}
+	if declined == 0 {
+		return 0, domain.ErrNoSuchSection
+	}
+
+	session.UpdatedAt = now
+	if err := e.save(ctx, session); err != nil {
+		return 0, fmt.Errorf("saving session: %w", err)
+	}
+	e.log.Debug("session %s declined %d optional step(s) in %q", sessionID, declined, section)
+	return declined, nil
+}
+
+// Remaining estimates the cooking time left; see domain.Session.TimeLeft.
+func (e *Engine) Remaining(ctx context.Context, sessionID string) (time.Duration, error) {
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("loading session: %w", err)
+	}
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return 0, fmt.Errorf("getting recipe: %w", err)
+	}
+	return session.TimeLeft(recipe, e.clock.Now()), nil
+}
+
+// Pace says how far behind (positive) or ahead (negative) of the
+// recipe's estimates the session is; see domain.Session.Pace.
+func (e *Engine) Pace(ctx context.Context, sessionID string) (time.Duration, error) {
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("loading session: %w", err)
+	}
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return 0, fmt.Errorf("getting recipe: %w", err)
+	}
+	return session.Pace(recipe, e.clock.Now()), nil
+}
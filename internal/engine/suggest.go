@@ -0,0 +1,220 @@
+package engine
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Suggestions ──────────────────────────────────────────────────
+//
+// "What should I cook?" gets two or three recipes with a reason for
+// each, rather than the whole list.  The request can say how long
+// there is ("in under 30 minutes", "something quick") and what's to
+// hand ("with chicken and rice"); recipes that clash with the cook's
+// diet are left out, ones cooked lately go to the back, and one tagged
+// for the season comes forward.  Ties are broken at random, so asking
+// again gives something else.
+//
+// Leaving out what clashes is a best effort: an ingredient list can't
+// promise "no nuts".  So a recipe is only said to fit the diet when
+// every need was one it can be checked against — vegetarian or vegan —
+// and never for an allergy.
+
+// Suggestion is a recipe worth cooking and why.
+type Suggestion struct {
+	Recipe domain.RecipeSummary
+	Time   time.Duration // all in; 0 when the recipe doesn't say
+	Uses   []string      // ingredients asked about that it uses
+	Season string        // the season it's tagged for, when that's now
+	Fresh  bool          // not among the recent ones
+	Diet   bool          // checked against every dietary need and fits them
+}
+
+// Suggest picks up to n recipes for query, which may be empty.  diet
+// is the cook's dietary needs as profiles keep them ("vegetarian",
+// "allergic to peanuts", "no pork"); recent is the IDs of recipes
+// cooked lately, most recent last.
+func (e *Engine) Suggest(ctx context.Context, query string, diet, recent []string, n int) ([]Suggestion, error) {
+	rest, keep := metaFilter(query)
+	var have []string
+	for _, w := range strings.Fields(rest) {
+		if !suggestFiller[w] {
+			have = append(have, w)
+		}
+	}
+
+	list, err := e.recipes.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing recipes: %w", err)
+	}
+	rand.Shuffle(len(list), func(i, j int) { list[i], list[j] = list[j], list[i] })
+
+	var avoid [][]string
+	vouch := len(diet) > 0
+	for _, need := range diet {
+		words, checked := readNeed(need)
+		avoid = append(avoid, words)
+		vouch = vouch && checked
+	}
+
+	season := seasonOf(e.clock.Now())
+	type scored struct {
+		Suggestion
+		score int
+	}
+	var out []scored
+	for _, summary := range list {
+		if keep != nil && !keep(summary) {
+			continue
+		}
+		r, err := e.recipes.Get(ctx, summary.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting recipe: %w", err)
+		}
+		if slices.ContainsFunc(avoid, func(words []string) bool { return clashes(r, words) }) {
+			continue
+		}
+		s := scored{Suggestion: Suggestion{Recipe: summary, Time: cmp.Or(r.TotalTime(), stepsLength(r)), Diet: vouch}}
+		if len(have) > 0 {
+			text := strings.Join(have, " ")
+			for _, ing := range r.Ingredients {
+				if ing.MentionedIn(text) {
+					s.Uses = append(s.Uses, strings.ToLower(ing.Name))
+				}
+			}
+			if len(s.Uses) == 0 {
+				continue
+			}
+			s.score += 2 * len(s.Uses)
+		}
+		if indexFold(r.Tags, season) >= 0 {
+			s.Season = season
+			s.score += 2
+		}
+		if i := slices.Index(recent, r.ID); i >= 0 {
+			s.score -= 3 + i // the later it was cooked, the further back
+		} else if len(recent) > 0 {
+			s.Fresh = true
+		}
+		out = append(out, s)
+	}
+
+	slices.SortStableFunc(out, func(a, b scored) int { return b.score - a.score })
+	picks := make([]Suggestion, 0, min(n, len(out)))
+	for _, s := range out[:min(n, len(out))] {
+		picks = append(picks, s.Suggestion)
+	}
+	return picks, nil
+}
+
+// suggestFiller is left over from "what can we make tonight with some
+// chicken" once the time and difficulty terms are out.
+var suggestFiller = map[string]bool{
+	"i": true, "we": true, "me": true, "my": true, "have": true, "got": true, "use": true, "up": true,
+	"with": true, "and": true, "or": true, "a": true, "an": true, "some": true, "something": true,
+	"anything": true, "in": true, "for": true, "to": true, "of": true, "tonight": true, "today": true,
+	"dinner": true, "lunch": true, "breakfast": true, "tea": true,
+}
+
+// seasonOf is the season at t, in the northern hemisphere.
+func seasonOf(t time.Time) string {
+	switch t.Month() {
+	case time.March, time.April, time.May:
+		return "spring"
+	case time.June, time.July, time.August:
+		return "summer"
+	case time.September, time.October, time.November:
+		return "autumn"
+	default:
+		return "winter"
+	}
+}
+
+// Words for what a vegetarian or vegan diet leaves out.
+var (
+	meatWords = []string{
+		"chicken", "beef", "pork", "bacon", "ham", "lamb", "sausage", "turkey", "duck", "veal",
+		"prosciutto", "pancetta", "chorizo", "mince", "steak", "fish", "salmon", "tuna", "cod",
+		"anchovy", "anchovies", "shrimp", "prawn", "prawns", "gelatin",
+	}
+	animalWords = []string{"egg", "eggs", "milk", "butter", "cream", "cheese", "parmesan", "mozzarella", "yogurt", "honey"}
+)
+
+// foodGroups are the words a need names a whole group of ingredients
+// by, and what they stand for.
+var foodGroups = map[string][]string{
+	"dairy": {"milk", "butter", "cream", "cheese", "parmesan", "mozzarella", "gruyere", "yogurt", "ghee",
+		"buttermilk", "fraiche", "mascarpone", "ricotta", "whey"},
+	"nut": {"nut", "almond", "cashew", "walnut", "pecan", "hazelnut", "pistachio", "peanut", "macadamia",
+		"praline", "marzipan"},
+	"gluten": {"flour", "wheat", "bread", "breadcrumbs", "panko", "pasta", "spaghetti", "noodles", "couscous",
+		"barley", "rye", "semolina", "bulgur"},
+	"shellfish": {"shrimp", "prawn", "crab", "lobster", "mussel", "clam", "oyster", "scallop", "langoustine"},
+}
+
+func init() {
+	foodGroups["lactose"] = foodGroups["dairy"]
+	foodGroups["wheat"] = foodGroups["gluten"]
+}
+
+// needFiller is what's left of "allergic to tree nuts" or "nut
+// allergy" besides the food itself.
+var needFiller = map[string]bool{
+	"and": true, "or": true, "tree": true, "allergy": true, "allergies": true, "intolerance": true, "intolerant": true,
+}
+
+// readNeed turns a dietary need, kept the way profiles keep them
+// ("vegan", "vegetarian", "allergic to X", "no X", "X-free"), into the
+// ingredient words it rules out.  checked is whether a recipe without
+// any of them can be said to fit: only for vegetarian and vegan, since
+// anything else is read word for word and an allergy can't be vouched
+// for from an ingredient list.
+func readNeed(need string) (avoid []string, checked bool) {
+	need = strings.ToLower(strings.TrimSpace(need))
+	allergy := strings.Contains(need, "allerg")
+	switch {
+	case strings.Contains(need, "vegan"):
+		return append(slices.Clone(meatWords), animalWords...), !allergy
+	case strings.Contains(need, "vegetarian"):
+		return meatWords, !allergy
+	}
+	for _, prefix := range []string{"allergic to ", "no ", "avoid ", "not "} {
+		if rest, ok := strings.CutPrefix(need, prefix); ok {
+			need = rest
+			break
+		}
+	}
+	need = strings.TrimSuffix(strings.TrimSuffix(need, "-free"), " free")
+	for _, w := range strings.FieldsFunc(need, func(r rune) bool { return r == ',' || r == ' ' || r == '-' }) {
+		if needFiller[w] {
+			continue
+		}
+		avoid = append(avoid, w)
+		if group, ok := foodGroups[strings.TrimSuffix(w, "s")]; ok {
+			avoid = append(avoid, group...)
+		}
+	}
+	return avoid, false
+}
+
+// clashes reports whether the recipe has an ingredient named by one of
+// avoid, singular or plural.
+func clashes(r *domain.Recipe, avoid []string) bool {
+	for _, ing := range r.Ingredients {
+		for _, w := range strings.Fields(strings.ToLower(ing.Name)) {
+			for _, a := range avoid {
+				if w == a || w+"s" == a || w == a+"s" || w+"es" == a || w == a+"es" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
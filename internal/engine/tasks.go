@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Helper tasks ─────────────────────────────────────────────────
+//
+// Cooking with a helper, the side jobs a step suggests ("while the water
+// heats, chop the broccoli") can be handed to them by name.  Each becomes
+// a task with its own done state, separate from the steps: the cook moves
+// on while the helper works, and the task stays open until someone says
+// it's done.
+
+// Delegate hands text to helper as a task on the current step.  Empty
+// text hands over the step's parallel hints not already given to
+// someone; domain.ErrNothingToHandOff if there are none.
+func (e *Engine) Delegate(ctx context.Context, sessionID, helper, text string) ([]*domain.Task, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	helper = helperName(helper)
+	if helper == "" {
+		return nil, fmt.Errorf("delegating: no helper named")
+	}
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading session: %w", err)
+	}
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+	idx := session.CurrentStepIndex
+
+	texts := []string{strings.TrimSpace(text)}
+	if texts[0] == "" {
+		if idx >= len(recipe.Steps) {
+			return nil, domain.ErrNothingToHandOff
+		}
+		texts = texts[:0]
+		for _, hint := range recipe.Steps[idx].ParallelHints {
+			if !handedOff(session, idx, hint) {
+				texts = append(texts, hint)
+			}
+		}
+		if len(texts) == 0 {
+			return nil, domain.ErrNothingToHandOff
+		}
+	}
+
+	now := e.clock.Now()
+	var given []*domain.Task
+	for _, t := range texts {
+		task := &domain.Task{ID: generateID(), Helper: helper, Text: t, Step: idx, Assigned: now}
+		session.Tasks = append(session.Tasks, task)
+		given = append(given, task)
+	}
+	session.UpdatedAt = now
+	if err := e.save(ctx, session); err != nil {
+		return nil, fmt.Errorf("saving session: %w", err)
+	}
+	e.log.Info("session %s: %d task(s) for %s", sessionID, len(given), helper)
+	return given, nil
+}
+
+// FinishTasks marks tasks done, or with done false open again.  helper
+// narrows it to that person's tasks and words to the one whose text they
+// match best; with neither, the only task there is to mark.  "Sam's
+// done" marks all of Sam's open tasks.  Returns domain.ErrNotFound when
+// nothing fits and domain.ErrAmbiguous when more than one task does
+// without a helper to say whose.
+func (e *Engine) FinishTasks(ctx context.Context, sessionID, helper, words string, done bool) ([]*domain.Task, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading session: %w", err)
+	}
+
+	var candidates []*domain.Task
+	for _, t := range session.Tasks {
+		if t.Done != done && (helper == "" || strings.EqualFold(t.Helper, helper)) {
+			candidates = append(candidates, t)
+		}
+	}
+	if words != "" {
+		candidates = matchTask(candidates, words)
+	}
+	switch {
+	case len(candidates) == 0:
+		return nil, domain.ErrNotFound
+	case len(candidates) > 1 && helper == "":
+		return nil, domain.ErrAmbiguous
+	}
+
+	now := e.clock.Now()
+	for _, t := range candidates {
+		t.Done = done
+		t.DoneAt = time.Time{}
+		if done {
+			t.DoneAt = now
+		}
+	}
+	session.UpdatedAt = now
+	if err := e.save(ctx, session); err != nil {
+		return nil, fmt.Errorf("saving session: %w", err)
+	}
+	return candidates, nil
+}
+
+// Tasks returns the session's tasks, all helpers' when helper is empty.
+func (e *Engine) Tasks(ctx context.Context, sessionID, helper string) ([]*domain.Task, error) {
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading session: %w", err)
+	}
+	var out []*domain.Task
+	for _, t := range session.Tasks {
+		if helper == "" || strings.EqualFold(t.Helper, helper) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// matchTask keeps the tasks sharing the most words with words, the same
+// way step conditions are matched.
+func matchTask(tasks []*domain.Task, words string) []*domain.Task {
+	want := conditionStems(words)
+	var best []*domain.Task
+	bestScore := 0
+	for _, t := range tasks {
+		score := 0
+		have := conditionStems(t.Text)
+		for w := range want {
+			if have[w] {
+				score++
+			}
+		}
+		switch {
+		case score > bestScore:
+			best, bestScore = []*domain.Task{t}, score
+		case score == bestScore && score > 0:
+			best = append(best, t)
+		}
+	}
+	return best
+}
+
+// handedOff reports whether a hint on step idx was already given to
+// someone.
+func handedOff(session *domain.Session, idx int, hint string) bool {
+	for _, t := range session.Tasks {
+		if t.Step == idx && t.Text == hint {
+			return true
+		}
+	}
+	return false
+}
+
+// helperName tidies a name as heard: "sam" is "Sam".
+func helperName(name string) string {
+	name = strings.Trim(strings.TrimSpace(name), ".,:!?")
+	r, n := utf8.DecodeRuneInString(name)
+	if n == 0 {
+		return ""
+	}
+	return string(unicode.ToUpper(r)) + name[n:]
+}
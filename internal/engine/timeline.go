@@ -0,0 +1,168 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Timeline ─────────────────────────────────────────────────────
+//
+// A cook as it actually went, laid out on a time axis: each step from
+// when it started to when it was done, each timer's runs and how long it
+// rang before anyone answered it, the pauses and waits, and the moments
+// the watcher spoke up.  The steps come from the step states; the rest
+// from the session's recorded events.
+
+// Stretch is one span on a session's timeline.
+type Stretch struct {
+	Lane   string // the row it sits on: "Steps", "Paused", "Watcher", or "<label> timer"
+	Kind   StretchKind
+	Label  string
+	Detail string
+	From   time.Time
+	To     time.Time // equal to From for a moment, such as a nudge
+}
+
+// StretchKind is what a stretch of the timeline was.
+type StretchKind int
+
+const (
+	StretchStep StretchKind = iota
+	StretchSkipped
+	StretchTimer   // counting down
+	StretchRinging // gone off and not yet dismissed
+	StretchPaused
+	StretchWaiting
+	StretchNudge
+)
+
+// Timeline is a session laid out from its start to its end.
+type Timeline struct {
+	Recipe    string
+	Start     time.Time
+	End       time.Time // when it finished, or now if it hasn't
+	Stretches []Stretch // the steps, then the timers, pauses and nudges
+}
+
+// Timeline lays out a session, finished or not.  Anything still open
+// at the end (a running timer, a pause) runs to the end.
+func (e *Engine) Timeline(ctx context.Context, sessionID string) (*Timeline, error) {
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading session: %w", err)
+	}
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+
+	end := e.clock.Now()
+	if session.Status == domain.SessionCompleted || session.Status == domain.SessionAbandoned {
+		end = session.UpdatedAt
+	}
+	tl := &Timeline{Recipe: session.RecipeName, Start: session.StartedAt, End: end}
+	var groups [4][]Stretch // steps, timers, pauses and waits, nudges
+	add := func(lane string, kind StretchKind, label, detail string, from, to time.Time) {
+		g := 0
+		switch kind {
+		case StretchTimer, StretchRinging:
+			g = 1
+		case StretchPaused, StretchWaiting:
+			g = 2
+		case StretchNudge:
+			g = 3
+		}
+		groups[g] = append(groups[g], Stretch{Lane: lane, Kind: kind, Label: label, Detail: detail, From: from, To: to})
+	}
+
+	for i, step := range recipe.Steps {
+		st, ok := session.StepStates[i]
+		if !ok || st.StartedAt.IsZero() {
+			continue
+		}
+		to := st.CompletedAt
+		if to.IsZero() {
+			to = end
+		}
+		kind := StretchStep
+		if st.Status == domain.StepSkipped {
+			kind = StretchSkipped
+		}
+		add("Steps", kind, fmt.Sprintf("Step %d", step.Order), step.Instruction, st.StartedAt, to)
+	}
+
+	// Each timer gets a lane of its own.
+	var order []string
+	running := make(map[string]time.Time)
+	ringing := make(map[string]time.Time)
+	timerLane := func(id string) string {
+		if ts, ok := session.TimerStates[id]; ok {
+			return ts.Label + " timer"
+		}
+		return id
+	}
+	var pausedAt, waitingAt time.Time
+	for _, ev := range session.Timeline {
+		switch ev.Kind {
+		case domain.EventTimerStarted:
+			if !slices.Contains(order, ev.Ref) {
+				order = append(order, ev.Ref)
+			}
+			if from, ok := ringing[ev.Ref]; ok { // restarted after going off
+				add(timerLane(ev.Ref), StretchRinging, "ringing", "", from, ev.At)
+				delete(ringing, ev.Ref)
+			}
+			running[ev.Ref] = ev.At
+		case domain.EventTimerFired, domain.EventTimerStopped:
+			if from, ok := running[ev.Ref]; ok {
+				add(timerLane(ev.Ref), StretchTimer, "running", "", from, ev.At)
+				delete(running, ev.Ref)
+			}
+			if from, ok := ringing[ev.Ref]; ok {
+				add(timerLane(ev.Ref), StretchRinging, "ringing", "", from, ev.At)
+				delete(ringing, ev.Ref)
+			}
+			if ev.Kind == domain.EventTimerFired {
+				ringing[ev.Ref] = ev.At
+			}
+		case domain.EventPaused:
+			pausedAt = ev.At
+		case domain.EventResumed:
+			if !pausedAt.IsZero() {
+				add("Paused", StretchPaused, "paused", "", pausedAt, ev.At)
+				pausedAt = time.Time{}
+			}
+		case domain.EventWaitStarted:
+			waitingAt = ev.At
+		case domain.EventWaitEnded:
+			if !waitingAt.IsZero() {
+				add("Paused", StretchWaiting, "waiting", "", waitingAt, ev.At)
+				waitingAt = time.Time{}
+			}
+		case domain.EventNudge:
+			add("Watcher", StretchNudge, "nudge", ev.Text, ev.At, ev.At)
+		}
+	}
+	for _, id := range order {
+		if from, ok := running[id]; ok {
+			add(timerLane(id), StretchTimer, "running", "", from, end)
+		}
+		if from, ok := ringing[id]; ok {
+			add(timerLane(id), StretchRinging, "ringing", "", from, end)
+		}
+	}
+	if !pausedAt.IsZero() {
+		add("Paused", StretchPaused, "paused", "", pausedAt, end)
+	}
+	if !waitingAt.IsZero() {
+		add("Paused", StretchWaiting, "waiting", "", waitingAt, end)
+	}
+	for _, g := range groups {
+		tl.Stretches = append(tl.Stretches, g...)
+	}
+	return tl, nil
+}
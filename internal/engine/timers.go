@@ -0,0 +1,234 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Timer addressing ─────────────────────────────────────────────
+//
+// With several timers going, "dismiss 2" or "dismiss water" should hit
+// the right one without a round trip to the AI.  Active timers are kept
+// in recipe step order so the indices status prints stay put while the
+// timers tick down.
+
+// activeTimers returns the session's running, paused, and fired timers ordered
+// by the position of their step in the recipe, then by label.
+func activeTimers(session *domain.Session, recipe *domain.Recipe) []*domain.TimerState {
+	order := make(map[string]int, len(recipe.Steps))
+	for i, st := range recipe.Steps {
+		order[st.ID] = i
+	}
+	pos := func(ts *domain.TimerState) int {
+		if i, ok := order[ts.StepID]; ok {
+			return i
+		}
+		return len(recipe.Steps) // timers not tied to a recipe step go last
+	}
+
+	var active []*domain.TimerState
+	for _, ts := range session.TimerStates {
+		switch ts.Status {
+		case domain.TimerRunning, domain.TimerPaused, domain.TimerFired:
+			active = append(active, ts)
+		}
+	}
+	slices.SortFunc(active, func(a, b *domain.TimerState) int {
+		if d := pos(a) - pos(b); d != 0 {
+			return d
+		}
+		if c := strings.Compare(a.Label, b.Label); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return active
+}
+
+// ResolveTimer finds the active timer ref names.  ref is a timer ID (what
+// a click on the timer bar sends), a 1-based index into ActiveTimers
+// ("2"), or a label: an exact match wins, then a label that starts with
+// ref, then one that contains it.  Returns
+// domain.ErrNotFound when nothing matches and domain.ErrAmbiguous when
+// more than one timer does.
+func (e *Engine) ResolveTimer(ctx context.Context, sessionID, ref string) (*domain.TimerState, error) {
+	active, err := e.ActiveTimers(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	ref = strings.TrimPrefix(ref, "#")
+	ref = strings.TrimSpace(strings.TrimPrefix(ref, "the "))
+	ref = strings.TrimSpace(strings.TrimSuffix(ref, " timer"))
+	if ref == "" {
+		return nil, fmt.Errorf("timer %q: %w", ref, domain.ErrNotFound)
+	}
+
+	for _, ts := range active {
+		if strings.EqualFold(ts.ID, ref) {
+			return ts, nil
+		}
+	}
+
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 || n > len(active) {
+			return nil, fmt.Errorf("timer %d: %w", n, domain.ErrNotFound)
+		}
+		return active[n-1], nil
+	}
+
+	for _, match := range []func(label string) bool{
+		func(label string) bool { return label == ref },
+		func(label string) bool { return strings.HasPrefix(label, ref) },
+		func(label string) bool { return strings.Contains(label, ref) },
+	} {
+		var found []*domain.TimerState
+		for _, ts := range active {
+			if match(strings.ToLower(ts.Label)) {
+				found = append(found, ts)
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		default:
+			return nil, fmt.Errorf("timer %q matches %d timers: %w", ref, len(found), domain.ErrAmbiguous)
+		}
+	}
+	return nil, fmt.Errorf("timer %q: %w", ref, domain.ErrNotFound)
+}
+
+// ── Timer control ────────────────────────────────────────────────
+//
+// Single timers can be paused, resumed, cancelled, or restarted without
+// pausing the whole session.  Cancelling is for a timer that's no longer
+// wanted; dismissing is acknowledging one that has gone off.
+
+// PauseTimer pauses one running timer.
+func (e *Engine) PauseTimer(ctx context.Context, sessionID, timerID string) error {
+	return e.updateTimer(ctx, sessionID, timerID, "pause", func(ts *domain.TimerState) (domain.TimerStatus, bool) {
+		return domain.TimerPaused, ts.Status == domain.TimerRunning
+	})
+}
+
+// ResumeTimer restarts the countdown of one paused timer.  Timers stay
+// paused while the session is.
+func (e *Engine) ResumeTimer(ctx context.Context, sessionID, timerID string) error {
+	return e.updateTimer(ctx, sessionID, timerID, "resume", func(ts *domain.TimerState) (domain.TimerStatus, bool) {
+		return domain.TimerRunning, ts.Status == domain.TimerPaused
+	})
+}
+
+// CancelTimer stops a timer that hasn't gone off yet, pending ones
+// included, without it ever firing.
+func (e *Engine) CancelTimer(ctx context.Context, sessionID, timerID string) error {
+	return e.updateTimer(ctx, sessionID, timerID, "cancel", func(ts *domain.TimerState) (domain.TimerStatus, bool) {
+		switch ts.Status {
+		case domain.TimerPending, domain.TimerRunning, domain.TimerPaused:
+			return domain.TimerCancelled, true
+		}
+		return ts.Status, false
+	})
+}
+
+// RestartTimer sets a timer back to its full duration and starts it,
+// whatever state it was in.
+func (e *Engine) RestartTimer(ctx context.Context, sessionID, timerID string) error {
+	return e.updateTimer(ctx, sessionID, timerID, "restart", func(ts *domain.TimerState) (domain.TimerStatus, bool) {
+		ts.Remaining = ts.Duration
+		ts.LastNotified = time.Time{}
+		ts.LastRemindedAt = time.Time{}
+		ts.WarnedAlmost = false
+		ts.EscalationLevel = 0
+		return domain.TimerRunning, true
+	})
+}
+
+// PauseAllTimers pauses every running timer and returns how many it
+// paused.  Unlike Pause, the session itself carries on.
+func (e *Engine) PauseAllTimers(ctx context.Context, sessionID string) (int, error) {
+	return e.switchTimers(ctx, sessionID, domain.TimerRunning, domain.TimerPaused)
+}
+
+// ResumeAllTimers resumes every paused timer and returns how many it
+// resumed.
+func (e *Engine) ResumeAllTimers(ctx context.Context, sessionID string) (int, error) {
+	return e.switchTimers(ctx, sessionID, domain.TimerPaused, domain.TimerRunning)
+}
+
+// updateTimer loads the session, applies change to one timer, and saves.
+// change returns the status the timer moves to, and false when its
+// state doesn't allow the action.  It leaves the status itself to
+// updateTimer, so the timeline sees the change.
+func (e *Engine) updateTimer(ctx context.Context, sessionID, timerID, action string, change func(*domain.TimerState) (domain.TimerStatus, bool)) error {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("loading session: %w", err)
+	}
+	if action != "cancel" && session.Status != domain.SessionActive {
+		return domain.ErrSessionNotActive
+	}
+
+	ts, ok := session.TimerStates[timerID]
+	if !ok {
+		return fmt.Errorf("timer %q: %w", timerID, domain.ErrNotFound)
+	}
+	to, ok := change(ts)
+	if !ok {
+		return fmt.Errorf("timer %q is %s, cannot %s", timerID, ts.Status, action)
+	}
+	now := e.clock.Now()
+	session.SetTimer(ts, to, now)
+	session.UpdatedAt = now
+
+	if err := e.save(ctx, session); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+
+	e.log.Info("%s timer %s (%s)", action, timerID, ts.Label)
+	return nil
+}
+
+// switchTimers moves every timer in status from to status to.
+func (e *Engine) switchTimers(ctx context.Context, sessionID string, from, to domain.TimerStatus) (int, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("loading session: %w", err)
+	}
+	if session.Status != domain.SessionActive {
+		return 0, domain.ErrSessionNotActive
+	}
+
+	now := e.clock.Now()
+	n := 0
+	for _, ts := range session.TimerStates {
+		if ts.Status == from {
+			session.SetTimer(ts, to, now)
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	session.UpdatedAt = now
+
+	if err := e.save(ctx, session); err != nil {
+		return 0, fmt.Errorf("saving session: %w", err)
+	}
+
+	e.log.Info("%d timers %s in session %s", n, to, sessionID)
+	return n, nil
+}
@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Variants ─────────────────────────────────────────────────────
+//
+// A variant is a full copy of a recipe saved under its own name, with
+// ParentID pointing back at the original.  Duplicating before asking the
+// AI for changes keeps the original intact — modifications are applied
+// to whichever recipe is selected or being cooked.
+
+// DuplicateRecipe copies a recipe into a new variant called name.  A
+// name that doesn't mention the original is prefixed with it, so "Mom's
+// version" of Chicken Alfredo becomes "Chicken Alfredo — Mom's version".
+// An empty name gives "<original> — copy".
+func (e *Engine) DuplicateRecipe(ctx context.Context, recipeID, name string) (*domain.Recipe, error) {
+	adder, ok := e.recipes.(RecipeAdder)
+	if !ok {
+		return nil, fmt.Errorf("recipe source does not support adding recipes")
+	}
+	parent, err := e.recipes.Get(ctx, recipeID)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "copy"
+	}
+	if !strings.Contains(strings.ToLower(name), strings.ToLower(parent.Name)) {
+		name = parent.Name + " — " + name
+	}
+
+	variant := copyRecipe(parent)
+	variant.Name = name
+	variant.ParentID = parent.ID
+	variant.Version = 0
+
+	// Pick the first free ID: "chicken-alfredo-moms-version", then -2, -3...
+	base := slugify(name)
+	for i := 1; ; i++ {
+		variant.ID = base
+		if i > 1 {
+			variant.ID = fmt.Sprintf("%s-%d", base, i)
+		}
+		if _, err := e.recipes.Get(ctx, variant.ID); err != nil {
+			break
+		}
+	}
+
+	if err := adder.Add(ctx, variant); err != nil {
+		return nil, fmt.Errorf("adding variant: %w", err)
+	}
+	e.log.Info("recipe %s duplicated as %s", parent.ID, variant.ID)
+	return variant, nil
+}
+
+// MoveSession points a session at a different recipe with the same steps
+// — used to carry on cooking a freshly duplicated variant, so changes
+// made mid-cook land on the variant rather than the original.
+func (e *Engine) MoveSession(ctx context.Context, sessionID, recipeID string) (*domain.Session, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("loading session: %w", err)
+	}
+	from, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return nil, fmt.Errorf("loading recipe: %w", err)
+	}
+	to, err := e.recipes.Get(ctx, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("loading recipe: %w", err)
+	}
+	if len(from.Steps) != len(to.Steps) {
+		return nil, fmt.Errorf("recipe %s has %d steps, session expects %d", recipeID, len(to.Steps), len(from.Steps))
+	}
+
+	session.RecipeID = to.ID
+	session.RecipeName = to.Name
+	session.UpdatedAt = e.clock.Now()
+	if err := e.save(ctx, session); err != nil {
+		return nil, fmt.Errorf("saving session: %w", err)
+	}
+	e.log.Info("session %s moved to recipe %s", sessionID, recipeID)
+	return session, nil
+}
+
+// copyRecipe deep-copies a recipe so edits to the copy never reach the
+// original's slices.
+func copyRecipe(r *domain.Recipe) *domain.Recipe {
+	c := *r
+	c.Ingredients = append([]domain.Ingredient(nil), r.Ingredients...)
+	c.Tags = append([]string(nil), r.Tags...)
+	c.Collections = append([]string(nil), r.Collections...)
+	c.Equipment = append([]string(nil), r.Equipment...)
+	c.Steps = make([]domain.Step, len(r.Steps))
+	for i, s := range r.Steps {
+		s.Conditions = append([]domain.StepCondition(nil), s.Conditions...)
+		s.ParallelHints = append([]string(nil), s.ParallelHints...)
+		s.Notes = append([]string(nil), s.Notes...)
+		s.Equipment = append([]string(nil), s.Equipment...)
+		s.Ingredients = append([]string(nil), s.Ingredients...)
+		if s.TimerConfig != nil {
+			tc := *s.TimerConfig
+			s.TimerConfig = &tc
+		}
+		c.Steps[i] = s
+	}
+	return &c
+}
+
+// slugify turns a recipe name into an ID: "Mom's Alfredo" -> "moms-alfredo".
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		case r == '\'' || r == '’':
+			// Drop apostrophes rather than splitting on them.
+		default:
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return "recipe"
+	}
+	return b.String()
+}
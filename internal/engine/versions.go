@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Versions ─────────────────────────────────────────────────────
+//
+// Every change made through ReviseRecipe keeps a snapshot of the recipe
+// before and after, with a note of what changed, so "versions" can show
+// the history and an older version can be brought back.  Restoring is
+// itself a revision: nothing is lost, and the newer version can be
+// restored again.  Tags, collections, and the name are labels rather
+// than content and stay as they are.
+
+// RecipeVersioner is an optional interface that RecipeSource
+// implementations can satisfy to keep version snapshots.
+type RecipeVersioner interface {
+	SaveVersion(ctx context.Context, recipeID string, v domain.RecipeVersion) error
+	Versions(ctx context.Context, recipeID string) ([]domain.RecipeVersion, error)
+}
+
+// ReviseRecipe applies change to the recipe and saves it as a new
+// version described by what.  When the source keeps versions, the
+// recipe as it was is snapshotted first (if it hasn't been already).
+func (e *Engine) ReviseRecipe(ctx context.Context, recipe *domain.Recipe, what string, change func(*domain.Recipe) error) error {
+	versioner, _ := e.recipes.(RecipeVersioner)
+	if versioner != nil {
+		if err := e.snapshotCurrent(ctx, versioner, recipe); err != nil {
+			return err
+		}
+	}
+
+	if err := change(recipe); err != nil {
+		return err
+	}
+	if err := e.UpdateRecipe(ctx, recipe); err != nil {
+		return err
+	}
+
+	if versioner != nil {
+		v := domain.RecipeVersion{Version: recipe.Version, SavedAt: e.clock.Now(), Change: what, Recipe: copyRecipe(recipe)}
+		if err := versioner.SaveVersion(ctx, recipe.ID, v); err != nil {
+			return fmt.Errorf("saving version: %w", err)
+		}
+	}
+	return nil
+}
+
+// snapshotCurrent records the recipe as it stands, unless its current
+// version is already on record.  Changes made outside ReviseRecipe
+// (notes kept, tags) bump the version without a snapshot; those are
+// recorded here as "other edits".
+func (e *Engine) snapshotCurrent(ctx context.Context, versioner RecipeVersioner, recipe *domain.Recipe) error {
+	history, err := versioner.Versions(ctx, recipe.ID)
+	if err != nil {
+		return fmt.Errorf("loading versions: %w", err)
+	}
+	if n := len(history); n > 0 && history[n-1].Version == recipe.Version {
+		return nil
+	}
+	what := "original"
+	if len(history) > 0 || recipe.Version > 1 {
+		what = "other edits"
+	}
+	v := domain.RecipeVersion{Version: recipe.Version, SavedAt: e.clock.Now(), Change: what, Recipe: copyRecipe(recipe)}
+	if err := versioner.SaveVersion(ctx, recipe.ID, v); err != nil {
+		return fmt.Errorf("saving version: %w", err)
+	}
+	return nil
+}
+
+// RecipeVersions returns the recorded versions of a recipe, oldest
+// first.  A recipe that has never been revised has just its current
+// version.
+func (e *Engine) RecipeVersions(ctx context.Context, recipeID string) ([]domain.RecipeVersion, error) {
+	recipe, err := e.recipes.Get(ctx, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+	current := domain.RecipeVersion{Version: recipe.Version, Change: "original", Recipe: recipe}
+	versioner, ok := e.recipes.(RecipeVersioner)
+	if !ok {
+		return []domain.RecipeVersion{current}, nil
+	}
+	history, err := versioner.Versions(ctx, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("loading versions: %w", err)
+	}
+	if n := len(history); n == 0 || history[n-1].Version != recipe.Version {
+		if n > 0 || recipe.Version > 1 {
+			current.Change = "other edits"
+		}
+		history = append(history, current)
+	}
+	return history, nil
+}
+
+// RestoreVersion brings back an older version's ingredients, steps, and
+// timings as a new version.  Returns domain.ErrNotFound for a version
+// that isn't on record.
+func (e *Engine) RestoreVersion(ctx context.Context, recipeID string, version int) (*domain.Recipe, error) {
+	history, err := e.RecipeVersions(ctx, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	var old *domain.Recipe
+	for _, v := range history {
+		if v.Version == version {
+			old = v.Recipe
+		}
+	}
+	if old == nil {
+		return nil, domain.ErrNotFound
+	}
+	recipe, err := e.recipes.Get(ctx, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("getting recipe: %w", err)
+	}
+
+	err = e.ReviseRecipe(ctx, recipe, fmt.Sprintf("restored version %d", version), func(r *domain.Recipe) error {
+		c := copyRecipe(old)
+		r.Description = c.Description
+		r.Servings = c.Servings
+		r.Ingredients = c.Ingredients
+		r.Steps = c.Steps
+		r.Equipment = c.Equipment
+		r.PrepTime, r.CookTime, r.Difficulty = c.PrepTime, c.CookTime, c.Difficulty
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.log.Info("recipe %s restored to version %d (now v%d)", recipeID, version, recipe.Version)
+	return recipe, nil
+}
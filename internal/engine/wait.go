@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Hands-off waits ──────────────────────────────────────────────
+//
+// A step with a Wait ("marinate 2 hours") doesn't need the cook, so
+// instead of sitting on it while the watcher asks whether everything's
+// okay, the session goes into SessionWaiting until a wall-clock time.
+// The timer supervisor wakes it when the time comes; a hibernating
+// store keeps it on disk in between, so the app can be closed.
+
+// StartWait begins the current step's wait.  Running timers are paused
+// for the duration.  Returns when the session will wake, or
+// domain.ErrNoWait if the current step has no wait.
+func (e *Engine) StartWait(ctx context.Context, sessionID string) (time.Time, error) {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("loading session: %w", err)
+	}
+	if session.Status != domain.SessionActive {
+		return time.Time{}, domain.ErrSessionNotActive
+	}
+	recipe, err := e.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("getting recipe: %w", err)
+	}
+	idx := session.CurrentStepIndex
+	if idx >= len(recipe.Steps) || recipe.Steps[idx].Wait <= 0 {
+		return time.Time{}, domain.ErrNoWait
+	}
+
+	now := e.clock.Now()
+	session.Status = domain.SessionWaiting
+	session.WaitUntil = now.Add(recipe.Steps[idx].Wait)
+	session.UpdatedAt = now
+	session.Record(now, domain.EventWaitStarted, "", "")
+	session.HoldTimers(now)
+
+	if err := e.save(ctx, session); err != nil {
+		return time.Time{}, fmt.Errorf("saving session: %w", err)
+	}
+	e.log.Info("session %s waiting until %s", sessionID, session.WaitUntil.Format(time.Kitchen))
+	return session.WaitUntil, nil
+}
+
+// EndWait wakes a waiting session, early or on time, and resumes its
+// timers.  The wait step stays current; Advance moves past it.
+func (e *Engine) EndWait(ctx context.Context, sessionID string) error {
+	e.sessions.Lock()
+	defer e.sessions.Unlock()
+	session, err := e.store.Load(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("loading session: %w", err)
+	}
+	if session.Status != domain.SessionWaiting {
+		return nil
+	}
+	session.Wake(e.clock.Now())
+	if err := e.save(ctx, session); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+	e.log.Info("session %s finished waiting", sessionID)
+	return nil
+}
@@ -0,0 +1,150 @@
+// Package gantt draws a cook as it actually went — the steps, each
+// timer's runs, the pauses and waits, the watcher's nudges — as a Gantt
+// chart on a time axis, so a long recipe can be looked back on and the
+// next attempt planned better.
+//
+// The chart is a standalone HTML page around an inline SVG: no scripts,
+// nothing fetched, and it opens in any browser.
+package gantt
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"time"
+)
+
+// Bar is one span on the chart.
+type Bar struct {
+	Lane   string // the row it's drawn on; rows are in order of first use
+	Label  string // written on the bar when it fits
+	Detail string // shown on hover
+	From   time.Time
+	To     time.Time // equal to From for a moment, drawn as a marker
+	Class  string    // colours it: step, skipped, timer, ringing, paused, waiting, nudge
+}
+
+// Chart is everything drawn.
+type Chart struct {
+	Title   string
+	Summary []string // lines under the title
+	Start   time.Time
+	End     time.Time
+	Bars    []Bar
+}
+
+// Layout, in pixels.
+const (
+	labelWidth = 180
+	plotWidth  = 900
+	rowHeight  = 28
+	barHeight  = 18
+	axisHeight = 30
+)
+
+// ticks are the spacings the axis picks from: the first that keeps it
+// to a dozen marks or fewer.
+var ticks = []time.Duration{
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute, 15 * time.Minute,
+	30 * time.Minute, time.Hour, 2 * time.Hour, 4 * time.Hour, 12 * time.Hour, 24 * time.Hour,
+}
+
+const style = `body{font-family:system-ui,sans-serif;margin:2em;color:#222}
+h1{font-size:1.4em;margin:0 0 .3em}
+ul{margin:0 0 1.5em;padding-left:1.2em;color:#555}
+svg text{font-size:12px}
+.lane{fill:#333}.tick{stroke:#ddd}.axis{fill:#888}
+.step{fill:#4c8bd6}.skipped{fill:#b8c7da}.timer{fill:#e39b2d}.ringing{fill:#d6453d}
+.paused{fill:#9a9a9a}.waiting{fill:#7bb67b}.nudge{fill:#8a5cc2}
+.bar text{fill:#fff;pointer-events:none}`
+
+// WriteHTML writes the chart as an HTML page.
+func WriteHTML(w io.Writer, c Chart) error {
+	bw := bufio.NewWriter(w)
+	esc := html.EscapeString
+
+	var lanes []string
+	row := make(map[string]int)
+	for _, b := range c.Bars {
+		if _, ok := row[b.Lane]; !ok {
+			row[b.Lane] = len(lanes)
+			lanes = append(lanes, b.Lane)
+		}
+	}
+
+	span := c.End.Sub(c.Start)
+	if span <= 0 {
+		span = time.Minute
+	}
+	x := func(t time.Time) float64 {
+		return labelWidth + float64(t.Sub(c.Start))/float64(span)*plotWidth
+	}
+	height := axisHeight + len(lanes)*rowHeight
+
+	fmt.Fprintf(bw, "<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>%s</title>\n<style>%s</style></head><body>\n", esc(c.Title), style)
+	fmt.Fprintf(bw, "<h1>%s</h1>\n", esc(c.Title))
+	if len(c.Summary) > 0 {
+		bw.WriteString("<ul>\n")
+		for _, s := range c.Summary {
+			fmt.Fprintf(bw, "<li>%s</li>\n", esc(s))
+		}
+		bw.WriteString("</ul>\n")
+	}
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\">\n", labelWidth+plotWidth+20, height)
+
+	// The axis: a mark every step, on the clock.
+	step := ticks[len(ticks)-1]
+	for _, d := range ticks {
+		if span/d <= 12 {
+			step = d
+			break
+		}
+	}
+	for t := c.Start.Truncate(step); !t.After(c.End); t = t.Add(step) {
+		if t.Before(c.Start) {
+			continue
+		}
+		fmt.Fprintf(bw, "<line class=\"tick\" x1=\"%.1f\" y1=\"%d\" x2=\"%.1f\" y2=\"%d\"/>\n", x(t), axisHeight-8, x(t), height)
+		fmt.Fprintf(bw, "<text class=\"axis\" x=\"%.1f\" y=\"%d\" text-anchor=\"middle\">%s</text>\n", x(t), axisHeight-12, t.Format("15:04"))
+	}
+
+	for i, l := range lanes {
+		fmt.Fprintf(bw, "<text class=\"lane\" x=\"0\" y=\"%d\">%s</text>\n", axisHeight+i*rowHeight+rowHeight/2+4, esc(l))
+	}
+
+	for _, b := range c.Bars {
+		top := axisHeight + row[b.Lane]*rowHeight + (rowHeight-barHeight)/2
+		title := b.Label
+		if b.Detail != "" {
+			title += ": " + b.Detail
+		}
+		title += fmt.Sprintf(" (%s", b.From.Format("15:04"))
+		if b.To.After(b.From) {
+			title += fmt.Sprintf("–%s, %s", b.To.Format("15:04"), b.To.Sub(b.From).Round(time.Second))
+		}
+		title += ")"
+
+		fmt.Fprintf(bw, "<g class=\"bar\"><title>%s</title>", esc(title))
+		if !b.To.After(b.From) {
+			cx, cy := x(b.From), top+barHeight/2
+			fmt.Fprintf(bw, "<polygon class=\"%s\" points=\"%.1f,%d %.1f,%d %.1f,%d %.1f,%d\"/>",
+				esc(b.Class), cx, cy-barHeight/2, cx+6, cy, cx, cy+barHeight/2, cx-6, cy)
+		} else {
+			x0, x1 := x(b.From), x(b.To)
+			fmt.Fprintf(bw, "<rect class=\"%s\" x=\"%.1f\" y=\"%d\" width=\"%.1f\" height=\"%d\" rx=\"3\"/>",
+				esc(b.Class), x0, top, max(x1-x0, 1), barHeight)
+			// Roughly 7px a character; leave the label off a bar too short for it.
+			if b.Label != "" && x1-x0 > float64(7*len(b.Label)+8) {
+				fmt.Fprintf(bw, "<text x=\"%.1f\" y=\"%d\">%s</text>", x0+4, top+barHeight-5, esc(b.Label))
+			}
+		}
+		bw.WriteString("</g>\n")
+	}
+
+	bw.WriteString("</svg>\n</body></html>\n")
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing timeline: %w", err)
+	}
+	return nil
+}
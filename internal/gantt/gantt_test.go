@@ -0,0 +1,52 @@
+package gantt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteHTML(t *testing.T) {
+	start := time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time { return start.Add(time.Duration(m) * time.Minute) }
+	var b strings.Builder
+	err := WriteHTML(&b, Chart{
+		Title:   "Chicken & rice",
+		Summary: []string{"50m from start to finish"},
+		Start:   start,
+		End:     at(50),
+		Bars: []Bar{
+			{Lane: "Steps", Label: "Step 1", Detail: "Sear the <chicken>", From: at(0), To: at(20), Class: "step"},
+			{Lane: "Rice timer", Label: "running", From: at(5), To: at(25), Class: "timer"},
+			{Lane: "Steps", Label: "Step 2", From: at(20), To: at(50), Class: "step"},
+			{Lane: "Watcher", Label: "nudge", Detail: "Still searing?", From: at(15), To: at(15), Class: "nudge"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"<title>Chicken &amp; rice</title>",
+		"<li>50m from start to finish</li>",
+		"Step 1: Sear the &lt;chicken&gt; (18:00–18:20, 20m0s)",
+		`<text class="axis" x="180.0" y="18" text-anchor="middle">18:00</text>`,
+		`<text class="axis" x="1080.0" y="18" text-anchor="middle">18:50</text>`,
+		`<rect class="timer" x="270.0" y="`,
+		"<polygon class=\"nudge\"",
+		"</svg>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	// Three lanes, in order of first use.
+	steps, timer, watcher := strings.Index(out, ">Steps<"), strings.Index(out, ">Rice timer<"), strings.Index(out, ">Watcher<")
+	if steps < 0 || !(steps < timer && timer < watcher) {
+		t.Errorf("lanes out of order: steps %d, timer %d, watcher %d", steps, timer, watcher)
+	}
+	if n := strings.Count(out, `class="lane"`); n != 3 {
+		t.Errorf("%d lanes, want 3", n)
+	}
+}
@@ -1,6 +1,10 @@
 package gpt
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // ActionType identifies what kind of recipe modification the AI wants to make.
 type ActionType string
@@ -14,6 +18,7 @@ const (
 	ActionAddStep          ActionType = "add_step"
 	ActionUpdateServings   ActionType = "update_servings"
 	ActionUpdateTimer      ActionType = "update_timer"
+	ActionRemoveEquipment  ActionType = "remove_equipment"
 )
 
 // ModifyResponse is the structured JSON the AI returns for modification
@@ -40,6 +45,7 @@ type Action struct {
 	// Step fields (update/add/remove)
 	StepIndex   int    `json:"step_index,omitempty"` // 1-based
 	Instruction string `json:"instruction,omitempty"`
+	Wait        string `json:"wait,omitempty"` // hands-off wait after the step, e.g. "2h"
 
 	// Timer fields
 	TimerLabel    string `json:"timer_label,omitempty"`
@@ -47,6 +53,74 @@ type Action struct {
 
 	// Servings
 	Servings int `json:"servings,omitempty"`
+
+	// Equipment
+	Equipment string `json:"equipment,omitempty"`
+}
+
+// String describes the action briefly, for version history and logs:
+// "swap margarine for butter", "servings to 4", "step 3 timer to 20m".
+func (a Action) String() string {
+	switch a.Type {
+	case ActionUpdateIngredient:
+		if a.NewIngredientName != "" {
+			return fmt.Sprintf("swap %s for %s", a.IngredientName, a.NewIngredientName)
+		}
+		return "change " + a.IngredientName
+	case ActionRemoveIngredient:
+		return "remove " + a.IngredientName
+	case ActionAddIngredient:
+		return "add " + a.IngredientName
+	case ActionUpdateStep:
+		return fmt.Sprintf("reword step %d", a.StepIndex)
+	case ActionRemoveStep:
+		return fmt.Sprintf("remove step %d", a.StepIndex)
+	case ActionAddStep:
+		return fmt.Sprintf("add step %d", a.StepIndex)
+	case ActionUpdateServings:
+		return fmt.Sprintf("servings to %d", a.Servings)
+	case ActionUpdateTimer:
+		return fmt.Sprintf("step %d timer to %s", a.StepIndex, a.TimerDuration)
+	case ActionRemoveEquipment:
+		return "no " + a.Equipment
+	}
+	return string(a.Type)
+}
+
+// DescribeActions joins the actions' descriptions: "servings to 4,
+// remove garlic".
+func DescribeActions(actions []Action) string {
+	parts := make([]string, len(actions))
+	for i, act := range actions {
+		parts[i] = act.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// AffectsTiming reports whether any of actions changes ingredients or
+// servings — the kind of change that can throw off step instructions and
+// timers (see Agent.Replan).
+func AffectsTiming(actions []Action) bool {
+	for _, act := range actions {
+		switch act.Type {
+		case ActionUpdateIngredient, ActionRemoveIngredient, ActionAddIngredient, ActionUpdateServings:
+			return true
+		}
+	}
+	return false
+}
+
+// parsedWait returns the step's hands-off wait, 0 when there is none, or
+// an error when the AI sent one that isn't a duration.
+func (a Action) parsedWait() (time.Duration, error) {
+	if a.Wait == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(a.Wait)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid wait: %q", a.Wait)
+	}
+	return d, nil
 }
 
 // ParsedTimerDuration returns the timer duration as time.Duration, or 0.
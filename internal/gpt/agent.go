@@ -3,10 +3,13 @@ package gpt
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/hammamikhairi/ottocook/internal/conversation"
 	"github.com/hammamikhairi/ottocook/internal/domain"
 	"github.com/hammamikhairi/ottocook/internal/logger"
 )
@@ -14,13 +17,95 @@ import (
 // Agent wraps the OpenAI Client with cooking-domain context building.
 // It is the single entry-point the CLI calls for AI-powered features.
 type Agent struct {
-	client *Client
-	log    *logger.Logger
+	client    *Client
+	log       *logger.Logger
+	prompts   Prompts
+	retriever Retriever // nil = only the current recipe is in context
+	tools     *ToolRegistry
+
+	contextBudget int // estimated tokens of context per call; 0 = no limit
+
+	mu       sync.Mutex
+	units    string   // preferred measurement system; "" = no preference
+	language string   // whisper code the cook last spoke in; "" = English
+	speaker  string   // who's talking, by voice; "" = unknown
+	diet     []string // the speaker's dietary needs
+}
+
+// Retriever finds recipes relevant to a question, best first.
+type Retriever interface {
+	Retrieve(ctx context.Context, query string, k int) ([]*domain.Recipe, error)
+}
+
+// libraryMatches is how many retrieved recipes go into a question's context.
+const libraryMatches = 3
+
+// AgentOption configures the Agent.
+type AgentOption func(*Agent)
+
+// WithPrompts replaces the built-in system prompts (see LoadPrompts).
+func WithPrompts(p Prompts) AgentOption {
+	return func(a *Agent) {
+		a.prompts = p
+	}
+}
+
+// WithRetriever lets AskQuestion pull matching recipes from the user's
+// whole library into context, not just the one being cooked.
+func WithRetriever(r Retriever) AgentOption {
+	return func(a *Agent) {
+		a.retriever = r
+	}
+}
+
+// WithTools replaces the built-in tool registry (see DefaultTools).
+func WithTools(r *ToolRegistry) AgentOption {
+	return func(a *Agent) {
+		a.tools = r
+	}
+}
+
+// EnvUnits names the env var holding the preferred measurement system.
+const EnvUnits = "OTTOCOOK_UNITS"
+
+// Measurement systems accepted by WithUnits.
+const (
+	UnitsMetric = "metric"
+	UnitsUS     = "us"
+)
+
+// WithUnits asks the model to give quantities in the user's preferred
+// measurement system (UnitsMetric or UnitsUS).  Anything else is ignored.
+func WithUnits(units string) AgentOption {
+	return func(a *Agent) {
+		a.SetUnits(units)
+	}
+}
+
+// SetUnits changes the measurement system (UnitsMetric or UnitsUS) from
+// the next call.  Anything else is ignored.
+func (a *Agent) SetUnits(units string) {
+	switch units = strings.ToLower(strings.TrimSpace(units)); units {
+	case UnitsMetric, UnitsUS:
+		a.mu.Lock()
+		a.units = units
+		a.mu.Unlock()
+	}
+}
+
+func (a *Agent) preferredUnits() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.units
 }
 
 // NewAgent creates a cooking AI agent backed by the given Client.
-func NewAgent(client *Client, log *logger.Logger) *Agent {
-	return &Agent{client: client, log: log}
+func NewAgent(client *Client, log *logger.Logger, opts ...AgentOption) *Agent {
+	a := &Agent{client: client, log: log, prompts: DefaultPrompts(), tools: NewToolRegistry(DefaultTools()...), contextBudget: DefaultContextBudget}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 // ── Public API ───────────────────────────────────────────────────
@@ -28,30 +113,94 @@ func NewAgent(client *Client, log *logger.Logger) *Agent {
 // AskQuestion sends a free-form question to the model together with the
 // full cooking context and returns the assistant's answer.
 func (a *Agent) AskQuestion(ctx context.Context, question string, recipe *domain.Recipe, session *domain.Session) (string, error) {
-	messages := a.buildMessages(PromptQuestion, question, recipe, session)
+	return a.client.Chat(ctx, a.questionMessages(ctx, a.prompts.Question, question, recipe, session))
+}
+
+// AskAgain re-asks a question whose answer the user said was wrong,
+// under the stricter retry prompt, with the rejected answer in view.
+func (a *Agent) AskAgain(ctx context.Context, question, rejected string, recipe *domain.Recipe, session *domain.Session) (string, error) {
+	messages := a.questionMessages(ctx, a.prompts.Question+"\n\n"+a.prompts.Retry, question, recipe, session)
+	messages = append(messages,
+		TextMessage(RoleAssistant, rejected),
+		TextMessage(RoleUser, "That's wrong. Try again."),
+	)
 	return a.client.Chat(ctx, messages)
 }
 
+// questionMessages builds the messages for a question, with the library
+// block when the question is about the user's recipes.
+func (a *Agent) questionMessages(ctx context.Context, systemPrompt, question string, recipe *domain.Recipe, session *domain.Session) []Message {
+	messages := a.buildMessages(systemPrompt, question, recipe, session)
+	if lib := a.libraryContext(ctx, question); lib != "" {
+		// Slot the library block in just before the question.
+		last := messages[len(messages)-1]
+		messages = append(messages[:len(messages)-1],
+			TextMessage(RoleUser, lib),
+			TextMessage(RoleAssistant, "Got it, I have those recipes."),
+			last,
+		)
+	}
+	return messages
+}
+
+// Pair asks the model what to drink with the recipe.  request is what
+// the user said, which may ask for something in particular ("a beer",
+// "no alcohol").
+func (a *Agent) Pair(ctx context.Context, request string, recipe *domain.Recipe, session *domain.Session) (string, error) {
+	return a.client.Chat(ctx, a.buildMessages(a.prompts.Pairing, request, recipe, session))
+}
+
 // Modify sends a modification request to the model and returns a structured
 // ModifyResponse containing actions to apply and a spoken summary.
 func (a *Agent) Modify(ctx context.Context, request string, recipe *domain.Recipe, session *domain.Session) (*ModifyResponse, error) {
-	messages := a.buildMessages(PromptModify, request, recipe, session)
-	raw, err := a.client.Chat(ctx, messages)
+	messages := a.editMessages(a.prompts.Modify, request, recipe, session)
+	var resp ModifyResponse
+	raw, err := a.chatJSON(ctx, messages, "modify", modifySchema, &resp)
+	if errors.Is(err, errSchema) {
+		a.log.Error("gpt: failed to parse modify JSON: %v\nraw: %s", err, raw)
+		// Fall back: treat the whole response as a spoken summary with no actions.
+		return &ModifyResponse{Summary: raw}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// Strip markdown code fences if the model wraps the JSON (common).
-	raw = stripCodeFence(raw)
+	a.log.Debug("gpt: modify response: %d actions, summary=%q", len(resp.Actions), truncate(resp.Summary, 80))
+	return &resp, nil
+}
 
+// Replan asks the model to review the recipe once the applied actions
+// have changed its ingredients or servings, and returns the step and timer changes it
+// proposes.  Any other kind of action it returns is dropped.
+func (a *Agent) Replan(ctx context.Context, applied []Action, recipe *domain.Recipe, session *domain.Session) (*ModifyResponse, error) {
+	changes, err := json.Marshal(applied)
+	if err != nil {
+		return nil, fmt.Errorf("encoding changes: %w", err)
+	}
+	messages := a.editMessages(a.prompts.Replan, "Changes just applied: "+string(changes), recipe, session)
 	var resp ModifyResponse
-	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
-		a.log.Error("gpt: failed to parse modify JSON: %v\nraw: %s", err, raw)
-		// Fall back: treat the whole response as a spoken summary with no actions.
-		return &ModifyResponse{Summary: raw}, nil
+	raw, err := a.chatJSON(ctx, messages, "replan", modifySchema, &resp)
+	if errors.Is(err, errSchema) {
+		// Unlike Modify there's nothing to say if it's garbled: the
+		// change itself has already been confirmed.
+		a.log.Error("gpt: failed to parse replan JSON: %v\nraw: %s", err, raw)
+		return &ModifyResponse{}, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	a.log.Debug("gpt: modify response: %d actions, summary=%q", len(resp.Actions), truncate(resp.Summary, 80))
+	kept := resp.Actions[:0]
+	for _, act := range resp.Actions {
+		if act.Type == ActionUpdateStep || act.Type == ActionUpdateTimer {
+			kept = append(kept, act)
+		} else {
+			a.log.Debug("gpt: replan dropped %s action", act.Type)
+		}
+	}
+	resp.Actions = kept
+
+	a.log.Debug("gpt: replan response: %d actions, summary=%q", len(resp.Actions), truncate(resp.Summary, 80))
 	return &resp, nil
 }
 
@@ -63,19 +212,16 @@ type DismissTimerResponse struct {
 
 // DismissTimer asks the model which timer(s) the user wants to dismiss.
 func (a *Agent) DismissTimer(ctx context.Context, request string, recipe *domain.Recipe, session *domain.Session) (*DismissTimerResponse, error) {
-	messages := a.buildMessages(PromptDismissTimer, request, recipe, session)
-	raw, err := a.client.Chat(ctx, messages)
-	if err != nil {
-		return nil, err
-	}
-
-	raw = stripCodeFence(raw)
-
+	messages := a.buildMessages(a.prompts.DismissTimer, request, recipe, session)
 	var resp DismissTimerResponse
-	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+	raw, err := a.chatJSON(ctx, messages, "dismiss_timer", dismissTimerSchema, &resp)
+	if errors.Is(err, errSchema) {
 		a.log.Error("gpt: failed to parse dismiss timer JSON: %v\nraw: %s", err, raw)
 		return &DismissTimerResponse{Summary: raw}, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	a.log.Debug("gpt: dismiss timer response: ids=%v, summary=%q", resp.TimerIDs, resp.Summary)
 	return &resp, nil
@@ -90,29 +236,78 @@ type classifyResponse struct {
 // Classify sends unrecognised user input to the model for intent classification.
 // Returns a classified Intent, or IntentUnknown if classification fails.
 func (a *Agent) Classify(ctx context.Context, input string, recipe *domain.Recipe, session *domain.Session) (*domain.Intent, error) {
-	messages := a.buildMessages(PromptClassify, input, recipe, session)
-	raw, err := a.client.Chat(ctx, messages)
-	if err != nil {
-		return nil, err
-	}
-
-	raw = stripCodeFence(raw)
-
+	messages := a.buildMessages(a.prompts.Classify+"\n\n"+a.tools.prompt(), input, recipe, session)
 	var resp classifyResponse
-	if err := json.Unmarshal([]byte(raw), &resp); err != nil {
+	raw, err := a.chatJSON(ctx, messages, "classify", a.tools.schema(), &resp)
+	if errors.Is(err, errSchema) {
 		a.log.Error("gpt: failed to parse classify JSON: %v\nraw: %s", err, raw)
 		return &domain.Intent{Type: domain.IntentUnknown, Payload: input}, nil
 	}
+	if err != nil {
+		return nil, err
+	}
 
-	intentType := domain.IntentFromString(resp.Intent)
-	a.log.Debug("gpt: classified %q -> %s (payload=%q)", input, intentType, resp.Payload)
+	tool, _ := a.tools.Lookup(resp.Intent)
+	intentType := tool.Intent
+	a.log.Debug("gpt: classified %q -> %s (payload=%q)", input, resp.Intent, resp.Payload)
 
 	payload := resp.Payload
-	if payload == "" {
+	// A list_recipes payload is a tag/collection filter; don't turn "what
+	// can we cook?" into one.
+	if payload == "" && intentType != domain.IntentListRecipes {
 		payload = input
 	}
+	if intentType == domain.IntentPlugin {
+		payload = tool.Name + ": " + payload
+	}
 
-	return &domain.Intent{Type: intentType, Payload: payload}, nil
+	return conversation.Structure(&domain.Intent{Type: intentType, Payload: payload}), nil
+}
+
+// RegisterTool makes another capability available to Classify, such as
+// a plugin's command.
+func (a *Agent) RegisterTool(t Tool) error {
+	return a.tools.Register(t)
+}
+
+// maxReasks is how many times a reply that fails schema validation is
+// sent back to the model for correction before giving up.
+const maxReasks = 2
+
+// chatJSON requests a reply constrained to schema, validates it, and
+// decodes it into out.  A reply that doesn't validate is sent back with
+// the validation error so the model can fix it.  On giving up, the error
+// wraps errSchema and raw is the last reply, for fallbacks.  Without
+// response_format the model only has the prompt to go on, which lets it
+// leave out fields that don't apply, so those may be missing.
+func (a *Agent) chatJSON(ctx context.Context, messages []Message, name string, schema Schema, out any) (raw string, err error) {
+	format := SchemaFormat(name, schema)
+	for attempt := 0; ; attempt++ {
+		raw, err = a.client.ChatFormat(ctx, messages, format)
+		if err != nil {
+			return "", err
+		}
+		// Strip markdown code fences if the model wraps the JSON (common
+		// when response_format isn't honoured).
+		raw = stripCodeFence(raw)
+
+		check := schema
+		if !a.client.SendsFormat() {
+			check = nullsOptional(schema)
+		}
+		verr := validateJSON(check, raw)
+		if verr == nil {
+			return raw, json.Unmarshal([]byte(raw), out)
+		}
+		if attempt == maxReasks {
+			return raw, verr
+		}
+		a.log.Debug("gpt: %s reply failed validation (attempt %d): %v", name, attempt+1, verr)
+		messages = append(messages,
+			TextMessage(RoleAssistant, raw),
+			TextMessage(RoleUser, fmt.Sprintf("That reply is invalid: %v. Respond again with only the corrected JSON object.", verr)),
+		)
+	}
 }
 
 // stripCodeFence removes ```json ... ``` wrappers that LLMs love to add.
@@ -136,12 +331,33 @@ func stripCodeFence(s string) string {
 // buildMessages assembles the system prompt, an optional cooking-context
 // user message, and the actual user query.
 func (a *Agent) buildMessages(systemPrompt, userQuery string, recipe *domain.Recipe, session *domain.Session) []Message {
+	return a.assembleMessages(systemPrompt, userQuery, a.buildContext(recipe, session, false))
+}
+
+// editMessages is buildMessages for a call whose answer rewrites the
+// recipe, so its steps and ingredients aren't trimmed to fit the budget.
+func (a *Agent) editMessages(systemPrompt, userQuery string, recipe *domain.Recipe, session *domain.Session) []Message {
+	return a.assembleMessages(systemPrompt, userQuery, a.buildContext(recipe, session, true))
+}
+
+// assembleMessages puts the system prompt with its instructions, the
+// context block when there is one, and the query together.
+func (a *Agent) assembleMessages(systemPrompt, userQuery, ctxBlock string) []Message {
+	if units := a.preferredUnits(); units != "" {
+		systemPrompt += "\n\n" + unitsInstruction(units)
+	}
+	if lang := a.spokenLanguage(); lang != "" {
+		systemPrompt += "\n\n" + languageInstruction(lang)
+	}
+	if who := a.speakerInstruction(); who != "" {
+		systemPrompt += "\n\n" + who
+	}
 	msgs := []Message{
 		TextMessage(RoleSystem, systemPrompt),
 	}
 
 	// Inject cooking context if available.
-	if ctxBlock := a.buildContext(recipe, session); ctxBlock != "" {
+	if ctxBlock != "" {
 		msgs = append(msgs, TextMessage(RoleUser, ctxBlock))
 		// Fake an ack so the model treats context as established.
 		msgs = append(msgs, TextMessage(RoleAssistant, "Got it, I have the context."))
@@ -151,51 +367,119 @@ func (a *Agent) buildMessages(systemPrompt, userQuery string, recipe *domain.Rec
 	return msgs
 }
 
-// buildContext serializes the current recipe and session state into a
-// plain-text block the model can reason over. Includes full timer state,
-// step progress, and current-step details so the model can give informed
-// answers about what's happening right now.
-func (a *Agent) buildContext(recipe *domain.Recipe, session *domain.Session) string {
-	if recipe == nil {
+// unitsInstruction tells the model which measurement system to answer in.
+func unitsInstruction(units string) string {
+	if units == UnitsUS {
+		return "The user measures in US customary units (cups, tablespoons, ounces, pounds, °F). Give quantities and temperatures in those units. The recipe context is already in them; when you change an ingredient's quantity, always give its unit too."
+	}
+	return "The user measures in metric units (grams, millilitres, °C). Give quantities and temperatures in those units. The recipe context is already in them; when you change an ingredient's quantity, always give its unit too."
+}
+
+// libraryContext lists the recipes in the library that best match the
+// question, or "" when there's no retriever or nothing matches.
+func (a *Agent) libraryContext(ctx context.Context, question string) string {
+	if a.retriever == nil {
+		return ""
+	}
+	matches, err := a.retriever.Retrieve(ctx, question, libraryMatches)
+	if err != nil {
+		a.log.Error("gpt: recipe retrieval failed: %v", err)
+		return ""
+	}
+	if len(matches) == 0 {
 		return ""
 	}
 
+	var b strings.Builder
+	b.WriteString("[Recipe Library — the user's saved recipes that best match this question]\n")
+	for _, r := range matches {
+		names := make([]string, len(r.Ingredients))
+		for i, ing := range r.Ingredients {
+			names[i] = ing.Name
+		}
+		fmt.Fprintf(&b, "- %s: %s\n  Ingredients: %s\n", r.Name, r.Description, strings.Join(names, ", "))
+		if len(r.Tags) > 0 {
+			fmt.Fprintf(&b, "  Tags: %s\n", strings.Join(r.Tags, ", "))
+		}
+	}
+	a.log.Debug("gpt: added %d library recipes to context", len(matches))
+	return b.String()
+}
+
+// ingredientText is one ingredient line of the context, in the units
+// preference.
+func (a *Agent) ingredientText(ing domain.Ingredient) string {
+	opt := ""
+	if ing.Prep != "" {
+		opt = ", " + ing.Prep
+	}
+	if ing.Optional {
+		opt += " (optional)"
+	}
+	if ing.Quantity <= 0 {
+		return ing.Name + opt
+	}
+	if ing.SizeDescriptor != "" {
+		return fmt.Sprintf("%s %s %s%s", domain.FormatQuantity(ing.Quantity), ing.SizeDescriptor, ing.Name, opt)
+	}
+	q, unit := localizeQuantity(ing.Quantity, ing.Unit, a.preferredUnits())
+	return fmt.Sprintf("%s %s %s%s", domain.FormatQuantity(q), unit, ing.Name, opt)
+}
+
+// renderContext serializes the current recipe and session state into a
+// plain-text block the model can reason over, at the level of detail d
+// allows (see buildContext).  Includes timer state, step progress, and
+// current-step details so the model can give informed answers about
+// what's happening right now.  With a units preference, quantities and
+// temperatures are given in that system (see units.go).
+func (a *Agent) renderContext(recipe *domain.Recipe, session *domain.Session, d contextDetail) string {
+	units := a.preferredUnits()
 	var b strings.Builder
 	b.WriteString("[Current Recipe Context]\n")
 	fmt.Fprintf(&b, "Recipe: %s\n", recipe.Name)
 	fmt.Fprintf(&b, "Description: %s\n", recipe.Description)
 	fmt.Fprintf(&b, "Servings: %d\n", recipe.Servings)
+	if recipe.PrepTime > 0 || recipe.CookTime > 0 {
+		fmt.Fprintf(&b, "Time: prep %s, cook %s\n", formatDuration(recipe.PrepTime), formatDuration(recipe.CookTime))
+	}
+	if d := recipe.Difficulty.String(); d != "" {
+		fmt.Fprintf(&b, "Difficulty: %s\n", d)
+	}
+	if len(recipe.Equipment) > 0 {
+		fmt.Fprintf(&b, "Equipment: %s\n", strings.Join(recipe.Equipment, ", "))
+	}
+
+	current := -1
+	if session != nil {
+		current = session.CurrentStepIndex
+	}
 
-	// Ingredients
+	// Ingredients — past the cap, the rest by name only.
 	b.WriteString("\nIngredients:\n")
-	for _, ing := range recipe.Ingredients {
-		opt := ""
-		if ing.Optional {
-			opt = " (optional)"
-		}
-		if ing.Quantity > 0 {
-			if ing.SizeDescriptor != "" {
-				fmt.Fprintf(&b, "- %.0f %s %s%s\n", ing.Quantity, ing.SizeDescriptor, ing.Name, opt)
-			} else {
-				fmt.Fprintf(&b, "- %.0f %s %s%s\n", ing.Quantity, ing.Unit, ing.Name, opt)
-			}
-		} else {
-			fmt.Fprintf(&b, "- %s%s\n", ing.Name, opt)
-		}
+	limit := d.ingredients
+	if d.edit {
+		limit = 0
+	}
+	listed, rest := capIngredients(recipe, current, limit)
+	for _, ing := range listed {
+		fmt.Fprintf(&b, "- %s\n", a.ingredientText(ing))
+	}
+	if len(rest) > 0 {
+		fmt.Fprintf(&b, "- also: %s\n", strings.Join(rest, ", "))
 	}
 
 	// Steps — show timer configs so the model knows which steps use timers.
 	b.WriteString("\nSteps:\n")
-	for _, step := range recipe.Steps {
-		fmt.Fprintf(&b, "%d. %s", step.Order, step.Instruction)
-		if step.TimerConfig != nil {
-			fmt.Fprintf(&b, " [has timer: %s, %s]", step.TimerConfig.Label, formatDuration(step.TimerConfig.Duration))
-		} else {
-			b.WriteString(" [no timer]")
-		}
-		b.WriteString("\n")
-		for _, c := range step.Conditions {
-			fmt.Fprintf(&b, "   condition: %s\n", c.Description)
+	for i, step := range recipe.Steps {
+		switch {
+		case d.fullSteps || d.edit || i == current+1:
+			a.writeStep(&b, step)
+		case i == current:
+			fmt.Fprintf(&b, "%d. (current step, see below)\n", step.Order)
+		case session != nil && finished(session, i):
+			fmt.Fprintf(&b, "%d. [%s] %s\n", step.Order, session.StepStates[i].Status, truncate(localizeText(step.Instruction, units), d.doneChars))
+		default:
+			fmt.Fprintf(&b, "%d. %s%s\n", step.Order, truncate(localizeText(step.Instruction, units), d.aheadChars), timerTag(step))
 		}
 	}
 
@@ -213,25 +497,38 @@ func (a *Agent) buildContext(recipe *domain.Recipe, session *domain.Session) str
 		if currentIdx >= 0 && currentIdx < totalSteps {
 			cur := recipe.Steps[currentIdx]
 			fmt.Fprintf(&b, "\n[Current Step Detail]\n")
-			fmt.Fprintf(&b, "Step %d: %s\n", cur.Order, cur.Instruction)
+			fmt.Fprintf(&b, "Step %d: %s\n", cur.Order, localizeText(cur.Instruction, units))
 			if cur.TimerConfig != nil {
 				fmt.Fprintf(&b, "This step has a timer: %s (%s)\n", cur.TimerConfig.Label, formatDuration(cur.TimerConfig.Duration))
 			} else {
 				b.WriteString("This step does NOT have a timer.\n")
 			}
 			for _, c := range cur.Conditions {
-				fmt.Fprintf(&b, "Done when: %s\n", c.Description)
+				fmt.Fprintf(&b, "Done when: %s\n", localizeText(c.Description, units))
+			}
+			if uses := recipe.StepIngredients(currentIdx); len(uses) > 0 {
+				b.WriteString("Ingredients this step uses:\n")
+				for _, ing := range uses {
+					fmt.Fprintf(&b, "- %s\n", a.ingredientText(ing))
+				}
+			}
+			if ss, ok := session.StepStates[currentIdx]; ok {
+				for _, n := range ss.Notes {
+					fmt.Fprintf(&b, "User's note on this step: %s\n", n)
+				}
 			}
 		}
 
-		// Step progress.
-		b.WriteString("\n[Step Progress]\n")
-		for i, step := range recipe.Steps {
-			status := "pending"
-			if ss, ok := session.StepStates[i]; ok {
-				status = ss.Status.String()
+		// Step progress.  Trimmed contexts mark it on the steps instead.
+		if d.fullSteps {
+			b.WriteString("\n[Step Progress]\n")
+			for i, step := range recipe.Steps {
+				status := "pending"
+				if ss, ok := session.StepStates[i]; ok {
+					status = ss.Status.String()
+				}
+				fmt.Fprintf(&b, "Step %d (%s): %s\n", step.Order, status, truncate(localizeText(step.Instruction, units), 50))
 			}
-			fmt.Fprintf(&b, "Step %d (%s): %s\n", step.Order, status, truncate(step.Instruction, 50))
 		}
 
 		// Timer state — explicit about presence/absence.
@@ -267,6 +564,28 @@ func (a *Agent) buildContext(recipe *domain.Recipe, session *domain.Session) str
 	return b.String()
 }
 
+// writeStep writes one step in full: instruction, timer, conditions,
+// equipment, and notes from earlier cooks.
+func (a *Agent) writeStep(b *strings.Builder, step domain.Step) {
+	units := a.preferredUnits()
+	fmt.Fprintf(b, "%d. %s", step.Order, localizeText(step.Instruction, units))
+	if step.TimerConfig != nil {
+		fmt.Fprintf(b, " [has timer: %s, %s]", step.TimerConfig.Label, formatDuration(step.TimerConfig.Duration))
+	} else {
+		b.WriteString(" [no timer]")
+	}
+	b.WriteString("\n")
+	for _, c := range step.Conditions {
+		fmt.Fprintf(b, "   condition: %s\n", localizeText(c.Description, units))
+	}
+	if len(step.Equipment) > 0 {
+		fmt.Fprintf(b, "   equipment: %s\n", strings.Join(step.Equipment, ", "))
+	}
+	for _, n := range step.Notes {
+		fmt.Fprintf(b, "   note from an earlier cook: %s\n", n)
+	}
+}
+
 func formatDuration(d time.Duration) string {
 	d = d.Round(time.Second)
 	if d < time.Minute {
@@ -37,6 +37,8 @@ func applyOne(r *domain.Recipe, act Action) error {
 		return updateServings(r, act)
 	case ActionUpdateTimer:
 		return updateTimer(r, act)
+	case ActionRemoveEquipment:
+		return removeEquipment(r, act)
 	default:
 		return fmt.Errorf("unknown action type: %s", act.Type)
 	}
@@ -61,6 +63,12 @@ func updateIngredient(r *domain.Recipe, act Action) error {
 		return fmt.Errorf("ingredient %q not found", act.IngredientName)
 	}
 	ing := &r.Ingredients[idx]
+	// The model sees quantities in the cook's units (see units.go), so a
+	// bare number for "2 cups" may well mean 500 ml.  Measured
+	// ingredients need the unit the new quantity is in.
+	if _, measured := measures[strings.ToLower(strings.TrimSpace(ing.Unit))]; measured && act.Quantity > 0 && act.Unit == "" {
+		return fmt.Errorf("new quantity for %q has no unit", ing.Name)
+	}
 	oldName := ing.Name
 	if act.NewIngredientName != "" {
 		ing.Name = act.NewIngredientName
@@ -68,6 +76,13 @@ func updateIngredient(r *domain.Recipe, act Action) error {
 		// instructions so the recipe stays consistent even if the
 		// AI forgot to emit update_step actions.
 		replaceInSteps(r, oldName, act.NewIngredientName)
+		for i := range r.Steps {
+			for j, n := range r.Steps[i].Ingredients {
+				if strings.EqualFold(n, oldName) {
+					r.Steps[i].Ingredients[j] = act.NewIngredientName
+				}
+			}
+		}
 	}
 	if act.Quantity > 0 {
 		ing.Quantity = act.Quantity
@@ -133,9 +148,16 @@ func updateStep(r *domain.Recipe, act Action) error {
 	if idx < 0 || idx >= len(r.Steps) {
 		return fmt.Errorf("step %d out of range (1-%d)", act.StepIndex, len(r.Steps))
 	}
+	wait, err := act.parsedWait()
+	if err != nil {
+		return err
+	}
 	if act.Instruction != "" {
 		r.Steps[idx].Instruction = act.Instruction
 	}
+	if wait > 0 {
+		r.Steps[idx].Wait = wait
+	}
 	return nil
 }
 
@@ -157,10 +179,15 @@ func addStep(r *domain.Recipe, act Action) error {
 	if idx < 0 || idx > len(r.Steps) {
 		idx = len(r.Steps) // append at end
 	}
+	wait, err := act.parsedWait()
+	if err != nil {
+		return err
+	}
 	newStep := domain.Step{
 		ID:          fmt.Sprintf("step-%d", len(r.Steps)+1),
 		Order:       idx + 1,
 		Instruction: act.Instruction,
+		Wait:        wait,
 	}
 	// Insert at position.
 	r.Steps = append(r.Steps, domain.Step{})
@@ -210,3 +237,35 @@ func updateTimer(r *domain.Recipe, act Action) error {
 	}
 	return nil
 }
+
+// ── Equipment ────────────────────────────────────────────────────
+
+// removeEquipment drops an item from the recipe and from every step that
+// lists it, once the steps have been reworked to do without it.
+func removeEquipment(r *domain.Recipe, act Action) error {
+	name := strings.ToLower(strings.TrimSpace(act.Equipment))
+	drop := func(items []string) ([]string, bool) {
+		var kept []string
+		found := false
+		for _, item := range items {
+			if strings.ToLower(item) == name {
+				found = true
+				continue
+			}
+			kept = append(kept, item)
+		}
+		return kept, found
+	}
+
+	var found bool
+	r.Equipment, found = drop(r.Equipment)
+	for i := range r.Steps {
+		var ok bool
+		r.Steps[i].Equipment, ok = drop(r.Steps[i].Equipment)
+		found = found || ok
+	}
+	if !found {
+		return fmt.Errorf("equipment %q not found", act.Equipment)
+	}
+	return nil
+}
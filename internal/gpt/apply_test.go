@@ -0,0 +1,68 @@
+package gpt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+func TestUpdateIngredientUnit(t *testing.T) {
+	tests := []struct {
+		name     string
+		act      Action
+		wantErr  bool
+		wantQ    float64
+		wantUnit string
+	}{
+		{"quantity and unit", Action{IngredientName: "milk", Quantity: 500, Unit: "ml"}, false, 500, "ml"},
+		{"bare quantity of a measure", Action{IngredientName: "milk", Quantity: 500}, true, 2, "cups"},
+		{"bare quantity of a count", Action{IngredientName: "garlic", Quantity: 4}, false, 4, "cloves"},
+		{"rename only", Action{IngredientName: "milk", NewIngredientName: "oat milk"}, false, 2, "cups"},
+	}
+	for _, tt := range tests {
+		r := &domain.Recipe{Ingredients: []domain.Ingredient{
+			{Name: "milk", Quantity: 2, Unit: "cups"},
+			{Name: "garlic", Quantity: 3, Unit: "cloves"},
+		}}
+		tt.act.Type = ActionUpdateIngredient
+		err := ApplyActions(r, []Action{tt.act})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		ing := r.Ingredients[findIngredient(r, tt.act.IngredientName)]
+		if ing.Quantity != tt.wantQ || ing.Unit != tt.wantUnit {
+			t.Errorf("%s: got %v %q, want %v %q", tt.name, ing.Quantity, ing.Unit, tt.wantQ, tt.wantUnit)
+		}
+	}
+}
+
+func TestStepWait(t *testing.T) {
+	tests := []struct {
+		name     string
+		act      Action
+		wantErr  bool
+		step     int // 0-based, in the result
+		wantWait time.Duration
+	}{
+		{"add with wait", Action{Type: ActionAddStep, StepIndex: 1, Instruction: "Marinate", Wait: "2h"}, false, 0, 2 * time.Hour},
+		{"add without wait", Action{Type: ActionAddStep, StepIndex: 1, Instruction: "Marinate"}, false, 0, 0},
+		{"add with bad wait", Action{Type: ActionAddStep, StepIndex: 1, Instruction: "Marinate", Wait: "overnight"}, true, 0, time.Hour}, // not added
+		{"update adds wait", Action{Type: ActionUpdateStep, StepIndex: 2, Wait: "30m"}, false, 1, 30 * time.Minute},
+		{"update keeps wait", Action{Type: ActionUpdateStep, StepIndex: 1, Instruction: "Chill the dough"}, false, 0, time.Hour},
+		{"update with bad wait", Action{Type: ActionUpdateStep, StepIndex: 1, Wait: "-5m"}, true, 0, time.Hour},
+	}
+	for _, tt := range tests {
+		r := &domain.Recipe{Steps: []domain.Step{
+			{ID: "s1", Order: 1, Instruction: "Chill", Wait: time.Hour},
+			{ID: "s2", Order: 2, Instruction: "Roll out"},
+		}}
+		err := ApplyActions(r, []Action{tt.act})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if got := r.Steps[tt.step].Wait; got != tt.wantWait {
+			t.Errorf("%s: step %d wait = %v, want %v", tt.name, tt.step+1, got, tt.wantWait)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package gpt
+
+import (
+	"slices"
+	"unicode/utf8"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Context budget ───────────────────────────────────────────────
+//
+// Every call sends the recipe and session along as context.  For a short
+// recipe that's everything, in full.  For a long one that gets slow and
+// expensive, and most of it is beside the point: the model needs the
+// step being cooked and the one after it, not the exact wording of
+// step 3 of 40.  So the context is rendered at decreasing levels of
+// detail until its estimated size fits the budget.  Every step and
+// every ingredient stays named at every level, so "remove the garlic"
+// or "change step 12" still has something to point at.
+//
+// Calls whose answer rewrites the recipe — modify and replan — are the
+// exception: the model can only rewrite what it has seen, so every step
+// and ingredient goes in full for them, and only the rest is trimmed.
+
+// DefaultContextBudget is the context size aimed for, in estimated
+// tokens.
+const DefaultContextBudget = 1500
+
+// contextDetail is one level of detail for renderContext.
+type contextDetail struct {
+	fullSteps   bool // every step in full, plus a progress table
+	doneChars   int  // characters kept of each finished step
+	aheadChars  int  // characters kept of each step after the next
+	ingredients int  // ingredients given in full; the rest by name (0 = all)
+	edit        bool // steps and ingredients in full at any level
+}
+
+// contextTiers are tried in order; the first that fits is used, and the
+// last is used regardless.  Below the first, only the current and next
+// steps are in full.
+var contextTiers = []contextDetail{
+	{fullSteps: true},
+	{doneChars: 50, aheadChars: 80},
+	{doneChars: 30, aheadChars: 40, ingredients: 15},
+	{doneChars: 20, aheadChars: 25, ingredients: 8},
+}
+
+// WithContextBudget sets roughly how many tokens of recipe and session
+// context go with each call.  0 sends everything in full.
+func WithContextBudget(tokens int) AgentOption {
+	return func(a *Agent) {
+		a.contextBudget = max(tokens, 0)
+	}
+}
+
+// estimateTokens guesses how many tokens text comes to: about four
+// characters each for English, which is close enough to budget by.
+func estimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// buildContext renders the recipe and session as context at the most
+// detail the budget allows, or "" without a recipe.  edit keeps every
+// step and ingredient in full for a call that rewrites them.
+func (a *Agent) buildContext(recipe *domain.Recipe, session *domain.Session, edit bool) string {
+	if recipe == nil {
+		return ""
+	}
+	var text string
+	for i, d := range contextTiers {
+		d.edit = edit
+		text = a.renderContext(recipe, session, d)
+		tokens := estimateTokens(text)
+		if a.contextBudget == 0 || tokens <= a.contextBudget || i == len(contextTiers)-1 {
+			if i > 0 {
+				a.log.Debug("gpt: context trimmed to level %d, ~%d tokens (budget %d)", i, tokens, a.contextBudget)
+			}
+			break
+		}
+	}
+	return text
+}
+
+// capIngredients splits the ingredients into those given in full and
+// the names of the rest, keeping recipe order.  Ingredients the current
+// and next steps use come first in line for the cap.
+func capIngredients(recipe *domain.Recipe, current, limit int) ([]domain.Ingredient, []string) {
+	if limit <= 0 || len(recipe.Ingredients) <= limit {
+		return recipe.Ingredients, nil
+	}
+	keep := make(map[string]bool, limit)
+	var wanted []string
+	for _, idx := range []int{current, current + 1} {
+		if idx >= 0 && idx < len(recipe.Steps) {
+			for _, ing := range recipe.StepIngredients(idx) {
+				wanted = append(wanted, ing.Name)
+			}
+		}
+	}
+	for _, ing := range recipe.Ingredients {
+		wanted = append(wanted, ing.Name)
+	}
+	for _, name := range wanted {
+		if len(keep) == limit {
+			break
+		}
+		keep[name] = true
+	}
+
+	var listed []domain.Ingredient
+	var rest []string
+	for _, ing := range recipe.Ingredients {
+		if keep[ing.Name] {
+			listed = append(listed, ing)
+		} else if !slices.Contains(rest, ing.Name) {
+			rest = append(rest, ing.Name)
+		}
+	}
+	return listed, rest
+}
+
+// finished reports whether step i of the session is done or skipped.
+func finished(session *domain.Session, i int) bool {
+	ss, ok := session.StepStates[i]
+	return ok && (ss.Status == domain.StepDone || ss.Status == domain.StepSkipped)
+}
+
+// timerTag is the short note on a trimmed step that it has a timer.
+func timerTag(step domain.Step) string {
+	if step.TimerConfig == nil {
+		return ""
+	}
+	return " [timer: " + formatDuration(step.TimerConfig.Duration) + "]"
+}
@@ -0,0 +1,84 @@
+package gpt
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// longRecipe has n steps, each using its own ingredient.
+func longRecipe(n int) *domain.Recipe {
+	r := &domain.Recipe{Name: "Feast", Description: "A long one.", Servings: 8}
+	for i := 1; i <= n; i++ {
+		name := fmt.Sprintf("spice%02d", i)
+		r.Ingredients = append(r.Ingredients, domain.Ingredient{Name: name, Quantity: 1, Unit: "teaspoon"})
+		r.Steps = append(r.Steps, domain.Step{
+			ID: fmt.Sprintf("s%d", i), Order: i,
+			Instruction: fmt.Sprintf("Toast the %s in a dry pan, stirring constantly so it browns evenly without scorching, then tip it into the mortar and grind it fine.", name),
+			Conditions:  []domain.StepCondition{{Description: "Fragrant and a shade darker"}},
+		})
+	}
+	return r
+}
+
+func TestContextBudget(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	recipe := longRecipe(40)
+	session := &domain.Session{CurrentStepIndex: 20, StepStates: map[int]*domain.StepState{}, StartedAt: time.Now()}
+	for i := range recipe.Steps {
+		st := domain.StepPending
+		if i < 20 {
+			st = domain.StepDone
+		}
+		session.StepStates[i] = &domain.StepState{Status: st}
+	}
+	session.StepStates[20].Status = domain.StepActive
+
+	full := NewAgent(nil, log, WithContextBudget(0)).buildContext(recipe, session, false)
+	trimmed := NewAgent(nil, log, WithContextBudget(1500)).buildContext(recipe, session, false)
+	if got := estimateTokens(trimmed); got > 1500 || got >= estimateTokens(full) {
+		t.Fatalf("trimmed context ~%d tokens (full ~%d), want it under the 1500 budget", got, estimateTokens(full))
+	}
+
+	// The current and next steps stay whole; the rest are still named.
+	for _, want := range []string{
+		"Step 21: Toast the spice21",
+		"22. Toast the spice22 in a dry pan, stirring constantly so it browns evenly without scorching, then tip it into the mortar and grind it fine.",
+		"- 1 teaspoon spice21",
+		"1. [done] Toast",
+		"40. Toast",
+	} {
+		if !strings.Contains(trimmed, want) {
+			t.Errorf("trimmed context lacks %q:\n%s", want, trimmed)
+		}
+	}
+	if !strings.Contains(trimmed, "spice01") || !strings.Contains(trimmed, "spice40") {
+		t.Errorf("trimmed context dropped an ingredient's name:\n%s", trimmed)
+	}
+
+	// A call that rewrites steps sees every one of them whole.
+	edit := NewAgent(nil, log, WithContextBudget(1500)).buildContext(recipe, session, true)
+	for _, want := range []string{
+		"1. Toast the spice01 in a dry pan, stirring constantly so it browns evenly without scorching, then tip it into the mortar and grind it fine.",
+		"21. Toast the spice21 in a dry pan, stirring constantly so it browns evenly without scorching, then tip it into the mortar and grind it fine.",
+		"40. Toast the spice40 in a dry pan, stirring constantly so it browns evenly without scorching, then tip it into the mortar and grind it fine.",
+		"- 1 teaspoon spice40",
+	} {
+		if !strings.Contains(edit, want) {
+			t.Errorf("edit context lacks %q", want)
+		}
+	}
+	if strings.Contains(edit, "[Step Progress]") {
+		t.Error("edit context kept the progress table over budget")
+	}
+
+	// A short recipe goes in full.
+	short := longRecipe(3)
+	if got := NewAgent(nil, log).buildContext(short, nil, false); !strings.Contains(got, "3. Toast the spice03 in a dry pan, stirring constantly") || !strings.Contains(got, "condition: Fragrant") {
+		t.Errorf("short recipe was trimmed:\n%s", got)
+	}
+}
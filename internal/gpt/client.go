@@ -7,12 +7,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sync/atomic"
 	"time"
 
 	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/metrics"
 )
 
 // ── Wire types ───────────────────────────────────────────────────
@@ -57,6 +61,27 @@ type payload struct {
 	TopP        float64   `json:"top_p"`
 	MaxTokens   int       `json:"max_tokens"`
 	Model       string    `json:"model,omitempty"`
+
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+}
+
+// ResponseFormat asks the endpoint to constrain its reply to JSON
+// matching a schema (OpenAI "structured outputs").
+type ResponseFormat struct {
+	Type       string      `json:"type"` // "json_schema"
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+// JSONSchema names a schema for ResponseFormat.
+type JSONSchema struct {
+	Name   string `json:"name"`
+	Schema Schema `json:"schema"`
+	Strict bool   `json:"strict"`
+}
+
+// SchemaFormat returns a strict json_schema response format.
+func SchemaFormat(name string, schema Schema) *ResponseFormat {
+	return &ResponseFormat{Type: "json_schema", JSONSchema: &JSONSchema{Name: name, Schema: schema, Strict: true}}
 }
 
 // apiResponse is the top-level response envelope.
@@ -96,6 +121,23 @@ func WithHTTPTimeout(d time.Duration) ClientOption {
 	return func(c *Client) { c.http.Timeout = d }
 }
 
+// WithHTTPClient replaces the HTTP client, e.g. to route requests
+// through a custom transport.  WithHTTPTimeout applies to whichever
+// client is set when it runs.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) { c.http = hc }
+}
+
+// WithMetrics records request latency and failures in the given registry.
+func WithMetrics(reg *metrics.Registry) ClientOption {
+	return func(c *Client) {
+		c.latency = reg.Histogram("ottocook_ai_request_seconds",
+			"Latency of chat-completion requests.", metrics.LatencyBuckets)
+		c.failures = reg.Counter("ottocook_ai_request_failures_total",
+			"Chat-completion requests that returned an error.")
+	}
+}
+
 // Client talks to an OpenAI-compatible chat-completions endpoint.
 type Client struct {
 	endpoint    string
@@ -106,6 +148,13 @@ type Client struct {
 	maxTokens   int
 	http        *http.Client
 	log         *logger.Logger
+
+	latency  *metrics.Histogram // nil when metrics are disabled
+	failures *metrics.Counter
+
+	// noFormat is set once the endpoint rejects response_format (older
+	// API versions do), after which it's no longer sent.
+	noFormat atomic.Bool
 }
 
 // NewClient creates an OpenAI chat client.
@@ -131,12 +180,48 @@ func NewClient(endpoint, apiKey string, log *logger.Logger, opts ...ClientOption
 
 // Chat sends a chat-completion request and returns the assistant's reply.
 func (c *Client) Chat(ctx context.Context, messages []Message) (string, error) {
+	return c.ChatFormat(ctx, messages, nil)
+}
+
+// ChatFormat is Chat with a response_format.  If the endpoint rejects
+// the format, the request is retried without it and the format is
+// dropped for the rest of the client's life; callers validate the
+// reply themselves either way.
+func (c *Client) ChatFormat(ctx context.Context, messages []Message, format *ResponseFormat) (string, error) {
+	start := time.Now()
+	if c.noFormat.Load() {
+		format = nil
+	}
+	reply, err := c.chat(ctx, messages, format)
+	if format != nil && errors.Is(err, errFormatRejected) {
+		c.log.Info("gpt: endpoint rejected response_format, falling back to plain JSON prompts")
+		c.noFormat.Store(true)
+		reply, err = c.chat(ctx, messages, nil)
+	}
+	c.latency.ObserveSince(start)
+	if err != nil {
+		c.failures.Inc()
+	}
+	return reply, err
+}
+
+// SendsFormat reports whether response_format is still being sent, i.e.
+// the endpoint hasn't rejected it.
+func (c *Client) SendsFormat() bool {
+	return !c.noFormat.Load()
+}
+
+// errFormatRejected marks a 400 complaining about response_format.
+var errFormatRejected = errors.New("response_format not supported")
+
+func (c *Client) chat(ctx context.Context, messages []Message, format *ResponseFormat) (string, error) {
 	body := payload{
-		Messages:    messages,
-		Temperature: c.temperature,
-		TopP:        c.topP,
-		MaxTokens:   c.maxTokens,
-		Model:       c.model,
+		Messages:       messages,
+		Temperature:    c.temperature,
+		TopP:           c.topP,
+		MaxTokens:      c.maxTokens,
+		Model:          c.model,
+		ResponseFormat: format,
 	}
 
 	jsonData, err := json.Marshal(body)
@@ -164,6 +249,9 @@ func (c *Client) Chat(ctx context.Context, messages []Message) (string, error) {
 		return "", fmt.Errorf("gpt: read response: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusBadRequest && format != nil && bytes.Contains(respBody, []byte("response_format")) {
+		return "", fmt.Errorf("gpt: API %s: %w", resp.Status, errFormatRejected)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("gpt: API %s\n%s", resp.Status, string(respBody))
 	}
@@ -182,6 +270,14 @@ func (c *Client) Chat(ctx context.Context, messages []Message) (string, error) {
 	return reply, nil
 }
 
+// IsUnavailable reports whether err means the endpoint couldn't be
+// reached at all (DNS, refused connection, timeout), as opposed to the
+// API answering with an error or something unusable.
+func IsUnavailable(err error) bool {
+	var uerr *url.Error
+	return errors.As(err, &uerr) && !errors.Is(err, context.Canceled)
+}
+
 func truncate(s string, n int) string {
 	if len(s) <= n {
 		return s
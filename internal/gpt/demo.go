@@ -0,0 +1,119 @@
+package gpt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// ── Demo ─────────────────────────────────────────────────────────
+//
+// The demo client never leaves the machine: its transport answers every
+// chat-completion request from a script, so a demo (or a screenshot
+// test) looks the same every time and needs no keys.  Questions get a
+// canned answer picked by keyword; classify, modify, replan, safety and
+// dismiss requests get well-formed JSON that changes nothing.
+
+// demoEndpoint is never dialled; it only has to parse.
+const demoEndpoint = "http://demo.invalid/chat/completions"
+
+// demoAnswers are tried in order against the question; the first whose
+// keyword appears wins.
+var demoAnswers = []struct{ keyword, answer string }{
+	{"substitut", "In a pinch, swap like for like: another hard cheese for parmesan, shallot for onion, any neutral oil for another."},
+	{"done", "Go by the cues in the step rather than the clock — colour, smell, and texture tell you more than the timer."},
+	{"salt", "Season a little at a time and taste as you go; you can always add more."},
+	{"how long", "The step's timer has the recipe's estimate. Check a minute or two early."},
+	{"burn", "Take the pan off the heat for a moment and turn it down. A little colour is fine; black and bitter is not."},
+}
+
+const demoDefaultAnswer = "Good question. In the demo I keep my answers short: follow the step, taste as you go, and trust your nose."
+
+// NewDemoClient returns a Client that answers from a script instead of
+// calling an endpoint.
+func NewDemoClient(log *logger.Logger) *Client {
+	return NewClient(demoEndpoint, "", log, WithHTTPClient(&http.Client{Transport: demoTransport{}}))
+}
+
+type demoTransport struct{}
+
+func (demoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body payload
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("demo: decoding request: %w", err)
+	}
+	req.Body.Close()
+
+	name := ""
+	if body.ResponseFormat != nil && body.ResponseFormat.JSONSchema != nil {
+		name = body.ResponseFormat.JSONSchema.Name
+	}
+	reply := demoReply(name, lastUserText(body.Messages))
+
+	var resp apiResponse
+	resp.Choices = make([]choice, 1)
+	resp.Choices[0].Message.Role = RoleAssistant
+	resp.Choices[0].Message.Content = reply
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}, nil
+}
+
+// demoReply is the scripted reply to a request of the named schema
+// ("" for a free-form question).
+func demoReply(name, input string) string {
+	switch name {
+	case "classify":
+		return mustJSON(classifyResponse{Intent: "ask_question", Payload: input})
+	case "modify":
+		return mustJSON(ModifyResponse{Actions: []Action{}, Summary: "Recipe changes are switched off in the demo, so I've left the recipe as it is."})
+	case "replan":
+		return mustJSON(ModifyResponse{Actions: []Action{}, Summary: ""})
+	case "safety":
+		return mustJSON(safetyResponse{Issues: []SafetyIssue{}})
+	case "dismiss_timer":
+		return mustJSON(DismissTimerResponse{TimerIDs: []string{}, Summary: "Say dismiss with the timer's number and I'll stop it."})
+	}
+	q := strings.ToLower(input)
+	for _, a := range demoAnswers {
+		if strings.Contains(q, a.keyword) {
+			return a.answer
+		}
+	}
+	return demoDefaultAnswer
+}
+
+func lastUserText(messages []Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role != RoleUser {
+			continue
+		}
+		for _, c := range messages[i].Content {
+			if c.Text != "" {
+				return c.Text
+			}
+		}
+	}
+	return ""
+}
+
+func mustJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,105 @@
+package gpt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ── Answer log ───────────────────────────────────────────────────
+//
+// Every answer the agent gives is appended to a JSONL event log, and so
+// is every "good answer" or "that's wrong" said about one, pointing back
+// at it by ID.  The log never rewrites a line, so it can be tailed, and
+// over time it's a local dataset of questions, answers, and verdicts to
+// tune the prompts against.
+//
+//	{"event":"answer","id":"1812c3f0a9b1e2d4","at":"…","kind":"question","request":"how long do I rest it?","answer":"…"}
+//	{"event":"feedback","id":"1812c3f0a9b1e2d4","at":"…","rating":"down"}
+//	{"event":"answer","id":"1812c3f1c0d2a7e9","at":"…","kind":"question","request":"…","answer":"…","retry_of":"1812c3f0a9b1e2d4"}
+
+// Ratings an answer can be given.
+const (
+	RatingUp   = "up"
+	RatingDown = "down"
+)
+
+// AnswerEvent is one line of the answer log.
+type AnswerEvent struct {
+	Event   string    `json:"event"` // "answer" or "feedback"
+	ID      string    `json:"id"`    // the answer's ID, on both kinds
+	At      time.Time `json:"at"`
+	Kind    string    `json:"kind,omitempty"`     // answer: which call made it ("question")
+	Request string    `json:"request,omitempty"`  // answer: what the user asked
+	Answer  string    `json:"answer,omitempty"`   // answer: what was said back
+	RetryOf string    `json:"retry_of,omitempty"` // answer: the rejected answer this replaces
+	Rating  string    `json:"rating,omitempty"`   // feedback: RatingUp or RatingDown
+}
+
+// AnswerLog appends answer and feedback events to a JSONL file.  A nil
+// *AnswerLog records nothing but still hands out IDs, so feedback works
+// the same with logging off.
+type AnswerLog struct {
+	mu   sync.Mutex
+	path string
+	last int64
+}
+
+// NewAnswerLog logs to path, creating it (and its directory) on the
+// first event.
+func NewAnswerLog(path string) *AnswerLog {
+	return &AnswerLog{path: path}
+}
+
+// Answer logs an answer and returns its ID.
+func (l *AnswerLog) Answer(kind, request, answer, retryOf string) (string, error) {
+	now := time.Now()
+	id := l.nextID(now)
+	return id, l.append(AnswerEvent{Event: "answer", ID: id, At: now, Kind: kind, Request: request, Answer: answer, RetryOf: retryOf})
+}
+
+// Feedback logs a rating of the answer with the given ID.
+func (l *AnswerLog) Feedback(id, rating string) error {
+	return l.append(AnswerEvent{Event: "feedback", ID: id, At: time.Now(), Rating: rating})
+}
+
+// nextID derives an ID from the clock, bumped past the last one so two
+// answers in the same nanosecond still differ.
+func (l *AnswerLog) nextID(now time.Time) string {
+	n := now.UnixNano()
+	if l != nil {
+		l.mu.Lock()
+		n = max(n, l.last+1)
+		l.last = n
+		l.mu.Unlock()
+	}
+	return fmt.Sprintf("%x", n)
+}
+
+func (l *AnswerLog) append(ev AnswerEvent) error {
+	if l == nil || l.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,84 @@
+package gpt
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// readEvents decodes every line of the answer log at path.
+func readEvents(t *testing.T, path string) []AnswerEvent {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening answer log: %v", err)
+	}
+	defer f.Close()
+	var events []AnswerEvent
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var ev AnswerEvent
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			t.Fatalf("line %q: %v", sc.Text(), err)
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+func TestAnswerLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "ai.jsonl")
+	l := NewAnswerLog(path)
+
+	first, err := l.Answer("question", "how long do I rest it?", "Ten minutes.", "")
+	if err != nil {
+		t.Fatalf("Answer: %v", err)
+	}
+	if err := l.Feedback(first, RatingDown); err != nil {
+		t.Fatalf("Feedback: %v", err)
+	}
+	retry, err := l.Answer("question", "how long do I rest it?", "Five minutes.", first)
+	if err != nil {
+		t.Fatalf("Answer: %v", err)
+	}
+	if err := l.Feedback(retry, RatingUp); err != nil {
+		t.Fatalf("Feedback: %v", err)
+	}
+	if first == retry {
+		t.Fatalf("two answers got the same ID %s", first)
+	}
+
+	want := []AnswerEvent{
+		{Event: "answer", ID: first, Kind: "question", Request: "how long do I rest it?", Answer: "Ten minutes."},
+		{Event: "feedback", ID: first, Rating: RatingDown},
+		{Event: "answer", ID: retry, Kind: "question", Request: "how long do I rest it?", Answer: "Five minutes.", RetryOf: first},
+		{Event: "feedback", ID: retry, Rating: RatingUp},
+	}
+	got := readEvents(t, path)
+	if len(got) != len(want) {
+		t.Fatalf("logged %d events, want %d: %+v", len(got), len(want), got)
+	}
+	for i, ev := range got {
+		if ev.At.IsZero() {
+			t.Errorf("event %d has no time", i)
+		}
+		ev.At = want[i].At
+		if ev != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, ev, want[i])
+		}
+	}
+}
+
+func TestAnswerLogOff(t *testing.T) {
+	for _, l := range []*AnswerLog{nil, NewAnswerLog("")} {
+		a, err := l.Answer("question", "q", "a", "")
+		if err != nil || a == "" {
+			t.Errorf("Answer = %q, %v; want an ID and no error", a, err)
+		}
+		if err := l.Feedback(a, RatingUp); err != nil {
+			t.Errorf("Feedback: %v", err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package gpt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ── Spoken language ──────────────────────────────────────────────
+//
+// In a bilingual kitchen the cook may ask in French one minute and in
+// English the next.  The ear reports the language each voice command
+// was transcribed in, and the agent tells the model, so replies come
+// back in the language the question was asked in.
+
+// languageNames spells out the whisper codes most kitchens will use.
+var languageNames = map[string]string{
+	"ar": "Arabic", "de": "German", "es": "Spanish", "fr": "French",
+	"hi": "Hindi", "it": "Italian", "ja": "Japanese", "ko": "Korean",
+	"nl": "Dutch", "pl": "Polish", "pt": "Portuguese", "ru": "Russian",
+	"sv": "Swedish", "tr": "Turkish", "uk": "Ukrainian", "zh": "Chinese",
+}
+
+// SetLanguage records the language (a whisper code such as "fr") the
+// cook last spoke in; the next calls ask the model to reply in it.
+// "" or "en" goes back to English.
+func (a *Agent) SetLanguage(lang string) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "en" || lang == "auto" {
+		lang = ""
+	}
+	a.mu.Lock()
+	a.language = lang
+	a.mu.Unlock()
+}
+
+func (a *Agent) spokenLanguage() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.language
+}
+
+// languageInstruction tells the model which language the cook spoke.
+func languageInstruction(lang string) string {
+	name, ok := languageNames[lang]
+	if !ok {
+		name = fmt.Sprintf("the language with code %q", lang)
+	}
+	return fmt.Sprintf("The user is speaking %s. Read their words as %s and write everything meant for them (answers, speech, reasons) in %s. Keep JSON keys, intent names and other fixed values in English.", name, name, name)
+}
@@ -0,0 +1,25 @@
+package gpt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+func TestSpokenLanguageInPrompt(t *testing.T) {
+	a := NewAgent(nil, logger.New(logger.LevelOff, nil))
+	system := func() string { return a.buildMessages("Be helpful.", "q", nil, nil)[0].Content[0].Text }
+
+	if strings.Contains(system(), "speaking") {
+		t.Fatalf("language instruction without a language:\n%s", system())
+	}
+	a.SetLanguage("FR")
+	if !strings.Contains(system(), "The user is speaking French.") {
+		t.Errorf("no French instruction:\n%s", system())
+	}
+	a.SetLanguage("en")
+	if strings.Contains(system(), "speaking") {
+		t.Errorf("instruction kept after switching back to English:\n%s", system())
+	}
+}
@@ -0,0 +1,199 @@
+package gpt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Safety ───────────────────────────────────────────────────────
+//
+// A model that rewrites steps will now and then write one that hurts
+// someone: raw chicken left on the counter all afternoon, water near a
+// pan of hot oil.  Before a change is applied, every step it writes goes
+// through a short list of rules.  A rule that blocks drops the action;
+// one that warns has the cook confirm first.  The rules only catch the
+// obvious; Agent.SafetyReview is an optional second opinion from the
+// model for what they miss.
+
+// SafetyIssue is a problem found in one proposed action.
+type SafetyIssue struct {
+	Action  int    `json:"action"`  // index into the actions checked
+	Message string `json:"message"` // what's wrong, short enough to say aloud
+	Block   bool   `json:"block"`   // too dangerous to apply; otherwise a warning
+}
+
+// safetyRule flags a step whose text matches every pattern in all and
+// none in unless.  A rule with held set is about how long something is
+// left: it also needs a clause matching held that, with the step's
+// timer, lasts at least minHours.
+type safetyRule struct {
+	all      []*regexp.Regexp
+	unless   *regexp.Regexp
+	held     *regexp.Regexp
+	minHours float64
+	message  string
+	block    bool
+}
+
+var safetyRules = []safetyRule{
+	{
+		all: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\b(?:chicken|poultry|turkey|duck|pork|beef|lamb|mince|meat|fish|seafood|shrimp|prawns?|eggs?|cooked rice|milk|cream)\b`),
+		},
+		held:     regexp.MustCompile(`(?i)\b(?:room temperature|on the (?:counter|bench|worktop)|countertop|unrefrigerated|out of the fridge|(?:leave|left|sit|set)(?: \w+){0,2} out)\b`),
+		minHours: 2,
+		message:  "Perishable food shouldn't sit out for more than two hours. Keep it in the fridge.",
+		block:    true,
+	},
+	{
+		all: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\bwater\b[^.]*\b(?:(?:oil|grease|fat) fire|(?:burning|flaming|smoking|boiling|hot) (?:oil|fat))\b|\b(?:(?:oil|grease|fat) fire|(?:burning|flaming|smoking|boiling|hot) (?:oil|fat))\b[^.]*\bwater\b`),
+		},
+		unless:  regexp.MustCompile(`(?i)\b(?:never|don'?t|do not|avoid|dry)\b`),
+		message: "Never let water near hot or burning oil. Smother an oil fire with a lid and turn off the heat.",
+		block:   true,
+	},
+	{
+		all: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\b(?:chicken|turkey|poultry)\b[^.]*\b(?:rare|medium[- ]rare|pink (?:in|at) the (?:middle|centre|center)|still pink|slightly pink)\b`),
+		},
+		unless:  regexp.MustCompile(`(?i)\b(?:no longer|not|no|isn'?t|never)\b[^.]*\bpink\b|juices run clear`),
+		message: "Chicken and turkey have to be cooked through, to 74°C (165°F), not left pink.",
+		block:   true,
+	},
+	{
+		all: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\b(?:deep[- ]?fr(?:y|ies|ied)|hot oil|smoking oil|heat (?:the )?oil to|oil (?:to|at|reaches) (?:1[6-9]\d|2[0-4]\d) ?°? ?c\b|oil (?:to|at|reaches) (?:3[2-9]\d|4[0-7]\d) ?°? ?f\b)`),
+		},
+		unless:  regexp.MustCompile(`(?i)\b(?:careful|carefully|caution|splatter|spatter|away from you|never leave|don'?t leave|do not leave|dry|thermometer|gently lower)\b`),
+		message: "That step uses hot oil without a word of care. Dry the food, lower it in away from you, and never leave the pan.",
+	},
+	{
+		all: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\b(?:foil|metal|aluminium|aluminum)\b[^.]*\bmicrowave|\bmicrowave\b[^.]*\b(?:foil|metal|aluminium|aluminum)\b`),
+		},
+		unless:  regexp.MustCompile(`(?i)\b(?:no|never|not|don'?t|remove|without)\b`),
+		message: "Metal and foil spark in a microwave.",
+	},
+}
+
+var (
+	holdHours   = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(?:hours?|hrs?)\b`)
+	holdMinutes = regexp.MustCompile(`(?i)(\d+)\s*(?:minutes?|mins?)\b`)
+	overnight   = regexp.MustCompile(`(?i)\b(?:overnight|all day|all afternoon)\b`)
+	// clauseBreak splits a step into the parts a hold time belongs to:
+	// "Refrigerate for 2 hours, then leave out 10 minutes" is two.
+	clauseBreak = regexp.MustCompile(`(?i)[;!?]|\.(?:\s|$)|,?\s*\b(?:then|before|after)\b`)
+)
+
+// ScreenActions checks the steps the actions would write against the
+// safety rules.  A timer change is checked against the instruction of
+// the step it times.
+func ScreenActions(recipe *domain.Recipe, actions []Action) []SafetyIssue {
+	var issues []SafetyIssue
+	for i, act := range actions {
+		text, timer := actionText(recipe, act)
+		if text == "" {
+			continue
+		}
+		for _, r := range safetyRules {
+			if r.matches(text, timer) {
+				issues = append(issues, SafetyIssue{Action: i, Message: r.message, Block: r.block})
+			}
+		}
+	}
+	return issues
+}
+
+// actionText is the step text an action would leave behind and any
+// timer it sets.
+func actionText(recipe *domain.Recipe, act Action) (string, time.Duration) {
+	switch act.Type {
+	case ActionAddStep, ActionUpdateStep:
+		return act.Instruction, act.ParsedTimerDuration()
+	case ActionUpdateTimer:
+		if recipe != nil && act.StepIndex >= 1 && act.StepIndex <= len(recipe.Steps) {
+			return recipe.Steps[act.StepIndex-1].Instruction, act.ParsedTimerDuration()
+		}
+	}
+	return "", 0
+}
+
+func (r safetyRule) matches(text string, timer time.Duration) bool {
+	for _, re := range r.all {
+		if !re.MatchString(text) {
+			return false
+		}
+	}
+	if r.unless != nil && r.unless.MatchString(text) {
+		return false
+	}
+	if r.held == nil {
+		return true
+	}
+	// Only the times said of the holding itself count, not "chill for
+	// 2 hours" elsewhere in the step.
+	for _, clause := range clauseBreak.Split(text, -1) {
+		if r.held.MatchString(clause) && max(holdTime(clause), timer).Hours() >= r.minHours {
+			return true
+		}
+	}
+	return false
+}
+
+// holdTime is the longest time the text mentions ("2-3 hours" is 3),
+// with "overnight" as eight hours.
+func holdTime(text string) time.Duration {
+	var longest time.Duration
+	if overnight.MatchString(text) {
+		longest = 8 * time.Hour
+	}
+	for _, m := range holdHours.FindAllStringSubmatch(text, -1) {
+		h, _ := strconv.ParseFloat(m[1], 64)
+		longest = max(longest, time.Duration(h*float64(time.Hour)))
+	}
+	for _, m := range holdMinutes.FindAllStringSubmatch(text, -1) {
+		n, _ := strconv.Atoi(m[1])
+		longest = max(longest, time.Duration(n)*time.Minute)
+	}
+	return longest
+}
+
+type safetyResponse struct {
+	Issues []SafetyIssue `json:"issues"`
+}
+
+// SafetyReview asks the model whether the actions would make the recipe
+// unsafe, for what the rules don't catch.  A reply that can't be
+// understood is logged and counts as no issues.
+func (a *Agent) SafetyReview(ctx context.Context, actions []Action, recipe *domain.Recipe, session *domain.Session) ([]SafetyIssue, error) {
+	changes, err := json.Marshal(actions)
+	if err != nil {
+		return nil, fmt.Errorf("encoding changes: %w", err)
+	}
+	messages := a.buildMessages(a.prompts.Safety, "Proposed changes: "+string(changes), recipe, session)
+	var resp safetyResponse
+	raw, err := a.chatJSON(ctx, messages, "safety", safetySchema, &resp)
+	if errors.Is(err, errSchema) {
+		a.log.Error("gpt: failed to parse safety JSON: %v\nraw: %s", err, raw)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	issues := resp.Issues[:0]
+	for _, is := range resp.Issues {
+		if is.Action >= 0 && is.Action < len(actions) && is.Message != "" {
+			issues = append(issues, is)
+		}
+	}
+	return issues, nil
+}
@@ -0,0 +1,46 @@
+package gpt
+
+import (
+	"testing"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+func TestScreenActions(t *testing.T) {
+	recipe := &domain.Recipe{Steps: []domain.Step{
+		{Instruction: "Leave the marinated chicken on the counter to come to room temperature."},
+	}}
+	tests := []struct {
+		act       Action
+		wantIssue bool
+		wantBlock bool
+	}{
+		{Action{Type: ActionUpdateStep, Instruction: "Let the raw chicken rest at room temperature for 4 hours."}, true, true},
+		{Action{Type: ActionUpdateStep, Instruction: "Take the chicken out of the fridge 30 minutes ahead so it's at room temperature."}, false, false},
+		{Action{Type: ActionAddStep, Instruction: "Leave the cooked rice out on the counter overnight."}, true, true},
+		{Action{Type: ActionUpdateTimer, StepIndex: 1, TimerDuration: "3h"}, true, true},
+		{Action{Type: ActionUpdateTimer, StepIndex: 1, TimerDuration: "20m"}, false, false},
+		{Action{Type: ActionUpdateStep, Instruction: "Sear the chicken, leaving it slightly pink in the middle."}, true, true},
+		{Action{Type: ActionUpdateStep, Instruction: "Cook the chicken until it's no longer pink in the middle."}, false, false},
+		{Action{Type: ActionAddStep, Instruction: "If the pan catches, throw water on the oil fire."}, true, true},
+		{Action{Type: ActionAddStep, Instruction: "Deep fry the fritters until golden."}, true, false},
+		{Action{Type: ActionAddStep, Instruction: "Pat the fritters dry and deep fry them, lowering them in away from you."}, false, false},
+		{Action{Type: ActionAddIngredient, IngredientName: "raw chicken"}, false, false},
+		// The hold time is the one said of leaving it out.
+		{Action{Type: ActionUpdateStep, Instruction: "Refrigerate the chicken for 2 hours, then leave it out 10 minutes."}, false, false},
+		{Action{Type: ActionUpdateStep, Instruction: "Leave the chicken out 10 minutes before you refrigerate it for 4 hours."}, false, false},
+		{Action{Type: ActionUpdateStep, Instruction: "Brown the pork for 10 minutes, then leave it on the counter for 3 hours."}, true, true},
+		{Action{Type: ActionUpdateStep, Instruction: "Sear the beef for 5 minutes. Let it sit out for 2.5 hours."}, true, true},
+	}
+
+	for _, tt := range tests {
+		issues := ScreenActions(recipe, []Action{tt.act})
+		if got := len(issues) > 0; got != tt.wantIssue {
+			t.Errorf("%s %q: issue=%v, want %v (%+v)", tt.act.Type, tt.act.Instruction, got, tt.wantIssue, issues)
+			continue
+		}
+		if tt.wantIssue && issues[0].Block != tt.wantBlock {
+			t.Errorf("%s %q: block=%v, want %v", tt.act.Type, tt.act.Instruction, issues[0].Block, tt.wantBlock)
+		}
+	}
+}
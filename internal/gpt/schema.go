@@ -0,0 +1,246 @@
+package gpt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ── Structured output schemas ────────────────────────────────────
+//
+// The JSON-returning calls (Modify, DismissTimer, Classify, SafetyReview) send a JSON
+// schema as response_format so the provider constrains the reply, and
+// validate the reply against the same schema on our side — not every
+// deployment honours response_format, and the ones that do still
+// occasionally get it wrong.  Where response_format was dropped, fields
+// that may be null may also be missing, as the prompts allow.  Only the
+// subset of JSON Schema these schemas use is supported: type (single or
+// list), properties, required, additionalProperties: false, items, and
+// enum.
+
+// Schema is a JSON Schema document.
+type Schema = map[string]any
+
+// errSchema wraps every validation failure so callers can tell a bad
+// reply from a failed request.
+var errSchema = errors.New("reply does not match schema")
+
+// nullable returns a schema type that also accepts null.  Strict mode
+// requires every property to be listed in "required", so optional
+// fields are expressed as nullable instead.
+func nullable(typ string) []any { return []any{typ, "null"} }
+
+// nullsOptional returns a copy of s in which required fields that may be
+// null may also be left out, as the prompts allow.  It's what a reply is
+// checked against when response_format wasn't sent.
+func nullsOptional(s Schema) Schema {
+	out := make(Schema, len(s))
+	for k, v := range s {
+		out[k] = v
+	}
+	if props, ok := s["properties"].(Schema); ok {
+		sub := make(Schema, len(props))
+		for k, p := range props {
+			sub[k] = nullsOptional(p.(Schema))
+		}
+		out["properties"] = sub
+		if req, ok := s["required"].([]any); ok {
+			var keep []any
+			for _, r := range req {
+				if p, ok := props[r.(string)].(Schema); !ok || !matchesType(p["type"], nil) {
+					keep = append(keep, r)
+				}
+			}
+			out["required"] = keep
+		}
+	}
+	if items, ok := s["items"].(Schema); ok {
+		out["items"] = nullsOptional(items)
+	}
+	return out
+}
+
+var modifySchema = Schema{
+	"type": "object",
+	"properties": Schema{
+		"actions": Schema{
+			"type": "array",
+			"items": Schema{
+				"type": "object",
+				"properties": Schema{
+					"type": Schema{"type": "string", "enum": []any{
+						string(ActionUpdateIngredient), string(ActionRemoveIngredient), string(ActionAddIngredient),
+						string(ActionUpdateStep), string(ActionRemoveStep), string(ActionAddStep),
+						string(ActionUpdateServings), string(ActionUpdateTimer), string(ActionRemoveEquipment),
+					}},
+					"ingredient_name":     Schema{"type": nullable("string")},
+					"new_ingredient_name": Schema{"type": nullable("string")},
+					"quantity":            Schema{"type": nullable("number")},
+					"unit":                Schema{"type": nullable("string")},
+					"size_descriptor":     Schema{"type": nullable("string")},
+					"step_index":          Schema{"type": nullable("integer")},
+					"instruction":         Schema{"type": nullable("string")},
+					"wait":                Schema{"type": nullable("string")},
+					"timer_label":         Schema{"type": nullable("string")},
+					"timer_duration":      Schema{"type": nullable("string")},
+					"servings":            Schema{"type": nullable("integer")},
+					"equipment":           Schema{"type": nullable("string")},
+				},
+				"required": []any{
+					"type", "ingredient_name", "new_ingredient_name", "quantity", "unit", "size_descriptor",
+					"step_index", "instruction", "wait", "timer_label", "timer_duration", "servings", "equipment",
+				},
+				"additionalProperties": false,
+			},
+		},
+		"summary": Schema{"type": "string"},
+	},
+	"required":             []any{"actions", "summary"},
+	"additionalProperties": false,
+}
+
+var dismissTimerSchema = Schema{
+	"type": "object",
+	"properties": Schema{
+		"timer_ids": Schema{"type": "array", "items": Schema{"type": "string"}},
+		"summary":   Schema{"type": "string"},
+	},
+	"required":             []any{"timer_ids", "summary"},
+	"additionalProperties": false,
+}
+
+var safetySchema = Schema{
+	"type": "object",
+	"properties": Schema{
+		"issues": Schema{
+			"type": "array",
+			"items": Schema{
+				"type": "object",
+				"properties": Schema{
+					"action":  Schema{"type": "integer"},
+					"message": Schema{"type": "string"},
+					"block":   Schema{"type": "boolean"},
+				},
+				"required":             []any{"action", "message", "block"},
+				"additionalProperties": false,
+			},
+		},
+	},
+	"required":             []any{"issues"},
+	"additionalProperties": false,
+}
+
+// classifySchema is the classify schema with only the built-in tools;
+// an agent's own comes from its registry.
+var classifySchema = NewToolRegistry(DefaultTools()...).schema()
+
+// validateJSON decodes raw and checks it against schema.
+func validateJSON(schema Schema, raw string) error {
+	var v any
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return fmt.Errorf("%w: not valid JSON: %v", errSchema, err)
+	}
+	if err := validate(schema, v, "$"); err != nil {
+		return fmt.Errorf("%w: %v", errSchema, err)
+	}
+	return nil
+}
+
+func validate(s Schema, v any, path string) error {
+	if t, ok := s["type"]; ok && !matchesType(t, v) {
+		return fmt.Errorf("%s: expected %v, got %s", path, t, jsonType(v))
+	}
+	if enum, ok := s["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			if e == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, v, enum)
+		}
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		props, _ := s["properties"].(Schema)
+		if req, ok := s["required"].([]any); ok {
+			for _, r := range req {
+				if _, present := val[r.(string)]; !present {
+					return fmt.Errorf("%s: missing required field %q", path, r)
+				}
+			}
+		}
+		if s["additionalProperties"] == false {
+			for k := range val {
+				if _, known := props[k]; !known {
+					return fmt.Errorf("%s: unexpected field %q", path, k)
+				}
+			}
+		}
+		for k, sub := range props {
+			if fv, present := val[k]; present {
+				if err := validate(sub.(Schema), fv, path+"."+k); err != nil {
+					return err
+				}
+			}
+		}
+	case []any:
+		if items, ok := s["items"].(Schema); ok {
+			for i, item := range val {
+				if err := validate(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func matchesType(t any, v any) bool {
+	switch t := t.(type) {
+	case string:
+		return typeIs(t, v)
+	case []any:
+		for _, alt := range t {
+			if s, ok := alt.(string); ok && typeIs(s, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeIs(t string, v any) bool {
+	switch t {
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == float64(int64(f))
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	default:
+		return jsonType(v) == t
+	}
+}
+
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
@@ -0,0 +1,220 @@
+package gpt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+func TestValidateJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema Schema
+		raw    string
+		ok     bool
+	}{
+		{"dismiss ok", dismissTimerSchema, `{"timer_ids":["timer-step-1"],"summary":"Done."}`, true},
+		{"dismiss missing field", dismissTimerSchema, `{"timer_ids":[]}`, false},
+		{"dismiss extra field", dismissTimerSchema, `{"timer_ids":[],"summary":"","all":true}`, false},
+		{"dismiss wrong item type", dismissTimerSchema, `{"timer_ids":[1],"summary":""}`, false},
+		{"classify ok", classifySchema, `{"intent":"advance","payload":""}`, true},
+		{"classify unknown intent", classifySchema, `{"intent":"dance","payload":""}`, false},
+		{"not json", classifySchema, `Sure! Here you go`, false},
+		{"modify nullable fields", modifySchema, `{"actions":[{"type":"remove_step","step_index":3,
+			"ingredient_name":null,"new_ingredient_name":null,"quantity":null,"unit":null,"size_descriptor":null,
+			"instruction":null,"wait":null,"timer_label":null,"timer_duration":null,"servings":null,"equipment":null}],"summary":"Removed."}`, true},
+		{"modify fractional step", modifySchema, `{"actions":[{"type":"remove_step","step_index":2.5,
+			"ingredient_name":null,"new_ingredient_name":null,"quantity":null,"unit":null,"size_descriptor":null,
+			"instruction":null,"wait":null,"timer_label":null,"timer_duration":null,"servings":null,"equipment":null}],"summary":""}`, false},
+	}
+	for _, tt := range tests {
+		err := validateJSON(tt.schema, tt.raw)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: validateJSON err = %v, want ok=%v", tt.name, err, tt.ok)
+		}
+		if err != nil && !errors.Is(err, errSchema) {
+			t.Errorf("%s: error %v should wrap errSchema", tt.name, err)
+		}
+	}
+}
+
+// chatServer replies with each of replies in turn and records the
+// request bodies it received.
+func chatServer(t *testing.T, replies ...string) (*httptest.Server, *[]payload) {
+	t.Helper()
+	var got []payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p payload
+		json.NewDecoder(r.Body).Decode(&p)
+		got = append(got, p)
+		reply := replies[min(len(got), len(replies))-1]
+		fmt.Fprintf(w, `{"choices":[{"message":{"role":"assistant","content":%q}}]}`, reply)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &got
+}
+
+func TestChatJSONReasksOnSchemaViolation(t *testing.T) {
+	srv, got := chatServer(t, `{"intent":"dance"}`, `{"intent":"advance","payload":""}`)
+	agent := NewAgent(NewClient(srv.URL, "key", logger.New(logger.LevelOff, nil)), logger.New(logger.LevelOff, nil))
+
+	intent, err := agent.Classify(context.Background(), "next one please", nil, nil)
+	if err != nil {
+		t.Fatalf("Classify: %v", err)
+	}
+	if intent.Type.String() != "advance" {
+		t.Errorf("expected advance after re-ask, got %s", intent.Type)
+	}
+	if len(*got) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(*got))
+	}
+	if rf := (*got)[0].ResponseFormat; rf == nil || rf.Type != "json_schema" {
+		t.Errorf("expected json_schema response_format, got %+v", rf)
+	}
+	last := (*got)[1].Messages
+	if fix := last[len(last)-1].Content[0].Text; !strings.Contains(fix, "invalid") {
+		t.Errorf("re-ask should explain the violation, got %q", fix)
+	}
+}
+
+func TestChatJSONGivesUpAfterMaxReasks(t *testing.T) {
+	srv, got := chatServer(t, `not json`)
+	agent := NewAgent(NewClient(srv.URL, "key", logger.New(logger.LevelOff, nil)), logger.New(logger.LevelOff, nil))
+
+	resp, err := agent.DismissTimer(context.Background(), "stop it", nil, nil)
+	if err != nil {
+		t.Fatalf("DismissTimer: %v", err)
+	}
+	if resp.Summary != "not json" || len(resp.TimerIDs) != 0 {
+		t.Errorf("expected raw-summary fallback, got %+v", resp)
+	}
+	if len(*got) != 1+maxReasks {
+		t.Errorf("expected %d requests, got %d", 1+maxReasks, len(*got))
+	}
+}
+
+func TestChatFormatFallsBackWhenRejected(t *testing.T) {
+	var formats []bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p payload
+		json.NewDecoder(r.Body).Decode(&p)
+		formats = append(formats, p.ResponseFormat != nil)
+		if p.ResponseFormat != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"Invalid parameter: 'response_format' is not supported"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"{}"}}]}`)
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "key", logger.New(logger.LevelOff, nil))
+	format := SchemaFormat("x", Schema{"type": "object"})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.ChatFormat(context.Background(), nil, format); err != nil {
+			t.Fatalf("ChatFormat: %v", err)
+		}
+	}
+	// First call tries with the format then without; the second skips it.
+	want := []bool{true, false, false}
+	if fmt.Sprint(formats) != fmt.Sprint(want) {
+		t.Errorf("response_format sent = %v, want %v", formats, want)
+	}
+}
+
+func TestModifyWithoutFormatAllowsMissingNulls(t *testing.T) {
+	// As the prompt's examples have it: only the fields that apply.
+	reply := `{"actions":[{"type":"remove_step","step_index":3}],"summary":"Removed step 3."}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p payload
+		json.NewDecoder(r.Body).Decode(&p)
+		if p.ResponseFormat != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"Invalid parameter: 'response_format' is not supported"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"choices":[{"message":{"role":"assistant","content":%q}}]}`, reply)
+	}))
+	defer srv.Close()
+	agent := NewAgent(NewClient(srv.URL, "key", logger.New(logger.LevelOff, nil)), logger.New(logger.LevelOff, nil))
+
+	resp, err := agent.Modify(context.Background(), "drop step 3", nil, nil)
+	if err != nil {
+		t.Fatalf("Modify: %v", err)
+	}
+	if len(resp.Actions) != 1 || resp.Actions[0].Type != ActionRemoveStep || resp.Summary != "Removed step 3." {
+		t.Fatalf("Modify = %+v; want the remove_step action", resp)
+	}
+
+	// The fields that can't be null are still required.
+	if err := validateJSON(nullsOptional(modifySchema), `{"actions":[{"step_index":3}],"summary":""}`); err == nil {
+		t.Error("an action without a type validated")
+	}
+	if err := validateJSON(modifySchema, reply); err == nil {
+		t.Error("the strict schema accepted missing fields")
+	}
+}
+
+func TestReplanKeepsOnlyStepChanges(t *testing.T) {
+	reply := `{"actions":[
+		{"type":"update_timer","step_index":3,"timer_label":"Chicken searing","timer_duration":"20m",
+		 "ingredient_name":null,"new_ingredient_name":null,"quantity":null,"unit":null,"size_descriptor":null,
+		 "instruction":null,"wait":null,"servings":null,"equipment":null},
+		{"type":"update_servings","servings":8,
+		 "ingredient_name":null,"new_ingredient_name":null,"quantity":null,"unit":null,"size_descriptor":null,
+		 "step_index":null,"instruction":null,"wait":null,"timer_label":null,"timer_duration":null,"equipment":null}],
+		"summary":"Sear in two batches. Update the steps?"}`
+	srv, got := chatServer(t, reply)
+	agent := NewAgent(NewClient(srv.URL, "key", logger.New(logger.LevelOff, nil)), logger.New(logger.LevelOff, nil))
+
+	applied := []Action{{Type: ActionUpdateServings, Servings: 4}}
+	if !AffectsTiming(applied) || AffectsTiming([]Action{{Type: ActionUpdateStep}}) {
+		t.Fatal("AffectsTiming should flag servings changes and only those kinds")
+	}
+	resp, err := agent.Replan(context.Background(), applied, nil, nil)
+	if err != nil {
+		t.Fatalf("Replan: %v", err)
+	}
+	if len(resp.Actions) != 1 || resp.Actions[0].Type != ActionUpdateTimer {
+		t.Fatalf("Replan actions = %+v, want just the timer update", resp.Actions)
+	}
+	msgs := (*got)[0].Messages
+	if q := msgs[len(msgs)-1].Content[0].Text; !strings.Contains(q, `"update_servings"`) {
+		t.Errorf("replan request should list the applied changes, got %q", q)
+	}
+}
+
+func TestDemoClientRepliesValidate(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	agent := NewAgent(NewDemoClient(log), log)
+	ctx := context.Background()
+
+	for name, schema := range map[string]Schema{"classify": classifySchema, "modify": modifySchema, "replan": modifySchema, "dismiss_timer": dismissTimerSchema} {
+		if err := validateJSON(schema, demoReply(name, "x")); err != nil {
+			t.Errorf("demo %s reply doesn't validate: %v", name, err)
+		}
+	}
+
+	intent, err := agent.Classify(ctx, "is the chicken done", nil, nil)
+	if err != nil || intent.Type.String() != "ask_question" {
+		t.Fatalf("Classify = %v, %v; want ask_question", intent, err)
+	}
+	answer, err := agent.AskQuestion(ctx, "Is the chicken done?", nil, nil)
+	if err != nil || answer != demoAnswers[1].answer {
+		t.Fatalf("AskQuestion = %q, %v; want the scripted answer", answer, err)
+	}
+	mod, err := agent.Modify(ctx, "double it", nil, nil)
+	if err != nil || len(mod.Actions) != 0 || mod.Summary == "" {
+		t.Fatalf("Modify = %+v, %v; want no actions and a summary", mod, err)
+	}
+	if _, err := agent.DismissTimer(ctx, "the pasta one", nil, nil); err != nil {
+		t.Fatalf("DismissTimer: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package gpt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ── Speaker ──────────────────────────────────────────────────────
+//
+// With speaker identification on, the app knows who asked, and whoever
+// that is brings their diet along: "can I add cheese?" gets a different
+// answer from the one who's vegan.
+
+// SetSpeaker records who is talking and their dietary needs; the next
+// calls take them into account.  An empty name means nobody known.
+func (a *Agent) SetSpeaker(name string, diet []string) {
+	a.mu.Lock()
+	a.speaker, a.diet = name, append([]string(nil), diet...)
+	a.mu.Unlock()
+}
+
+// speakerInstruction describes the speaker for the system prompt, or ""
+// when nobody is known.
+func (a *Agent) speakerInstruction() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.speaker == "" {
+		return ""
+	}
+	if len(a.diet) == 0 {
+		return fmt.Sprintf("The person speaking is %s.", a.speaker)
+	}
+	return fmt.Sprintf("The person speaking is %s. Their dietary needs: %s. Respect them in every answer and change you suggest for them, and warn them when something in the recipe doesn't fit.",
+		a.speaker, strings.Join(a.diet, "; "))
+}
@@ -0,0 +1,22 @@
+package gpt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+func TestSpeakerInPrompt(t *testing.T) {
+	a := NewAgent(nil, logger.New(logger.LevelOff, nil))
+	system := func() string { return a.buildMessages("Be helpful.", "q", nil, nil)[0].Content[0].Text }
+
+	a.SetSpeaker("Sam", []string{"vegetarian", "allergic to peanuts"})
+	if !strings.Contains(system(), "The person speaking is Sam. Their dietary needs: vegetarian; allergic to peanuts.") {
+		t.Errorf("no speaker instruction:\n%s", system())
+	}
+	a.SetSpeaker("", nil)
+	if strings.Contains(system(), "person speaking") {
+		t.Errorf("speaker kept after clearing:\n%s", system())
+	}
+}
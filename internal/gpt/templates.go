@@ -0,0 +1,130 @@
+package gpt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// ── Prompt overrides ─────────────────────────────────────────────
+//
+// Any of the system prompts can be replaced without recompiling by
+// dropping a file named after it into the prompts directory:
+//
+//	question.tmpl  retry.tmpl  modify.tmpl  replan.tmpl  safety.tmpl
+//	dismiss_timer.tmpl  classify.tmpl  pairing.tmpl
+//
+// Files are text/template.  {{.Default}} expands to the built-in prompt,
+// so a tweak can extend it rather than copy it wholesale.
+
+// EnvPromptsDir overrides the default prompt override directory.
+const EnvPromptsDir = "OTTOCOOK_PROMPTS_DIR"
+
+// Prompts holds the system prompt used for each agent call.
+type Prompts struct {
+	Question     string
+	Retry        string // added to Question when an answer was marked wrong
+	Modify       string
+	Replan       string
+	Safety       string
+	DismissTimer string
+	Classify     string
+	Pairing      string
+}
+
+// DefaultPrompts returns the built-in prompts.
+func DefaultPrompts() Prompts {
+	return Prompts{
+		Question:     PromptQuestion,
+		Retry:        PromptRetry,
+		Modify:       PromptModify,
+		Replan:       PromptReplan,
+		Safety:       PromptSafety,
+		DismissTimer: PromptDismissTimer,
+		Classify:     PromptClassify,
+		Pairing:      PromptPairing,
+	}
+}
+
+// promptData is what override templates are executed with.
+type promptData struct {
+	Default string
+}
+
+// DefaultPromptsDir returns where prompt overrides are looked for:
+// $OTTOCOOK_PROMPTS_DIR, else <user config dir>/ottocook/prompts.
+func DefaultPromptsDir() string {
+	if dir := os.Getenv(EnvPromptsDir); dir != "" {
+		return dir
+	}
+	if cfg, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(cfg, "ottocook", "prompts")
+	}
+	return ""
+}
+
+// LoadPrompts starts from the built-in prompts and applies any overrides
+// found in dir, returning the names of the prompts it replaced.  A
+// missing dir or file just means "use the default".  A template that
+// fails to parse or execute keeps its default and is reported in the
+// returned error; the other prompts still load.
+func LoadPrompts(dir string) (Prompts, []string, error) {
+	p := DefaultPrompts()
+	if dir == "" {
+		return p, nil, nil
+	}
+	slots := []struct {
+		name string
+		dst  *string
+	}{
+		{"question", &p.Question},
+		{"retry", &p.Retry},
+		{"modify", &p.Modify},
+		{"replan", &p.Replan},
+		{"safety", &p.Safety},
+		{"dismiss_timer", &p.DismissTimer},
+		{"classify", &p.Classify},
+		{"pairing", &p.Pairing},
+	}
+
+	var loaded []string
+	var errs []error
+	for _, s := range slots {
+		path := filepath.Join(dir, s.name+".tmpl")
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		out, err := renderPrompt(s.name, string(data), *s.dst)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		*s.dst = out
+		loaded = append(loaded, s.name)
+	}
+	return p, loaded, errors.Join(errs...)
+}
+
+func renderPrompt(name, text, def string) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, promptData{Default: def}); err != nil {
+		return "", err
+	}
+	out := strings.TrimSpace(b.String())
+	if out == "" {
+		return "", errors.New("template rendered an empty prompt")
+	}
+	return out, nil
+}
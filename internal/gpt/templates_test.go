@@ -0,0 +1,72 @@
+package gpt
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoadPrompts(t *testing.T) {
+	dir := t.TempDir()
+	for name, text := range map[string]string{
+		"question": "Be terse.\n",
+		"modify":   "{{.Default}}\n\nAlways use metric units.",
+		"safety":   "{{.Default",            // doesn't parse
+		"retry":    "{{.Missing}}",          // doesn't execute
+		"classify": "  {{/* nothing */}}\n", // renders empty
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name+".tmpl"), []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, loaded, err := LoadPrompts(dir)
+	slices.Sort(loaded)
+	if !slices.Equal(loaded, []string{"modify", "question"}) {
+		t.Errorf("loaded = %v, want modify and question", loaded)
+	}
+	if p.Question != "Be terse." {
+		t.Errorf("Question = %q, want the override", p.Question)
+	}
+	if !strings.HasPrefix(p.Modify, strings.TrimSpace(PromptModify)) || !strings.HasSuffix(p.Modify, "Always use metric units.") {
+		t.Errorf("Modify = %q, want the default with the line added", p.Modify)
+	}
+
+	// The broken ones keep their defaults and are all reported.
+	if p.Safety != PromptSafety || p.Retry != PromptRetry || p.Classify != PromptClassify {
+		t.Error("a template that failed replaced its default")
+	}
+	if err == nil {
+		t.Fatal("LoadPrompts reported no errors")
+	}
+	for _, name := range []string{"safety.tmpl", "retry.tmpl", "classify.tmpl"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q doesn't mention %s", err, name)
+		}
+	}
+	if !strings.Contains(err.Error(), "empty prompt") {
+		t.Errorf("error %q doesn't say the prompt was empty", err)
+	}
+
+	if p.Pairing != PromptPairing || p.Replan != PromptReplan {
+		t.Error("a prompt without an override changed")
+	}
+}
+
+func TestLoadPromptsWithoutOverrides(t *testing.T) {
+	for _, dir := range []string{"", filepath.Join(t.TempDir(), "none")} {
+		p, loaded, err := LoadPrompts(dir)
+		if err != nil || len(loaded) != 0 || p != DefaultPrompts() {
+			t.Errorf("LoadPrompts(%q) = %v, %v; want the defaults", dir, loaded, err)
+		}
+	}
+}
+
+func TestDefaultPromptsDir(t *testing.T) {
+	t.Setenv(EnvPromptsDir, "/somewhere/prompts")
+	if got := DefaultPromptsDir(); got != "/somewhere/prompts" {
+		t.Errorf("DefaultPromptsDir = %q, want $%s", got, EnvPromptsDir)
+	}
+}
@@ -0,0 +1,176 @@
+package gpt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Tool registry ────────────────────────────────────────────────
+//
+// What the model can ask ottocook to do when it classifies input: every
+// capability, with when to pick it and what goes in its payload.  The
+// intent list in the classify prompt and the enum in its schema are both
+// built from the registry, so a capability is callable by the model as
+// soon as it's registered, with no prompt to edit by hand.  Plugins add
+// theirs at start (see Agent.RegisterTool).
+
+// Tool is one capability the model can pick.
+type Tool struct {
+	Name        string            // what the model answers with; snake_case
+	Intent      domain.IntentType // what the answer becomes
+	Description string            // when to pick it, with examples, and what its payload holds
+	Payload     bool              // the payload is required
+}
+
+// unknownTool is always offered, and always listed last.
+var unknownTool = Tool{Name: "unknown", Intent: domain.IntentUnknown, Description: "genuinely unrelated or nonsensical input"}
+
+// ToolRegistry is the set of tools offered to the model.
+type ToolRegistry struct {
+	mu    sync.RWMutex
+	tools []Tool
+}
+
+// NewToolRegistry returns a registry holding tools.  It panics on a
+// duplicate name, as the built-in set is fixed at compile time.
+func NewToolRegistry(tools ...Tool) *ToolRegistry {
+	r := &ToolRegistry{}
+	for _, t := range tools {
+		if err := r.Register(t); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register offers another tool to the model.
+func (r *ToolRegistry) Register(t Tool) error {
+	if t.Name == "" || t.Description == "" {
+		return fmt.Errorf("tool %q needs a name and a description", t.Name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.lookup(t.Name); ok || t.Name == unknownTool.Name {
+		return fmt.Errorf("tool %q is already registered", t.Name)
+	}
+	r.tools = append(r.tools, t)
+	return nil
+}
+
+// Lookup returns the tool the model named.
+func (r *ToolRegistry) Lookup(name string) (Tool, bool) {
+	if name == unknownTool.Name {
+		return unknownTool, true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lookup(name)
+}
+
+func (r *ToolRegistry) lookup(name string) (Tool, bool) {
+	for _, t := range r.tools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Tool{}, false
+}
+
+// prompt lists the tools for the classify prompt.
+func (r *ToolRegistry) prompt() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var b strings.Builder
+	var required []string
+	b.WriteString("Available intents:\n")
+	for _, t := range append(r.tools, unknownTool) {
+		fmt.Fprintf(&b, "- %q — %s\n", t.Name, t.Description)
+		if t.Payload {
+			required = append(required, t.Name)
+		}
+	}
+	fmt.Fprintf(&b, "\n\"payload\" is required for: %s. For others, set it as the intent says, or to \"\".", strings.Join(required, ", "))
+	return b.String()
+}
+
+// schema is the classify reply's schema, its intent limited to the
+// registered tools.
+func (r *ToolRegistry) schema() Schema {
+	r.mu.RLock()
+	names := []string{unknownTool.Name}
+	for _, t := range r.tools {
+		names = append(names, t.Name)
+	}
+	r.mu.RUnlock()
+	sort.Strings(names)
+	enum := make([]any, len(names))
+	for i, n := range names {
+		enum[i] = n
+	}
+	return Schema{
+		"type": "object",
+		"properties": Schema{
+			"intent":  Schema{"type": "string", "enum": enum},
+			"payload": Schema{"type": "string"},
+		},
+		"required":             []any{"intent", "payload"},
+		"additionalProperties": false,
+	}
+}
+
+// DefaultTools returns the built-in capabilities: one per intent the
+// parser knows, in the order they're best listed to the model.
+func DefaultTools() []Tool {
+	return []Tool{
+		{Name: "list_recipes", Intent: domain.IntentListRecipes, Description: `user wants to see available recipes (e.g. "show me what we can cook", "what recipes do you have"). To filter by a tag or collection, set "payload" to its name (e.g. "show my weeknight favorites" -> "weeknight favorites").`},
+		{Name: "search_recipes", Intent: domain.IntentSearch, Payload: true, Description: `user wants to find recipes by ingredient or kind of dish (e.g. "find me something with broccoli", "got anything vegetarian"). Set "payload" to just the search terms (e.g. "broccoli").`},
+		{Name: "tag_recipe", Intent: domain.IntentTag, Payload: true, Description: `user wants to add or remove a tag on the selected recipe. Set "payload" to "tag <tag>" or "untag <tag>" (e.g. "mark this as quick" -> "tag quick").`},
+		{Name: "collect_recipe", Intent: domain.IntentCollect, Payload: true, Description: `user wants to add the selected recipe to, or remove it from, a named collection. Set "payload" to "add this to <name>" or "remove this from <name>".`},
+		{Name: "duplicate_recipe", Intent: domain.IntentDuplicate, Payload: true, Description: `user wants to save a copy of the selected recipe as their own variant, keeping the original (e.g. "save this as mom's version", "make a copy first"). Set "payload" to "duplicate as <name>", or "duplicate" if no name was given.`},
+		{Name: "add_note", Intent: domain.IntentNote, Payload: true, Description: `user wants to jot down a note about the current step while cooking (e.g. "note that the sauce needed 5 extra minutes", "remember to use less salt next time"). Set "payload" to "note: <text>", or "note for next time: <text>" if they want it kept in the recipe; "keep my notes" saves every note from this session.`},
+		{Name: "select_recipe", Intent: domain.IntentSelectRecipe, Payload: true, Description: `user wants to pick a specific recipe (e.g. "let's do the pasta", "I want eggs"). Set "payload" to the recipe reference.`},
+		{Name: "start_cooking", Intent: domain.IntentStartCooking, Description: `user wants to begin cooking the selected recipe (e.g. "let's go", "I'm ready", "fire it up")`},
+		{Name: "advance", Intent: domain.IntentAdvance, Description: `user wants to move to the next step (e.g. "what's next", "I'm done with this step", "move on")`},
+		{Name: "skip", Intent: domain.IntentSkip, Description: `user wants to skip the current step (e.g. "skip this one", "pass"), or jump ahead to a later one (e.g. "go to step five"). Set "payload" to "step <number>" when they name a step.`},
+		{Name: "repeat", Intent: domain.IntentRepeat, Description: `user wants to hear the current step again (e.g. "say that again", "what was that", "repeat please", "what step are we on")`},
+		{Name: "repeat_last", Intent: domain.IntentRepeatLast, Description: `user wants to hear the last thing the assistant said, regardless of what it was (e.g. "repeat that", "say that again", "what did you say", "come again")`},
+		{Name: "resume_last", Intent: domain.IntentResumeLast, Description: `user wants the assistant to pick up an answer it was cut off in the middle of (e.g. "what were you saying?", "go on", "sorry, carry on")`},
+		{Name: "pause", Intent: domain.IntentPause, Description: `user wants to pause (e.g. "hold on", "one sec", "I need a break")`},
+		{Name: "resume", Intent: domain.IntentResume, Description: `user wants to resume after pausing (e.g. "I'm back", "let's continue", "ready again")`},
+		{Name: "status", Intent: domain.IntentStatus, Description: `user wants to know current progress (e.g. "where are we", "what step are we on", "how far along")`},
+		{Name: "quit", Intent: domain.IntentQuit, Description: `user wants to stop and exit (e.g. "I'm done", "cancel everything", "get me out")`},
+		{Name: "help", Intent: domain.IntentHelp, Description: `user wants to see available commands, or how one works (e.g. "what can I say", "how do timers work"). Set "payload" to that command (e.g. "timer") when they ask about one.`},
+		{Name: "dismiss_timer", Intent: domain.IntentDismissTimer, Description: `user wants to dismiss or acknowledge a timer (e.g. "dismiss the simmer timer", "stop the boil timer", or "got it" while one is going off). Set "payload" to the full request so we know which timer.`},
+		{Name: "start_timer", Intent: domain.IntentStartTimer, Description: `user is ready for the current step's timer to start (e.g. "start the timer", "it's in the pan, go"). Set "payload" to "all" to start every timer waiting on them.`},
+		{Name: "timer_control", Intent: domain.IntentTimerControl, Payload: true, Description: `user wants to pause, resume, cancel, or restart a timer without pausing the session (e.g. "pause all timers", "cancel the chicken timer", "restart the simmer timer"). Set "payload" to the full request.`},
+		{Name: "serve_time", Intent: domain.IntentServeTime, Payload: true, Description: `user says when they want to eat, so the steps can be timed to it (e.g. "dinner at 19:30", "we're eating at 7", "I want it ready by 8pm"). Set "payload" to the time, or "clear" to drop a time set earlier.`},
+		{Name: "prep_list", Intent: domain.IntentPrepList, Description: `user wants the knife work to do before cooking, all in one list (e.g. "what do I need to chop", "things to prep", "mise en place").`},
+		{Name: "how_much", Intent: domain.IntentHowMuch, Payload: true, Description: `user asks how much of an ingredient the recipe uses (e.g. "how much garlic", "how many cloves of garlic do I need"). Set "payload" to the full question.`},
+		{Name: "recipe_versions", Intent: domain.IntentVersions, Description: `user wants the history of changes to the recipe, or to go back to or cook an earlier version (e.g. "show versions", "cook version 2", "go back to version 1"). Set "payload" to "" to list them, "restore N" to go back to version N, or "start N" to cook it.`},
+		{Name: "misheard", Intent: domain.IntentMisheard, Description: `user says their last voice command was misheard (e.g. "that's not what I said", "no, I said next", "I wasn't talking to you"). Set "payload" to what they actually said, "nothing" if they weren't talking to the assistant, or "" if they don't say.`},
+		{Name: "check_condition", Intent: domain.IntentCheck, Description: `user reports that one of the current step's done-conditions is met (e.g. "the water is boiling", "chicken's at 74", "check off the first one"). Set "payload" to the words naming the condition, or a number, or "all".`},
+		{Name: "delegate", Intent: domain.IntentDelegate, Description: `user hands a job to someone cooking with them (e.g. "Sam can do the broccoli", "give the side jobs to Alex"). Set "payload" to "Name: the job", or just "Name" to hand over the current step's side jobs.`},
+		{Name: "task_done", Intent: domain.IntentTaskDone, Description: `user says a helper has finished their job (e.g. "Sam finished the broccoli"). Set "payload" to "Name" or "Name: words from the job"; prefix "not " when they say it isn't done after all.`},
+		{Name: "tasks", Intent: domain.IntentTasks, Description: `user asks what helpers are doing (e.g. "what's Sam on?"). Set "payload" to the helper's name, or "" for everyone.`},
+		{Name: "calendar", Intent: domain.IntentCalendar, Description: `user wants the upcoming times (wait ends, timers, serve time) in their calendar or reminders (e.g. "remind me on my phone when the marinade's done").`},
+		{Name: "timeline", Intent: domain.IntentTimeline, Description: `user wants to see how the cook went, drawn on a time axis (e.g. "show me the timeline", "how did that go, time-wise").`},
+		{Name: "compare_recipes", Intent: domain.IntentCompare, Payload: true, Description: `user wants two recipes, or two versions of one, side by side (e.g. "compare 1 and 2", "how's version 3 different from version 1"). Set "payload" to "<first> vs <second>", each a list number, "v" and a version number, or a recipe name.`},
+		{Name: "pairing", Intent: domain.IntentPairing, Description: `user asks what to drink with the recipe (e.g. "what wine goes with this?", "any drink pairing?", "what beer would go with it"). Set "payload" to what they said.`},
+		{Name: "suggest_recipe", Intent: domain.IntentSuggest, Description: `user wants ideas for what to cook rather than the whole list (e.g. "what should I cook?", "what can I make with chicken in half an hour", "surprise me"). Set "payload" to what they said about time or ingredients, in words like "in under 30 minutes with chicken", or "".`},
+		{Name: "microphone", Intent: domain.IntentMic, Description: `user wants the microphone off for privacy, or back on (e.g. "stop listening for a bit", "you can listen again"). Set "payload" to "off" or "on".`},
+		{Name: "voice", Intent: domain.IntentVoice, Description: `user wants speech and voice input shut down entirely, or brought back (e.g. "voice off", "text only", "turn the audio back on"). Set "payload" to "off" or "on".`},
+		{Name: "reload", Intent: domain.IntentReload, Description: `user wants the config file re-read and its changes applied now (e.g. "reload", "reload the settings").`},
+		{Name: "missed_wake", Intent: domain.IntentMissedWake, Description: `user says Otto didn't hear them say the wake word (e.g. "you didn't hear me", "I called you twice").`},
+		{Name: "enroll_voice", Intent: domain.IntentEnrollVoice, Description: `user wants Otto to learn their voice (e.g. "remember my voice as Sam"). Set "payload" to their name.`},
+		{Name: "diet", Intent: domain.IntentDiet, Description: `user states a lasting dietary need for themselves or someone else (e.g. "I'm allergic to peanuts", "Alex is vegan"). Set "payload" to "Name: need" for someone named, or ": need" for the speaker.`},
+		{Name: "cancel", Intent: domain.IntentCancel, Description: `user calls off what they asked you for (e.g. "never mind", "forget it", "cancel that").`},
+		{Name: "acknowledge", Intent: domain.IntentAcknowledge, Description: `user only acknowledges what was said and wants nothing done, with no timer going off (e.g. "ok", "thanks", "cool", "got it").`},
+		{Name: "answer_feedback", Intent: domain.IntentFeedback, Description: `user rates your last answer (e.g. "good answer", "that's wrong", "thumbs down"). Set "payload" to "up" or "down".`},
+		{Name: "ask_question", Intent: domain.IntentAskQuestion, Payload: true, Description: `user is asking a cooking question (e.g. "can I use butter instead", "what temperature should it be"). Set "payload" to the full question.`},
+		{Name: "modify", Intent: domain.IntentModify, Payload: true, Description: `user wants to change the recipe (e.g. "I only have 2 cloves", "double the servings", "no chili"). Set "payload" to the full request.`},
+	}
+}
@@ -0,0 +1,47 @@
+package gpt
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+func TestDefaultToolsCoverIntents(t *testing.T) {
+	r := NewToolRegistry(DefaultTools()...)
+	for _, name := range domain.IntentNames() {
+		tool, ok := r.Lookup(name)
+		if !ok {
+			t.Errorf("intent %q has no tool, so the model can't pick it", name)
+			continue
+		}
+		if tool.Intent != domain.IntentFromString(name) {
+			t.Errorf("tool %q becomes %s, want %s", name, tool.Intent, domain.IntentFromString(name))
+		}
+	}
+}
+
+func TestClassifyRegisteredTool(t *testing.T) {
+	srv, got := chatServer(t, `{"intent":"wine_pairing","payload":""}`)
+	log := logger.New(logger.LevelOff, nil)
+	agent := NewAgent(NewClient(srv.URL, "key", log), log)
+	if err := agent.RegisterTool(Tool{Name: "wine_pairing", Intent: domain.IntentPlugin, Description: "user wants a wine for the dish"}); err != nil {
+		t.Fatalf("RegisterTool: %v", err)
+	}
+	if err := agent.RegisterTool(Tool{Name: "advance", Intent: domain.IntentPlugin, Description: "clash"}); err == nil {
+		t.Error("registering a built-in name twice succeeded")
+	}
+
+	intent, err := agent.Classify(context.Background(), "which red would suit this", nil, nil)
+	if err != nil {
+		t.Fatalf("Classify: %v", err)
+	}
+	if intent.Type != domain.IntentPlugin || intent.Payload != "wine_pairing: which red would suit this" {
+		t.Errorf("Classify = %s %q, want the plugin with the input", intent.Type, intent.Payload)
+	}
+	if sys := (*got)[0].Messages[0].Content[0].Text; !strings.Contains(sys, `- "wine_pairing" — user wants a wine`) {
+		t.Errorf("registered tool missing from the prompt:\n%s", sys)
+	}
+}
@@ -0,0 +1,149 @@
+package gpt
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ── Measurement localization ─────────────────────────────────────
+//
+// With a units preference, buildContext gives the model the recipe in
+// that system, so its answers and modifications don't mix cups with
+// millilitres.  Only like-for-like conversions are made (volume to
+// volume, mass to mass): going from cups of flour to grams needs a
+// density we don't have.  Spoons are left alone — they're used the same
+// way in both systems.
+
+type measureDim int
+
+const (
+	dimVolume measureDim = iota
+	dimMass
+)
+
+// measure is a unit that belongs to one system.
+type measure struct {
+	system string
+	dim    measureDim
+	base   float64 // millilitres or grams per unit
+}
+
+var measures = map[string]measure{
+	"cup":          {UnitsUS, dimVolume, 236.588},
+	"cups":         {UnitsUS, dimVolume, 236.588},
+	"fluid ounce":  {UnitsUS, dimVolume, 29.574},
+	"fluid ounces": {UnitsUS, dimVolume, 29.574},
+	"fl oz":        {UnitsUS, dimVolume, 29.574},
+	"pint":         {UnitsUS, dimVolume, 473.176},
+	"pints":        {UnitsUS, dimVolume, 473.176},
+	"quart":        {UnitsUS, dimVolume, 946.353},
+	"quarts":       {UnitsUS, dimVolume, 946.353},
+	"ounce":        {UnitsUS, dimMass, 28.35},
+	"ounces":       {UnitsUS, dimMass, 28.35},
+	"oz":           {UnitsUS, dimMass, 28.35},
+	"pound":        {UnitsUS, dimMass, 453.592},
+	"pounds":       {UnitsUS, dimMass, 453.592},
+	"lb":           {UnitsUS, dimMass, 453.592},
+	"lbs":          {UnitsUS, dimMass, 453.592},
+	"milliliter":   {UnitsMetric, dimVolume, 1},
+	"milliliters":  {UnitsMetric, dimVolume, 1},
+	"millilitre":   {UnitsMetric, dimVolume, 1},
+	"millilitres":  {UnitsMetric, dimVolume, 1},
+	"ml":           {UnitsMetric, dimVolume, 1},
+	"liter":        {UnitsMetric, dimVolume, 1000},
+	"liters":       {UnitsMetric, dimVolume, 1000},
+	"litre":        {UnitsMetric, dimVolume, 1000},
+	"litres":       {UnitsMetric, dimVolume, 1000},
+	"l":            {UnitsMetric, dimVolume, 1000},
+	"gram":         {UnitsMetric, dimMass, 1},
+	"grams":        {UnitsMetric, dimMass, 1},
+	"g":            {UnitsMetric, dimMass, 1},
+	"kilogram":     {UnitsMetric, dimMass, 1000},
+	"kilograms":    {UnitsMetric, dimMass, 1000},
+	"kg":           {UnitsMetric, dimMass, 1000},
+}
+
+// localizeQuantity converts q of unit into the units system, returning
+// the amount and unit unchanged when there's nothing to convert.
+func localizeQuantity(q float64, unit, units string) (float64, string) {
+	m, ok := measures[strings.ToLower(strings.TrimSpace(unit))]
+	if !ok || units == "" || m.system == units {
+		return q, unit
+	}
+	base := q * m.base
+
+	if units == UnitsMetric {
+		switch {
+		case m.dim == dimVolume && base >= 1000:
+			return math.Round(base/10) / 100, "litres"
+		case m.dim == dimVolume:
+			return roundTo(base, 5), "ml"
+		case base >= 1000:
+			return math.Round(base/10) / 100, "kg"
+		default:
+			return roundTo(base, 5), "grams"
+		}
+	}
+
+	if m.dim == dimMass {
+		if base >= 453.592 {
+			return roundTo(base/453.592, 0.25), "pounds"
+		}
+		return roundTo(base/28.35, 0.5), "ounces"
+	}
+	switch {
+	case base < 14.787*3:
+		return roundTo(base/14.787, 0.5), "tablespoons"
+	default:
+		return roundTo(base/236.588, 0.125), "cups"
+	}
+}
+
+// roundTo rounds v to the nearest step, never to zero.
+func roundTo(v, step float64) float64 {
+	r := math.Round(v/step) * step
+	if r == 0 {
+		return step
+	}
+	return r
+}
+
+var (
+	tempPairRe = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*°\s*([FC])\s*/\s*(\d+(?:\.\d+)?)\s*°\s*([FC])\b`)
+	tempRe     = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*°\s*([FC])\b`)
+)
+
+// localizeText rewrites temperatures in free text ("bake at 350°F") into
+// the units system.  Where both are given ("165°F / 74°C") only the
+// preferred one is kept.
+func localizeText(s, units string) string {
+	if units == "" {
+		return s
+	}
+	want := "C"
+	if units == UnitsUS {
+		want = "F"
+	}
+	s = tempPairRe.ReplaceAllStringFunc(s, func(m string) string {
+		sm := tempPairRe.FindStringSubmatch(m)
+		if sm[4] == want {
+			return sm[3] + "°" + want
+		}
+		return sm[1] + "°" + sm[2]
+	})
+	return tempRe.ReplaceAllStringFunc(s, func(m string) string {
+		sm := tempRe.FindStringSubmatch(m)
+		if sm[2] == want {
+			return m
+		}
+		v, _ := strconv.ParseFloat(sm[1], 64)
+		if want == "C" {
+			v = roundTo((v-32)*5/9, 5)
+		} else {
+			v = roundTo(v*9/5+32, 5)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64) + "°" + want
+	})
+}
@@ -0,0 +1,48 @@
+package gpt
+
+import "testing"
+
+func TestLocalizeQuantity(t *testing.T) {
+	tests := []struct {
+		q     float64
+		unit  string
+		units string
+		wantQ float64
+		wantU string
+	}{
+		{1, "cup", UnitsMetric, 235, "ml"},
+		{8, "cups", UnitsMetric, 1.89, "litres"},
+		{1, "pound", UnitsMetric, 455, "grams"},
+		{500, "grams", UnitsUS, 1, "pounds"},
+		{100, "g", UnitsUS, 3.5, "ounces"},
+		{250, "ml", UnitsUS, 1, "cups"},
+		{30, "ml", UnitsUS, 2, "tablespoons"},
+		{2, "tablespoons", UnitsMetric, 2, "tablespoons"}, // spoons stay
+		{3, "cloves", UnitsMetric, 3, "cloves"},
+		{1, "cup", UnitsUS, 1, "cup"},
+		{1, "cup", "", 1, "cup"},
+	}
+	for _, tt := range tests {
+		q, u := localizeQuantity(tt.q, tt.unit, tt.units)
+		if q != tt.wantQ || u != tt.wantU {
+			t.Errorf("localizeQuantity(%v, %q, %q) = %v %q, want %v %q", tt.q, tt.unit, tt.units, q, u, tt.wantQ, tt.wantU)
+		}
+	}
+}
+
+func TestLocalizeText(t *testing.T) {
+	tests := []struct {
+		in, units, want string
+	}{
+		{"Bake at 350°F for 20 minutes", UnitsMetric, "Bake at 175°C for 20 minutes"},
+		{"Bake at 180°C", UnitsUS, "Bake at 355°F"},
+		{"Internal temperature reaches 165°F / 74°C", UnitsMetric, "Internal temperature reaches 74°C"},
+		{"Internal temperature reaches 165°F / 74°C", UnitsUS, "Internal temperature reaches 165°F"},
+		{"Bake at 350°F", "", "Bake at 350°F"},
+	}
+	for _, tt := range tests {
+		if got := localizeText(tt.in, tt.units); got != tt.want {
+			t.Errorf("localizeText(%q, %q) = %q, want %q", tt.in, tt.units, got, tt.want)
+		}
+	}
+}
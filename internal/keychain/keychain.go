@@ -0,0 +1,60 @@
+// Package keychain keeps credentials in the operating system's secret
+// store instead of a dotenv file: the Keychain on macOS, the Secret
+// Service (via secret-tool) on Linux, and the Credential Manager on
+// Windows.  Entries are filed under the "ottocook" service, keyed by the
+// env var name they stand in for.
+package keychain
+
+import (
+	"errors"
+	"os"
+)
+
+// Service is the name entries are stored under.
+const Service = "ottocook"
+
+var (
+	// ErrNotFound means the key has no entry.
+	ErrNotFound = errors.New("keychain: not found")
+	// ErrUnsupported means there's no usable secret store on this system.
+	ErrUnsupported = errors.New("keychain: not supported on this system")
+)
+
+// backend is one platform's secret store.
+type backend interface {
+	get(key string) (string, error)
+	set(key, value string) error
+	delete(key string) error
+}
+
+// store is the platform backend, chosen at build time.
+var store backend = platformBackend()
+
+// Get returns the value stored for key.
+func Get(key string) (string, error) { return store.get(key) }
+
+// Set stores value under key, replacing any existing entry.
+func Set(key, value string) error { return store.set(key, value) }
+
+// Delete removes key's entry.  Deleting a missing key returns ErrNotFound.
+func Delete(key string) error { return store.delete(key) }
+
+// Load fills in each key that isn't already set in the environment from
+// the keychain, and returns the ones it found.  The environment always
+// wins, so a key exported for one run overrides the stored one.  Missing
+// entries and an unavailable keychain are not errors.
+func Load(keys ...string) []string {
+	var loaded []string
+	for _, k := range keys {
+		if os.Getenv(k) != "" {
+			continue
+		}
+		v, err := store.get(k)
+		if err != nil || v == "" {
+			continue
+		}
+		os.Setenv(k, v)
+		loaded = append(loaded, k)
+	}
+	return loaded
+}
@@ -0,0 +1,57 @@
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// macKeychain uses the security(1) tool that ships with macOS.
+type macKeychain struct{}
+
+func platformBackend() backend { return macKeychain{} }
+
+func (macKeychain) get(key string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", Service, "-a", key, "-w").Output()
+	if err != nil {
+		return "", notFound(err)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+func (m macKeychain) set(key, value string) error {
+	// The command goes in on stdin (security -i) so the secret never
+	// shows up in ps.  -U updates an existing entry instead of failing.
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		quote(Service), quote(key), quote(value)))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("security: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	// In interactive mode a failed command doesn't fail the process,
+	// so read the entry back.
+	if got, err := m.get(key); err != nil || got != value {
+		return fmt.Errorf("security: entry not stored: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// quote makes s one argument on a security -i command line.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func (macKeychain) delete(key string) error {
+	return notFound(exec.Command("security", "delete-generic-password", "-s", Service, "-a", key).Run())
+}
+
+// notFound maps security's "item could not be found" exit status (44).
+func notFound(err error) error {
+	var exit *exec.ExitError
+	if errors.As(err, &exit) && exit.ExitCode() == 44 {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,12 @@
+//go:build !darwin && !windows && !linux && !freebsd && !openbsd && !netbsd
+
+package keychain
+
+// unsupported is the backend for systems without a known secret store.
+type unsupported struct{}
+
+func platformBackend() backend { return unsupported{} }
+
+func (unsupported) get(string) (string, error) { return "", ErrUnsupported }
+func (unsupported) set(string, string) error   { return ErrUnsupported }
+func (unsupported) delete(string) error        { return ErrUnsupported }
@@ -0,0 +1,58 @@
+package keychain
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+type memBackend map[string]string
+
+func (m memBackend) get(key string) (string, error) {
+	v, ok := m[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+func (m memBackend) set(key, value string) error { m[key] = value; return nil }
+
+func (m memBackend) delete(key string) error {
+	if _, ok := m[key]; !ok {
+		return ErrNotFound
+	}
+	delete(m, key)
+	return nil
+}
+
+func TestLoad(t *testing.T) {
+	orig := store
+	t.Cleanup(func() { store = orig })
+	store = memBackend{}
+
+	if err := Set("OTTO_TEST_STORED", "from-keychain"); err != nil {
+		t.Fatal(err)
+	}
+	Set("OTTO_TEST_EXPORTED", "from-keychain")
+	t.Setenv("OTTO_TEST_EXPORTED", "from-env")
+	t.Setenv("OTTO_TEST_STORED", "")
+
+	loaded := Load("OTTO_TEST_STORED", "OTTO_TEST_EXPORTED", "OTTO_TEST_MISSING")
+	if len(loaded) != 1 || loaded[0] != "OTTO_TEST_STORED" {
+		t.Errorf("loaded = %v, want [OTTO_TEST_STORED]", loaded)
+	}
+	if got := os.Getenv("OTTO_TEST_STORED"); got != "from-keychain" {
+		t.Errorf("stored key = %q, want it filled from the keychain", got)
+	}
+	if got := os.Getenv("OTTO_TEST_EXPORTED"); got != "from-env" {
+		t.Errorf("exported key = %q, the environment should win", got)
+	}
+
+	if err := Delete("OTTO_TEST_STORED"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Get("OTTO_TEST_STORED"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,52 @@
+//go:build linux || freebsd || openbsd || netbsd
+
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secretService talks to the desktop's Secret Service (GNOME Keyring,
+// KWallet) through secret-tool from libsecret.
+type secretService struct{}
+
+func platformBackend() backend { return secretService{} }
+
+func (secretService) get(key string) (string, error) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return "", ErrUnsupported
+	}
+	out, err := exec.Command("secret-tool", "lookup", "service", Service, "key", key).Output()
+	if err != nil {
+		// secret-tool exits 1 with no output for a missing entry.
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && len(exit.Stderr) == 0 {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+func (secretService) set(key, value string) error {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return ErrUnsupported
+	}
+	// The secret goes in on stdin so it never shows up in ps.
+	cmd := exec.Command("secret-tool", "store", "--label", fmt.Sprintf("%s %s", Service, key), "service", Service, "key", key)
+	cmd.Stdin = strings.NewReader(value)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("secret-tool: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (s secretService) delete(key string) error {
+	if _, err := s.get(key); err != nil {
+		return err
+	}
+	return exec.Command("secret-tool", "clear", "service", Service, "key", key).Run()
+}
@@ -0,0 +1,154 @@
+// Package models knows where OttoCook's model files come from and where
+// they live on disk: the Whisper GGML models used for speech-to-text and
+// the openWakeWord feature models used by the wake word detector.
+//
+// Pull downloads and verifies a model into a cache directory; Resolve
+// finds an already-downloaded copy at startup so a missing bin/ file is
+// not fatal.
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// EnvModelsDir overrides the default model cache directory.
+const EnvModelsDir = "OTTOCOOK_MODELS_DIR"
+
+// Kind identifies a model file format so downloads can be sanity-checked.
+type Kind int
+
+const (
+	// GGML is a whisper.cpp model (ggml-*.bin).
+	GGML Kind = iota
+	// ONNX is an ONNX graph (melspectrogram, embedding, wakeword).
+	ONNX
+)
+
+func (k Kind) String() string {
+	switch k {
+	case GGML:
+		return "ggml"
+	case ONNX:
+		return "onnx"
+	default:
+		return "unknown"
+	}
+}
+
+// Model describes one downloadable model file.
+type Model struct {
+	Name string // short name used on the command line, e.g. "small"
+	File string // file name on disk, e.g. "ggml-small.bin"
+	URL  string
+	Kind Kind
+	Size string // approximate download size, for display only
+	// SHA256 pins the file's digest; when empty, Pull relies on the
+	// one the server publishes.
+	SHA256 string
+}
+
+const (
+	whisperBaseURL   = "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/"
+	wakewordBaseURL  = "https://github.com/dscripka/openWakeWord/releases/download/v0.5.1/"
+	defaultWhisperID = "small"
+)
+
+var catalog = []Model{
+	{Name: "tiny", File: "ggml-tiny.bin", URL: whisperBaseURL + "ggml-tiny.bin", Kind: GGML, Size: "75 MB"},
+	{Name: "tiny.en", File: "ggml-tiny.en.bin", URL: whisperBaseURL + "ggml-tiny.en.bin", Kind: GGML, Size: "75 MB"},
+	{Name: "base", File: "ggml-base.bin", URL: whisperBaseURL + "ggml-base.bin", Kind: GGML, Size: "142 MB"},
+	{Name: "base.en", File: "ggml-base.en.bin", URL: whisperBaseURL + "ggml-base.en.bin", Kind: GGML, Size: "142 MB"},
+	{Name: "small", File: "ggml-small.bin", URL: whisperBaseURL + "ggml-small.bin", Kind: GGML, Size: "466 MB"},
+	{Name: "small.en", File: "ggml-small.en.bin", URL: whisperBaseURL + "ggml-small.en.bin", Kind: GGML, Size: "466 MB"},
+	{Name: "medium", File: "ggml-medium.bin", URL: whisperBaseURL + "ggml-medium.bin", Kind: GGML, Size: "1.5 GB"},
+	{Name: "medium.en", File: "ggml-medium.en.bin", URL: whisperBaseURL + "ggml-medium.en.bin", Kind: GGML, Size: "1.5 GB"},
+	{Name: "melspectrogram", File: "melspectrogram.onnx", URL: wakewordBaseURL + "melspectrogram.onnx", Kind: ONNX, Size: "1 MB"},
+	{Name: "embedding", File: "embedding_model.onnx", URL: wakewordBaseURL + "embedding_model.onnx", Kind: ONNX, Size: "1 MB"},
+}
+
+// groups are shorthand names that expand to several models.
+var groups = map[string][]string{
+	"wakeword": {"melspectrogram", "embedding"},
+	"all":      {defaultWhisperID, "melspectrogram", "embedding"},
+}
+
+// Catalog returns every known model, in display order.
+func Catalog() []Model {
+	return append([]Model(nil), catalog...)
+}
+
+// Lookup expands a model or group name into the models it names.
+func Lookup(name string) ([]Model, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if members, ok := groups[name]; ok {
+		var out []Model
+		for _, m := range members {
+			found, _ := Lookup(m)
+			out = append(out, found...)
+		}
+		return out, true
+	}
+	for _, m := range catalog {
+		if m.Name == name || m.File == name {
+			return []Model{m}, true
+		}
+	}
+	return nil, false
+}
+
+// Groups returns the group names accepted by Lookup, sorted.
+func Groups() []string {
+	names := make([]string, 0, len(groups))
+	for g := range groups {
+		names = append(names, g)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultDir is where Pull stores models: $OTTOCOOK_MODELS_DIR, or
+// ottocook/models under the user cache directory.
+func DefaultDir() string {
+	if dir := os.Getenv(EnvModelsDir); dir != "" {
+		return dir
+	}
+	if cache, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(cache, "ottocook", "models")
+	}
+	return filepath.Join(".otto-cache", "models")
+}
+
+// Resolve returns a usable path for a model file.  If path exists it is
+// returned as-is; otherwise the file name is looked up in bin/, models/,
+// and the default cache directory, in that order.
+func Resolve(path string) (string, bool) {
+	if path == "" {
+		return "", false
+	}
+	if _, err := os.Stat(path); err == nil {
+		return path, true
+	}
+	name := filepath.Base(path)
+	for _, dir := range []string{"bin", "models", DefaultDir()} {
+		candidate := filepath.Join(dir, name)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// PullHint returns the command that fetches the model behind a file
+// name, or "" when the file isn't in the catalog.
+func PullHint(path string) string {
+	name := filepath.Base(path)
+	for _, m := range catalog {
+		if m.File == name {
+			return "ottocook models pull " + m.Name
+		}
+	}
+	return ""
+}
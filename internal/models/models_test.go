@@ -0,0 +1,161 @@
+package models
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func fakeGGML() []byte {
+	b := binary.LittleEndian.AppendUint32(nil, ggmlMagic)
+	return append(b, "weights"...)
+}
+
+func serve(t *testing.T, body []byte, etag string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if etag != "" {
+			w.Header().Set("X-Linked-Etag", `"`+etag+`"`)
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestPullVerifiesAndWritesSidecar(t *testing.T) {
+	body := fakeGGML()
+	sum := sha256.Sum256(body)
+	srv := serve(t, body, hex.EncodeToString(sum[:]))
+	dir := t.TempDir()
+
+	m := Model{Name: "test", File: "ggml-test.bin", URL: srv.URL, Kind: GGML}
+	path, err := Pull(context.Background(), srv.Client(), m, dir, nil)
+	if err != nil {
+		t.Fatalf("Pull: %v", err)
+	}
+	if path != filepath.Join(dir, "ggml-test.bin") {
+		t.Errorf("unexpected path %s", path)
+	}
+	if err := Verify(path, GGML); err != nil {
+		t.Errorf("Verify after pull: %v", err)
+	}
+
+	// Corrupting the file must be caught via the sidecar.
+	os.WriteFile(path, append(fakeGGML(), 'x'), 0o644)
+	if err := Verify(path, GGML); err == nil {
+		t.Error("expected checksum mismatch after corruption")
+	}
+}
+
+func TestPullRejectsChecksumMismatch(t *testing.T) {
+	srv := serve(t, fakeGGML(), strings.Repeat("ab", sha256.Size))
+	dir := t.TempDir()
+
+	m := Model{Name: "test", File: "ggml-test.bin", URL: srv.URL, Kind: GGML}
+	if _, err := Pull(context.Background(), srv.Client(), m, dir, nil); err == nil {
+		t.Fatal("expected checksum error")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ggml-test.bin")); err == nil {
+		t.Error("corrupt download should not be left in place")
+	}
+}
+
+func TestPullRejectsWrongFormat(t *testing.T) {
+	srv := serve(t, []byte("<html>not found</html>"), "")
+	m := Model{Name: "test", File: "ggml-test.bin", URL: srv.URL, Kind: GGML}
+	if _, err := Pull(context.Background(), srv.Client(), m, t.TempDir(), nil, AllowUnverified()); err == nil {
+		t.Fatal("expected format error for an HTML body")
+	}
+}
+
+func TestPullReadsDigestBeforeRedirect(t *testing.T) {
+	// Like Hugging Face: the digest is on the redirect, not the CDN.
+	body := fakeGGML()
+	cdn := serve(t, body, "")
+	for _, tt := range []struct {
+		name string
+		sum  []byte
+		ok   bool
+	}{
+		{"match", body, true},
+		{"mismatch", append(fakeGGML(), 'x'), false},
+	} {
+		sum := sha256.Sum256(tt.sum)
+		hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Linked-Etag", `"`+hex.EncodeToString(sum[:])+`"`)
+			http.Redirect(w, r, cdn.URL, http.StatusFound)
+		}))
+		defer hub.Close()
+
+		m := Model{Name: "test", File: "ggml-test.bin", URL: hub.URL, Kind: GGML}
+		_, err := Pull(context.Background(), hub.Client(), m, t.TempDir(), nil)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: Pull error = %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestPullWithoutDigest(t *testing.T) {
+	body := fakeGGML()
+	srv := serve(t, body, "")
+	m := Model{Name: "test", File: "ggml-test.bin", URL: srv.URL, Kind: GGML}
+
+	dir := t.TempDir()
+	if _, err := Pull(context.Background(), srv.Client(), m, dir, nil); !errors.Is(err, ErrUnverified) {
+		t.Fatalf("Pull error = %v, want ErrUnverified", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ggml-test.bin")); err == nil {
+		t.Error("an unverified download was installed")
+	}
+	if _, err := Pull(context.Background(), srv.Client(), m, dir, nil, AllowUnverified()); err != nil {
+		t.Errorf("Pull with AllowUnverified: %v", err)
+	}
+
+	// A pinned digest needs nothing from the server.
+	sum := sha256.Sum256(body)
+	m.SHA256 = hex.EncodeToString(sum[:])
+	if _, err := Pull(context.Background(), srv.Client(), m, t.TempDir(), nil); err != nil {
+		t.Errorf("Pull with a pinned digest: %v", err)
+	}
+	m.SHA256 = strings.Repeat("ab", sha256.Size)
+	if _, err := Pull(context.Background(), srv.Client(), m, t.TempDir(), nil); err == nil {
+		t.Error("Pull passed a download that doesn't match its pinned digest")
+	}
+}
+
+func TestLookupGroups(t *testing.T) {
+	ms, ok := Lookup("wakeword")
+	if !ok || len(ms) != 2 {
+		t.Fatalf("expected wakeword group to expand to 2 models, got %v", ms)
+	}
+	if ms, ok := Lookup("ggml-small.bin"); !ok || ms[0].Name != "small" {
+		t.Errorf("expected lookup by file name to find small, got %v", ms)
+	}
+	if _, ok := Lookup("nope"); ok {
+		t.Error("expected unknown model to miss")
+	}
+}
+
+func TestResolveFallsBackToCacheDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(EnvModelsDir, dir)
+	want := filepath.Join(dir, "ggml-tiny.bin")
+	os.WriteFile(want, fakeGGML(), 0o644)
+
+	got, ok := Resolve("does/not/exist/ggml-tiny.bin")
+	if !ok || got != want {
+		t.Errorf("expected %s, got %q (ok=%v)", want, got, ok)
+	}
+	if _, ok := Resolve("does/not/exist/ggml-huge.bin"); ok {
+		t.Error("expected missing model to be unresolved")
+	}
+}
@@ -0,0 +1,224 @@
+package models
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ggmlMagic is the little-endian header of every whisper.cpp model file.
+const ggmlMagic = 0x67676d6c
+
+// ErrUnverified is returned by Pull when neither the catalog nor the
+// server has a SHA-256 to check a download against.
+var ErrUnverified = errors.New("no published SHA-256 to verify the download against")
+
+// PullOption configures Pull.
+type PullOption func(*pullConfig)
+
+type pullConfig struct {
+	unverified bool
+}
+
+// AllowUnverified lets Pull install a model it can't check a SHA-256
+// for, instead of failing with ErrUnverified.
+func AllowUnverified() PullOption {
+	return func(c *pullConfig) { c.unverified = true }
+}
+
+// Pull downloads m into dir and verifies it before moving it into place,
+// so a partial or corrupt download never shadows a good file.  The
+// SHA-256 is checked against m.SHA256, or else the X-Linked-Etag the
+// server publishes (Hugging Face does for LFS files, on the redirect to
+// its CDN), and recorded in a <file>.sha256 sidecar.  With neither, Pull
+// fails with ErrUnverified unless AllowUnverified is given.  An
+// existing, verified copy is left untouched.
+//
+// progress, if non-nil, receives human-readable status lines.
+func Pull(ctx context.Context, client *http.Client, m Model, dir string, progress io.Writer, opts ...PullOption) (string, error) {
+	var cfg pullConfig
+	for _, o := range opts {
+		o(&cfg)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if progress == nil {
+		progress = io.Discard
+	}
+	dest := filepath.Join(dir, m.File)
+
+	if err := Verify(dest, m.Kind); err == nil {
+		fmt.Fprintf(progress, "%s: already present at %s\n", m.Name, dest)
+		return dest, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	// The digest is on the first response, not the CDN's it redirects
+	// to, so catch it on the way past.
+	var published string
+	hops := *client
+	hops.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if published == "" && req.Response != nil {
+			published = publishedSHA256(req.Response.Header)
+		}
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+	resp, err := hops.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", m.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading %s: %s", m.Name, resp.Status)
+	}
+	if published == "" {
+		published = publishedSHA256(resp.Header)
+	}
+	want := strings.ToLower(m.SHA256)
+	if want == "" {
+		want = published
+	}
+	if want == "" {
+		if !cfg.unverified {
+			return "", fmt.Errorf("%s: %w", m.Name, ErrUnverified)
+		}
+		fmt.Fprintf(progress, "%s: warning: no SHA-256 published, installing unverified\n", m.Name)
+	}
+
+	tmp, err := os.CreateTemp(dir, m.File+".part-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	fmt.Fprintf(progress, "%s: downloading %s (%s)\n", m.Name, m.URL, m.Size)
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), resp.Body)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", fmt.Errorf("downloading %s: %w", m.Name, err)
+	}
+	if resp.ContentLength > 0 && n != resp.ContentLength {
+		return "", fmt.Errorf("downloading %s: got %d bytes, expected %d", m.Name, n, resp.ContentLength)
+	}
+
+	sum := hex.EncodeToString(h.Sum(nil))
+	if want != "" && want != sum {
+		return "", fmt.Errorf("%s: checksum mismatch (got %s, expected %s)", m.Name, sum, want)
+	}
+	if err := checkFormat(tmp.Name(), m.Kind); err != nil {
+		return "", fmt.Errorf("%s: %w", m.Name, err)
+	}
+
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(dest+".sha256", []byte(sum+"\n"), 0o644); err != nil {
+		return "", err
+	}
+	fmt.Fprintf(progress, "%s: saved to %s (sha256 %s)\n", m.Name, dest, sum[:12])
+	return dest, nil
+}
+
+// Verify checks that path exists, looks like a model of the given kind,
+// and — when a .sha256 sidecar is present — still matches its checksum.
+func Verify(path string, kind Kind) error {
+	if err := checkFormat(path, kind); err != nil {
+		return err
+	}
+	want, err := os.ReadFile(path + ".sha256")
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	got, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if got != strings.TrimSpace(string(want)) {
+		return fmt.Errorf("%s: checksum does not match %s.sha256", path, filepath.Base(path))
+	}
+	return nil
+}
+
+// checkFormat reads the file header and rejects obvious garbage such as
+// an HTML error page saved under a model's name.
+func checkFormat(path string, kind Kind) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var head [4]byte
+	if _, err := io.ReadFull(f, head[:]); err != nil {
+		return fmt.Errorf("%s: too short to be a model", path)
+	}
+	switch kind {
+	case GGML:
+		if binary.LittleEndian.Uint32(head[:]) != ggmlMagic {
+			return fmt.Errorf("%s: not a GGML model (bad magic)", path)
+		}
+	case ONNX:
+		// An ONNX ModelProto starts with field 1 (ir_version, varint).
+		if head[0] != 0x08 {
+			return fmt.Errorf("%s: not an ONNX model", path)
+		}
+	}
+	return nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// publishedSHA256 extracts a SHA-256 from Hugging Face's X-Linked-Etag
+// header, which carries the LFS object hash.  Anything that isn't a
+// 64-character hex digest is ignored.
+func publishedSHA256(h http.Header) string {
+	etag := strings.Trim(h.Get("X-Linked-Etag"), `"`)
+	etag = strings.TrimPrefix(etag, "W/")
+	etag = strings.Trim(etag, `"`)
+	if len(etag) != sha256.Size*2 {
+		return ""
+	}
+	if _, err := hex.DecodeString(etag); err != nil {
+		return ""
+	}
+	return strings.ToLower(etag)
+}
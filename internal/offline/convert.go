@@ -0,0 +1,162 @@
+package offline
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ── Unit conversion ─────────────────────────────────────────────
+
+type dimension int
+
+const (
+	volume dimension = iota
+	mass
+	temperature
+)
+
+type unit struct {
+	name   string // how it's spoken in answers, plural
+	dim    dimension
+	factor float64 // millilitres or grams per unit; unused for temperature
+}
+
+var units = map[string]unit{
+	"teaspoon":    {"teaspoons", volume, 4.929},
+	"tsp":         {"teaspoons", volume, 4.929},
+	"tablespoon":  {"tablespoons", volume, 14.787},
+	"tbsp":        {"tablespoons", volume, 14.787},
+	"cup":         {"cups", volume, 236.588},
+	"fluid ounce": {"fluid ounces", volume, 29.574},
+	"fl oz":       {"fluid ounces", volume, 29.574},
+	"pint":        {"pints", volume, 473.176},
+	"quart":       {"quarts", volume, 946.353},
+	"gallon":      {"gallons", volume, 3785.41},
+	"milliliter":  {"millilitres", volume, 1},
+	"millilitre":  {"millilitres", volume, 1},
+	"ml":          {"millilitres", volume, 1},
+	"liter":       {"litres", volume, 1000},
+	"litre":       {"litres", volume, 1000},
+	"l":           {"litres", volume, 1000},
+	"gram":        {"grams", mass, 1},
+	"g":           {"grams", mass, 1},
+	"kilogram":    {"kilograms", mass, 1000},
+	"kilo":        {"kilograms", mass, 1000},
+	"kg":          {"kilograms", mass, 1000},
+	"ounce":       {"ounces", mass, 28.35},
+	"oz":          {"ounces", mass, 28.35},
+	"pound":       {"pounds", mass, 453.592},
+	"lb":          {"pounds", mass, 453.592},
+	"lbs":         {"pounds", mass, 453.592},
+	"celsius":     {"degrees Celsius", temperature, 0},
+	"centigrade":  {"degrees Celsius", temperature, 0},
+	"c":           {"degrees Celsius", temperature, 0},
+	"fahrenheit":  {"degrees Fahrenheit", temperature, 0},
+	"f":           {"degrees Fahrenheit", temperature, 0},
+}
+
+var (
+	// "2 cups to ml", "convert 350 f into celsius"
+	convertTo *regexp.Regexp
+	// "how many grams in a pound", "how many ml are in 3 tablespoons"
+	convertHowMany *regexp.Regexp
+)
+
+func init() {
+	names := make([]string, 0, len(units))
+	for k := range units {
+		names = append(names, regexp.QuoteMeta(k))
+	}
+	// Longest first so "fl oz" wins over "oz" and "lbs" over "lb".
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	u := `(` + strings.Join(names, "|") + `)(?:e?s)?\b`
+	num := `\b(half an?|\d+(?:\.\d+)?(?:/\d+)?|an?|one)`
+	convertTo = regexp.MustCompile(num + `\s*` + u + `\s+(?:to|in|into|as)\s+` + u)
+	convertHowMany = regexp.MustCompile(`how many\s+` + u + `\s+(?:are\s+|is\s+)?(?:in|to)\s+` + num + `\s*` + u)
+}
+
+// convert answers unit conversion questions.
+func convert(q string) (string, bool) {
+	q = strings.NewReplacer("degrees ", "", "degree ", "", "°", " ").Replace(q)
+	var amount, from, to string
+	if m := convertTo.FindStringSubmatch(q); m != nil {
+		amount, from, to = m[1], m[2], m[3]
+	} else if m := convertHowMany.FindStringSubmatch(q); m != nil {
+		to, amount, from = m[1], m[2], m[3]
+	} else {
+		return "", false
+	}
+	v, ok := parseAmount(amount)
+	if !ok {
+		return "", false
+	}
+	fu, tu := units[from], units[to]
+	if fu.name == tu.name {
+		return "", false
+	}
+	if fu.dim != tu.dim {
+		if fu.dim == temperature || tu.dim == temperature {
+			return "", false
+		}
+		return fmt.Sprintf("%s measure %s and %s measure %s, so it depends on the ingredient. A cup of water is about 240 grams, a cup of flour about 125.",
+			capitalize(fu.name), dimName(fu.dim), tu.name, dimName(tu.dim)), true
+	}
+
+	var out float64
+	if fu.dim == temperature {
+		if tu.name == "degrees Celsius" {
+			out = (v - 32) * 5 / 9
+		} else {
+			out = v*9/5 + 32
+		}
+		out = math.Round(out)
+	} else {
+		out = v * fu.factor / tu.factor
+	}
+	return fmt.Sprintf("%s %s is about %s %s.", formatAmount(v), fu.spoken(v), formatAmount(out), tu.spoken(out)), true
+}
+
+// spoken returns the unit name agreeing with amount v.
+func (u unit) spoken(v float64) string {
+	if v == 1 && u.dim != temperature {
+		return strings.TrimSuffix(u.name, "s")
+	}
+	return u.name
+}
+
+func parseAmount(s string) (float64, bool) {
+	switch s {
+	case "a", "an", "one":
+		return 1, true
+	case "half a", "half an":
+		return 0.5, true
+	}
+	if num, den, ok := strings.Cut(s, "/"); ok {
+		n, err1 := strconv.ParseFloat(num, 64)
+		d, err2 := strconv.ParseFloat(den, 64)
+		if err1 != nil || err2 != nil || d == 0 {
+			return 0, false
+		}
+		return n / d, true
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	return v, err == nil
+}
+
+func dimName(d dimension) string {
+	if d == mass {
+		return "weight"
+	}
+	return "volume"
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,58 @@
+package offline
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ── Glossary ────────────────────────────────────────────────────
+
+var glossary = map[string]string{
+	"al dente":      "cooked until tender but still firm when bitten, usually pasta",
+	"baste":         "spooning fat or cooking juices over food while it cooks to keep it moist",
+	"blanch":        "boiling briefly, then plunging into ice water to stop the cooking",
+	"bloom":         "soaking gelatin in cold liquid, or warming spices in fat to release their flavour",
+	"braise":        "browning, then cooking slowly in a little liquid with the lid on",
+	"brunoise":      "cutting into tiny, even cubes, about three millimetres",
+	"caramelize":    "cooking slowly until the sugars brown and turn sweet",
+	"chiffonade":    "stacking leaves, rolling them tight, and slicing into thin ribbons",
+	"cream":         "beating butter and sugar together until pale and fluffy",
+	"deglaze":       "pouring liquid into a hot pan and scraping up the browned bits",
+	"dice":          "cutting into even cubes",
+	"emulsify":      "whisking two liquids that don't mix, like oil and vinegar, into a smooth sauce",
+	"fold":          "gently mixing with a spatula, cutting down through the middle and turning over, so you don't knock the air out",
+	"julienne":      "cutting into thin matchsticks",
+	"knead":         "pushing, folding, and turning dough until it's smooth and springy",
+	"mince":         "chopping as finely as you can",
+	"mise en place": "having everything measured, chopped, and ready before you start cooking",
+	"parboil":       "boiling until partly cooked, to finish another way later",
+	"poach":         "cooking gently in liquid that's barely simmering",
+	"proof":         "letting dough rise, or checking that yeast is alive in warm water",
+	"reduce":        "simmering a liquid uncovered so it thickens and the flavour concentrates",
+	"render":        "cooking fatty meat slowly so the fat melts out",
+	"rest":          "leaving cooked meat alone for a few minutes so the juices settle",
+	"roux":          "equal parts fat and flour cooked together, used to thicken sauces",
+	"saute":         "cooking quickly in a little fat over fairly high heat, moving it around",
+	"sauté":         "cooking quickly in a little fat over fairly high heat, moving it around",
+	"score":         "cutting shallow lines into the surface",
+	"sear":          "browning the surface over very high heat",
+	"simmer":        "cooking just below a boil, with small bubbles breaking the surface now and then",
+	"temper":        "warming eggs slowly with a little hot liquid so they don't scramble, or heating and cooling chocolate so it sets glossy",
+	"whisk":         "beating quickly to add air or blend smoothly",
+	"zest":          "the coloured outer skin of citrus, grated without the bitter white pith",
+}
+
+// defineCue marks a question asking what a term means.
+var defineCue = regexp.MustCompile(`\b(mean|means|meaning|define|definition|what is|what's|what does|how do (i|you))\b`)
+
+// define answers "what does X mean" questions from the glossary.
+func define(q string) (string, bool) {
+	if !defineCue.MatchString(q) {
+		return "", false
+	}
+	term, ok := longestMatch(q, glossary)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%s is %s.", capitalize(term), glossary[term]), true
+}
@@ -0,0 +1,88 @@
+// Package offline answers simple cooking questions without the AI agent.
+// It's the fallback when GPT credentials are missing or the endpoint is
+// unreachable: a unit converter, a substitution table, safe cooking
+// temperatures, a glossary of techniques, drink pairings by style of
+// dish, and plain-text search over the recipes on hand.  Nothing here
+// is clever — it only answers what it can answer with certainty.
+package offline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// Answer tries each offline source in turn and returns the first answer.
+// current is the recipe being cooked (may be nil); library is every recipe
+// available for "which recipe..." questions.  ok is false when nothing
+// matched.
+func Answer(question string, current *domain.Recipe, library []*domain.Recipe) (answer string, ok bool) {
+	q := strings.ToLower(strings.TrimSpace(question))
+	if q == "" {
+		return "", false
+	}
+	sources := []func() (string, bool){
+		func() (string, bool) { return convert(q) },
+		func() (string, bool) { return substitute(q) },
+		func() (string, bool) { return foodSafety(q) },
+		func() (string, bool) { return define(q) },
+		func() (string, bool) { return pairWith(q, current) },
+		func() (string, bool) { return searchRecipe(q, current) },
+		func() (string, bool) { return searchLibrary(q, library) },
+	}
+	for _, src := range sources {
+		if a, ok := src(); ok {
+			return a, true
+		}
+	}
+	return "", false
+}
+
+// formatAmount prints a quantity the way you'd say it: whole numbers
+// bare, small amounts to one decimal.
+func formatAmount(v float64) string {
+	switch {
+	case v >= 100:
+		return fmt.Sprintf("%.0f", v)
+	case v == float64(int(v)):
+		return fmt.Sprintf("%d", int(v))
+	default:
+		s := fmt.Sprintf("%.1f", v)
+		if s == "0.0" {
+			s = fmt.Sprintf("%.2f", v)
+		}
+		return strings.TrimSuffix(s, ".0")
+	}
+}
+
+// containsWord reports whether phrase appears in s on word boundaries.
+func containsWord(s, phrase string) bool {
+	for i := 0; ; {
+		j := strings.Index(s[i:], phrase)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(phrase)
+		if (start == 0 || !isWordByte(s[start-1])) && (end == len(s) || !isWordByte(s[end])) {
+			return true
+		}
+		i = start + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '\''
+}
+
+// longestMatch returns the longest key of table found as a word (or its
+// plural) in q.
+func longestMatch[V any](q string, table map[string]V) (string, bool) {
+	best := ""
+	for key := range table {
+		if len(key) > len(best) && (containsWord(q, key) || containsWord(q, key+"s") || containsWord(q, key+"es")) {
+			best = key
+		}
+	}
+	return best, best != ""
+}
@@ -0,0 +1,90 @@
+package offline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+func testRecipe() *domain.Recipe {
+	return &domain.Recipe{
+		Name: "Garlic Pasta",
+		Ingredients: []domain.Ingredient{
+			{Name: "spaghetti", Quantity: 200, Unit: "grams"},
+			{Name: "garlic", Quantity: 4, Unit: "cloves"},
+			{Name: "olive oil", Quantity: 3, Unit: "tablespoons"},
+		},
+		Steps: []domain.Step{
+			{Order: 1, Instruction: "Boil the spaghetti in salted water until al dente."},
+			{Order: 2, Instruction: "Warm the oil and fry the sliced garlic until golden."},
+			{Order: 3, Instruction: "Toss the pasta with the garlic oil."},
+		},
+	}
+}
+
+func TestAnswer(t *testing.T) {
+	library := []*domain.Recipe{
+		testRecipe(),
+		{Name: "Leek Soup", Ingredients: []domain.Ingredient{{Name: "leeks"}, {Name: "potatoes"}}},
+	}
+	tests := []struct {
+		question string
+		want     string // substring of the answer
+	}{
+		{"how many grams in a pound?", "454 grams"},
+		{"convert 2 cups to ml", "473 millilitres"},
+		{"what is 350 degrees fahrenheit in celsius", "177 degrees Celsius"},
+		{"1/2 cup to tablespoons", "8 tablespoons"},
+		{"how many grams in a cup", "depends on the ingredient"},
+		{"what can I use instead of buttermilk?", "lemon juice or vinegar"},
+		{"I ran out of brown sugar", "molasses"},
+		{"what temperature is chicken safe at?", "74 degrees Celsius"},
+		{"is ground beef safe at 65 degrees", "71 degrees Celsius"},
+		{"how long can cooked rice sit out?", "more than an hour"},
+		{"what does deglaze mean?", "scraping up the browned bits"},
+		{"what wine goes with this?", "Chardonnay"},
+		{"what should I drink with this", "Chardonnay"},
+		{"how much garlic do I need?", "4 cloves garlic"},
+		{"how long do I fry the garlic?", "Step 2"},
+		{"which of my recipes use leeks?", "Leek Soup"},
+	}
+	for _, tt := range tests {
+		got, ok := Answer(tt.question, testRecipe(), library)
+		if !ok {
+			t.Errorf("Answer(%q): no answer", tt.question)
+			continue
+		}
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("Answer(%q) = %q, want it to contain %q", tt.question, got, tt.want)
+		}
+	}
+}
+
+func TestAnswerNoMatch(t *testing.T) {
+	library := []*domain.Recipe{testRecipe()}
+	for _, q := range []string{"", "what's the meaning of life?", "tell me a joke", "what side dish goes with this?", "which recipes have chocolate"} {
+		if got, ok := Answer(q, testRecipe(), library); ok {
+			t.Errorf("Answer(%q) = %q, expected no answer", q, got)
+		}
+	}
+}
+
+func TestPairingFor(t *testing.T) {
+	tests := []struct {
+		recipe *domain.Recipe
+		want   string // substring of the wine
+	}{
+		{testRecipe(), "Chardonnay"},
+		{&domain.Recipe{Name: "Chicken Alfredo", Tags: []string{"italian", "pasta", "chicken"},
+			Ingredients: []domain.Ingredient{{Name: "chicken breast"}, {Name: "gruyere cheese"}}}, "oaked Chardonnay"},
+		{&domain.Recipe{Name: "Green Curry", Ingredients: []domain.Ingredient{{Name: "shrimp"}}}, "Riesling"},
+		{&domain.Recipe{Name: "Vegetable Stir Fry", Tags: []string{"asian", "vegan"}}, "Grüner Veltliner"},
+		{&domain.Recipe{Name: "Toast"}, "rosé"},
+	}
+	for _, tt := range tests {
+		if got := PairingFor(tt.recipe); !strings.Contains(got.Wine, tt.want) || got.Soft == "" {
+			t.Errorf("PairingFor(%s) = %+v, want a wine like %q", tt.recipe.Name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package offline
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Drink pairings ──────────────────────────────────────────────
+
+// Pairing is what to drink with a dish.
+type Pairing struct {
+	Style string // the kind of dish it's paired as, e.g. "a creamy dish"
+	Wine  string
+	Soft  string // without alcohol
+}
+
+// pairings are tried in order, so the more telling styles come first: a
+// creamy chicken pasta pairs as creamy, not as chicken or as pasta.
+var pairings = []struct {
+	words []string // any of these in the tags, name, or ingredients
+	Pairing
+}{
+	{[]string{"chocolate", "dessert", "cake", "brownie", "cookie", "tart", "pudding"},
+		Pairing{"a dessert", "a tawny port or a Moscato d'Asti", "a strong coffee or cold milk"}},
+	{[]string{"spicy", "curry", "chili", "chilli", "jalapeno", "sriracha", "thai", "indian", "szechuan", "sichuan"},
+		Pairing{"a spicy dish", "an off-dry Riesling or a Gewürztraminer", "a mango lassi or ginger beer"}},
+	{[]string{"fish", "seafood", "salmon", "tuna", "cod", "shrimp", "prawn", "prawns", "mussels", "clams", "scallops", "crab"},
+		Pairing{"fish", "a Sauvignon Blanc or an Albariño", "sparkling water with lemon"}},
+	{[]string{"creamy", "cream", "alfredo", "carbonara", "gruyere", "cheese", "risotto"},
+		Pairing{"a creamy dish", "an oaked Chardonnay or a Soave", "sparkling apple juice"}},
+	{[]string{"beef", "steak", "lamb", "venison", "brisket", "burger", "bbq", "barbecue"},
+		Pairing{"red meat", "a Cabernet Sauvignon or a Malbec", "tart cherry juice or a strong black tea"}},
+	{[]string{"mushroom", "mushrooms", "duck", "pork", "sausage"},
+		Pairing{"an earthy dish", "a Pinot Noir", "cold-brewed black tea"}},
+	{[]string{"tomato", "tomatoes", "pizza", "bolognese", "marinara", "lasagna", "italian"},
+		Pairing{"a tomato dish", "a Chianti or a Barbera", "a blood orange soda"}},
+	{[]string{"asian", "stir fry", "soy sauce", "ginger", "teriyaki", "noodles"},
+		Pairing{"a stir fry", "a dry Riesling or a Grüner Veltliner", "jasmine tea or iced green tea"}},
+	{[]string{"chicken", "turkey", "poultry", "pasta"},
+		Pairing{"chicken or pasta", "a Chardonnay or a light Pinot Noir", "iced tea with lemon"}},
+	{[]string{"salad", "vegetables", "vegetable", "vegan", "vegetarian", "healthy"},
+		Pairing{"a light dish", "a Sauvignon Blanc or a dry rosé", "iced mint tea"}},
+}
+
+// anyPairing is for a dish the table doesn't recognise.
+var anyPairing = Pairing{"this", "a dry rosé", "sparkling water with citrus"}
+
+// PairingFor picks a drink for the recipe from its tags, name, and
+// ingredients.
+func PairingFor(r *domain.Recipe) Pairing {
+	parts := append([]string{r.Name}, r.Tags...)
+	for _, ing := range r.Ingredients {
+		parts = append(parts, ing.Name)
+	}
+	text := strings.ToLower(strings.Join(parts, ", "))
+	for _, p := range pairings {
+		for _, w := range p.words {
+			if containsWord(text, w) {
+				return p.Pairing
+			}
+		}
+	}
+	return anyPairing
+}
+
+// Sentence says the pairing.
+func (p Pairing) Sentence() string {
+	return fmt.Sprintf("With %s, try %s. Without alcohol, %s.", p.Style, p.Wine, p.Soft)
+}
+
+// pairingCue marks a question about what to drink: "goes with" or
+// "pair" alone could be about a side dish.
+var pairingCue = regexp.MustCompile(`\b(wine|wines|beer|beers|drink|drinks|beverage|beverages|cocktail|cocktails)\b`)
+
+// pairWith answers "what wine goes with this" for the current recipe.
+func pairWith(q string, current *domain.Recipe) (string, bool) {
+	if current == nil || !pairingCue.MatchString(q) {
+		return "", false
+	}
+	return PairingFor(current).Sentence(), true
+}
@@ -0,0 +1,207 @@
+package offline
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Food safety ─────────────────────────────────────────────────
+//
+// Safe internal temperatures and how long cooked food can sit out, per
+// food.  The temperatures are the usual food-agency figures, measured in
+// the thickest part; a rest means holding the meat off the heat that
+// long before carving or eating.
+
+// SafeTemp is the food-safety guidance for one food.
+type SafeTemp struct {
+	Food       string        // what it's called in answers
+	Celsius    int           // safe internal temperature; 0 when there isn't one to give
+	Fahrenheit int           // the same, as the US agencies round it
+	Rest       time.Duration // rest after cooking, 0 if none
+	MaxOut     time.Duration // longest it should sit at room temperature once cooked
+}
+
+// DefaultMaxOut is how long cooked perishable food can sit out: two
+// hours, less on a hot day.
+const DefaultMaxOut = 2 * time.Hour
+
+var (
+	poultry    = SafeTemp{Food: "poultry", Celsius: 74, Fahrenheit: 165, MaxOut: DefaultMaxOut}
+	groundMeat = SafeTemp{Food: "minced meat", Celsius: 71, Fahrenheit: 160, MaxOut: DefaultMaxOut}
+	wholeMeat  = SafeTemp{Food: "whole cuts", Celsius: 63, Fahrenheit: 145, Rest: 3 * time.Minute, MaxOut: DefaultMaxOut}
+	seafood    = SafeTemp{Food: "fish", Celsius: 63, Fahrenheit: 145, MaxOut: DefaultMaxOut}
+	eggDishes  = SafeTemp{Food: "egg dishes", Celsius: 71, Fahrenheit: 160, MaxOut: DefaultMaxOut}
+	leftovers  = SafeTemp{Food: "leftovers", Celsius: 74, Fahrenheit: 165, MaxOut: DefaultMaxOut}
+	cookedRice = SafeTemp{Food: "rice", MaxOut: time.Hour}
+)
+
+// safeTemps maps the words a recipe uses to the guidance for them.
+var safeTemps = map[string]SafeTemp{
+	"chicken":        named(poultry, "chicken"),
+	"turkey":         named(poultry, "turkey"),
+	"duck":           named(poultry, "duck"),
+	"poultry":        poultry,
+	"ground beef":    named(groundMeat, "ground beef"),
+	"minced beef":    named(groundMeat, "minced beef"),
+	"ground pork":    named(groundMeat, "ground pork"),
+	"minced pork":    named(groundMeat, "minced pork"),
+	"ground lamb":    named(groundMeat, "ground lamb"),
+	"minced lamb":    named(groundMeat, "minced lamb"),
+	"mince":          named(groundMeat, "mince"),
+	"burger":         named(groundMeat, "burgers"),
+	"meatball":       named(groundMeat, "meatballs"),
+	"sausage":        named(groundMeat, "sausages"),
+	"ground chicken": named(poultry, "ground chicken"),
+	"ground turkey":  named(poultry, "ground turkey"),
+	"beef":           named(wholeMeat, "beef"),
+	"steak":          named(wholeMeat, "steak"),
+	"pork":           named(wholeMeat, "pork"),
+	"lamb":           named(wholeMeat, "lamb"),
+	"veal":           named(wholeMeat, "veal"),
+	"ham":            named(wholeMeat, "fresh ham"),
+	"fish":           seafood,
+	"salmon":         named(seafood, "salmon"),
+	"tuna":           named(seafood, "tuna"),
+	"cod":            named(seafood, "cod"),
+	"shrimp":         named(seafood, "shrimp"),
+	"prawn":          named(seafood, "prawns"),
+	"scallop":        named(seafood, "scallops"),
+	"egg":            named(eggDishes, "eggs"),
+	"casserole":      named(leftovers, "casseroles"),
+	"leftover":       leftovers,
+	"cooked rice":    cookedRice,
+	"rice":           cookedRice,
+}
+
+func named(t SafeTemp, food string) SafeTemp {
+	t.Food = food
+	return t
+}
+
+// String gives the guidance the way a step shows it:
+// "chicken: 74°C / 165°F".
+func (t SafeTemp) String() string {
+	if t.Celsius == 0 {
+		return fmt.Sprintf("%s: out of the fridge no more than %s once cooked", t.Food, spokenHours(t.MaxOut))
+	}
+	s := fmt.Sprintf("%s: %d°C / %d°F", t.Food, t.Celsius, t.Fahrenheit)
+	if t.Rest > 0 {
+		s += fmt.Sprintf(", rest %d min", int(t.Rest.Minutes()))
+	}
+	return s
+}
+
+// FoodSafety returns the guidance for every food text mentions, longest
+// name first, so "ground beef" isn't also counted as "beef".
+func FoodSafety(text string) []SafeTemp {
+	q := strings.ToLower(text)
+	keys := make([]string, 0, len(safeTemps))
+	for k := range safeTemps {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	var out []SafeTemp
+	var matched []string
+	for _, k := range keys {
+		if !containsWord(q, k) && !containsWord(q, k+"s") && !containsWord(q, k+"es") {
+			continue
+		}
+		covered := false
+		for _, m := range matched {
+			if strings.Contains(m, k) {
+				covered = true
+				break
+			}
+		}
+		matched = append(matched, k)
+		if !covered {
+			out = append(out, safeTemps[k])
+		}
+	}
+	return out
+}
+
+// heatCue marks a step that cooks what it names, rather than one that
+// only cuts or seasons it.
+var heatCue = regexp.MustCompile(`(?i)\b(cook|cooked|roast|grill|fry|fried|sear|bake|broil|simmer|poach|brown|barbecue|bbq|saute|sauté|reheat|done|cooked through)\b`)
+
+// StepSafety is the temperature guidance for the foods a step cooks, or
+// nil when it doesn't cook any.
+func StepSafety(instruction string) []SafeTemp {
+	if !heatCue.MatchString(instruction) {
+		return nil
+	}
+	var out []SafeTemp
+	for _, t := range FoodSafety(instruction) {
+		if t.Celsius > 0 {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Perishable returns the food in the recipe that can sit out the least
+// time once cooked, and how long that is.  ok is false for a recipe
+// with nothing perishable in it.
+func Perishable(recipe *domain.Recipe) (SafeTemp, bool) {
+	text := recipe.Name
+	for _, ing := range recipe.Ingredients {
+		text += ". " + ing.Name
+	}
+	var best SafeTemp
+	for _, t := range FoodSafety(text) {
+		if best.MaxOut == 0 || t.MaxOut < best.MaxOut {
+			best = t
+		}
+	}
+	return best, best.MaxOut > 0
+}
+
+// safetyCue is what makes a question about food safety.
+var safetyCue = regexp.MustCompile(`\b(safe|safely|temperature|temp|internal|undercooked|raw|sit out|left out|leave out|stay out|room temperature|fridge)\b`)
+
+// sitOutCue marks a question about holding time rather than doneness.
+var sitOutCue = regexp.MustCompile(`\b(sit out|left out|leave (it |them )?out|stay out|room temperature|fridge|how long can)\b`)
+
+// foodSafety answers "what temperature is chicken safe at" and "how long
+// can cooked rice sit out".
+func foodSafety(q string) (string, bool) {
+	if !safetyCue.MatchString(q) {
+		return "", false
+	}
+	foods := FoodSafety(q)
+	if len(foods) == 0 {
+		return "", false
+	}
+	t := foods[0]
+	if sitOutCue.MatchString(q) || t.Celsius == 0 {
+		answer := fmt.Sprintf("Cooked %s shouldn't sit out more than %s", t.Food, spokenHours(t.MaxOut))
+		if t.MaxOut > time.Hour {
+			answer += ", or an hour on a hot day"
+		}
+		return answer + ". Then it goes in the fridge.", true
+	}
+	answer := fmt.Sprintf("%s is safe at %d degrees Celsius, %d Fahrenheit, in the thickest part.", capitalize(t.Food), t.Celsius, t.Fahrenheit)
+	if t.Rest > 0 {
+		answer += fmt.Sprintf(" Then let it rest %d minutes.", int(t.Rest.Minutes()))
+	}
+	return answer, true
+}
+
+func spokenHours(d time.Duration) string {
+	if d == time.Hour {
+		return "an hour"
+	}
+	return fmt.Sprintf("%d hours", int(d.Hours()))
+}
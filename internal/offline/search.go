@@ -0,0 +1,148 @@
+package offline
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Recipe text search ──────────────────────────────────────────
+
+var (
+	howMuch      = regexp.MustCompile(`\bhow (much|many)\b`)
+	aboutLibrary = regexp.MustCompile(`\b(which|what|any|other)\b.*\brecipes?\b|\brecipes? (with|that|using|use)\b`)
+)
+
+// stopwords never count as a match on their own.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "at": true, "be": true, "can": true,
+	"do": true, "does": true, "for": true, "from": true, "have": true, "how": true,
+	"i": true, "in": true, "is": true, "it": true, "long": true, "many": true, "much": true,
+	"my": true, "need": true, "of": true, "on": true, "or": true, "should": true,
+	"step": true, "that": true, "the": true, "this": true, "to": true, "use": true,
+	"uses": true, "using": true, "we": true, "what": true, "when": true, "which": true,
+	"with": true, "you": true, "recipe": true, "recipes": true, "any": true, "other": true,
+	"put": true, "add": true, "there": true, "i'm": true, "me": true, "tell": true,
+}
+
+// keywords returns the content words of s, lowercased and roughly
+// singularised.
+func keywords(s string) []string {
+	var out []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '\'' || r >= 0x80)
+	}) {
+		if stopwords[w] || len(w) < 3 {
+			continue
+		}
+		out = append(out, stem(w))
+	}
+	return out
+}
+
+func stem(w string) string {
+	switch {
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		return w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "oes") && len(w) > 4:
+		return w[:len(w)-2]
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && len(w) > 3:
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+// overlap counts how many of words appear in text.
+func overlap(words []string, text string) int {
+	have := map[string]bool{}
+	for _, w := range keywords(text) {
+		have[w] = true
+	}
+	n := 0
+	for _, w := range words {
+		if have[w] {
+			n++
+		}
+	}
+	return n
+}
+
+// searchRecipe answers from the current recipe: quantities for "how
+// much X" and, otherwise, the step that best matches the question.
+func searchRecipe(q string, r *domain.Recipe) (string, bool) {
+	if r == nil || aboutLibrary.MatchString(q) {
+		return "", false
+	}
+	words := keywords(q)
+	if len(words) == 0 {
+		return "", false
+	}
+
+	if howMuch.MatchString(q) {
+		for _, ing := range r.Ingredients {
+			if overlap(words, ing.Name) > 0 {
+				return fmt.Sprintf("%s calls for %s.", r.Name, describeIngredient(ing)), true
+			}
+		}
+	}
+
+	best, bestScore := -1, 0
+	for i, s := range r.Steps {
+		text := s.Instruction
+		for _, c := range s.Conditions {
+			text += " " + c.Description
+		}
+		if n := overlap(words, text); n > bestScore {
+			best, bestScore = i, n
+		}
+	}
+	if best < 0 {
+		return "", false
+	}
+	s := r.Steps[best]
+	return fmt.Sprintf("Step %d says: %s", s.Order, s.Instruction), true
+}
+
+// searchLibrary answers "which recipes use X" from every recipe on hand.
+func searchLibrary(q string, library []*domain.Recipe) (string, bool) {
+	if !aboutLibrary.MatchString(q) {
+		return "", false
+	}
+	words := keywords(q)
+	if len(words) == 0 {
+		return "", false
+	}
+	var names []string
+	for _, r := range library {
+		text := r.Name + " " + strings.Join(r.Tags, " ")
+		for _, ing := range r.Ingredients {
+			text += " " + ing.Name
+		}
+		if overlap(words, text) > 0 {
+			names = append(names, r.Name)
+		}
+	}
+	// No hits may only mean the question is worded unlike the recipes
+	// ("anything sweet?"), so it's left to the AI.
+	switch len(names) {
+	case 0:
+		return "", false
+	case 1:
+		return fmt.Sprintf("Just one: %s.", names[0]), true
+	default:
+		return fmt.Sprintf("%d recipes: %s.", len(names), strings.Join(names, ", ")), true
+	}
+}
+
+func describeIngredient(ing domain.Ingredient) string {
+	if ing.Quantity <= 0 {
+		return strings.TrimSpace(ing.SizeDescriptor + " " + ing.Name)
+	}
+	measure := ing.Unit
+	if ing.SizeDescriptor != "" {
+		measure = ing.SizeDescriptor
+	}
+	return strings.Join(strings.Fields(domain.FormatQuantity(ing.Quantity)+" "+measure+" "+ing.Name), " ")
+}
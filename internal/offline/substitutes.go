@@ -0,0 +1,70 @@
+package offline
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ── Substitutions ───────────────────────────────────────────────
+
+// substitutes maps an ingredient to what can stand in for it.
+var substitutes = map[string]string{
+	"buttermilk":        "a cup of milk with a tablespoon of lemon juice or vinegar, left for five minutes",
+	"butter":            "the same weight of margarine, or about three quarters as much oil",
+	"egg":               "a tablespoon of ground flax mixed with three tablespoons of water, or a quarter cup of applesauce in baking",
+	"baking powder":     "a quarter teaspoon of baking soda plus half a teaspoon of cream of tartar, per teaspoon",
+	"baking soda":       "three times as much baking powder",
+	"brown sugar":       "white sugar with a tablespoon of molasses per cup",
+	"heavy cream":       "three quarters of a cup of milk plus a quarter cup of melted butter, per cup. It won't whip",
+	"cream":             "milk with a little melted butter, or evaporated milk",
+	"sour cream":        "plain yogurt, one for one",
+	"yogurt":            "sour cream, one for one",
+	"milk":              "any unsweetened plant milk, or half evaporated milk and half water",
+	"white wine":        "stock with a splash of white wine vinegar or lemon juice",
+	"red wine":          "stock with a splash of red wine vinegar",
+	"wine":              "stock with a splash of vinegar or lemon juice",
+	"lemon juice":       "lime juice, or half as much vinegar",
+	"lime juice":        "lemon juice",
+	"vinegar":           "lemon juice, one for one",
+	"garlic":            "an eighth of a teaspoon of garlic powder per clove",
+	"onion":             "a tablespoon of onion powder per medium onion, or shallots or leeks",
+	"shallot":           "a small piece of onion with a pinch of garlic",
+	"fresh herbs":       "a third as much dried herb",
+	"cornstarch":        "twice as much plain flour",
+	"flour":             "for thickening, half as much cornstarch",
+	"honey":             "maple syrup, or a cup and a quarter of sugar plus a quarter cup of liquid per cup",
+	"maple syrup":       "honey, or brown sugar with a splash of water",
+	"sugar":             "three quarters as much honey, and cut the other liquid a little",
+	"parmesan":          "pecorino or grana padano",
+	"soy sauce":         "tamari, or coconut aminos",
+	"breadcrumbs":       "crushed crackers or rolled oats",
+	"stock":             "water with a bouillon cube, or water and a pinch of salt",
+	"broth":             "water with a bouillon cube, or water and a pinch of salt",
+	"tomato paste":      "three times as much tomato sauce, cooked down",
+	"mayonnaise":        "plain yogurt or sour cream",
+	"rice vinegar":      "apple cider vinegar with a pinch of sugar",
+	"fish sauce":        "soy sauce with a squeeze of lime",
+	"chili flakes":      "a pinch of cayenne",
+	"cayenne":           "chili flakes or hot paprika",
+	"olive oil":         "any neutral oil, or melted butter",
+	"oil":               "melted butter, or another neutral oil",
+	"mascarpone":        "cream cheese beaten with a little cream",
+	"ricotta":           "cottage cheese, blended smooth",
+	"self-rising flour": "a cup of plain flour with one and a half teaspoons of baking powder and a pinch of salt",
+}
+
+// substituteCue is what makes a question about substitution rather than
+// about the ingredient itself.
+var substituteCue = regexp.MustCompile(`\b(instead of|substitute|substitution|replace|replacement|swap|in place of|alternative|don'?t have|do not have|out of|ran out|run out|without)\b`)
+
+// substitute answers "what can I use instead of X" questions.
+func substitute(q string) (string, bool) {
+	if !substituteCue.MatchString(q) {
+		return "", false
+	}
+	name, ok := longestMatch(q, substitutes)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("Instead of %s, use %s.", name, substitutes[name]), true
+}
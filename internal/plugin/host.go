@@ -0,0 +1,160 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Host ─────────────────────────────────────────────────────────
+//
+// The host starts every plugin in a directory and routes input to them.
+// Plugins only get what the built-in commands leave: input the keyword
+// parser didn't recognise, or took for a question for the AI.  A plugin
+// intent comes out of the parser as domain.IntentPlugin with the payload
+// "<intent>: <input>" and domain.PluginArgs.
+
+// Host runs a set of plugins.
+type Host struct {
+	plugins []*Plugin
+	rules   []rule
+	owner   map[string]*Plugin // intent name → the plugin handling it
+}
+
+type rule struct {
+	regex  *regexp.Regexp
+	intent string
+}
+
+// Load starts every executable in dir.  A plugin that fails to start,
+// or whose patterns don't compile, is skipped and reported in the
+// error; the rest are still loaded.  A missing dir loads nothing.
+func Load(ctx context.Context, dir string) (*Host, error) {
+	h := &Host{owner: make(map[string]*Plugin)}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return h, fmt.Errorf("reading plugin dir: %w", err)
+	}
+
+	var errs []error
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+		p, err := Start(ctx, filepath.Join(dir, e.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := h.add(p); err != nil {
+			p.Close()
+			errs = append(errs, err)
+		}
+	}
+	return h, errors.Join(errs...)
+}
+
+// add registers a started plugin's intents.
+func (h *Host) add(p *Plugin) error {
+	var rules []rule
+	for _, in := range p.Intents {
+		if in.Name == "" || strings.Contains(in.Name, ":") {
+			return fmt.Errorf("plugin %s: bad intent name %q", p.Name, in.Name)
+		}
+		if other, ok := h.owner[in.Name]; ok {
+			return fmt.Errorf("plugin %s: intent %q is already %s's", p.Name, in.Name, other.Name)
+		}
+		for _, pat := range in.Patterns {
+			re, err := regexp.Compile(pat)
+			if err != nil {
+				return fmt.Errorf("plugin %s: intent %s: %w", p.Name, in.Name, err)
+			}
+			rules = append(rules, rule{regex: re, intent: in.Name})
+		}
+	}
+	for _, in := range p.Intents {
+		h.owner[in.Name] = p
+	}
+	h.rules = append(h.rules, rules...)
+	h.plugins = append(h.plugins, p)
+	return nil
+}
+
+// Plugins returns the running plugins, in the order they were loaded.
+func (h *Host) Plugins() []*Plugin {
+	return h.plugins
+}
+
+// Match returns the plugin intent input matches, if any.
+func (h *Host) Match(input string) (intent string, ok bool) {
+	for _, r := range h.rules {
+		if r.regex.MatchString(input) {
+			return r.intent, true
+		}
+	}
+	return "", false
+}
+
+// Handle passes a request to the plugin that owns its intent.
+func (h *Host) Handle(ctx context.Context, req Request) (*Reply, error) {
+	p, ok := h.owner[req.Intent]
+	if !ok {
+		return nil, fmt.Errorf("no plugin handles %q", req.Intent)
+	}
+	return p.Handle(ctx, req)
+}
+
+// Close stops every plugin.
+func (h *Host) Close() error {
+	var errs []error
+	for _, p := range h.plugins {
+		if err := p.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("plugin %s: %w", p.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ── Parser ───────────────────────────────────────────────────────
+
+// Compile-time interface check.
+var _ domain.IntentParser = (*Parser)(nil)
+
+// Parser lets plugins claim what another parser doesn't recognise.
+type Parser struct {
+	next domain.IntentParser
+	host *Host
+}
+
+// NewParser wraps next so that input it returns as unknown or as a
+// question goes to a plugin when one's pattern matches.
+func NewParser(next domain.IntentParser, host *Host) *Parser {
+	return &Parser{next: next, host: host}
+}
+
+// Parse implements domain.IntentParser.
+func (p *Parser) Parse(ctx context.Context, input string, session *domain.Session) (*domain.Intent, error) {
+	intent, err := p.next.Parse(ctx, input, session)
+	if err != nil || (intent.Type != domain.IntentUnknown && intent.Type != domain.IntentAskQuestion) {
+		return intent, err
+	}
+	trimmed := strings.TrimSpace(input)
+	if name, ok := p.host.Match(trimmed); ok {
+		return &domain.Intent{
+			Type:    domain.IntentPlugin,
+			Payload: name + ": " + trimmed,
+			Args:    domain.PluginArgs{Intent: name, Text: trimmed},
+		}, nil
+	}
+	return intent, nil
+}
@@ -0,0 +1,214 @@
+// Package plugin runs third-party extensions: a wine-pairing helper, a
+// shopping-list sync, anything that wants a command of its own without
+// a fork of ottocook.
+//
+// A plugin is an executable.  ottocook starts it and talks to it over
+// its stdin and stdout, one JSON object per line:
+//
+//	→ {"id":1,"method":"describe"}
+//	← {"id":1,"result":{"name":"wine","intents":[{"name":"wine_pairing",
+//	     "description":"suggest a wine for the dish",
+//	     "patterns":["(?i)^what wine (goes|would go) with (this|it)\\??$"]}]}}
+//	→ {"id":2,"method":"handle","params":{"intent":"wine_pairing",
+//	     "text":"what wine goes with this?","recipe":"Coq au vin","step":3}}
+//	← {"id":2,"result":{"say":"A light Burgundy.","print":["Pinot noir, Beaujolais"]}}
+//
+// describe is asked once, at start.  An intent's description is shown
+// in help and offered to the AI classifier alongside the built-in
+// commands.  handle is asked each time input matches one of the
+// plugin's patterns, or the classifier picks the intent; what it says
+// is spoken, what it prints is shown.  A reply may carry
+// {"error":"..."} instead of a result.  Anything the plugin writes to
+// stderr is ignored.
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrExited is returned by calls to a plugin that has stopped running.
+var ErrExited = errors.New("plugin exited")
+
+// Manifest is a plugin's answer to describe.
+type Manifest struct {
+	Name    string   `json:"name"`
+	Intents []Intent `json:"intents"`
+}
+
+// Intent is a command a plugin handles.
+type Intent struct {
+	Name        string   `json:"name"` // snake_case, unique across plugins
+	Description string   `json:"description"`
+	Patterns    []string `json:"patterns"` // Go regexps matched against the whole input
+	Examples    []string `json:"examples,omitempty"`
+}
+
+// Request is what handle is told.
+type Request struct {
+	Intent   string `json:"intent"`
+	Text     string `json:"text"`             // the input as typed or heard
+	Recipe   string `json:"recipe,omitempty"` // the recipe being cooked or selected
+	Step     int    `json:"step,omitempty"`   // 1-based step being cooked; 0 when not cooking
+	StepText string `json:"step_text,omitempty"`
+}
+
+// Reply is what handle answers.
+type Reply struct {
+	Say   string   `json:"say,omitempty"`   // spoken, and printed as chat
+	Print []string `json:"print,omitempty"` // shown, not spoken
+}
+
+type request struct {
+	ID     int    `json:"id"`
+	Method string `json:"method"`
+	Params any    `json:"params,omitempty"`
+}
+
+type response struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+}
+
+// Plugin is a running plugin process.
+type Plugin struct {
+	Manifest
+	path string
+	cmd  *exec.Cmd
+
+	mu     sync.Mutex // one call at a time
+	stdin  io.WriteCloser
+	nextID int
+	out    chan response
+	done   chan struct{} // closed when stdout closes
+}
+
+// describeTimeout bounds how long a plugin has to describe itself, so
+// one that never answers can't hold up startup.
+var describeTimeout = 5 * time.Second
+
+// Start runs the plugin at path and asks it to describe itself.
+func Start(ctx context.Context, path string) (*Plugin, error) {
+	cmd := exec.Command(path)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", path, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", path, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting plugin %s: %w", path, err)
+	}
+	p := &Plugin{
+		path:  path,
+		cmd:   cmd,
+		stdin: stdin,
+		out:   make(chan response),
+		done:  make(chan struct{}),
+	}
+	go p.read(stdout)
+
+	ctx, cancel := context.WithTimeout(ctx, describeTimeout)
+	defer cancel()
+	if err := p.call(ctx, "describe", nil, &p.Manifest); err != nil {
+		p.Close()
+		return nil, err
+	}
+	if p.Name == "" {
+		p.Name = filepath.Base(path)
+	}
+	return p, nil
+}
+
+// read passes the plugin's replies to call until stdout closes.  Lines
+// that aren't JSON are skipped.
+func (p *Plugin) read(stdout io.Reader) {
+	defer close(p.done)
+	sc := bufio.NewScanner(stdout)
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+	for sc.Scan() {
+		var r response
+		if json.Unmarshal(sc.Bytes(), &r) != nil {
+			continue
+		}
+		select {
+		case p.out <- r:
+		case <-time.After(replyWait):
+			// Nobody's waiting: the call it answers gave up.
+		}
+	}
+}
+
+// replyWait is how long read holds a reply for a caller that has gone.
+const replyWait = time.Second
+
+// Handle asks the plugin to handle one of its intents.
+func (p *Plugin) Handle(ctx context.Context, req Request) (*Reply, error) {
+	var reply Reply
+	if err := p.call(ctx, "handle", req, &reply); err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+// call sends one request and waits for its reply.
+func (p *Plugin) call(ctx context.Context, method string, params, result any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.nextID++
+	id := p.nextID
+	line, err := json.Marshal(request{ID: id, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("plugin %s: encoding %s: %w", p.path, method, err)
+	}
+	if _, err := p.stdin.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("plugin %s: %w", p.path, ErrExited)
+	}
+	for {
+		select {
+		case r := <-p.out:
+			if r.ID != id {
+				continue // a late reply to a call that timed out
+			}
+			if r.Error != "" {
+				return fmt.Errorf("plugin %s: %s", p.path, r.Error)
+			}
+			if err := json.Unmarshal(r.Result, result); err != nil {
+				return fmt.Errorf("plugin %s: decoding %s reply: %w", p.path, method, err)
+			}
+			return nil
+		case <-p.done:
+			return fmt.Errorf("plugin %s: %w", p.path, ErrExited)
+		case <-ctx.Done():
+			return fmt.Errorf("plugin %s: %s: %w", p.path, method, ctx.Err())
+		}
+	}
+}
+
+// Close stops the plugin: its stdin is closed, and if it hasn't exited
+// within a second it's killed.
+func (p *Plugin) Close() error {
+	p.stdin.Close()
+	select {
+	case <-p.done:
+	case <-time.After(closeWait):
+		p.cmd.Process.Kill()
+		<-p.done
+	}
+	return p.cmd.Wait()
+}
+
+// closeWait is how long a plugin has to exit after its stdin closes.
+const closeWait = time.Second
@@ -0,0 +1,141 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// TestMain doubles as the plugin under test: the test binary re-run
+// with OTTO_TEST_PLUGIN set answers the protocol on stdin and stdout.
+func TestMain(m *testing.M) {
+	switch os.Getenv("OTTO_TEST_PLUGIN") {
+	case "":
+	case "silent":
+		io.Copy(io.Discard, os.Stdin)
+		return
+	default:
+		fakePlugin()
+		return
+	}
+	os.Exit(m.Run())
+}
+
+func fakePlugin() {
+	sc := bufio.NewScanner(os.Stdin)
+	for sc.Scan() {
+		var req struct {
+			ID     int     `json:"id"`
+			Method string  `json:"method"`
+			Params Request `json:"params"`
+		}
+		json.Unmarshal(sc.Bytes(), &req)
+		fmt.Println("not json, skipped")
+		var reply any
+		switch {
+		case req.Method == "describe":
+			reply = map[string]any{"id": req.ID, "result": Manifest{Name: "wine", Intents: []Intent{
+				{Name: "wine_pairing", Patterns: []string{`(?i)^what wine goes with (this|it)\??$`}},
+				{Name: "broken", Patterns: []string{`(?i)^break$`}},
+			}}}
+		case req.Params.Intent == "wine_pairing":
+			reply = map[string]any{"id": req.ID, "result": Reply{Say: "Burgundy with the " + req.Params.Recipe}}
+		default:
+			reply = map[string]any{"id": req.ID, "error": "no idea"}
+		}
+		line, _ := json.Marshal(reply)
+		fmt.Println(string(line))
+	}
+}
+
+// stubParser returns the same intent for everything.
+type stubParser domain.IntentType
+
+func (s stubParser) Parse(ctx context.Context, input string, session *domain.Session) (*domain.Intent, error) {
+	return &domain.Intent{Type: domain.IntentType(s), Payload: input}, nil
+}
+
+func TestHost(t *testing.T) {
+	dir := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\nOTTO_TEST_PLUGIN=1 exec %q\n", os.Args[0])
+	if err := os.WriteFile(filepath.Join(dir, "wine"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "README"), []byte("not a plugin"), 0o644)
+
+	ctx := context.Background()
+	h, err := Load(ctx, dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	defer h.Close()
+	if n := len(h.Plugins()); n != 1 {
+		t.Fatalf("loaded %d plugins, want 1", n)
+	}
+
+	intent, err := NewParser(stubParser(domain.IntentAskQuestion), h).Parse(ctx, "What wine goes with this?", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if intent.Type != domain.IntentPlugin || intent.Payload != "wine_pairing: What wine goes with this?" {
+		t.Fatalf("Parse = %s %q, want plugin wine_pairing", intent.Type, intent.Payload)
+	}
+	if got, _ := NewParser(stubParser(domain.IntentAdvance), h).Parse(ctx, "What wine goes with this?", nil); got.Type != domain.IntentAdvance {
+		t.Errorf("a built-in command went to the plugin: %s", got.Type)
+	}
+
+	args := intent.Args.(domain.PluginArgs)
+	reply, err := h.Handle(ctx, Request{Intent: args.Intent, Text: args.Text, Recipe: "coq au vin"})
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if reply.Say != "Burgundy with the coq au vin" {
+		t.Errorf("Say = %q", reply.Say)
+	}
+	if _, err := h.Handle(ctx, Request{Intent: "broken", Text: "break"}); err == nil || !strings.Contains(err.Error(), "no idea") {
+		t.Errorf("Handle(broken) = %v, want the plugin's error", err)
+	}
+	if _, err := h.Handle(ctx, Request{Intent: "nobody", Text: "hi"}); err == nil {
+		t.Error("Handle of an intent no plugin owns succeeded")
+	}
+}
+
+func TestLoadMissingDir(t *testing.T) {
+	h, err := Load(context.Background(), filepath.Join(t.TempDir(), "none"))
+	if err != nil || len(h.Plugins()) != 0 {
+		t.Errorf("Load(missing) = %d plugins, %v", len(h.Plugins()), err)
+	}
+}
+
+func TestLoadSkipsSilentPlugin(t *testing.T) {
+	defer func(d time.Duration) { describeTimeout = d }(describeTimeout)
+	describeTimeout = 100 * time.Millisecond
+
+	dir := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\nOTTO_TEST_PLUGIN=silent exec %q\n", os.Args[0])
+	if err := os.WriteFile(filepath.Join(dir, "mute"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	h, err := Load(context.Background(), dir)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Load = %v, want the describe to time out", err)
+	}
+	if n := len(h.Plugins()); n != 0 {
+		t.Errorf("loaded %d plugins, want none", n)
+	}
+	if took := time.Since(start); took > 5*time.Second {
+		t.Errorf("Load took %s", took)
+	}
+}
@@ -0,0 +1,214 @@
+package recipe
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// Index is a BM25 full-text index over every recipe in a source, so
+// questions like "which of my recipes uses leeks?" can be answered from
+// the library rather than just the recipe being cooked.  It rebuilds
+// itself whenever a recipe is added, removed, or changes version.
+type Index struct {
+	src domain.RecipeSource
+
+	mu     sync.Mutex
+	stamp  string // IDs and versions the index was built from
+	docs   []indexedDoc
+	df     map[string]int // term -> number of docs containing it
+	avgLen float64
+}
+
+type indexedDoc struct {
+	recipe *domain.Recipe
+	tf     map[string]int
+	length int
+}
+
+// BM25 parameters — the usual defaults.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Field weights: a match in the name or ingredient list says more about
+// a recipe than a passing mention in a step.
+const (
+	weightName       = 3
+	weightIngredient = 2
+	weightTag        = 2
+	weightText       = 1
+)
+
+// NewIndex creates an index over src.  It's built lazily on first use.
+func NewIndex(src domain.RecipeSource) *Index {
+	return &Index{src: src}
+}
+
+// Retrieve returns up to k recipes matching query, best first.  Recipes
+// sharing no terms with the query are never returned.
+func (x *Index) Retrieve(ctx context.Context, query string, k int) ([]*domain.Recipe, error) {
+	if err := x.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	qterms := uniqueTerms(query)
+	n := float64(len(x.docs))
+	type hit struct {
+		recipe *domain.Recipe
+		score  float64
+	}
+	var hits []hit
+	for _, d := range x.docs {
+		score := 0.0
+		for _, t := range qterms {
+			tf := float64(d.tf[t])
+			if tf == 0 {
+				continue
+			}
+			df := float64(x.df[t])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			norm := tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*float64(d.length)/x.avgLen))
+			score += idf * norm
+		}
+		if score > 0 {
+			hits = append(hits, hit{d.recipe, score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+
+	if k > 0 && len(hits) > k {
+		hits = hits[:k]
+	}
+	out := make([]*domain.Recipe, len(hits))
+	for i, h := range hits {
+		out[i] = h.recipe
+	}
+	return out, nil
+}
+
+// refresh rebuilds the index if the source's recipes have changed.  The
+// listing carries each recipe's version, so an unchanged library costs
+// one List; recipes are only fetched when something moved.
+func (x *Index) refresh(ctx context.Context) error {
+	summaries, err := x.src.List(ctx)
+	if err != nil {
+		return fmt.Errorf("index: list recipes: %w", err)
+	}
+	var stamp strings.Builder
+	for _, s := range summaries {
+		fmt.Fprintf(&stamp, "%s@%d;", s.ID, s.Version)
+	}
+	x.mu.Lock()
+	fresh := stamp.String() == x.stamp
+	x.mu.Unlock()
+	if fresh {
+		return nil
+	}
+
+	recipes := make([]*domain.Recipe, 0, len(summaries))
+	for _, s := range summaries {
+		r, err := x.src.Get(ctx, s.ID)
+		if err != nil {
+			return fmt.Errorf("index: get %s: %w", s.ID, err)
+		}
+		recipes = append(recipes, r)
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.docs = x.docs[:0]
+	x.df = make(map[string]int)
+	total := 0
+	for _, r := range recipes {
+		d := indexedDoc{recipe: r, tf: make(map[string]int)}
+		add := func(text string, weight int) {
+			for _, t := range terms(text) {
+				d.tf[t] += weight
+				d.length += weight
+			}
+		}
+		add(r.Name, weightName)
+		add(r.Description, weightText)
+		for _, tag := range r.Tags {
+			add(tag, weightTag)
+		}
+		for _, ing := range r.Ingredients {
+			add(ing.Name, weightIngredient)
+		}
+		for _, s := range r.Steps {
+			add(s.Instruction, weightText)
+		}
+		for t := range d.tf {
+			x.df[t]++
+		}
+		total += d.length
+		x.docs = append(x.docs, d)
+	}
+	x.avgLen = 1
+	if len(x.docs) > 0 && total > 0 {
+		x.avgLen = float64(total) / float64(len(x.docs))
+	}
+	x.stamp = stamp.String()
+	return nil
+}
+
+// indexStopwords are too common in recipes and questions to rank on.
+var indexStopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "any": true, "anything": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "can": true, "do": true, "does": true, "for": true,
+	"from": true, "have": true, "how": true, "i": true, "if": true, "in": true, "into": true,
+	"is": true, "it": true, "me": true, "my": true, "of": true, "on": true, "or": true,
+	"recipe": true, "recipes": true, "so": true, "something": true, "that": true, "the": true, "then": true,
+	"there": true, "this": true, "to": true, "until": true, "use": true, "uses": true,
+	"using": true, "we": true, "what": true, "which": true, "with": true, "you": true,
+	"your": true,
+}
+
+// terms splits text into lowercased, roughly singularised index terms.
+func terms(text string) []string {
+	var out []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if indexStopwords[w] || len(w) < 2 {
+			continue
+		}
+		out = append(out, singular(w))
+	}
+	return out
+}
+
+func uniqueTerms(text string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, t := range terms(text) {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func singular(w string) string {
+	switch {
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		return w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "oes") && len(w) > 4:
+		return w[:len(w)-2]
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && len(w) > 3:
+		return w[:len(w)-1]
+	}
+	return w
+}
@@ -0,0 +1,109 @@
+package recipe
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+func TestIndexRetrieve(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	idx := NewIndex(NewMemorySource(log))
+	ctx := context.Background()
+
+	tests := []struct {
+		query string
+		want  []string // recipe IDs, best first
+	}{
+		{"which of my recipes uses broccoli?", []string{"vegetable-stir-fry"}},
+		{"something with chicken and cheese", []string{"chicken-alfredo"}},
+		{"recipes with garlic", []string{"chicken-alfredo", "vegetable-stir-fry"}},
+		{"anything with leeks?", nil},
+	}
+	for _, tt := range tests {
+		got, err := idx.Retrieve(ctx, tt.query, 5)
+		if err != nil {
+			t.Fatalf("Retrieve(%q): %v", tt.query, err)
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("Retrieve(%q) returned %d recipes, want %d", tt.query, len(got), len(tt.want))
+			continue
+		}
+		// With one hit its ID must match; with several the scores may
+		// tie, so only check each expected recipe is among them.
+		for i, r := range got {
+			if len(tt.want) == 1 && r.ID != tt.want[i] {
+				t.Errorf("Retrieve(%q)[%d] = %s, want %s", tt.query, i, r.ID, tt.want[i])
+			}
+		}
+		for _, id := range tt.want {
+			if !slices.ContainsFunc(got, func(r *domain.Recipe) bool { return r.ID == id }) {
+				t.Errorf("Retrieve(%q) is missing %s", tt.query, id)
+			}
+		}
+	}
+}
+
+func TestIndexRebuildsOnUpdate(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	src := NewMemorySource(log)
+	idx := NewIndex(src)
+	ctx := context.Background()
+
+	if got, _ := idx.Retrieve(ctx, "leeks", 5); len(got) != 0 {
+		t.Fatalf("expected no leek recipes yet, got %d", len(got))
+	}
+
+	r, _ := src.Get(ctx, "chicken-alfredo")
+	r.Ingredients = append(r.Ingredients, domain.Ingredient{Name: "leek", Quantity: 1})
+	if err := src.Update(ctx, r); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	got, _ := idx.Retrieve(ctx, "which recipes use leeks", 5)
+	if len(got) != 1 || got[0].ID != "chicken-alfredo" {
+		t.Errorf("expected updated recipe to be found, got %v", got)
+	}
+}
+
+// countingSource counts the recipes fetched from it.
+type countingSource struct {
+	domain.RecipeSource
+	gets int
+}
+
+func (s *countingSource) Get(ctx context.Context, id string) (*domain.Recipe, error) {
+	s.gets++
+	return s.RecipeSource.Get(ctx, id)
+}
+
+func TestIndexRefreshOnlyFetchesChanges(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	mem := NewMemorySource(log)
+	src := &countingSource{RecipeSource: mem}
+	idx := NewIndex(src)
+	ctx := context.Background()
+
+	idx.Retrieve(ctx, "garlic", 5)
+	built := src.gets
+	if built == 0 {
+		t.Fatal("the first query fetched no recipes")
+	}
+	idx.Retrieve(ctx, "broccoli", 5)
+	if src.gets != built {
+		t.Errorf("an unchanged library fetched %d more recipes", src.gets-built)
+	}
+
+	r, _ := mem.Get(ctx, "chicken-alfredo")
+	r.Description += " With leeks."
+	if err := mem.Update(ctx, r); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	idx.Retrieve(ctx, "leeks", 5)
+	if src.gets == built {
+		t.Error("a new version didn't rebuild the index")
+	}
+}
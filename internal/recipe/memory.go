@@ -17,16 +17,18 @@ var _ domain.RecipeSource = (*MemorySource)(nil)
 
 // MemorySource holds recipes in memory. Safe for concurrent reads.
 type MemorySource struct {
-	mu      sync.RWMutex
-	recipes map[string]*domain.Recipe
-	log     *logger.Logger
+	mu       sync.RWMutex
+	recipes  map[string]*domain.Recipe
+	versions map[string][]domain.RecipeVersion // by recipe ID, oldest first
+	log      *logger.Logger
 }
 
 // NewMemorySource creates a recipe source preloaded with built-in recipes.
 func NewMemorySource(log *logger.Logger) *MemorySource {
 	src := &MemorySource{
-		recipes: make(map[string]*domain.Recipe),
-		log:     log,
+		recipes:  make(map[string]*domain.Recipe),
+		versions: make(map[string][]domain.RecipeVersion),
+		log:      log,
 	}
 	src.seed()
 	return src
@@ -41,12 +43,7 @@ func (s *MemorySource) List(ctx context.Context) ([]domain.RecipeSummary, error)
 
 	out := make([]domain.RecipeSummary, 0, len(s.recipes))
 	for _, r := range s.recipes {
-		out = append(out, domain.RecipeSummary{
-			ID:          r.ID,
-			Name:        r.Name,
-			Description: r.Description,
-			Tags:        r.Tags,
-		})
+		out = append(out, r.Summary())
 	}
 	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
 	return out, nil
@@ -79,28 +76,77 @@ func (s *MemorySource) Update(ctx context.Context, recipe *domain.Recipe) error
 	return nil
 }
 
-// Search returns recipes whose name or tags contain the query string.
+// Add stores a new recipe. The recipe ID must not already exist.
+func (s *MemorySource) Add(ctx context.Context, recipe *domain.Recipe) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.recipes[recipe.ID]; ok {
+		return domain.ErrAlreadyExists
+	}
+	if recipe.Version == 0 {
+		recipe.Version = 1
+	}
+	s.recipes[recipe.ID] = recipe
+	s.log.Info("recipe added: %s", recipe.Name)
+	return nil
+}
+
+// SaveVersion records a snapshot of a recipe version.  The snapshot
+// must not share slices with the live recipe.
+func (s *MemorySource) SaveVersion(ctx context.Context, recipeID string, v domain.RecipeVersion) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.recipes[recipeID]; !ok {
+		return domain.ErrNotFound
+	}
+	s.versions[recipeID] = append(s.versions[recipeID], v)
+	return nil
+}
+
+// Versions returns the recorded versions of a recipe, oldest first.
+func (s *MemorySource) Versions(ctx context.Context, recipeID string) ([]domain.RecipeVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.recipes[recipeID]; !ok {
+		return nil, domain.ErrNotFound
+	}
+	return append([]domain.RecipeVersion(nil), s.versions[recipeID]...), nil
+}
+
+// Search returns recipes matching every word of the query in their name,
+// description, tags, or ingredients, sorted by name.
 func (s *MemorySource) Search(ctx context.Context, query string) ([]domain.RecipeSummary, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	q := strings.ToLower(query)
-	s.log.Debug("searching recipes for: %s", q)
+	words := terms(query)
+	s.log.Debug("searching recipes for: %v", words)
+	if len(words) == 0 {
+		return nil, nil
+	}
 
 	var out []domain.RecipeSummary
 	for _, r := range s.recipes {
-		if s.matches(r, q) {
-			out = append(out, domain.RecipeSummary{
-				ID:          r.ID,
-				Name:        r.Name,
-				Description: r.Description,
-				Tags:        r.Tags,
-			})
+		if s.matchesAll(r, words) {
+			out = append(out, r.Summary())
 		}
 	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
 	return out, nil
 }
 
+func (s *MemorySource) matchesAll(r *domain.Recipe, words []string) bool {
+	for _, w := range words {
+		if !s.matches(r, w) {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *MemorySource) matches(r *domain.Recipe, query string) bool {
 	if strings.Contains(strings.ToLower(r.Name), query) {
 		return true
@@ -113,6 +159,11 @@ func (s *MemorySource) matches(r *domain.Recipe, query string) bool {
 			return true
 		}
 	}
+	for _, ing := range r.Ingredients {
+		if strings.Contains(strings.ToLower(ing.Name), query) {
+			return true
+		}
+	}
 	return false
 }
 
@@ -135,13 +186,17 @@ func (s *MemorySource) chickenAlfredo() *domain.Recipe {
 		Description: "Creamy spaghetti alfredo with pan-seared chicken. Rich, indulgent, and not from a jar.",
 		Servings:    2,
 		Tags:        []string{"italian", "pasta", "chicken", "comfort"},
+		PrepTime:    10 * time.Minute,
+		CookTime:    35 * time.Minute,
+		Difficulty:  domain.DifficultyMedium,
+		Equipment:   []string{"large pot", "skillet"},
 		Ingredients: []domain.Ingredient{
 			{Name: "spaghetti", Quantity: 250, Unit: "grams"},
 			{Name: "chicken breast", Quantity: 2, Unit: "pieces", SizeDescriptor: "medium"},
 			{Name: "creme fraiche", Quantity: 1, Unit: "cup"},
 			{Name: "gruyere cheese", Quantity: 1, Unit: "cup", SizeDescriptor: "grated"},
 			{Name: "margarine", Quantity: 3, Unit: "tablespoons"},
-			{Name: "garlic", Quantity: 4, Unit: "cloves", SizeDescriptor: "medium"},
+			{Name: "garlic", Quantity: 4, Unit: "cloves", SizeDescriptor: "medium", Prep: "minced"},
 			{Name: "olive oil", Quantity: 1, Unit: "tablespoon"},
 			{Name: "salt", Quantity: 0, Unit: "", SizeDescriptor: "to taste"},
 			{Name: "black pepper", Quantity: 0, Unit: "", SizeDescriptor: "to taste"},
@@ -173,6 +228,7 @@ func (s *MemorySource) chickenAlfredo() *domain.Recipe {
 					{Type: domain.ConditionTemperature, Description: "Internal temperature reaches 165°F / 74°C"},
 				},
 				TimerConfig: &domain.TimerConfig{Duration: 12 * time.Minute, Label: "Chicken searing"},
+				Equipment:   []string{"meat thermometer"},
 			},
 			{
 				ID: "ca-4", Order: 4,
@@ -182,6 +238,7 @@ func (s *MemorySource) chickenAlfredo() *domain.Recipe {
 					{Type: domain.ConditionTime, Description: "About 10 minutes or per package directions"},
 				},
 				TimerConfig: &domain.TimerConfig{Duration: 10 * time.Minute, Label: "Pasta cooking"},
+				Equipment:   []string{"colander"},
 			},
 			{
 				ID: "ca-5", Order: 5,
@@ -210,6 +267,7 @@ func (s *MemorySource) chickenAlfredo() *domain.Recipe {
 			{
 				ID: "ca-8", Order: 8,
 				Instruction: "Slice the rested chicken into strips. Toss the drained pasta into the sauce. Add the chicken on top. Serve immediately -- alfredo does not reheat well.",
+				Ingredients: []string{"chicken breast", "spaghetti"},
 				Conditions: []domain.StepCondition{
 					{Type: domain.ConditionManual, Description: "Plated with chicken on top"},
 				},
@@ -226,13 +284,17 @@ func (s *MemorySource) vegetableStirFry() *domain.Recipe {
 		Description: "Fast, crunchy, and customizable. The key is a screaming hot pan and not overcrowding it.",
 		Servings:    2,
 		Tags:        []string{"asian", "vegetables", "quick", "vegan", "healthy"},
+		PrepTime:    15 * time.Minute,
+		CookTime:    10 * time.Minute,
+		Difficulty:  domain.DifficultyEasy,
+		Equipment:   []string{"wok"},
 		Ingredients: []domain.Ingredient{
-			{Name: "bell pepper", Quantity: 1, Unit: "pieces", SizeDescriptor: "large"},
-			{Name: "broccoli florets", Quantity: 2, Unit: "cups"},
-			{Name: "carrot", Quantity: 1, Unit: "pieces", SizeDescriptor: "medium"},
-			{Name: "snap peas", Quantity: 1, Unit: "cup"},
-			{Name: "garlic", Quantity: 3, Unit: "cloves", SizeDescriptor: "medium"},
-			{Name: "fresh ginger", Quantity: 1, Unit: "tablespoon", SizeDescriptor: "grated"},
+			{Name: "bell pepper", Quantity: 1, Unit: "pieces", SizeDescriptor: "large", Prep: "sliced into strips"},
+			{Name: "broccoli florets", Quantity: 2, Unit: "cups", Prep: "cut into small florets"},
+			{Name: "carrot", Quantity: 1, Unit: "pieces", SizeDescriptor: "medium", Prep: "julienned"},
+			{Name: "snap peas", Quantity: 1, Unit: "cup", Prep: "trimmed"},
+			{Name: "garlic", Quantity: 3, Unit: "cloves", SizeDescriptor: "medium", Prep: "minced"},
+			{Name: "fresh ginger", Quantity: 1, Unit: "tablespoon", SizeDescriptor: "grated", Prep: "grated"},
 			{Name: "soy sauce", Quantity: 2, Unit: "tablespoons"},
 			{Name: "sesame oil", Quantity: 1, Unit: "tablespoon"},
 			{Name: "vegetable oil", Quantity: 2, Unit: "tablespoons"},
@@ -241,7 +303,7 @@ func (s *MemorySource) vegetableStirFry() *domain.Recipe {
 		},
 		Steps: []domain.Step{
 			{
-				ID: "vsf-1", Order: 1,
+				ID: "vsf-1", Order: 1, Section: "Rice", Optional: true,
 				Instruction:   "If serving with rice, start the rice first. Get that going before you touch anything else.",
 				ParallelHints: []string{"Rice cooks in the background while you prep and stir-fry"},
 				Conditions: []domain.StepCondition{
@@ -254,6 +316,7 @@ func (s *MemorySource) vegetableStirFry() *domain.Recipe {
 				Conditions: []domain.StepCondition{
 					{Type: domain.ConditionManual, Description: "All vegetables prepped and within arm's reach"},
 				},
+				Equipment: []string{"grater"},
 			},
 			{
 				ID: "vsf-3", Order: 3,
@@ -2,6 +2,7 @@ package recipe
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/hammamikhairi/ottocook/internal/domain"
@@ -73,6 +74,8 @@ func TestMemorySourceSearch(t *testing.T) {
 		{"chicken", 1},
 		{"pasta", 1},
 		{"vegan", 1},
+		{"broccoli", 1}, // ingredient
+		{"garlic chicken", 1},
 		{"nonexistent-query-xyz", 0},
 	}
 
@@ -88,3 +91,52 @@ func TestMemorySourceSearch(t *testing.T) {
 		})
 	}
 }
+
+func TestRecipeAllEquipment(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	src := NewMemorySource(log)
+
+	r, err := src.Get(context.Background(), "chicken-alfredo")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	// Recipe equipment first, then step extras; a step repeating
+	// something already listed doesn't add it twice.
+	r.Steps[4].Equipment = []string{"Skillet"}
+
+	got := strings.Join(r.AllEquipment(), ", ")
+	want := "large pot, skillet, meat thermometer, colander"
+	if got != want {
+		t.Fatalf("AllEquipment() = %q, want %q", got, want)
+	}
+}
+
+func TestRecipeStepIngredients(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	src := NewMemorySource(log)
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		recipe string
+		step   int
+		want   string
+	}{
+		{"chicken-alfredo", 4, "margarine, garlic"},                  // read from the instruction
+		{"chicken-alfredo", 7, "spaghetti, chicken breast"},          // named by the step
+		{"chicken-alfredo", 1, "chicken breast, salt, black pepper"}, // "pepper" picks out black pepper
+		{"vegetable-stir-fry", 2, "soy sauce, sesame oil, cornstarch"},
+		{"vegetable-stir-fry", 1, "bell pepper, broccoli florets, carrot, snap peas, garlic, fresh ginger"},
+	} {
+		r, err := src.Get(ctx, tt.recipe)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		var names []string
+		for _, ing := range r.StepIngredients(tt.step) {
+			names = append(names, ing.Name)
+		}
+		if got := strings.Join(names, ", "); got != tt.want {
+			t.Errorf("%s step %d uses %q, want %q", tt.recipe, tt.step+1, got, tt.want)
+		}
+	}
+}
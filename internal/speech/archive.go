@@ -0,0 +1,114 @@
+package speech
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/wakeword"
+)
+
+// ── Audio archive ────────────────────────────────────────────────
+//
+// The WAVs whisper transcribes are temp files, gone by the time anyone
+// wonders why "next" came out as "text".  With an archive set, the ear
+// also keeps each command as it was heard: <time>.wav, and <time>.txt
+// with the transcription and its confidence beside it.  The archive is
+// pruned after every clip, oldest first, to stay under its size cap and
+// age limit.
+
+// DefaultAudioDir is where kept commands go.
+const DefaultAudioDir = ".otto-stt/audio"
+
+// AudioArchive keeps captured voice commands on disk.
+type AudioArchive struct {
+	dir      string
+	maxBytes int64         // total size of the archive; 0 for no cap
+	maxAge   time.Duration // older clips are deleted; 0 keeps them
+}
+
+// NewAudioArchive keeps clips in dir, pruned to maxBytes and maxAge.
+func NewAudioArchive(dir string, maxBytes int64, maxAge time.Duration) *AudioArchive {
+	return &AudioArchive{dir: dir, maxBytes: maxBytes, maxAge: maxAge}
+}
+
+// Keep saves a command's audio (16 kHz mono) and transcription, then
+// prunes the archive.  Returns the WAV's path.
+func (a *AudioArchive) Keep(pcm []int16, text string, confidence float64, at time.Time) (string, error) {
+	if err := os.MkdirAll(a.dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating audio archive: %w", err)
+	}
+	base := filepath.Join(a.dir, at.Format("20060102-150405.000"))
+	if err := wakeword.WriteWAV(base+".wav", pcm); err != nil {
+		return "", fmt.Errorf("writing clip: %w", err)
+	}
+	note := fmt.Sprintf("%s\nconfidence %.2f\n", text, confidence)
+	if err := os.WriteFile(base+".txt", []byte(note), 0o644); err != nil {
+		return "", fmt.Errorf("writing transcription: %w", err)
+	}
+	if err := a.prune(at); err != nil {
+		return base + ".wav", fmt.Errorf("pruning audio archive: %w", err)
+	}
+	return base + ".wav", nil
+}
+
+// prune deletes clips older than maxAge, then the oldest until the
+// archive fits in maxBytes.  A clip's .wav and .txt go together.
+func (a *AudioArchive) prune(now time.Time) error {
+	type clip struct {
+		base string
+		mod  time.Time
+		size int64
+	}
+	clips := make(map[string]*clip)
+	err := filepath.WalkDir(a.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		ext := filepath.Ext(path)
+		if ext != ".wav" && ext != ".txt" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		base := strings.TrimSuffix(path, ext)
+		c := clips[base]
+		if c == nil {
+			c = &clip{base: base}
+			clips[base] = c
+		}
+		c.size += info.Size()
+		if ext == ".wav" {
+			c.mod = info.ModTime()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sorted := make([]*clip, 0, len(clips))
+	var total int64
+	for _, c := range clips {
+		sorted = append(sorted, c)
+		total += c.size
+	}
+	slices.SortFunc(sorted, func(x, y *clip) int { return strings.Compare(x.base, y.base) })
+	for _, c := range sorted[:max(0, len(sorted)-1)] { // the newest clip always stays
+		tooOld := a.maxAge > 0 && now.Sub(c.mod) > a.maxAge
+		tooBig := a.maxBytes > 0 && total > a.maxBytes
+		if !tooOld && !tooBig {
+			continue
+		}
+		os.Remove(c.base + ".wav")
+		os.Remove(c.base + ".txt")
+		total -= c.size
+	}
+	return nil
+}
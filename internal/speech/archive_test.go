@@ -0,0 +1,80 @@
+package speech
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// kept lists the clips in dir by name, without extension.
+func kept(t *testing.T, dir string) []string {
+	t.Helper()
+	wavs, err := filepath.Glob(filepath.Join(dir, "*.wav"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, w := range wavs {
+		base := strings.TrimSuffix(w, ".wav")
+		if _, err := os.Stat(base + ".txt"); err != nil {
+			t.Errorf("%s has no transcription beside it", filepath.Base(w))
+		}
+		names = append(names, filepath.Base(base))
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestAudioArchivePrune(t *testing.T) {
+	pcm := make([]int16, 1600)
+	start := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	at := func(i int) time.Time { return start.Add(time.Duration(i) * time.Minute) }
+	name := func(i int) string { return at(i).Format("20060102-150405.000") }
+
+	// clipSize is what one clip of pcm takes on disk.
+	probe := NewAudioArchive(t.TempDir(), 0, 0)
+	wav, err := probe.Keep(pcm, "next", 0.9, at(0))
+	if err != nil {
+		t.Fatalf("Keep: %v", err)
+	}
+	w, _ := os.Stat(wav)
+	txt, _ := os.Stat(strings.TrimSuffix(wav, ".wav") + ".txt")
+	clipSize := w.Size() + txt.Size()
+
+	tests := []struct {
+		name     string
+		maxBytes int64
+		maxAge   time.Duration
+		old      int // clips, from the first, whose files are two hours old
+		want     []string
+	}{
+		{"no limits", 0, 0, 3, []string{name(0), name(1), name(2), name(3)}},
+		{"size cap drops the oldest", 2 * clipSize, 0, 0, []string{name(2), name(3)}},
+		{"age limit", 0, time.Hour, 2, []string{name(2), name(3)}},
+		{"both", 3 * clipSize, time.Hour, 1, []string{name(1), name(2), name(3)}},
+		{"the newest stays over the cap", clipSize / 2, 0, 0, []string{name(3)}},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		a := NewAudioArchive(dir, tt.maxBytes, tt.maxAge)
+		for i := range 4 {
+			if _, err := a.Keep(pcm, "next", 0.9, at(i)); err != nil {
+				t.Fatalf("%s: Keep %d: %v", tt.name, i, err)
+			}
+		}
+		now := time.Now()
+		for i := range tt.old {
+			old := now.Add(-2 * time.Hour)
+			os.Chtimes(filepath.Join(dir, name(i)+".wav"), old, old)
+		}
+		if err := a.prune(now); err != nil {
+			t.Fatalf("%s: prune: %v", tt.name, err)
+		}
+		if got := kept(t, dir); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: kept %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
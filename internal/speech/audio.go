@@ -0,0 +1,107 @@
+package speech
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/wakeword"
+)
+
+// ── Voice off and on ─────────────────────────────────────────────
+//
+// Audio starts and stops the audio subsystems together: the mouth and
+// its player, the wake word detector and the ear.  "Voice off" closes
+// every one, freeing the sound card and the microphone and ending the
+// goroutines that drive them; "voice on" starts them again on the same
+// objects, so caches, tuned thresholds and subscriptions carry over
+// and nothing has to be rewired.
+
+// Audio is the audio side of Otto, to be switched off and on as one.
+// Any part may be nil.
+type Audio struct {
+	mouth    *Mouth
+	player   *Player
+	detector *wakeword.Detector
+	ear      *Ear
+	log      *logger.Logger
+
+	mu     sync.Mutex
+	on     bool
+	cancel context.CancelFunc // ends what Start started, even parts still starting
+}
+
+// NewAudio gathers the audio subsystems, not yet started.
+func NewAudio(mouth *Mouth, player *Player, detector *wakeword.Detector, ear *Ear, log *logger.Logger) *Audio {
+	return &Audio{mouth: mouth, player: player, detector: detector, ear: ear, log: log}
+}
+
+// Start brings up whatever isn't running, speech first, so the ear
+// has a mouth to keep out of the way of.  Parts run until ctx is done
+// or Close.
+func (v *Audio) Start(ctx context.Context) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.on {
+		return
+	}
+	v.on = true
+	ctx, v.cancel = context.WithCancel(ctx)
+
+	if v.player != nil {
+		if err := v.player.Reopen(); err != nil {
+			v.log.Error("audio: reopening the audio player: %v", err)
+		}
+	}
+	if v.mouth != nil {
+		v.mouth.Start(ctx)
+	}
+	if v.detector != nil {
+		go func() {
+			err := v.detector.Start(ctx)
+			if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, wakeword.ErrRunning) {
+				v.log.Error("wakeword detector failed: %v", err)
+			}
+		}()
+	}
+	if v.ear != nil {
+		go v.ear.Run(ctx)
+	}
+	v.log.Info("audio: on")
+}
+
+// Close stops everything Start started, ear first, and waits for each
+// part to let go of its device.
+func (v *Audio) Close() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.on {
+		return
+	}
+	v.on = false
+	v.cancel()
+
+	if v.ear != nil {
+		v.ear.Close()
+	}
+	if v.detector != nil {
+		v.detector.Close()
+	}
+	if v.mouth != nil {
+		v.mouth.Close()
+	}
+	if v.player != nil {
+		if err := v.player.Close(); err != nil {
+			v.log.Error("audio: closing the audio player: %v", err)
+		}
+	}
+	v.log.Info("audio: off")
+}
+
+// On reports whether the audio is started.
+func (v *Audio) On() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.on
+}
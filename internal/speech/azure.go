@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hammamikhairi/ottocook/internal/logger"
@@ -35,18 +36,40 @@ func WithHTTPTimeout(d time.Duration) AzureOption {
 	}
 }
 
+// WithQuota guards synthesis with a rate limit and daily character
+// budget (see Quota).  Requests the quota turns away fail with ErrQuota.
+func WithQuota(q *Quota) AzureOption {
+	return func(c *AzureClient) {
+		c.quota = q
+	}
+}
+
 // AzureClient handles text-to-speech synthesis via Azure Cognitive Services.
 type AzureClient struct {
 	subscriptionKey string
 	region          string
-	voice           string
 	format          string
 	httpClient      *http.Client
+	quota           *Quota // nil = unlimited
 	log             *logger.Logger
+
+	mu    sync.Mutex
+	voice string
 }
 
 // Voice returns the configured voice name.
-func (c *AzureClient) Voice() string { return c.voice }
+func (c *AzureClient) Voice() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.voice
+}
+
+// SetVoice changes the voice, from the next line synthesized.
+func (c *AzureClient) SetVoice(voice string) {
+	c.mu.Lock()
+	c.voice = voice
+	c.mu.Unlock()
+}
 
 // NewAzureClient creates an Azure TTS client with the given credentials.
 func NewAzureClient(key, region string, log *logger.Logger, opts ...AzureOption) *AzureClient {
@@ -66,12 +89,26 @@ func NewAzureClient(key, region string, log *logger.Logger, opts ...AzureOption)
 	return c
 }
 
+// Quota returns the client's quota, or nil if it has none.
+func (c *AzureClient) Quota() *Quota { return c.quota }
+
 // Synthesize converts text to speech audio data (WAV bytes).
 func (c *AzureClient) Synthesize(ctx context.Context, text string) ([]byte, error) {
+	return c.SynthesizeAt(ctx, text, PriorityNormal)
+}
+
+// SynthesizeAt is Synthesize for speech of a given priority, which
+// decides whether it goes ahead when the quota is running low.
+func (c *AzureClient) SynthesizeAt(ctx context.Context, text string, priority Priority) ([]byte, error) {
+	if err := c.quota.Acquire(ctx, len([]rune(text)), priority); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("https://%s.tts.speech.microsoft.com/cognitiveservices/v1", c.region)
 
-	ssml := c.buildSSML(text)
-	c.log.Debug("azure tts: synthesizing %d chars with voice %s", len(text), c.voice)
+	voice := c.Voice()
+	ssml := buildSSML(voice, text)
+	c.log.Debug("azure tts: synthesizing %d chars with voice %s", len(text), voice)
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, strings.NewReader(ssml))
 	if err != nil {
@@ -104,9 +141,9 @@ func (c *AzureClient) Synthesize(ctx context.Context, text string) ([]byte, erro
 }
 
 // buildSSML creates SSML markup for the synthesis request.
-func (c *AzureClient) buildSSML(text string) string {
+func buildSSML(voice, text string) string {
 	return fmt.Sprintf(
 		`<speak version='1.0' xml:lang='en-US'><voice xml:lang='en-US' name='%s'>%s</voice></speak>`,
-		c.voice, text,
+		voice, text,
 	)
 }
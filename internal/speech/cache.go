@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/metrics"
 )
 
 // AudioCache is a thread-safe two-tier cache (in-memory + filesystem) for
@@ -23,13 +24,16 @@ import (
 // disabled, giving the user a warm start from previous runs.
 type AudioCache struct {
 	mu        sync.RWMutex
-	entries   map[string][]byte // hash -> WAV bytes
+	entries   map[string][]byte // hash -> audio bytes (WAV, MP3, or Ogg)
 	log       *logger.Logger
 	voice     string // included in every cache key
 	cacheDir  string // filesystem cache directory (empty = no disk layer)
 	diskWrite bool   // whether to persist new entries to disk
 	hits      int64
 	misses    int64
+
+	hitsTotal   *metrics.Counter // nil when metrics are disabled
+	missesTotal *metrics.Counter
 }
 
 // NewAudioCache creates an audio cache.
@@ -58,6 +62,12 @@ func NewAudioCache(voice, cacheDir string, diskWrite bool, log *logger.Logger) *
 	return c
 }
 
+// instrument mirrors hit/miss counts into the given registry.
+func (c *AudioCache) instrument(reg *metrics.Registry) {
+	c.hitsTotal = reg.Counter("ottocook_tts_cache_hits_total", "TTS audio cache hits (memory or disk).")
+	c.missesTotal = reg.Counter("ottocook_tts_cache_misses_total", "TTS audio cache misses.")
+}
+
 // Get returns cached audio for the given text and true, or nil and false.
 // It checks the in-memory map first, then falls back to the disk cache.
 func (c *AudioCache) Get(text string) ([]byte, bool) {
@@ -72,6 +82,7 @@ func (c *AudioCache) Get(text string) ([]byte, bool) {
 		c.mu.Lock()
 		c.hits++
 		c.mu.Unlock()
+		c.hitsTotal.Inc()
 		c.log.Debug("cache hit (mem): %s (%d bytes)", truncateForLog(text, 40), len(data))
 		return data, true
 	}
@@ -84,6 +95,7 @@ func (c *AudioCache) Get(text string) ([]byte, bool) {
 			c.entries[key] = diskData
 			c.hits++
 			c.mu.Unlock()
+			c.hitsTotal.Inc()
 			c.log.Debug("cache hit (disk): %s (%d bytes)", truncateForLog(text, 40), len(diskData))
 			return diskData, true
 		}
@@ -92,6 +104,7 @@ func (c *AudioCache) Get(text string) ([]byte, bool) {
 	c.mu.Lock()
 	c.misses++
 	c.mu.Unlock()
+	c.missesTotal.Inc()
 	return nil, false
 }
 
@@ -155,20 +168,50 @@ func (c *AudioCache) Clear() {
 
 // ── hashing ──────────────────────────────────────────────────────
 
+// SetVoice changes the voice baked into keys, so lines are looked up
+// (and stored) for the new voice from now on.
+func (c *AudioCache) SetVoice(voice string) {
+	c.mu.Lock()
+	c.voice = voice
+	c.mu.Unlock()
+}
+
 // hashKey returns a hex-encoded SHA-256 of voice + ":" + text.
 func (c *AudioCache) hashKey(text string) string {
-	h := sha256.Sum256([]byte(c.voice + ":" + text))
+	c.mu.RLock()
+	voice := c.voice
+	c.mu.RUnlock()
+	h := sha256.Sum256([]byte(voice + ":" + text))
 	return hex.EncodeToString(h[:])
 }
 
 // ── disk helpers ─────────────────────────────────────────────────
 
-func (c *AudioCache) diskPath(key string) string {
-	return filepath.Join(c.cacheDir, key+".wav")
+// diskExts are the file extensions cache entries may have on disk, one
+// per audio format.
+var diskExts = []string{FormatWAV, FormatMP3, FormatOgg}
+
+func (c *AudioCache) diskPath(key, ext string) string {
+	return filepath.Join(c.cacheDir, key+"."+ext)
+}
+
+// findOnDisk returns the path of key's entry in whichever format it was
+// stored, or "" if there is none.
+func (c *AudioCache) findOnDisk(key string) string {
+	for _, ext := range diskExts {
+		if path := c.diskPath(key, ext); fileExists(path) {
+			return path
+		}
+	}
+	return ""
 }
 
 func (c *AudioCache) readDisk(key string) ([]byte, bool) {
-	data, err := os.ReadFile(c.diskPath(key))
+	path := c.findOnDisk(key)
+	if path == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, false
 	}
@@ -176,7 +219,11 @@ func (c *AudioCache) readDisk(key string) ([]byte, bool) {
 }
 
 func (c *AudioCache) writeDisk(key string, audio []byte) {
-	path := c.diskPath(key)
+	ext := sniffAudio(audio)
+	if ext == "" {
+		ext = FormatWAV
+	}
+	path := c.diskPath(key, ext)
 	if err := os.WriteFile(path, audio, 0o644); err != nil {
 		c.log.Error("cache: disk write failed for %s: %v", path, err)
 	} else {
@@ -185,7 +232,11 @@ func (c *AudioCache) writeDisk(key string, audio []byte) {
 }
 
 func (c *AudioCache) existsOnDisk(key string) bool {
-	_, err := os.Stat(c.diskPath(key))
+	return c.findOnDisk(key) != ""
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
 	return err == nil
 }
 
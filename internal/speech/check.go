@@ -0,0 +1,125 @@
+package speech
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ── Device checks ────────────────────────────────────────────────
+//
+// Used by first-run setup to confirm the speakers and microphone work
+// before anything depends on them.
+
+// Voices is a short list of Azure neural voices offered during setup.
+// Any voice Azure supports can be put in the config by hand.
+var Voices = []string{
+	DefaultVoice,
+	"en-US-AndrewNeural",
+	"en-US-EmmaNeural",
+	"en-GB-SoniaNeural",
+	"en-GB-RyanNeural",
+	"en-AU-NatashaNeural",
+}
+
+// SilenceRMS is the mic level (RMS on a 0–1 scale) below which the ear
+// treats input as silence.  A test recording that never gets above it
+// means the mic is muted or too far away.
+const SilenceRMS = 0.008
+
+// Tone returns a sine beep as a WAV in the player's format, faded in
+// and out so it doesn't click.
+func Tone(freq float64, d time.Duration) []byte {
+	return Tones(d, freq)
+}
+
+// MicOffEarcon is played when the microphone goes off: three falling
+// notes.
+func MicOffEarcon() []byte { return Tones(110*time.Millisecond, 880, 660, 440) }
+
+// MicOnEarcon is played when it comes back on: the same notes rising.
+func MicOnEarcon() []byte { return Tones(110*time.Millisecond, 440, 660, 880) }
+
+// Tones is Tone for a run of notes, each lasting d: a short tune for an
+// earcon.
+func Tones(d time.Duration, freqs ...float64) []byte {
+	n := int(d.Seconds() * SampleRate)
+	fade := SampleRate / 100 // 10ms
+	pcm := make([]byte, 0, n*2*len(freqs))
+	for _, freq := range freqs {
+		for i := range n {
+			amp := 0.3
+			if i < fade {
+				amp *= float64(i) / float64(fade)
+			} else if n-i < fade {
+				amp *= float64(n-i) / float64(fade)
+			}
+			v := int16(amp * 32767 * math.Sin(2*math.Pi*freq*float64(i)/SampleRate))
+			pcm = binary.LittleEndian.AppendUint16(pcm, uint16(v))
+		}
+	}
+
+	const headerSize = 44
+	wav := make([]byte, headerSize, headerSize+len(pcm))
+	copy(wav[0:], "RIFF")
+	binary.LittleEndian.PutUint32(wav[4:], uint32(36+len(pcm)))
+	copy(wav[8:], "WAVE")
+	copy(wav[12:], "fmt ")
+	binary.LittleEndian.PutUint32(wav[16:], 16)
+	binary.LittleEndian.PutUint16(wav[20:], 1) // PCM
+	binary.LittleEndian.PutUint16(wav[22:], ChannelCount)
+	binary.LittleEndian.PutUint32(wav[24:], SampleRate)
+	binary.LittleEndian.PutUint32(wav[28:], SampleRate*ChannelCount*BitDepth/8)
+	binary.LittleEndian.PutUint16(wav[32:], ChannelCount*BitDepth/8)
+	binary.LittleEndian.PutUint16(wav[34:], BitDepth)
+	copy(wav[36:], "data")
+	binary.LittleEndian.PutUint32(wav[40:], uint32(len(pcm)))
+	return append(wav, pcm...)
+}
+
+// MicLevel records from the default input device for d and returns the
+// loudest RMS level seen in any ~64ms window, on a 0–1 scale.  level is
+// called with each window's RMS as it's measured, for a live meter; it
+// may be nil.
+//
+// Don't call this while an Ear is running — it initialises PortAudio
+// itself.
+func MicLevel(d time.Duration, level func(rms float64)) (float64, error) {
+	const (
+		sampleRate = 16000
+		frames     = 1024
+	)
+	if err := initMic(); err != nil {
+		return 0, fmt.Errorf("portaudio init: %w", err)
+	}
+	defer terminateMic()
+
+	buf := make([]float32, frames)
+	stream, err := openMic(sampleRate, buf)
+	if err != nil {
+		return 0, fmt.Errorf("opening input stream: %w", err)
+	}
+	defer stream.Close()
+	if err := stream.Start(); err != nil {
+		return 0, fmt.Errorf("starting input stream: %w", err)
+	}
+	defer stream.Stop()
+
+	var peak float64
+	for deadline := time.Now().Add(d); time.Now().Before(deadline); {
+		if err := stream.Read(); err != nil {
+			return peak, fmt.Errorf("reading input stream: %w", err)
+		}
+		var sumSq float64
+		for _, s := range buf {
+			sumSq += float64(s) * float64(s)
+		}
+		rms := math.Sqrt(sumSq / float64(len(buf)))
+		peak = max(peak, rms)
+		if level != nil {
+			level(rms)
+		}
+	}
+	return peak, nil
+}
@@ -0,0 +1,96 @@
+package speech
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// ── Compressed audio ─────────────────────────────────────────────
+//
+// Azure can return MP3 or Ogg/Opus instead of WAV, which makes the disk
+// cache roughly ten times smaller.  Compressed audio is cached as-is and
+// decoded to WAV just before playback by ffmpeg, so it's only offered
+// when ffmpeg is on the PATH.  The format is sniffed from the bytes, so a
+// cache holding a mix of formats plays fine.
+
+// Audio formats that can be requested from the TTS backend.
+const (
+	FormatWAV = "wav"
+	FormatMP3 = "mp3"
+	FormatOgg = "ogg"
+)
+
+// azureFormats maps a format name to Azure's output format header.
+var azureFormats = map[string]string{
+	FormatWAV: DefaultAudioFormat,
+	FormatMP3: "audio-24khz-48kbitrate-mono-mp3",
+	FormatOgg: "ogg-24khz-16bit-mono-opus",
+}
+
+// AzureAudioFormat returns the Azure output format for a format name.
+func AzureAudioFormat(name string) (string, error) {
+	f, ok := azureFormats[name]
+	if !ok {
+		return "", fmt.Errorf("unknown audio format %q (want %s, %s, or %s)", name, FormatWAV, FormatMP3, FormatOgg)
+	}
+	return f, nil
+}
+
+// CanDecode reports whether audio in the named format can be played
+// here: WAV always, compressed formats only with ffmpeg installed.
+func CanDecode(name string) bool {
+	if name == FormatWAV {
+		return true
+	}
+	_, err := exec.LookPath("ffmpeg")
+	return err == nil
+}
+
+// sniffAudio identifies audio by its first bytes: FormatWAV, FormatMP3,
+// FormatOgg, or "" if unknown.
+func sniffAudio(data []byte) string {
+	switch {
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WAVE":
+		return FormatWAV
+	case len(data) >= 4 && string(data[0:4]) == "OggS":
+		return FormatOgg
+	case len(data) >= 3 && string(data[0:3]) == "ID3",
+		len(data) >= 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0: // MPEG frame sync
+		return FormatMP3
+	}
+	return ""
+}
+
+// decodeToWAV returns audio as a WAV, running compressed formats through
+// ffmpeg.  WAV is returned unchanged.
+func decodeToWAV(audio []byte) ([]byte, error) {
+	switch sniffAudio(audio) {
+	case FormatWAV:
+		return audio, nil
+	case FormatMP3, FormatOgg:
+	default:
+		return nil, fmt.Errorf("unrecognised audio data")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-hide_banner", "-loglevel", "error",
+		"-i", "pipe:0",
+		"-f", "wav", "-acodec", "pcm_s16le",
+		"-ar", strconv.Itoa(SampleRate), "-ac", strconv.Itoa(ChannelCount),
+		"pipe:1",
+	)
+	cmd.Stdin = bytes.NewReader(audio)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg decode: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return out.Bytes(), nil
+}
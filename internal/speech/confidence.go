@@ -0,0 +1,103 @@
+package speech
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ── Transcription confidence ────────────────────────────────────
+//
+// whisper-cli's --output-json-full writes a <input>.json next to each
+// clip with a probability for every decoded token.  The mean over the
+// real (non-special) tokens is a cheap, decent proxy for how sure
+// whisper was — low values usually mean it guessed at mumbled or noisy
+// audio.
+
+// Utterance is one voice command delivered by the Ear.
+type Utterance struct {
+	Text string
+	// Confidence is whisper's mean token probability in [0, 1], or -1
+	// when it couldn't be measured.
+	Confidence float64
+	// Audio is the archived clip of the command, or "" when audio
+	// isn't being kept.
+	Audio string
+	// Language is the language the command was transcribed in: the
+	// wake word's, whisper's pick under "auto", or the ear's own.
+	// "" when unknown.
+	Language string
+	// Voiceprint is the speaker embedding of the command, with
+	// WithVoiceprints; nil otherwise or when it was too short.
+	Voiceprint []float32
+	// Overheard is set for speech caught in always-listening mode
+	// without the wake word, which the app should only act on when it's
+	// clearly a command.
+	Overheard bool
+}
+
+// whisperJSONArgs makes whisper-cli emit per-token probabilities.
+var whisperJSONArgs = []string{"--output-json-full"}
+
+type whisperJSON struct {
+	Result struct {
+		Language string `json:"language"`
+	} `json:"result"`
+	Transcription []struct {
+		Tokens []struct {
+			Text string  `json:"text"`
+			P    float64 `json:"p"`
+		} `json:"tokens"`
+	} `json:"transcription"`
+}
+
+// tokenStats accumulates token probabilities across clips.
+type tokenStats struct {
+	sum      float64
+	n        int
+	language string // as whisper detected it, last one read
+}
+
+// addFile folds in the probabilities from one whisper JSON file.
+func (s *tokenStats) addFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var out whisperJSON
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+	if out.Result.Language != "" {
+		s.language = out.Result.Language
+	}
+	for _, seg := range out.Transcription {
+		for _, tok := range seg.Tokens {
+			// Special tokens ([_BEG_], [_TT_150], ...) carry no signal.
+			if strings.HasPrefix(strings.TrimSpace(tok.Text), "[_") {
+				continue
+			}
+			s.sum += tok.P
+			s.n++
+		}
+	}
+	return nil
+}
+
+// collect reads and removes every JSON file matching pattern.
+func (s *tokenStats) collect(pattern string) {
+	paths, _ := filepath.Glob(pattern)
+	for _, p := range paths {
+		_ = s.addFile(p)
+		os.Remove(p)
+	}
+}
+
+// confidence returns the mean probability, or -1 with no tokens.
+func (s *tokenStats) confidence() float64 {
+	if s.n == 0 {
+		return -1
+	}
+	return s.sum / float64(s.n)
+}
@@ -16,10 +16,11 @@ const (
 	BitDepth     = 16
 )
 
-// Env var names for Azure Speech credentials.
+// Env var names for Azure Speech credentials and voice.
 const (
 	EnvAzureSpeechKey    = "AZURE_SPEECH_KEY"
 	EnvAzureSpeechRegion = "AZURE_SPEECH_REGION"
+	EnvVoice             = "OTTOCOOK_VOICE" // overrides DefaultVoice
 )
 
 // Priority levels for speech requests. Higher value = speaks first.
@@ -32,9 +33,24 @@ const (
 	PriorityCritical                 // urgent alerts, errors
 )
 
+// Channel names where speech comes from, so it can be cleared by
+// source: a new step can drop pending watcher chatter without touching
+// a timer alert.
+type Channel string
+
+const (
+	ChannelGeneral Channel = "general" // replies to commands
+	ChannelSteps   Channel = "steps"   // step narration
+	ChannelTimers  Channel = "timers"  // timer alerts and reminders
+	ChannelWatcher Channel = "watcher" // session-watcher comments
+	ChannelAI      Channel = "ai"      // AI answers and thinking fillers
+)
+
 // SpeechRequest is a queued item waiting to be spoken.
 type SpeechRequest struct {
 	Text     string
+	Audio    []byte // a sound played as-is instead of speaking Text (see Mouth.Play)
 	Priority Priority
+	Channel  Channel
 	QueuedAt time.Time
 }
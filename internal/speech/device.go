@@ -0,0 +1,48 @@
+package speech
+
+import (
+	"errors"
+	"io"
+)
+
+// ── Audio devices ────────────────────────────────────────────────
+//
+// Everything that opens the sound card or the microphone goes through
+// the few functions in device_audio.go: oto for playback, PortAudio for
+// the ear's level monitor and the whisper recorder.  Built with -tags
+// noaudio, device_noaudio.go stands in and every device fails to open
+// with ErrNoAudio, so a headless binary (text only, for a container)
+// needs none of their C libraries.
+
+// ErrNoAudio is returned for any device in a build without audio.
+var ErrNoAudio = errors.New("built without audio (-tags noaudio)")
+
+// speakers is the open output device: oto's context.
+type speakers interface {
+	NewPlayer(r io.Reader) sound
+	Suspend() error
+	Resume() error
+}
+
+// sound is one playback on the speakers.
+type sound interface {
+	Play()
+	IsPlaying() bool
+	Pause()
+	Close() error
+}
+
+// micStream is an open input stream that fills its buffer on each Read.
+type micStream interface {
+	Start() error
+	Read() error
+	Stop() error
+	Close() error
+}
+
+// recorder records the microphone from Start to Stop, then hands the
+// transcription to the callback it was made with.
+type recorder interface {
+	Start() error
+	Stop()
+}
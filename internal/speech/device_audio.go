@@ -0,0 +1,57 @@
+//go:build !noaudio
+
+package speech
+
+import (
+	"io"
+
+	"github.com/ebitengine/oto/v3"
+	"github.com/gordonklaus/portaudio"
+	audiotranscriber "github.com/sklyt/whisper/pkg"
+)
+
+// AudioSupported reports whether this build can open audio devices.
+const AudioSupported = true
+
+// openSpeakers opens the default output device.  oto allows one per
+// process.
+func openSpeakers() (speakers, error) {
+	ctx, ready, err := oto.NewContext(&oto.NewContextOptions{
+		SampleRate:   SampleRate,
+		ChannelCount: ChannelCount,
+		Format:       oto.FormatSignedInt16LE,
+	})
+	if err != nil {
+		return nil, err
+	}
+	<-ready
+	return otoSpeakers{ctx}, nil
+}
+
+type otoSpeakers struct{ *oto.Context }
+
+func (s otoSpeakers) NewPlayer(r io.Reader) sound { return s.Context.NewPlayer(r) }
+
+// initMic initialises PortAudio.  Each call needs a terminateMic.
+func initMic() error { return portaudio.Initialize() }
+
+func terminateMic() { portaudio.Terminate() }
+
+// openMic opens the default input as mono float32, len(buf) frames per
+// Read.
+func openMic(sampleRate float64, buf []float32) (micStream, error) {
+	s, err := portaudio.OpenDefaultStream(1, 0, sampleRate, len(buf), buf)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// newRecorder makes a whisper recorder; done gets the transcription.
+func newRecorder(whisperCLI, modelPath, tempDir string, done func(text string), verbose bool) (recorder, error) {
+	t, err := audiotranscriber.NewTranscriber(whisperCLI, modelPath, tempDir, "wav", done, verbose)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,18 @@
+//go:build noaudio
+
+package speech
+
+// AudioSupported reports whether this build can open audio devices.
+const AudioSupported = false
+
+func openSpeakers() (speakers, error) { return nil, ErrNoAudio }
+
+func initMic() error { return ErrNoAudio }
+
+func terminateMic() {}
+
+func openMic(float64, []float32) (micStream, error) { return nil, ErrNoAudio }
+
+func newRecorder(string, string, string, func(string), bool) (recorder, error) {
+	return nil, ErrNoAudio
+}
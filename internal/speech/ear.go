@@ -2,17 +2,20 @@ package speech
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/gordonklaus/portaudio"
-	audiotranscriber "github.com/sklyt/whisper/pkg"
-
 	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/metrics"
 	"github.com/hammamikhairi/ottocook/internal/wakeword"
 )
 
@@ -26,6 +29,10 @@ const (
 	earListening
 	// earMuted — ear is asleep while mouth speaks.
 	earMuted
+	// earMicOff — the microphone is off until turned back on.
+	earMicOff
+	// earClosed — Close has stopped the ear until it's Run again.
+	earClosed
 )
 
 // EarState exports the ear state type for consumers (e.g. display).
@@ -36,6 +43,8 @@ const (
 	EarDormant   = earDormant
 	EarListening = earListening
 	EarMuted     = earMuted
+	EarMicOff    = earMicOff
+	EarClosed    = earClosed
 )
 
 // wakeWordTexts are patterns that may bleed into the whisper
@@ -53,7 +62,7 @@ var wakeWordTexts = []string{
 
 // envAnnotation matches whisper environmental annotations like
 // "(keyboard clicking)", "[laughter]", "(speaking French)", etc.
-var envAnnotation = regexp.MustCompile(`[\(\[][a-zA-Z][a-zA-Z\s]*[\)\]]`)
+var envAnnotation = regexp.MustCompile(`[\(\[]\p{L}[\p{L}\s'’-]*[\)\]]`)
 
 // ── Options ──────────────────────────────────────────────────────
 
@@ -71,6 +80,60 @@ func WithListenTimeout(d time.Duration) EarOption {
 	return func(e *Ear) { e.listenTimeout = d }
 }
 
+// WithWhisperArgs passes extra command-line flags to every whisper-cli
+// invocation, e.g. "-fa" for flash attention, "-t 8" for more threads,
+// or "-dev 1" to pick a GPU.
+func WithWhisperArgs(args ...string) EarOption {
+	return func(e *Ear) { e.whisperArgs = args }
+}
+
+// WithLanguage sets the spoken language passed to whisper-cli ("-l"),
+// e.g. "fr", "de", or "auto" to let whisper detect it.  Without it
+// whisper assumes English and renders other languages as English.
+// The language also selects extra transcription cleanup rules.
+func WithLanguage(lang string) EarOption {
+	return func(e *Ear) { e.language = strings.ToLower(strings.TrimSpace(lang)) }
+}
+
+// WithAudioArchive keeps every captured command's audio and
+// transcription in the archive.
+func WithAudioArchive(a *AudioArchive) EarOption {
+	return func(e *Ear) { e.archive = a }
+}
+
+// Voiceprinter turns a voice command's audio (16 kHz mono) into a
+// speaker embedding.
+type Voiceprinter interface {
+	Embed(pcm []int16) ([]float32, error)
+}
+
+// WithVoiceprints attaches a voiceprint to every captured command, so
+// the app can tell who's speaking.
+func WithVoiceprints(v Voiceprinter) EarOption {
+	return func(e *Ear) { e.voiceprints = v }
+}
+
+// WithAlwaysListening makes the ear transcribe everything said, not
+// just what follows the wake word.  Speech without the wake word comes
+// through as Overheard.  For private kitchens only.
+func WithAlwaysListening() EarOption {
+	return func(e *Ear) { e.always = true }
+}
+
+// WithThresholdTuning lets the wake threshold adapt: up after several
+// wakes in a row capture nothing, down after "you didn't hear me".
+func WithThresholdTuning() EarOption {
+	return func(e *Ear) { e.tuner = wakeword.NewTuner(e.detector.Threshold()) }
+}
+
+// WithEarMetrics records speech-to-text latency in the given registry.
+func WithEarMetrics(reg *metrics.Registry) EarOption {
+	return func(e *Ear) {
+		e.sttLatency = reg.Histogram("ottocook_stt_transcription_seconds",
+			"Time from end of capture until whisper returns the transcription.", metrics.LatencyBuckets)
+	}
+}
+
 // ── Ear ──────────────────────────────────────────────────────────
 
 // Ear provides wake-word-triggered speech-to-text input.
@@ -83,22 +146,36 @@ func WithListenTimeout(d time.Duration) EarOption {
 //     → capture the full command → send text on the channel.
 //  3. Return to dormant.
 type Ear struct {
-	whisperBin string
-	modelPath  string
-	tempDir    string
-	log        *logger.Logger
-	mouth      *Mouth             // optional — interrupt on wake word
-	detector   *wakeword.Detector // ONNX-based wake word detector
-
-	listenTimeout time.Duration // max active listening window
+	whisperBin  string
+	whisperArgs []string          // extra flags for whisper-cli
+	language    string            // whisper "-l" value; "" leaves whisper's default (en)
+	execBins    map[string]string // what the transcriber runs, per language: whisperBin or a wrapper adding the flags
+	modelPath   string
+	tempDir     string
+	log         *logger.Logger
+	mouth       Speaker            // optional — interrupt on wake word
+	detector    *wakeword.Detector // ONNX-based wake word detector
+
+	listenTimeout time.Duration      // max active listening window
+	sttLatency    *metrics.Histogram // nil when metrics are disabled
+	archive       *AudioArchive      // nil unless captured commands are kept
+	tuner         *wakeword.Tuner    // nil unless the threshold adapts; guarded by mu
+	voiceprints   Voiceprinter       // nil unless commands are voiceprinted
+	always        bool               // transcribe everything, wake word or not
 
 	mu            sync.Mutex
 	muted         bool
+	mouthBusy     bool          // the mouth is speaking, per OnSpeakingChange
+	mouthQuiet    chan struct{} // closed when the mouth next stops speaking; nil until waited on
+	micOff        bool          // privacy mode: capture stopped until MicOn
 	state         earState
-	textCh        chan string          // transcribed text flows here
+	textCh        chan Utterance       // transcribed commands flow here
 	wakeCh        chan struct{}        // wakeword detector signals here
 	cancelCh      chan struct{}        // externally cancel active listening
 	onStateChange func(state earState) // optional UI callback
+	stop          context.CancelFunc   // ends Run; nil when it isn't running
+	stopped       chan struct{}        // closed once Run has returned
+	closed        bool                 // between Close and Run
 }
 
 // NewEar creates a wake-word-triggered voice input listener.
@@ -106,8 +183,9 @@ type Ear struct {
 //   - whisperBin: path to the whisper-cli executable
 //   - modelPath:  path to the Whisper GGML model file
 //   - detector:   pre-configured openWakeWord detector
-//   - mouth:      optional Mouth — will be interrupted when wake word is heard
-func NewEar(whisperBin, modelPath string, detector *wakeword.Detector, mouth *Mouth, log *logger.Logger, opts ...EarOption) *Ear {
+//   - mouth:      optional Speaker (nil, not a nil *Mouth) — will be
+//     interrupted when wake word is heard
+func NewEar(whisperBin, modelPath string, detector *wakeword.Detector, mouth Speaker, log *logger.Logger, opts ...EarOption) *Ear {
 	e := &Ear{
 		whisperBin:    whisperBin,
 		modelPath:     modelPath,
@@ -117,18 +195,23 @@ func NewEar(whisperBin, modelPath string, detector *wakeword.Detector, mouth *Mo
 		detector:      detector,
 		listenTimeout: 15 * time.Second,
 		state:         earDormant,
-		textCh:        make(chan string, 8),
+		textCh:        make(chan Utterance, 8),
 		wakeCh:        make(chan struct{}, 1),
 		cancelCh:      make(chan struct{}, 1),
+		execBins:      make(map[string]string),
 	}
 	for _, opt := range opts {
 		opt(e)
 	}
+	if mouth != nil {
+		mouth.OnSpeakingChange(e.mouthSpeaking)
+	}
 
 	// Validate that the whisper binary is reachable.
 	if _, err := exec.LookPath(e.whisperBin); err != nil {
 		log.Error("ear: whisper binary %q not found in PATH: %v", e.whisperBin, err)
 	}
+	e.execFor(e.language)
 
 	// Wire the detector callback → wakeCh.
 	detector.OnDetected = func() {
@@ -141,8 +224,49 @@ func NewEar(whisperBin, modelPath string, detector *wakeword.Detector, mouth *Mo
 	return e
 }
 
-// C returns the channel that receives transcribed text.
-func (e *Ear) C() <-chan string {
+// whisperDefaultLanguage is what whisper-cli transcribes without "-l".
+const whisperDefaultLanguage = "en"
+
+// whisperFlags returns the whisper-cli flags for transcribing lang.
+// Whisper's own default needs no flag.
+func (e *Ear) whisperFlags(lang string) []string {
+	if lang == "" || lang == whisperDefaultLanguage {
+		return e.whisperArgs
+	}
+	return append([]string{"-l", lang}, e.whisperArgs...)
+}
+
+// execFor returns what the transcriber runs to transcribe lang: a
+// wrapper adding the language and extra flags, written on first use.
+// The wrapper also asks for per-token probabilities so commands carry a
+// confidence score.
+func (e *Ear) execFor(lang string) string {
+	if bin, ok := e.execBins[lang]; ok {
+		return bin
+	}
+	bin := e.whisperBin
+	name := "whisper-cli-wrapper.sh"
+	if lang != e.language {
+		name = "whisper-cli-wrapper-" + lang + ".sh"
+	}
+	flags := e.whisperFlags(lang)
+	wrapArgs := append(append([]string(nil), flags...), whisperJSONArgs...)
+	if wrapper, err := writeWhisperWrapper(e.tempDir, name, e.whisperBin, wrapArgs); err != nil {
+		if len(flags) > 0 {
+			e.log.Error("ear: whisper args %v ignored, so whisper transcribes as English: %v", flags, err)
+		} else {
+			e.log.Debug("ear: transcription confidence unavailable: %v", err)
+		}
+	} else {
+		bin = wrapper
+		e.log.Debug("ear: whisper-cli extra args %v via %s", wrapArgs, wrapper)
+	}
+	e.execBins[lang] = bin
+	return bin
+}
+
+// C returns the channel that receives transcribed commands.
+func (e *Ear) C() <-chan Utterance {
 	return e.textCh
 }
 
@@ -171,6 +295,88 @@ func (e *Ear) Mute() {
 	e.log.Debug("ear: muted (state=%d)", curState)
 }
 
+// MicOff turns the microphone off for privacy: any command being
+// captured is dropped and the detector stops its capture device, so
+// nothing is heard at all until MicOn.  Muting for speech doesn't turn
+// it back on.
+func (e *Ear) MicOff() {
+	e.mu.Lock()
+	e.micOff = true
+	e.mu.Unlock()
+	e.CancelListening()
+	e.detector.SetCapture(false)
+	e.setState(earMicOff)
+	e.log.Info("ear: microphone off")
+}
+
+// MicOn turns the microphone back on after MicOff.
+func (e *Ear) MicOn() {
+	e.mu.Lock()
+	e.micOff = false
+	muted := e.muted
+	e.mu.Unlock()
+	e.detector.SetCapture(true)
+	if muted {
+		e.setState(earMuted)
+	} else {
+		e.setState(earDormant)
+	}
+	e.log.Info("ear: microphone on")
+}
+
+// SetIdle lets the detector save power while there's nothing cooking:
+// it stops scoring in a quiet room and picks up at the next sound.
+func (e *Ear) SetIdle(on bool) {
+	e.detector.SetIdle(on)
+}
+
+// FalseWake records that the last wake wasn't meant for Otto.  Returns
+// the wake threshold and whether it went up.
+func (e *Ear) FalseWake() (float64, bool) {
+	return e.tune((*wakeword.Tuner).Empty)
+}
+
+// MissedWake records that the wake word was said and not heard.
+// Returns the wake threshold and whether it came down.
+func (e *Ear) MissedWake() (float64, bool) {
+	return e.tune((*wakeword.Tuner).Missed)
+}
+
+// SetThreshold sets the wake threshold.  With threshold tuning, it
+// adapts from the new value from now on, within the usual range of it.
+func (e *Ear) SetThreshold(t float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.detector.SetThreshold(t)
+	if e.tuner != nil {
+		e.tuner = wakeword.NewTuner(t)
+	}
+}
+
+// tune records a wake outcome with the tuner and hands any new
+// threshold to the detector.
+func (e *Ear) tune(outcome func(*wakeword.Tuner) bool) (float64, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.tuner == nil {
+		return e.detector.Threshold(), false
+	}
+	if !outcome(e.tuner) {
+		return e.tuner.Threshold(), false
+	}
+	t := e.tuner.Threshold()
+	e.detector.SetThreshold(t)
+	e.log.Info("ear: wake threshold now %.2f", t)
+	return t, true
+}
+
+// MicIsOff reports whether the microphone has been turned off.
+func (e *Ear) MicIsOff() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.micOff
+}
+
 // CancelListening aborts an in-progress listening session (if any)
 // and returns the ear to dormant. Safe to call from any goroutine.
 func (e *Ear) CancelListening() {
@@ -205,22 +411,59 @@ func (e *Ear) isMuted() bool {
 	return e.muted
 }
 
-// Run starts the ear.  Blocks until ctx is cancelled.  The wakeword
-// detector must already be running in its own goroutine.
+// Run starts the ear.  Blocks until ctx is cancelled or Close is
+// called, and can be called again after.  The wakeword detector must
+// already be running in its own goroutine.  Running a running ear
+// returns at once.
 func (e *Ear) Run(ctx context.Context) {
+	e.mu.Lock()
+	if e.stop != nil {
+		e.mu.Unlock()
+		return
+	}
+	ctx, stop := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	e.stop, e.stopped = stop, stopped
+	reopened := e.closed
+	e.closed = false
+	e.mu.Unlock()
+	defer func() {
+		stop()
+		e.mu.Lock()
+		if e.stopped == stopped {
+			e.stop, e.stopped = nil, nil
+		}
+		e.mu.Unlock()
+		close(stopped)
+	}()
+
 	e.log.Info("ear: started (timeout=%s)", e.listenTimeout)
+	if reopened {
+		if e.isMuted() {
+			e.setState(earMuted)
+		} else {
+			e.setState(earDormant)
+		}
+	}
 
 	// Initialise PortAudio once for the lifetime of the ear.
 	// Repeated Init/Terminate cycles corrupt the CoreAudio HAL on
 	// macOS, progressively reducing the gain seen by the concurrent
-	// malgo capture device.
-	if err := portaudio.Initialize(); err != nil {
+	// malgo capture device.  A Close and Run again cycles it once, but
+	// only with the detector closed too (see Audio), so nothing is
+	// capturing meanwhile.
+	if err := initMic(); err != nil {
 		e.log.Error("ear: portaudio init failed: %v", err)
 		return
 	}
-	defer portaudio.Terminate()
+	defer terminateMic()
 	e.log.Debug("ear: portaudio initialized (once)")
 
+	if e.always {
+		e.overhear(ctx)
+		return
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -228,7 +471,7 @@ func (e *Ear) Run(ctx context.Context) {
 			return
 
 		case <-e.wakeCh:
-			if e.isMuted() {
+			if e.isMuted() || e.MicIsOff() {
 				e.log.Debug("ear: wake word ignored — muted")
 				continue
 			}
@@ -237,6 +480,28 @@ func (e *Ear) Run(ctx context.Context) {
 	}
 }
 
+// Close stops Run and waits for it: a command being captured is
+// dropped, a transcription in flight is killed, and PortAudio is
+// released.  The ear keeps its settings and subscriptions, so Run
+// picks up where it left off.  Closing an ear that isn't running does
+// nothing.
+func (e *Ear) Close() {
+	e.mu.Lock()
+	stop, stopped := e.stop, e.stopped
+	e.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	e.CancelListening()
+	stop()
+	<-stopped
+	e.mu.Lock()
+	e.closed = true
+	e.mu.Unlock()
+	e.setState(earClosed)
+	e.log.Info("ear: closed")
+}
+
 // ── State helpers ────────────────────────────────────────────────
 
 func (e *Ear) getState() earState {
@@ -247,6 +512,12 @@ func (e *Ear) getState() earState {
 
 func (e *Ear) setState(s earState) {
 	e.mu.Lock()
+	switch {
+	case e.closed:
+		s = earClosed // nothing but Run leaves it
+	case e.micOff:
+		s = earMicOff // nothing but MicOn leaves it
+	}
 	e.state = s
 	cb := e.onStateChange
 	e.mu.Unlock()
@@ -255,15 +526,53 @@ func (e *Ear) setState(s earState) {
 	}
 }
 
-// waitForMouth blocks until the mouth finishes speaking so the
-// microphone doesn't pick it up.
+// mouthSpeaking is the mouth's OnSpeakingChange subscription: the ear
+// sleeps while Otto talks, so the wake word detector and whisper don't
+// pick up the speaker output.
+func (e *Ear) mouthSpeaking(speaking bool) {
+	e.mu.Lock()
+	e.mouthBusy = speaking
+	if !speaking && e.mouthQuiet != nil {
+		close(e.mouthQuiet)
+		e.mouthQuiet = nil
+	}
+	e.mu.Unlock()
+	if speaking {
+		e.Mute()
+	} else {
+		e.Unmute()
+	}
+}
+
+// isMouthBusy reports whether the mouth is speaking.
+func (e *Ear) isMouthBusy() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.mouthBusy
+}
+
+// waitForMouth blocks until the mouth finishes speaking, and whatever
+// it has queued, so the microphone doesn't pick it up.
 func (e *Ear) waitForMouth(ctx context.Context) {
 	if e.mouth == nil {
 		return
 	}
-	for e.mouth.IsSpeaking() || e.mouth.QueueLen() > 0 {
+	for {
+		e.mu.Lock()
+		if !e.mouthBusy && e.mouth.QueueLen() == 0 {
+			e.mu.Unlock()
+			return
+		}
+		if e.mouthQuiet == nil {
+			e.mouthQuiet = make(chan struct{})
+		}
+		quiet := e.mouthQuiet
+		e.mu.Unlock()
 		select {
-		case <-time.After(100 * time.Millisecond):
+		case <-quiet:
+		case <-time.After(time.Second):
+			// Queued speech can be interrupted before it ever starts,
+			// and then there's no change to wait for.
 		case <-ctx.Done():
 			return
 		}
@@ -296,7 +605,7 @@ func (e *Ear) onWakeWord(ctx context.Context) {
 		e.mouth.Say(filler, PriorityCritical)
 		e.log.Debug("ear: said %q", filler)
 	}
-	sent := e.doListening(ctx)
+	sent := e.doListening(ctx, true)
 
 	if sent {
 		// Text was captured → an AI response is coming.  Mute so the
@@ -313,6 +622,48 @@ func (e *Ear) onWakeWord(ctx context.Context) {
 	}
 }
 
+// ── Always listening ─────────────────────────────────────────────
+
+// overhear runs always-listening mode: back to back listening sessions
+// with no wake word or filler, while Otto isn't talking and the mic is
+// on.  Said during a session, the wake word marks the speech as
+// addressed to Otto; on its own it wakes the ear as usual.
+func (e *Ear) overhear(ctx context.Context) {
+	e.log.Info("ear: always listening")
+	for {
+		select {
+		case <-ctx.Done():
+			e.log.Info("ear: stopped")
+			return
+		case <-e.wakeCh:
+			if !e.isMuted() && !e.MicIsOff() {
+				e.onWakeWord(ctx)
+			}
+			continue
+		default:
+		}
+		if e.isMuted() || e.MicIsOff() {
+			select {
+			case <-time.After(200 * time.Millisecond):
+			case <-ctx.Done():
+			}
+			continue
+		}
+		e.setState(earListening)
+		sent := e.doListening(ctx, false)
+
+		// A wake word during the session was part of what was sent.
+		// Said on its own, it still wakes the ear.
+		select {
+		case <-e.wakeCh:
+			if !sent {
+				e.onWakeWord(ctx)
+			}
+		default:
+		}
+	}
+}
+
 // ── Active listening mode ────────────────────────────────────────
 
 // doListening opens a single Whisper transcriber for the whole session
@@ -323,18 +674,28 @@ func (e *Ear) onWakeWord(ctx context.Context) {
 // handles mid-sentence pauses just fine; we only control the outer
 // "are you done talking?" boundary.
 //
-// Returns true if transcribed text was sent on textCh.
-func (e *Ear) doListening(ctx context.Context) bool {
-	e.log.Info("ear: listening...")
+// woken is false in always-listening mode, when nobody said the wake
+// word and there's no filler to wait out.
+//
+// Returns true if an utterance was sent on textCh.
+func (e *Ear) doListening(ctx context.Context, woken bool) bool {
+	// Woken in a second language, the command will be in it too.
+	lang := e.language
+	if l := e.detector.DetectedLanguage(); l != "" && woken {
+		lang = l
+	}
+	e.log.Info("ear: listening (language=%q)...", lang)
 
 	// Grace period: wait for the mouth to finish saying the filler
 	// and give the user a moment to start speaking.
 	e.waitForMouth(ctx)
-	select {
-	case <-time.After(500 * time.Millisecond):
-	case <-ctx.Done():
-		e.setState(earDormant)
-		return false
+	if woken {
+		select {
+		case <-time.After(500 * time.Millisecond):
+		case <-ctx.Done():
+			e.setState(earDormant)
+			return false
+		}
 	}
 
 	// ── RMS monitor stream ───────────────────────────────────────
@@ -347,7 +708,7 @@ func (e *Ear) doListening(ctx context.Context) bool {
 	)
 
 	monBuf := make([]float32, monFrames)
-	monStream, err := portaudio.OpenDefaultStream(1, 0, float64(monSampleRate), monFrames, monBuf)
+	monStream, err := openMic(monSampleRate, monBuf)
 	if err != nil {
 		e.log.Error("ear: monitor stream open failed: %v", err)
 		e.setState(earDormant)
@@ -360,6 +721,37 @@ func (e *Ear) doListening(ctx context.Context) bool {
 		return false
 	}
 
+	// ── Pre-roll ─────────────────────────────────────────────────
+	// Whatever the user said between the wake word and now (while the
+	// filler played and during the grace period) never reaches the
+	// transcriber below, so recover it from the detector's history
+	// buffer and transcribe it alongside.
+	var preText string
+	var preStats tokenStats
+	preDone := make(chan struct{})
+	var pre []int16
+	if woken {
+		pre = e.detector.SinceDetection()
+	}
+	var clip []int16 // everything heard, for the archive and voiceprint
+	keepClip := e.archive != nil || e.voiceprints != nil
+	if keepClip {
+		clip = append(clip, pre...)
+	}
+	if pcmRMS(pre) >= rmsThresh {
+		go func() {
+			defer close(preDone)
+			text, err := e.transcribeClip(ctx, pre, lang, &preStats)
+			if err != nil {
+				e.log.Debug("ear: pre-roll transcription failed: %v", err)
+				return
+			}
+			preText = text
+		}()
+	} else {
+		close(preDone)
+	}
+
 	// ── Whisper transcriber (single instance for the session) ────
 	var result string
 	var wg sync.WaitGroup
@@ -371,9 +763,7 @@ func (e *Ear) doListening(ctx context.Context) bool {
 	}
 
 	verbose := e.log.GetLevel() >= logger.LevelVerbose
-	t, err := audiotranscriber.NewTranscriber(
-		e.whisperBin, e.modelPath, e.tempDir, "wav", callback, verbose,
-	)
+	t, err := newRecorder(e.execFor(lang), e.modelPath, e.tempDir, callback, verbose)
 	if err != nil {
 		e.log.Error("ear: transcriber init failed: %v", err)
 		monStream.Stop()
@@ -393,6 +783,7 @@ func (e *Ear) doListening(ctx context.Context) bool {
 	deadline := time.After(e.listenTimeout)
 	lastLoud := time.Now()
 	heardSpeech := false
+	cancelled := false
 
 	for {
 		select {
@@ -403,6 +794,7 @@ func (e *Ear) doListening(ctx context.Context) bool {
 			goto cleanup
 		case <-e.cancelCh:
 			e.log.Debug("ear: listening cancelled")
+			cancelled = true
 			goto cleanup
 		default:
 		}
@@ -416,11 +808,16 @@ func (e *Ear) doListening(ctx context.Context) bool {
 		for _, s := range monBuf {
 			sumSq += float64(s) * float64(s)
 		}
+		if keepClip {
+			for _, s := range monBuf {
+				clip = append(clip, int16(max(-1, min(1, s))*32767))
+			}
+		}
 		rms := math.Sqrt(sumSq / float64(len(monBuf)))
 
 		// Ignore audio while the mouth is speaking — otherwise TTS
 		// playback bleeds into the mic and gets treated as user speech.
-		if e.mouth != nil && e.mouth.IsSpeaking() {
+		if e.isMouthBusy() {
 			continue
 		}
 
@@ -447,32 +844,141 @@ cleanup:
 	monStream.Stop()
 	monStream.Close()
 
+	transcribeStart := time.Now()
 	t.Stop()
 	wg.Wait()
+	<-preDone
+	e.sttLatency.ObserveSince(transcribeStart)
+
+	stats := preStats
+	stats.collect(filepath.Join(e.tempDir, "chunk_*.json"))
+	confidence := stats.confidence()
 
 	e.setState(earDormant)
 
-	combined := strings.TrimSpace(result)
-	combined = cleanTranscription(combined)
+	combined := strings.TrimSpace(preText + " " + result)
+	combined = cleanTranscription(combined, lang)
+	addressed := woken || strings.ToLower(combined) != stripWakeWordText(combined)
 	combined = stripWakeWordText(combined)
 	combined = e.stripMouthEcho(combined)
 	combined = strings.TrimSpace(combined)
 
+	var audio string
+	if e.archive != nil && addressed && (heardSpeech || combined != "") {
+		if audio, err = e.archive.Keep(clip, combined, confidence, time.Now()); err != nil {
+			e.log.Error("ear: keeping audio: %v", err)
+		}
+	}
+
 	if combined == "" {
 		e.log.Debug("ear: listening ended with no input")
+		if woken && !cancelled && ctx.Err() == nil {
+			e.tune((*wakeword.Tuner).Empty)
+		}
 		return false
 	}
+	if woken {
+		e.tune(func(t *wakeword.Tuner) bool { t.Heard(); return false })
+	}
 
-	e.log.Info("ear: heard command: %q", combined)
+	spoken := lang
+	if stats.language != "" {
+		spoken = stats.language
+	} else if spoken == "auto" {
+		spoken = ""
+	}
+	var voiceprint []float32
+	if e.voiceprints != nil {
+		if voiceprint, err = e.voiceprints.Embed(clip); err != nil {
+			e.log.Error("ear: voiceprint: %v", err)
+		}
+	}
+	e.log.Info("ear: heard command: %q (confidence=%.2f, language=%s, audio=%s, addressed=%v)", combined, confidence, spoken, audio, addressed)
 
 	select {
-	case e.textCh <- combined:
+	case e.textCh <- Utterance{Text: combined, Confidence: confidence, Audio: audio, Language: spoken, Voiceprint: voiceprint, Overheard: !addressed}:
 		return true
 	case <-ctx.Done():
 		return false
 	}
 }
 
+// transcribeClip runs whisper-cli over an in-memory clip (16 kHz mono)
+// and returns the raw text.  Token probabilities are added to stats.
+func (e *Ear) transcribeClip(ctx context.Context, pcm []int16, lang string, stats *tokenStats) (string, error) {
+	wav := filepath.Join(e.tempDir, fmt.Sprintf("preroll_%d.wav", time.Now().UnixNano()))
+	if err := wakeword.WriteWAV(wav, pcm); err != nil {
+		return "", err
+	}
+	defer os.Remove(wav)
+	defer os.Remove(wav + ".txt")
+
+	args := []string{"-m", e.modelPath, wav, "--output-txt"}
+	args = append(args, e.whisperFlags(lang)...)
+	args = append(args, whisperJSONArgs...)
+	out, err := exec.CommandContext(ctx, e.whisperBin, args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	text, err := os.ReadFile(wav + ".txt")
+	if err != nil {
+		return "", err
+	}
+	stats.collect(wav + ".json")
+	e.log.Debug("ear: pre-roll (%.1fs) transcribed: %q", float64(len(pcm))/16000, strings.TrimSpace(string(text)))
+	return string(text), nil
+}
+
+// writeWhisperWrapper creates a tiny shell script that runs whisper-cli
+// with extra flags appended.  The transcriber library hard-codes its
+// whisper-cli arguments, so a wrapper is the only way to add ours.
+func writeWhisperWrapper(dir, name, bin string, args []string) (string, error) {
+	if runtime.GOOS == "windows" {
+		return "", errors.New("extra whisper args are not supported on Windows")
+	}
+	resolved, err := exec.LookPath(bin)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err = filepath.Abs(resolved); err != nil {
+		return "", err
+	}
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, shellQuote(resolved), `"$@"`)
+	for _, a := range args {
+		quoted = append(quoted, shellQuote(a))
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	path, err := filepath.Abs(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	script := "#!/bin/sh\nexec " + strings.Join(quoted, " ") + "\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// pcmRMS returns the RMS level of 16-bit samples on a 0–1 scale.
+func pcmRMS(pcm []int16) float64 {
+	if len(pcm) == 0 {
+		return 0
+	}
+	var sumSq float64
+	for _, v := range pcm {
+		f := float64(v) / 32768
+		sumSq += f * f
+	}
+	return math.Sqrt(sumSq / float64(len(pcm)))
+}
+
 // ── Text cleanup ─────────────────────────────────────────────────
 
 // stripMouthEcho removes text that matches what the mouth recently
@@ -508,8 +1014,9 @@ func stripWakeWordText(text string) string {
 // cleanTranscription strips whitespace, normalizes newlines, and
 // removes common whisper artifacts like "[BLANK_AUDIO]", "(silence)",
 // etc. Artifacts are stripped from anywhere in the text, not just as
-// exact full-string matches.
-func cleanTranscription(s string) string {
+// exact full-string matches.  lang adds that language's artifacts on top
+// of the English ones (see sttlang.go).
+func cleanTranscription(s, lang string) string {
 	// Normalize newlines and collapse whitespace.
 	s = strings.ReplaceAll(s, "\r\n", " ")
 	s = strings.ReplaceAll(s, "\n", " ")
@@ -557,6 +1064,7 @@ func cleanTranscription(s string) string {
 		"(buzzing)",
 		"(beeping)",
 	}
+	junkPatterns = append(junkPatterns, languageAnnotations(lang)...)
 	for _, j := range junkPatterns {
 		s = strings.ReplaceAll(s, j, "")
 		s = strings.ReplaceAll(s, strings.ToLower(j), "")
@@ -589,6 +1097,7 @@ func cleanTranscription(s string) string {
 		"The end.",
 		"Sous-titres réalisés para la communauté d'Amara.org",
 	}
+	hallucinations = append(hallucinations, languageHallucinations(lang)...)
 	lower := strings.ToLower(s)
 	for _, h := range hallucinations {
 		if strings.ToLower(h) == lower {
@@ -0,0 +1,58 @@
+package speech
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/wakeword"
+)
+
+// TestEarSleepsWhileMouthSpeaks checks the handoff: the ear mutes while
+// Otto talks, waitForMouth holds listening until it's done, and the ear
+// wakes up after.
+func TestEarSleepsWhileMouthSpeaks(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	tts, player := newFakeSynth("Jenny"), &fakePlayer{}
+	tts.gate = make(chan struct{})
+	m := NewMouth(tts, player, log, WithChunkSize(0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Start(ctx)
+	defer m.Close()
+	e := NewEar("whisper-cli", "", &wakeword.Detector{}, m, log)
+
+	if e.isMuted() {
+		t.Fatal("ear muted before anything was said")
+	}
+	m.Say("Your pasta is ready.", PriorityNormal)
+	deadline := time.Now().Add(2 * time.Second)
+	for !e.isMuted() {
+		if time.Now().After(deadline) {
+			t.Fatal("ear never muted while the mouth spoke")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	waited := make(chan struct{})
+	go func() {
+		e.waitForMouth(ctx)
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		t.Fatal("waitForMouth returned while the mouth was still speaking")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	tts.gate <- struct{}{}
+	select {
+	case <-waited:
+	case <-time.After(2 * time.Second):
+		t.Fatal("waitForMouth never returned after the mouth finished")
+	}
+	if e.isMuted() || e.isMouthBusy() {
+		t.Error("ear still asleep after the mouth finished")
+	}
+}
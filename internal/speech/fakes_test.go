@@ -0,0 +1,106 @@
+package speech
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSynth synthesizes text as "voice:text", counting calls.  With gate
+// set, each synthesis waits for a value on it (or its context).
+type fakeSynth struct {
+	mu    sync.Mutex
+	voice string
+	calls []string
+	gate  chan struct{}
+}
+
+func newFakeSynth(voice string) *fakeSynth { return &fakeSynth{voice: voice} }
+
+func (f *fakeSynth) Voice() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.voice
+}
+
+func (f *fakeSynth) SetVoice(voice string) {
+	f.mu.Lock()
+	f.voice = voice
+	f.mu.Unlock()
+}
+
+func (f *fakeSynth) SynthesizeAt(ctx context.Context, text string, priority Priority) ([]byte, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, text)
+	voice, gate := f.voice, f.gate
+	f.mu.Unlock()
+	if gate != nil {
+		select {
+		case <-gate:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return []byte(voice + ":" + text), nil
+}
+
+// synthesized returns the texts synthesized so far.
+func (f *fakeSynth) synthesized() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.calls)
+}
+
+// fakePlayer records what it plays.
+type fakePlayer struct {
+	mu     sync.Mutex
+	played []string
+}
+
+func (p *fakePlayer) Play(audio []byte) error {
+	p.mu.Lock()
+	p.played = append(p.played, string(audio))
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *fakePlayer) Stop() {}
+
+// wait returns what's been played once there are n, failing the test
+// if that takes too long.
+func (p *fakePlayer) wait(t *testing.T, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		p.mu.Lock()
+		played := slices.Clone(p.played)
+		p.mu.Unlock()
+		if len(played) >= n {
+			return played
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("played %q, want %d things", played, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// fakeClock is a domain.Clock that only moves when told to.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
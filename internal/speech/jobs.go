@@ -0,0 +1,94 @@
+package speech
+
+import (
+	"slices"
+)
+
+// ── Jobs ─────────────────────────────────────────────────────────
+//
+// What the pipeline is working on right now — the line being
+// synthesized chunk by chunk, whether it came out of the cache, what's
+// queued behind it, and what's being prefetched — so a status display
+// can say why speech hasn't started yet.
+
+// MouthJobs is a snapshot of the mouth's work.
+type MouthJobs struct {
+	Chunks         int      // chunks in the line being spoken; 0 when idle
+	Ready          int      // of those, synthesized or found in the cache so far
+	CacheHit       bool     // every ready chunk came from the cache
+	Queued         int      // lines waiting their turn
+	Prefetching    int      // prefetch chunks queued or running
+	PrefetchGroups []string // their groups, e.g. "step"; ungrouped ones aren't named
+}
+
+// Jobs returns what the mouth is working on.
+func (m *Mouth) Jobs() MouthJobs {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.jobsLocked()
+}
+
+// OnJobsChange registers a callback invoked with a fresh snapshot
+// whenever the mouth's work changes.  It's called from the mouth's
+// goroutines, often; keep it quick.
+func (m *Mouth) OnJobsChange(fn func(MouthJobs)) {
+	m.mu.Lock()
+	m.onJobsChange = fn
+	m.mu.Unlock()
+}
+
+func (m *Mouth) jobsLocked() MouthJobs {
+	j := MouthJobs{
+		Chunks:   m.jobChunks,
+		Ready:    m.jobReady,
+		CacheHit: m.jobReady > 0 && m.jobHits == m.jobReady,
+		Queued:   len(m.queue),
+	}
+	for _, job := range m.inflight {
+		if job.wanted {
+			continue // part of the line being spoken
+		}
+		j.Prefetching++
+		for g := range job.groups {
+			if g != "" && !slices.Contains(j.PrefetchGroups, g) {
+				j.PrefetchGroups = append(j.PrefetchGroups, g)
+			}
+		}
+	}
+	slices.Sort(j.PrefetchGroups)
+	return j
+}
+
+// jobsChanged tells the OnJobsChange callback.  Must be called without
+// m.mu held.
+func (m *Mouth) jobsChanged() {
+	m.mu.Lock()
+	cb := m.onJobsChange
+	var j MouthJobs
+	if cb != nil {
+		j = m.jobsLocked()
+	}
+	m.mu.Unlock()
+	if cb != nil {
+		cb(j)
+	}
+}
+
+// startJob records that a line of n chunks is about to be synthesized.
+func (m *Mouth) startJob(n int) {
+	m.mu.Lock()
+	m.jobChunks, m.jobReady, m.jobHits = n, 0, 0
+	m.mu.Unlock()
+	m.jobsChanged()
+}
+
+// chunkReady records that a chunk of the current line has its audio.
+func (m *Mouth) chunkReady(cached bool) {
+	m.mu.Lock()
+	m.jobReady++
+	if cached {
+		m.jobHits++
+	}
+	m.mu.Unlock()
+	m.jobsChanged()
+}
@@ -0,0 +1,94 @@
+package speech
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// jobCounts is the part of MouthJobs the tests check.
+type jobCounts struct {
+	chunks, ready int
+	hit           bool
+	queued        int
+}
+
+func countsOf(j MouthJobs) jobCounts {
+	return jobCounts{j.Chunks, j.Ready, j.CacheHit, j.Queued}
+}
+
+// waitJobs waits for the mouth's jobs to come to want, failing the test
+// if they never do.
+func waitJobs(t *testing.T, m *Mouth, want jobCounts) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		got := countsOf(m.Jobs())
+		if got == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("jobs = %+v, want %+v", got, want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestMouthJobs(t *testing.T) {
+	tts, player := newFakeSynth("Jenny"), &fakePlayer{}
+	m := NewMouth(tts, player, logger.New(logger.LevelOff, nil), WithChunkSize(20))
+	var mu sync.Mutex
+	var seen []jobCounts
+	m.OnJobsChange(func(j MouthJobs) {
+		mu.Lock()
+		seen = append(seen, countsOf(j))
+		mu.Unlock()
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Start(ctx)
+	defer m.Close()
+
+	// One chunk, synthesized.
+	m.Say("Stir the sauce.", PriorityNormal)
+	player.wait(t, 1)
+	waitJobs(t, m, jobCounts{})
+
+	// Two chunks: the first from the cache, the second held up.
+	tts.mu.Lock()
+	tts.gate = make(chan struct{})
+	tts.mu.Unlock()
+	m.Say("Stir the sauce. Then taste it and season.", PriorityNormal)
+	waitJobs(t, m, jobCounts{chunks: 2, ready: 1, hit: true})
+	m.Say("Plate up.", PriorityNormal)
+	waitJobs(t, m, jobCounts{chunks: 2, ready: 1, hit: true, queued: 1})
+
+	// Interrupt drops the queue and the line; nothing it cut off counts
+	// towards the next one.
+	m.Interrupt()
+	waitJobs(t, m, jobCounts{})
+	tts.mu.Lock()
+	tts.gate = nil
+	tts.mu.Unlock()
+	m.Say("Stir the sauce.", PriorityNormal)
+	player.wait(t, 2)
+	waitJobs(t, m, jobCounts{})
+
+	mu.Lock()
+	defer mu.Unlock()
+	var sawHit bool
+	for _, j := range seen {
+		if j.ready > j.chunks {
+			t.Errorf("reported %d of %d chunks ready", j.ready, j.chunks)
+		}
+		if j == (jobCounts{chunks: 1, ready: 1, hit: true}) {
+			sawHit = true
+		}
+	}
+	if !sawHit {
+		t.Errorf("the line said again after the interrupt wasn't reported as a cache hit: %+v", seen)
+	}
+}
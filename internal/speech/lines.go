@@ -7,9 +7,31 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
+	"sync"
 	"time"
 )
 
+// lineRand picks between alternative lines (fillers, acknowledgments).
+// It's seeded from the clock unless SeedLines fixes it.
+var lineRand = struct {
+	sync.Mutex
+	r *rand.Rand
+}{r: rand.New(rand.NewSource(time.Now().UnixNano()))}
+
+// SeedLines makes line choices repeatable: the same seed picks the same
+// fillers in the same order.  For demos and tests.
+func SeedLines(seed int64) {
+	lineRand.Lock()
+	defer lineRand.Unlock()
+	lineRand.r = rand.New(rand.NewSource(seed))
+}
+
+func pick(lines []string) string {
+	lineRand.Lock()
+	defer lineRand.Unlock()
+	return lines[lineRand.r.Intn(len(lines))]
+}
+
 // ── Greeting / Global ────────────────────────────────────────────
 
 func LineWelcome() string {
@@ -28,11 +50,20 @@ func LineNothingToRepeat() string {
 	return "I haven't said anything yet."
 }
 
+func LineNothingToResume() string {
+	return "I wasn't in the middle of anything."
+}
+
+func LineRetryingRequest() string {
+	return "Sorry, lost my train of thought. Let me try that again."
+}
+
 // ── Recipe selection ─────────────────────────────────────────────
 
 // LineRecipeSelected is spoken after the user picks a recipe number.
-// It reads out the ingredients so they can gather them.
-func LineRecipeSelected(name string, ingredients []string) string {
+// It reads out the ingredients so they can gather them, with quantities
+// put the way they're said (see NormalizeSpeech), then any equipment.
+func LineRecipeSelected(name string, ingredients, equipment []string) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "%s. You'll need: ", name)
 	for i, ing := range ingredients {
@@ -43,14 +74,151 @@ func LineRecipeSelected(name string, ingredients []string) string {
 		}
 		b.WriteString(ing)
 	}
+	if len(equipment) > 0 {
+		fmt.Fprintf(&b, ". You'll also need %s", joinAnd(withArticles(equipment)))
+	}
 	b.WriteString(". Say start when you're ready.")
-	return b.String()
+	return NormalizeSpeech(b.String())
 }
 
 func LineInvalidSelection(payload string) string {
 	return fmt.Sprintf("Invalid selection: %s. Pick a number from the list.", payload)
 }
 
+// LineSearchResults reads out numbered search results.
+func LineSearchResults(names []string) string {
+	if len(names) == 1 {
+		return fmt.Sprintf("I found one: %s. Say one to pick it.", names[0])
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "I found %d. ", len(names))
+	for i, n := range names {
+		fmt.Fprintf(&b, "Number %d, %s. ", i+1, n)
+	}
+	b.WriteString("Say a number to pick one.")
+	return b.String()
+}
+
+func LineNoSearchResults(query string) string {
+	return fmt.Sprintf("I couldn't find any recipes with %s.", query)
+}
+
+// LineSuggestions reads out a few suggested recipes, numbered, each
+// with why it's there.
+func LineSuggestions(names, reasons []string) string {
+	var b strings.Builder
+	for i, n := range names {
+		if i == 0 {
+			fmt.Fprintf(&b, "How about number 1, %s", n)
+		} else {
+			fmt.Fprintf(&b, "Or number %d, %s", i+1, n)
+		}
+		if reasons[i] != "" {
+			b.WriteString(": " + reasons[i])
+		}
+		b.WriteString(". ")
+	}
+	if len(names) == 1 {
+		b.WriteString("Say one to pick it.")
+	} else {
+		b.WriteString("Say a number to pick one.")
+	}
+	return b.String()
+}
+
+// SuggestionReason says why a recipe was suggested: the ingredients
+// asked about that it uses, the season it's tagged for, how long it
+// takes, and whether it's a change from lately or checked against a
+// diet.  Any may be left empty.
+func SuggestionReason(uses []string, season string, total time.Duration, fresh, diet bool) string {
+	var parts []string
+	if len(uses) > 0 {
+		parts = append(parts, "it uses your "+joinAnd(uses))
+	}
+	if season != "" {
+		parts = append(parts, "it's one for "+season)
+	}
+	if total > 0 {
+		parts = append(parts, "it takes about "+FormatDurationSpeech(total.Round(time.Minute)))
+	}
+	if fresh {
+		parts = append(parts, "you haven't made it lately")
+	}
+	if diet {
+		parts = append(parts, "it fits your diet")
+	}
+	return joinAnd(parts)
+}
+
+// LineNoSuggestions answers "what should I cook?" when nothing fits.
+func LineNoSuggestions() string {
+	return "Nothing I know fits that. Say list to see every recipe."
+}
+
+// ── Tags and collections ─────────────────────────────────────────
+
+func LineTagged(recipe, tag string, removed, changed bool) string {
+	switch {
+	case removed && changed:
+		return fmt.Sprintf("Removed the %s tag from %s.", tag, recipe)
+	case removed:
+		return fmt.Sprintf("%s isn't tagged %s.", recipe, tag)
+	case changed:
+		return fmt.Sprintf("Tagged %s as %s.", recipe, tag)
+	default:
+		return fmt.Sprintf("%s is already tagged %s.", recipe, tag)
+	}
+}
+
+func LineCollected(recipe, collection string, removed, changed bool) string {
+	switch {
+	case removed && changed:
+		return fmt.Sprintf("Removed %s from %s.", recipe, collection)
+	case removed:
+		return fmt.Sprintf("%s isn't in %s.", recipe, collection)
+	case changed:
+		return fmt.Sprintf("Added %s to %s.", recipe, collection)
+	default:
+		return fmt.Sprintf("%s is already in %s.", recipe, collection)
+	}
+}
+
+func LineDuplicated(variant string, cooking bool) string {
+	if cooking {
+		return fmt.Sprintf("Saved a copy as %s. We'll keep cooking from the copy, so any changes stay off the original.", variant)
+	}
+	return fmt.Sprintf("Saved a copy as %s and selected it. Changes you ask for now only touch the copy.", variant)
+}
+
+func LineNoted(step int, kept bool) string {
+	if kept {
+		return fmt.Sprintf("Noted on step %d, and saved to the recipe for next time.", step)
+	}
+	return fmt.Sprintf("Noted on step %d. Say keep my notes if you want them in the recipe for next time.", step)
+}
+
+func LineNotesKept(n int) string {
+	switch n {
+	case 0:
+		return "There are no new notes to keep."
+	case 1:
+		return "Saved your note to the recipe for next time."
+	default:
+		return fmt.Sprintf("Saved your %d notes to the recipe for next time.", n)
+	}
+}
+
+func LineCollections(names []string) string {
+	if len(names) == 0 {
+		return "You don't have any collections yet. Say add this to, and a name, to start one."
+	}
+	return fmt.Sprintf("Your collections: %s.", joinAnd(names))
+}
+
+func LineNoLabelMatches(label string) string {
+	return fmt.Sprintf("No recipes are tagged or collected as %s.", label)
+}
+
 func LinePickRecipeFirst() string {
 	return "Pick a recipe first."
 }
@@ -85,6 +253,30 @@ func LineSkipped() string {
 	return "Skipped."
 }
 
+func LineSkippedSection() string {
+	return "Skipped the rest of that section."
+}
+
+func LineSkippedTo(section string) string {
+	return fmt.Sprintf("Skipping ahead to %s.", section)
+}
+
+func LineSkippedToStep(step int) string {
+	return fmt.Sprintf("Skipping ahead to step %d.", step)
+}
+
+func LineDeclinedSection(section string) string {
+	return fmt.Sprintf("Okay, we'll leave out the %s.", section)
+}
+
+func LineNoSuchSection(section string) string {
+	return fmt.Sprintf("There's no %s section coming up that I can skip.", section)
+}
+
+func LineNoSuchStep(step string) string {
+	return fmt.Sprintf("There's no step %s ahead of us to skip to.", step)
+}
+
 func LinePaused() string {
 	return "Paused. Timers are on hold. Say resume when ready."
 }
@@ -101,6 +293,177 @@ func LineResumed() string {
 	return "Resumed."
 }
 
+// ── Hands-off waits ──────────────────────────────────────────────
+
+func LineWaitStarted(d time.Duration, until time.Time) string {
+	return fmt.Sprintf("Okay, %s. I'll call you at %s, and you can close me until then.", FormatDurationSpeech(d), until.Format(time.Kitchen))
+}
+
+func LineWaitingStill(recipeName string, left time.Duration) string {
+	return fmt.Sprintf("Welcome back. %s is still waiting, %s to go. Say next if it's ready early.", recipeName, FormatDurationSpeech(left))
+}
+
+func LineWelcomeBack(recipeName string) string {
+	return fmt.Sprintf("Welcome back. The wait's over, let's finish %s.", recipeName)
+}
+
+// ── Unfinished sessions ──────────────────────────────────────────
+
+func LineUnfinishedOne(recipeName string, step, total int) string {
+	return fmt.Sprintf("You didn't finish %s last time. You were on step %d of %d. Pick it up, or abandon it?", recipeName, step, total)
+}
+
+func LineUnfinishedMany(n int) string {
+	return fmt.Sprintf("You have %d unfinished sessions from last time. Say resume or abandon, and the number.", n)
+}
+
+func LinePickedUp(recipeName string, step, total int) string {
+	return fmt.Sprintf("Okay, back to %s. Step %d of %d.", recipeName, step, total)
+}
+
+func LineUnfinishedAbandoned(recipeName string) string {
+	return fmt.Sprintf("%s abandoned.", recipeName)
+}
+
+// LineConfirmAbandonUnfinished asks before throwing away a session from
+// last time.
+func LineConfirmAbandonUnfinished(recipeName string) string {
+	return fmt.Sprintf("Throw away %s and its progress? Yes or no.", recipeName)
+}
+
+func LineUnfinishedKept(recipeName string) string {
+	return fmt.Sprintf("Okay, keeping %s. Say resume or abandon.", recipeName)
+}
+
+func LineNoSuchSession(n int) string {
+	return fmt.Sprintf("There's no session %d. Pick one from the list.", n)
+}
+
+// LineHowMuch answers "how much garlic?" from the recipe.
+func LineHowMuch(amounts []string) string {
+	return NormalizeSpeech(fmt.Sprintf("You need %s.", joinAnd(amounts)))
+}
+
+// ── Versions ─────────────────────────────────────────────────────
+
+func LineVersions(count, current int) string {
+	if count <= 1 {
+		return "This recipe hasn't been changed yet. It's the original."
+	}
+	return fmt.Sprintf("%d versions; you're on version %d. Say go back to version and a number to restore one, or cook version and a number.", count, current)
+}
+
+func LineVersionRestored(old, now int) string {
+	return fmt.Sprintf("Back to version %d. It's saved as version %d, so nothing's lost.", old, now)
+}
+
+func LineVersionCurrent(v int) string {
+	return fmt.Sprintf("Version %d is the current one.", v)
+}
+
+func LineNoSuchVersion(v int) string {
+	return fmt.Sprintf("There's no version %d. Say versions to hear the list.", v)
+}
+
+func LineVersionWhileCooking() string {
+	return "You're cooking this one right now. Finish or quit first, then switch versions."
+}
+
+// ── Comparing ────────────────────────────────────────────────────
+
+// LineComparison sums up how recipe b differs from recipe a: the
+// ingredients it adds, drops, or uses a different amount of, then its
+// steps and time.
+func LineComparison(a, b string, onlyA, onlyB, changed []string, stepsA, stepsB int, timeA, timeB time.Duration) string {
+	var parts []string
+	if len(onlyB) > 0 {
+		parts = append(parts, "adds "+fewNames(onlyB))
+	}
+	if len(onlyA) > 0 {
+		parts = append(parts, "drops "+fewNames(onlyA))
+	}
+	if len(changed) > 0 {
+		parts = append(parts, "uses different amounts of "+fewNames(changed))
+	}
+	switch {
+	case stepsA != stepsB:
+		parts = append(parts, fmt.Sprintf("has %d steps to %d", stepsB, stepsA))
+	case len(parts) > 0:
+		parts = append(parts, fmt.Sprintf("has the same %d steps", stepsB))
+	}
+	switch d := (timeB - timeA).Round(time.Minute); {
+	case d > 0:
+		parts = append(parts, "takes about "+FormatDurationSpeech(d)+" longer")
+	case d < 0:
+		parts = append(parts, "saves about "+FormatDurationSpeech(-d))
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%s and %s use the same ingredients, in the same %d steps.", a, b, stepsA)
+	}
+	return fmt.Sprintf("Next to %s, %s %s.", a, b, joinAnd(parts))
+}
+
+// fewNames names up to three things and counts the rest.
+func fewNames(names []string) string {
+	if len(names) <= 3 {
+		return joinAnd(names)
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:3], ", "), len(names)-3)
+}
+
+// LineCompareWhich asks for the two recipes to compare.
+func LineCompareWhich() string {
+	return "Which two? Say compare and two numbers from the list, like compare 1 and 2, or two versions, like compare version 1 and version 3."
+}
+
+// LineNoRecipeToCompare answers a comparison naming a recipe or version
+// that can't be found.
+func LineNoRecipeToCompare(ref string) string {
+	return fmt.Sprintf("I can't find %s to compare.", ref)
+}
+
+// ── Prep list ────────────────────────────────────────────────────
+
+// LinePrepList reads out the knife work, each item put as "the garlic
+// minced".
+func LinePrepList(items []string) string {
+	if len(items) == 0 {
+		return "Nothing to chop for this one."
+	}
+	return fmt.Sprintf("Before the heat goes on, get %s.", joinAnd(items))
+}
+
+// ── Serve time ───────────────────────────────────────────────────
+
+func LineServeTimeNoted(at time.Time) string {
+	return fmt.Sprintf("Okay, eating at %s. Pick a recipe and I'll tell you when to start.", at.Format(time.Kitchen))
+}
+
+func LineServeStartBy(at, startBy time.Time) string {
+	if startBy.Before(time.Now()) {
+		late := time.Since(startBy).Round(time.Minute)
+		return fmt.Sprintf("That's tight: starting now, it'll be ready about %s after %s.", FormatDurationSpeech(late), at.Format(time.Kitchen))
+	}
+	return fmt.Sprintf("To eat at %s, start by %s.", at.Format(time.Kitchen), startBy.Format(time.Kitchen))
+}
+
+// LineServeTimeSet confirms a serve time mid-cook; late is how far past
+// it the current estimate runs (zero or negative when on track).
+func LineServeTimeSet(at time.Time, late time.Duration) string {
+	if late >= time.Minute {
+		return fmt.Sprintf("Okay, eating at %s. At this pace you're about %s behind.", at.Format(time.Kitchen), FormatDurationSpeech(late.Round(time.Minute)))
+	}
+	return fmt.Sprintf("Okay, eating at %s. You're on track.", at.Format(time.Kitchen))
+}
+
+func LineServeTimeCleared() string {
+	return "Okay, no serve time."
+}
+
+func LineBadServeTime(said string) string {
+	return fmt.Sprintf("I didn't get a time from %q. Try something like dinner at 7:30.", said)
+}
+
 func LineAbandoned() string {
 	return "Session abandoned."
 }
@@ -117,6 +480,64 @@ func LineNoActiveTimers() string {
 	return "No active timers to dismiss."
 }
 
+// LineTimerPaused confirms one timer was paused.
+func LineTimerPaused(label string) string {
+	return fmt.Sprintf("%s timer paused.", label)
+}
+
+// LineTimerResumed confirms one timer is counting down again.
+func LineTimerResumed(label string) string {
+	return fmt.Sprintf("%s timer resumed.", label)
+}
+
+// LineTimerCancelled confirms a timer was stopped before it went off.
+func LineTimerCancelled(label string) string {
+	return fmt.Sprintf("%s timer cancelled.", label)
+}
+
+// LineTimerRestarted confirms a timer was set back to its full length.
+func LineTimerRestarted(label string, d time.Duration) string {
+	return fmt.Sprintf("%s timer restarted — %s.", label, FormatDurationSpeech(d))
+}
+
+// LineTimersPaused confirms "pause all timers".
+func LineTimersPaused(n int) string {
+	switch n {
+	case 0:
+		return "No timers are running."
+	case 1:
+		return "Paused the one running timer."
+	}
+	return fmt.Sprintf("Paused all %d timers. Say resume all timers to start them again.", n)
+}
+
+// LineTimersResumed confirms "resume all timers".
+func LineTimersResumed(n int) string {
+	switch n {
+	case 0:
+		return "No timers are paused."
+	case 1:
+		return "Resumed the paused timer."
+	}
+	return fmt.Sprintf("Resumed all %d timers.", n)
+}
+
+// LineTimerCantDo says a timer isn't in a state the action applies to,
+// e.g. pausing one that's already gone off.
+func LineTimerCantDo(label, status, action string) string {
+	return fmt.Sprintf("The %s timer is %s, so I can't %s it.", label, status, action)
+}
+
+// LineWhichTimer asks which timer a command meant.
+func LineWhichTimer() string {
+	return "Which timer? Say its number or name — status lists them."
+}
+
+// LineNoSuchTimer answers "dismiss 4" when there's no timer 4.
+func LineNoSuchTimer(ref string) string {
+	return fmt.Sprintf("There's no timer %s. Say status to see them.", ref)
+}
+
 // LineNextPreview builds a short spoken preview of the upcoming step.
 func LineNextPreview(nextOrder int, instruction string) string {
 	// Truncate to ~80 chars for speech.
@@ -136,6 +557,83 @@ func LineMustWait(timerLabel string) string {
 	return fmt.Sprintf("Wait for the %s timer before moving on — the next step needs it done.", timerLabel)
 }
 
+// LineConfirmHeard reads back a command that may have been misheard.
+func LineConfirmHeard(heard string) string {
+	return fmt.Sprintf("Did you say \"%s\"? Yes or no.", heard)
+}
+
+// LineMisheardNothing answers "that's not what I said" when there's no
+// recent voice command to correct.
+func LineMisheardNothing() string {
+	return "I haven't acted on anything you said just now."
+}
+
+// LineSayAgain asks for a misheard command again.
+func LineSayAgain() string {
+	return "Sorry. What did you say?"
+}
+
+// LineFalseWake acknowledges that nobody was talking to Otto.
+func LineFalseWake() string {
+	return "Sorry, my mistake."
+}
+
+// LineMissedWake apologises for not hearing the wake word; lowered when
+// Otto will now listen out for it more closely.
+func LineMissedWake(lowered bool) string {
+	if lowered {
+		return "Sorry I keep missing you. I'll listen out for Hey Chef more closely."
+	}
+	return "Sorry I missed that."
+}
+
+// LineMisheardNoted acknowledges a correction that isn't a command.
+func LineMisheardNoted() string {
+	return "Sorry, noted."
+}
+
+func LineConfirmCancelled() string {
+	return "Okay, never mind."
+}
+
+// LineConfirmQuit asks before abandoning a session in progress.
+func LineConfirmQuit(recipeName string) string {
+	return fmt.Sprintf("You're still cooking %s. Quit and lose your progress? Yes or no.", recipeName)
+}
+
+// LineEquipmentCheck asks before starting whether everything the recipe
+// needs is to hand.
+func LineEquipmentCheck(equipment []string) string {
+	return fmt.Sprintf("Before we start: you'll need %s. Got everything?", joinAnd(withArticles(equipment)))
+}
+
+// LineEquipmentMissing is the reply to "no" at the equipment check.
+func LineEquipmentMissing() string {
+	return "No problem. Tell me what you're missing and I'll work around it, or say start when you're set."
+}
+
+// LineReplan asks whether to apply the follow-up changes the agent
+// proposed after a modification.
+func LineReplan(summary string) string {
+	summary = strings.TrimSpace(summary)
+	if strings.HasSuffix(summary, "?") {
+		return summary
+	}
+	if summary == "" {
+		return "That change affects the steps too. Update them?"
+	}
+	return summary + " Update the steps?"
+}
+
+func LineReplanDone() string {
+	return "Steps updated."
+}
+
+// LineConfirmRemoval asks before deleting steps or ingredients.
+func LineConfirmRemoval(items []string) string {
+	return fmt.Sprintf("That removes %s. Go ahead?", joinAnd(items))
+}
+
 func LineUnknown(input string) string {
 	return fmt.Sprintf("Didn't catch that: %s.", input)
 }
@@ -150,6 +648,276 @@ func LineAIError() string {
 	return "Something went wrong with the AI. Try again."
 }
 
+// LineAICancelled acknowledges an AI request called off mid-way.
+func LineAICancelled() string {
+	return "Okay, dropped it."
+}
+
+// LineAITimeout is said when the AI took too long to answer.
+func LineAITimeout() string {
+	return "The AI is taking too long. Ask me again in a bit, or say \"what were you saying\" to retry."
+}
+
+// LineNothingToCancel answers "never mind" with nothing going on.
+func LineNothingToCancel() string {
+	return "Nothing to cancel."
+}
+
+// LineSlowDown turns away an AI request over the rate limit.
+func LineSlowDown() string {
+	return "That's a lot of questions at once. Give me a minute."
+}
+
+// LineSafetyBlocked explains a change that wasn't applied.
+func LineSafetyBlocked(reason string) string {
+	return "I won't make that change. " + reason
+}
+
+// LineSafetyRest stands in for the AI's summary when part of a change
+// was blocked.
+func LineSafetyRest(applied string) string {
+	return "The rest goes ahead: " + applied + "."
+}
+
+// LineSafetyWarning asks before applying a change the safety check
+// has doubts about.
+func LineSafetyWarning(warnings []string) string {
+	return "Heads up. " + strings.Join(warnings, " ") + " Apply it anyway? Yes or no."
+}
+
+// LineConditionChecked confirms a condition checked off and says what's
+// still open.
+func LineConditionChecked(open []string) string {
+	switch len(open) {
+	case 0:
+		return "Got it. That's everything for this step. Say next when you're ready."
+	case 1:
+		return "Got it. Still waiting on: " + open[0] + "."
+	}
+	return fmt.Sprintf("Got it. %d still to go: %s.", len(open), strings.Join(open, "; "))
+}
+
+// LineWhichCondition asks which condition was meant when more than one
+// fits.
+func LineWhichCondition() string {
+	return "Which one? Say check off and its number."
+}
+
+// LineNoSuchCondition answers a check-off that matches none of the
+// step's conditions.
+func LineNoSuchCondition() string {
+	return "That isn't one of this step's conditions."
+}
+
+// LineConditionsOpen asks before moving past a step with conditions not
+// yet checked off.
+func LineConditionsOpen(open []string) string {
+	return "You haven't checked off: " + strings.Join(open, "; ") + ". Move on anyway? Yes or no."
+}
+
+// LineDelegated confirms tasks handed to a helper.
+func LineDelegated(helper string, tasks []string) string {
+	if len(tasks) == 1 {
+		return fmt.Sprintf("%s, over to you: %s.", helper, tasks[0])
+	}
+	return fmt.Sprintf("%s, over to you: %s.", helper, strings.Join(tasks, "; then "))
+}
+
+// LineNothingToHandOff answers "give the side jobs to Sam" on a step
+// without any.
+func LineNothingToHandOff() string {
+	return "There's nothing on the side for this step. Say the job too, like: Sam, chop the broccoli."
+}
+
+// LineTaskDone thanks a helper, and says how many of their tasks are
+// left.
+func LineTaskDone(helper string, open int) string {
+	switch open {
+	case 0:
+		return fmt.Sprintf("Thanks, %s. That's all yours done.", helper)
+	case 1:
+		return fmt.Sprintf("Thanks, %s. One more to go.", helper)
+	}
+	return fmt.Sprintf("Thanks, %s. %d more to go.", helper, open)
+}
+
+// LineTaskReopened answers "Sam isn't done after all".
+func LineTaskReopened(helper string, tasks []string) string {
+	return fmt.Sprintf("OK, %s is still on %s.", helper, strings.Join(tasks, " and "))
+}
+
+// LineNoSuchTask answers a done or not-done that fits none of the
+// helper's tasks.
+func LineNoSuchTask(helper string) string {
+	return fmt.Sprintf("I can't tell which of %s's jobs you mean.", helper)
+}
+
+// LineNoTasks answers a task question when nobody, or not that helper,
+// has been given anything.
+func LineNoTasks(helper string) string {
+	if helper == "" {
+		return "Nobody's been given a job yet."
+	}
+	return fmt.Sprintf("%s hasn't been given a job.", helper)
+}
+
+// LineOpenTasks sums up the helpers' open tasks.
+func LineOpenTasks(open []string) string {
+	switch len(open) {
+	case 0:
+		return "Every job's done."
+	case 1:
+		return "Still going: " + open[0] + "."
+	}
+	return fmt.Sprintf("%d jobs going. %s.", len(open), strings.Join(open, ". "))
+}
+
+// LineCalendarExported confirms milestones written to the calendar file.
+func LineCalendarExported(n int) string {
+	if n == 1 {
+		return "Done. One reminder's ready for your calendar; I'll keep it up to date."
+	}
+	return fmt.Sprintf("Done. %d reminders are ready for your calendar; I'll keep them up to date.", n)
+}
+
+// LineNothingToSchedule answers a calendar export with nothing coming
+// up.
+func LineNothingToSchedule() string {
+	return "There's nothing coming up to put in a calendar. Start a wait or a timer, or tell me when you're eating."
+}
+
+// LineTimelineWritten confirms the session's timeline was written.
+func LineTimelineWritten() string {
+	return "Done. The timeline's ready to open in your browser."
+}
+
+// LineNoTimeline answers a timeline request with no session started
+// or finished.
+func LineNoTimeline() string {
+	return "There's nothing to draw yet. Cook something first and I'll lay it out for you."
+}
+
+// LineMicOff is shown when the microphone goes off.
+func LineMicOff() string {
+	return "Microphone off. I can't hear anything now. Press Ctrl+O or type mic on to turn it back on."
+}
+
+// LineMicOn confirms the microphone is back on.
+func LineMicOn() string {
+	return "Microphone's back on. Say Hey Chef when you need me."
+}
+
+// LineMicAlready answers turning the mic off or on when it already is.
+func LineMicAlready(off bool) string {
+	if off {
+		return "The microphone's already off."
+	}
+	return "The microphone's already on."
+}
+
+// LineNoMic answers mic commands without voice input.
+func LineNoMic() string {
+	return "Voice input isn't on, so there's no microphone to turn off."
+}
+
+// LineAudioOff is shown as speech and voice input shut down.
+func LineAudioOff() string {
+	return "Voice off. I'll answer here in text until you type voice on."
+}
+
+// LineAudioOn confirms speech, and voice input when listening, are
+// back.
+func LineAudioOn(listening bool) string {
+	if !listening {
+		return "Voice is back on."
+	}
+	return "Voice is back on. Say Hey Chef when you need me."
+}
+
+// LineAudioAlready answers voice off or on when it already is.
+func LineAudioAlready(off bool) string {
+	if off {
+		return "The voice is already off."
+	}
+	return "The voice is already on."
+}
+
+// LineNoAudio answers voice off and on when Otto started without
+// speech or voice input.
+func LineNoAudio() string {
+	return "Speech and voice input weren't started, so there's nothing to turn off or on."
+}
+
+// LineReloaded confirms settings changed by reloading the config.
+func LineReloaded(changed []string) string {
+	return "Settings updated: " + joinAnd(changed) + "."
+}
+
+// LineNothingReloaded answers "reload" when the config hasn't changed.
+func LineNothingReloaded() string {
+	return "Nothing's changed in the config since I last read it."
+}
+
+// LineNoConfig answers "reload" with no config file to read.
+func LineNoConfig() string {
+	return "There's no config file to reload. Run ottocook setup to make one."
+}
+
+// LineVoiceEnrolled confirms learning a voice; prints is how many
+// samples of it Otto now has.
+func LineVoiceEnrolled(name string, prints int) string {
+	if prints == 1 {
+		return fmt.Sprintf("Nice to meet you, %s. Say that a couple more times and I'll know your voice better.", name)
+	}
+	return fmt.Sprintf("Got it, %s. I know your voice a little better now.", name)
+}
+
+// LineNoVoiceprint answers enrolling a voice Otto hasn't heard.
+func LineNoVoiceprint() string {
+	return "I need to hear you say it. Say remember my voice as, then your name."
+}
+
+// LineNoSpeakerID answers speaker commands without speaker recognition.
+func LineNoSpeakerID() string {
+	return "I can't tell voices apart. Start me with -speaker-model to turn that on."
+}
+
+// LineWhoseDiet asks who a dietary need is for.
+func LineWhoseDiet() string {
+	return "I don't know whose voice that is yet. Say remember my voice as, then your name, first."
+}
+
+// LineDietNoted confirms a dietary need.
+func LineDietNoted(name, need string) string {
+	return fmt.Sprintf("Noted: %s, %s. I'll keep it in mind when %s asks.", name, need, name)
+}
+
+// LineNoVoice answers wake word complaints without voice input.
+func LineNoVoice() string {
+	return "Voice input isn't on, so I wasn't listening for Hey Chef."
+}
+
+// LineNoAnswerToRate answers feedback when there's no recent AI answer.
+func LineNoAnswerToRate() string {
+	return "I haven't answered anything just now."
+}
+
+// LineAnswerThanks acknowledges a good rating.
+func LineAnswerThanks() string {
+	return "Good to know."
+}
+
+// LineAnswerGiveUp answers a second "that's wrong" without a third try.
+func LineAnswerGiveUp() string {
+	return "Sorry, I don't have a better answer. Check the recipe."
+}
+
+// LineOfflineAnswer prefixes an answer from the built-in notes so the
+// user knows it didn't come from the AI.
+func LineOfflineAnswer(answer string) string {
+	return "I can't reach the AI, so this is from my own notes. " + answer
+}
+
 // ── Thinking fillers ─────────────────────────────────────────────
 // Spoken while waiting for the AI to respond. Randomized to avoid repetition.
 
@@ -194,17 +962,22 @@ var thinkingClassify = []string{
 
 // LineThinkingQuestion returns a random filler for when a question is being processed.
 func LineThinkingQuestion() string {
-	return thinkingQuestion[rand.Intn(len(thinkingQuestion))]
+	return pick(thinkingQuestion)
+}
+
+// LineThinkingAgain is the filler while a rejected answer is retried.
+func LineThinkingAgain() string {
+	return "Let me think again."
 }
 
 // LineThinkingModify returns a random filler for when a modification is being processed.
 func LineThinkingModify() string {
-	return thinkingModify[rand.Intn(len(thinkingModify))]
+	return pick(thinkingModify)
 }
 
 // LineThinkingClassify returns a random filler for when the AI is classifying unknown input.
 func LineThinkingClassify() string {
-	return thinkingClassify[rand.Intn(len(thinkingClassify))]
+	return pick(thinkingClassify)
 }
 
 // ThinkingFillers returns every filler string (question + modify + classify) so they
@@ -220,9 +993,10 @@ func ThinkingFillers() []string {
 // ── Step narration ───────────────────────────────────────────────
 
 // LineStep builds the spoken text for a cooking step. It includes
-// conditions, tips, and timer info so the user gets everything in
-// one continuous utterance.
-func LineStep(order, total int, instruction string, conditions []string, tips []string, timerLabel string, timerDur time.Duration) string {
+// conditions, tips, notes from earlier cooks, and timer info so the
+// user gets everything in one continuous utterance.  Quantities,
+// ranges, and temperatures are normalized for speech.
+func LineStep(order, total int, instruction string, conditions, tips, notes []string, timerLabel string, timerDur time.Duration) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "Step %d of %d. %s", order, total, instruction)
 	for _, c := range conditions {
@@ -231,24 +1005,75 @@ func LineStep(order, total int, instruction string, conditions []string, tips []
 	for _, t := range tips {
 		fmt.Fprintf(&b, " Tip: %s.", t)
 	}
+	for _, n := range notes {
+		fmt.Fprintf(&b, " Your note from last time: %s.", strings.TrimRight(n, "."))
+	}
 	if timerLabel != "" {
 		fmt.Fprintf(&b, " Timer set: %s, %s.", timerLabel, FormatDurationSpeech(timerDur))
 	}
-	return b.String()
+	return NormalizeSpeech(b.String())
 }
 
 // ── Status ───────────────────────────────────────────────────────
 
-func LineStatus(step, total int, recipeName string, activeTimers int) string {
+// LineStatus sums up the session.  pace is how far behind (positive) or
+// ahead of the recipe's estimates the cook is; finish is when it should
+// be done, or zero when there's no estimate.
+func LineStatus(step, total int, recipeName string, activeTimers int, pace time.Duration, finish time.Time) string {
 	s := fmt.Sprintf("Step %d of %d, cooking %s.", step, total, recipeName)
 	if activeTimers == 1 {
 		s += " 1 timer running."
 	} else if activeTimers > 1 {
 		s += fmt.Sprintf(" %d timers running.", activeTimers)
 	}
+	if p := LinePace(pace); p != "" {
+		s += " " + p
+	}
+	if !finish.IsZero() {
+		s += fmt.Sprintf(" Done around %s.", finish.Format(time.Kitchen))
+	}
 	return s
 }
 
+// LinePace says how the cook is doing against the recipe's estimates,
+// or nothing when it's within a minute either way.
+func LinePace(pace time.Duration) string {
+	pace = pace.Round(time.Minute)
+	switch {
+	case pace >= time.Minute:
+		return fmt.Sprintf("You're running %s behind the recipe's estimates.", FormatDurationSpeech(pace))
+	case pace <= -time.Minute:
+		return fmt.Sprintf("You're %s ahead of the recipe's estimates.", FormatDurationSpeech(-pace))
+	}
+	return ""
+}
+
+// ── Cook-along ───────────────────────────────────────────────────
+
+func LineCookAlongJoined(partner string) string {
+	return fmt.Sprintf("%s is cooking along with you.", partner)
+}
+
+func LineCookAlongPickedUp(partner, recipeName string, step int) string {
+	return fmt.Sprintf("%s is cooking %s, so we'll join them on step %d.", partner, recipeName, step)
+}
+
+func LineCookAlongProgress(partner string, step, total int, status string) string {
+	switch status {
+	case "completed":
+		return fmt.Sprintf("%s has finished.", partner)
+	case "paused":
+		return fmt.Sprintf("%s has paused on step %d.", partner, step)
+	case "abandoned":
+		return fmt.Sprintf("%s has stopped cooking.", partner)
+	}
+	return fmt.Sprintf("%s is on step %d of %d.", partner, step, total)
+}
+
+func LineCookAlongLeft(partner string) string {
+	return fmt.Sprintf("%s has left the cook-along.", partner)
+}
+
 // ── Helpers ──────────────────────────────────────────────────────
 
 // ── Listening acknowledgment ─────────────────────────────────────
@@ -268,7 +1093,7 @@ var listeningFillers = []string{
 // LineListening returns a random acknowledgment for when the wake
 // word is detected.
 func LineListening() string {
-	return listeningFillers[rand.Intn(len(listeningFillers))]
+	return pick(listeningFillers)
 }
 
 // ListeningFillers returns all listening acknowledgment strings so
@@ -282,6 +1107,23 @@ func ListeningFillers() []string {
 // FormatDurationSpeech returns a human-friendly spoken duration.
 func FormatDurationSpeech(d time.Duration) string {
 	d = d.Round(time.Second)
+	if d >= time.Hour {
+		// Long waits: hours and whole minutes; the seconds don't matter.
+		h := int(d.Hours())
+		m := int(d.Round(time.Minute).Minutes()) % 60
+		hours := fmt.Sprintf("%d hours", h)
+		if h == 1 {
+			hours = "1 hour"
+		}
+		switch m {
+		case 0:
+			return hours
+		case 1:
+			return hours + " 1 minute"
+		default:
+			return fmt.Sprintf("%s %d minutes", hours, m)
+		}
+	}
 	m := int(d.Minutes())
 	s := int(d.Seconds()) % 60
 	switch {
@@ -295,3 +1137,26 @@ func FormatDurationSpeech(d time.Duration) string {
 		return fmt.Sprintf("%d minutes %d seconds", m, s)
 	}
 }
+
+// withArticles puts "a" or "an" before each item: "wok" -> "a wok".
+func withArticles(items []string) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = article(strings.ToLower(item)) + " " + item
+	}
+	return out
+}
+
+// joinAnd joins items as spoken English: "a", "a and b", "a, b, and c".
+func joinAnd(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " and " + items[1]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1]
+	}
+}
@@ -0,0 +1,46 @@
+package speech
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLineComparison(t *testing.T) {
+	tests := []struct {
+		name           string
+		onlyA, onlyB   []string
+		changed        []string
+		stepsA, stepsB int
+		timeA, timeB   time.Duration
+		want           string
+	}{
+		{
+			name:   "same",
+			stepsA: 6, stepsB: 6, timeA: 40 * time.Minute, timeB: 40 * time.Minute,
+			want: "v1 and v2 use the same ingredients, in the same 6 steps.",
+		},
+		{
+			name:  "adds and drops",
+			onlyA: []string{"butter"}, onlyB: []string{"olive oil"},
+			stepsA: 6, stepsB: 6, timeA: 40 * time.Minute, timeB: 40 * time.Minute,
+			want: "Next to v1, v2 adds olive oil, drops butter, and has the same 6 steps.",
+		},
+		{
+			name:    "more than three changed, longer",
+			changed: []string{"garlic", "cream", "salt", "pepper", "basil"},
+			stepsA:  6, stepsB: 7, timeA: 40 * time.Minute, timeB: 55 * time.Minute,
+			want: "Next to v1, v2 uses different amounts of garlic, cream, salt and 2 more, has 7 steps to 6, and takes about 15 minutes longer.",
+		},
+		{
+			name:   "only quicker",
+			stepsA: 6, stepsB: 6, timeA: 40 * time.Minute, timeB: 30 * time.Minute,
+			want: "Next to v1, v2 saves about 10 minutes.",
+		},
+	}
+	for _, tt := range tests {
+		got := LineComparison("v1", "v2", tt.onlyA, tt.onlyB, tt.changed, tt.stepsA, tt.stepsB, tt.timeA, tt.timeB)
+		if got != tt.want {
+			t.Errorf("%s:\n got %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package speech
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ── Misrecognitions ─────────────────────────────────────────────
+//
+// "That's not what I said" is the one signal there is about how well
+// voice input is doing.  Each correction goes into a small JSONL log:
+// what whisper heard, what the cook meant (when they said), and how sure
+// whisper was.  Corrections where nobody was talking to Otto at all are
+// false wakes.  Over a few sessions the log shows which commands keep
+// getting mangled, how often the wake word fires on nothing, and what
+// -stt-min-confidence would have caught — the things the wake threshold,
+// the whisper model and the confidence cut-off are tuned against.
+
+// Misheard is one logged correction.
+type Misheard struct {
+	At         time.Time `json:"at"`
+	Heard      string    `json:"heard"`
+	Meant      string    `json:"meant,omitempty"`      // "" when the cook didn't say
+	Confidence float64   `json:"confidence"`           // whisper's, or -1 when unmeasured
+	FalseWake  bool      `json:"false_wake,omitempty"` // nobody was talking to Otto
+	Audio      string    `json:"audio,omitempty"`      // the kept clip, with -keep-audio
+}
+
+// MisheardLog appends corrections to a JSONL file.  A nil *MisheardLog
+// records nothing.
+type MisheardLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewMisheardLog logs corrections to path, creating it (and its
+// directory) on the first one.
+func NewMisheardLog(path string) *MisheardLog {
+	return &MisheardLog{path: path}
+}
+
+// Record appends one correction.
+func (l *MisheardLog) Record(m Misheard) error {
+	if l == nil {
+		return nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadMisheard reads the corrections logged at path.  Lines that don't
+// parse are skipped and reported; the rest are returned.
+func ReadMisheard(path string) ([]Misheard, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []Misheard
+	var errs []error
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var m Misheard
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			errs = append(errs, fmt.Errorf("%s:%d: %w", path, n, err))
+			continue
+		}
+		out = append(out, m)
+	}
+	if err := sc.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return out, errors.Join(errs...)
+}
+
+// MisheardPair is one heard→meant mix-up and how often it happened.
+// Meant is "" when the cook didn't say what they meant.
+type MisheardPair struct {
+	Heard string
+	Meant string
+	Count int
+}
+
+// ConfidenceCut is how many measured misrecognitions a given
+// -stt-min-confidence would have read back before acting on.
+type ConfidenceCut struct {
+	Threshold float64
+	Caught    int
+}
+
+// MisheardReport summarises a correction log.
+type MisheardReport struct {
+	Total      int
+	FalseWakes int
+	Measured   int             // entries with a confidence
+	Pairs      []MisheardPair  // most common first; false wakes excluded
+	Cuts       []ConfidenceCut // by rising threshold
+}
+
+// reportThresholds are the confidence cut-offs a report evaluates.
+var reportThresholds = []float64{0.5, 0.6, 0.7, 0.8, 0.9}
+
+// SummarizeMisheard groups corrections by what was heard and meant,
+// ignoring case and trailing punctuation.
+func SummarizeMisheard(entries []Misheard) MisheardReport {
+	r := MisheardReport{Total: len(entries)}
+	counts := make(map[[2]string]int)
+	for _, m := range entries {
+		if m.Confidence >= 0 {
+			r.Measured++
+		}
+		if m.FalseWake {
+			r.FalseWakes++
+			continue
+		}
+		counts[[2]string{normalizeHeard(m.Heard), normalizeHeard(m.Meant)}]++
+	}
+	for k, n := range counts {
+		r.Pairs = append(r.Pairs, MisheardPair{Heard: k[0], Meant: k[1], Count: n})
+	}
+	slices.SortFunc(r.Pairs, func(a, b MisheardPair) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Heard, b.Heard), cmp.Compare(a.Meant, b.Meant))
+	})
+
+	for _, t := range reportThresholds {
+		cut := ConfidenceCut{Threshold: t}
+		for _, m := range entries {
+			if m.Confidence >= 0 && m.Confidence < t {
+				cut.Caught++
+			}
+		}
+		r.Cuts = append(r.Cuts, cut)
+	}
+	return r
+}
+
+// normalizeHeard folds away the differences that don't matter when
+// counting the same mix-up twice.
+func normalizeHeard(s string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(s), ".,!?\"' "))
+}
@@ -0,0 +1,78 @@
+package speech
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMisheardLogRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "misheard.jsonl")
+	log := NewMisheardLog(path)
+	at := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	want := []Misheard{
+		{At: at, Heard: "next", Meant: "pause", Confidence: 0.55},
+		{At: at.Add(time.Minute), Heard: "stop", Confidence: -1, FalseWake: true, Audio: "clip.wav"},
+	}
+	for _, m := range want {
+		if err := log.Record(m); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	if err := (*MisheardLog)(nil).Record(want[0]); err != nil {
+		t.Errorf("nil log: Record = %v", err)
+	}
+
+	got, err := ReadMisheard(path)
+	if err != nil {
+		t.Fatalf("ReadMisheard: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("read back %+v, want %+v", got, want)
+	}
+
+	// A broken line is reported, and the rest still read.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{not json\n\n")
+	f.Close()
+	log.Record(want[0])
+	got, err = ReadMisheard(path)
+	if err == nil {
+		t.Error("a broken line wasn't reported")
+	}
+	if len(got) != 3 {
+		t.Errorf("read %d entries around a broken line, want 3", len(got))
+	}
+}
+
+func TestSummarizeMisheard(t *testing.T) {
+	entries := []Misheard{
+		{Heard: "Next.", Meant: "pause", Confidence: 0.45},
+		{Heard: "next", Meant: "Pause!", Confidence: 0.65},
+		{Heard: "skip", Meant: "", Confidence: -1},
+		{Heard: "stop", Confidence: 0.85, FalseWake: true},
+		{Heard: "repeat", Meant: "pause", Confidence: 0.95},
+	}
+	r := SummarizeMisheard(entries)
+
+	if r.Total != 5 || r.FalseWakes != 1 || r.Measured != 4 {
+		t.Errorf("total %d, false wakes %d, measured %d; want 5, 1, 4", r.Total, r.FalseWakes, r.Measured)
+	}
+	wantPairs := []MisheardPair{
+		{Heard: "next", Meant: "pause", Count: 2},
+		{Heard: "repeat", Meant: "pause", Count: 1},
+		{Heard: "skip", Meant: "", Count: 1},
+	}
+	if !reflect.DeepEqual(r.Pairs, wantPairs) {
+		t.Errorf("pairs = %+v, want %+v", r.Pairs, wantPairs)
+	}
+	wantCuts := []ConfidenceCut{{0.5, 1}, {0.6, 1}, {0.7, 2}, {0.8, 2}, {0.9, 3}}
+	if !reflect.DeepEqual(r.Cuts, wantCuts) {
+		t.Errorf("cuts = %+v, want %+v", r.Cuts, wantCuts)
+	}
+}
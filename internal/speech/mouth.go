@@ -1,13 +1,19 @@
+// Package speech is Otto's voice: the Mouth queues, synthesizes, and
+// plays everything said aloud, and the Ear waits for the wake word and
+// transcribes what follows.
 package speech
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 	"unicode"
 
 	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/metrics"
 )
 
 // MouthOption configures the Mouth.
@@ -45,6 +51,74 @@ func WithDiskWrite(enabled bool) MouthOption {
 	}
 }
 
+// WithPrefetchWorkers caps how many prefetch syntheses run at once.
+// Extra prefetches wait their turn.
+func WithPrefetchWorkers(n int) MouthOption {
+	return func(m *Mouth) {
+		m.prefetchSlots = make(chan struct{}, max(n, 1))
+	}
+}
+
+// WithPriorityAging raises a queued item's effective priority by one
+// level for every d it has waited, so a stream of timer alerts can't
+// keep step narration waiting forever.  Aging stops at PriorityHigh;
+// PriorityCritical always goes first.  0 disables aging.
+func WithPriorityAging(d time.Duration) MouthOption {
+	return func(m *Mouth) {
+		m.agingStep = d
+	}
+}
+
+// WithSynthTimeout bounds each TTS request, so a hung backend skips a
+// line instead of holding up everything queued behind it.  0 leaves
+// only the HTTP client's own timeout.
+func WithSynthTimeout(d time.Duration) MouthOption {
+	return func(m *Mouth) {
+		m.synthTimeout = d
+	}
+}
+
+// WithMetrics records cache hit/miss counts and TTS synthesis latency
+// in the given registry. A nil registry disables instrumentation.
+func WithMetrics(reg *metrics.Registry) MouthOption {
+	return func(m *Mouth) {
+		m.metrics = reg
+	}
+}
+
+// Synthesizer turns text into audio.  *AzureClient is the real one.
+type Synthesizer interface {
+	Voice() string
+	SynthesizeAt(ctx context.Context, text string, priority Priority) ([]byte, error)
+}
+
+// AudioPlayer plays synthesized audio.  *Player is the real one.
+type AudioPlayer interface {
+	Play(audio []byte) error
+	Stop()
+}
+
+// Speaker is the speech output the rest of Otto depends on: the ear
+// keeps out of its way and cuts it off on the wake word, notifiers speak
+// through it, and the controller runs the conversation on it.  *Mouth is
+// the real one; tests and other dispatchers bring their own.
+type Speaker interface {
+	Say(text string, priority Priority)
+	SayOn(ch Channel, text string, priority Priority)
+	Interrupt()
+	IsSpeaking() bool
+	QueueLen() int
+	LastSpoken() string
+	OnSpeakingChange(fn func(speaking bool)) (unsubscribe func())
+}
+
+// Compile-time interface checks.
+var (
+	_ Synthesizer = (*AzureClient)(nil)
+	_ AudioPlayer = (*Player)(nil)
+	_ Speaker     = (*Mouth)(nil)
+)
+
 // Mouth is the central speech dispatcher. It serializes all speech output
 // through a single pipeline: queue -> chunk -> synthesize (parallel) -> play
 // (sequential). Only one thing speaks at a time. Higher priority items are
@@ -53,25 +127,47 @@ func WithDiskWrite(enabled bool) MouthOption {
 // An internal AudioCache transparently avoids re-synthesizing identical text.
 // Use Prefetch to pre-warm the cache for text that will be spoken soon.
 type Mouth struct {
-	tts    *AzureClient
-	player *Player
+	tts    Synthesizer
+	player AudioPlayer
 	log    *logger.Logger
 	cache  *AudioCache
 
+	metrics      *metrics.Registry
+	synthLatency *metrics.Histogram
+
 	mu               sync.Mutex
 	queue            []SpeechRequest
 	notify           chan struct{}
 	speaking         bool
-	interrupted      bool                // set by Interrupt(), checked between chunks
-	chunkSize        int                 // chars per TTS request, 0 = no chunking
-	cacheDir         string              // filesystem cache directory
-	diskWrite        bool                // persist new cache entries to disk
-	lastSpokenText   string              // most recent non-filler text spoken
-	onSpeakingChange func(speaking bool) // called when speaking state changes
+	interrupted      bool                 // set by Interrupt(), checked between chunks
+	chunkSize        int                  // chars per TTS request, 0 = no chunking
+	synthTimeout     time.Duration        // per TTS request, 0 = none
+	cancelCurrent    context.CancelFunc   // aborts the current item's synthesis
+	agingStep        time.Duration        // wait that earns one priority level, 0 = no aging
+	cacheDir         string               // filesystem cache directory
+	diskWrite        bool                 // persist new cache entries to disk
+	lastSpokenText   string               // most recent non-filler text spoken
+	current          *SpeechRequest       // item being spoken, nil when idle
+	remaining        []string             // chunks of current not yet finished
+	interruptedText  string               // what Interrupt cut off, until taken
+	onSpeakingChange []*speakingSub       // called when speaking state changes
+	synthWaiting     int                  // syntheses playback is waiting on
+	onSynthesizing   []func(waiting bool) // called as synthWaiting leaves and returns to 0
+	onJobsChange     func(MouthJobs)      // called when the work below or the queue changes
+	jobChunks        int                  // chunks in the line being spoken
+	jobReady         int                  // of those, with audio
+	jobHits          int                  // of those, from the cache
+	stop             context.CancelFunc   // ends the process loop; nil when it isn't running
+	stopped          chan struct{}        // closed once the process loop has ended
+	closed           bool                 // between Close and Start: nothing is queued
+
+	prefetchSlots chan struct{}        // one token per running prefetch synthesis
+	inflight      map[string]*synthJob // prefetches queued or running, by chunk text
+	groups        map[string][]string  // chunks last prefetched under each group
 }
 
 // NewMouth creates a speech dispatcher with the given TTS client and player.
-func NewMouth(tts *AzureClient, player *Player, log *logger.Logger, opts ...MouthOption) *Mouth {
+func NewMouth(tts Synthesizer, player AudioPlayer, log *logger.Logger, opts ...MouthOption) *Mouth {
 	m := &Mouth{
 		tts:       tts,
 		player:    player,
@@ -79,6 +175,13 @@ func NewMouth(tts *AzureClient, player *Player, log *logger.Logger, opts ...Mout
 		notify:    make(chan struct{}, 32),
 		chunkSize: 200,  // sensible default — roughly 2 sentences
 		diskWrite: true, // default: persist to disk
+		agingStep: 15 * time.Second,
+
+		synthTimeout: 20 * time.Second,
+
+		prefetchSlots: make(chan struct{}, 2),
+		inflight:      make(map[string]*synthJob),
+		groups:        make(map[string][]string),
 	}
 	for _, opt := range opts {
 		opt(m)
@@ -86,26 +189,42 @@ func NewMouth(tts *AzureClient, player *Player, log *logger.Logger, opts ...Mout
 	// Build the cache after options are applied so voice/cacheDir/diskWrite
 	// are all settled.
 	m.cache = NewAudioCache(tts.Voice(), m.cacheDir, m.diskWrite, log)
+	m.cache.instrument(m.metrics)
+	m.synthLatency = m.metrics.Histogram("ottocook_tts_synthesis_seconds",
+		"Latency of TTS synthesis requests (cache misses only).", metrics.LatencyBuckets)
 	return m
 }
 
-// Say queues text to be spoken at the given priority. Non-blocking.
-// When something at PriorityNormal or above is queued, any stale
-// PriorityLow items are flushed — they're no longer relevant.
+// Say queues text to be spoken at the given priority on ChannelGeneral.
+// Non-blocking.  When something at PriorityNormal or above is queued,
+// any stale PriorityLow items are flushed — they're no longer relevant.
 func (m *Mouth) Say(text string, priority Priority) {
+	m.SayOn(ChannelGeneral, text, priority)
+}
+
+// SayOn is Say for speech from a particular channel, which
+// InterruptChannel can later clear on its own.
+func (m *Mouth) SayOn(ch Channel, text string, priority Priority) {
 	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		m.log.Debug("mouth: closed, not saying: %s", truncate(text, 60))
+		return
+	}
 	if priority >= PriorityNormal {
 		m.flushLowLocked()
 	}
 	m.queue = append(m.queue, SpeechRequest{
 		Text:     text,
 		Priority: priority,
+		Channel:  ch,
 		QueuedAt: time.Now(),
 	})
 	qLen := len(m.queue)
 	m.mu.Unlock()
+	m.jobsChanged()
 
-	m.log.Debug("mouth: queued (priority=%d, queue_len=%d): %s", priority, qLen, truncate(text, 60))
+	m.log.Debug("mouth: queued (channel=%s, priority=%d, queue_len=%d): %s", ch, priority, qLen, truncate(text, 60))
 
 	// Signal the processing goroutine.
 	select {
@@ -114,6 +233,29 @@ func (m *Mouth) Say(text string, priority Priority) {
 	}
 }
 
+// Play queues a sound, such as an earcon, to be played in turn with
+// speech.  name is what the logs call it.
+func (m *Mouth) Play(name string, audio []byte, priority Priority) {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.queue = append(m.queue, SpeechRequest{
+		Text:     name,
+		Audio:    audio,
+		Priority: priority,
+		Channel:  ChannelGeneral,
+		QueuedAt: time.Now(),
+	})
+	m.mu.Unlock()
+	m.jobsChanged()
+	select {
+	case m.notify <- struct{}{}:
+	default:
+	}
+}
+
 // flushLowLocked removes all PriorityLow items from the queue.
 // Must be called with m.mu held.
 func (m *Mouth) flushLowLocked() {
@@ -138,11 +280,45 @@ func (m *Mouth) IsSpeaking() bool {
 	return m.speaking
 }
 
-// OnSpeakingChange registers a callback invoked when the mouth starts
-// or stops speaking.  Useful for muting/unmuting the mic.
-func (m *Mouth) OnSpeakingChange(fn func(speaking bool)) {
+// speakingSub is one OnSpeakingChange subscription; its address is
+// what unsubscribing finds it by.
+type speakingSub struct{ fn func(speaking bool) }
+
+// OnSpeakingChange subscribes fn to the mouth starting and stopping
+// speaking: the ear sleeps through it, the inspector shows it.  Every
+// subscriber is called, in order, from the mouth's goroutine, until it
+// calls the returned unsubscribe.
+func (m *Mouth) OnSpeakingChange(fn func(speaking bool)) (unsubscribe func()) {
+	sub := &speakingSub{fn}
+	m.mu.Lock()
+	m.onSpeakingChange = append(m.onSpeakingChange, sub)
+	m.mu.Unlock()
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		// A fresh slice: speakingChanged may be ranging over the old one.
+		m.onSpeakingChange = slices.DeleteFunc(slices.Clone(m.onSpeakingChange), func(s *speakingSub) bool { return s == sub })
+	}
+}
+
+// speakingChanged tells the OnSpeakingChange subscribers.  Must be
+// called without m.mu held.
+func (m *Mouth) speakingChanged(speaking bool) {
+	m.mu.Lock()
+	subs := m.onSpeakingChange
+	m.mu.Unlock()
+	for _, sub := range subs {
+		sub.fn(speaking)
+	}
+}
+
+// OnSynthesizing subscribes fn to playback starting and stopping
+// waiting on the TTS backend: a line that wasn't cached has to be
+// synthesized before it's heard.  Cache hits don't call it.  Useful for
+// a busy indicator.  Like OnSpeakingChange, every subscriber is called.
+func (m *Mouth) OnSynthesizing(fn func(waiting bool)) {
 	m.mu.Lock()
-	m.onSpeakingChange = fn
+	m.onSynthesizing = append(m.onSynthesizing, fn)
 	m.mu.Unlock()
 }
 
@@ -158,9 +334,16 @@ func (m *Mouth) QueueLen() int {
 // something more important needs to be spoken immediately.
 func (m *Mouth) Interrupt() {
 	m.mu.Lock()
+	if cut := m.cutOffLocked(); cut != "" {
+		m.interruptedText = cut
+	}
 	m.queue = m.queue[:0]
 	m.interrupted = true
+	if m.cancelCurrent != nil {
+		m.cancelCurrent()
+	}
 	m.mu.Unlock()
+	m.jobsChanged()
 
 	// Stop the audio player mid-playback.
 	m.player.Stop()
@@ -168,12 +351,103 @@ func (m *Mouth) Interrupt() {
 	m.log.Debug("mouth: interrupted — queue cleared, playback stopped")
 }
 
-// Start begins the speech processing goroutine. Non-blocking.
+// InterruptChannel drops queued speech from the given channels and
+// stops the current item if it came from one of them.  Everything else
+// keeps its place.  Unlike Interrupt, nothing is kept for "what were
+// you saying?".
+func (m *Mouth) InterruptChannel(chs ...Channel) {
+	m.mu.Lock()
+	n := 0
+	for _, item := range m.queue {
+		if !slices.Contains(chs, item.Channel) {
+			m.queue[n] = item
+			n++
+		}
+	}
+	dropped := len(m.queue) - n
+	m.queue = m.queue[:n]
+	stop := m.current != nil && slices.Contains(chs, m.current.Channel)
+	if stop {
+		m.interrupted = true
+		if m.cancelCurrent != nil {
+			m.cancelCurrent()
+		}
+	}
+	m.mu.Unlock()
+	m.jobsChanged()
+
+	if stop {
+		m.player.Stop()
+	}
+	if dropped > 0 || stop {
+		m.log.Debug("mouth: interrupted %v — %d queued dropped, current stopped=%v", chs, dropped, stop)
+	}
+}
+
+// cutOffLocked returns the text Interrupt is about to drop: the unplayed
+// rest of the current item plus anything queued at PriorityNormal or
+// above.  Fillers and short acks aren't worth resuming and are skipped.
+// Must be called with m.mu held.
+func (m *Mouth) cutOffLocked() string {
+	var parts []string
+	if m.current != nil && len(m.current.Text) > 20 {
+		parts = append(parts, m.remaining...)
+	}
+	for _, item := range m.queue {
+		if item.Priority >= PriorityNormal && len(item.Text) > 20 {
+			parts = append(parts, item.Text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// TakeInterrupted returns the text the last Interrupt cut off and
+// forgets it, or "" if nothing was cut off since the last call.
+func (m *Mouth) TakeInterrupted() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	text := m.interruptedText
+	m.interruptedText = ""
+	return text
+}
+
+// Start begins the speech processing goroutine. Non-blocking.  It
+// runs until ctx is done or Close; starting a running mouth does
+// nothing.
 func (m *Mouth) Start(ctx context.Context) {
-	go m.processLoop(ctx)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stop != nil {
+		return
+	}
+	ctx, m.stop = context.WithCancel(ctx)
+	m.stopped = make(chan struct{})
+	m.closed = false
+	go func(stopped chan struct{}) {
+		defer close(stopped)
+		m.processLoop(ctx)
+	}(m.stopped)
 	m.log.Info("mouth started")
 }
 
+// Close stops what's being said, drops the queue, and ends the
+// processing goroutine, waiting for it.  Until Start is called again,
+// speech and sounds are dropped rather than queued.  The player is left
+// open; close it too to free the sound card.
+func (m *Mouth) Close() {
+	m.mu.Lock()
+	stop, stopped := m.stop, m.stopped
+	m.stop, m.stopped = nil, nil
+	m.closed = true
+	m.mu.Unlock()
+
+	m.Interrupt()
+	if stop != nil {
+		stop()
+		<-stopped
+	}
+}
+
 // processLoop waits for queued items and processes them one at a time.
 func (m *Mouth) processLoop(ctx context.Context) {
 	for {
@@ -207,15 +481,27 @@ func (m *Mouth) drain(ctx context.Context) {
 			return
 		}
 
+		// An interrupt cancels the item's context, so synthesis still in
+		// flight is abandoned rather than waited out.
+		itemCtx, cancel := context.WithCancel(ctx)
 		m.mu.Lock()
 		m.speaking = true
-		cb := m.onSpeakingChange
+		m.current = &item
+		m.cancelCurrent = cancel
 		m.mu.Unlock()
-		if cb != nil {
-			cb(true)
-		}
+		m.speakingChanged(true)
+		m.jobsChanged()
+
+		m.process(itemCtx, item)
+		cancel()
 
-		m.process(ctx, item)
+		// A non-filler line heard in full supersedes whatever an earlier
+		// interrupt cut off — "what were you saying?" would be stale.
+		m.mu.Lock()
+		if !m.interrupted && len(item.Text) > 20 {
+			m.interruptedText = ""
+		}
+		m.mu.Unlock()
 
 		// Track the last spoken text (skip fillers / very short acks).
 		if len(item.Text) > 20 {
@@ -226,15 +512,18 @@ func (m *Mouth) drain(ctx context.Context) {
 
 		m.mu.Lock()
 		m.speaking = false
-		cb = m.onSpeakingChange
+		m.current = nil
+		m.cancelCurrent = nil
+		m.remaining = nil
+		m.jobChunks, m.jobReady, m.jobHits = 0, 0, 0
 		m.mu.Unlock()
-		if cb != nil {
-			cb(false)
-		}
+		m.jobsChanged()
+		m.speakingChanged(false)
 	}
 }
 
-// dequeue removes and returns the highest priority item from the queue.
+// dequeue removes and returns the item with the highest effective
+// priority (see effectivePriority).  Ties go to whichever was queued first.
 func (m *Mouth) dequeue() (SpeechRequest, bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -243,28 +532,53 @@ func (m *Mouth) dequeue() (SpeechRequest, bool) {
 		return SpeechRequest{}, false
 	}
 
+	now := time.Now()
 	bestIdx := 0
+	best := m.effectivePriority(m.queue[0], now)
 	for i, item := range m.queue {
-		if item.Priority > m.queue[bestIdx].Priority {
-			bestIdx = i
+		if p := m.effectivePriority(item, now); p > best {
+			bestIdx, best = i, p
 		}
 	}
+	if item := m.queue[bestIdx]; best > item.Priority {
+		m.log.Debug("mouth: aged priority %d -> %d after %s", item.Priority, best, now.Sub(item.QueuedAt).Round(time.Second))
+	}
 
 	item := m.queue[bestIdx]
 	m.queue = append(m.queue[:bestIdx], m.queue[bestIdx+1:]...)
 	return item, true
 }
 
+// effectivePriority is the item's priority raised one level per
+// agingStep waited, up to PriorityHigh.  Items that start above that
+// keep their own priority.
+func (m *Mouth) effectivePriority(item SpeechRequest, now time.Time) Priority {
+	if m.agingStep <= 0 || item.Priority >= PriorityHigh {
+		return item.Priority
+	}
+	aged := item.Priority + Priority(now.Sub(item.QueuedAt)/m.agingStep)
+	return min(aged, PriorityHigh)
+}
+
 // process synthesizes and plays a single speech request, using chunked
 // parallel synthesis for long text.
 func (m *Mouth) process(ctx context.Context, req SpeechRequest) {
 	waitTime := time.Since(req.QueuedAt).Round(time.Millisecond)
+	if req.Audio != nil {
+		m.log.Debug("mouth: playing %s (waited=%s)", req.Text, waitTime)
+		if err := m.player.Play(req.Audio); err != nil {
+			m.log.Error("mouth: playing %s: %v", req.Text, err)
+		}
+		return
+	}
 	m.log.Debug("mouth: speaking (priority=%d, waited=%s): %s", req.Priority, waitTime, truncate(req.Text, 60))
 
 	chunks := m.splitChunks(req.Text)
+	m.startJob(len(chunks))
 	if len(chunks) <= 1 {
 		// Short text — single request, no concurrency overhead.
-		m.synthAndPlay(ctx, req.Text)
+		m.setRemaining([]string{req.Text})
+		m.synthAndPlay(ctx, req.Text, req.Priority)
 		return
 	}
 
@@ -280,7 +594,7 @@ func (m *Mouth) process(ctx context.Context, req SpeechRequest) {
 
 	for i, chunk := range chunks {
 		go func(idx int, text string) {
-			audio, err := m.synthesizeWithCache(ctx, text)
+			audio, err := m.synthesizeWithCache(ctx, text, req.Priority)
 			results <- result{idx: idx, audio: audio, err: err}
 		}(i, chunk)
 	}
@@ -289,7 +603,11 @@ func (m *Mouth) process(ctx context.Context, req SpeechRequest) {
 	audioSlots := make([][]byte, len(chunks))
 	for range chunks {
 		r := <-results
-		if r.err != nil {
+		if errors.Is(r.err, ErrQuota) {
+			m.log.Info("mouth: TTS quota low, skipping chunk %d", r.idx)
+		} else if errors.Is(r.err, context.Canceled) {
+			m.log.Debug("mouth: chunk %d synthesis cancelled", r.idx)
+		} else if r.err != nil {
 			m.log.Error("mouth: chunk %d synthesis failed: %v", r.idx, r.err)
 			// Continue — we'll skip the failed chunk during playback.
 		} else {
@@ -316,16 +634,33 @@ func (m *Mouth) process(ctx context.Context, req SpeechRequest) {
 			m.log.Debug("mouth: aborting chunk playback (interrupted)")
 			return
 		}
+		m.setRemaining(chunks[i:])
 		if err := m.player.Play(audio); err != nil {
 			m.log.Error("mouth: chunk %d playback failed: %v", i, err)
 		}
 	}
 }
 
+// setRemaining records which chunks of the current item haven't
+// finished playing, so an interrupt knows where to resume from.
+func (m *Mouth) setRemaining(chunks []string) {
+	m.mu.Lock()
+	m.remaining = chunks
+	m.mu.Unlock()
+}
+
 // synthAndPlay does a single synthesize-then-play for short text.
 // Uses the cache to avoid redundant Azure calls.
-func (m *Mouth) synthAndPlay(ctx context.Context, text string) {
-	audioData, err := m.synthesizeWithCache(ctx, text)
+func (m *Mouth) synthAndPlay(ctx context.Context, text string, priority Priority) {
+	audioData, err := m.synthesizeWithCache(ctx, text, priority)
+	if errors.Is(err, ErrQuota) {
+		m.log.Info("mouth: TTS quota low, not speaking: %s", truncate(text, 60))
+		return
+	}
+	if errors.Is(err, context.Canceled) {
+		m.log.Debug("mouth: synthesis cancelled: %s", truncate(text, 60))
+		return
+	}
 	if err != nil {
 		m.log.Error("mouth: synthesis failed: %v", err)
 		return
@@ -337,18 +672,60 @@ func (m *Mouth) synthAndPlay(ctx context.Context, text string) {
 
 // synthesizeWithCache checks the cache first, otherwise calls Azure and
 // stores the result. Thread-safe.
-func (m *Mouth) synthesizeWithCache(ctx context.Context, text string) ([]byte, error) {
+func (m *Mouth) synthesizeWithCache(ctx context.Context, text string, priority Priority) ([]byte, error) {
 	if audio, ok := m.cache.Get(text); ok {
+		m.chunkReady(true)
+		return audio, nil
+	}
+	m.synthWait(1)
+	defer m.synthWait(-1)
+	// A prefetch already working on it will finish sooner than a new
+	// request would.
+	if audio, ok := m.awaitPrefetch(ctx, text); ok {
+		m.chunkReady(false)
 		return audio, nil
 	}
-	audio, err := m.tts.Synthesize(ctx, text)
+	audio, err := m.synthesize(ctx, text, priority)
 	if err != nil {
 		return nil, err
 	}
 	m.cache.Put(text, audio)
+	m.chunkReady(false)
 	return audio, nil
 }
 
+// synthWait counts the syntheses playback is waiting on, telling the
+// OnSynthesizing subscribers when the first starts and the last is done.
+func (m *Mouth) synthWait(delta int) {
+	m.mu.Lock()
+	m.synthWaiting += delta
+	edge := m.synthWaiting == 0 || m.synthWaiting == delta
+	waiting := m.synthWaiting > 0
+	subs := m.onSynthesizing
+	m.mu.Unlock()
+	if !edge {
+		return
+	}
+	for _, fn := range subs {
+		fn(waiting)
+	}
+}
+
+// synthesize calls the TTS backend and records its latency.
+func (m *Mouth) synthesize(ctx context.Context, text string, priority Priority) ([]byte, error) {
+	if m.synthTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.synthTimeout)
+		defer cancel()
+	}
+	start := time.Now()
+	audio, err := m.tts.SynthesizeAt(ctx, text, priority)
+	if err == nil {
+		m.synthLatency.ObserveSince(start)
+	}
+	return audio, err
+}
+
 // splitChunks breaks text into sentence-boundary chunks of approximately
 // m.chunkSize characters. If chunkSize is 0 or the text is short, it
 // returns the text as-is in a single slice.
@@ -422,40 +799,7 @@ func truncate(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
-// ── Prefetching / Cache ──────────────────────────────────────────
-
-// Prefetch pre-synthesizes the given texts in background goroutines and
-// stores the results in the audio cache. It skips texts that are already
-// cached. Non-blocking — launches goroutines and returns immediately.
-//
-// Call it any time you know what text will be spoken next (e.g. the next
-// cooking step) so playback starts instantly when Say is called.
-func (m *Mouth) Prefetch(ctx context.Context, texts ...string) {
-	for _, text := range texts {
-		if text == "" {
-			continue
-		}
-
-		// For long text, split into the same chunks Say would use.
-		chunks := m.splitChunks(text)
-		for _, chunk := range chunks {
-			if m.cache.Has(chunk) {
-				m.log.Debug("prefetch: already cached: %s", truncate(chunk, 50))
-				continue
-			}
-			go func(t string) {
-				m.log.Debug("prefetch: synthesizing: %s", truncate(t, 50))
-				audio, err := m.tts.Synthesize(ctx, t)
-				if err != nil {
-					m.log.Error("prefetch: synthesis failed: %v", err)
-					return
-				}
-				m.cache.Put(t, audio)
-				m.log.Debug("prefetch: cached %d bytes for: %s", len(audio), truncate(t, 50))
-			}(chunk)
-		}
-	}
-}
+// ── Cache ────────────────────────────────────────────────────────
 
 // LastSpoken returns the most recently spoken non-filler text.
 func (m *Mouth) LastSpoken() string {
@@ -466,3 +810,20 @@ func (m *Mouth) LastSpoken() string {
 
 // Cache returns the audio cache used by this Mouth. Useful for stats/logging.
 func (m *Mouth) Cache() *AudioCache { return m.cache }
+
+// SetVoice switches to another voice, if the synthesizer can, from the
+// next line synthesized.  Cached lines in the old voice stay on disk
+// for when it's switched back.
+func (m *Mouth) SetVoice(voice string) bool {
+	tts, ok := m.tts.(interface{ SetVoice(string) })
+	if !ok {
+		return false
+	}
+	if m.tts.Voice() == voice {
+		return true
+	}
+	tts.SetVoice(voice)
+	m.cache.SetVoice(voice)
+	m.log.Info("mouth: voice is now %s", voice)
+	return true
+}
@@ -0,0 +1,195 @@
+package speech
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+func TestMouthSetVoice(t *testing.T) {
+	tts, player := newFakeSynth("Jenny"), &fakePlayer{}
+	m := NewMouth(tts, player, logger.New(logger.LevelOff, nil), WithChunkSize(0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Start(ctx)
+	defer m.Close()
+
+	say := func(n int) string {
+		t.Helper()
+		m.Say("Stir the sauce.", PriorityNormal)
+		return player.wait(t, n)[n-1]
+	}
+	say(1)
+	say(2) // from the cache
+	if !m.SetVoice("Ryan") {
+		t.Fatal("SetVoice = false for a synthesizer that can change voice")
+	}
+	if got := say(3); got != "Ryan:Stir the sauce." {
+		t.Errorf("after SetVoice played %q, want it in the new voice", got)
+	}
+	m.SetVoice("Jenny")
+	if got := say(4); got != "Jenny:Stir the sauce." {
+		t.Errorf("switched back, played %q", got)
+	}
+	if got := tts.synthesized(); !slices.Equal(got, []string{"Stir the sauce.", "Stir the sauce."}) {
+		t.Errorf("synthesized %q; want each voice once, the rest cached", got)
+	}
+
+	fixed := NewMouth(struct{ Synthesizer }{newFakeSynth("Jenny")}, player, logger.New(logger.LevelOff, nil))
+	if fixed.SetVoice("Ryan") {
+		t.Error("SetVoice = true for a synthesizer that can't change voice")
+	}
+}
+
+func TestAudioCacheSetVoice(t *testing.T) {
+	c := NewAudioCache("Jenny", t.TempDir(), true, logger.New(logger.LevelOff, nil))
+	c.Put("Hello.", []byte("jenny"))
+	c.SetVoice("Ryan")
+	if _, ok := c.Get("Hello."); ok {
+		t.Error("a line cached in the old voice was found for the new one")
+	}
+	c.Put("Hello.", []byte("ryan"))
+	c.SetVoice("Jenny")
+	if got, ok := c.Get("Hello."); !ok || string(got) != "jenny" {
+		t.Errorf("Get after switching back = %q, %v; want the old voice's line", got, ok)
+	}
+}
+
+func TestMouthInterruptCancelsSynthesis(t *testing.T) {
+	tts, player := newFakeSynth("Jenny"), &fakePlayer{}
+	tts.gate = make(chan struct{}) // never opened: synthesis hangs until cancelled
+	m := NewMouth(tts, player, logger.New(logger.LevelOff, nil), WithChunkSize(0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Start(ctx)
+	defer m.Close()
+
+	m.Say("The sauce is thickening nicely.", PriorityNormal)
+	deadline := time.Now().Add(2 * time.Second)
+	for len(tts.synthesized()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("synthesis never started")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	m.Interrupt()
+
+	tts.mu.Lock()
+	tts.gate = nil
+	tts.mu.Unlock()
+	m.Say("Stir it.", PriorityNormal)
+	if got := player.wait(t, 1); got[0] != "Jenny:Stir it." {
+		t.Errorf("played %q first; the interrupted line should never play", got)
+	}
+}
+
+func TestMouthRestart(t *testing.T) {
+	tts, player := newFakeSynth("Jenny"), &fakePlayer{}
+	m := NewMouth(tts, player, logger.New(logger.LevelOff, nil), WithChunkSize(0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m.Start(ctx)
+	m.Say("One.", PriorityNormal)
+	player.wait(t, 1)
+
+	m.mu.Lock()
+	stopped := m.stopped
+	m.mu.Unlock()
+	m.Close()
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("Close returned with the processing goroutine still running")
+	}
+
+	m.Say("Two.", PriorityNormal)
+	if n := m.QueueLen(); n != 0 {
+		t.Errorf("%d lines queued while closed, want them dropped", n)
+	}
+
+	m.Start(ctx)
+	defer m.Close()
+	m.Say("Three.", PriorityNormal)
+	if got := player.wait(t, 2); !slices.Equal(got, []string{"Jenny:One.", "Jenny:Three."}) {
+		t.Errorf("played %q across a restart", got)
+	}
+}
+
+func TestMouthOnSynthesizing(t *testing.T) {
+	tts, player := newFakeSynth("Jenny"), &fakePlayer{}
+	tts.gate = make(chan struct{})
+	m := NewMouth(tts, player, logger.New(logger.LevelOff, nil), WithChunkSize(0))
+	var mu sync.Mutex
+	var first, second []bool
+	m.OnSynthesizing(func(waiting bool) { mu.Lock(); first = append(first, waiting); mu.Unlock() })
+	m.OnSynthesizing(func(waiting bool) { mu.Lock(); second = append(second, waiting); mu.Unlock() })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Start(ctx)
+	defer m.Close()
+
+	m.Say("Stir the sauce.", PriorityNormal)
+	tts.gate <- struct{}{}
+	player.wait(t, 1)
+	m.Say("Stir the sauce.", PriorityNormal) // cached: no wait to report
+	player.wait(t, 2)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, got := range [][]bool{first, second} {
+		if !slices.Equal(got, []bool{true, false}) {
+			t.Errorf("subscriber %d saw %v, want one wait starting and ending", i+1, got)
+		}
+	}
+}
+
+func TestMouthOnSpeakingChangeUnsubscribe(t *testing.T) {
+	tts, player := newFakeSynth("Jenny"), &fakePlayer{}
+	m := NewMouth(tts, player, logger.New(logger.LevelOff, nil), WithChunkSize(0))
+	var mu sync.Mutex
+	var kept, dropped []bool
+	m.OnSpeakingChange(func(speaking bool) { mu.Lock(); kept = append(kept, speaking); mu.Unlock() })
+	unsubscribe := m.OnSpeakingChange(func(speaking bool) { mu.Lock(); dropped = append(dropped, speaking); mu.Unlock() })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Start(ctx)
+	defer m.Close()
+
+	// heard waits for the first subscriber to have seen n changes.
+	heard := func(n int) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			mu.Lock()
+			got := len(kept)
+			mu.Unlock()
+			if got >= n {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("subscriber saw %d changes, want %d", got, n)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	m.Say("One.", PriorityNormal)
+	heard(2)
+	unsubscribe()
+	m.Say("Two.", PriorityNormal)
+	heard(4)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !slices.Equal(kept, []bool{true, false, true, false}) {
+		t.Errorf("subscriber saw %v, want both lines", kept)
+	}
+	if !slices.Equal(dropped, []bool{true, false}) {
+		t.Errorf("unsubscribed subscriber saw %v, want only the first line", dropped)
+	}
+}
@@ -0,0 +1,220 @@
+package speech
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ── Spoken normalization ─────────────────────────────────────────
+//
+// Recipe text is written to be read, not heard: "0.5 cups", "3-4 min",
+// "165°F / 74°C".  NormalizeSpeech rewrites those into what a person
+// would say ("half a cup", "3 to 4 minutes", "165 degrees Fahrenheit,
+// or 74 degrees Celsius") before the text goes to the TTS.  Anything it
+// doesn't recognise is left alone.
+
+// unitName is a unit's spoken singular and plural.
+type unitName struct{ one, many string }
+
+// spokenUnits maps every spelling of a unit (lower case) to how it's said.
+var spokenUnits = map[string]unitName{}
+
+func init() {
+	for _, u := range []struct {
+		one, many string
+		abbrevs   []string
+	}{
+		{"cup", "cups", nil},
+		{"tablespoon", "tablespoons", []string{"tbsp", "tbsps", "tbs", "tbl"}},
+		{"teaspoon", "teaspoons", []string{"tsp", "tsps"}},
+		{"gram", "grams", []string{"g", "gr"}},
+		{"kilogram", "kilograms", []string{"kg", "kgs"}},
+		{"milliliter", "milliliters", []string{"ml", "millilitre", "millilitres"}},
+		{"liter", "liters", []string{"litre", "litres"}},
+		{"ounce", "ounces", []string{"oz"}},
+		{"pound", "pounds", []string{"lb", "lbs"}},
+		{"second", "seconds", []string{"sec", "secs"}},
+		{"minute", "minutes", []string{"min", "mins"}},
+		{"hour", "hours", []string{"hr", "hrs"}},
+		{"centimeter", "centimeters", []string{"cm", "centimetre", "centimetres"}},
+		{"inch", "inches", nil},
+		{"clove", "cloves", nil},
+		{"piece", "pieces", nil},
+		{"slice", "slices", nil},
+		{"pinch", "pinches", nil},
+		{"dash", "dashes", nil},
+		{"can", "cans", nil},
+		{"stick", "sticks", nil},
+		{"sprig", "sprigs", nil},
+		{"bunch", "bunches", nil},
+		{"handful", "handfuls", nil},
+	} {
+		n := unitName{u.one, u.many}
+		spokenUnits[u.one] = n
+		spokenUnits[u.many] = n
+		for _, a := range u.abbrevs {
+			spokenUnits[a] = n
+		}
+	}
+
+	names := make([]string, 0, len(spokenUnits))
+	for k := range spokenUnits {
+		names = append(names, regexp.QuoteMeta(k))
+	}
+	// Longest first so "tbsp" wins over "tbs" and "mins" over "min".
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	units := strings.Join(names, "|")
+	amountRe = regexp.MustCompile(`(?i)(^|[^\w.,/\-–—])` + amountPattern + `(?:\s*(` + units + `)(?:\b|$))?`)
+	rangeRe = regexp.MustCompile(`(?i)(^|[^\w.,/\-–—])(\d+(?:\.\d+)?)\s*[-–—]\s*(\d+(?:\.\d+)?)(?:(\s*(?:°|(?:degrees|` + units + `)\b))|($|[^\w/\-–—]))`)
+}
+
+// vulgarFractions are the fraction glyphs recipes use.
+var vulgarFractions = map[string]float64{
+	"½": 1.0 / 2, "⅓": 1.0 / 3, "⅔": 2.0 / 3, "¼": 1.0 / 4, "¾": 3.0 / 4,
+	"⅕": 1.0 / 5, "⅛": 1.0 / 8, "⅜": 3.0 / 8, "⅝": 5.0 / 8, "⅞": 7.0 / 8,
+}
+
+// spokenFractions says the fractional part of an amount, nearest first.
+// A quantity whose fraction isn't one of these is read as a decimal.
+var spokenFractions = []struct {
+	v           float64
+	alone, with string // "three quarters (of a cup)", "(two) and three quarters"
+}{
+	{1.0 / 2, "half", "a half"},
+	{1.0 / 4, "a quarter", "a quarter"},
+	{3.0 / 4, "three quarters", "three quarters"},
+	{1.0 / 3, "a third", "a third"},
+	{2.0 / 3, "two thirds", "two thirds"},
+	{1.0 / 8, "an eighth", "an eighth"},
+}
+
+const amountPattern = `(?:(\d+)\s+(\d+)/(\d+)|(?:(\d+)\s*)?([½⅓⅔¼¾⅕⅛⅜⅝⅞])|(\d+)/(\d+)|(\d{1,3}(?:,\d{3})+(?:\.\d+)?|\d+(?:\.\d+)?))`
+
+var (
+	amountRe *regexp.Regexp // built in init from spokenUnits
+
+	tempPairRe = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*°\s*([FC])\s*/\s*(\d+(?:\.\d+)?)\s*°\s*([FC])\b`)
+	tempRe     = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(?:°\s*|degrees\s+)([FC])\b`)
+
+	// rangeRe is a range of amounts, "3-4 min" or "2-3": one with a unit
+	// after it, or of small numbers, is read "3 to 4".  Longer ones, like
+	// the "555-1234" of a phone number, are left as written.
+	rangeRe *regexp.Regexp // built in init from spokenUnits
+)
+
+// maxBareRange is the most digits a range without a unit may have.
+const maxBareRange = 2
+
+// NormalizeSpeech rewrites quantities, fractions, ranges, and
+// temperatures in text the way they're spoken.
+func NormalizeSpeech(text string) string {
+	text = tempPairRe.ReplaceAllString(text, "$1°$2, or $3°$4")
+	text = tempRe.ReplaceAllStringFunc(text, func(m string) string {
+		sm := tempRe.FindStringSubmatch(m)
+		scale := "Fahrenheit"
+		if sm[2] == "C" {
+			scale = "Celsius"
+		}
+		return sm[1] + " degrees " + scale
+	})
+	text = rangeRe.ReplaceAllStringFunc(text, func(m string) string {
+		sm := rangeRe.FindStringSubmatch(m)
+		if sm[4] == "" && (len(sm[2]) > maxBareRange || len(sm[3]) > maxBareRange) {
+			return m
+		}
+		return sm[1] + sm[2] + " to " + sm[3] + sm[4] + sm[5]
+	})
+	return amountRe.ReplaceAllStringFunc(text, func(m string) string {
+		sm := amountRe.FindStringSubmatch(m)
+		return sm[1] + spokenAmount(sm[2:10], sm[10])
+	})
+}
+
+// spokenAmount says one matched quantity (groups from amountPattern)
+// and its unit, if it had one.
+func spokenAmount(g []string, unit string) string {
+	var v float64
+	written := ""
+	switch {
+	case g[0] != "": // "1 1/2"
+		v = atof(g[0]) + ratio(g[1], g[2])
+		written = g[0] + " " + g[1] + "/" + g[2]
+	case g[4] != "": // "1½", "½"
+		v = atof(g[3]) + vulgarFractions[g[4]]
+		written = strings.TrimSpace(g[3] + " " + g[4])
+	case g[5] != "": // "1/2"
+		v = ratio(g[5], g[6])
+		written = g[5] + "/" + g[6]
+	default: // "2", "0.5"
+		v = atof(g[7])
+		written = g[7]
+	}
+
+	n, hasUnit := spokenUnits[strings.ToLower(unit)]
+	whole := math.Floor(v)
+	frac := v - whole
+	fracWords, fracWith := "", ""
+	if frac > 0.005 {
+		for _, f := range spokenFractions {
+			if math.Abs(frac-f.v) < 0.01 {
+				fracWords, fracWith = f.alone, f.with
+				break
+			}
+		}
+	}
+
+	switch {
+	case frac > 0.005 && fracWords == "":
+		// Not a fraction anyone says; read the number as written.
+		if hasUnit {
+			return written + " " + n.many
+		}
+		return written
+	case whole == 0 && fracWords != "":
+		if !hasUnit {
+			return fracWords
+		}
+		if fracWords == "half" {
+			return "half " + article(n.one) + " " + n.one
+		}
+		return fracWords + " of " + article(n.one) + " " + n.one
+	case fracWords != "":
+		s := strconv.Itoa(int(whole)) + " and " + fracWith
+		if hasUnit {
+			s += " " + n.many
+		}
+		return s
+	}
+
+	s := strconv.FormatFloat(whole, 'f', -1, 64)
+	if !hasUnit {
+		return s
+	}
+	if whole == 1 {
+		return s + " " + n.one
+	}
+	return s + " " + n.many
+}
+
+func atof(s string) float64 {
+	v, _ := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+	return v
+}
+
+func ratio(num, den string) float64 {
+	d := atof(den)
+	if d == 0 {
+		return 0
+	}
+	return atof(num) / d
+}
+
+func article(word string) string {
+	if word != "" && strings.ContainsRune("aeiou", rune(word[0])) {
+		return "an"
+	}
+	return "a"
+}
@@ -0,0 +1,34 @@
+package speech
+
+import "testing"
+
+func TestNormalizeSpeech(t *testing.T) {
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"Add 0.5 cups of stock", "Add half a cup of stock"},
+		{"Cook to 165°F / 74°C", "Cook to 165 degrees Fahrenheit, or 74 degrees Celsius"},
+		{"Bake at 200°C", "Bake at 200 degrees Celsius"},
+		{"Simmer 3-4 min", "Simmer 3 to 4 minutes"},
+		{"Simmer 3–4 minutes", "Simmer 3 to 4 minutes"},
+		{"Roast at 350-375°F", "Roast at 350 to 375 degrees Fahrenheit"},
+		{"Serves 3-4.", "Serves 3 to 4."},
+		{"Add ½ tsp salt", "Add half a teaspoon salt"},
+		{"Add 1½ tsp salt", "Add 1 and a half teaspoons salt"},
+		{"Stir in 1 1/2 cups milk", "Stir in 1 and a half cups milk"},
+		{"Add 3/4 cup sugar", "Add three quarters of a cup sugar"},
+		{"Weigh out 1,000 g flour", "Weigh out 1000 grams flour"},
+		{"Add 2 cloves garlic", "Add 2 cloves garlic"},
+		{"Add 1 clove garlic", "Add 1 clove garlic"},
+		{"Add 0.3 cups water", "Add 0.3 cups water"},
+		// Not amounts.
+		{"Call 555-1234 for help", "Call 555-1234 for help"},
+		{"Call 1-800-555-0199", "Call 1-800-555-0199"},
+		{"Or call 555-1234.", "Or call 555-1234."},
+		{"Chop the onion", "Chop the onion"},
+	} {
+		if got := NormalizeSpeech(tt.in); got != tt.want {
+			t.Errorf("NormalizeSpeech(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -12,21 +12,37 @@ import (
 // Compile-time interface check.
 var _ domain.Notifier = (*SpeakingNotifier)(nil)
 
-// SpeakingNotifier wraps a text notifier and also speaks messages through the Mouth.
+// SpeakingNotifier wraps a text notifier and also speaks messages through a Speaker.
 // Messages are printed immediately (via the inner notifier) and queued for speech.
 type SpeakingNotifier struct {
-	text  domain.Notifier
-	mouth *Mouth
-	log   *logger.Logger
+	text    domain.Notifier
+	mouth   Speaker
+	log     *logger.Logger
+	channel Channel
+}
+
+// NotifierOption configures a SpeakingNotifier.
+type NotifierOption func(*SpeakingNotifier)
+
+// OnChannel sets the channel the notifier speaks on (default ChannelGeneral).
+func OnChannel(ch Channel) NotifierOption {
+	return func(n *SpeakingNotifier) {
+		n.channel = ch
+	}
 }
 
 // NewSpeakingNotifier creates a notifier that both prints and speaks.
-func NewSpeakingNotifier(text domain.Notifier, mouth *Mouth, log *logger.Logger) *SpeakingNotifier {
-	return &SpeakingNotifier{
-		text:  text,
-		mouth: mouth,
-		log:   log,
+func NewSpeakingNotifier(text domain.Notifier, mouth Speaker, log *logger.Logger, opts ...NotifierOption) *SpeakingNotifier {
+	n := &SpeakingNotifier{
+		text:    text,
+		mouth:   mouth,
+		log:     log,
+		channel: ChannelGeneral,
+	}
+	for _, opt := range opts {
+		opt(n)
 	}
+	return n
 }
 
 // Notify prints the message and queues it for speech at normal priority.
@@ -34,7 +50,7 @@ func (n *SpeakingNotifier) Notify(ctx context.Context, message string) error {
 	if err := n.text.Notify(ctx, message); err != nil {
 		return err
 	}
-	n.mouth.Say(cleanForSpeech(message), PriorityNormal)
+	n.mouth.SayOn(n.channel, cleanForSpeech(message), PriorityNormal)
 	return nil
 }
 
@@ -43,7 +59,7 @@ func (n *SpeakingNotifier) NotifyUrgent(ctx context.Context, message string) err
 	if err := n.text.NotifyUrgent(ctx, message); err != nil {
 		return err
 	}
-	n.mouth.Say(cleanForSpeech(message), PriorityHigh)
+	n.mouth.SayOn(n.channel, cleanForSpeech(message), PriorityHigh)
 	return nil
 }
 
@@ -2,53 +2,56 @@ package speech
 
 import (
 	"bytes"
-	"encoding/binary"
 	"errors"
 	"sync"
 	"time"
 
-	"github.com/ebitengine/oto/v3"
 	"github.com/hammamikhairi/ottocook/internal/logger"
 )
 
+// ErrPlayerClosed is returned by Play between Close and Reopen.
+var ErrPlayerClosed = errors.New("audio player closed")
+
 // Player handles audio playback of WAV/PCM data via oto.
 type Player struct {
-	ctx    *oto.Context
+	ctx    speakers
 	log    *logger.Logger
 	mu     sync.Mutex
-	active *oto.Player // currently playing, nil when idle
+	active sound // currently playing, nil when idle
+	closed bool  // between Close and Reopen
 }
 
 // NewPlayer creates an audio player. Initializes the system audio context.
 // Returns an error if the audio device is unavailable.
 func NewPlayer(log *logger.Logger) (*Player, error) {
-	op := &oto.NewContextOptions{
-		SampleRate:   SampleRate,
-		ChannelCount: ChannelCount,
-		Format:       oto.FormatSignedInt16LE,
-	}
-
-	ctx, readyChan, err := oto.NewContext(op)
+	ctx, err := openSpeakers()
 	if err != nil {
 		return nil, err
 	}
-	<-readyChan
 
 	log.Debug("audio player initialized (rate=%d, channels=%d)", SampleRate, ChannelCount)
 	return &Player{ctx: ctx, log: log}, nil
 }
 
-// Play plays WAV audio data synchronously. Blocks until playback finishes
-// or Stop is called.
-func (p *Player) Play(wavData []byte) error {
-	pcm, err := extractPCM(wavData)
+// Play plays audio synchronously. Blocks until playback finishes or
+// Stop is called.  WAV in another PCM layout (rate, channels, bit depth)
+// is converted first, and MP3 or Ogg is decoded (see decodeToWAV).
+func (p *Player) Play(audio []byte) error {
+	wavData, err := decodeToWAV(audio)
+	if err != nil {
+		return err
+	}
+	pcm, err := playerPCM(wavData)
 	if err != nil {
 		return err
 	}
-
-	player := p.ctx.NewPlayer(bytes.NewReader(pcm))
 
 	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrPlayerClosed
+	}
+	player := p.ctx.NewPlayer(bytes.NewReader(pcm))
 	p.active = player
 	p.mu.Unlock()
 
@@ -80,38 +83,29 @@ func (p *Player) Stop() {
 	}
 }
 
-// extractPCM strips the WAV/RIFF header and returns raw PCM data.
-func extractPCM(wav []byte) ([]byte, error) {
-	if len(wav) < 44 {
-		return nil, errors.New("wav data too short")
-	}
-
-	// Verify RIFF header.
-	if string(wav[0:4]) != "RIFF" || string(wav[8:12]) != "WAVE" {
-		return nil, errors.New("not a valid WAV file")
+// Close stops what's playing and suspends the audio device, so the
+// sound card is free for other programs.  oto allows one context per
+// process, so the device isn't torn down outright: Reopen resumes it.
+func (p *Player) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	p.Stop()
+	if err := p.ctx.Suspend(); err != nil {
+		return err
 	}
+	p.log.Debug("audio player: closed")
+	return nil
+}
 
-	// Walk chunks to find the "data" chunk.
-	pos := 12
-	for pos < len(wav)-8 {
-		chunkID := string(wav[pos : pos+4])
-		chunkSize := int(binary.LittleEndian.Uint32(wav[pos+4 : pos+8]))
-
-		if chunkID == "data" {
-			start := pos + 8
-			end := start + chunkSize
-			if end > len(wav) {
-				end = len(wav)
-			}
-			return wav[start:end], nil
-		}
-
-		pos += 8 + chunkSize
-		// Chunks are word-aligned.
-		if chunkSize%2 != 0 {
-			pos++
-		}
+// Reopen resumes the audio device after Close.
+func (p *Player) Reopen() error {
+	if err := p.ctx.Resume(); err != nil {
+		return err
 	}
-
-	return nil, errors.New("data chunk not found in WAV")
+	p.mu.Lock()
+	p.closed = false
+	p.mu.Unlock()
+	p.log.Debug("audio player: reopened")
+	return nil
 }
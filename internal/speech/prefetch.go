@@ -0,0 +1,152 @@
+package speech
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// ── Prefetching ──────────────────────────────────────────────────
+//
+// Prefetches synthesize text that's likely to be said soon so playback
+// starts instantly.  They run on a small pool (WithPrefetchWorkers), a
+// chunk already queued or running isn't requested twice, and prefetches
+// made under a group are cancelled when a later call for the same group
+// no longer needs them — clicking through several recipes only
+// synthesizes the one you stop on.
+
+// synthJob is one prefetch chunk, queued or running.
+type synthJob struct {
+	cancel  context.CancelFunc
+	done    chan struct{}
+	promote chan struct{} // closed when wanted: skip the queue
+	audio   []byte
+	err     error
+
+	groups map[string]bool // groups that still want this chunk
+	wanted bool            // the speech path is waiting on it; never cancel
+}
+
+// Prefetch pre-synthesizes the given texts in the background and stores
+// the results in the audio cache, skipping anything already cached or
+// on its way.  Non-blocking.
+//
+// Call it any time you know what text will be spoken next (e.g. the next
+// cooking step) so playback starts instantly when Say is called.
+func (m *Mouth) Prefetch(ctx context.Context, texts ...string) {
+	m.PrefetchGroup(ctx, "", texts...)
+}
+
+// PrefetchGroup is Prefetch for text that's only worth having while it's
+// the group's latest: each call replaces the group's previous set, and
+// chunks from that set nothing else wants are cancelled.  The "" group
+// is never replaced.
+func (m *Mouth) PrefetchGroup(ctx context.Context, group string, texts ...string) {
+	var chunks []string
+	for _, text := range texts {
+		if text != "" {
+			// For long text, split into the same chunks Say would use.
+			chunks = append(chunks, m.splitChunks(text)...)
+		}
+	}
+
+	defer m.jobsChanged() // runs after the unlock below
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if group != "" {
+		for _, old := range m.groups[group] {
+			if slices.Contains(chunks, old) {
+				continue
+			}
+			if job, ok := m.inflight[old]; ok {
+				delete(job.groups, group)
+				if len(job.groups) == 0 && !job.wanted {
+					m.log.Debug("prefetch: no longer needed: %s", truncate(old, 50))
+					job.cancel()
+				}
+			}
+		}
+		m.groups[group] = chunks
+	}
+
+	for _, chunk := range chunks {
+		if job, ok := m.inflight[chunk]; ok {
+			job.groups[group] = true
+			continue
+		}
+		if m.cache.Has(chunk) {
+			m.log.Debug("prefetch: already cached: %s", truncate(chunk, 50))
+			continue
+		}
+		jobCtx, cancel := context.WithCancel(ctx)
+		job := &synthJob{
+			cancel:  cancel,
+			done:    make(chan struct{}),
+			promote: make(chan struct{}),
+			groups:  map[string]bool{group: true},
+		}
+		m.inflight[chunk] = job
+		go m.runPrefetch(jobCtx, chunk, job)
+	}
+}
+
+// runPrefetch waits for a free slot, synthesizes one chunk, and caches it.
+func (m *Mouth) runPrefetch(ctx context.Context, text string, job *synthJob) {
+	defer func() {
+		m.mu.Lock()
+		if m.inflight[text] == job {
+			delete(m.inflight, text)
+		}
+		m.mu.Unlock()
+		job.cancel()
+		close(job.done)
+		m.jobsChanged()
+	}()
+
+	select {
+	case m.prefetchSlots <- struct{}{}:
+		defer func() { <-m.prefetchSlots }()
+	case <-job.promote:
+		// About to be spoken — don't make it wait behind speculation.
+	case <-ctx.Done():
+		job.err = ctx.Err()
+		return
+	}
+
+	m.log.Debug("prefetch: synthesizing: %s", truncate(text, 50))
+	job.audio, job.err = m.synthesize(ctx, text, priorityPrefetch)
+	switch {
+	case errors.Is(job.err, context.Canceled):
+		m.log.Debug("prefetch: cancelled: %s", truncate(text, 50))
+	case errors.Is(job.err, ErrQuota):
+		m.log.Debug("prefetch: skipped, TTS quota low: %s", truncate(text, 50))
+	case job.err != nil:
+		m.log.Error("prefetch: synthesis failed: %v", job.err)
+	default:
+		m.cache.Put(text, job.audio)
+		m.log.Debug("prefetch: cached %d bytes for: %s", len(job.audio), truncate(text, 50))
+	}
+}
+
+// awaitPrefetch waits for a prefetch of text if one is queued or running
+// and returns its audio.  ok is false when there's none or it failed, in
+// which case the caller synthesizes the text itself.
+func (m *Mouth) awaitPrefetch(ctx context.Context, text string) (audio []byte, ok bool) {
+	m.mu.Lock()
+	job, found := m.inflight[text]
+	if found && !job.wanted {
+		job.wanted = true
+		close(job.promote)
+	}
+	m.mu.Unlock()
+	if !found {
+		return nil, false
+	}
+	select {
+	case <-job.done:
+		return job.audio, job.err == nil
+	case <-ctx.Done():
+		return nil, false
+	}
+}
@@ -0,0 +1,113 @@
+package speech
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// eventually fails the test unless cond comes true within a couple of
+// seconds.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("never %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// inflight lists the chunks a mouth is prefetching, sorted.
+func inflight(m *Mouth) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var chunks []string
+	for c := range m.inflight {
+		chunks = append(chunks, c)
+	}
+	slices.Sort(chunks)
+	return chunks
+}
+
+func TestPrefetchPool(t *testing.T) {
+	tts := newFakeSynth("Jenny")
+	tts.gate = make(chan struct{})
+	m := NewMouth(tts, &fakePlayer{}, logger.New(logger.LevelOff, nil), WithChunkSize(0), WithPrefetchWorkers(2))
+	ctx := context.Background()
+
+	m.Prefetch(ctx, "One.", "Two.", "Three.")
+	m.Prefetch(ctx, "Two.", "Three.") // already on their way
+	eventually(t, "started two prefetches", func() bool { return len(tts.synthesized()) == 2 })
+	time.Sleep(20 * time.Millisecond)
+	if got := tts.synthesized(); len(got) != 2 {
+		t.Errorf("synthesizing %q with two workers", got)
+	}
+	if got := inflight(m); !slices.Equal(got, []string{"One.", "Three.", "Two."}) {
+		t.Errorf("in flight %q, want all three", got)
+	}
+
+	close(tts.gate)
+	eventually(t, "finished prefetching", func() bool { return len(inflight(m)) == 0 })
+	got := tts.synthesized()
+	slices.Sort(got)
+	if !slices.Equal(got, []string{"One.", "Three.", "Two."}) {
+		t.Errorf("synthesized %q; want each chunk once", got)
+	}
+	for _, c := range got {
+		if !m.cache.Has(c) {
+			t.Errorf("%q wasn't cached", c)
+		}
+	}
+
+	m.Prefetch(ctx, "One.")
+	if n := len(tts.synthesized()); n != 3 {
+		t.Errorf("prefetching a cached chunk synthesized it again (%d calls)", n)
+	}
+}
+
+func TestPrefetchGroup(t *testing.T) {
+	tts := newFakeSynth("Jenny")
+	tts.gate = make(chan struct{}) // never opened: prefetches hang until cancelled
+	m := NewMouth(tts, &fakePlayer{}, logger.New(logger.LevelOff, nil), WithChunkSize(0), WithPrefetchWorkers(1))
+	ctx := context.Background()
+
+	// Clicking through recipes: only the latest preview is still wanted,
+	// unless something else asked for it too.
+	m.Prefetch(ctx, "Shared.")
+	m.PrefetchGroup(ctx, "preview", "Alfredo.", "Shared.")
+	m.PrefetchGroup(ctx, "preview", "Stir fry.")
+	eventually(t, "cancelled the old preview", func() bool {
+		return slices.Equal(inflight(m), []string{"Shared.", "Stir fry."})
+	})
+
+	// A chunk about to be spoken skips the queue and isn't cancelled.
+	done := make(chan []byte)
+	go func() {
+		audio, _ := m.awaitPrefetch(ctx, "Stir fry.")
+		done <- audio
+	}()
+	eventually(t, "promoted the wanted chunk", func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.inflight["Stir fry."].wanted
+	})
+	eventually(t, "synthesized the wanted chunk", func() bool { return slices.Contains(tts.synthesized(), "Stir fry.") })
+	m.PrefetchGroup(ctx, "preview")
+	time.Sleep(20 * time.Millisecond)
+	if got := inflight(m); !slices.Contains(got, "Stir fry.") {
+		t.Errorf("in flight %q; a chunk being waited on was cancelled", got)
+	}
+
+	tts.mu.Lock()
+	gate := tts.gate
+	tts.mu.Unlock()
+	close(gate)
+	if audio := <-done; string(audio) != "Jenny:Stir fry." {
+		t.Errorf("awaitPrefetch = %q", audio)
+	}
+}
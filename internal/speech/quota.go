@@ -0,0 +1,207 @@
+package speech
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// ErrQuota is returned when the rate limit or daily character budget
+// turns a synthesis request away.
+var ErrQuota = errors.New("tts quota: request skipped")
+
+// priorityPrefetch ranks speculative prefetches below anything actually
+// queued to be said, so they're the first thing a quota drops.
+const priorityPrefetch Priority = -1
+
+// Share of the daily budget after which each kind of request is
+// refused.  High and critical speech (timer alerts) is never refused by
+// the budget — missing a "your pasta is done" costs more than going a
+// little over.
+const (
+	prefetchCutoff = 0.70
+	lowCutoff      = 0.85
+	normalCutoff   = 1.00
+)
+
+// Quota guards a metered TTS backend with a token bucket on requests
+// and a daily budget on characters.  When either runs short, the least
+// important speech is skipped first: prefetches, then PriorityLow, then
+// PriorityNormal.
+//
+// Characters used today are saved to a small JSON file so a restart
+// doesn't reset the count.  A nil *Quota allows everything.
+type Quota struct {
+	log   *logger.Logger
+	clock domain.Clock
+
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	budget int    // characters per day, 0 = unlimited
+	used   int    // characters synthesized today
+	day    string // date used applies to, "2006-01-02"
+	path   string // state file, "" = don't persist
+}
+
+// quotaState is the persisted part of a Quota.
+type quotaState struct {
+	Day   string `json:"day"`
+	Chars int    `json:"chars"`
+}
+
+// QuotaOption configures a Quota.
+type QuotaOption func(*Quota)
+
+// WithQuotaClock sets the clock the rate limit refills by and the daily
+// budget rolls over by.
+func WithQuotaClock(c domain.Clock) QuotaOption {
+	return func(q *Quota) { q.clock = c }
+}
+
+// NewQuota allows perMinute requests (bursting up to that many at once)
+// and dailyChars characters per calendar day.  0 disables either limit.
+// statePath is where today's usage is kept between runs; "" keeps it in
+// memory only.
+func NewQuota(perMinute, dailyChars int, statePath string, log *logger.Logger, opts ...QuotaOption) *Quota {
+	q := &Quota{
+		log:    log,
+		clock:  domain.SystemClock{},
+		rate:   float64(perMinute) / 60,
+		burst:  float64(perMinute),
+		tokens: float64(perMinute),
+		budget: dailyChars,
+		path:   statePath,
+	}
+	for _, o := range opts {
+		o(q)
+	}
+	q.last, q.day = q.clock.Now(), q.today()
+	if statePath != "" {
+		if data, err := os.ReadFile(statePath); err == nil {
+			var st quotaState
+			if json.Unmarshal(data, &st) == nil && st.Day == q.day {
+				q.used = st.Chars
+			}
+		}
+	}
+	return q
+}
+
+// Acquire reserves room for a request of chars characters at priority
+// p.  Prefetch and PriorityLow requests are refused at once if no
+// request token is free; more important ones wait for a token (or ctx).
+// Returns ErrQuota when the request should be skipped.
+func (q *Quota) Acquire(ctx context.Context, chars int, p Priority) error {
+	if q == nil {
+		return nil
+	}
+	for {
+		q.mu.Lock()
+		q.rollDayLocked()
+		if q.budget > 0 && p < PriorityHigh {
+			cutoff := normalCutoff
+			switch {
+			case p <= priorityPrefetch:
+				cutoff = prefetchCutoff
+			case p == PriorityLow:
+				cutoff = lowCutoff
+			}
+			if float64(q.used+chars) > cutoff*float64(q.budget) {
+				q.mu.Unlock()
+				q.log.Debug("tts quota: skipping priority %d request, %d/%d chars used today", p, q.used, q.budget)
+				return ErrQuota
+			}
+		}
+
+		wait := q.refillLocked()
+		if q.rate == 0 || q.tokens >= 1 {
+			if q.rate > 0 {
+				q.tokens--
+			}
+			q.used += chars
+			q.saveLocked()
+			q.mu.Unlock()
+			return nil
+		}
+		q.mu.Unlock()
+
+		if p <= PriorityLow {
+			q.log.Debug("tts quota: rate limited, skipping priority %d request", p)
+			return ErrQuota
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Usage returns the characters used today and the daily budget.
+func (q *Quota) Usage() (used, budget int) {
+	if q == nil {
+		return 0, 0
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.rollDayLocked()
+	return q.used, q.budget
+}
+
+// refillLocked tops the bucket up for the time since the last call and
+// returns how long until the next token if it's empty.
+func (q *Quota) refillLocked() time.Duration {
+	if q.rate == 0 {
+		return 0
+	}
+	now := q.clock.Now()
+	q.tokens = min(q.burst, q.tokens+now.Sub(q.last).Seconds()*q.rate)
+	q.last = now
+	if q.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - q.tokens) / q.rate * float64(time.Second))
+}
+
+// rollDayLocked resets the character count at midnight.
+func (q *Quota) rollDayLocked() {
+	if d := q.today(); d != q.day {
+		q.day, q.used = d, 0
+	}
+}
+
+func (q *Quota) saveLocked() {
+	if q.path == "" {
+		return
+	}
+	data, _ := json.Marshal(quotaState{Day: q.day, Chars: q.used})
+	if dir := filepath.Dir(q.path); dir != "." {
+		os.MkdirAll(dir, 0o755)
+	}
+	if err := os.WriteFile(q.path, data, 0o644); err != nil {
+		q.log.Error("tts quota: saving usage: %v", err)
+	}
+}
+
+func (q *Quota) today() string { return q.clock.Now().Format("2006-01-02") }
+
+// String describes today's usage for logs.
+func (q *Quota) String() string {
+	used, budget := q.Usage()
+	if budget == 0 {
+		return fmt.Sprintf("%d chars today, no daily budget", used)
+	}
+	return fmt.Sprintf("%d/%d chars today", used, budget)
+}
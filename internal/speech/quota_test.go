@@ -0,0 +1,107 @@
+package speech
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+func TestQuotaBudget(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 18, 0, 0, 0, time.Local)}
+	q := NewQuota(0, 100, "", logger.New(logger.LevelOff, nil), WithQuotaClock(clock))
+
+	// Each request is checked against what's been used before it.
+	tests := []struct {
+		name     string
+		chars    int
+		priority Priority
+		ok       bool
+	}{
+		{"prefetch over 70%", 71, priorityPrefetch, false},
+		{"prefetch up to 70%", 70, priorityPrefetch, true},
+		{"low over 85%", 16, PriorityLow, false},
+		{"low up to 85%", 15, PriorityLow, true},
+		{"normal over the budget", 16, PriorityNormal, false},
+		{"normal up to the budget", 15, PriorityNormal, true},
+		{"high past the budget", 50, PriorityHigh, true},
+		{"critical past the budget", 50, PriorityCritical, true},
+		{"prefetch with nothing left", 1, priorityPrefetch, false},
+	}
+	for _, tt := range tests {
+		err := q.Acquire(context.Background(), tt.chars, tt.priority)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: Acquire = %v, want ok %v", tt.name, err, tt.ok)
+		}
+		if err != nil && !errors.Is(err, ErrQuota) {
+			t.Errorf("%s: Acquire = %v, want ErrQuota", tt.name, err)
+		}
+	}
+	if used, budget := q.Usage(); used != 200 || budget != 100 {
+		t.Errorf("Usage = %d/%d, want 200/100", used, budget)
+	}
+
+	clock.advance(24 * time.Hour)
+	if used, _ := q.Usage(); used != 0 {
+		t.Errorf("used %d the next day, want 0", used)
+	}
+}
+
+func TestQuotaRate(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 18, 0, 0, 0, time.Local)}
+	q := NewQuota(2, 0, "", logger.New(logger.LevelOff, nil), WithQuotaClock(clock))
+	ctx := context.Background()
+
+	for i := range 2 {
+		if err := q.Acquire(ctx, 10, PriorityNormal); err != nil {
+			t.Fatalf("request %d within the burst: %v", i, err)
+		}
+	}
+	// The bucket is empty: low priority and prefetches are dropped, the
+	// rest wait for a token.
+	for _, p := range []Priority{priorityPrefetch, PriorityLow} {
+		if err := q.Acquire(ctx, 10, p); !errors.Is(err, ErrQuota) {
+			t.Errorf("priority %d on an empty bucket: Acquire = %v, want ErrQuota", p, err)
+		}
+	}
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := q.Acquire(cancelled, 10, PriorityNormal); !errors.Is(err, context.Canceled) {
+		t.Errorf("waiting for a token with ctx cancelled: Acquire = %v, want context.Canceled", err)
+	}
+
+	// Two a minute is one every 30s.
+	clock.advance(30 * time.Second)
+	if err := q.Acquire(ctx, 10, PriorityLow); err != nil {
+		t.Errorf("after a refill: Acquire = %v", err)
+	}
+	if err := q.Acquire(ctx, 10, PriorityLow); !errors.Is(err, ErrQuota) {
+		t.Errorf("a refill only gives one token: Acquire = %v, want ErrQuota", err)
+	}
+
+	var none *Quota
+	if err := none.Acquire(ctx, 1e6, priorityPrefetch); err != nil {
+		t.Errorf("nil quota: Acquire = %v", err)
+	}
+}
+
+func TestQuotaPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "quota.json")
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 18, 0, 0, 0, time.Local)}
+	log := logger.New(logger.LevelOff, nil)
+
+	q := NewQuota(0, 1000, path, log, WithQuotaClock(clock))
+	if err := q.Acquire(context.Background(), 40, PriorityNormal); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if used, _ := NewQuota(0, 1000, path, log, WithQuotaClock(clock)).Usage(); used != 40 {
+		t.Errorf("after a restart: used %d, want 40", used)
+	}
+	clock.advance(24 * time.Hour)
+	if used, _ := NewQuota(0, 1000, path, log, WithQuotaClock(clock)).Usage(); used != 0 {
+		t.Errorf("after a restart the next day: used %d, want 0", used)
+	}
+}
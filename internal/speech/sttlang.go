@@ -0,0 +1,124 @@
+package speech
+
+// ── Per-language transcription cleanup ──────────────────────────
+//
+// Whisper's artifacts depend on the language it's transcribing: sound
+// annotations come out translated ("(Musik)", "[Musique]") and silence
+// is often "transcribed" as the subtitle credits it saw most during
+// training.  The English lists in cleanTranscription always apply;
+// these are layered on top for the configured -stt-language.
+
+type sttCleanup struct {
+	annotations    []string // stripped from anywhere in the text
+	hallucinations []string // discarded when they're the whole transcription
+}
+
+var sttLanguages = map[string]sttCleanup{
+	"fr": {
+		annotations: []string{"(Musique)", "[Musique]", "(musique)", "(Rires)", "(Applaudissements)", "(silence)", "(inaudible)", "(Bruit de fond)"},
+		hallucinations: []string{
+			"Sous-titres réalisés par la communauté d'Amara.org",
+			"Sous-titrage ST' 501",
+			"Merci d'avoir regardé.",
+			"Merci.",
+			"Au revoir.",
+		},
+	},
+	"de": {
+		annotations: []string{"(Musik)", "[Musik]", "(Lachen)", "(Applaus)", "(Stille)", "(unverständlich)", "(Hintergrundgeräusche)"},
+		hallucinations: []string{
+			"Untertitel im Auftrag des ZDF für funk, 2017",
+			"Untertitel der Amara.org-Community",
+			"Untertitelung des ZDF, 2020",
+			"Vielen Dank fürs Zuschauen.",
+			"Danke.",
+			"Tschüss.",
+		},
+	},
+	"es": {
+		annotations: []string{"(Música)", "[Música]", "(música)", "(Risas)", "(Aplausos)", "(silencio)", "(inaudible)"},
+		hallucinations: []string{
+			"Subtítulos realizados por la comunidad de Amara.org",
+			"¡Gracias por ver!",
+			"Gracias por ver el video.",
+			"Gracias.",
+			"Adiós.",
+		},
+	},
+	"it": {
+		annotations: []string{"(Musica)", "[Musica]", "(Risate)", "(Applausi)", "(silenzio)"},
+		hallucinations: []string{
+			"Sottotitoli creati dalla comunità Amara.org",
+			"Sottotitoli e revisione a cura di QTSS",
+			"Grazie per la visione.",
+			"Grazie.",
+		},
+	},
+	"pt": {
+		annotations: []string{"(Música)", "[Música]", "(Risos)", "(Aplausos)", "(silêncio)"},
+		hallucinations: []string{
+			"Legendas pela comunidade Amara.org",
+			"Obrigado por assistir.",
+			"Obrigado.",
+			"Tchau.",
+		},
+	},
+	"nl": {
+		annotations: []string{"(Muziek)", "[Muziek]", "(Gelach)", "(Applaus)", "(stilte)"},
+		hallucinations: []string{
+			"Ondertiteld door de Amara.org gemeenschap",
+			"Ondertiteling door de Amara.org gemeenschap",
+			"Bedankt voor het kijken.",
+			"Dank je wel.",
+		},
+	},
+	"ar": {
+		annotations: []string{"(موسيقى)", "[موسيقى]", "(تصفيق)", "(ضحك)"},
+		hallucinations: []string{
+			"ترجمة نانسي قنقر",
+			"اشتركوا في القناة",
+			"شكرا لكم على المشاهدة",
+			"شكرا.",
+		},
+	},
+	"ja": {
+		annotations: []string{"(音楽)", "[音楽]", "(拍手)", "(笑)"},
+		hallucinations: []string{
+			"ご視聴ありがとうございました",
+			"ご視聴ありがとうございました。",
+			"チャンネル登録をお願いします",
+		},
+	},
+}
+
+// sttLanguageList returns the cleanup rules for lang.  "auto" means
+// whisper picks the language per utterance, so every table applies.
+func sttLanguageList(lang string) []sttCleanup {
+	if lang == "auto" {
+		all := make([]sttCleanup, 0, len(sttLanguages))
+		for _, c := range sttLanguages {
+			all = append(all, c)
+		}
+		return all
+	}
+	if c, ok := sttLanguages[lang]; ok {
+		return []sttCleanup{c}
+	}
+	return nil
+}
+
+func languageAnnotations(lang string) []string {
+	var out []string
+	for _, c := range sttLanguageList(lang) {
+		out = append(out, c.annotations...)
+	}
+	return out
+}
+
+func languageHallucinations(lang string) []string {
+	var out []string
+	for _, c := range sttLanguageList(lang) {
+		out = append(out, c.hallucinations...)
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+package speech
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSTTLanguageList(t *testing.T) {
+	for _, tt := range []struct {
+		lang string
+		want int
+	}{
+		{"fr", 1},
+		{"ja", 1},
+		{"auto", len(sttLanguages)},
+		{"en", 0}, // the English lists are cleanTranscription's own
+		{"", 0},
+		{"xx", 0},
+	} {
+		if got := len(sttLanguageList(tt.lang)); got != tt.want {
+			t.Errorf("sttLanguageList(%q) has %d tables, want %d", tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestSTTLanguageTables(t *testing.T) {
+	for lang, c := range sttLanguages {
+		if len(c.annotations) == 0 || len(c.hallucinations) == 0 {
+			t.Errorf("%s: %d annotations, %d hallucinations; want some of each", lang, len(c.annotations), len(c.hallucinations))
+		}
+		for _, a := range c.annotations {
+			if !strings.ContainsAny(a[:1], "([") || !strings.ContainsAny(a[len(a)-1:], ")]") {
+				t.Errorf("%s: annotation %q isn't bracketed", lang, a)
+			}
+		}
+		for _, h := range c.hallucinations {
+			if strings.TrimSpace(h) != h || h == "" {
+				t.Errorf("%s: hallucination %q has stray spaces", lang, h)
+			}
+		}
+	}
+}
+
+func TestCleanTranscriptionLanguage(t *testing.T) {
+	for _, tt := range []struct {
+		in, lang, want string
+	}{
+		{"(Musique) ajoute le sel", "fr", "ajoute le sel"},
+		{"Merci.", "fr", ""},
+		{"merci.", "fr", ""},
+		{"Merci.", "en", "Merci."},
+		{"Merci.", "", "Merci."},
+		{"Sous-titres réalisés par la communauté d'Amara.org", "fr", ""},
+		{"weiter [Musik]", "de", "weiter"},
+		{"Vielen Dank fürs Zuschauen.", "de", ""},
+		{"Danke.", "es", "Danke."},
+		{"Danke.", "auto", ""},
+		{"Gracias por ver el video.", "auto", ""},
+		{"(Risas) siguiente paso", "es", "siguiente paso"},
+		{"Grazie.", "it", ""},
+		{"Obrigado por assistir.", "pt", ""},
+		{"Bedankt voor het kijken.", "nl", ""},
+		{"شكرا لكم على المشاهدة", "ar", ""},
+		{"ご視聴ありがとうございました。", "ja", ""},
+		{"(音楽) 次", "ja", "次"},
+		// A hallucination inside a real command is left alone.
+		{"merci, étape suivante", "fr", "merci, étape suivante"},
+	} {
+		if got := cleanTranscription(tt.in, tt.lang); got != tt.want {
+			t.Errorf("cleanTranscription(%q, %q) = %q, want %q", tt.in, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestWhisperFlagsLanguage(t *testing.T) {
+	e := &Ear{whisperArgs: []string{"-fa"}}
+	for _, tt := range []struct {
+		lang, want string
+	}{
+		{"", "-fa"},
+		{"en", "-fa"}, // whisper's default needs no flag, or a wrapper to pass one
+		{"fr", "-l fr -fa"},
+		{"auto", "-l auto -fa"},
+	} {
+		if got := strings.Join(e.whisperFlags(tt.lang), " "); got != tt.want {
+			t.Errorf("whisperFlags(%q) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+}
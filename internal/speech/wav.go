@@ -0,0 +1,196 @@
+package speech
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ── WAV decoding ─────────────────────────────────────────────────
+//
+// The player's device is opened once at SampleRate/ChannelCount/16-bit.
+// Azure returns exactly that, but a different backend or a cache entry
+// written under an older audio format may not, and raw PCM in the wrong
+// layout plays at the wrong speed or as noise.  So the fmt chunk is read
+// and anything else is converted on the way in.
+
+// WAV format codes.
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+// wavFormat is the part of a WAV fmt chunk needed to decode samples.
+type wavFormat struct {
+	code       uint16
+	channels   int
+	sampleRate int
+	bits       int
+}
+
+func (f wavFormat) String() string {
+	return fmt.Sprintf("%d Hz, %d ch, %d-bit (format %#x)", f.sampleRate, f.channels, f.bits, f.code)
+}
+
+// parseWAV walks the RIFF chunks and returns the format and the raw
+// bytes of the data chunk.
+func parseWAV(wav []byte) (wavFormat, []byte, error) {
+	var f wavFormat
+	if len(wav) < 12 || string(wav[0:4]) != "RIFF" || string(wav[8:12]) != "WAVE" {
+		return f, nil, errors.New("not a valid WAV file")
+	}
+
+	var haveFmt bool
+	pos := 12
+	for pos+8 <= len(wav) {
+		chunkID := string(wav[pos : pos+4])
+		chunkSize := int(binary.LittleEndian.Uint32(wav[pos+4 : pos+8]))
+		start := pos + 8
+		end := min(start+chunkSize, len(wav))
+		if chunkSize < 0 || start > end {
+			break
+		}
+
+		switch chunkID {
+		case "fmt ":
+			if end-start < 16 {
+				return f, nil, errors.New("wav fmt chunk too short")
+			}
+			c := wav[start:end]
+			f.code = binary.LittleEndian.Uint16(c[0:2])
+			f.channels = int(binary.LittleEndian.Uint16(c[2:4]))
+			f.sampleRate = int(binary.LittleEndian.Uint32(c[4:8]))
+			f.bits = int(binary.LittleEndian.Uint16(c[14:16]))
+			// WAVE_FORMAT_EXTENSIBLE keeps the real code at the start
+			// of the sub-format GUID.
+			if f.code == wavFormatExtensible && len(c) >= 26 {
+				f.code = binary.LittleEndian.Uint16(c[24:26])
+			}
+			haveFmt = true
+		case "data":
+			if !haveFmt {
+				return f, nil, errors.New("wav data chunk before fmt chunk")
+			}
+			return f, wav[start:end], nil
+		}
+
+		pos = start + chunkSize
+		// Chunks are word-aligned.
+		if chunkSize%2 != 0 {
+			pos++
+		}
+	}
+	return f, nil, errors.New("data chunk not found in WAV")
+}
+
+// playerPCM returns wav's samples as 16-bit little-endian mono PCM at
+// SampleRate, converting when the file differs.
+func playerPCM(wav []byte) ([]byte, error) {
+	f, data, err := parseWAV(wav)
+	if err != nil {
+		return nil, err
+	}
+	if f.code == wavFormatPCM && f.bits == BitDepth && f.channels == ChannelCount && f.sampleRate == SampleRate {
+		return data, nil
+	}
+
+	samples, err := decodeSamples(f, data)
+	if err != nil {
+		return nil, err
+	}
+	samples = downmix(samples, f.channels)
+	samples = resample(samples, f.sampleRate, SampleRate)
+
+	out := make([]byte, len(samples)*2)
+	for i, v := range samples {
+		v = max(-1, min(1, v))
+		binary.LittleEndian.PutUint16(out[i*2:], uint16(int16(math.Round(v*32767))))
+	}
+	return out, nil
+}
+
+// decodeSamples turns interleaved PCM or float data into samples in
+// [-1, 1].
+func decodeSamples(f wavFormat, data []byte) ([]float64, error) {
+	if f.channels < 1 || f.sampleRate < 1 {
+		return nil, fmt.Errorf("unsupported WAV format: %s", f)
+	}
+	width := f.bits / 8
+	if width == 0 {
+		return nil, fmt.Errorf("unsupported WAV format: %s", f)
+	}
+	n := len(data) / width
+	out := make([]float64, n)
+
+	switch {
+	case f.code == wavFormatPCM && f.bits == 8:
+		for i := range n {
+			out[i] = (float64(data[i]) - 128) / 128 // 8-bit WAV is unsigned
+		}
+	case f.code == wavFormatPCM && f.bits == 16:
+		for i := range n {
+			out[i] = float64(int16(binary.LittleEndian.Uint16(data[i*2:]))) / 32768
+		}
+	case f.code == wavFormatPCM && f.bits == 24:
+		for i := range n {
+			b := data[i*3:]
+			v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+			out[i] = float64(v) / (1 << 23)
+		}
+	case f.code == wavFormatPCM && f.bits == 32:
+		for i := range n {
+			out[i] = float64(int32(binary.LittleEndian.Uint32(data[i*4:]))) / (1 << 31)
+		}
+	case f.code == wavFormatFloat && f.bits == 32:
+		for i := range n {
+			out[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:])))
+		}
+	case f.code == wavFormatFloat && f.bits == 64:
+		for i := range n {
+			out[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported WAV format: %s", f)
+	}
+	return out, nil
+}
+
+// downmix averages interleaved frames of channels samples down to mono.
+func downmix(samples []float64, channels int) []float64 {
+	if channels == 1 {
+		return samples
+	}
+	out := make([]float64, len(samples)/channels)
+	for i := range out {
+		var sum float64
+		for c := range channels {
+			sum += samples[i*channels+c]
+		}
+		out[i] = sum / float64(channels)
+	}
+	return out
+}
+
+// resample converts mono samples between rates by linear interpolation
+// — plenty for speech.
+func resample(samples []float64, from, to int) []float64 {
+	if from == to || len(samples) == 0 {
+		return samples
+	}
+	n := int(int64(len(samples)) * int64(to) / int64(from))
+	out := make([]float64, n)
+	step := float64(from) / float64(to)
+	for i := range out {
+		pos := float64(i) * step
+		j := int(pos)
+		if j+1 >= len(samples) {
+			out[i] = samples[len(samples)-1]
+			continue
+		}
+		frac := pos - float64(j)
+		out[i] = samples[j]*(1-frac) + samples[j+1]*frac
+	}
+	return out
+}
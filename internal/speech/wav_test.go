@@ -0,0 +1,133 @@
+package speech
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// chunk is a RIFF chunk, padded to an even length.
+func chunk(id string, body []byte) []byte {
+	b := binary.LittleEndian.AppendUint32([]byte(id), uint32(len(body)))
+	b = append(b, body...)
+	if len(body)%2 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// fmtChunk is a fmt chunk body; extensible ones carry code in the
+// sub-format.
+func fmtChunk(code uint16, channels, rate, bits int) []byte {
+	b := binary.LittleEndian.AppendUint16(nil, code)
+	b = binary.LittleEndian.AppendUint16(b, uint16(channels))
+	b = binary.LittleEndian.AppendUint32(b, uint32(rate))
+	b = binary.LittleEndian.AppendUint32(b, uint32(rate*channels*bits/8))
+	b = binary.LittleEndian.AppendUint16(b, uint16(channels*bits/8))
+	b = binary.LittleEndian.AppendUint16(b, uint16(bits))
+	return b
+}
+
+// riff wraps chunks in a WAVE file.
+func riff(chunks ...[]byte) []byte {
+	body := []byte("WAVE")
+	for _, c := range chunks {
+		body = append(body, c...)
+	}
+	return append(binary.LittleEndian.AppendUint32([]byte("RIFF"), uint32(len(body))), body...)
+}
+
+// pcm16 encodes samples as 16-bit little-endian PCM.
+func pcm16(samples ...int16) []byte {
+	var b []byte
+	for _, s := range samples {
+		b = binary.LittleEndian.AppendUint16(b, uint16(s))
+	}
+	return b
+}
+
+func TestParseWAV(t *testing.T) {
+	data := pcm16(1, 2, 3)
+	extensible := append(fmtChunk(wavFormatExtensible, 2, 48000, 32), make([]byte, 24)...)
+	binary.LittleEndian.PutUint16(extensible[24:], wavFormatFloat)
+
+	tests := []struct {
+		name    string
+		wav     []byte
+		want    wavFormat
+		wantErr bool
+	}{
+		{"plain", riff(chunk("fmt ", fmtChunk(wavFormatPCM, 1, 24000, 16)), chunk("data", data)),
+			wavFormat{wavFormatPCM, 1, 24000, 16}, false},
+		{"odd chunk before fmt", riff(chunk("LIST", []byte("abc")), chunk("fmt ", fmtChunk(wavFormatPCM, 2, 44100, 8)), chunk("data", data)),
+			wavFormat{wavFormatPCM, 2, 44100, 8}, false},
+		{"extensible", riff(chunk("fmt ", extensible), chunk("data", data)),
+			wavFormat{wavFormatFloat, 2, 48000, 32}, false},
+		{"not a wav", []byte("ID3 not a wave file"), wavFormat{}, true},
+		{"short fmt", riff(chunk("fmt ", make([]byte, 14)), chunk("data", data)), wavFormat{}, true},
+		{"data before fmt", riff(chunk("data", data), chunk("fmt ", fmtChunk(wavFormatPCM, 1, 24000, 16))), wavFormat{}, true},
+		{"no data", riff(chunk("fmt ", fmtChunk(wavFormatPCM, 1, 24000, 16))), wavFormat{}, true},
+	}
+	for _, tt := range tests {
+		f, got, err := parseWAV(tt.wav)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if f != tt.want {
+			t.Errorf("%s: format %s, want %s", tt.name, f, tt.want)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("%s: data % x, want % x", tt.name, got, data)
+		}
+	}
+}
+
+func TestPlayerPCM(t *testing.T) {
+	float32LE := func(vs ...float32) []byte {
+		var b []byte
+		for _, v := range vs {
+			b = binary.LittleEndian.AppendUint32(b, math.Float32bits(v))
+		}
+		return b
+	}
+
+	tests := []struct {
+		name    string
+		format  []byte
+		data    []byte
+		want    []byte
+		wantErr bool
+	}{
+		{"native passes through", fmtChunk(wavFormatPCM, 1, SampleRate, 16), pcm16(100, -100, 7), pcm16(100, -100, 7), false},
+		{"stereo is averaged", fmtChunk(wavFormatPCM, 2, SampleRate, 16), pcm16(1000, 3000, -2000, 0), pcm16(2000, -1000), false},
+		{"double rate is halved", fmtChunk(wavFormatPCM, 1, 2*SampleRate, 16), pcm16(0, 100, 200, 300), pcm16(0, 200), false},
+		{"half rate is interpolated", fmtChunk(wavFormatPCM, 1, SampleRate/2, 16), pcm16(0, 1000), pcm16(0, 500, 1000, 1000), false},
+		{"8-bit is unsigned", fmtChunk(wavFormatPCM, 1, SampleRate, 8), []byte{128, 192, 0}, pcm16(0, 16384, -32767), false},
+		{"float is clipped", fmtChunk(wavFormatFloat, 1, SampleRate, 32), float32LE(0.5, 2), pcm16(16384, 32767), false},
+		{"unsupported", fmtChunk(2, 1, SampleRate, 4), []byte{1, 2}, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := playerPCM(riff(chunk("fmt ", tt.format), chunk("data", tt.data)))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, samplesOf(got), samplesOf(tt.want))
+		}
+	}
+}
+
+// samplesOf decodes 16-bit PCM for failure messages.
+func samplesOf(b []byte) []int16 {
+	out := make([]int16, len(b)/2)
+	for i := range out {
+		out[i] = int16(binary.LittleEndian.Uint16(b[i*2:]))
+	}
+	return out
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// Compile-time interface check.
+var _ domain.SessionStore = (*Hibernator)(nil)
+
+// Hibernator wraps a SessionStore and keeps a JSON copy of every session
+// in a hands-off wait (domain.SessionWaiting) in a directory, so closing
+// the app during a two-hour marinade doesn't lose it.  The file goes away
+// once the session stops waiting.  Restore loads the files back at
+// startup.
+type Hibernator struct {
+	domain.SessionStore
+	dir string
+	log *logger.Logger
+}
+
+// NewHibernator wraps inner, hibernating waiting sessions into dir.
+func NewHibernator(inner domain.SessionStore, dir string, log *logger.Logger) *Hibernator {
+	return &Hibernator{SessionStore: inner, dir: dir, log: log}
+}
+
+// Save saves to the wrapped store, then writes or removes the session's
+// hibernation file to match its status.
+func (h *Hibernator) Save(ctx context.Context, session *domain.Session) error {
+	if err := h.SessionStore.Save(ctx, session); err != nil {
+		return err
+	}
+	if session.Status != domain.SessionWaiting {
+		h.remove(session.ID)
+		return nil
+	}
+
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding session: %w", err)
+	}
+	if err := os.MkdirAll(h.dir, 0o755); err != nil {
+		return fmt.Errorf("creating hibernation dir: %w", err)
+	}
+	// Write then rename so a crash never leaves half a file.
+	tmp := h.path(session.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing session: %w", err)
+	}
+	if err := os.Rename(tmp, h.path(session.ID)); err != nil {
+		return fmt.Errorf("writing session: %w", err)
+	}
+	h.log.Debug("hibernated session %s until %s", session.ID, session.WaitUntil)
+	return nil
+}
+
+// Delete deletes from the wrapped store and removes any hibernation file.
+func (h *Hibernator) Delete(ctx context.Context, id string) error {
+	h.remove(id)
+	return h.SessionStore.Delete(ctx, id)
+}
+
+// Restore loads every hibernated session into the wrapped store and
+// returns them.  Unreadable files are logged and skipped.
+func (h *Hibernator) Restore(ctx context.Context) ([]*domain.Session, error) {
+	entries, err := os.ReadDir(h.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading hibernation dir: %w", err)
+	}
+
+	var out []*domain.Session
+	for _, ent := range entries {
+		if ent.IsDir() || !strings.HasSuffix(ent.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(h.dir, ent.Name()))
+		if err != nil {
+			h.log.Error("restoring %s: %v", ent.Name(), err)
+			continue
+		}
+		var session domain.Session
+		if err := json.Unmarshal(data, &session); err != nil {
+			h.log.Error("restoring %s: %v", ent.Name(), err)
+			continue
+		}
+		if err := h.SessionStore.Save(ctx, &session); err != nil {
+			return out, fmt.Errorf("restoring session %s: %w", session.ID, err)
+		}
+		h.log.Info("restored waiting session %s (%s)", session.ID, session.RecipeName)
+		out = append(out, &session)
+	}
+	return out, nil
+}
+
+func (h *Hibernator) path(id string) string {
+	return filepath.Join(h.dir, id+".json")
+}
+
+func (h *Hibernator) remove(id string) {
+	if err := os.Remove(h.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		h.log.Error("removing hibernated session %s: %v", id, err)
+	}
+}
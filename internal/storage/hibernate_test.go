@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+func TestHibernatorRoundTrip(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	dir := t.TempDir()
+	ctx := context.Background()
+	h := NewHibernator(NewMemoryStore(log), dir, log)
+
+	wake := time.Now().Add(2 * time.Hour).Round(time.Second)
+	session := &domain.Session{
+		ID:         "marinade",
+		RecipeName: "Kebabs",
+		Status:     domain.SessionWaiting,
+		WaitUntil:  wake,
+		StepStates: map[int]*domain.StepState{0: {Status: domain.StepActive}},
+		TimerStates: map[string]*domain.TimerState{
+			"t1": {ID: "t1", Label: "Rice", Remaining: time.Minute, Status: domain.TimerPaused, Held: true},
+		},
+	}
+	if err := h.Save(ctx, session); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	file := filepath.Join(dir, "marinade.json")
+	if _, err := os.Stat(file); err != nil {
+		t.Fatalf("waiting session not written to disk: %v", err)
+	}
+
+	// A fresh process restores it.
+	h2 := NewHibernator(NewMemoryStore(log), dir, log)
+	restored, err := h2.Restore(ctx)
+	if err != nil || len(restored) != 1 {
+		t.Fatalf("restore = %d sessions, %v; want 1", len(restored), err)
+	}
+	got, err := h2.Load(ctx, "marinade")
+	if err != nil {
+		t.Fatalf("load restored: %v", err)
+	}
+	if !got.WaitUntil.Equal(wake) || got.StepStates[0].Status != domain.StepActive || got.TimerStates["t1"].Remaining != time.Minute {
+		t.Fatalf("restored session differs: %+v", got)
+	}
+
+	// Waking removes the file.
+	got.Wake(time.Now())
+	if err := h2.Save(ctx, got); err != nil {
+		t.Fatalf("save woken: %v", err)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Fatalf("file still there after waking: %v", err)
+	}
+	if got.TimerStates["t1"].Status != domain.TimerRunning {
+		t.Fatalf("timer not resumed on wake: %s", got.TimerStates["t1"].Status)
+	}
+}
+
+func TestJournalRecover(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	dir := t.TempDir()
+	ctx := context.Background()
+	j := NewJournal(NewMemoryStore(log), dir, log)
+
+	braise := &domain.Session{
+		ID:         "braise",
+		RecipeName: "Short ribs",
+		Status:     domain.SessionActive,
+		StepStates: map[int]*domain.StepState{0: {Status: domain.StepActive}},
+		TimerStates: map[string]*domain.TimerState{
+			"oven": {ID: "oven", Label: "Oven", Remaining: 3 * time.Hour, Status: domain.TimerRunning},
+			"rest": {ID: "rest", Label: "Rest", Remaining: 10 * time.Minute, Status: domain.TimerPaused},
+		},
+	}
+	stew := &domain.Session{ID: "stew", RecipeName: "Stew", Status: domain.SessionActive}
+	for _, s := range []*domain.Session{braise, stew} {
+		if err := j.Save(ctx, s); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+	}
+
+	// Crash before any flush: the log alone brings both back.
+	got, err := NewJournal(NewMemoryStore(log), dir, log).Recover(ctx)
+	if err != nil || len(got) != 2 {
+		t.Fatalf("recover from log = %d sessions, %v; want 2", len(got), err)
+	}
+
+	// Snapshot, then delete one and pause the other in the log, then a
+	// torn final line.
+	if err := j.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if err := j.Delete(ctx, "stew"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	braise.Status = domain.SessionPaused
+	if err := j.Save(ctx, braise); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, journalLog), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	f.WriteString(`{"op":"save","at":"2026-`)
+	f.Close()
+
+	store := NewMemoryStore(log)
+	got, err = NewJournal(store, dir, log).Recover(ctx)
+	if err != nil || len(got) != 1 || got[0].ID != "braise" {
+		t.Fatalf("recover = %v, %v; want just braise", got, err)
+	}
+	s, err := store.Load(ctx, "braise")
+	if err != nil || s.Status != domain.SessionPaused {
+		t.Fatalf("recovered braise = %+v, %v; want paused", s, err)
+	}
+	if oven := s.TimerStates["oven"].Remaining; oven >= 3*time.Hour || oven < 3*time.Hour-time.Minute {
+		t.Errorf("running timer remaining = %v; want a little under 3h", oven)
+	}
+	if rest := s.TimerStates["rest"].Remaining; rest != 10*time.Minute {
+		t.Errorf("paused timer remaining = %v; want 10m untouched", rest)
+	}
+	if _, err := store.Load(ctx, "stew"); err == nil {
+		t.Error("deleted session came back")
+	}
+}
@@ -0,0 +1,305 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// Compile-time interface check.
+var _ domain.SessionStore = (*Journal)(nil)
+
+// ── Journal ──────────────────────────────────────────────────────
+//
+// A power cut halfway through a four-hour braise shouldn't lose the
+// braise.  Every session save is appended to a write-ahead log before it
+// reaches the wrapped store, and the log is synced to disk every couple
+// of seconds.  Once a minute (and on the way out) the live sessions are
+// written to a snapshot and the log starts over, so it never grows past a
+// minute of timer ticks.  Recover reads the snapshot, replays the log on
+// top, and takes the time Otto was down off any timer that was running.
+
+const (
+	journalLog      = "journal.wal"
+	journalSnapshot = "sessions.json"
+)
+
+// JournalOption configures a Journal.
+type JournalOption func(*Journal)
+
+// WithSyncInterval sets how often the log is synced to disk — the most
+// timer state a crash can lose.
+func WithSyncInterval(d time.Duration) JournalOption {
+	return func(j *Journal) { j.syncEvery = d }
+}
+
+// WithCompactInterval sets how often the live sessions are snapshotted
+// and the log started over.
+func WithCompactInterval(d time.Duration) JournalOption {
+	return func(j *Journal) { j.compactEvery = d }
+}
+
+// Journal wraps a SessionStore and writes every change ahead to a log in
+// dir, so sessions survive a crash.
+type Journal struct {
+	domain.SessionStore
+	dir string
+	log *logger.Logger
+
+	syncEvery    time.Duration
+	compactEvery time.Duration
+
+	mu    sync.Mutex
+	file  *os.File // open log, nil until the first write
+	dirty bool     // written since the last sync
+}
+
+// journalRecord is one line of the log.
+type journalRecord struct {
+	Op      string          `json:"op"` // "save" or "delete"
+	At      time.Time       `json:"at"`
+	ID      string          `json:"id"`
+	Session *domain.Session `json:"session,omitempty"`
+}
+
+// journalState is the snapshot file.
+type journalState struct {
+	SavedAt  time.Time         `json:"saved_at"`
+	Sessions []*domain.Session `json:"sessions"`
+}
+
+// NewJournal wraps inner, journaling its sessions into dir.
+func NewJournal(inner domain.SessionStore, dir string, log *logger.Logger, opts ...JournalOption) *Journal {
+	j := &Journal{
+		SessionStore: inner,
+		dir:          dir,
+		log:          log,
+		syncEvery:    2 * time.Second,
+		compactEvery: time.Minute,
+	}
+	for _, o := range opts {
+		o(j)
+	}
+	return j
+}
+
+// Save logs the session, then saves it to the wrapped store.
+func (j *Journal) Save(ctx context.Context, session *domain.Session) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.append(journalRecord{Op: "save", At: time.Now(), ID: session.ID, Session: session}); err != nil {
+		j.log.Error("journaling session %s: %v", session.ID, err)
+	}
+	return j.SessionStore.Save(ctx, session)
+}
+
+// Delete logs the deletion, then deletes from the wrapped store.
+func (j *Journal) Delete(ctx context.Context, id string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.append(journalRecord{Op: "delete", At: time.Now(), ID: id}); err != nil {
+		j.log.Error("journaling delete of %s: %v", id, err)
+	}
+	return j.SessionStore.Delete(ctx, id)
+}
+
+// append writes one record to the log.  The write reaches the OS at once;
+// Run syncs it to disk.  Callers hold j.mu.
+func (j *Journal) append(rec journalRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encoding record: %w", err)
+	}
+	if j.file == nil {
+		if err := os.MkdirAll(j.dir, 0o755); err != nil {
+			return fmt.Errorf("creating journal dir: %w", err)
+		}
+		f, err := os.OpenFile(filepath.Join(j.dir, journalLog), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("opening journal: %w", err)
+		}
+		j.file = f
+	}
+	if _, err := j.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing journal: %w", err)
+	}
+	j.dirty = true
+	return nil
+}
+
+// Run syncs the log and compacts it on their intervals until ctx is
+// cancelled.  Close does the final flush.
+func (j *Journal) Run(ctx context.Context) {
+	syncTick := time.NewTicker(j.syncEvery)
+	defer syncTick.Stop()
+	compactTick := time.NewTicker(j.compactEvery)
+	defer compactTick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-syncTick.C:
+			j.sync()
+		case <-compactTick.C:
+			if err := j.Flush(ctx); err != nil {
+				j.log.Error("compacting journal: %v", err)
+			}
+		}
+	}
+}
+
+func (j *Journal) sync() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil || !j.dirty {
+		return
+	}
+	if err := j.file.Sync(); err != nil {
+		j.log.Error("syncing journal: %v", err)
+		return
+	}
+	j.dirty = false
+}
+
+// Flush snapshots every live session and starts the log over.
+func (j *Journal) Flush(ctx context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	sessions, err := j.SessionStore.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+	data, err := json.MarshalIndent(journalState{SavedAt: time.Now(), Sessions: sessions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+	if err := os.MkdirAll(j.dir, 0o755); err != nil {
+		return fmt.Errorf("creating journal dir: %w", err)
+	}
+	// Write, sync, then rename so a crash leaves either snapshot whole.
+	path := filepath.Join(j.dir, journalSnapshot)
+	tmp, err := os.Create(path + ".tmp")
+	if err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+
+	// Everything in the log is in the snapshot now.
+	if j.file != nil {
+		j.file.Close()
+		j.file = nil
+	}
+	if err := os.Remove(filepath.Join(j.dir, journalLog)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("truncating journal: %w", err)
+	}
+	j.dirty = false
+	j.log.Debug("journal compacted, %d sessions", len(sessions))
+	return nil
+}
+
+// Close writes a final snapshot and closes the log.
+func (j *Journal) Close() error {
+	return j.Flush(context.Background())
+}
+
+// Recover loads the snapshot and replays the log into the wrapped store,
+// returning the sessions that were live, most recently touched first.
+// Timers that were running lose the time since they were last saved.  A
+// half-written last line — the crash itself — is skipped.
+func (j *Journal) Recover(ctx context.Context) ([]*domain.Session, error) {
+	live := map[string]*domain.Session{}
+	savedAt := map[string]time.Time{}
+
+	data, err := os.ReadFile(filepath.Join(j.dir, journalSnapshot))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("reading snapshot: %w", err)
+	default:
+		var state journalState
+		if err := json.Unmarshal(data, &state); err != nil {
+			j.log.Error("reading journal snapshot: %v", err)
+		}
+		for _, s := range state.Sessions {
+			live[s.ID], savedAt[s.ID] = s, state.SavedAt
+		}
+	}
+
+	data, err = os.ReadFile(filepath.Join(j.dir, journalLog))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading journal: %w", err)
+	}
+	replayed := 0
+	lines := bufio.NewScanner(bytes.NewReader(data))
+	lines.Buffer(nil, 16<<20)
+	for lines.Scan() {
+		var rec journalRecord
+		if err := json.Unmarshal(lines.Bytes(), &rec); err != nil {
+			j.log.Debug("journal: skipping unreadable record: %v", err)
+			continue
+		}
+		replayed++
+		if rec.Op == "delete" || rec.Session == nil {
+			delete(live, rec.ID)
+			continue
+		}
+		live[rec.ID], savedAt[rec.ID] = rec.Session, rec.At
+	}
+
+	var out []*domain.Session
+	for id, s := range live {
+		if s.Status != domain.SessionActive && s.Status != domain.SessionPaused && s.Status != domain.SessionWaiting {
+			continue
+		}
+		catchUp(s, time.Since(savedAt[id]))
+		if err := j.SessionStore.Save(ctx, s); err != nil {
+			return out, fmt.Errorf("recovering session %s: %w", id, err)
+		}
+		j.log.Info("recovered session %s (%s)", id, s.RecipeName)
+		out = append(out, s)
+	}
+	// Most recently touched first.
+	slices.SortFunc(out, func(a, b *domain.Session) int { return b.UpdatedAt.Compare(a.UpdatedAt) })
+	j.log.Debug("journal: %d sessions recovered, %d records replayed", len(out), replayed)
+	return out, nil
+}
+
+// catchUp takes the time Otto was down off every running timer.
+func catchUp(s *domain.Session, down time.Duration) {
+	if down <= 0 {
+		return
+	}
+	for _, ts := range s.TimerStates {
+		if ts.Status == domain.TimerRunning {
+			ts.Remaining = max(0, ts.Remaining-down)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"sync"
 
 	"github.com/hammamikhairi/ottocook/internal/domain"
@@ -12,7 +14,9 @@ import (
 // Compile-time interface check.
 var _ domain.SessionStore = (*MemoryStore)(nil)
 
-// MemoryStore is an in-memory session store. Safe for concurrent access.
+// MemoryStore is an in-memory session store. Safe for concurrent access:
+// it keeps its own copy of every session and hands out copies, so the
+// engine and the timer supervisor never share a *domain.Session.
 type MemoryStore struct {
 	mu       sync.RWMutex
 	sessions map[string]*domain.Session
@@ -32,8 +36,12 @@ func (s *MemoryStore) Save(ctx context.Context, session *domain.Session) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	own, err := copySession(session)
+	if err != nil {
+		return err
+	}
 	s.log.Debug("saving session %s (recipe=%s, status=%s)", session.ID, session.RecipeID, session.Status)
-	s.sessions[session.ID] = session
+	s.sessions[session.ID] = own
 	return nil
 }
 
@@ -47,7 +55,7 @@ func (s *MemoryStore) Load(ctx context.Context, id string) (*domain.Session, err
 		s.log.Debug("session not found: %s", id)
 		return nil, domain.ErrNotFound
 	}
-	return sess, nil
+	return copySession(sess)
 }
 
 // Delete removes a session by ID.
@@ -63,17 +71,35 @@ func (s *MemoryStore) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// ListActive returns all sessions with active or paused status.
+// ListActive returns all sessions with active, paused, or waiting status.
 func (s *MemoryStore) ListActive(ctx context.Context) ([]*domain.Session, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	var out []*domain.Session
 	for _, sess := range s.sessions {
-		if sess.Status == domain.SessionActive || sess.Status == domain.SessionPaused {
-			out = append(out, sess)
+		if sess.Status == domain.SessionActive || sess.Status == domain.SessionPaused || sess.Status == domain.SessionWaiting {
+			c, err := copySession(sess)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, c)
 		}
 	}
 	s.log.Debug("listing active sessions, count=%d", len(out))
 	return out, nil
 }
+
+// copySession deep-copies a session by round-tripping it through JSON,
+// the format it's journaled in.
+func copySession(session *domain.Session) (*domain.Session, error) {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return nil, fmt.Errorf("copying session %s: %w", session.ID, err)
+	}
+	var out domain.Session
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("copying session %s: %w", session.ID, err)
+	}
+	return &out, nil
+}
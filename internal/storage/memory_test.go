@@ -80,6 +80,7 @@ func TestMemoryStoreListActiveFilters(t *testing.T) {
 		{ID: "s2", Status: domain.SessionPaused, StepStates: map[int]*domain.StepState{}, TimerStates: map[string]*domain.TimerState{}},
 		{ID: "s3", Status: domain.SessionCompleted, StepStates: map[int]*domain.StepState{}, TimerStates: map[string]*domain.TimerState{}},
 		{ID: "s4", Status: domain.SessionAbandoned, StepStates: map[int]*domain.StepState{}, TimerStates: map[string]*domain.TimerState{}},
+		{ID: "s5", Status: domain.SessionWaiting, StepStates: map[int]*domain.StepState{}, TimerStates: map[string]*domain.TimerState{}},
 	}
 
 	for _, s := range sessions {
@@ -92,7 +93,7 @@ func TestMemoryStoreListActiveFilters(t *testing.T) {
 	if err != nil {
 		t.Fatalf("list active: %v", err)
 	}
-	if len(active) != 2 {
-		t.Fatalf("expected 2 active/paused sessions, got %d", len(active))
+	if len(active) != 3 {
+		t.Fatalf("expected 3 active/paused/waiting sessions, got %d", len(active))
 	}
 }
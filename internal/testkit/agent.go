@@ -0,0 +1,170 @@
+package testkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/gpt"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// Request kinds, by the response schema the agent asks for.
+const (
+	KindQuestion     = "" // free-form answer, no schema
+	KindClassify     = "classify"
+	KindModify       = "modify"
+	KindReplan       = "replan"
+	KindDismissTimer = "dismiss_timer"
+)
+
+// AgentCall is one request the agent made.
+type AgentCall struct {
+	Kind  string
+	Input string // the last user message
+}
+
+// FakeAgent answers the gpt.Agent's chat-completion requests from
+// scripted replies, so the real agent code — prompts, schema checks,
+// fallbacks — runs against a known conversation.  A request with no
+// reply queued for its kind gets a 500, as if the endpoint were down.
+type FakeAgent struct {
+	mu      sync.Mutex
+	replies map[string][]string
+	calls   []AgentCall
+	held    chan struct{} // closed by Release; nil unless held
+}
+
+// NewFakeAgent returns a FakeAgent with nothing scripted.
+func NewFakeAgent() *FakeAgent {
+	return &FakeAgent{replies: map[string][]string{}}
+}
+
+// Reply queues a reply for the next request of kind.  JSON kinds need a
+// reply that passes the agent's schema, or it will re-ask.
+func (f *FakeAgent) Reply(kind, reply string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.replies[kind] = append(f.replies[kind], reply)
+}
+
+// Calls returns the requests made so far.
+func (f *FakeAgent) Calls() []AgentCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]AgentCall(nil), f.calls...)
+}
+
+// Hold keeps requests waiting, as a slow endpoint would, until Release
+// or until the agent gives up on them.
+func (f *FakeAgent) Hold() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.held == nil {
+		f.held = make(chan struct{})
+	}
+}
+
+// Release lets held requests, and the ones after, through.
+func (f *FakeAgent) Release() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.held != nil {
+		close(f.held)
+		f.held = nil
+	}
+}
+
+// WaitCalls waits up to timeout for n requests to have been made.
+func (f *FakeAgent) WaitCalls(n int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for len(f.Calls()) < n {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return true
+}
+
+// Agent returns a gpt.Agent whose requests this FakeAgent answers.
+func (f *FakeAgent) Agent(log *logger.Logger, opts ...gpt.AgentOption) *gpt.Agent {
+	client := gpt.NewClient("http://fake.invalid/chat/completions", "", log,
+		gpt.WithHTTPClient(&http.Client{Transport: f}))
+	return gpt.NewAgent(client, log, opts...)
+}
+
+// fakeRequest is the part of a chat-completion request FakeAgent reads.
+type fakeRequest struct {
+	Messages       []gpt.Message `json:"messages"`
+	ResponseFormat *struct {
+		JSONSchema *struct {
+			Name string `json:"name"`
+		} `json:"json_schema"`
+	} `json:"response_format"`
+}
+
+// RoundTrip implements http.RoundTripper.
+func (f *FakeAgent) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body fakeRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("fake agent: decoding request: %w", err)
+	}
+	req.Body.Close()
+
+	call := AgentCall{}
+	if rf := body.ResponseFormat; rf != nil && rf.JSONSchema != nil {
+		call.Kind = rf.JSONSchema.Name
+	}
+	for i := len(body.Messages) - 1; i >= 0 && call.Input == ""; i-- {
+		if m := body.Messages[i]; m.Role == gpt.RoleUser && len(m.Content) > 0 {
+			call.Input = m.Content[0].Text
+		}
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	held := f.held
+	f.mu.Unlock()
+	if held != nil {
+		select {
+		case <-held:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	f.mu.Lock()
+	queue := f.replies[call.Kind]
+	var reply string
+	ok := len(queue) > 0
+	if ok {
+		reply, f.replies[call.Kind] = queue[0], queue[1:]
+	}
+	f.mu.Unlock()
+
+	if !ok {
+		return response(req, http.StatusInternalServerError, fmt.Sprintf("no scripted %q reply", call.Kind)), nil
+	}
+	envelope, err := json.Marshal(map[string]any{
+		"choices": []any{map[string]any{"message": map[string]string{"role": gpt.RoleAssistant, "content": reply}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response(req, http.StatusOK, string(envelope)), nil
+}
+
+func response(req *http.Request, code int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: code,
+		Status:     fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+		Request:    req,
+	}
+}
@@ -0,0 +1,59 @@
+package testkit
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Screen collects what the UI prints when it runs without a terminal
+// (see display.UI.SetOutput), one line per entry with styling removed.
+type Screen struct {
+	lines
+	mu      sync.Mutex
+	partial bytes.Buffer
+	read    int // lines already matched by Expect
+}
+
+// NewScreen returns an empty screen.
+func NewScreen() *Screen { return &Screen{} }
+
+// Write implements io.Writer.
+func (s *Screen) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.partial.Write(p)
+	for {
+		line, err := s.partial.ReadString('\n')
+		if err != nil {
+			// No newline yet; keep it for the next write.
+			s.partial.Reset()
+			s.partial.WriteString(line)
+			return len(p), nil
+		}
+		s.add(strings.TrimSpace(ansi.Strip(line)))
+	}
+}
+
+// Lines returns everything printed so far.
+func (s *Screen) Lines() []string { return s.snapshot() }
+
+// Expect waits up to timeout for a line containing substr, printed after
+// whatever the last successful Expect matched, and returns it.
+func (s *Screen) Expect(substr string, timeout time.Duration) (string, bool) {
+	s.mu.Lock()
+	from := s.read
+	s.mu.Unlock()
+
+	line, next, ok := s.waitFor(from, substr, timeout)
+	if ok {
+		s.mu.Lock()
+		s.read = next
+		s.mu.Unlock()
+	}
+	return line, ok
+}
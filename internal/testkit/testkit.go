@@ -0,0 +1,81 @@
+// Package testkit provides deterministic stand-ins for Otto's outside
+// world — text-to-speech, the microphone, and the AI endpoint — plus a
+// Screen that captures what the UI prints, so tests can drive a whole
+// cooking session without audio, a terminal, or network access.
+package testkit
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// pollInterval is how often WaitFor looks for a new line.
+const pollInterval = 5 * time.Millisecond
+
+// lines is an append-only, concurrency-safe list of strings.
+type lines struct {
+	mu  sync.Mutex
+	all []string
+}
+
+func (l *lines) add(s string) {
+	l.mu.Lock()
+	l.all = append(l.all, s)
+	l.mu.Unlock()
+}
+
+func (l *lines) snapshot() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.all...)
+}
+
+// waitFor returns the first line at or after index from containing
+// substr, waiting up to timeout for one to arrive.  The returned index
+// is just past the match, for the next search.
+func (l *lines) waitFor(from int, substr string, timeout time.Duration) (string, int, bool) {
+	deadline := time.Now().Add(timeout)
+	for {
+		all := l.snapshot()
+		for i := from; i < len(all); i++ {
+			if strings.Contains(all[i], substr) {
+				return all[i], i + 1, true
+			}
+		}
+		if time.Now().After(deadline) {
+			return "", len(all), false
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// FakeClock is a domain.Clock that only moves when told to.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a clock stopped at now.
+func NewFakeClock(now time.Time) *FakeClock { return &FakeClock{now: now} }
+
+// Now implements domain.Clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// Set moves the clock to t.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.now = t
+	c.mu.Unlock()
+}
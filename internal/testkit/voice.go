@@ -0,0 +1,71 @@
+package testkit
+
+import (
+	"context"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/speech"
+)
+
+// Compile-time interface checks.
+var (
+	_ speech.Synthesizer = (*FakeTTS)(nil)
+	_ speech.AudioPlayer = (*FakeTTS)(nil)
+)
+
+// FakeTTS stands in for both the TTS service and the audio player: the
+// "audio" it synthesizes is the text itself, and playing it records the
+// text as spoken.  Pass it as both arguments to speech.NewMouth.
+type FakeTTS struct {
+	spoken lines
+}
+
+// NewFakeTTS returns a FakeTTS that has said nothing yet.
+func NewFakeTTS() *FakeTTS { return &FakeTTS{} }
+
+// Voice implements speech.Synthesizer.
+func (f *FakeTTS) Voice() string { return "fake" }
+
+// SynthesizeAt implements speech.Synthesizer.
+func (f *FakeTTS) SynthesizeAt(ctx context.Context, text string, priority speech.Priority) ([]byte, error) {
+	return []byte(text), nil
+}
+
+// Play implements speech.AudioPlayer.
+func (f *FakeTTS) Play(audio []byte) error {
+	f.spoken.add(string(audio))
+	return nil
+}
+
+// Stop implements speech.AudioPlayer.
+func (f *FakeTTS) Stop() {}
+
+// Spoken returns everything played so far, in order.
+func (f *FakeTTS) Spoken() []string { return f.spoken.snapshot() }
+
+// WaitSpoken waits up to timeout for something containing substr to be
+// played, and returns it.
+func (f *FakeTTS) WaitSpoken(substr string, timeout time.Duration) (string, bool) {
+	line, _, ok := f.spoken.waitFor(0, substr, timeout)
+	return line, ok
+}
+
+// FakeEar stands in for speech.Ear: whatever a test Hears comes out of C
+// as if whisper had transcribed it.
+type FakeEar struct {
+	ch chan speech.Utterance
+}
+
+// NewFakeEar returns a FakeEar that has heard nothing.
+func NewFakeEar() *FakeEar {
+	return &FakeEar{ch: make(chan speech.Utterance, 16)}
+}
+
+// C returns heard utterances, like speech.Ear.C.
+func (f *FakeEar) C() <-chan speech.Utterance { return f.ch }
+
+// Hear delivers text as an utterance heard with the given confidence
+// (-1 when unmeasured).
+func (f *FakeEar) Hear(text string, confidence float64) {
+	f.ch <- speech.Utterance{Text: text, Confidence: confidence}
+}
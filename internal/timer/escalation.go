@@ -0,0 +1,261 @@
+package timer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ── Escalation ───────────────────────────────────────────────────
+//
+// A fired timer nags until it's dismissed: one alert when it goes off,
+// then reminders on a cooldown, each a little more insistent, until the
+// ladder runs out.  The ladder is configurable — how many rungs, what
+// each one says and whether it interrupts, how the wait between them
+// grows, and whether the last one also goes out through an external
+// notifier (a phone push, a desktop notification).  A config has a
+// default ladder and rules that pick a different one by timer label, so
+// "rice" can stay quiet while "caramel" shouts.
+//
+//	{
+//	  "default": {"interval": "15s", "growth": 1.5, "external": true},
+//	  "rules": [
+//	    {"match": "caramel|sugar", "interval": "5s", "levels": [
+//	      {"message": "[Timer] {{.Label}} is up.", "urgent": true},
+//	      {"message": "[Timer] {{.Label}} -- it burns fast, {{.Since}} already.", "urgent": true}
+//	    ]}
+//	  ]
+//	}
+//
+// Messages are text/templates over {{.Label}} and {{.Since}} (how long
+// ago the timer went off, e.g. "2 minutes").  Anything left out of a
+// ladder falls back to the built-in one.
+
+// EnvEscalationFile overrides where the escalation config is looked for.
+const EnvEscalationFile = "OTTOCOOK_ESCALATION"
+
+// Duration is a time.Duration written as "15s" or "2m" in JSON.
+type Duration time.Duration
+
+// UnmarshalJSON parses a Go duration string.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"15s\": %w", err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalJSON writes the duration as a Go duration string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// EscalationLevel is one rung of the ladder.
+type EscalationLevel struct {
+	Message string `json:"message"` // text/template over .Label and .Since
+	Urgent  bool   `json:"urgent"`  // interrupt whatever is being said
+
+	tmpl *template.Template
+}
+
+// Escalation is how a fired timer nags: the first level is the alert
+// when it goes off, each later one a reminder.
+type Escalation struct {
+	Levels   []EscalationLevel `json:"levels,omitempty"`
+	Interval Duration          `json:"interval,omitempty"` // wait before the first reminder; 0 uses the supervisor's cooldown
+	Growth   float64           `json:"growth,omitempty"`   // each wait is the last one times this; 0 or 1 keeps them even
+	External bool              `json:"external,omitempty"` // the last level also goes to the external notifier
+}
+
+// EscalationRule applies its ladder to timers whose label matches.
+type EscalationRule struct {
+	Match string `json:"match"` // case-insensitive regexp on the timer label
+	Escalation
+
+	re *regexp.Regexp
+}
+
+// EscalationConfig is the default ladder and the per-label rules.  The
+// first matching rule wins.
+type EscalationConfig struct {
+	Default Escalation       `json:"default"`
+	Rules   []EscalationRule `json:"rules,omitempty"`
+}
+
+// escalationData is what level messages are executed with.
+type escalationData struct {
+	Label string
+	Since string
+}
+
+// DefaultEscalation is the built-in ladder: the alert, then three
+// reminders on the supervisor's cooldown.
+func DefaultEscalation() Escalation {
+	e := Escalation{
+		Levels: []EscalationLevel{
+			{Message: "[Timer] {{.Label}} is up.", Urgent: true},
+			{Message: "[Timer] {{.Label}} -- check it now."},
+			{Message: "[Timer] {{.Label}}. Now."},
+			{Message: "[Timer] {{.Label}}."},
+		},
+		Growth: 1,
+	}
+	if err := e.compile(); err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// DefaultEscalationConfig is the built-in ladder with no rules.
+func DefaultEscalationConfig() EscalationConfig {
+	return EscalationConfig{Default: DefaultEscalation()}
+}
+
+// DefaultEscalationFile returns where the escalation config is looked
+// for: $OTTOCOOK_ESCALATION, else <user config dir>/ottocook/escalation.json.
+func DefaultEscalationFile() string {
+	if path := os.Getenv(EnvEscalationFile); path != "" {
+		return path
+	}
+	if cfg, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(cfg, "ottocook", "escalation.json")
+	}
+	return ""
+}
+
+// ErrEscalationUnreadable wraps the error when the escalation file
+// can't be read or parsed at all, as opposed to a bad rule in it.
+var ErrEscalationUnreadable = errors.New("escalation config unreadable")
+
+// LoadEscalation reads the escalation config at path.  A missing file
+// (or an empty path) means the built-in ladder.  A config that can't be
+// read or fails to parse is reported, wrapping ErrEscalationUnreadable,
+// and the built-in ladder used instead; a rule with a bad pattern or
+// template is dropped and reported, and the rest load.
+func LoadEscalation(path string) (EscalationConfig, error) {
+	if path == "" {
+		return DefaultEscalationConfig(), nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultEscalationConfig(), nil
+	}
+	if err != nil {
+		return DefaultEscalationConfig(), fmt.Errorf("%w: %w", ErrEscalationUnreadable, err)
+	}
+	var cfg EscalationConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return DefaultEscalationConfig(), fmt.Errorf("%s: %w: %w", path, ErrEscalationUnreadable, err)
+	}
+
+	var errs []error
+	cfg.Default.fill(DefaultEscalation())
+	if err := cfg.Default.compile(); err != nil {
+		errs = append(errs, fmt.Errorf("%s: default: %w", path, err))
+		cfg.Default = DefaultEscalation()
+	}
+	rules := cfg.Rules[:0]
+	for _, r := range cfg.Rules {
+		re, err := regexp.Compile("(?i)" + r.Match)
+		if err == nil {
+			r.re = re
+			r.fill(cfg.Default)
+			err = r.compile()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: rule %q: %w", path, r.Match, err))
+			continue
+		}
+		rules = append(rules, r)
+	}
+	cfg.Rules = rules
+	return cfg, errors.Join(errs...)
+}
+
+// For returns the ladder for a timer label.
+func (c EscalationConfig) For(label string) Escalation {
+	for _, r := range c.Rules {
+		if r.re != nil && r.re.MatchString(label) {
+			return r.Escalation
+		}
+	}
+	if len(c.Default.Levels) == 0 {
+		return DefaultEscalation()
+	}
+	return c.Default
+}
+
+// fill takes anything e leaves out from def.
+func (e *Escalation) fill(def Escalation) {
+	if len(e.Levels) == 0 {
+		e.Levels = def.Levels
+	}
+	if e.Interval == 0 {
+		e.Interval = def.Interval
+	}
+	if e.Growth == 0 {
+		e.Growth = def.Growth
+	}
+}
+
+// compile parses every level's message, so bad templates surface at load
+// rather than when a timer goes off.
+func (e *Escalation) compile() error {
+	levels := make([]EscalationLevel, len(e.Levels))
+	for i, l := range e.Levels {
+		t, err := template.New(fmt.Sprintf("level %d", i+1)).Option("missingkey=error").Parse(l.Message)
+		if err == nil {
+			err = t.Execute(new(strings.Builder), escalationData{Label: "timer", Since: "1 minute"})
+		}
+		if err != nil {
+			return err
+		}
+		l.tmpl = t
+		levels[i] = l
+	}
+	e.Levels = levels
+	return nil
+}
+
+// wait is how long after the previous alert level n (1 for the first
+// reminder) is due, given the supervisor's cooldown as the fallback.
+func (e Escalation) wait(n int, cooldown time.Duration) time.Duration {
+	base := time.Duration(e.Interval)
+	if base <= 0 {
+		base = cooldown
+	}
+	if e.Growth <= 0 || e.Growth == 1 || n <= 1 {
+		return base
+	}
+	return time.Duration(float64(base) * math.Pow(e.Growth, float64(n-1)))
+}
+
+// message renders level n for a timer.  Past the end of the ladder the
+// last level repeats.
+func (e Escalation) message(n int, label string, since time.Duration) (string, bool) {
+	if len(e.Levels) == 0 {
+		return fmt.Sprintf("[Timer] %s is up.", label), true
+	}
+	l := e.Levels[min(n, len(e.Levels)-1)]
+	if l.tmpl == nil {
+		return l.Message, l.Urgent
+	}
+	var b strings.Builder
+	if err := l.tmpl.Execute(&b, escalationData{Label: label, Since: formatRemaining(since)}); err != nil {
+		return fmt.Sprintf("[Timer] %s is up.", label), l.Urgent
+	}
+	return b.String(), l.Urgent
+}
@@ -0,0 +1,119 @@
+package timer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/storage"
+)
+
+func TestLoadEscalation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "escalation.json")
+	cfg, err := LoadEscalation(path)
+	if err != nil || len(cfg.For("Pasta").Levels) != 4 {
+		t.Fatalf("missing file: %d levels, err %v; want the built-in 4", len(cfg.For("Pasta").Levels), err)
+	}
+
+	os.WriteFile(path, []byte(`{
+		"default": {"interval": "1m"},
+		"rules": [
+			{"match": "caramel", "external": true, "levels": [
+				{"message": "{{.Label}} is up", "urgent": true},
+				{"message": "{{.Label}}, {{.Since}} ago"}
+			]},
+			{"match": "(unclosed"},
+			{"match": "rice", "levels": [{"message": "{{.Nope}}"}]}
+		]
+	}`), 0o644)
+	cfg, err = LoadEscalation(path)
+	if err == nil {
+		t.Fatal("want the bad pattern and template reported")
+	}
+	if len(cfg.Rules) != 1 {
+		t.Fatalf("got %d rules, want the good one kept", len(cfg.Rules))
+	}
+
+	esc := cfg.For("Salted Caramel")
+	if !esc.External || len(esc.Levels) != 2 || time.Duration(esc.Interval) != time.Minute {
+		t.Fatalf("caramel ladder = %+v", esc)
+	}
+	if msg, urgent := esc.message(1, "Caramel", 2*time.Minute); msg != "Caramel, 2 minutes ago" || urgent {
+		t.Fatalf("level 2 = %q urgent=%v", msg, urgent)
+	}
+	if msg, _ := cfg.For("Rice").message(0, "Rice", 0); msg != "[Timer] Rice is up." {
+		t.Fatalf("rice fell back to %q, want the default ladder", msg)
+	}
+
+	os.WriteFile(path, []byte(`{"default": {"interval": `), 0o644)
+	if cfg, err = LoadEscalation(path); !errors.Is(err, ErrEscalationUnreadable) || len(cfg.For("Pasta").Levels) != 4 {
+		t.Fatalf("half a file: err %v; want ErrEscalationUnreadable and the built-in ladder", err)
+	}
+}
+
+func TestSupervisorFollowsLadder(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	store := storage.NewMemoryStore(log)
+	notifier, external := &mockNotifier{}, &mockNotifier{}
+	ctx := context.Background()
+
+	session := &domain.Session{
+		ID:         "ladder-test",
+		RecipeID:   "test",
+		RecipeName: "Test",
+		Status:     domain.SessionActive,
+		StepStates: map[int]*domain.StepState{0: {Status: domain.StepActive}},
+		TimerStates: map[string]*domain.TimerState{
+			"t1": {ID: "t1", Label: "Caramel", Duration: time.Second, Remaining: 10 * time.Millisecond, Status: domain.TimerRunning},
+		},
+		StartedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	esc := Escalation{
+		Levels: []EscalationLevel{
+			{Message: "{{.Label}} is up", Urgent: true},
+			{Message: "{{.Label}} again"},
+			{Message: "{{.Label}}!", Urgent: true},
+		},
+		Interval: Duration(30 * time.Millisecond),
+		External: true,
+	}
+	if err := esc.compile(); err != nil {
+		t.Fatal(err)
+	}
+	sup := New(store, notifier, log,
+		WithTickInterval(10*time.Millisecond),
+		WithEscalation(EscalationConfig{Default: DefaultEscalation(), Rules: []EscalationRule{{Match: "caramel", Escalation: esc, re: regexp.MustCompile("(?i)caramel")}}}),
+		WithExternalNotifier(external),
+	)
+	sup.Start(ctx)
+	defer sup.Stop()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for external.urgentCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond) // nothing past the last level
+
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	if !slices.Equal(notifier.urgent, []string{"Caramel is up", "Caramel!"}) || !slices.Equal(notifier.messages, []string{"Caramel again"}) {
+		t.Fatalf("urgent %q, normal %q", notifier.urgent, notifier.messages)
+	}
+	external.mu.Lock()
+	defer external.mu.Unlock()
+	if len(external.urgent) != 1 || external.urgent[0] != "Caramel!" {
+		t.Fatalf("external got %q, want only the last level", external.urgent)
+	}
+}
@@ -0,0 +1,95 @@
+package timer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+	"github.com/hammamikhairi/ottocook/internal/offline"
+)
+
+// ── Leftovers ────────────────────────────────────────────────────
+//
+// Once a session with meat, fish, eggs or rice in it finishes, the food
+// is on the table and the clock on it has started: cooked perishables
+// shouldn't sit out more than two hours (rice, one).  The watcher notices
+// a session finishing, says when there's half an hour left to get the
+// leftovers in the fridge, and again when the time's up.  Only while
+// Otto is running — nothing is kept across a restart.
+
+// leftoversNotice is how long before the limit the first reminder comes.
+const leftoversNotice = 30 * time.Minute
+
+// servedLeftovers is a finished session whose food is sitting out.
+type servedLeftovers struct {
+	recipe string
+	food   offline.SafeTemp
+	done   time.Time
+	warned int // reminders given: 1 for the heads-up, 2 when time's up
+}
+
+// checkLeftovers picks up sessions that have finished since the last
+// check and reminds about food that's been out a while.
+func (w *Watcher) checkLeftovers(ctx context.Context, active []*domain.Session) {
+	now := w.clock.Now()
+	current := make(map[string]bool, len(active))
+	for _, s := range active {
+		current[s.ID] = true
+	}
+	for id := range w.active {
+		if !current[id] {
+			w.startLeftovers(ctx, id)
+		}
+	}
+	w.active = current
+
+	for id, l := range w.served {
+		msg := l.message(now)
+		if msg == "" {
+			continue
+		}
+		if l.warned == 2 {
+			delete(w.served, id)
+		}
+		if err := w.notifier.Notify(ctx, msg); err != nil {
+			w.log.Error("watcher: notify: %v", err)
+		}
+	}
+}
+
+// startLeftovers starts the clock on a session that has left the active
+// list, if it finished (rather than being abandoned) with perishable
+// food in it.
+func (w *Watcher) startLeftovers(ctx context.Context, id string) {
+	session, err := w.store.Load(ctx, id)
+	if err != nil || session.Status != domain.SessionCompleted {
+		return
+	}
+	recipe, err := w.recipes.Get(ctx, session.RecipeID)
+	if err != nil {
+		return
+	}
+	food, ok := offline.Perishable(recipe)
+	if !ok {
+		return
+	}
+	w.log.Debug("watcher: %s finished with %s in it, watching for %s", session.RecipeName, food.Food, food.MaxOut)
+	w.served[id] = &servedLeftovers{recipe: session.RecipeName, food: food, done: session.UpdatedAt}
+}
+
+// message is the reminder due at now, if any, and marks it given.
+func (l *servedLeftovers) message(now time.Time) string {
+	out := now.Sub(l.done)
+	switch {
+	case l.warned < 2 && out >= l.food.MaxOut:
+		l.warned = 2
+		return fmt.Sprintf("[Watcher] The %s from %s has been out %s. Into the fridge now, or throw it out.",
+			l.food.Food, l.recipe, formatRemaining(out))
+	case l.warned < 1 && out >= l.food.MaxOut-leftoversNotice:
+		l.warned = 1
+		return fmt.Sprintf("[Watcher] %s finished %s ago. Get the leftover %s into the fridge within %s.",
+			l.recipe, formatRemaining(out), l.food.Food, formatRemaining(l.food.MaxOut-out))
+	}
+	return ""
+}
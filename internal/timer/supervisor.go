@@ -22,6 +22,17 @@ func WithTickInterval(d time.Duration) Option {
 	}
 }
 
+// WithSpeed makes timers run factor times faster than the wall clock —
+// 20 turns an 8-minute boil into 24 seconds.  For demos; factors of 1 or
+// less are ignored.
+func WithSpeed(factor float64) Option {
+	return func(s *Supervisor) {
+		if factor > 1 {
+			s.speed = factor
+		}
+	}
+}
+
 // WithNotifyCooldown sets the minimum time between repeated notifications.
 func WithNotifyCooldown(d time.Duration) Option {
 	return func(s *Supervisor) {
@@ -29,13 +40,29 @@ func WithNotifyCooldown(d time.Duration) Option {
 	}
 }
 
-// WithMaxEscalation sets the escalation level after which the supervisor stops nagging.
+// WithMaxEscalation caps how many reminders follow a timer's first
+// alert, however long its escalation ladder is.  0 leaves it to the ladder.
 func WithMaxEscalation(level int) Option {
 	return func(s *Supervisor) {
 		s.maxEscalation = level
 	}
 }
 
+// WithEscalation sets the escalation ladders fired timers follow.
+func WithEscalation(cfg EscalationConfig) Option {
+	return func(s *Supervisor) {
+		s.escalation = cfg
+	}
+}
+
+// WithExternalNotifier sets where the last level of a ladder marked
+// external is also sent, for the cook who has walked away.
+func WithExternalNotifier(n domain.Notifier) Option {
+	return func(s *Supervisor) {
+		s.external = n
+	}
+}
+
 // WithReminderInterval sets how often running timers send periodic reminders.
 func WithReminderInterval(d time.Duration) Option {
 	return func(s *Supervisor) {
@@ -51,6 +78,23 @@ func WithAlmostDoneThreshold(d time.Duration) Option {
 	}
 }
 
+// WithClock sets the clock the supervisor (and its watcher) reads, for
+// reminders, cooldowns, and waking hands-off waits.
+func WithClock(c domain.Clock) Option {
+	return func(s *Supervisor) {
+		s.clock = c
+	}
+}
+
+// WithSessionLock sets the lock held while a session is loaded, changed
+// and saved; pass the engine's, so a tick and a command never each
+// overwrite the other's change.
+func WithSessionLock(l sync.Locker) Option {
+	return func(s *Supervisor) {
+		s.sessionLock = l
+	}
+}
+
 // WithWatcher enables the session watcher with the given recipe source and options.
 func WithWatcher(recipes domain.RecipeSource, opts ...WatcherOption) Option {
 	return func(s *Supervisor) {
@@ -64,20 +108,25 @@ func WithWatcher(recipes domain.RecipeSource, opts ...WatcherOption) Option {
 type Supervisor struct {
 	store               domain.SessionStore
 	notifier            domain.Notifier
+	external            domain.Notifier // last-level alerts, nil for none
 	log                 *logger.Logger
+	clock               domain.Clock
 	tickInterval        time.Duration
+	speed               float64 // timer time per wall-clock time
 	notifyCooldown      time.Duration
 	maxEscalation       int
-	reminderInterval    time.Duration // periodic "X remaining" reminders
 	almostDoneThreshold time.Duration // "almost done" warning threshold
+	sessionLock         sync.Locker   // held from load to save
 
 	watcherRecipes domain.RecipeSource
 	watcherOpts    []WatcherOption
 	watcher        *Watcher
 
-	mu      sync.Mutex
-	running bool
-	cancel  context.CancelFunc
+	mu               sync.Mutex
+	running          bool
+	cancel           context.CancelFunc
+	escalation       EscalationConfig
+	reminderInterval time.Duration // periodic "X remaining" reminders
 }
 
 // New creates a timer supervisor with the given dependencies and options.
@@ -86,11 +135,14 @@ func New(store domain.SessionStore, notifier domain.Notifier, log *logger.Logger
 		store:               store,
 		notifier:            notifier,
 		log:                 log,
+		clock:               domain.SystemClock{},
 		tickInterval:        1 * time.Second,
+		speed:               1,
 		notifyCooldown:      15 * time.Second,
-		maxEscalation:       3,
+		escalation:          DefaultEscalationConfig(),
 		reminderInterval:    2 * time.Minute,
 		almostDoneThreshold: 30 * time.Second,
+		sessionLock:         &sync.Mutex{},
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -116,11 +168,42 @@ func (s *Supervisor) Start(ctx context.Context) {
 
 	// Start watcher if configured.
 	if s.watcherRecipes != nil {
-		s.watcher = NewWatcher(s.store, s.watcherRecipes, s.notifier, s.log, s.watcherOpts...)
+		opts := append([]WatcherOption{WithWatcherClock(s.clock), WithWatcherSessionLock(s.sessionLock)}, s.watcherOpts...)
+		s.watcher = NewWatcher(s.store, s.watcherRecipes, s.notifier, s.log, opts...)
 		go s.watcher.Run(childCtx)
 	}
 
-	s.log.Info("timer supervisor started (tick=%s, cooldown=%s)", s.tickInterval, s.notifyCooldown)
+	s.log.Info("timer supervisor started (tick=%s, cooldown=%s, speed=%gx)", s.tickInterval, s.notifyCooldown, s.speed)
+}
+
+// SetEscalation replaces the escalation ladders, from the next alert.
+// Timers part way up a ladder carry on from the same level.
+func (s *Supervisor) SetEscalation(cfg EscalationConfig) {
+	s.mu.Lock()
+	s.escalation = cfg
+	s.mu.Unlock()
+}
+
+// SetReminderInterval changes how often running timers get an "X
+// remaining" reminder; 0 stops them.
+func (s *Supervisor) SetReminderInterval(d time.Duration) {
+	s.mu.Lock()
+	s.reminderInterval = d
+	s.mu.Unlock()
+}
+
+// ladder is the escalation ladder for a timer's label.
+func (s *Supervisor) ladder(label string) Escalation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.escalation.For(label)
+}
+
+// remindEvery is the reminder interval.
+func (s *Supervisor) remindEvery() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reminderInterval
 }
 
 // Stop gracefully shuts down the supervisor.
@@ -161,18 +244,52 @@ func (s *Supervisor) tick(ctx context.Context) {
 	}
 
 	for _, session := range sessions {
-		s.processSession(ctx, session)
+		s.processSession(ctx, session.ID)
 	}
 }
 
-// processSession handles timer updates for a single session.
-func (s *Supervisor) processSession(ctx context.Context, session *domain.Session) {
-	if session.Status != domain.SessionActive {
+// alert is a notification held back until its session is saved and
+// unlocked, so a slow notifier never holds up the engine.
+type alert struct {
+	msg      string
+	urgent   bool
+	external bool // also sent through the external notifier
+}
+
+// processSession handles timer updates for a single session.  The
+// session is loaded fresh and saved under the session lock, so a change
+// the engine makes between ticks isn't overwritten.
+func (s *Supervisor) processSession(ctx context.Context, sessionID string) {
+	s.sessionLock.Lock()
+	session, err := s.store.Load(ctx, sessionID)
+	if err != nil {
+		s.sessionLock.Unlock()
+		s.log.Error("supervisor: loading session %s: %v", sessionID, err)
 		return
 	}
-
+	var alerts []alert
 	changed := false
-	now := time.Now()
+	switch session.Status {
+	case domain.SessionWaiting:
+		alerts, changed = s.wakeIfDue(session)
+	case domain.SessionActive:
+		alerts, changed = s.runTimers(session)
+	}
+	if changed {
+		if err := s.store.Save(ctx, session); err != nil {
+			s.log.Error("supervisor: saving session %s: %v", session.ID, err)
+		}
+	}
+	s.sessionLock.Unlock()
+
+	s.send(ctx, alerts)
+}
+
+// runTimers counts down an active session's running timers and works
+// out which alerts are due.
+func (s *Supervisor) runTimers(session *domain.Session) (alerts []alert, changed bool) {
+	now := s.clock.Now()
+	every := s.remindEvery()
 
 	for _, ts := range session.TimerStates {
 		if ts.Status != domain.TimerRunning {
@@ -180,56 +297,40 @@ func (s *Supervisor) processSession(ctx context.Context, session *domain.Session
 		}
 
 		// Decrement remaining time.
-		ts.Remaining -= s.tickInterval
+		ts.Remaining -= time.Duration(float64(s.tickInterval) * s.speed)
 		changed = true
 
 		if ts.Remaining <= 0 {
 			ts.Remaining = 0
-			ts.Status = domain.TimerFired
+			session.SetTimer(ts, domain.TimerFired, now)
+			ts.FiredAt = now
 			s.log.Debug("timer %s fired for session %s", ts.ID, session.ID)
 
-			msg := s.escalationMessage(ts)
-			if err := s.notifier.NotifyUrgent(ctx, msg); err != nil {
-				s.log.Error("supervisor: notifying timer fire: %v", err)
-			}
-			ts.LastNotified = now
-			ts.EscalationLevel = 1
+			alerts = append(alerts, s.escalate(ts, now))
 			continue
 		}
 
 		// "Almost done" warning — once, when remaining crosses the threshold.
 		if !ts.WarnedAlmost && ts.Remaining <= s.almostDoneThreshold && ts.Duration > s.almostDoneThreshold*2 {
 			ts.WarnedAlmost = true
-			changed = true
-			msg := fmt.Sprintf("[Timer] %s — almost done, %s left.", ts.Label, formatRemaining(ts.Remaining))
-			if err := s.notifier.Notify(ctx, msg); err != nil {
-				s.log.Error("supervisor: almost-done notify: %v", err)
-			}
+			alerts = append(alerts, alert{msg: fmt.Sprintf("[Timer] %s — almost done, %s left.", ts.Label, formatRemaining(ts.Remaining))})
 			ts.LastRemindedAt = now
 			continue
 		}
 
 		// Periodic reminder every reminderInterval.
-		if s.reminderInterval > 0 && ts.Duration > s.reminderInterval {
+		if every > 0 && ts.Duration > every {
 			sinceLastReminder := now.Sub(ts.LastRemindedAt)
 			if ts.LastRemindedAt.IsZero() {
 				// First reminder after reminderInterval from start.
 				elapsed := ts.Duration - ts.Remaining
-				if elapsed >= s.reminderInterval {
+				if elapsed >= every {
 					ts.LastRemindedAt = now
-					changed = true
-					msg := fmt.Sprintf("[Timer] %s — %s remaining.", ts.Label, formatRemaining(ts.Remaining))
-					if err := s.notifier.Notify(ctx, msg); err != nil {
-						s.log.Error("supervisor: reminder notify: %v", err)
-					}
+					alerts = append(alerts, alert{msg: fmt.Sprintf("[Timer] %s — %s remaining.", ts.Label, formatRemaining(ts.Remaining))})
 				}
-			} else if sinceLastReminder >= s.reminderInterval {
+			} else if sinceLastReminder >= every {
 				ts.LastRemindedAt = now
-				changed = true
-				msg := fmt.Sprintf("[Timer] %s — %s remaining.", ts.Label, formatRemaining(ts.Remaining))
-				if err := s.notifier.Notify(ctx, msg); err != nil {
-					s.log.Error("supervisor: reminder notify: %v", err)
-				}
+				alerts = append(alerts, alert{msg: fmt.Sprintf("[Timer] %s — %s remaining.", ts.Label, formatRemaining(ts.Remaining))})
 			}
 		}
 	}
@@ -240,41 +341,70 @@ func (s *Supervisor) processSession(ctx context.Context, session *domain.Session
 			continue
 		}
 
-		if ts.EscalationLevel > s.maxEscalation {
+		esc := s.ladder(ts.Label)
+		if ts.EscalationLevel >= len(esc.Levels) || (s.maxEscalation > 0 && ts.EscalationLevel > s.maxEscalation) {
 			continue // Stop nagging.
 		}
 
-		if !ts.LastNotified.IsZero() && now.Sub(ts.LastNotified) < s.notifyCooldown {
+		if !ts.LastNotified.IsZero() && now.Sub(ts.LastNotified) < esc.wait(ts.EscalationLevel, s.notifyCooldown) {
 			continue // Cooldown active.
 		}
 
-		msg := s.escalationMessage(ts)
-		if err := s.notifier.Notify(ctx, msg); err != nil {
-			s.log.Error("supervisor: escalation notify: %v", err)
-		}
-		ts.LastNotified = now
-		ts.EscalationLevel++
+		alerts = append(alerts, s.escalate(ts, now))
 		changed = true
 	}
+	return alerts, changed
+}
 
-	if changed {
-		if err := s.store.Save(ctx, session); err != nil {
-			s.log.Error("supervisor: saving session %s: %v", session.ID, err)
-		}
+// wakeIfDue ends a hands-off wait once its wall-clock time has come,
+// including one that ran out while the app was closed.
+func (s *Supervisor) wakeIfDue(session *domain.Session) (alerts []alert, changed bool) {
+	now := s.clock.Now()
+	if now.Before(session.WaitUntil) {
+		return nil, false
+	}
+	late := now.Sub(session.WaitUntil)
+	session.Wake(now)
+
+	msg := fmt.Sprintf("[Timer] %s — the wait is over. Say next when you're ready to carry on.", session.RecipeName)
+	if late > time.Minute {
+		msg = fmt.Sprintf("[Timer] %s — the wait ended %s ago. Say next when you're ready to carry on.", session.RecipeName, formatRemaining(late))
 	}
+	return []alert{{msg: msg, urgent: true}}, true
 }
 
-// escalationMessage returns a message based on the escalation level.
-func (s *Supervisor) escalationMessage(ts *domain.TimerState) string {
-	switch ts.EscalationLevel {
-	case 0:
-		return fmt.Sprintf("[Timer] %s is up.", ts.Label)
-	case 1:
-		return fmt.Sprintf("[Timer] %s -- check it now.", ts.Label)
-	case 2:
-		return fmt.Sprintf("[Timer] %s. Now.", ts.Label)
-	default:
-		return fmt.Sprintf("[Timer] %s.", ts.Label)
+// escalate moves a fired timer up its ladder and returns the alert for
+// the level it was on.  The last level of an external ladder also goes
+// out through the external notifier.
+func (s *Supervisor) escalate(ts *domain.TimerState, now time.Time) alert {
+	esc := s.ladder(ts.Label)
+	level := ts.EscalationLevel
+	since := time.Duration(0)
+	if !ts.FiredAt.IsZero() {
+		since = now.Sub(ts.FiredAt)
+	}
+	msg, urgent := esc.message(level, ts.Label, since)
+
+	ts.LastNotified = now
+	ts.EscalationLevel = level + 1
+	return alert{msg: msg, urgent: urgent, external: esc.External && level == len(esc.Levels)-1}
+}
+
+// send delivers alerts once their session is unlocked.
+func (s *Supervisor) send(ctx context.Context, alerts []alert) {
+	for _, a := range alerts {
+		notify := s.notifier.Notify
+		if a.urgent {
+			notify = s.notifier.NotifyUrgent
+		}
+		if err := notify(ctx, a.msg); err != nil {
+			s.log.Error("supervisor: notify: %v", err)
+		}
+		if a.external && s.external != nil {
+			if err := s.external.NotifyUrgent(ctx, a.msg); err != nil {
+				s.log.Error("supervisor: external notify: %v", err)
+			}
+		}
 	}
 }
 
@@ -2,6 +2,7 @@ package timer
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/hammamikhairi/ottocook/internal/domain"
 	"github.com/hammamikhairi/ottocook/internal/logger"
 	"github.com/hammamikhairi/ottocook/internal/storage"
+	"github.com/hammamikhairi/ottocook/internal/testkit"
 )
 
 // mockNotifier collects notifications for testing.
@@ -145,6 +147,59 @@ func TestSupervisorRespectsMaxEscalation(t *testing.T) {
 	}
 }
 
+func TestSupervisorSetReminderInterval(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	store := storage.NewMemoryStore(log)
+	notifier := &mockNotifier{}
+	ctx := context.Background()
+
+	session := &domain.Session{
+		ID:               "reminder-test",
+		RecipeID:         "test",
+		RecipeName:       "Test",
+		Status:           domain.SessionActive,
+		CurrentStepIndex: 0,
+		StepStates:       map[int]*domain.StepState{0: {Status: domain.StepActive}},
+		TimerStates: map[string]*domain.TimerState{
+			"t1": {
+				ID:        "t1",
+				StepID:    "step-1",
+				Label:     "Rice",
+				Duration:  time.Minute,
+				Remaining: 50 * time.Second,
+				Status:    domain.TimerRunning,
+			},
+		},
+		StartedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	// The default two-minute interval is longer than the timer, so
+	// nothing is said until it's shortened.
+	sup := New(store, notifier, log, WithTickInterval(50*time.Millisecond))
+	sup.Start(ctx)
+	defer sup.Stop()
+
+	time.Sleep(150 * time.Millisecond)
+	notifier.mu.Lock()
+	before := len(notifier.messages)
+	notifier.mu.Unlock()
+	if before > 0 {
+		t.Fatalf("expected no reminders yet, got %d", before)
+	}
+
+	sup.SetReminderInterval(time.Second)
+	time.Sleep(150 * time.Millisecond)
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	if len(notifier.messages) == 0 || !strings.Contains(notifier.messages[0], "Rice") {
+		t.Fatalf("expected a reminder for Rice, got %v", notifier.messages)
+	}
+}
+
 func TestSupervisorSkipsPausedSessions(t *testing.T) {
 	log := logger.New(logger.LevelOff, nil)
 	store := storage.NewMemoryStore(log)
@@ -187,3 +242,101 @@ func TestSupervisorSkipsPausedSessions(t *testing.T) {
 		t.Fatal("expected no notifications for paused session")
 	}
 }
+
+func TestSupervisorWakesWaitingSession(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	store := storage.NewMemoryStore(log)
+	notifier := &mockNotifier{}
+	ctx := context.Background()
+
+	session := &domain.Session{
+		ID:         "wait-test",
+		RecipeName: "Test",
+		Status:     domain.SessionWaiting,
+		WaitUntil:  time.Now().Add(100 * time.Millisecond),
+		StepStates: map[int]*domain.StepState{0: {Status: domain.StepActive}},
+		TimerStates: map[string]*domain.TimerState{
+			"t1": {ID: "t1", Label: "Test Timer", Duration: time.Hour, Remaining: time.Hour, Status: domain.TimerPaused, Held: true},
+		},
+	}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	sup := New(store, notifier, log, WithTickInterval(50*time.Millisecond))
+	sup.Start(ctx)
+	defer sup.Stop()
+
+	time.Sleep(300 * time.Millisecond)
+
+	if notifier.urgentCount() != 1 {
+		t.Fatalf("expected one wake-up notification, got %d", notifier.urgentCount())
+	}
+	s, _ := store.Load(ctx, "wait-test")
+	if s.Status != domain.SessionActive || s.TimerStates["t1"].Status != domain.TimerRunning {
+		t.Fatalf("session not woken: status=%s timer=%s", s.Status, s.TimerStates["t1"].Status)
+	}
+}
+
+func TestSupervisorSpeed(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	store := storage.NewMemoryStore(log)
+	ctx := context.Background()
+
+	session := &domain.Session{
+		ID:         "demo",
+		Status:     domain.SessionActive,
+		StepStates: map[int]*domain.StepState{0: {Status: domain.StepActive}},
+		TimerStates: map[string]*domain.TimerState{
+			"boil": {ID: "boil", Label: "Boil", Duration: 8 * time.Minute, Remaining: 8 * time.Minute, Status: domain.TimerRunning},
+		},
+	}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	sup := New(store, &mockNotifier{}, log, WithSpeed(20))
+	sup.processSession(ctx, session.ID)
+
+	session, _ = store.Load(ctx, session.ID)
+	if got := session.TimerStates["boil"].Remaining; got != 8*time.Minute-20*time.Second {
+		t.Fatalf("remaining after one tick at 20x = %v, want 7m40s", got)
+	}
+}
+
+func TestSupervisorWakeUsesClock(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	store := storage.NewMemoryStore(log)
+	notifier := &mockNotifier{}
+	ctx := context.Background()
+	clock := testkit.NewFakeClock(time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC))
+
+	session := &domain.Session{
+		ID:         "overnight",
+		RecipeName: "Brisket",
+		Status:     domain.SessionWaiting,
+		WaitUntil:  clock.Now().Add(8 * time.Hour),
+		StepStates: map[int]*domain.StepState{0: {Status: domain.StepActive}},
+	}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	sup := New(store, notifier, log, WithClock(clock))
+
+	clock.Advance(7 * time.Hour)
+	sup.processSession(ctx, session.ID)
+	session, _ = store.Load(ctx, session.ID)
+	if session.Status != domain.SessionWaiting {
+		t.Fatal("woke an hour early")
+	}
+
+	clock.Advance(3 * time.Hour)
+	sup.processSession(ctx, session.ID)
+	session, _ = store.Load(ctx, session.ID)
+	if session.Status != domain.SessionActive || !session.UpdatedAt.Equal(clock.Now()) {
+		t.Fatalf("session after the wait = %s updated %s; want active, updated now", session.Status, session.UpdatedAt)
+	}
+	if notifier.urgentCount() != 1 || !strings.Contains(notifier.urgent[0], "ended 120 minutes ago") {
+		t.Fatalf("urgent = %q; want one saying the wait ended 120 minutes ago", notifier.urgent)
+	}
+}
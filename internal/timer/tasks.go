@@ -0,0 +1,38 @@
+package timer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/domain"
+)
+
+// ── Helper tasks ─────────────────────────────────────────────────
+//
+// A task handed to a helper is easy to lose track of while the cook gets
+// on with the steps.  The watcher asks after each open task every few
+// minutes until someone says it's done.
+
+// taskReminder is how long a task runs between reminders.
+const taskReminder = 5 * time.Minute
+
+// checkTasks reminds about helper tasks open a while.
+func (w *Watcher) checkTasks(ctx context.Context, session *domain.Session) {
+	now := w.clock.Now()
+	for _, t := range session.OpenTasks("") {
+		last, ok := w.taskReminded[t.ID]
+		if !ok {
+			last = t.Assigned
+		}
+		if now.Sub(last) < taskReminder {
+			continue
+		}
+		w.taskReminded[t.ID] = now
+		msg := fmt.Sprintf("[Watcher] Checking in with %s: %s, %s so far. Say %s's done when it is.",
+			t.Helper, t.Text, formatRemaining(now.Sub(t.Assigned)), t.Helper)
+		if err := w.notifier.Notify(ctx, msg); err != nil {
+			w.log.Error("watcher: notify: %v", err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package timer
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/hammamikhairi/ottocook/internal/domain"
@@ -19,6 +20,29 @@ func WithWatchInterval(d time.Duration) WatcherOption {
 	}
 }
 
+// WithWatcherNotifier sends the watcher's comments to n instead of the
+// supervisor's notifier, e.g. to speak them on their own channel.
+func WithWatcherNotifier(n domain.Notifier) WatcherOption {
+	return func(w *Watcher) {
+		w.notifier = n
+	}
+}
+
+// WithWatcherClock sets the clock the watcher reads.
+func WithWatcherClock(c domain.Clock) WatcherOption {
+	return func(w *Watcher) {
+		w.clock = c
+	}
+}
+
+// WithWatcherSessionLock sets the lock held while the watcher loads,
+// changes and saves a session.  The supervisor passes its own.
+func WithWatcherSessionLock(l sync.Locker) WatcherOption {
+	return func(w *Watcher) {
+		w.sessionLock = l
+	}
+}
+
 // Watcher periodically inspects the full session state and provides
 // contextual commentary — reminders about idle steps, timer awareness,
 // and general "keep an eye on it" nudges. Runs on a slower cycle than
@@ -28,7 +52,16 @@ type Watcher struct {
 	recipes  domain.RecipeSource
 	notifier domain.Notifier
 	log      *logger.Logger
+	clock    domain.Clock
 	interval time.Duration
+
+	sessionLock sync.Locker // held from load to save
+
+	lateWarned map[string]int              // session ID -> step index last warned about running late
+	active     map[string]bool             // sessions active at the last check
+	served     map[string]*servedLeftovers // finished sessions whose food may still be out
+
+	taskReminded map[string]time.Time // helper task ID -> last reminder
 }
 
 // NewWatcher creates a watcher with the given dependencies.
@@ -38,7 +71,15 @@ func NewWatcher(store domain.SessionStore, recipes domain.RecipeSource, notifier
 		recipes:  recipes,
 		notifier: notifier,
 		log:      log,
+		clock:    domain.SystemClock{},
 		interval: 1 * time.Minute,
+
+		sessionLock: &sync.Mutex{},
+		lateWarned:  make(map[string]int),
+		active:      make(map[string]bool),
+		served:      make(map[string]*servedLeftovers),
+
+		taskReminded: make(map[string]time.Time),
 	}
 	for _, opt := range opts {
 		opt(w)
@@ -75,12 +116,14 @@ func (w *Watcher) check(ctx context.Context) {
 
 	for _, session := range sessions {
 		w.inspect(ctx, session)
+		w.checkTasks(ctx, session)
 	}
+	w.checkLeftovers(ctx, sessions)
 }
 
 // inspect examines a single session and decides what to say.
 func (w *Watcher) inspect(ctx context.Context, session *domain.Session) {
-	now := time.Now()
+	now := w.clock.Now()
 
 	// Log the check itself.
 	w.log.Debug("watcher: checked status — session=%s recipe=%s status=%s step=%d/%d",
@@ -115,20 +158,57 @@ func (w *Watcher) inspect(ctx context.Context, session *domain.Session) {
 
 	// Build a contextual message based on what we see.
 	msg := w.buildMessage(session, step, stepState, onStepFor)
+	if msg == "" {
+		msg = w.lateMessage(session, recipe, now)
+	}
 	if msg == "" {
 		return
 	}
 
-	if err := w.notifier.Notify(ctx, msg); err != nil {
+	// A timer going unanswered is worth interrupting for.
+	notify := w.notifier.Notify
+	if session.Status == domain.SessionActive && hasFired(session) {
+		notify = w.notifier.NotifyUrgent
+	}
+	if err := notify(ctx, msg); err != nil {
 		w.log.Error("watcher: notify: %v", err)
+		return
+	}
+
+	// Keep it for the session's timeline, on the session as it is now
+	// rather than as it was listed.
+	w.sessionLock.Lock()
+	defer w.sessionLock.Unlock()
+	current, err := w.store.Load(ctx, session.ID)
+	if err != nil {
+		w.log.Error("watcher: loading session %s: %v", session.ID, err)
+		return
+	}
+	current.Record(now, domain.EventNudge, "", msg)
+	if err := w.store.Save(ctx, current); err != nil {
+		w.log.Error("watcher: saving session %s: %v", session.ID, err)
 	}
 }
 
 // buildMessage decides what to tell the user based on current state.
 func (w *Watcher) buildMessage(session *domain.Session, step *domain.Step, stepState *domain.StepState, onStepFor time.Duration) string {
+	// Waiting on purpose — the supervisor wakes it when it's time.
+	if session.Status == domain.SessionWaiting {
+		return ""
+	}
+
+	// A wait step that hasn't been started: say how, rather than nagging.
+	if step.Wait > 0 && session.Status == domain.SessionActive {
+		if onStepFor > 3*time.Minute && onStepFor < 3*time.Minute+w.interval {
+			return fmt.Sprintf("[Watcher] Step %d is a %s wait. Say ready once it's going, and you can close me until it's done.",
+				step.Order, step.Wait.Round(time.Minute))
+		}
+		return ""
+	}
+
 	// Paused session — gentle nudge.
 	if session.Status == domain.SessionPaused {
-		elapsed := time.Since(session.UpdatedAt).Round(time.Second)
+		elapsed := w.clock.Now().Sub(session.UpdatedAt).Round(time.Second)
 		return fmt.Sprintf("[Watcher] Session paused for %s. Your food isn't cooking itself.", elapsed)
 	}
 
@@ -177,6 +257,39 @@ func (w *Watcher) buildMessage(session *domain.Session, step *domain.Step, stepS
 	return ""
 }
 
+// lateLimit is how far past the serve time the estimate has to run
+// before the watcher says something.
+const lateLimit = 5 * time.Minute
+
+// lateMessage warns, once per step, when the session has a serve time
+// and the time left puts the food on the table well after it.
+func (w *Watcher) lateMessage(session *domain.Session, recipe *domain.Recipe, now time.Time) string {
+	if session.ServeAt.IsZero() || session.Status != domain.SessionActive {
+		return ""
+	}
+	late := now.Add(session.TimeLeft(recipe, now)).Sub(session.ServeAt)
+	if late < lateLimit {
+		return ""
+	}
+	if warned, ok := w.lateWarned[session.ID]; ok && warned == session.CurrentStepIndex {
+		return ""
+	}
+	w.lateWarned[session.ID] = session.CurrentStepIndex
+	return fmt.Sprintf("[Watcher] Running about %s behind for %s — anything you can do in parallel?",
+		late.Round(time.Minute), session.ServeAt.Format(time.Kitchen))
+}
+
+// hasFired reports whether any of the session's timers has gone off
+// and not been dismissed.
+func hasFired(session *domain.Session) bool {
+	for _, ts := range session.TimerStates {
+		if ts.Status == domain.TimerFired {
+			return true
+		}
+	}
+	return false
+}
+
 // joinNames joins a slice of names into a comma-separated string.
 func joinNames(names []string) string {
 	if len(names) == 1 {
@@ -2,6 +2,7 @@ package timer
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/hammamikhairi/ottocook/internal/logger"
 	"github.com/hammamikhairi/ottocook/internal/recipe"
 	"github.com/hammamikhairi/ottocook/internal/storage"
+	"github.com/hammamikhairi/ottocook/internal/testkit"
 )
 
 // collectingNotifier captures messages for assertions.
@@ -251,3 +253,164 @@ func TestWatcherQuietWhenNothingToReport(t *testing.T) {
 		t.Fatalf("expected no notifications for fresh session, got %d: %q", notifier.count(), notifier.last())
 	}
 }
+
+func TestWatcherWarnsWhenRunningLate(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	store := storage.NewMemoryStore(log)
+	recipes := recipe.NewMemorySource(log)
+	notifier := &collectingNotifier{}
+	ctx := context.Background()
+
+	// Chicken alfredo has well over ten minutes to go; dinner is in ten.
+	session := &domain.Session{
+		ID:               "watcher-late",
+		RecipeID:         "chicken-alfredo",
+		RecipeName:       "Chicken Alfredo",
+		Status:           domain.SessionActive,
+		CurrentStepIndex: 0,
+		Servings:         2,
+		StepStates: map[int]*domain.StepState{
+			0: {Status: domain.StepActive, StartedAt: time.Now()},
+			1: {Status: domain.StepPending},
+			2: {Status: domain.StepPending},
+			3: {Status: domain.StepPending},
+			4: {Status: domain.StepPending},
+			5: {Status: domain.StepPending},
+			6: {Status: domain.StepPending},
+			7: {Status: domain.StepPending},
+		},
+		TimerStates: map[string]*domain.TimerState{},
+		StartedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+		ServeAt:     time.Now().Add(10 * time.Minute),
+	}
+
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	w := NewWatcher(store, recipes, notifier, log, WithWatchInterval(50*time.Millisecond))
+	wCtx, cancel := context.WithCancel(ctx)
+	go w.Run(wCtx)
+
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+
+	// Once per step, not every cycle.
+	if notifier.count() != 1 {
+		t.Fatalf("expected one running-late warning, got %d: %q", notifier.count(), notifier.last())
+	}
+	if !strings.Contains(notifier.last(), "behind") {
+		t.Fatalf("unexpected message: %q", notifier.last())
+	}
+}
+
+func TestWatcherLeftovers(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	store := storage.NewMemoryStore(log)
+	recipes := recipe.NewMemorySource(log)
+	notifier := &collectingNotifier{}
+	clock := testkit.NewFakeClock(time.Now())
+	ctx := context.Background()
+
+	session := &domain.Session{
+		ID:          "watcher-leftovers",
+		RecipeID:    "chicken-alfredo",
+		RecipeName:  "Chicken Alfredo",
+		Status:      domain.SessionActive,
+		StepStates:  map[int]*domain.StepState{0: {Status: domain.StepActive, StartedAt: clock.Now()}},
+		TimerStates: map[string]*domain.TimerState{},
+		StartedAt:   clock.Now(),
+		UpdatedAt:   clock.Now(),
+	}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	w := NewWatcher(store, recipes, notifier, log, WithWatcherClock(clock))
+	w.check(ctx)
+	before := notifier.count()
+
+	session.Status = domain.SessionCompleted
+	session.UpdatedAt = clock.Now()
+	store.Save(ctx, session)
+	w.check(ctx)
+
+	clock.Advance(80 * time.Minute)
+	w.check(ctx)
+	if notifier.count() != before {
+		t.Fatalf("reminded after 80 minutes: %q", notifier.last())
+	}
+
+	clock.Advance(15 * time.Minute)
+	w.check(ctx)
+	if notifier.count() != before+1 || !strings.Contains(notifier.last(), "within 25 minutes") {
+		t.Fatalf("want the heads-up, got %d messages, last %q", notifier.count()-before, notifier.last())
+	}
+
+	clock.Advance(30 * time.Minute)
+	w.check(ctx)
+	w.check(ctx)
+	if notifier.count() != before+2 || !strings.Contains(notifier.last(), "chicken") {
+		t.Fatalf("want one time's-up reminder, got %d messages, last %q", notifier.count()-before, notifier.last())
+	}
+}
+
+func TestWatcherTaskReminders(t *testing.T) {
+	log := logger.New(logger.LevelOff, nil)
+	store := storage.NewMemoryStore(log)
+	recipes := recipe.NewMemorySource(log)
+	notifier := &collectingNotifier{}
+	clock := testkit.NewFakeClock(time.Now())
+	ctx := context.Background()
+
+	session := &domain.Session{
+		ID:          "watcher-tasks",
+		RecipeID:    "chicken-alfredo",
+		RecipeName:  "Chicken Alfredo",
+		Status:      domain.SessionActive,
+		StepStates:  map[int]*domain.StepState{0: {Status: domain.StepActive, StartedAt: clock.Now()}},
+		TimerStates: map[string]*domain.TimerState{},
+		Tasks:       []*domain.Task{{ID: "t1", Helper: "Sam", Text: "chop the broccoli", Assigned: clock.Now()}},
+		StartedAt:   clock.Now(),
+		UpdatedAt:   clock.Now(),
+	}
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	reminders := func() int {
+		notifier.mu.Lock()
+		defer notifier.mu.Unlock()
+		n := 0
+		for _, m := range notifier.messages {
+			if strings.Contains(m, "Checking in with Sam: chop the broccoli") {
+				n++
+			}
+		}
+		return n
+	}
+
+	w := NewWatcher(store, recipes, notifier, log, WithWatcherClock(clock))
+	clock.Advance(4 * time.Minute)
+	w.check(ctx)
+	if n := reminders(); n != 0 {
+		t.Fatalf("%d reminders after 4 minutes, want none", n)
+	}
+
+	clock.Advance(2 * time.Minute)
+	w.check(ctx)
+	w.check(ctx)
+	if n := reminders(); n != 1 {
+		t.Fatalf("%d reminders after 6 minutes, want 1", n)
+	}
+
+	session.Tasks[0].Done = true
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	clock.Advance(10 * time.Minute)
+	w.check(ctx)
+	if n := reminders(); n != 1 {
+		t.Fatalf("reminded about a finished task: %d reminders", n)
+	}
+}
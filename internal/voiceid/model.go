@@ -0,0 +1,89 @@
+package voiceid
+
+import (
+	"fmt"
+	"math"
+
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// minSamples is the shortest clip worth embedding: under a second, a
+// voiceprint says more about the room than the speaker.
+const minSamples = 16000
+
+// Model computes speaker embeddings with an ONNX model that takes raw
+// 16 kHz mono audio, shaped [1, samples] and scaled to ±1, and returns
+// one embedding, [1, dim].  The model is loaded on first use, since the
+// ONNX Runtime environment only comes up with the wake word detector.
+// Not safe for concurrent use.
+type Model struct {
+	path    string
+	sess    *ort.DynamicAdvancedSession
+	loadErr error // a model that failed to load isn't tried again
+}
+
+// New returns the model at path, loaded on the first Embed.  Call
+// Close when done.
+func New(path string) *Model {
+	return &Model{path: path}
+}
+
+// load opens the ONNX session.
+func (m *Model) load() error {
+	inputs, outputs, err := ort.GetInputOutputInfo(m.path)
+	if err != nil {
+		return fmt.Errorf("reading speaker model: %w", err)
+	}
+	if len(inputs) == 0 || len(outputs) == 0 {
+		return fmt.Errorf("speaker model %s has no inputs or outputs", m.path)
+	}
+	m.sess, err = ort.NewDynamicAdvancedSession(m.path,
+		[]string{inputs[0].Name}, []string{outputs[0].Name}, nil)
+	if err != nil {
+		return fmt.Errorf("loading speaker model: %w", err)
+	}
+	return nil
+}
+
+// Embed returns the voiceprint of pcm (16 kHz mono), or nil for a clip
+// too short to tell.
+func (m *Model) Embed(pcm []int16) ([]float32, error) {
+	if len(pcm) < minSamples {
+		return nil, nil
+	}
+	if m.sess == nil {
+		if m.loadErr == nil {
+			m.loadErr = m.load()
+		}
+		if m.loadErr != nil {
+			return nil, m.loadErr
+		}
+	}
+	data := make([]float32, len(pcm))
+	for i, s := range pcm {
+		data[i] = float32(s) / math.MaxInt16
+	}
+	in, err := ort.NewTensor(ort.NewShape(1, int64(len(data))), data)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Destroy()
+
+	outputs := []ort.Value{nil}
+	if err := m.sess.Run([]ort.Value{in}, outputs); err != nil {
+		return nil, fmt.Errorf("embedding voice: %w", err)
+	}
+	defer outputs[0].Destroy()
+	out, ok := outputs[0].(*ort.Tensor[float32])
+	if !ok {
+		return nil, fmt.Errorf("speaker model output is %T, want float32", outputs[0])
+	}
+	return append([]float32(nil), out.GetData()...), nil
+}
+
+// Close releases the model.
+func (m *Model) Close() {
+	if m.sess != nil {
+		m.sess.Destroy()
+	}
+}
@@ -0,0 +1,150 @@
+// Package voiceid tells the people in a kitchen apart by voice, so
+// what's true of one of them — their diet, their allergies — follows
+// them from command to command without anyone saying who's talking.
+//
+// A speaker embedding model turns a voice command into a voiceprint;
+// profiles keep a few voiceprints per person, enrolled with "remember
+// my voice as Sam", and the closest profile above a threshold is the
+// speaker.
+package voiceid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
+)
+
+// DefaultThreshold is the cosine similarity a voiceprint needs to an
+// enrolled speaker to count as them.
+const DefaultThreshold = 0.6
+
+// maxPrints is how many voiceprints a profile keeps; the oldest go as
+// new ones are enrolled, so a profile follows a voice with a cold.
+const maxPrints = 8
+
+// Profile is one person in the household.
+type Profile struct {
+	Name   string      `json:"name"`
+	Diet   []string    `json:"diet,omitempty"` // "vegetarian", "allergic to peanuts"
+	Prints [][]float32 `json:"prints,omitempty"`
+}
+
+// Profiles is the household, kept in a JSON file that's fine to edit
+// by hand.
+type Profiles struct {
+	path      string
+	threshold float64
+	people    []*Profile
+}
+
+// Load reads the profiles at path.  A missing file is an empty
+// household.
+func Load(path string, threshold float64) (*Profiles, error) {
+	p := &Profiles{path: path, threshold: threshold}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading profiles: %w", err)
+	}
+	if err := json.Unmarshal(data, &p.people); err != nil {
+		return nil, fmt.Errorf("parsing profiles %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Save writes the profiles back to their file.
+func (p *Profiles) Save() error {
+	data, err := json.MarshalIndent(p.people, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(p.path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("writing profiles: %w", err)
+	}
+	if err := os.Rename(p.path+".tmp", p.path); err != nil {
+		return fmt.Errorf("writing profiles: %w", err)
+	}
+	return nil
+}
+
+// Get returns the profile called name, or nil.
+func (p *Profiles) Get(name string) *Profile {
+	for _, pr := range p.people {
+		if strings.EqualFold(pr.Name, name) {
+			return pr
+		}
+	}
+	return nil
+}
+
+// ensure returns the profile called name, adding it if it's new.
+func (p *Profiles) ensure(name string) *Profile {
+	if pr := p.Get(name); pr != nil {
+		return pr
+	}
+	pr := &Profile{Name: name}
+	p.people = append(p.people, pr)
+	return pr
+}
+
+// Enroll adds a voiceprint to name's profile, creating it if needed.
+func (p *Profiles) Enroll(name string, vp []float32) *Profile {
+	pr := p.ensure(name)
+	pr.Prints = append(pr.Prints, vp)
+	if extra := len(pr.Prints) - maxPrints; extra > 0 {
+		pr.Prints = slices.Delete(pr.Prints, 0, extra)
+	}
+	return pr
+}
+
+// AddDiet adds a dietary need to name's profile, creating it if needed.
+// Saying the same thing twice doesn't list it twice.
+func (p *Profiles) AddDiet(name, need string) *Profile {
+	pr := p.ensure(name)
+	if !slices.ContainsFunc(pr.Diet, func(d string) bool { return strings.EqualFold(d, need) }) {
+		pr.Diet = append(pr.Diet, need)
+	}
+	return pr
+}
+
+// Identify returns the profile with a voiceprint closest to vp,
+// or nil when none is close enough.
+func (p *Profiles) Identify(vp []float32) (*Profile, float64) {
+	var best *Profile
+	bestScore := p.threshold
+	for _, pr := range p.people {
+		for _, known := range pr.Prints {
+			if s := cosine(vp, known); s >= bestScore {
+				best, bestScore = pr, s
+			}
+		}
+	}
+	if best == nil {
+		return nil, 0
+	}
+	return best, bestScore
+}
+
+// cosine returns the cosine similarity of a and b, or 0 when they can't
+// be compared.
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
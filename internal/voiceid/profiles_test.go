@@ -0,0 +1,50 @@
+package voiceid
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestProfilesIdentify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	p, err := Load(path, DefaultThreshold)
+	if err != nil {
+		t.Fatalf("Load missing file: %v", err)
+	}
+	p.Enroll("Sam", []float32{1, 0, 0})
+	p.Enroll("Alex", []float32{0, 1, 0})
+	p.AddDiet("sam", "vegetarian")
+	p.AddDiet("Sam", "Vegetarian")
+	if err := p.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	p, err = Load(path, DefaultThreshold)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	who, score := p.Identify([]float32{0.9, 0.2, 0.1})
+	if who == nil || who.Name != "Sam" {
+		t.Fatalf("Identify = %v (%.2f), want Sam", who, score)
+	}
+	if len(who.Diet) != 1 {
+		t.Errorf("Diet = %q, want the one need", who.Diet)
+	}
+	if who, _ := p.Identify([]float32{0, 0, 1}); who != nil {
+		t.Errorf("Identify(stranger) = %s, want nobody", who.Name)
+	}
+	if who, _ := p.Identify([]float32{1, 0}); who != nil {
+		t.Errorf("Identify(wrong size) = %s, want nobody", who.Name)
+	}
+}
+
+func TestEnrollKeepsRecentPrints(t *testing.T) {
+	p := &Profiles{threshold: DefaultThreshold}
+	for i := range maxPrints + 3 {
+		p.Enroll("Sam", []float32{float32(i)})
+	}
+	prints := p.Get("Sam").Prints
+	if len(prints) != maxPrints || prints[0][0] != 3 {
+		t.Errorf("kept %d prints starting at %v, want %d from 3", len(prints), prints[0], maxPrints)
+	}
+}
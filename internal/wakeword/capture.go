@@ -0,0 +1,47 @@
+//go:build !noaudio
+
+package wakeword
+
+import "github.com/gen2brain/malgo"
+
+// malgoCapture is the microphone, opened through miniaudio.
+type malgoCapture struct {
+	ctx    *malgo.AllocatedContext
+	device *malgo.Device
+}
+
+// openCapture opens the default capture device for 16 kHz mono S16
+// audio.  onData gets the raw little-endian samples as they arrive, on
+// miniaudio's thread.  Nothing is captured until Start.
+func openCapture(onData func(raw []byte)) (capture, error) {
+	ctx, err := malgo.InitContext(nil, malgo.ContextConfig{}, func(_ string) {})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := malgo.DefaultDeviceConfig(malgo.Capture)
+	cfg.SampleRate = sampleRate
+	cfg.Capture.Format = malgo.FormatS16
+	cfg.Capture.Channels = 1
+	cfg.Alsa.NoMMap = 1
+
+	device, err := malgo.InitDevice(ctx.Context, cfg, malgo.DeviceCallbacks{
+		Data: func(_ []byte, raw []byte, _ uint32) { onData(raw) },
+	})
+	if err != nil {
+		_ = ctx.Uninit()
+		ctx.Free()
+		return nil, err
+	}
+	return &malgoCapture{ctx: ctx, device: device}, nil
+}
+
+func (c *malgoCapture) Start() error { return c.device.Start() }
+
+func (c *malgoCapture) Stop() error { return c.device.Stop() }
+
+func (c *malgoCapture) Close() {
+	c.device.Uninit()
+	_ = c.ctx.Uninit()
+	c.ctx.Free()
+}
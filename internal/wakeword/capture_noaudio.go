@@ -0,0 +1,5 @@
+//go:build noaudio
+
+package wakeword
+
+func openCapture(func([]byte)) (capture, error) { return nil, ErrNoAudio }
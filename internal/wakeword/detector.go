@@ -12,13 +12,13 @@ package wakeword
 import (
 	"context"
 	"encoding/binary"
-	"math"
+	"errors"
 	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/gen2brain/malgo"
 	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/metrics"
 	ort "github.com/yalue/onnxruntime_go"
 )
 
@@ -35,20 +35,20 @@ const (
 	melBins       = 32 // melspectrogram output bands
 	nMelFrames    = 5  // 1280 samples → 5 mel frames
 
-	// scoreWindowSize is the number of recent scores to track.
+	// DefaultScoreWindow is the number of recent scores to track.
 	// The detector triggers when the max score in this window
 	// exceeds the threshold.  This compensates for frame-alignment
 	// variance — the peak may arrive one frame before or after
 	// the "ideal" position.  5 frames ≈ 400 ms.
-	scoreWindowSize = 5
+	DefaultScoreWindow = 5
 
-	// recentWindow is how many of the most recent embedding slots to
-	// actually pass to the wakeword model.  The rest are zeroed.
+	// DefaultRecentWindow is how many of the most recent embedding slots
+	// to actually pass to the wakeword model.  The rest are zeroed.
 	// This mimics the initial-launch state where the model saw
 	// [0,0,...,0, speech, speech] and scored 0.8+.  Silence embeddings
 	// in older slots can never accumulate and suppress detection because
 	// they're always masked to zero at scoring time.
-	recentWindow = 5 // ~400 ms of context (5 × 80 ms embed steps)
+	DefaultRecentWindow = 5 // ~400 ms of context (5 × 80 ms embed steps)
 )
 
 // Config holds the paths and tuning knobs for a Detector.
@@ -59,9 +59,56 @@ type Config struct {
 	EmbeddingModel string // e.g. "bin/embedding_model.onnx"
 	OnnxLib        string // e.g. "bin/libonnxruntime.dylib"
 
+	// Wakewords are further wake word models scored alongside
+	// WakewordModel, each tagged with the language of the household
+	// member who says it ("fr" for a "Salut Chef" model), so a
+	// bilingual kitchen can wake Otto in either language.  The
+	// verifier only checks WakewordModel.
+	Wakewords []Wakeword
+
 	// Detection tuning.
-	Threshold float64       // score ≥ threshold → detected (default 0.5)
-	Cooldown  time.Duration // min time between detections (default 1.5 s)
+	Threshold    float64       // score ≥ threshold → detected (default 0.5)
+	Cooldown     time.Duration // min audio time between detections (default 1.5 s)
+	ScoreWindow  int           // trailing scores considered per decision (default 5)
+	RecentWindow int           // embedding slots fed to the model, 1–16 (default 5)
+
+	// Optional second stage.  When VerifyModel is set, every first-stage
+	// hit is re-scored over the full, un-padded embedding history and
+	// dropped unless it reaches VerifyThreshold (default: Threshold).
+	// This filters out the TV-audio false positives the zero-padded
+	// first stage is prone to, at the cost of one extra model run per
+	// candidate.  The verifier must be trained on full histories: the
+	// first-stage model isn't, which is why it's padded, and over real
+	// silence it would reject genuine wake words too.
+	VerifyModel     string
+	VerifyThreshold float64
+
+	// Accelerator selects the ONNX Runtime execution provider: "cpu"
+	// (default), "coreml" (Apple Neural Engine / GPU), "cuda", or
+	// "directml".  If the provider isn't available in the loaded
+	// runtime library the detector logs a warning and stays on CPU.
+	Accelerator string
+
+	// History is how much raw microphone audio to keep in a rolling
+	// buffer (default 5 s).  It keeps filling while the detector is
+	// paused, so the Ear can recover speech spoken between the wake word
+	// and the moment its own recorder starts.
+	History time.Duration
+
+	// IdleLevel is the RMS (0–1) below which the room counts as quiet
+	// while the detector is idle (see SetIdle); DefaultIdleLevel suits
+	// most kitchens.  0 keeps scoring at full rate even when idle.
+	IdleLevel float64
+
+	// Metrics, when non-nil, receives the score distribution, detection
+	// count, and dropped audio frames.
+	Metrics *metrics.Registry
+}
+
+// Wakeword is an extra wake word model and the language spoken with it.
+type Wakeword struct {
+	Model    string
+	Language string // whisper language code, e.g. "fr"
 }
 
 func (c *Config) defaults() {
@@ -71,6 +118,21 @@ func (c *Config) defaults() {
 	if c.Cooldown <= 0 {
 		c.Cooldown = 1500 * time.Millisecond
 	}
+	if c.ScoreWindow <= 0 {
+		c.ScoreWindow = DefaultScoreWindow
+	}
+	if c.RecentWindow <= 0 {
+		c.RecentWindow = DefaultRecentWindow
+	}
+	if c.RecentWindow > nEmbedFrames {
+		c.RecentWindow = nEmbedFrames
+	}
+	if c.VerifyThreshold <= 0 {
+		c.VerifyThreshold = c.Threshold
+	}
+	if c.History <= 0 {
+		c.History = 5 * time.Second
+	}
 }
 
 // Detector listens for a wakeword continuously and fires OnDetected.
@@ -82,15 +144,74 @@ type Detector struct {
 	// is detected.  Set before calling Start.
 	OnDetected func()
 
+	history    *pcmRing     // rolling raw mic audio, written even while paused
+	detectPos  atomic.Int64 // history position of the last detection
+	detectLang atomic.Value // string: Wakeword.Language of the last detection
+
 	mu         sync.Mutex
 	paused     bool
-	needsReset bool // set on Resume to flush stale pipeline state
+	idle       bool    // nothing's cooking: stop scoring in a quiet room
+	threshold  float64 // Config.Threshold, as tuned since
+	needsReset bool    // set on Resume to flush stale pipeline state
+	captureOff bool    // the capture device is stopped, not just ignored
+
+	captureCh chan struct{}      // wakes the loop to start or stop the device
+	ready     chan struct{}      // closed once the models are warm and the mic is up
+	stop      context.CancelFunc // ends Start; nil when it isn't running
+	stopped   chan struct{}      // closed once Start has returned
 }
 
 // New creates a Detector.  Call Start to begin listening.
 func New(cfg Config, log *logger.Logger) *Detector {
 	cfg.defaults()
-	return &Detector{cfg: cfg, log: log}
+	return &Detector{
+		cfg:       cfg,
+		log:       log,
+		history:   newPCMRing(int(cfg.History * sampleRate / time.Second)),
+		captureCh: make(chan struct{}, 1),
+		ready:     make(chan struct{}),
+		threshold: cfg.Threshold,
+	}
+}
+
+// SetThreshold changes the detection threshold, from the next score.
+// The verifier's threshold stays as configured.
+func (d *Detector) SetThreshold(t float64) {
+	d.mu.Lock()
+	d.threshold = t
+	d.mu.Unlock()
+}
+
+// Threshold returns the detection threshold.
+func (d *Detector) Threshold() float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.threshold
+}
+
+// Ready is closed once Start has warmed up the models and opened the
+// microphone, i.e. from when a wake word would be heard.  It is never
+// closed if Start fails.  After a Close, the next Start has a new one.
+func (d *Detector) Ready() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.ready
+}
+
+// SinceDetection returns the microphone audio (16 kHz mono) captured
+// after the most recent detection, up to Config.History of it.  The Ear
+// uses this to recover words spoken right after the wake phrase, while
+// the listening filler was still playing.
+func (d *Detector) SinceDetection() []int16 {
+	return d.history.since(d.detectPos.Load())
+}
+
+// DetectedLanguage returns the language of the wake word behind the most
+// recent detection: a Config.Wakewords language, or "" for the main
+// model.
+func (d *Detector) DetectedLanguage() string {
+	lang, _ := d.detectLang.Load().(string)
+	return lang
 }
 
 // Pause temporarily stops detecting (e.g. while TTS is playing so we
@@ -109,6 +230,50 @@ func (d *Detector) Resume() {
 	d.mu.Unlock()
 }
 
+// SetCapture starts or stops the microphone itself.  Unlike Pause, a
+// stopped capture device hears nothing at all: the OS shows the mic as
+// unused and the history buffer stops filling.  Takes effect within a
+// frame; safe to call before Start.
+func (d *Detector) SetCapture(on bool) {
+	d.mu.Lock()
+	d.captureOff = !on
+	if on {
+		d.needsReset = true
+	}
+	d.mu.Unlock()
+	select {
+	case d.captureCh <- struct{}{}:
+	default: // already signalled
+	}
+}
+
+// Capturing reports whether the microphone is on.
+func (d *Detector) Capturing() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return !d.captureOff
+}
+
+// SetIdle tells the detector whether the app is idle, with no session
+// and so no timers.  Idle, it stops running the models once the room
+// has been quiet a few seconds (Config.IdleLevel), and picks up again
+// at the next sound.  Safe to call repeatedly and before Start.
+func (d *Detector) SetIdle(on bool) {
+	d.mu.Lock()
+	changed := d.idle != on
+	d.idle = on
+	d.mu.Unlock()
+	if changed {
+		d.log.Debug("wakeword: idle=%v", on)
+	}
+}
+
+func (d *Detector) isIdle() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.idle && d.cfg.IdleLevel > 0
+}
+
 func (d *Detector) isPaused() bool {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -127,10 +292,51 @@ func (d *Detector) checkReset() bool {
 	return false
 }
 
+// ErrRunning is returned by Start when the detector is already running.
+var ErrRunning = errors.New("wakeword: detector already running")
+
+// capture is the microphone, delivering audio to the callback it was
+// opened with between Start and Stop (see capture.go).
+type capture interface {
+	Start() error
+	Stop() error
+	Close()
+}
+
+// ErrNoAudio is returned by Start in a build without audio capture
+// (-tags noaudio).
+var ErrNoAudio = errors.New("wakeword: built without audio (-tags noaudio)")
+
 // Start initialises the ONNX models and the audio capture device,
-// then processes audio in a blocking loop until ctx is cancelled.
-// Run this in its own goroutine.
+// then processes audio in a blocking loop until ctx is cancelled or
+// Close is called.  Run this in its own goroutine.  Everything it opens
+// is released by the time it returns, so it can be started again.
 func (d *Detector) Start(ctx context.Context) error {
+	d.mu.Lock()
+	if d.stop != nil {
+		d.mu.Unlock()
+		return ErrRunning
+	}
+	ctx, stop := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	d.stop, d.stopped = stop, stopped
+	select {
+	case <-d.ready: // closed by an earlier run
+		d.ready = make(chan struct{})
+	default:
+	}
+	ready := d.ready
+	d.mu.Unlock()
+	defer func() {
+		stop()
+		d.mu.Lock()
+		if d.stopped == stopped {
+			d.stop, d.stopped = nil, nil
+		}
+		d.mu.Unlock()
+		close(stopped)
+	}()
+
 	// ── ONNX Runtime ────────────────────────────────────────────
 	d.log.Debug("wakeword: initializing ONNX runtime (lib=%s)", d.cfg.OnnxLib)
 	ort.SetSharedLibraryPath(d.cfg.OnnxLib)
@@ -141,330 +347,190 @@ func (d *Detector) Start(ctx context.Context) error {
 	defer ort.DestroyEnvironment()
 	d.log.Debug("wakeword: ONNX runtime initialized")
 
-	// ── Melspectrogram model ────────────────────────────────────
-	melspecIn, err := ort.NewEmptyTensor[float32](ort.NewShape(1, chunkSamples))
-	if err != nil {
-		return err
-	}
-	defer melspecIn.Destroy()
-
-	melspecOut, err := ort.NewEmptyTensor[float32](ort.NewShape(1, 1, nMelFrames, melBins))
-	if err != nil {
-		return err
-	}
-	defer melspecOut.Destroy()
-
-	msInInfo, msOutInfo, err := ort.GetInputOutputInfo(d.cfg.MelspecModel)
-	if err != nil {
-		return err
-	}
-	melspecSess, err := ort.NewAdvancedSession(
-		d.cfg.MelspecModel,
-		[]string{msInInfo[0].Name}, []string{msOutInfo[0].Name},
-		[]ort.Value{melspecIn}, []ort.Value{melspecOut},
-		nil,
-	)
-	if err != nil {
-		return err
-	}
-	defer melspecSess.Destroy()
-
-	// ── Embedding model ─────────────────────────────────────────
-	embedIn, err := ort.NewEmptyTensor[float32](ort.NewShape(1, melWindowSize, melBins, 1))
-	if err != nil {
-		return err
-	}
-	defer embedIn.Destroy()
-
-	embedOut, err := ort.NewEmptyTensor[float32](ort.NewShape(1, 1, 1, embeddingDim))
-	if err != nil {
-		return err
-	}
-	defer embedOut.Destroy()
-
-	emInInfo, emOutInfo, err := ort.GetInputOutputInfo(d.cfg.EmbeddingModel)
-	if err != nil {
-		return err
-	}
-	embedSess, err := ort.NewAdvancedSession(
-		d.cfg.EmbeddingModel,
-		[]string{emInInfo[0].Name}, []string{emOutInfo[0].Name},
-		[]ort.Value{embedIn}, []ort.Value{embedOut},
-		nil,
-	)
-	if err != nil {
-		return err
-	}
-	defer embedSess.Destroy()
-
-	// ── Wakeword model ──────────────────────────────────────────
-	wwIn, err := ort.NewEmptyTensor[float32](ort.NewShape(1, nEmbedFrames, embeddingDim))
-	if err != nil {
-		return err
-	}
-	defer wwIn.Destroy()
-
-	wwOut, err := ort.NewEmptyTensor[float32](ort.NewShape(1, 1))
+	p, err := newPipeline(d.cfg, d.log)
 	if err != nil {
 		return err
 	}
-	defer wwOut.Destroy()
-
-	wwInInfo, wwOutInfo, err := ort.GetInputOutputInfo(d.cfg.WakewordModel)
-	if err != nil {
-		return err
-	}
-	wwSess, err := ort.NewAdvancedSession(
-		d.cfg.WakewordModel,
-		[]string{wwInInfo[0].Name}, []string{wwOutInfo[0].Name},
-		[]ort.Value{wwIn}, []ort.Value{wwOut},
-		nil,
-	)
-	if err != nil {
-		return err
-	}
-	defer wwSess.Destroy()
+	defer p.Close()
+	d.log.Info("wakeword: models warmed up in %s", p.warmUp().Round(time.Millisecond))
 
 	// ── Audio capture via miniaudio ─────────────────────────────
-	mCtx, err := malgo.InitContext(nil, malgo.ContextConfig{}, func(_ string) {})
-	if err != nil {
-		return err
-	}
-	defer func() { _ = mCtx.Uninit(); mCtx.Free() }()
-
-	devCfg := malgo.DefaultDeviceConfig(malgo.Capture)
-	devCfg.SampleRate = sampleRate
-	devCfg.Capture.Format = malgo.FormatS16
-	devCfg.Capture.Channels = 1
-	devCfg.Alsa.NoMMap = 1
-
+	// Frames go back to free once processed, so the device callback
+	// isn't allocating twelve times a second.
 	audioCh := make(chan []int16, audioQueueCap)
+	free := make(chan []int16, audioQueueCap)
 	var audioDrops atomic.Int64
 
-	callbacks := malgo.DeviceCallbacks{
-		Data: func(_ []byte, raw []byte, _ uint32) {
-			if len(raw) == 0 {
-				return
-			}
-			n := len(raw) / 2
-			pcm := make([]int16, n)
-			for i := 0; i < n; i++ {
-				pcm[i] = int16(binary.LittleEndian.Uint16(raw[i*2 : i*2+2]))
-			}
-			select {
-			case audioCh <- pcm:
-			default:
-				audioDrops.Add(1)
-			}
-		},
-	}
+	droppedTotal := d.cfg.Metrics.Counter("ottocook_wakeword_dropped_frames_total",
+		"Audio frames dropped because the processing queue was full.")
+	idleTotal := d.cfg.Metrics.Counter("ottocook_wakeword_idle_frames_total",
+		"Audio frames not scored because the detector was idle in a quiet room.")
+	gate := newIdleGate(d.cfg.IdleLevel)
 
-	device, err := malgo.InitDevice(mCtx.Context, devCfg, callbacks)
+	device, err := openCapture(func(raw []byte) {
+		if len(raw) == 0 {
+			return
+		}
+		n := len(raw) / 2
+		var pcm []int16
+		select {
+		case pcm = <-free:
+		default:
+		}
+		if cap(pcm) < n {
+			pcm = make([]int16, n)
+		}
+		pcm = pcm[:n]
+		for i := 0; i < n; i++ {
+			pcm[i] = int16(binary.LittleEndian.Uint16(raw[i*2 : i*2+2]))
+		}
+		select {
+		case audioCh <- pcm:
+		default:
+			audioDrops.Add(1)
+			droppedTotal.Inc()
+		}
+	})
 	if err != nil {
 		return err
 	}
-	defer device.Uninit()
+	defer device.Close()
 
-	if err := device.Start(); err != nil {
-		d.log.Error("wakeword: audio device start failed: %v", err)
-		return err
+	capturing := false
+	if d.Capturing() {
+		if err := device.Start(); err != nil {
+			d.log.Error("wakeword: audio device start failed: %v", err)
+			return err
+		}
+		capturing = true
+		d.log.Debug("wakeword: audio capture started (rate=%d, chunk=%d)", sampleRate, chunkSamples)
 	}
-	defer device.Stop()
-	d.log.Debug("wakeword: audio capture started (rate=%d, chunk=%d)", sampleRate, chunkSamples)
+	defer func() {
+		if capturing {
+			device.Stop()
+		}
+	}()
+	close(ready)
 
 	chunksProcessed := 0
 
-	// ── Processing state ────────────────────────────────────────
-	melBuffer := make([]float32, 0, 300*melBins)
-	embedBuffer := make([]float32, nEmbedFrames*embeddingDim)
-	audioRem := make([]int16, 0, chunkSamples*2)
-	lastDetect := time.Time{}
-
-	// Trailing score window — trigger on max within the window.
-	scoreWindow := make([]float32, scoreWindowSize)
-	scoreIdx := 0
-
 	// Diagnostic counters.
 	var (
-		peakScore     float32
-		totalEmbeds   int
 		lastStatsDump = time.Now()
 		statInterval  = 2 * time.Second
 	)
 
-	// (no extra state needed — zero-padded scoring handles silence automatically)
-
-	// ── Main loop ───────────────────────────────────────────────
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-
-		case frame := <-audioCh:
-			if d.isPaused() {
-				continue
-			}
-
-			// After a Pause/Resume cycle, flush all pipeline state so
-			// stale mel frames and embeddings don't pollute scoring.
-			if d.checkReset() {
-				melBuffer = melBuffer[:0]
-				for i := range embedBuffer {
-					embedBuffer[i] = 0
-				}
-				audioRem = audioRem[:0]
-				for i := range scoreWindow {
-					scoreWindow[i] = 0
-				}
-				scoreIdx = 0
-				peakScore = 0
-				totalEmbeds = 0
-				d.log.Debug("wakeword: pipeline buffers reset after resume")
-			}
-
-			chunksProcessed++
-
-			// ── Periodic state dump (every 5s) ──────────────────
-			if now := time.Now(); now.Sub(lastStatsDump) >= statInterval {
-				drops := audioDrops.Load()
-				d.log.Debug("wakeword: [STATS] chunks=%d embeds=%d drops=%d melBuf=%d/%d(cap) audioRem=%d/%d(cap) peakScore=%.4f paused=%v",
-					chunksProcessed, totalEmbeds, drops,
-					len(melBuffer)/melBins, cap(melBuffer)/melBins,
-					len(audioRem), cap(audioRem),
-					peakScore, d.isPaused())
-				peakScore = 0
-				lastStatsDump = now
-			}
-
-			audioRem = append(audioRem, frame...)
+	onScore := func(ev scoreEvent) {
+		// Log score when it's interesting (above 10% of threshold)
+		// or at low frequency for ambient baseline.
+		if threshold := p.tracker.threshold; float64(ev.WindowMax) >= threshold*0.1 {
+			d.log.Debug("wakeword: score=%.6f max=%.6f (threshold=%.2f)", ev.Score, ev.WindowMax, threshold)
+		}
+		if !ev.Detected {
+			return
+		}
+		d.log.Info("wakeword: DETECTED (score=%.4f, windowMax=%.4f, language=%q)", ev.Score, ev.WindowMax, ev.Language)
+		d.detectPos.Store(d.history.pos())
+		d.detectLang.Store(ev.Language)
+		if d.OnDetected != nil {
+			d.OnDetected()
+		}
+	}
 
-			for len(audioRem) >= chunkSamples {
-				chunk := audioRem[:chunkSamples]
-				// Compact: copy remaining to front of slice to release old backing memory.
-				n := copy(audioRem, audioRem[chunkSamples:])
-				audioRem = audioRem[:n]
+	// process scores one frame from the microphone.
+	process := func(frame []int16) {
+		d.history.write(frame)
+		if d.isPaused() {
+			return
+		}
 
-				// ── Step 1: melspectrogram ───────────────────────
-				inData := melspecIn.GetData()
-				var sumSq float64
-				for i, v := range chunk {
-					inData[i] = float32(v)
-					sumSq += float64(v) * float64(v)
-				}
-				rms := math.Sqrt(sumSq / float64(len(chunk)))
-				_ = rms // kept for diagnostics
+		// After a Pause/Resume cycle, flush all pipeline state so
+		// stale mel frames and embeddings don't pollute scoring.
+		if d.checkReset() {
+			p.reset()
+			d.log.Debug("wakeword: pipeline buffers reset after resume")
+		}
 
-				if err := melspecSess.Run(); err != nil {
-					d.log.Error("wakeword: melspec run failed: %v", err)
-					continue
-				}
+		score, reopened := true, gate.open()
+		if d.isIdle() {
+			score, reopened = gate.admit(frame)
+		}
+		if !score {
+			idleTotal.Inc()
+			return
+		}
+		if reopened {
+			// Score the lead-up too: the embedding model needs
+			// over a second of context before the first score.
+			p.reset()
+			frame = d.history.since(d.history.pos() - int64(idlePreroll*sampleRate/time.Second))
+			d.log.Debug("wakeword: sound in a quiet room, scoring again")
+		}
 
-				melData := melspecOut.GetData()
-				for f := 0; f < nMelFrames; f++ {
-					for b := 0; b < melBins; b++ {
-						idx := f*melBins + b
-						if idx < len(melData) {
-							melBuffer = append(melBuffer, melData[idx]/10.0+2.0)
-						}
-					}
-				}
+		chunksProcessed++
+		p.setThreshold(d.Threshold())
+
+		// ── Periodic state dump (every 5s) ──────────────────
+		if now := time.Now(); now.Sub(lastStatsDump) >= statInterval {
+			drops := audioDrops.Load()
+			d.log.Debug("wakeword: [STATS] chunks=%d embeds=%d drops=%d melBuf=%d/%d(cap) audioRem=%d/%d(cap) peakScore=%.4f paused=%v",
+				chunksProcessed, p.totalEmbeds, drops,
+				len(p.melBuffer)/melBins, cap(p.melBuffer)/melBins,
+				len(p.audioRem), cap(p.audioRem),
+				p.peakScore, d.isPaused())
+			p.peakScore = 0
+			lastStatsDump = now
+		}
 
-				// ── Step 2: embedding ───────────────────────────
-				totalMel := len(melBuffer) / melBins
-				newEmbed := false
-
-				for totalMel >= melWindowSize {
-					eData := embedIn.GetData()
-					for i := 0; i < melWindowSize*melBins; i++ {
-						eData[i] = melBuffer[i]
-					}
-					if err := embedSess.Run(); err != nil {
-						d.log.Error("wakeword: embed run failed: %v", err)
-						break
-					}
-					eOut := embedOut.GetData()
-
-					// Normal sliding window: shift left, insert at end.
-					copy(embedBuffer, embedBuffer[embeddingDim:])
-					copy(embedBuffer[(nEmbedFrames-1)*embeddingDim:], eOut[:embeddingDim])
-					newEmbed = true
-
-					// Compact melBuffer: copy remaining to front to prevent
-					// unbounded backing-array growth from reslicing.
-					n := copy(melBuffer, melBuffer[melStepSize*melBins:])
-					melBuffer = melBuffer[:n]
-					totalMel = len(melBuffer) / melBins
-				}
+		p.feed(frame, onScore)
+	}
 
-				// Trim excess mel history (compact, not reslice).
-				if totalMel > melWindowSize {
-					excess := (totalMel - melWindowSize) * melBins
-					n := copy(melBuffer, melBuffer[excess:])
-					melBuffer = melBuffer[:n]
-				}
+	// ── Main loop ───────────────────────────────────────────────
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
 
-				if !newEmbed {
+		case <-d.captureCh:
+			switch want := d.Capturing(); {
+			case want && !capturing:
+				if err := device.Start(); err != nil {
+					d.log.Error("wakeword: audio device restart failed: %v", err)
 					continue
 				}
-
-				totalEmbeds++
-
-				// ── Step 3: wakeword scoring ────────────────────
-				// Feed the model a zero-padded buffer: only the last
-				// `recentWindow` embedding slots are real; the rest are
-				// zeros.  This permanently mimics the fresh-launch state
-				// where the model scores 0.8+ and prevents silence
-				// embeddings from ever suppressing detection.
-				//
-				// this will be our dirty little secret :)
-				wwData := wwIn.GetData()
-				padSlots := nEmbedFrames - recentWindow
-				for i := 0; i < padSlots*embeddingDim; i++ {
-					wwData[i] = 0
-				}
-				copy(wwData[padSlots*embeddingDim:], embedBuffer[padSlots*embeddingDim:])
-				if err := wwSess.Run(); err != nil {
-					d.log.Error("wakeword: ww run failed: %v", err)
+				capturing = true
+				d.log.Info("wakeword: microphone on")
+			case !want && capturing:
+				if err := device.Stop(); err != nil {
+					d.log.Error("wakeword: audio device stop failed: %v", err)
 					continue
 				}
+				capturing = false
+				d.log.Info("wakeword: microphone off")
+			}
 
-				score := wwOut.GetData()[0]
-				now := time.Now()
-
-				if score > peakScore {
-					peakScore = score
-				}
-
-				// Insert into trailing score window.
-				scoreWindow[scoreIdx%scoreWindowSize] = score
-				scoreIdx++
-
-				// Compute the max score in the window.
-				var maxScore float32
-				for _, s := range scoreWindow {
-					if s > maxScore {
-						maxScore = s
-					}
-				}
-
-				// Log score when it's interesting (above 10% of threshold)
-				// or at low frequency for ambient baseline.
-				if float64(maxScore) >= d.cfg.Threshold*0.1 {
-					d.log.Debug("wakeword: score=%.6f max=%.6f (threshold=%.2f)", score, maxScore, d.cfg.Threshold)
-				}
-
-				if float64(maxScore) >= d.cfg.Threshold && now.Sub(lastDetect) > d.cfg.Cooldown {
-					d.log.Info("wakeword: DETECTED (score=%.4f, windowMax=%.4f)", score, maxScore)
-					lastDetect = now
-					// Clear window so we don't re-trigger on the same peak.
-					for i := range scoreWindow {
-						scoreWindow[i] = 0
-					}
-					if d.OnDetected != nil {
-						d.OnDetected()
-					}
-				}
+		case frame := <-audioCh:
+			if capturing { // else queued before the device stopped
+				process(frame)
+			}
+			select {
+			case free <- frame:
+			default:
 			}
 		}
 	}
 }
+
+// Close stops Start and waits for it to release the microphone and the
+// models.  Closing a detector that isn't running does nothing.
+func (d *Detector) Close() {
+	d.mu.Lock()
+	stop, stopped := d.stop, d.stopped
+	d.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	stop()
+	<-stopped
+	d.log.Info("wakeword: closed")
+}
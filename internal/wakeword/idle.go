@@ -0,0 +1,77 @@
+package wakeword
+
+import (
+	"math"
+	"time"
+)
+
+// ── Idle gate ────────────────────────────────────────────────────
+//
+// At the recipe list with nothing cooking, the detector would still run
+// all three models twelve times a second over a silent kitchen.  Set
+// idle, it stops scoring once the room has been quiet a few seconds and
+// starts again at the first sound, replaying the last couple of seconds
+// from the history buffer so the start of "Hey Chef" isn't lost.
+
+const (
+	// DefaultIdleLevel is the RMS (−46 dBFS) below which an idle
+	// detector counts the room as quiet; a notch under the Ear's own
+	// silence level.
+	DefaultIdleLevel = 0.005
+
+	idleHangover = 3 * time.Second // quiet this long before scoring stops
+	idlePreroll  = 2 * time.Second // replayed when it starts again
+)
+
+// idleGate decides, frame by frame, whether an idle detector should
+// score.  Time is kept in samples, so the gate follows the audio and
+// not the wall clock.
+type idleGate struct {
+	level     float64
+	hangover  int64
+	clock     int64 // samples seen
+	lastSound int64 // clock at the last frame above level
+	closed    bool
+}
+
+func newIdleGate(level float64) *idleGate {
+	return &idleGate{level: level, hangover: int64(idleHangover * sampleRate / time.Second)}
+}
+
+// admit reports whether frame should be scored, and whether scoring is
+// starting again after the gate was closed.
+func (g *idleGate) admit(frame []int16) (score, reopened bool) {
+	g.clock += int64(len(frame))
+	if rms(frame) >= g.level {
+		g.lastSound = g.clock
+	}
+	if g.clock-g.lastSound > g.hangover {
+		g.closed = true
+		return false, false
+	}
+	reopened = g.closed
+	g.closed = false
+	return true, reopened
+}
+
+// open opens the gate, as when the detector stops being idle.  Reports
+// whether it was closed.
+func (g *idleGate) open() bool {
+	g.lastSound = g.clock
+	was := g.closed
+	g.closed = false
+	return was
+}
+
+// rms returns the root mean square of pcm, 0 to 1.
+func rms(pcm []int16) float64 {
+	if len(pcm) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range pcm {
+		v := float64(s) / math.MaxInt16
+		sum += v * v
+	}
+	return math.Sqrt(sum / float64(len(pcm)))
+}
@@ -0,0 +1,34 @@
+package wakeword
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdleGate(t *testing.T) {
+	g := newIdleGate(DefaultIdleLevel)
+	quiet := make([]int16, chunkSamples)
+	loud := make([]int16, chunkSamples)
+	for i := range loud {
+		loud[i] = 3000
+	}
+	chunks := int(idleHangover / (chunkSamples * time.Second / sampleRate)) // 80 ms each
+
+	for i := 0; i < chunks; i++ {
+		if score, _ := g.admit(quiet); !score {
+			t.Fatalf("gate closed after %d quiet chunks, before the hangover", i+1)
+		}
+	}
+	if score, _ := g.admit(quiet); score {
+		t.Fatal("gate still open after the hangover")
+	}
+	if score, reopened := g.admit(loud); !score || !reopened {
+		t.Fatalf("admit(loud) = %v, %v; want the gate reopened", score, reopened)
+	}
+	if _, reopened := g.admit(loud); reopened {
+		t.Error("reopened reported twice")
+	}
+	if g.open() {
+		t.Error("open() on an open gate reports it was closed")
+	}
+}
@@ -0,0 +1,484 @@
+package wakeword
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	"github.com/hammamikhairi/ottocook/internal/metrics"
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// destroyer is satisfied by every ONNX tensor and session.
+type destroyer interface {
+	Destroy() error
+}
+
+// pipeline owns the three ONNX sessions (melspectrogram → embedding →
+// wakeword) plus their rolling buffers, and turns raw 16 kHz PCM into
+// wakeword scores.  The live Detector and the offline Replay harness
+// both drive the same pipeline so benchmarks measure the real code path.
+//
+// Not safe for concurrent use.
+type pipeline struct {
+	log *logger.Logger
+
+	melspecIn, melspecOut *ort.Tensor[float32]
+	embedIn, embedOut     *ort.Tensor[float32]
+	wwIn, wwOut           *ort.Tensor[float32]
+	verifyIn, verifyOut   *ort.Tensor[float32] // nil without a verifier
+	melspecSess           *ort.AdvancedSession
+	embedSess             *ort.AdvancedSession
+	wwSess                *ort.AdvancedSession
+	verifySess            *ort.AdvancedSession
+	resources             []destroyer // destroyed in reverse order by Close
+	others                []*otherWakeword
+
+	recentWindow    int
+	verifyThreshold float64
+
+	melBuffer   []float32
+	embedBuffer []float32
+	audioRem    []int16
+	tracker     *scoreTracker
+	samplesFed  int64 // total samples consumed, used as the audio clock
+
+	// Diagnostics, reset by the caller as it sees fit.
+	peakScore   float32
+	totalEmbeds int
+
+	scoreHist       *metrics.Histogram
+	detectionsTotal *metrics.Counter
+	rejectionsTotal *metrics.Counter
+}
+
+// otherWakeword is one of Config.Wakewords, scored on the same
+// embeddings as the main model with a tracker of its own.
+type otherWakeword struct {
+	language string
+	in, out  *ort.Tensor[float32]
+	sess     *ort.AdvancedSession
+	tracker  *scoreTracker
+}
+
+// scoreEvent is emitted for every new wakeword score.
+type scoreEvent struct {
+	Score     float32
+	WindowMax float32
+	Offset    time.Duration // position in the audio stream
+	Detected  bool
+	Language  string // set when one of Config.Wakewords scored
+}
+
+// newPipeline builds the ONNX sessions for cfg.  The ONNX Runtime
+// environment must already be initialised.  Call Close when done.
+func newPipeline(cfg Config, log *logger.Logger) (*pipeline, error) {
+	p := &pipeline{
+		log:             log,
+		recentWindow:    cfg.RecentWindow,
+		verifyThreshold: cfg.VerifyThreshold,
+		melBuffer:       make([]float32, 0, 300*melBins),
+		embedBuffer:     make([]float32, nEmbedFrames*embeddingDim),
+		audioRem:        make([]int16, 0, chunkSamples*2),
+		tracker:         newScoreTracker(cfg.ScoreWindow, cfg.Threshold, cfg.Cooldown),
+		scoreHist: cfg.Metrics.Histogram("ottocook_wakeword_score",
+			"Distribution of per-embedding wakeword scores.", metrics.ScoreBuckets),
+		detectionsTotal: cfg.Metrics.Counter("ottocook_wakeword_detections_total",
+			"Wakeword detections above threshold."),
+		rejectionsTotal: cfg.Metrics.Counter("ottocook_wakeword_rejections_total",
+			"First-stage wakeword hits rejected by the verification model."),
+	}
+
+	opts := sessionOptions(cfg.Accelerator, log)
+	if opts != nil {
+		p.resources = append(p.resources, opts)
+	}
+
+	var err error
+	build := func(model string, inShape, outShape ort.Shape) (in, out *ort.Tensor[float32], sess *ort.AdvancedSession) {
+		if err != nil {
+			return nil, nil, nil
+		}
+		if in, err = ort.NewEmptyTensor[float32](inShape); err != nil {
+			return nil, nil, nil
+		}
+		p.resources = append(p.resources, in)
+		if out, err = ort.NewEmptyTensor[float32](outShape); err != nil {
+			return nil, nil, nil
+		}
+		p.resources = append(p.resources, out)
+		inInfo, outInfo, infoErr := ort.GetInputOutputInfo(model)
+		if infoErr != nil {
+			err = infoErr
+			return nil, nil, nil
+		}
+		sess, err = ort.NewAdvancedSession(
+			model,
+			[]string{inInfo[0].Name}, []string{outInfo[0].Name},
+			[]ort.Value{in}, []ort.Value{out},
+			opts,
+		)
+		if err != nil {
+			return nil, nil, nil
+		}
+		p.resources = append(p.resources, sess)
+		return in, out, sess
+	}
+
+	p.melspecIn, p.melspecOut, p.melspecSess = build(cfg.MelspecModel,
+		ort.NewShape(1, chunkSamples), ort.NewShape(1, 1, nMelFrames, melBins))
+	p.embedIn, p.embedOut, p.embedSess = build(cfg.EmbeddingModel,
+		ort.NewShape(1, melWindowSize, melBins, 1), ort.NewShape(1, 1, 1, embeddingDim))
+	p.wwIn, p.wwOut, p.wwSess = build(cfg.WakewordModel,
+		ort.NewShape(1, nEmbedFrames, embeddingDim), ort.NewShape(1, 1))
+	if cfg.VerifyModel != "" {
+		p.verifyIn, p.verifyOut, p.verifySess = build(cfg.VerifyModel,
+			ort.NewShape(1, nEmbedFrames, embeddingDim), ort.NewShape(1, 1))
+		p.tracker.verify = p.verify
+	}
+	for _, w := range cfg.Wakewords {
+		o := &otherWakeword{language: w.Language, tracker: newScoreTracker(cfg.ScoreWindow, cfg.Threshold, cfg.Cooldown)}
+		o.in, o.out, o.sess = build(w.Model, ort.NewShape(1, nEmbedFrames, embeddingDim), ort.NewShape(1, 1))
+		p.others = append(p.others, o)
+	}
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// Accelerators accepted by Config.Accelerator.
+var Accelerators = []string{"cpu", "coreml", "cuda", "directml"}
+
+// sessionOptions builds ONNX session options for the requested execution
+// provider.  It returns nil (plain CPU) for "cpu" or when the provider
+// can't be enabled — the models are small enough that CPU is always a
+// workable fallback.
+func sessionOptions(accel string, log *logger.Logger) *ort.SessionOptions {
+	if accel == "" || accel == "cpu" {
+		return nil
+	}
+	opts, err := ort.NewSessionOptions()
+	if err != nil {
+		log.Warn("wakeword: session options unavailable, using CPU: %v", err)
+		return nil
+	}
+
+	switch accel {
+	case "coreml":
+		err = opts.AppendExecutionProviderCoreMLV2(nil)
+	case "cuda":
+		var cuda *ort.CUDAProviderOptions
+		if cuda, err = ort.NewCUDAProviderOptions(); err == nil {
+			err = opts.AppendExecutionProviderCUDA(cuda)
+			cuda.Destroy()
+		}
+	case "directml":
+		err = opts.AppendExecutionProviderDirectML(0)
+	default:
+		err = fmt.Errorf("unknown accelerator %q (want one of %v)", accel, Accelerators)
+	}
+	if err != nil {
+		log.Warn("wakeword: %s acceleration unavailable, using CPU: %v", accel, err)
+		opts.Destroy()
+		return nil
+	}
+	log.Info("wakeword: using %s execution provider", accel)
+	return opts
+}
+
+// Close releases every ONNX tensor and session.
+func (p *pipeline) Close() {
+	for i := len(p.resources) - 1; i >= 0; i-- {
+		_ = p.resources[i].Destroy()
+	}
+	p.resources = nil
+}
+
+// warmUpRuns is how many times warmUp runs each model.  The first run
+// is the slow one; a couple more settle the allocator.
+const warmUpRuns = 3
+
+// warmUp runs every model a few times on silence, then resets.  ONNX
+// Runtime does a lot lazily on a session's first runs (allocating its
+// arenas, choosing kernels, compiling for an accelerator), and done on
+// live audio that stalls the loop long enough to drop frames — often
+// the first "Hey Chef" after launch.  Returns how long it took.
+func (p *pipeline) warmUp() time.Duration {
+	start := time.Now()
+	inputs := []*ort.Tensor[float32]{p.melspecIn, p.embedIn, p.wwIn, p.verifyIn}
+	sessions := []*ort.AdvancedSession{p.melspecSess, p.embedSess, p.wwSess, p.verifySess}
+	for _, o := range p.others {
+		inputs, sessions = append(inputs, o.in), append(sessions, o.sess)
+	}
+	for _, in := range inputs {
+		if in != nil {
+			clear(in.GetData())
+		}
+	}
+	for range warmUpRuns {
+		for _, s := range sessions {
+			if s == nil {
+				continue // no verifier
+			}
+			if err := s.Run(); err != nil {
+				p.log.Warn("wakeword: warm-up run failed: %v", err)
+				p.reset()
+				return time.Since(start)
+			}
+		}
+	}
+	p.reset()
+	return time.Since(start)
+}
+
+// reset flushes all buffered audio, mel frames, embeddings, and scores so
+// stale state doesn't pollute scoring after a pause.
+func (p *pipeline) reset() {
+	p.melBuffer = p.melBuffer[:0]
+	for i := range p.embedBuffer {
+		p.embedBuffer[i] = 0
+	}
+	p.audioRem = p.audioRem[:0]
+	p.tracker.reset()
+	for _, o := range p.others {
+		o.tracker.reset()
+	}
+	p.peakScore = 0
+	p.totalEmbeds = 0
+}
+
+// feed appends PCM samples and runs every complete 80 ms chunk through
+// the three models.  onScore is invoked once per new wakeword score.
+func (p *pipeline) feed(frame []int16, onScore func(scoreEvent)) {
+	p.audioRem = append(p.audioRem, frame...)
+
+	for len(p.audioRem) >= chunkSamples {
+		inData := p.melspecIn.GetData()
+		for i, v := range p.audioRem[:chunkSamples] {
+			inData[i] = float32(v)
+		}
+		// Compact: copy remaining to front of slice to release old backing memory.
+		n := copy(p.audioRem, p.audioRem[chunkSamples:])
+		p.audioRem = p.audioRem[:n]
+		p.samplesFed += chunkSamples
+
+		// ── Step 1: melspectrogram ───────────────────────────
+		if err := p.melspecSess.Run(); err != nil {
+			p.log.Error("wakeword: melspec run failed: %v", err)
+			continue
+		}
+
+		melData := p.melspecOut.GetData()
+		for f := 0; f < nMelFrames; f++ {
+			for b := 0; b < melBins; b++ {
+				idx := f*melBins + b
+				if idx < len(melData) {
+					p.melBuffer = append(p.melBuffer, melData[idx]/10.0+2.0)
+				}
+			}
+		}
+
+		// ── Step 2: embedding ───────────────────────────────
+		totalMel := len(p.melBuffer) / melBins
+		newEmbed := false
+
+		for totalMel >= melWindowSize {
+			copy(p.embedIn.GetData(), p.melBuffer[:melWindowSize*melBins])
+			if err := p.embedSess.Run(); err != nil {
+				p.log.Error("wakeword: embed run failed: %v", err)
+				break
+			}
+			eOut := p.embedOut.GetData()
+
+			// Normal sliding window: shift left, insert at end.
+			copy(p.embedBuffer, p.embedBuffer[embeddingDim:])
+			copy(p.embedBuffer[(nEmbedFrames-1)*embeddingDim:], eOut[:embeddingDim])
+			newEmbed = true
+
+			// Compact melBuffer: copy remaining to front to prevent
+			// unbounded backing-array growth from reslicing.
+			n := copy(p.melBuffer, p.melBuffer[melStepSize*melBins:])
+			p.melBuffer = p.melBuffer[:n]
+			totalMel = len(p.melBuffer) / melBins
+		}
+
+		// Trim excess mel history (compact, not reslice).
+		if totalMel > melWindowSize {
+			excess := (totalMel - melWindowSize) * melBins
+			n := copy(p.melBuffer, p.melBuffer[excess:])
+			p.melBuffer = p.melBuffer[:n]
+		}
+
+		if !newEmbed {
+			continue
+		}
+
+		p.totalEmbeds++
+
+		// ── Step 3: wakeword scoring ────────────────────────
+		// Feed the model a zero-padded buffer: only the last
+		// `recentWindow` embedding slots are real; the rest are
+		// zeros.  This permanently mimics the fresh-launch state
+		// where the model scores 0.8+ and prevents silence
+		// embeddings from ever suppressing detection.
+		//
+		// this will be our dirty little secret :)
+		wwData := p.wwIn.GetData()
+		padSlots := nEmbedFrames - p.recentWindow
+		for i := 0; i < padSlots*embeddingDim; i++ {
+			wwData[i] = 0
+		}
+		copy(wwData[padSlots*embeddingDim:], p.embedBuffer[padSlots*embeddingDim:])
+		if err := p.wwSess.Run(); err != nil {
+			p.log.Error("wakeword: ww run failed: %v", err)
+			continue
+		}
+
+		score := p.wwOut.GetData()[0]
+		if score > p.peakScore {
+			p.peakScore = score
+		}
+		p.scoreHist.Observe(float64(score))
+
+		offset := time.Duration(p.samplesFed) * time.Second / sampleRate
+		maxScore, detected := p.tracker.push(score, offset)
+		if detected {
+			p.detectionsTotal.Inc()
+		}
+		if onScore != nil {
+			onScore(scoreEvent{Score: score, WindowMax: maxScore, Offset: offset, Detected: detected})
+		}
+
+		// The other wake words see the same padded embeddings.
+		for _, o := range p.others {
+			copy(o.in.GetData(), wwData)
+			if err := o.sess.Run(); err != nil {
+				p.log.Error("wakeword: %s ww run failed: %v", o.language, err)
+				continue
+			}
+			score := o.out.GetData()[0]
+			maxScore, detected := o.tracker.push(score, offset)
+			if detected {
+				p.detectionsTotal.Inc()
+			}
+			if onScore != nil {
+				onScore(scoreEvent{Score: score, WindowMax: maxScore, Offset: offset, Detected: detected, Language: o.language})
+			}
+		}
+	}
+}
+
+// setThreshold changes the detection threshold of every wake word.
+func (p *pipeline) setThreshold(t float64) {
+	p.tracker.threshold = t
+	for _, o := range p.others {
+		o.tracker.threshold = t
+	}
+}
+
+// verify re-scores the current embedding history without zero-padding
+// and reports whether the candidate detection should stand.
+func (p *pipeline) verify() bool {
+	copy(p.verifyIn.GetData(), p.embedBuffer)
+	if err := p.verifySess.Run(); err != nil {
+		// Fail open: a broken verifier shouldn't make the wake word deaf.
+		p.log.Error("wakeword: verify run failed: %v", err)
+		return true
+	}
+	score := p.verifyOut.GetData()[0]
+	if float64(score) < p.verifyThreshold {
+		p.log.Debug("wakeword: candidate rejected by verifier (score=%.4f, threshold=%.2f)", score, p.verifyThreshold)
+		p.rejectionsTotal.Inc()
+		return false
+	}
+	p.log.Debug("wakeword: candidate confirmed by verifier (score=%.4f)", score)
+	return true
+}
+
+// ── Score tracking ───────────────────────────────────────────────
+
+// scoreTracker keeps a trailing window of scores and decides when the
+// window maximum constitutes a detection.  It is pure Go (no ONNX) so
+// the replay harness can re-evaluate recorded scores at different
+// thresholds without re-running the models.
+type scoreTracker struct {
+	window    []float32
+	offsets   []time.Duration // stream offset of each score in window
+	idx       int
+	threshold float64
+	cooldown  time.Duration
+	last      time.Duration
+	fired     bool
+
+	// verify, when set, must confirm a candidate before it counts.  A
+	// rejected candidate neither starts the cooldown nor clears the
+	// window, so a genuine wake word a frame later can still fire; but
+	// the peak it rejected isn't put to it again while that peak is
+	// still the window's maximum.
+	verify     func() bool
+	rejected   time.Duration // offset of the last peak verify rejected
+	isRejected bool
+}
+
+func newScoreTracker(windowSize int, threshold float64, cooldown time.Duration) *scoreTracker {
+	if windowSize <= 0 {
+		windowSize = 1
+	}
+	return &scoreTracker{
+		window:    make([]float32, windowSize),
+		offsets:   make([]time.Duration, windowSize),
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// push records a score at the given stream offset and returns the
+// current window maximum plus whether this push triggered a detection.
+func (t *scoreTracker) push(score float32, at time.Duration) (float32, bool) {
+	t.window[t.idx%len(t.window)] = score
+	t.offsets[t.idx%len(t.offsets)] = at
+	t.idx++
+
+	var maxScore float32
+	var maxAt time.Duration
+	for i, s := range t.window {
+		if s > maxScore {
+			maxScore, maxAt = s, t.offsets[i]
+		}
+	}
+
+	if float64(maxScore) < t.threshold {
+		return maxScore, false
+	}
+	if t.fired && at-t.last <= t.cooldown {
+		return maxScore, false
+	}
+	if t.verify != nil {
+		if t.isRejected && maxAt == t.rejected {
+			return maxScore, false // already turned down
+		}
+		if !t.verify() {
+			t.rejected, t.isRejected = maxAt, true
+			return maxScore, false
+		}
+	}
+
+	t.fired = true
+	t.last = at
+	t.isRejected = false
+	// Clear window so we don't re-trigger on the same peak.
+	for i := range t.window {
+		t.window[i] = 0
+	}
+	return maxScore, true
+}
+
+func (t *scoreTracker) reset() {
+	for i := range t.window {
+		t.window[i] = 0
+	}
+	t.idx = 0
+	t.isRejected = false
+}
@@ -0,0 +1,92 @@
+package wakeword
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+	ort "github.com/yalue/onnxruntime_go"
+)
+
+// ── Offline replay ───────────────────────────────────────────────
+//
+// Replay runs recorded audio through the same pipeline the live
+// Detector uses, so thresholds and window sizes can be tuned against
+// fixtures instead of a live microphone.
+
+// ReplayResult is the outcome of feeding one recording through the
+// pipeline.
+type ReplayResult struct {
+	Scores     []ScorePoint    // every wakeword score, in stream order
+	Detections []time.Duration // stream offsets where the detector fired
+	Chunks     int             // 80 ms chunks processed
+	Elapsed    time.Duration   // wall-clock time spent in the pipeline
+}
+
+// ScorePoint is a single wakeword score at a position in the stream.
+type ScorePoint struct {
+	Offset time.Duration
+	Score  float32
+}
+
+// PerChunk returns the average wall-clock cost of one 80 ms chunk.
+func (r *ReplayResult) PerChunk() time.Duration {
+	if r.Chunks == 0 {
+		return 0
+	}
+	return r.Elapsed / time.Duration(r.Chunks)
+}
+
+// Evaluate re-runs detection over the recorded scores with different
+// tuning, without touching the ONNX models.  Only the score window,
+// threshold, and cooldown can be varied this way; changing the recent
+// window alters the scores themselves and needs a fresh Replay.  The
+// second-stage verifier is not re-applied, so results reflect the first
+// stage alone.
+func (r *ReplayResult) Evaluate(threshold float64, window int, cooldown time.Duration) []time.Duration {
+	t := newScoreTracker(window, threshold, cooldown)
+	var hits []time.Duration
+	for _, s := range r.Scores {
+		if _, detected := t.push(s.Score, s.Offset); detected {
+			hits = append(hits, s.Offset)
+		}
+	}
+	return hits
+}
+
+// Replay feeds pcm (16 kHz mono) through the wakeword pipeline
+// described by cfg.  The ONNX Runtime environment is initialised on
+// first use and left running so repeated replays stay cheap.
+func Replay(cfg Config, pcm []int16, log *logger.Logger) (*ReplayResult, error) {
+	cfg.defaults()
+	if !ort.IsInitialized() {
+		ort.SetSharedLibraryPath(cfg.OnnxLib)
+		if err := ort.InitializeEnvironment(); err != nil {
+			return nil, fmt.Errorf("initializing onnx runtime: %w", err)
+		}
+	}
+
+	p, err := newPipeline(cfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("building pipeline: %w", err)
+	}
+	defer p.Close()
+	p.warmUp() // so Elapsed measures steady-state scoring
+
+	res := &ReplayResult{}
+	onScore := func(ev scoreEvent) {
+		res.Scores = append(res.Scores, ScorePoint{Offset: ev.Offset, Score: ev.Score})
+		if ev.Detected {
+			res.Detections = append(res.Detections, ev.Offset)
+		}
+	}
+
+	start := time.Now()
+	for off := 0; off < len(pcm); off += chunkSamples {
+		end := min(off+chunkSamples, len(pcm))
+		p.feed(pcm[off:end], onScore)
+	}
+	res.Elapsed = time.Since(start)
+	res.Chunks = len(pcm) / chunkSamples
+	return res, nil
+}
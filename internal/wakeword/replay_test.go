@@ -0,0 +1,296 @@
+package wakeword
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hammamikhairi/ottocook/internal/logger"
+)
+
+// Benchmarks and accuracy reports need real models and recordings, which
+// are not checked in.  Point these at a local openWakeWord setup, e.g.
+//
+//	OTTO_WW_DIR=$PWD go test ./internal/wakeword -bench . -run Accuracy -v
+//
+// OTTO_WW_DIR is expected to contain models/hey_otto.onnx and
+// bin/{melspectrogram,embedding_model}.onnx plus the ONNX Runtime
+// library; fixtures live in testdata/positive and testdata/negative.
+const envWakewordDir = "OTTO_WW_DIR"
+
+func fixtureConfig(tb testing.TB) Config {
+	tb.Helper()
+	dir := os.Getenv(envWakewordDir)
+	if dir == "" {
+		tb.Skipf("%s not set; skipping model-backed test", envWakewordDir)
+	}
+	lib := filepath.Join(dir, "bin", "libonnxruntime.so")
+	if _, err := os.Stat(lib); err != nil {
+		lib = filepath.Join(dir, "bin", "libonnxruntime.dylib")
+	}
+	return Config{
+		WakewordModel:  filepath.Join(dir, "models", "hey_otto.onnx"),
+		MelspecModel:   filepath.Join(dir, "bin", "melspectrogram.onnx"),
+		EmbeddingModel: filepath.Join(dir, "bin", "embedding_model.onnx"),
+		OnnxLib:        lib,
+	}
+}
+
+func fixtures(tb testing.TB, kind string) []string {
+	tb.Helper()
+	paths, _ := filepath.Glob(filepath.Join("testdata", kind, "*.wav"))
+	if len(paths) == 0 {
+		tb.Skipf("no testdata/%s/*.wav fixtures", kind)
+	}
+	return paths
+}
+
+// writeWAV writes a minimal 16-bit mono WAV with an extra chunk before
+// "data" to exercise chunk skipping.
+func writeWAV(t *testing.T, rate uint32, channels uint16, pcm []int16) string {
+	t.Helper()
+	data := make([]byte, len(pcm)*2)
+	for i, v := range pcm {
+		binary.LittleEndian.PutUint16(data[i*2:], uint16(v))
+	}
+
+	var b []byte
+	u32 := func(v uint32) { b = binary.LittleEndian.AppendUint32(b, v) }
+	u16 := func(v uint16) { b = binary.LittleEndian.AppendUint16(b, v) }
+
+	b = append(b, "RIFF"...)
+	u32(uint32(4 + 8 + 16 + 8 + 3 + 1 + 8 + len(data)))
+	b = append(b, "WAVE"...)
+	b = append(b, "fmt "...)
+	u32(16)
+	u16(1)
+	u16(channels)
+	u32(rate)
+	u32(rate * uint32(channels) * 2)
+	u16(channels * 2)
+	u16(16)
+	b = append(b, "LIST"...)
+	u32(3)
+	b = append(b, 'a', 'b', 'c', 0) // odd size + pad byte
+	b = append(b, "data"...)
+	u32(uint32(len(data)))
+	b = append(b, data...)
+
+	path := filepath.Join(t.TempDir(), "clip.wav")
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadWAV(t *testing.T) {
+	want := []int16{0, 1, -1, 32767, -32768}
+	got, err := ReadWAV(writeWAV(t, sampleRate, 1, want))
+	if err != nil {
+		t.Fatalf("ReadWAV: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d samples, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sample %d: expected %d, got %d", i, want[i], got[i])
+		}
+	}
+}
+
+func TestWriteWAVRoundTrip(t *testing.T) {
+	want := []int16{100, -200, 300}
+	path := filepath.Join(t.TempDir(), "out.wav")
+	if err := WriteWAV(path, want); err != nil {
+		t.Fatalf("WriteWAV: %v", err)
+	}
+	got, err := ReadWAV(path)
+	if err != nil {
+		t.Fatalf("ReadWAV: %v", err)
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[2] != want[2] {
+		t.Errorf("round trip: expected %v, got %v", want, got)
+	}
+}
+
+func TestReadWAVRejectsWrongFormat(t *testing.T) {
+	if _, err := ReadWAV(writeWAV(t, 44100, 1, []int16{0})); err == nil {
+		t.Error("expected error for 44.1 kHz input")
+	}
+	if _, err := ReadWAV(writeWAV(t, sampleRate, 2, []int16{0, 0})); err == nil {
+		t.Error("expected error for stereo input")
+	}
+}
+
+func TestScoreTrackerWindowAndCooldown(t *testing.T) {
+	tr := newScoreTracker(3, 0.5, time.Second)
+	step := 80 * time.Millisecond
+
+	scores := []float32{0.1, 0.6, 0.2, 0.1, 0.9, 0.1}
+	var fired []int
+	for i, s := range scores {
+		if _, hit := tr.push(s, time.Duration(i)*step); hit {
+			fired = append(fired, i)
+		}
+	}
+	// The 0.9 at index 4 falls inside the cooldown of the index-1 hit.
+	if len(fired) != 1 || fired[0] != 1 {
+		t.Fatalf("expected a single detection at index 1, got %v", fired)
+	}
+
+	if _, hit := tr.push(0.9, 2*time.Second); !hit {
+		t.Error("expected detection once cooldown elapsed")
+	}
+}
+
+func TestScoreTrackerWindowMax(t *testing.T) {
+	tr := newScoreTracker(2, 1.0, 0)
+	tr.push(0.4, 0)
+	if m, _ := tr.push(0.2, time.Millisecond); m != 0.4 {
+		t.Errorf("expected window max 0.4, got %v", m)
+	}
+	if m, _ := tr.push(0.1, 2*time.Millisecond); m != 0.2 {
+		t.Errorf("expected 0.4 to age out, got max %v", m)
+	}
+}
+
+func TestScoreTrackerVerifyRejection(t *testing.T) {
+	tr := newScoreTracker(3, 0.5, time.Second)
+	confirm := false
+	calls := 0
+	tr.verify = func() bool { calls++; return confirm }
+
+	if _, hit := tr.push(0.8, 0); hit {
+		t.Fatal("expected rejected candidate not to fire")
+	}
+	// The rejected peak isn't verified again while it tops the window.
+	tr.push(0.1, 80*time.Millisecond)
+	tr.push(0.6, 160*time.Millisecond)
+	if calls != 1 {
+		t.Fatalf("verifier ran %d times over one peak, want once", calls)
+	}
+
+	// A rejection must not start the cooldown: a new peak can fire
+	// straight away.
+	confirm = true
+	if _, hit := tr.push(0.9, 240*time.Millisecond); !hit {
+		t.Error("expected a new, confirmed peak to fire")
+	}
+	if calls != 2 {
+		t.Errorf("expected verifier to run twice, ran %d times", calls)
+	}
+}
+
+func TestScoreTrackerVerifyAfterPeakLeaves(t *testing.T) {
+	tr := newScoreTracker(2, 0.5, time.Second)
+	calls := 0
+	tr.verify = func() bool { calls++; return calls > 1 }
+
+	tr.push(0.8, 0) // rejected
+	// 0.8 still tops the window.
+	if _, hit := tr.push(0.6, 80*time.Millisecond); hit {
+		t.Fatal("fired on the rejected peak")
+	}
+	// Once 0.8 ages out, the 0.6 behind it gets its own hearing.
+	if _, hit := tr.push(0.2, 160*time.Millisecond); !hit {
+		t.Error("expected the next peak to be verified and fire")
+	}
+	if calls != 2 {
+		t.Errorf("verifier ran %d times, want 2", calls)
+	}
+}
+
+func TestReplayResultEvaluate(t *testing.T) {
+	res := &ReplayResult{}
+	for i, s := range []float32{0.2, 0.45, 0.2, 0.7, 0.1} {
+		res.Scores = append(res.Scores, ScorePoint{Offset: time.Duration(i) * time.Second, Score: s})
+	}
+	if hits := res.Evaluate(0.4, 1, 0); len(hits) != 2 {
+		t.Errorf("threshold 0.4: expected 2 hits, got %v", hits)
+	}
+	if hits := res.Evaluate(0.6, 1, 0); len(hits) != 1 || hits[0] != 3*time.Second {
+		t.Errorf("threshold 0.6: expected hit at 3s, got %v", hits)
+	}
+	if hits := res.Evaluate(0.4, 1, 5*time.Second); len(hits) != 1 {
+		t.Errorf("long cooldown: expected 1 hit, got %v", hits)
+	}
+}
+
+// TestReplayAccuracy reports detection rates on the fixture set across
+// a sweep of thresholds.  It only logs; tune from the -v output.
+func TestReplayAccuracy(t *testing.T) {
+	cfg := fixtureConfig(t)
+	log := logger.New(logger.LevelOff, nil)
+
+	type run struct {
+		positive bool
+		res      *ReplayResult
+	}
+	var runs []run
+	for _, kind := range []string{"positive", "negative"} {
+		paths, _ := filepath.Glob(filepath.Join("testdata", kind, "*.wav"))
+		for _, p := range paths {
+			pcm, err := ReadWAV(p)
+			if err != nil {
+				t.Fatalf("%s: %v", p, err)
+			}
+			res, err := Replay(cfg, pcm, log)
+			if err != nil {
+				t.Fatalf("%s: %v", p, err)
+			}
+			runs = append(runs, run{positive: kind == "positive", res: res})
+		}
+	}
+	if len(runs) == 0 {
+		t.Skip("no testdata fixtures")
+	}
+
+	for _, th := range []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6} {
+		for _, win := range []int{1, 3, DefaultScoreWindow, 8} {
+			var tp, fn, fp int
+			for _, r := range runs {
+				hits := len(r.res.Evaluate(th, win, 1500*time.Millisecond))
+				switch {
+				case r.positive && hits > 0:
+					tp++
+				case r.positive:
+					fn++
+				default:
+					fp += hits
+				}
+			}
+			t.Logf("threshold=%.1f window=%d  detected=%d missed=%d false=%d", th, win, tp, fn, fp)
+		}
+	}
+}
+
+func benchmarkReplay(b *testing.B, recentWindow int) {
+	cfg := fixtureConfig(b)
+	cfg.RecentWindow = recentWindow
+	pcm, err := ReadWAV(fixtures(b, "positive")[0])
+	if err != nil {
+		b.Fatal(err)
+	}
+	log := logger.New(logger.LevelOff, nil)
+
+	b.ResetTimer()
+	var chunks int
+	var elapsed time.Duration
+	for i := 0; i < b.N; i++ {
+		res, err := Replay(cfg, pcm, log)
+		if err != nil {
+			b.Fatal(err)
+		}
+		chunks += res.Chunks
+		elapsed += res.Elapsed
+	}
+	if chunks > 0 {
+		b.ReportMetric(float64(elapsed.Microseconds())/float64(chunks), "µs/chunk")
+	}
+}
+
+func BenchmarkReplayRecent5(b *testing.B)  { benchmarkReplay(b, DefaultRecentWindow) }
+func BenchmarkReplayRecent16(b *testing.B) { benchmarkReplay(b, nEmbedFrames) }
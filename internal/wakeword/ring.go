@@ -0,0 +1,72 @@
+package wakeword
+
+import "sync"
+
+// pcmRing is a fixed-size rolling buffer of the most recent microphone
+// samples.  Positions are absolute sample counts since the ring was
+// created, so a reader can hold on to a position and later ask for
+// everything captured after it.
+//
+// Safe for concurrent use: the detector writes from its processing
+// loop while the Ear reads from its own goroutine.
+type pcmRing struct {
+	mu    sync.Mutex
+	buf   []int16
+	total int64 // samples ever written
+}
+
+func newPCMRing(capacity int) *pcmRing {
+	return &pcmRing{buf: make([]int16, capacity)}
+}
+
+// write appends samples, overwriting the oldest once full.
+func (r *pcmRing) write(samples []int16) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	size := len(r.buf)
+	if size == 0 {
+		return
+	}
+	// Only the tail can survive a write larger than the ring.
+	if len(samples) > size {
+		r.total += int64(len(samples) - size)
+		samples = samples[len(samples)-size:]
+	}
+	start := int(r.total % int64(size))
+	n := copy(r.buf[start:], samples)
+	copy(r.buf, samples[n:])
+	r.total += int64(len(samples))
+}
+
+// pos returns the current write position.
+func (r *pcmRing) pos() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.total
+}
+
+// since returns a copy of every sample written after position from.
+// Audio older than the ring's capacity is gone; the result is clipped
+// to what is still held.
+func (r *pcmRing) since(from int64) []int16 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	size := int64(len(r.buf))
+	if oldest := r.total - size; from < oldest {
+		from = oldest
+	}
+	if from < 0 {
+		from = 0
+	}
+	if from >= r.total {
+		return nil
+	}
+
+	out := make([]int16, r.total-from)
+	start := int(from % size)
+	n := copy(out, r.buf[start:])
+	copy(out[n:], r.buf)
+	return out
+}
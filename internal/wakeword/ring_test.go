@@ -0,0 +1,53 @@
+package wakeword
+
+import (
+	"slices"
+	"testing"
+)
+
+func seq(from, to int) []int16 {
+	var out []int16
+	for i := from; i < to; i++ {
+		out = append(out, int16(i))
+	}
+	return out
+}
+
+func TestPCMRingSince(t *testing.T) {
+	r := newPCMRing(8)
+	r.write(seq(0, 5))
+	mark := r.pos()
+	r.write(seq(5, 7))
+
+	if got := r.since(mark); !slices.Equal(got, seq(5, 7)) {
+		t.Errorf("since(mark): expected %v, got %v", seq(5, 7), got)
+	}
+	if got := r.since(r.pos()); got != nil {
+		t.Errorf("since(pos): expected nil, got %v", got)
+	}
+}
+
+func TestPCMRingWrapsAndClips(t *testing.T) {
+	r := newPCMRing(8)
+	r.write(seq(0, 6))
+	r.write(seq(6, 13)) // wraps; 0..4 are overwritten
+
+	if got := r.since(0); !slices.Equal(got, seq(5, 13)) {
+		t.Errorf("expected oldest data clipped to %v, got %v", seq(5, 13), got)
+	}
+	if got := r.since(10); !slices.Equal(got, seq(10, 13)) {
+		t.Errorf("since(10): expected %v, got %v", seq(10, 13), got)
+	}
+}
+
+func TestPCMRingOversizedWrite(t *testing.T) {
+	r := newPCMRing(4)
+	r.write(seq(0, 10))
+
+	if r.pos() != 10 {
+		t.Errorf("expected position 10, got %d", r.pos())
+	}
+	if got := r.since(0); !slices.Equal(got, seq(6, 10)) {
+		t.Errorf("expected tail %v, got %v", seq(6, 10), got)
+	}
+}
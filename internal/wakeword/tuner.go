@@ -0,0 +1,79 @@
+package wakeword
+
+import "math"
+
+// ── Threshold tuning ─────────────────────────────────────────────
+//
+// The right threshold depends on the kitchen: the extractor fan, the
+// radio, how far the laptop is from the hob.  A Tuner moves it a step
+// at a time from how wakes turn out.  Several wakes in a row that
+// captured nothing (or were "I wasn't talking to you") mean it's too
+// eager; a couple of "you didn't hear me"s mean it's too deaf.  It
+// never strays more than tunerRange from where it started.
+
+const (
+	tunerStep   = 0.05
+	tunerRange  = 0.2 // either side of the starting threshold
+	tunerEmpty  = 3   // empty wakes in a row before raising
+	tunerMissed = 2   // missed wakes before lowering
+)
+
+// Tuner adjusts a wakeword threshold from wake outcomes.  Not safe for
+// concurrent use.
+type Tuner struct {
+	threshold float64
+	min, max  float64
+	empty     int // empty wakes since the last command or change
+	missed    int // missed wakes since the last change
+}
+
+// NewTuner starts tuning from threshold.
+func NewTuner(threshold float64) *Tuner {
+	return &Tuner{
+		threshold: threshold,
+		min:       math.Max(tunerStep, threshold-tunerRange),
+		max:       math.Min(1-tunerStep, threshold+tunerRange),
+	}
+}
+
+// Threshold returns the current threshold.
+func (t *Tuner) Threshold() float64 {
+	return t.threshold
+}
+
+// Heard records a wake that captured a command.
+func (t *Tuner) Heard() {
+	t.empty = 0
+}
+
+// Empty records a wake that captured nothing, or a false wake, and
+// reports whether the threshold went up.
+func (t *Tuner) Empty() bool {
+	t.empty++
+	if t.empty < tunerEmpty {
+		return false
+	}
+	return t.move(tunerStep)
+}
+
+// Missed records a wake word that wasn't heard and reports whether the
+// threshold came down.
+func (t *Tuner) Missed() bool {
+	t.missed++
+	if t.missed < tunerMissed {
+		return false
+	}
+	return t.move(-tunerStep)
+}
+
+// move shifts the threshold by delta within bounds and starts both
+// counts afresh.  Reports whether it moved.
+func (t *Tuner) move(delta float64) bool {
+	t.empty, t.missed = 0, 0
+	next := math.Round(math.Max(t.min, math.Min(t.max, t.threshold+delta))*100) / 100
+	if next == t.threshold {
+		return false
+	}
+	t.threshold = next
+	return true
+}
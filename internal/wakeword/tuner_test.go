@@ -0,0 +1,35 @@
+package wakeword
+
+import "testing"
+
+func TestTuner(t *testing.T) {
+	tu := NewTuner(0.5)
+
+	// A command in between starts the empty count again.
+	tu.Empty()
+	tu.Empty()
+	tu.Heard()
+	if tu.Empty() || tu.Empty() {
+		t.Fatal("raised before three empty wakes in a row")
+	}
+	if !tu.Empty() || tu.Threshold() != 0.55 {
+		t.Fatalf("after three empty wakes threshold = %.2f, want 0.55", tu.Threshold())
+	}
+
+	if tu.Missed() {
+		t.Fatal("lowered after one missed wake")
+	}
+	if !tu.Missed() || tu.Threshold() != 0.5 {
+		t.Fatalf("after two missed wakes threshold = %.2f, want 0.50", tu.Threshold())
+	}
+
+	for range 20 {
+		tu.Missed()
+	}
+	if tu.Threshold() != 0.3 {
+		t.Errorf("threshold = %.2f, want it held at 0.30", tu.Threshold())
+	}
+	if tu.Missed() || tu.Missed() {
+		t.Error("reported a change at the bound")
+	}
+}
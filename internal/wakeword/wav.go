@@ -0,0 +1,104 @@
+package wakeword
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ReadWAV loads a 16-bit PCM, mono, 16 kHz WAV file — the format the
+// pipeline expects.  Anything else is rejected rather than resampled so
+// fixtures stay faithful to what the microphone delivers.
+func ReadWAV(path string) ([]int16, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var riff [12]byte
+	if _, err := io.ReadFull(f, riff[:]); err != nil {
+		return nil, fmt.Errorf("reading wav header: %w", err)
+	}
+	if string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return nil, errors.New("not a RIFF/WAVE file")
+	}
+
+	var (
+		haveFmt bool
+		chunk   [8]byte
+	)
+	for {
+		if _, err := io.ReadFull(f, chunk[:]); err != nil {
+			return nil, fmt.Errorf("reading wav chunk: %w", err)
+		}
+		id := string(chunk[0:4])
+		size := binary.LittleEndian.Uint32(chunk[4:8])
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, fmt.Errorf("fmt chunk too short (%d bytes)", size)
+			}
+			buf := make([]byte, size+size%2)
+			if _, err := io.ReadFull(f, buf); err != nil {
+				return nil, fmt.Errorf("reading fmt chunk: %w", err)
+			}
+			format := binary.LittleEndian.Uint16(buf[0:2])
+			channels := binary.LittleEndian.Uint16(buf[2:4])
+			rate := binary.LittleEndian.Uint32(buf[4:8])
+			bits := binary.LittleEndian.Uint16(buf[14:16])
+			if format != 1 || channels != 1 || rate != sampleRate || bits != 16 {
+				return nil, fmt.Errorf("unsupported wav format (format=%d channels=%d rate=%d bits=%d); want PCM mono %d Hz 16-bit",
+					format, channels, rate, bits, sampleRate)
+			}
+			haveFmt = true
+
+		case "data":
+			if !haveFmt {
+				return nil, errors.New("data chunk before fmt chunk")
+			}
+			buf := make([]byte, size)
+			n, err := io.ReadFull(f, buf)
+			if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("reading data chunk: %w", err)
+			}
+			pcm := make([]int16, n/2)
+			for i := range pcm {
+				pcm[i] = int16(binary.LittleEndian.Uint16(buf[i*2:]))
+			}
+			return pcm, nil
+
+		default:
+			if _, err := f.Seek(int64(size+size%2), io.SeekCurrent); err != nil {
+				return nil, fmt.Errorf("skipping %q chunk: %w", id, err)
+			}
+		}
+	}
+}
+
+// WriteWAV saves pcm as a 16-bit PCM, mono, 16 kHz WAV file — the
+// counterpart of ReadWAV, and the format whisper-cli accepts directly.
+func WriteWAV(path string, pcm []int16) error {
+	const headerSize = 44
+	buf := make([]byte, headerSize+len(pcm)*2)
+	copy(buf[0:], "RIFF")
+	binary.LittleEndian.PutUint32(buf[4:], uint32(36+len(pcm)*2))
+	copy(buf[8:], "WAVE")
+	copy(buf[12:], "fmt ")
+	binary.LittleEndian.PutUint32(buf[16:], 16)
+	binary.LittleEndian.PutUint16(buf[20:], 1) // PCM
+	binary.LittleEndian.PutUint16(buf[22:], 1) // mono
+	binary.LittleEndian.PutUint32(buf[24:], sampleRate)
+	binary.LittleEndian.PutUint32(buf[28:], sampleRate*2)
+	binary.LittleEndian.PutUint16(buf[32:], 2)
+	binary.LittleEndian.PutUint16(buf[34:], 16)
+	copy(buf[36:], "data")
+	binary.LittleEndian.PutUint32(buf[40:], uint32(len(pcm)*2))
+	for i, v := range pcm {
+		binary.LittleEndian.PutUint16(buf[headerSize+i*2:], uint16(v))
+	}
+	return os.WriteFile(path, buf, 0o644)
+}